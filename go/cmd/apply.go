@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var (
+	applyDryRun bool
+	applyYes    bool
+)
+
+// applyCmd represents the apply command
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Bring VMs to the state declared in the config file",
+	Long: `Bring VMs to the state declared in the config file.
+
+VMs with desired-state (running or stopped), machine-type or schedule-policy
+in the config file are compared with their current state, and the starts,
+stops, machine type and schedule policy changes that close the difference are
+shown as a plan. After confirmation the plan is applied; VMs without these keys
+are never touched.
+
+A running VM whose machine type changes is stopped and started again, unless
+its desired state is stopped.
+
+Example config:
+  vm:
+    - name: sandbox
+      desired-state: running
+      machine-type: e2-standard-4
+      schedule-policy: weekday-stop
+
+Example:
+  gcectl apply --dry-run
+  gcectl apply --yes`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		infraLog.DefaultLogger.Debug("Apply desired state")
+
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vms := session.Config.ManagedVMs()
+		if len(vms) == 0 {
+			console.Error("No VM in the config file has desired-state, machine-type or schedule-policy")
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+
+		if err = session.OpenVMRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		applyUseCase := usecase.NewApplyDesiredStateUseCase(session.VMRepository, infraLog.DefaultLogger).
+			WithMaxConcurrency(session.Config.MaxConcurrency)
+		actions, err := applyUseCase.Plan(ctx, vms, session.Config.DesiredStates)
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to plan: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		console.RenderApplyPlan(actions)
+		if applyDryRun || len(actions) == 0 {
+			return
+		}
+
+		if !applyYes {
+			answer, promptErr := console.Prompt("Apply these changes? [y/N]:")
+			if promptErr != nil {
+				console.ErrorWithHint(fmt.Sprintf("Failed to read confirmation: %v", promptErr), promptErr)
+				session.Close()
+				os.Exit(cli.ExitCode(promptErr))
+			}
+			if !strings.EqualFold(answer, "y") && !strings.EqualFold(answer, "yes") {
+				console.Success("Canceled; no changes were applied")
+				return
+			}
+		}
+
+		vmNames := actionVMNames(actions)
+		err = console.ExecuteWithProgress(
+			ctx,
+			fmt.Sprintf("Applying %d changes to %s", len(actions), strings.Join(vmNames, ", ")),
+			func(ctx context.Context) error {
+				return applyUseCase.Apply(ctx, actions)
+			},
+		)
+		if err != nil {
+			session.Notify(operationEvents(model.EventOperationFailure, vmNames, fmt.Sprintf("failed to apply: %v", err))...)
+			console.ErrorWithHint(withCapabilityHint(fmt.Sprintf("Failed to apply: %v", err), err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		session.Notify(operationEvents(model.EventOperationSuccess, vmNames, "applied desired state")...)
+		console.Success(fmt.Sprintf("Applied %d changes to %s", len(actions), strings.Join(vmNames, ", ")))
+	},
+}
+
+// actionVMNames returns the distinct names of the VMs actions change, in order.
+func actionVMNames(actions []model.ApplyAction) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, a := range actions {
+		if !seen[a.VM.Name] {
+			seen[a.VM.Name] = true
+			names = append(names, a.VM.Name)
+		}
+	}
+	return names
+}
+
+func init() {
+	rootCmd.AddCommand(applyCmd)
+	applyCmd.Flags().BoolVar(&applyDryRun, "dry-run", false, "Show the plan without applying it")
+	applyCmd.Flags().BoolVarP(&applyYes, "yes", "y", false, "Apply without asking for confirmation")
+}
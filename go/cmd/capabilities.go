@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// capabilitiesCmd represents the capabilities command
+var capabilitiesCmd = &cobra.Command{
+	Use:   "capabilities <vm_name>",
+	Short: "List which gcectl operations are valid for the instance",
+	Long: `List which gcectl operations are valid for the instance in its current state,
+with the reason for any that are not.
+
+Example:
+  gcectl capabilities <vm_name>`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		vmName := args[0]
+		infraLog.DefaultLogger.Debugf("List capabilities of instance %s", vmName)
+
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(vmName)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		err = session.OpenVMRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		listCapabilitiesUseCase := usecase.NewListCapabilitiesUseCase(session.VMRepository)
+		caps, err := listCapabilitiesUseCase.Execute(ctx, vm)
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to get capabilities: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		console.RenderCapabilities(vmName, caps)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(capabilitiesCmd)
+}
+
+// withCapabilityHint appends a pointer to "gcectl capabilities" when err reports an unsupported operation.
+func withCapabilityHint(msg string, err error) string {
+	var capErr *model.CapabilityError
+	if !errors.As(err, &capErr) {
+		return msg
+	}
+	return fmt.Sprintf("%s (see: gcectl capabilities %s)", msg, capErr.VMName)
+}
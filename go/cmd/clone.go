@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/haru-256/gcectl/internal/infrastructure/config"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var cloneZone string
+
+// cloneCmd represents the clone command
+var cloneCmd = &cobra.Command{
+	Use:   "clone <vm_name> <new_vm_name>",
+	Short: "Copy a VM through a machine image",
+	Long: `Copy a VM through a machine image.
+
+A machine image of the VM is created (named after the VM and the current UTC time)
+and a new VM is created from it in the same project, in the VM's zone or in --zone.
+On success the new VM is added to the config file, unless it is a shared config.
+
+The machine image is kept so more copies can be made from it; delete it with
+gcloud compute machine-images delete when it is no longer needed.
+
+Example:
+  gcectl clone sandbox sandbox-2
+  gcectl clone sandbox sandbox-tokyo --zone asia-northeast1-a`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		vmName, newName := args[0], args[1]
+		infraLog.DefaultLogger.Debugf("Clone instance %s to %s", vmName, newName)
+
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(vmName)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		// 作成後に設定ファイルへ登録できない名前は先に弾く
+		if _, err = session.Config.ResolveVM(newName); err == nil {
+			console.Error(fmt.Sprintf("VM %s is already in the config file", newName))
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+
+		if err = session.OpenVMRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if err = session.OpenMachineImageRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		cloneUseCase := usecase.NewCloneVMUseCase(session.VMRepository, session.MachineImageRepository, infraLog.DefaultLogger).
+			WithPhaseRunner(console.ExecuteWithProgress)
+		result, err := cloneUseCase.Execute(ctx, vm.Project, vm.Zone, vm.Name, newName, cloneZone)
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to clone VM: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		cloned := fmt.Sprintf("Cloned %s to %s (%s), machine image %s is kept", vm.Name, newName, result.VM.Zone, result.MachineImage)
+		if remoteConfigSource != nil {
+			// The local copy of a shared config is replaced on the next refresh.
+			console.Success(fmt.Sprintf("%s; add %s to the shared config %s at its source", cloned, newName, remoteConfigSource.Raw))
+			return
+		}
+		if err = config.RegisterVM(CnfPath, result.VM); err != nil {
+			console.ErrorWithHint(fmt.Sprintf("VM %s was created but could not be added to the config file: %v", newName, err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		console.Success(cloned)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cloneCmd)
+	cloneCmd.Flags().StringVar(&cloneZone, "zone", "", "Zone of the new VM (default: the zone of the source VM)")
+}
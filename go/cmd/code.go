@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/editor"
+	"github.com/haru-256/gcectl/internal/infrastructure/sshconfig"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// codeInternal writes the internal IP of the VM to the ssh config entry
+var codeInternal bool
+
+// codeCmd represents the code command
+var codeCmd = &cobra.Command{
+	Use:   "code <vm_name> [remote_path]",
+	Short: "Open the instance in VS Code with Remote-SSH",
+	Long: `Start the instance unless it is running, write its Host entry to the gcectl
+block of ~/.ssh/config with its current IP, as ssh-config generate --write does
+for one VM, and open it in VS Code with the Remote-SSH extension.
+
+The folder opened is remote_path, or else the workspace of the VM in
+config.yaml; without either VS Code opens an empty remote window.
+
+config.yaml:
+  vm:
+    - name: my-vm
+      workspace: /home/alice/project
+
+Example:
+  gcectl code my-vm
+  gcectl code my-vm /srv/app
+  gcectl code my-vm --internal`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(args[0])
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		folder := session.Config.WorkspaceFor(vm.Name)
+		if len(args) == 2 {
+			folder = args[1]
+		}
+
+		if err = session.OpenVMRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if err = ensureRunning(ctx, console, session, vm); err != nil {
+			console.ErrorWithHint(withCapabilityHint(fmt.Sprintf("Failed to start %s: %v", vm.Name, err), err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		ip, err := usecase.NewGetVMIPUseCase(session.VMRepository).Execute(ctx, vm.Project, vm.Zone, vm.Name, codeInternal)
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to get the IP of %s: %v", vm.Name, err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		sshConfigPath, err := sshconfig.DefaultPath()
+		if err != nil {
+			console.Error(err.Error())
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+		host := model.SSHHost{Alias: vm.Name, HostName: ip, SSHOptions: session.Config.SSHOptionsFor(vm.Name)}
+		if err = sshconfig.UpsertFile(sshConfigPath, host); err != nil {
+			console.Error(err.Error())
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+
+		if err = editor.OpenRemoteSSH(ctx, vm.Name, folder); err != nil {
+			console.Error(err.Error())
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+		console.Success(fmt.Sprintf("Opened %s in VS Code", vm.Name))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(codeCmd)
+	codeCmd.Flags().BoolVar(&codeInternal, "internal", false, "Connect to the internal IP, e.g. over a VPN")
+}
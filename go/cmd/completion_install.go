@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/haru-256/gcectl/internal/infrastructure/completion"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+// completionInstallCmd represents the completion install command
+var completionInstallCmd = &cobra.Command{
+	Use:   "install [bash|zsh|fish]",
+	Short: "Install the autocompletion script for your shell",
+	Long: `Install the autocompletion script for bash, zsh or fish, detected from $SHELL
+unless named, and load it from the shell's startup file.
+
+  bash: the script goes to ~/.local/share/bash-completion/completions/gcectl
+        and is sourced from ~/.bashrc
+  zsh:  the script goes to ~/.local/share/gcectl/zsh/_gcectl, which ~/.zshrc
+        adds to fpath before running compinit
+  fish: the script goes to ~/.config/fish/completions/gcectl.fish, which fish
+        loads by itself
+
+XDG_DATA_HOME, XDG_CONFIG_HOME and ZDOTDIR move these locations as usual.
+Running it again refreshes the script, e.g. after upgrading gcectl, without
+adding the lines to the startup file twice. Start a new shell to use it.
+
+Example:
+  gcectl completion install
+  gcectl completion install zsh`,
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: completion.Shells,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		var shell string
+		if len(args) == 1 {
+			shell = args[0]
+		} else {
+			detected, err := completion.DetectShell()
+			if err != nil {
+				console.Error(err.Error())
+				os.Exit(cli.ExitFailure)
+			}
+			shell = detected
+		}
+
+		target, err := completion.TargetFor(shell)
+		if err != nil {
+			console.Error(err.Error())
+			os.Exit(cli.ExitFailure)
+		}
+		script, err := completionScript(cmd.Root(), shell)
+		if err != nil {
+			console.Error(fmt.Sprintf("failed to generate the %s completion: %v", shell, err))
+			os.Exit(cli.ExitFailure)
+		}
+		result, err := completion.Install(target, script)
+		if err != nil {
+			console.Error(err.Error())
+			os.Exit(cli.ExitFailure)
+		}
+
+		msg := fmt.Sprintf("Installed the %s completion in %s", shell, target.ScriptPath)
+		if result.RCUpdated {
+			msg += fmt.Sprintf(", loaded from %s", target.RCPath)
+		}
+		console.Success(msg + "; start a new shell to use it")
+	},
+}
+
+// completionScript generates the completion script of root for shell, the same
+// one `gcectl completion <shell>` prints.
+func completionScript(root *cobra.Command, shell string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	switch shell {
+	case "bash":
+		err = root.GenBashCompletionV2(&buf, true)
+	case "zsh":
+		err = root.GenZshCompletion(&buf)
+	case "fish":
+		err = root.GenFishCompletion(&buf, true)
+	default:
+		err = fmt.Errorf("unsupported shell %q", shell)
+	}
+	return buf.Bytes(), err
+}
+
+func init() {
+	// Create cobra's completion command now instead of at Execute, to add install to it.
+	rootCmd.InitDefaultCompletionCmd()
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == "completion" {
+			c.AddCommand(completionInstallCmd)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/infrastructure/config"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+// configMigrateDryRun only prints the changes config migrate would make
+var configMigrateDryRun bool
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config <command>",
+	Short: "Validate, migrate or refresh the config file",
+	Long: `Validate, migrate or refresh the config file.
+
+config.yaml starts with the version of its format, e.g. version: 1. gcectl
+still reads configs of older versions, and of none, which is version 0, but
+config migrate rewrites them in the latest format.
+
+--config also takes a config shared by a team, which is fetched once and then
+read from a local copy in ~/.cache/gcectl/remote-config:
+
+  gs://<bucket>/<object>                        a Cloud Storage object
+  https://<host>/<path>                         a file served over HTTPS
+  <repository>.git//<file>[?ref=<branch>]       a file in a git repository
+
+Example:
+  gcectl config validate
+  gcectl config migrate --dry-run
+  gcectl list --config gs://team-bucket/gcectl.yaml
+  gcectl context use team --config https://github.com/team/infra.git//gcectl.yaml?ref=main
+  gcectl config refresh`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := cmd.Help(); err != nil {
+			presenter.NewConsolePresenter().Error("Failed to run help command")
+			os.Exit(cli.ExitFailure)
+		}
+	},
+}
+
+// configValidateCmd represents the config validate command
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the config file against the latest schema",
+	Long: `Check the config file against the schema of the latest version: report keys
+gcectl does not know, which it would otherwise ignore, with the key likely
+meant, values of the wrong type, settings gcectl rejects and an outdated
+version. Exits with 1 when there is a problem.
+
+Example:
+  gcectl config validate
+  gcectl config validate --config ./team.yaml`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		problems, err := config.Validate(CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		if len(problems) > 0 {
+			lines := make([]string, len(problems))
+			for i, p := range problems {
+				lines[i] = "  " + p.String()
+			}
+			console.Error(fmt.Sprintf("%s has %d problem(s):\n%s", CnfPath, len(problems), strings.Join(lines, "\n")))
+			os.Exit(cli.ExitFailure)
+		}
+		console.Success(fmt.Sprintf("%s is a valid version %d config", CnfPath, config.CurrentVersion))
+	},
+}
+
+// configMigrateCmd represents the config migrate command
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade the config file to the latest schema version",
+	Long: `Upgrade the config file to the latest schema version, renaming keys whose
+name changed and adding settings a new version requires, and set its version.
+Comments and other settings are kept, and the original file is saved next to
+it with a .bak suffix. A shared config is migrated at its source, not here.
+
+Version 0 to 1 renames keys spelled with underscores or capitals, such as
+default_project or machineType, which version 0 ignored.
+
+Example:
+  gcectl config migrate --dry-run
+  gcectl config migrate`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		refuseSharedConfigEdit(console, "migrate it")
+		changes, err := config.Migrate(CnfPath, configMigrateDryRun)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		if len(changes) == 0 {
+			console.Success(fmt.Sprintf("%s is already version %d", CnfPath, config.CurrentVersion))
+			return
+		}
+		for _, change := range changes {
+			console.RenderText("  " + change + "\n")
+		}
+		if configMigrateDryRun {
+			console.Success(fmt.Sprintf("Would migrate %s to version %d", CnfPath, config.CurrentVersion))
+			return
+		}
+		console.Success(fmt.Sprintf("Migrated %s to version %d, keeping the original as %s.bak", CnfPath, config.CurrentVersion, CnfPath))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configMigrateCmd)
+	configMigrateCmd.Flags().BoolVar(&configMigrateDryRun, "dry-run", false, "print the changes without writing the file")
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/infrastructure/audit"
+	"github.com/haru-256/gcectl/internal/infrastructure/cache"
+	"github.com/haru-256/gcectl/internal/infrastructure/config"
+	"github.com/haru-256/gcectl/internal/infrastructure/history"
+	"github.com/haru-256/gcectl/internal/infrastructure/oplog"
+	"github.com/haru-256/gcectl/internal/infrastructure/profile"
+	"github.com/haru-256/gcectl/internal/infrastructure/remoteconfig"
+	"github.com/haru-256/gcectl/internal/infrastructure/scheduler"
+	"github.com/haru-256/gcectl/internal/infrastructure/snooze"
+	"github.com/haru-256/gcectl/internal/infrastructure/update"
+	"github.com/haru-256/gcectl/internal/infrastructure/vmlock"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+// gcectlPath is a file gcectl reads or writes, as listed by config path.
+type gcectlPath struct {
+	name string
+	path string
+	// note follows the path in the listing.
+	note string
+}
+
+// configPathNames are the names config path accepts, in the order it lists them.
+var configPathNames = []string{"config", "contexts", "history", "snoozes", "scheduled", "operations", "audit-log", "locks", "cache", "update-check", "remote-config"}
+
+// configPathCmd represents the config path command
+var configPathCmd = &cobra.Command{
+	Use:   "path [name]",
+	Short: "Print where gcectl keeps its config, state and cache files",
+	Long: `Print the files gcectl reads and writes, as resolved for this run, or the one
+named. They follow the XDG Base Directory Specification:
+
+  config, contexts          $XDG_CONFIG_HOME/gcectl  (~/.config/gcectl)
+  history, snoozes,
+  scheduled, operations,
+  audit-log, locks          $XDG_STATE_HOME/gcectl   (~/.local/state/gcectl)
+  cache, update-check,
+  remote-config             $XDG_CACHE_HOME/gcectl   (~/.cache/gcectl)
+
+A file an earlier version of gcectl created in the user config directory, such
+as ~/.config/gcectl/snoozes.json, keeps being used while it exists. --config,
+the current context and the operation-history and audit-log settings of the
+config take precedence as usual.
+
+Example:
+  gcectl config path
+  $EDITOR "$(gcectl config path config)"`,
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: configPathNames,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		paths, err := gcectlPaths()
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		if len(args) == 1 {
+			for _, p := range paths {
+				if p.name == args[0] {
+					console.RenderText(p.path + "\n")
+				}
+			}
+			return
+		}
+		var b strings.Builder
+		for _, p := range paths {
+			line := fmt.Sprintf("%-14s%s", p.name, p.path)
+			if p.note != "" {
+				line += " " + p.note
+			}
+			b.WriteString(line + "\n")
+		}
+		console.RenderText(b.String())
+	},
+}
+
+// gcectlPaths resolves the files of configPathNames for this run.
+func gcectlPaths() ([]gcectlPath, error) {
+	// The config may be missing or broken; its settings are optional here.
+	cfg, _ := config.NewConfig(CnfPath)
+	configPath := gcectlPath{name: "config", path: CnfPath}
+	if remoteConfigSource != nil {
+		configPath.note = "(copy of " + remoteConfigSource.Raw + ")"
+	}
+
+	defaults := map[string]func() (string, error){
+		"contexts":      profile.DefaultPath,
+		"history":       history.DefaultPath,
+		"snoozes":       snooze.DefaultPath,
+		"scheduled":     scheduler.DefaultPath,
+		"operations":    oplog.DefaultPath,
+		"audit-log":     audit.DefaultPath,
+		"locks":         vmlock.DefaultDir,
+		"cache":         cache.DefaultPath,
+		"update-check":  update.DefaultStatePath,
+		"remote-config": remoteconfig.DefaultCacheDir,
+	}
+	paths := []gcectlPath{configPath}
+	for _, name := range configPathNames[1:] {
+		path, err := defaults[name]()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case name == "operations" && cfg != nil && cfg.OperationHistory != "":
+			path = cfg.OperationHistory
+		case name == "audit-log" && cfg != nil && cfg.AuditLog.Path != "":
+			path = cfg.AuditLog.Path
+		}
+		paths = append(paths, gcectlPath{name: name, path: path})
+	}
+	return paths, nil
+}
+
+func init() {
+	configCmd.AddCommand(configPathCmd)
+}
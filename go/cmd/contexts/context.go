@@ -0,0 +1,45 @@
+package contexts
+
+import (
+	"os"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/infrastructure/profile"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+var ContextCmd = &cobra.Command{
+	Use:   "context <command>",
+	Short: "Switch between profiles of config file, project and zone",
+	Long: `Switch between profiles, like kubectl contexts, instead of passing -c to every
+command. A profile names a config file and optionally a project and zone that
+replace its default-project and default-zone.
+
+The profiles and the current one are kept in ~/.config/gcectl/contexts.json.
+The "default" profile is the default config file as is. --config naming another
+config file ignores the current profile.
+
+Example:
+  gcectl context use work --config ~/.config/gcectl/work.yaml --zone us-east1-b
+  gcectl context list
+  gcectl context use default`,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		infraLog.DefaultLogger.Debugf("run context command")
+		if err := cmd.Help(); err != nil {
+			console.Error("Failed to run help command")
+			os.Exit(cli.ExitFailure)
+		}
+	},
+}
+
+// openProfiles opens the profile file at its default location.
+func openProfiles() (*profile.File, error) {
+	path, err := profile.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return profile.Open(path)
+}
@@ -0,0 +1,34 @@
+package contexts
+
+import (
+	"os"
+
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+var currentCmd = &cobra.Command{
+	Use:   "current",
+	Short: "Print the name of the current profile",
+	Long: `Print the name of the current profile, "default" when none was chosen with
+gcectl context use.
+
+Example:
+  gcectl context current`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		profiles, err := openProfiles()
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		console.RenderText(profiles.Current().Name + "\n")
+	},
+}
+
+func init() {
+	ContextCmd.AddCommand(currentCmd)
+}
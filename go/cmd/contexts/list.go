@@ -0,0 +1,34 @@
+package contexts
+
+import (
+	"os"
+
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the profiles with the current one marked",
+	Long: `List the profiles with their config files, projects and zones. The current
+profile is marked with *.
+
+Example:
+  gcectl context list`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		profiles, err := openProfiles()
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		console.RenderProfiles(profiles.List(), profiles.Current().Name)
+	},
+}
+
+func init() {
+	ContextCmd.AddCommand(listCmd)
+}
@@ -0,0 +1,92 @@
+package contexts
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/remoteconfig"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+var (
+	useConfig  string
+	useProject string
+	useZone    string
+)
+
+var useCmd = &cobra.Command{
+	Use:   "use <profile>",
+	Short: "Make a profile current, creating or updating it with the given settings",
+	Long: `Make a profile current, so that later commands load its config file with its
+project and zone as default-project and default-zone.
+
+--config, --project and --zone create the profile or update those settings of
+it; an empty value unsets one. "default" is the default config file as is.
+
+Example:
+  gcectl context use work --config ~/.config/gcectl/work.yaml
+  gcectl context use work --project other-project
+  gcectl context use default`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		name := args[0]
+
+		profiles, err := openProfiles()
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+
+		p, exists := profiles.Get(name)
+		if !exists {
+			p = &model.Profile{Name: name}
+		}
+		changed := false
+		if cmd.Flags().Changed("config") {
+			p.ConfigPath = useConfig
+			// Remote configs, e.g. gs://bucket/gcectl.yaml, are kept as they are.
+			if _, remote, _ := remoteconfig.ParseSource(p.ConfigPath); p.ConfigPath != "" && !remote {
+				if p.ConfigPath, err = filepath.Abs(p.ConfigPath); err != nil {
+					console.ErrorWithHint(err.Error(), err)
+					os.Exit(cli.ExitFailure)
+				}
+			}
+			changed = true
+		}
+		if cmd.Flags().Changed("project") {
+			p.Project, changed = useProject, true
+		}
+		if cmd.Flags().Changed("zone") {
+			p.Zone, changed = useZone, true
+		}
+		if !exists && !changed && name != model.DefaultProfileName {
+			console.Error(fmt.Sprintf("Context %s does not exist; create it with --config, --project or --zone", name))
+			os.Exit(cli.ExitFailure)
+		}
+
+		if changed {
+			if err = profiles.Put(p); err != nil {
+				console.ErrorWithHint(err.Error(), err)
+				os.Exit(cli.ExitCode(err))
+			}
+		}
+		if err = profiles.Use(name); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		console.Success(fmt.Sprintf("Switched to context %s", name))
+	},
+}
+
+func init() {
+	ContextCmd.AddCommand(useCmd)
+	// The flags shadow the global --config so that it is stored instead of loaded.
+	useCmd.Flags().StringVarP(&useConfig, "config", "c", "", "Config file of the profile")
+	useCmd.Flags().StringVar(&useProject, "project", "", "Project replacing default-project of the config file")
+	useCmd.Flags().StringVar(&useZone, "zone", "", "Zone replacing default-zone of the config file")
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/haru-256/gcectl/internal/infrastructure/ssh"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+// cpRecursive copies directories recursively
+var cpRecursive bool
+
+// cpCmd represents the cp command
+var cpCmd = &cobra.Command{
+	Use:   "cp <source>... <destination>",
+	Short: "Copy files to or from the instance over SSH",
+	Long: `Copy files between this machine and an instance with scp. Paths on the
+instance are written vm:path, where vm is a VM name of config.yaml; either the
+destination or all sources are on the same instance. A relative path on the
+instance is relative to the home directory of the ssh user. Write a local path
+containing a colon as ./file:name.
+
+The connection is resolved as for exec: the external IP, the internal IP with
+--internal or an Identity-Aware Proxy tunnel with --iap, with the ssh options
+of the VM in config.yaml.
+
+Example:
+  gcectl cp local.txt vm1:/tmp/
+  gcectl cp vm1:/var/log/app.log .
+  gcectl cp -r ./dataset vm1:data/
+  gcectl cp --iap vm1:results.csv vm1:metrics.csv ./out/`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		sources, dest := args[:len(args)-1], args[len(args)-1]
+
+		vmName, paths, upload, err := splitCopyOperands(sources, dest)
+		if err != nil {
+			console.Error(err.Error())
+			os.Exit(cli.ExitFailure)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(vmName)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if err = session.OpenVMRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		target, err := resolveSSHTarget(ctx, session, vm)
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to resolve %s: %v", vm.Name, err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		if upload {
+			err = ssh.Upload(ctx, target, sources, paths[0], cpRecursive)
+		} else {
+			err = ssh.Download(ctx, target, paths, dest, cpRecursive)
+		}
+		var exitErr *ssh.ExitError
+		if errors.As(err, &exitErr) {
+			// scp has reported the failure on stderr already.
+			session.Close()
+			os.Exit(exitErr.Code)
+		}
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+	},
+}
+
+// splitCopyOperands works out the direction of a copy. For an upload it returns
+// the VM of dest and its path; for a download the VM of the sources and their
+// paths on it.
+func splitCopyOperands(sources []string, dest string) (vmName string, paths []string, upload bool, err error) {
+	if destVM, destPath, ok := ssh.SplitRemotePath(dest); ok {
+		for _, source := range sources {
+			if _, _, remote := ssh.SplitRemotePath(source); remote {
+				return "", nil, false, fmt.Errorf("cannot copy %s to %s: copies between instances are not supported", source, dest)
+			}
+		}
+		return destVM, []string{destPath}, true, nil
+	}
+	for _, source := range sources {
+		sourceVM, sourcePath, ok := ssh.SplitRemotePath(source)
+		if !ok {
+			return "", nil, false, fmt.Errorf("cannot copy %s to %s: one side must be on an instance, written vm:path", source, dest)
+		}
+		if vmName != "" && sourceVM != vmName {
+			return "", nil, false, fmt.Errorf("all sources must be on the same instance, got %s and %s", vmName, sourceVM)
+		}
+		vmName = sourceVM
+		paths = append(paths, sourcePath)
+	}
+	return vmName, paths, false, nil
+}
+
+func init() {
+	rootCmd.AddCommand(cpCmd)
+	addSSHTargetFlags(cpCmd)
+	cpCmd.Flags().BoolVarP(&cpRecursive, "recursive", "r", false, "Copy directories recursively")
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/cache"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/infrastructure/scheduler"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var (
+	daemonInterval         time.Duration
+	daemonRefreshInterval  time.Duration
+	daemonRestartPreempted bool
+	daemonAuditLog         string
+)
+
+// daemonCmd represents the daemon command
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Run scheduled stops and background upkeep until interrupted",
+	Long: `Run in the foreground until interrupted, doing on a timer what other
+commands otherwise leave to detached workers or cron:
+
+  - perform pending stops from 'on --ttl' and 'off --at' once they are due
+    (every --interval)
+  - refresh the VM state cache read by 'list --cached'
+    (every --refresh-interval, default cache-ttl from config)
+  - with --restart-preempted, start the VMs marked spot: true in settings
+    again after GCE preempts them (every --interval), as 'spot-guard' does
+
+Everything the daemon does, including failures, is appended as JSON lines to
+the audit log (--audit-log, default ~/.local/state/gcectl/audit.log).
+
+Example:
+  gcectl daemon
+  gcectl daemon --restart-preempted --interval 1m`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		// Keep running after the terminal that started us is closed
+		signal.Ignore(syscall.SIGHUP)
+
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		err = session.OpenVMRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if daemonRestartPreempted {
+			err = session.OpenOperationRepository(ctx)
+			if err != nil {
+				console.ErrorWithHint(err.Error(), err)
+				session.Close()
+				os.Exit(cli.ExitCode(err))
+			}
+		}
+
+		auditLog, err := cli.OpenAuditLog(session.Config, daemonAuditLog)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		refreshInterval := daemonRefreshInterval
+		if refreshInterval == 0 {
+			refreshInterval = session.Config.CacheTTL
+		}
+
+		tasks := []usecase.DaemonTask{
+			{Name: "scheduled-actions", Interval: daemonInterval, Run: runScheduledActionsTask(session)},
+			{Name: "refresh-cache", Interval: refreshInterval, Run: refreshCacheTask(session)},
+		}
+		if daemonRestartPreempted {
+			restartUseCase := usecase.NewRestartPreemptedUseCase(session.VMRepository, session.OperationRepository, infraLog.DefaultLogger)
+			tasks = append(tasks, usecase.DaemonTask{Name: "restart-preempted", Interval: daemonInterval, Run: restartPreemptedTask(session, restartUseCase)})
+		}
+
+		console.Success(fmt.Sprintf("Daemon started, auditing to %s", auditLog.Path()))
+		daemon := usecase.NewDaemonUseCase(tasks, auditLog, infraLog.DefaultLogger)
+		if runErr := daemon.Run(ctx); runErr != nil {
+			console.ErrorWithHint(runErr.Error(), runErr)
+			session.Close()
+			os.Exit(cli.ExitCode(runErr))
+		}
+	},
+}
+
+// runScheduledActionsTask performs the pending stops that are due and notifies about each.
+func runScheduledActionsTask(session *cli.Session) func(ctx context.Context) ([]*model.AuditEntry, error) {
+	return func(ctx context.Context) ([]*model.AuditEntry, error) {
+		// Re-open the file every round to see changes made by other commands
+		store, err := scheduler.OpenDefault()
+		if err != nil {
+			return nil, err
+		}
+		done, err := usecase.NewRunScheduledActionsUseCase(session.VMRepository, store, infraLog.DefaultLogger).Execute(ctx)
+		entries := make([]*model.AuditEntry, 0, len(done))
+		events := make([]model.Event, 0, len(done))
+		for _, action := range done {
+			message := "ran " + action.Description()
+			entries = append(entries, &model.AuditEntry{VMName: action.VMName, Message: message})
+			events = append(events, model.Event{Type: model.EventAutoStop, VMName: action.VMName, Message: message})
+		}
+		session.Notify(events...)
+		return entries, err
+	}
+}
+
+// refreshCacheTask fetches the state of every VM in settings into the cache.
+func refreshCacheTask(session *cli.Session) func(ctx context.Context) ([]*model.AuditEntry, error) {
+	return func(ctx context.Context) ([]*model.AuditEntry, error) {
+		cachePath, err := cache.DefaultPath()
+		if err != nil {
+			return nil, err
+		}
+		listVMsUC := usecase.NewListVMsUseCase(session.VMRepository).
+			WithMaxConcurrency(session.Config.MaxConcurrency).
+			WithCache(cache.Open(cachePath))
+		items, err := listVMsUC.Execute(ctx, session.Config.VMs)
+		return []*model.AuditEntry{{Message: fmt.Sprintf("refreshed cached state of %d VMs", len(items))}}, err
+	}
+}
+
+// restartPreemptedTask starts the VMs marked spot: true that were preempted and
+// notifies about each. restartUseCase is shared by every run so its backoff holds.
+func restartPreemptedTask(session *cli.Session, restartUseCase *usecase.RestartPreemptedUseCase) func(ctx context.Context) ([]*model.AuditEntry, error) {
+	return func(ctx context.Context) ([]*model.AuditEntry, error) {
+		restarted, err := restartUseCase.Execute(ctx, session.Config.SpotVMs())
+		entries := make([]*model.AuditEntry, 0, len(restarted))
+		names := make([]string, 0, len(restarted))
+		for _, vm := range restarted {
+			entries = append(entries, &model.AuditEntry{VMName: vm.Name, Message: "restarted after preemption"})
+			names = append(names, vm.Name)
+		}
+		session.Notify(operationEvents(model.EventPreemption, names, "restarted after preemption")...)
+		return entries, err
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(daemonCmd)
+	daemonCmd.Flags().DurationVar(&daemonInterval, "interval", 30*time.Second, "How often to run due stops and check for preempted VMs")
+	daemonCmd.Flags().DurationVar(&daemonRefreshInterval, "refresh-interval", 0, "How often to refresh the VM state cache (default cache-ttl from config)")
+	daemonCmd.Flags().BoolVar(&daemonRestartPreempted, "restart-preempted", false, "Start Spot VMs again after they are preempted")
+	daemonCmd.Flags().StringVar(&daemonAuditLog, "audit-log", "", "Path of the audit log (default ~/.local/state/gcectl/audit.log)")
+}
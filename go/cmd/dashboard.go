@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dashboardTop      int
+	dashboardInterval time.Duration
+	dashboardWatch    bool
+)
+
+// dashboardCmd represents the dashboard command
+var dashboardCmd = &cobra.Command{
+	Use:   "dashboard",
+	Short: "Show a summary of all VMs in settings",
+	Long: `Show a summary of all VMs in settings: counts by status, the estimated
+hourly cost of running VMs and the longest-running VMs.
+
+Costs are estimated from the hourly-cost table in config.yaml:
+
+  hourly-cost:
+    e2-medium: 0.0335
+    n1-standard-4: 0.19
+
+With --watch, the dashboard is refreshed every --interval until interrupted.
+
+Example:
+  gcectl dashboard
+  gcectl dashboard --watch --interval 30s --top 10`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		err = session.OpenVMRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		summarizeUC := usecase.NewSummarizeFleetUseCase(session.VMRepository).WithMaxConcurrency(session.Config.MaxConcurrency)
+
+		if !dashboardWatch {
+			if renderErr := renderDashboard(ctx, console, summarizeUC, session.Config.VMs, session.Config.HourlyCost); renderErr != nil {
+				console.ErrorWithHint(fmt.Sprintf("Failed to fetch some VMs: %v", renderErr), renderErr)
+				session.Close()
+				os.Exit(cli.ExitCode(renderErr))
+			}
+			return
+		}
+
+		ticker := time.NewTicker(dashboardInterval)
+		defer ticker.Stop()
+		for {
+			console.ClearScreen()
+			if renderErr := renderDashboard(ctx, console, summarizeUC, session.Config.VMs, session.Config.HourlyCost); renderErr != nil {
+				// Keep refreshing; transient API errors should not end the watch
+				console.ErrorWithHint(fmt.Sprintf("Failed to fetch some VMs: %v", renderErr), renderErr)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	},
+}
+
+// renderDashboard fetches and renders one snapshot of the fleet summary.
+func renderDashboard(ctx context.Context, console *presenter.ConsolePresenter, uc *usecase.SummarizeFleetUseCase, vms []*model.VM, hourlyCost map[string]float64) error {
+	summary, err := uc.Execute(ctx, vms, hourlyCost, dashboardTop)
+	infraLog.DefaultLogger.Debugf("Summarized %d VMs", summary.Total)
+
+	longest := make([]presenter.VMListItem, len(summary.LongestRunning))
+	for i, item := range summary.LongestRunning {
+		longest[i] = toPresenterListItem(item)
+	}
+	console.RenderFleetSummary(presenter.FleetSummary{
+		StatusCounts:         summary.StatusCounts,
+		LongestRunning:       longest,
+		UnpricedMachineTypes: summary.UnpricedMachineTypes,
+		HourlyCost:           summary.HourlyCost,
+		Total:                summary.Total,
+		UpdatedAt:            time.Now(),
+	})
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(dashboardCmd)
+	dashboardCmd.Flags().IntVar(&dashboardTop, "top", 5, "Number of longest-running VMs to show")
+	dashboardCmd.Flags().DurationVar(&dashboardInterval, "interval", 30*time.Second, "Refresh interval with --watch")
+	dashboardCmd.Flags().BoolVarP(&dashboardWatch, "watch", "w", false, "Refresh the dashboard until interrupted")
+}
@@ -6,7 +6,10 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 
+	"github.com/haru-256/gcectl/internal/domain/model"
 	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
 	"github.com/haru-256/gcectl/internal/interface/cli"
 	"github.com/haru-256/gcectl/internal/interface/presenter"
@@ -14,66 +17,165 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	describeAll bool
+	// describeOutput is the --output format: table or json
+	describeOutput string
+)
+
 // describeCmd represents the describe command
 var describeCmd = &cobra.Command{
-	Use:   "describe <vm_name>",
-	Short: "Describe the instance",
-	Long: `Describe the instance.
+	Use:   "describe <vm_name>... | --all | --selector <labels>",
+	Short: "Describe the instances",
+	Long: `Describe one or more instances, every VM in the config with --all, or
+the VMs with the GCP labels given by --selector.
+
+A VM name of "-" reads the names from stdin, one per line.
+
+The VMs are looked up concurrently, at most max-concurrency at a time.
+--output json prints a JSON object for a single VM, or an array for several.
+
+--format prints the VM with a Go template instead of the table, and --query
+prints the fields a jq-like path selects from it (from the array of VMs when
+several are described).
 
 Example:
-  gcectl describe <vm_name>`,
-	Args: cobra.ExactArgs(1),
+  gcectl describe <vm_name>
+  gcectl describe vm1 vm2
+  gcectl describe --all --output json
+  gcectl describe --selector env=dev
+  gcectl describe - < vms.txt
+  gcectl describe <vm_name> --format '{{.Status}} {{.Uptime}}'
+  gcectl describe <vm_name> --query '.Disks[].Name'`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if describeAll {
+			return cobra.NoArgs(cmd, args)
+		}
+		return vmArgs(cmd, args)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		console := presenter.NewConsolePresenter()
-		vmName := args[0]
-		infraLog.DefaultLogger.Debugf("Describe instance %s", vmName)
-		if vmName == "" {
-			console.Error("VM name is required")
-			os.Exit(1)
+		infraLog.DefaultLogger.Debugf("Describe instances %v", args)
+		if describeOutput != "table" && describeOutput != "json" {
+			console.Error(fmt.Sprintf("invalid --output %q: must be table or json", describeOutput))
+			os.Exit(cli.ExitFailure)
 		}
 
 		session, ctx, err := cli.NewSession(cmd, CnfPath)
 		if err != nil {
-			console.Error(err.Error())
-			os.Exit(1)
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
 		}
 		defer session.Close()
 
-		vm, err := session.Config.ResolveVM(vmName)
+		err = session.OpenVMRepository(ctx)
 		if err != nil {
-			console.Error(err.Error())
+			console.ErrorWithHint(err.Error(), err)
 			session.Close()
-			os.Exit(1)
+			os.Exit(cli.ExitCode(err))
 		}
 
-		err = session.OpenVMRepository(ctx)
-		if err != nil {
-			console.Error(err.Error())
-			session.Close()
-			os.Exit(1)
+		vms := session.Config.VMs
+		if !describeAll {
+			vms, err = resolveTargetVMs(ctx, session, args)
+			if err != nil {
+				console.ErrorWithHint(err.Error(), err)
+				session.Close()
+				os.Exit(cli.ExitCode(err))
+			}
 		}
 
-		describeVMUseCase := usecase.NewDescribeVMUseCase(session.VMRepository)
+		describeVMUseCase := usecase.NewDescribeVMUseCase(session.VMRepository).WithMaxConcurrency(session.Config.MaxConcurrency)
+		described, describeErr := describeVMUseCase.ExecuteMany(ctx, vms)
 
-		vmDetail, uptimeStr, err := describeVMUseCase.Execute(ctx, vm.Project, vm.Zone, vm.Name)
-		if err != nil {
-			console.Error(fmt.Sprintf("Failed to get VM info: %v", err))
-			session.Close()
-			os.Exit(1)
+		now := time.Now()
+		details := make([]presenter.VMDetail, len(described))
+		for i, item := range described {
+			details[i] = toPresenterDetail(item, now)
+		}
+		// A single named VM is shown as an object, several (or --all) as an array.
+		var data any = details
+		if len(args) == 1 && len(details) == 1 {
+			data = details[0]
+		}
+
+		switch {
+		case outputFormat != "" || outputQuery != "":
+			renderFormatted(console, session, details, data)
+		case describeOutput == "json":
+			if err := presenter.WriteVMDetailsJSON(os.Stdout, data); err != nil {
+				console.ErrorWithHint(fmt.Sprintf("Failed to write json: %v", err), err)
+				session.Close()
+				os.Exit(cli.ExitCode(err))
+			}
+		default:
+			for i, detail := range details {
+				if i > 0 {
+					fmt.Println()
+				}
+				console.RenderVMDetail(detail)
+			}
 		}
 
-		console.RenderVMDetail(presenter.VMDetail{
-			Name:           vmDetail.Name,
-			Project:        vmDetail.Project,
-			Zone:           vmDetail.Zone,
-			MachineType:    vmDetail.MachineType,
-			Status:         vmDetail.Status,
-			SchedulePolicy: vmDetail.SchedulePolicy,
-			Uptime:         uptimeStr,
-		})
+		if describeErr != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to get VM info: %v", describeErr), describeErr)
+			session.Close()
+			os.Exit(cli.ExitCode(describeErr))
+		}
 	},
 }
 
+// toPresenterDetail converts a described VM into the presenter detail.
+func toPresenterDetail(item usecase.VMListItem, now time.Time) presenter.VMDetail {
+	vm := item.VM
+	autoRestart, onHostMaintenance := schedulingOptions(vm)
+	return presenter.VMDetail{
+		Name:              vm.Name,
+		Project:           vm.Project,
+		Zone:              vm.Zone,
+		MachineType:       vm.MachineType,
+		Status:            vm.Status,
+		SchedulePolicy:    vm.SchedulePolicy,
+		ScheduleTimeZone:  scheduleTimeZone(vm),
+		Uptime:            item.Uptime,
+		StoppedFor:        item.StoppedFor,
+		Created:           formatTimestamp(vm.CreationTime),
+		LastStopped:       formatTimestamp(vm.LastStopTime),
+		NextSchedule:      usecase.NextScheduleString(vm, now),
+		AutomaticRestart:  autoRestart,
+		OnHostMaintenance: onHostMaintenance,
+		Maintenance:       formatTimestamp(vm.UpcomingMaintenance),
+		Labels:            vm.Labels,
+		Disks:             vm.Disks,
+		NetworkInterfaces: vm.NetworkInterfaces,
+		ServiceAccount:    vm.ServiceAccount,
+	}
+}
+
+// formatTimestamp formats t in local time, or returns "" when it is unknown.
+func formatTimestamp(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Local().Format("2006-01-02 15:04 MST")
+}
+
+// schedulingOptions formats the VM's automatic restart and on-host-maintenance options.
+// Both are empty when the scheduling of the VM is unknown.
+func schedulingOptions(vm *model.VM) (autoRestart, onHostMaintenance string) {
+	if vm.Scheduling == nil {
+		return "", ""
+	}
+	return strconv.FormatBool(vm.Scheduling.AutomaticRestartEnabled()), vm.Scheduling.OnHostMaintenance
+}
+
 func init() {
+	describeCmd.Flags().BoolVar(&describeAll, "all", false, "describe every VM in the config")
+	describeCmd.Flags().StringVarP(&describeOutput, "output", "o", "table", "output format: table or json")
+	addOutputFlags(describeCmd)
+	addSelectorFlag(describeCmd)
+	describeCmd.MarkFlagsMutuallyExclusive("all", "selector")
+	describeCmd.MarkFlagsMutuallyExclusive("output", "format")
+	describeCmd.MarkFlagsMutuallyExclusive("output", "query")
 	rootCmd.AddCommand(describeCmd)
 }
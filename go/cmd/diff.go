@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show how VMs differ from the state declared in the config file",
+	Long: `Show how VMs differ from the state declared in the config file.
+
+The status, machine type and schedule policy of each VM with desired-state,
+machine-type or schedule-policy in the config file are compared with the
+declared values, without changing anything. Use gcectl apply to close the
+difference.
+
+The command exits with code 7 when any VM differs, so CI can check for drift.
+
+Example:
+  gcectl diff`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		infraLog.DefaultLogger.Debug("Diff desired state")
+
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vms := session.Config.ManagedVMs()
+		if len(vms) == 0 {
+			console.Error("No VM in the config file has desired-state, machine-type or schedule-policy")
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+
+		if err = session.OpenVMRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		applyUseCase := usecase.NewApplyDesiredStateUseCase(session.VMRepository, infraLog.DefaultLogger)
+		drifts, err := applyUseCase.Diff(ctx, vms, session.Config.DesiredStates)
+		console.RenderDrift(drifts)
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to compare VMs: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		for _, drift := range drifts {
+			if len(drift.Fields) > 0 {
+				session.Close()
+				os.Exit(cli.ExitDrift)
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+}
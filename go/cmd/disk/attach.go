@@ -0,0 +1,74 @@
+package disk
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var attachCmd = &cobra.Command{
+	Use:   "attach <vm_name> <disk>",
+	Short: "Attach a disk to a VM",
+	Long: `Attach an existing persistent disk in the VM's zone as a secondary disk.
+The disk's name is used as its device name.
+
+Example:
+  gcectl disk attach sandbox data-2
+  gcectl disk attach sandbox shared-data --read-only`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(args[0])
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		err = session.OpenDiskRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		attachUseCase := usecase.NewAttachDiskUseCase(session.DiskRepository, infraLog.DefaultLogger)
+		message := fmt.Sprintf("Attaching disk %s to VM %s", args[1], vm.Name)
+		err = console.ExecuteWithProgress(ctx, message, func(ctx context.Context) error {
+			return attachUseCase.Execute(ctx, vm.Project, vm.Zone, vm.Name, args[1], readOnly)
+		})
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to attach disk: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		console.Success(fmt.Sprintf("Attached disk %s to %s", args[1], vm.Name))
+	},
+}
+
+var readOnly bool
+
+func init() {
+	DiskCmd.AddCommand(attachCmd)
+	attachCmd.Flags().BoolVar(&readOnly, "read-only", false, "Attach the disk in READ_ONLY mode")
+}
@@ -0,0 +1,70 @@
+package disk
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var detachCmd = &cobra.Command{
+	Use:   "detach <vm_name> <disk>",
+	Short: "Detach a disk from a VM",
+	Long: `Detach a secondary disk, given by name or device name, from a VM. The disk
+itself is kept. The boot disk cannot be detached.
+
+Example:
+  gcectl disk detach sandbox data-2`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(args[0])
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		err = session.OpenDiskRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		detachUseCase := usecase.NewDetachDiskUseCase(session.DiskRepository, infraLog.DefaultLogger)
+		message := fmt.Sprintf("Detaching disk %s from VM %s", args[1], vm.Name)
+		err = console.ExecuteWithProgress(ctx, message, func(ctx context.Context) error {
+			return detachUseCase.Execute(ctx, vm.Project, vm.Zone, vm.Name, args[1])
+		})
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to detach disk: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		console.Success(fmt.Sprintf("Detached disk %s from %s", args[1], vm.Name))
+	},
+}
+
+func init() {
+	DiskCmd.AddCommand(detachCmd)
+}
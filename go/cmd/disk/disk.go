@@ -0,0 +1,55 @@
+package disk
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+var DiskCmd = &cobra.Command{
+	Use:   "disk <command>",
+	Short: "Manage the disks of a VM",
+	Long: `List, resize, attach and detach the persistent disks of a configured VM.
+
+Example:
+  gcectl disk list sandbox
+  gcectl disk resize sandbox data-1 --size 200GB
+  gcectl disk attach sandbox data-2
+  gcectl disk detach sandbox data-2`,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		infraLog.DefaultLogger.Debugf("run disk command")
+		if err := cmd.Help(); err != nil {
+			console.Error("Failed to run help command")
+			os.Exit(cli.ExitFailure)
+		}
+	},
+}
+
+// parseSizeGB parses a disk size such as "200", "200GB" or "2TB" into gigabytes.
+func parseSizeGB(s string) (int64, error) {
+	if s == "" {
+		return 0, errors.New("--size is required")
+	}
+	upper := strings.ToUpper(strings.TrimSpace(s))
+	multiplier := int64(1)
+	switch {
+	case strings.HasSuffix(upper, "TB"):
+		multiplier = 1024
+		upper = strings.TrimSuffix(upper, "TB")
+	case strings.HasSuffix(upper, "GB"):
+		upper = strings.TrimSuffix(upper, "GB")
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(upper), 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid disk size %q: use e.g. 200GB or 2TB", s)
+	}
+	return n * multiplier, nil
+}
@@ -0,0 +1,64 @@
+package disk
+
+import (
+	"os"
+
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list <vm_name>",
+	Short: "List the disks of a VM",
+	Long: `List the disks attached to a VM with their device name, type, size and mode.
+
+Example:
+  gcectl disk list sandbox`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(args[0])
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		err = session.OpenDiskRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		listUseCase := usecase.NewListDisksUseCase(session.DiskRepository)
+		disks, err := listUseCase.Execute(ctx, vm.Project, vm.Zone, vm.Name)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		console.RenderDisks(disks)
+	},
+}
+
+func init() {
+	DiskCmd.AddCommand(listCmd)
+}
@@ -0,0 +1,81 @@
+package disk
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var resizeCmd = &cobra.Command{
+	Use:   "resize <vm_name> <disk>",
+	Short: "Grow a disk of a VM",
+	Long: `Grow a persistent disk attached to a VM. The disk can be given by name or
+device name. Disks cannot shrink, and the file system inside the VM has to be
+grown separately.
+
+Example:
+  gcectl disk resize sandbox data-1 --size 200GB
+  gcectl disk resize sandbox persistent-disk-0 --size 50`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		sizeGB, err := parseSizeGB(size)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(args[0])
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		err = session.OpenDiskRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		resizeUseCase := usecase.NewResizeDiskUseCase(session.DiskRepository, infraLog.DefaultLogger)
+		message := fmt.Sprintf("Resizing disk %s of VM %s to %d GB", args[1], vm.Name, sizeGB)
+		err = console.ExecuteWithProgress(ctx, message, func(ctx context.Context) error {
+			_, resizeErr := resizeUseCase.Execute(ctx, vm.Project, vm.Zone, vm.Name, args[1], sizeGB)
+			return resizeErr
+		})
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to resize disk: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		console.Success(fmt.Sprintf("Resized disk %s to %d GB", args[1], sizeGB))
+	},
+}
+
+var size string
+
+func init() {
+	DiskCmd.AddCommand(resizeCmd)
+	resizeCmd.Flags().StringVar(&size, "size", "", "New size of the disk (e.g. 200GB, 2TB)")
+}
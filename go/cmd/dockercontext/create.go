@@ -0,0 +1,118 @@
+package dockercontext
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/docker"
+	"github.com/haru-256/gcectl/internal/infrastructure/sshconfig"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var (
+	contextName string
+	useContext  bool
+	internal    bool
+)
+
+var createCmd = &cobra.Command{
+	Use:   "create <vm_name>",
+	Short: "Create a docker context for the docker daemon of a VM",
+	Long: `Create a docker context running docker commands on the VM over SSH, or update
+it when it exists, so docker build and docker run execute on the VM.
+
+The VM must be running. Its Host entry is written to the gcectl block of
+~/.ssh/config with its current IP, as ssh-config generate --write does for one
+VM, and the context connects to that host; rerun create after the IP of the VM
+changes. The ssh user needs access to the docker daemon, e.g. by being in the
+docker group.
+
+Example:
+  gcectl docker-context create gpu-box
+  gcectl docker-context create gpu-box --use
+  docker --context gpu-box build .`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(args[0])
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		name := contextName
+		if name == "" {
+			name = vm.Name
+		}
+
+		if err = session.OpenVMRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		ip, err := usecase.NewGetVMIPUseCase(session.VMRepository).Execute(ctx, vm.Project, vm.Zone, vm.Name, internal)
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to get the IP of %s: %v", vm.Name, err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		sshConfigPath, err := sshconfig.DefaultPath()
+		if err != nil {
+			console.Error(err.Error())
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+		host := model.SSHHost{Alias: vm.Name, HostName: ip, SSHOptions: session.Config.SSHOptionsFor(vm.Name)}
+		if err = sshconfig.UpsertFile(sshConfigPath, host); err != nil {
+			console.Error(err.Error())
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+
+		created, err := docker.SaveContext(ctx, name, vm.Name, "gcectl: "+vm.QualifiedName())
+		if err != nil {
+			console.Error(err.Error())
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+		verb := "Updated"
+		if created {
+			verb = "Created"
+		}
+		console.Success(fmt.Sprintf("%s docker context %s for %s", verb, name, vm.Name))
+
+		if useContext {
+			if err = docker.UseContext(ctx, name); err != nil {
+				console.Error(err.Error())
+				session.Close()
+				os.Exit(cli.ExitFailure)
+			}
+			console.Success(fmt.Sprintf("Docker commands now run on %s; switch back with: docker context use default", vm.Name))
+		}
+	},
+}
+
+func init() {
+	DockerContextCmd.AddCommand(createCmd)
+	createCmd.Flags().StringVar(&contextName, "name", "", "Name of the docker context (default: the VM name)")
+	createCmd.Flags().BoolVar(&useContext, "use", false, "Make the context the current docker context")
+	createCmd.Flags().BoolVar(&internal, "internal", false, "Connect to the internal IP, e.g. over a VPN")
+}
@@ -0,0 +1,28 @@
+package dockercontext
+
+import (
+	"os"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+var DockerContextCmd = &cobra.Command{
+	Use:   "docker-context <command>",
+	Short: "Manage docker contexts running on VMs",
+	Long: `Manage docker CLI contexts that run docker commands on the docker daemon of a VM
+over SSH.
+
+Example:
+  gcectl docker-context create gpu-box --use`,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		infraLog.DefaultLogger.Debugf("run docker-context command")
+		if err := cmd.Help(); err != nil {
+			console.Error("Failed to run help command")
+			os.Exit(cli.ExitFailure)
+		}
+	},
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/gcp"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that gcectl can manage the configured VMs",
+	Long: `Check that gcectl can manage the configured VMs.
+
+The following checks run in order, and each failure is reported with a fix:
+  - the config file can be loaded
+  - the credentials (--credentials-file, the credentials key or Application
+    Default Credentials) can obtain a token
+  - the Compute Engine API is enabled in every configured project
+  - the credentials hold the IAM permissions gcectl needs in every project
+
+Checks that depend on a failed one are skipped.
+
+Example:
+  gcectl doctor`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		// A session cannot be opened with a broken config, which is one of the
+		// things doctor reports, so the dependencies are built directly.
+		parentCtx := cmd.Context()
+		if parentCtx == nil {
+			parentCtx = context.Background()
+		}
+		ctx, stop := signal.NotifyContext(parentCtx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		cfg, cfgErr := cli.LoadConfig(CnfPath)
+		loadConfig := func() ([]*model.VM, error) {
+			if cfgErr != nil {
+				return nil, cfgErr
+			}
+			return cfg.VMs, nil
+		}
+		settings, err := cli.ClientSettings(cmd, cfg)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			stop()
+			os.Exit(cli.ExitCode(err))
+		}
+		doctorUC := usecase.NewDoctorUseCase(gcp.NewPreflightRepository(infraLog.DefaultLogger, settings), loadConfig, infraLog.DefaultLogger)
+		results := doctorUC.Execute(ctx)
+		console.RenderCheckResults(results)
+
+		if model.Failed(results) {
+			console.Error("Some checks failed")
+			stop()
+			os.Exit(cli.ExitFailure)
+		}
+		console.Success("gcectl is ready")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
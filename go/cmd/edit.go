@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/infrastructure/editor"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// editCmd represents the edit command
+var editCmd = &cobra.Command{
+	Use:   "edit <vm_name>",
+	Short: "Edit the raw instance resource in $EDITOR",
+	Long: `Edit the raw instance resource in $EDITOR.
+
+The instance is fetched as JSON and opened in $VISUAL or $EDITOR (default: vi).
+After the editor exits, the edited document is compared with the original and
+the following changes are applied:
+
+  - labels
+  - metadata.items
+  - scheduling (automaticRestart, onHostMaintenance, provisioningModel,
+    instanceTerminationAction, preemptible)
+  - machineType (the VM must be stopped)
+
+If any other field was changed, nothing is applied and the unsupported fields are reported.
+
+Example:
+  gcectl edit <vm_name>
+  EDITOR="code --wait" gcectl edit <vm_name>`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		vmName := args[0]
+		infraLog.DefaultLogger.Debugf("Edit instance %s", vmName)
+
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(vmName)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		err = session.OpenVMRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		editVMUseCase := usecase.NewEditVMUseCase(session.VMRepository, editor.NewEditor(), infraLog.DefaultLogger)
+
+		result, err := editVMUseCase.Execute(ctx, vm)
+		if err != nil {
+			var unsupportedErr *usecase.UnsupportedChangesError
+			if errors.As(err, &unsupportedErr) {
+				console.Error(fmt.Sprintf("Edit rejected, the following fields cannot be changed with gcectl edit:\n  - %s",
+					strings.Join(unsupportedErr.Fields, "\n  - ")))
+			} else {
+				console.ErrorWithHint(fmt.Sprintf("Failed to edit VM: %v", err), err)
+			}
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+
+		if len(result.Changed) == 0 {
+			console.Success("Edit cancelled, no changes made")
+			return
+		}
+		console.Success(fmt.Sprintf("Edited %s: %s", vmName, strings.Join(result.Changed, ", ")))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(editCmd)
+}
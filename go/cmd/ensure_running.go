@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+)
+
+// ensureRunning starts vm unless it is running, with the budget check of on when
+// a budget is configured. The VM repository of session must be open.
+func ensureRunning(ctx context.Context, console *presenter.ConsolePresenter, session *cli.Session, vm *model.VM) error {
+	startVMUseCase := usecase.NewStartVMUseCase(session.VMRepository, infraLog.DefaultLogger)
+	if session.Config.Budget.IsSet() {
+		checkBudgetUseCase, err := newCheckBudgetUseCase(ctx, session)
+		if err != nil {
+			return err
+		}
+		startVMUseCase.WithBudgetCheck(checkBudgetUseCase.Execute, false)
+	}
+
+	ensureRunningUseCase := usecase.NewEnsureRunningUseCase(session.VMRepository, startVMUseCase)
+	var started bool
+	err := console.ExecuteWithProgress(ctx, fmt.Sprintf("Checking that %s is running", vm.Name), func(ctx context.Context) error {
+		var runErr error
+		started, runErr = ensureRunningUseCase.Execute(ctx, vm)
+		return runErr
+	})
+	if err != nil {
+		return err
+	}
+	if started {
+		console.Success(fmt.Sprintf("Started %s", vm.Name))
+	}
+	return nil
+}
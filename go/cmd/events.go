@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// eventsLimit is the maximum number of events to show
+var eventsLimit int
+
+// eventsCmd represents the events command
+var eventsCmd = &cobra.Command{
+	Use:   "events <vm_name>",
+	Short: "List recent system events of a VM",
+	Long: `List the recent events GCE initiated on a VM rather than a user, newest first:
+host maintenance (live migration or stop), host errors, preemption of Spot VMs,
+automatic restarts and shutdowns from inside the guest OS.
+
+Scheduled host maintenance that has not happened yet is marked in list and
+shown by describe.
+
+Example:
+  gcectl events my-vm
+  gcectl events my-vm --limit 50`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(args[0])
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		// Opening the VM repository looks up the zone of VMs configured without one.
+		if err = session.OpenVMRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if err = session.OpenOperationRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		events, err := usecase.NewListSystemEventsUseCase(session.OperationRepository).Execute(ctx, vm, eventsLimit)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if len(events) == 0 {
+			console.Success(fmt.Sprintf("No recent system events on %s", vm.Name))
+			return
+		}
+		console.RenderSystemEvents(events)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(eventsCmd)
+	eventsCmd.Flags().IntVarP(&eventsLimit, "limit", "n", 20, "Maximum number of events")
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/haru-256/gcectl/internal/infrastructure/ssh"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+// execTTY allocates a terminal for the remote command
+var execTTY bool
+
+// execCmd represents the exec command
+var execCmd = &cobra.Command{
+	Use:   "exec <vm_name> -- <command> [args...]",
+	Short: "Run a command on the instance over SSH",
+	Long: `Run a one-off command on the instance with the OpenSSH client, streaming its
+stdin, stdout and stderr, and exit with the exit status of the remote command.
+
+The connection uses the external IP of the instance, the internal IP with
+--internal, or an Identity-Aware Proxy tunnel with --iap (needs gcloud), and the
+ssh user, port and identity-file of the VM in config.yaml. As with ssh, the
+remote shell runs the arguments joined with spaces.
+
+Example:
+  gcectl on build-box --wait-ssh
+  gcectl exec build-box -- make test
+  gcectl exec private-vm --iap -- sudo journalctl -u app
+  gcectl exec build-box -t -- htop`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if cmd.ArgsLenAtDash() != 1 || len(args) < 2 {
+			return errors.New("requires a VM name, then -- and the command to run")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		vmName, command := args[0], args[1:]
+
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(vmName)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if err = session.OpenVMRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		target, err := resolveSSHTarget(ctx, session, vm)
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to resolve %s: %v", vm.Name, err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		err = ssh.Exec(ctx, target, command, execTTY)
+		var exitErr *ssh.ExitError
+		if errors.As(err, &exitErr) {
+			session.Close()
+			os.Exit(exitErr.Code)
+		}
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(execCmd)
+	addSSHTargetFlags(execCmd)
+	execCmd.Flags().BoolVarP(&execTTY, "tty", "t", false, "Allocate a terminal, for interactive commands")
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/haru-256/gcectl/internal/infrastructure/config"
+	"github.com/haru-256/gcectl/internal/infrastructure/fakecompute"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// fakeComputeAddress is the local address the fake API listens on
+	fakeComputeAddress string
+)
+
+// fakeComputeCmd represents the fake-compute command
+var fakeComputeCmd = &cobra.Command{
+	Use:   "fake-compute",
+	Short: "Serve an in-memory fake of the Compute Engine API to try gcectl against",
+	Long: `Serve an in-memory fake of the Compute Engine API on a local port until
+interrupted. Point gcectl at it with --endpoint to try the commands without
+a GCP project or credentials; nothing leaves the machine.
+
+The fake holds the VMs of the config file, stopped and in their configured
+zones, and forgets every change when it exits. Operations finish immediately.
+Instances, operations and schedule policies are implemented; other commands fail
+with "not implemented by the fake Compute API".
+
+Example:
+  gcectl fake-compute --address localhost:8080
+  gcectl list --endpoint http://localhost:8080   # in another terminal
+  gcectl on my-vm --endpoint http://localhost:8080`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		cfg, err := config.NewConfig(CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		fake := fakecompute.New()
+		seeded := seedFakeCompute(fake, cfg)
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		ln, err := net.Listen("tcp", fakeComputeAddress)
+		if err != nil {
+			console.Error(fmt.Sprintf("failed to listen on %s: %v", fakeComputeAddress, err))
+			stop()
+			os.Exit(cli.ExitFailure)
+		}
+		console.Success(fmt.Sprintf("Serving a fake Compute Engine API with %d VMs at http://%s, press Ctrl-C to stop", seeded, ln.Addr()))
+		if err := serveFakeCompute(ctx, ln, fake); err != nil {
+			console.Error(err.Error())
+			stop()
+			os.Exit(cli.ExitFailure)
+		}
+	},
+}
+
+// seedFakeCompute adds the VMs of cfg to fake as stopped instances and returns
+// how many it added. VMs without a zone go to the default zone, or are skipped
+// when there is none.
+func seedFakeCompute(fake *fakecompute.Server, cfg *config.Config) int {
+	seeded := 0
+	for _, vm := range cfg.VMs {
+		zone := vm.Zone
+		if zone == "" {
+			zone = cfg.DefaultZone
+		}
+		if vm.Project == "" || zone == "" {
+			infraLog.DefaultLogger.Warnf("Skipping %s: the fake needs its project and zone", vm.Name)
+			continue
+		}
+		fake.AddInstance(vm.Project, zone, &computepb.Instance{Name: proto.String(vm.Name)})
+		seeded++
+	}
+	return seeded
+}
+
+// serveFakeCompute serves fake on ln until ctx is done.
+func serveFakeCompute(ctx context.Context, ln net.Listener, fake http.Handler) error {
+	server := &http.Server{Handler: fake, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	if err := server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("fake Compute API stopped: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(fakeComputeCmd)
+	fakeComputeCmd.Flags().StringVar(&fakeComputeAddress, "address", "localhost:8080", "Local address to listen on")
+}
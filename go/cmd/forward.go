@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+// forwardLocalPort is the local port to listen on
+var forwardLocalPort int
+
+// forwardCmd represents the forward command
+var forwardCmd = &cobra.Command{
+	Use:   "forward <vm_name> <forward_name>",
+	Short: "Start the instance if needed and tunnel to a named port of it",
+	Long: `Start the instance unless it is running, then forward a local port to one of
+the ports named under forwards: of the VM in config.yaml through an
+Identity-Aware Proxy tunnel, like tunnel, and print the local URL.
+
+The local port defaults to the instance port; --local-port 0 picks a free one.
+
+config.yaml:
+  vm:
+    - name: my-vm
+      forwards:
+        jupyter: 8888
+        tensorboard: 6006
+
+Example:
+  gcectl forward my-vm jupyter
+  gcectl forward my-vm tensorboard --local-port 16006`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		vmName, forwardName := args[0], args[1]
+
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(vmName)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		forwards := session.Config.ForwardsFor(vm.Name)
+		port, ok := forwards[forwardName]
+		if !ok {
+			msg := fmt.Sprintf("VM %s has no forward named %s", vm.Name, forwardName)
+			if len(forwards) == 0 {
+				msg += "; add a forwards: block to the VM in config.yaml"
+			} else {
+				names := make([]string, 0, len(forwards))
+				for name := range forwards {
+					names = append(names, name)
+				}
+				slices.Sort(names)
+				msg += fmt.Sprintf(" (configured: %s)", strings.Join(names, ", "))
+			}
+			console.Error(msg)
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+		localPort := port
+		if cmd.Flags().Changed("local-port") {
+			localPort = forwardLocalPort
+		}
+
+		if err = session.OpenVMRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if err = ensureRunning(ctx, console, session, vm); err != nil {
+			console.ErrorWithHint(withCapabilityHint(fmt.Sprintf("Failed to start %s: %v", vm.Name, err), err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		err = serveTunnel(ctx, cmd, session, vm, port, "localhost", localPort, func(addr net.Addr) {
+			console.Success(fmt.Sprintf("%s on %s: http://%s (press Ctrl-C to stop)", forwardName, vm.Name, addr))
+		})
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(forwardCmd)
+	forwardCmd.Flags().IntVar(&forwardLocalPort, "local-port", 0, "Local port to listen on (default: the instance port)")
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+var (
+	// genDocsMan generates man pages
+	genDocsMan bool
+	// genDocsMarkdown generates Markdown pages
+	genDocsMarkdown bool
+)
+
+// genDocsCmd represents the gen-docs command
+var genDocsCmd = &cobra.Command{
+	Use:    "gen-docs <dir> [--man] [--markdown]",
+	Short:  "Generate man pages and a Markdown command reference",
+	Hidden: true,
+	Long: `Generate a page per command from the built-in help: man pages (section 1)
+in <dir>/man and a Markdown command reference in <dir>/markdown. Pages of
+hidden commands, like this one, are not generated.
+
+The pages carry no generation time and man pages are dated with the build date
+of gcectl, so regenerating them with the same binary gives the same files.
+
+Example:
+  gcectl gen-docs --man --markdown ./docs
+  gcectl gen-docs --man /usr/share/gcectl   # then install man/*.1 into man1`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		if !genDocsMan && !genDocsMarkdown {
+			console.Error("specify --man, --markdown or both")
+			os.Exit(cli.ExitFailure)
+		}
+
+		if err := generateDocs(rootCmd, args[0], genDocsMan, genDocsMarkdown); err != nil {
+			console.Error(err.Error())
+			os.Exit(cli.ExitFailure)
+		}
+		console.Success(fmt.Sprintf("Generated the command reference in %s", args[0]))
+	},
+}
+
+// generateDocs writes the pages of root and its visible subcommands under dir,
+// man pages in dir/man and Markdown in dir/markdown.
+func generateDocs(root *cobra.Command, dir string, man, markdown bool) error {
+	root.DisableAutoGenTag = true
+	if man {
+		manDir := filepath.Join(dir, "man")
+		if err := os.MkdirAll(manDir, 0o755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", manDir, err)
+		}
+		header := &doc.GenManHeader{Title: "GCECTL", Section: "1", Source: "gcectl " + appVersion, Manual: "gcectl Manual"}
+		if built, err := time.Parse(time.RFC3339, appDate); err == nil {
+			header.Date = &built
+		}
+		restore := escapeForMan(root)
+		err := doc.GenManTree(root, header, manDir)
+		restore()
+		if err != nil {
+			return fmt.Errorf("failed to generate man pages: %w", err)
+		}
+		if err := unescapeManPages(manDir); err != nil {
+			return err
+		}
+	}
+	if markdown {
+		markdownDir := filepath.Join(dir, "markdown")
+		if err := os.MkdirAll(markdownDir, 0o755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", markdownDir, err)
+		}
+		if err := doc.GenMarkdownTree(root, markdownDir); err != nil {
+			return fmt.Errorf("failed to generate Markdown pages: %w", err)
+		}
+	}
+	return nil
+}
+
+// escapeForMan escapes "<" in the usage, help and examples of root and its
+// subcommands, which the Markdown to man conversion would otherwise drop as
+// HTML tags together with placeholders such as <vm_name>. The returned
+// function restores the commands. unescapeManPages undoes the escaping in the
+// verbatim parts of the generated pages.
+func escapeForMan(root *cobra.Command) func() {
+	type saved struct{ use, long, example string }
+	originals := make(map[*cobra.Command]saved)
+	var walk func(*cobra.Command)
+	walk = func(c *cobra.Command) {
+		originals[c] = saved{c.Use, c.Long, c.Example}
+		c.Use = strings.ReplaceAll(c.Use, "<", "&lt;")
+		c.Long = strings.ReplaceAll(c.Long, "<", "&lt;")
+		c.Example = strings.ReplaceAll(c.Example, "<", "&lt;")
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+	}
+	walk(root)
+	return func() {
+		for c, o := range originals {
+			c.Use, c.Long, c.Example = o.use, o.long, o.example
+		}
+	}
+}
+
+// unescapeManPages replaces the "&lt;" kept verbatim in the man pages in dir with "<".
+func unescapeManPages(dir string) error {
+	pages, err := filepath.Glob(filepath.Join(dir, "*.1"))
+	if err != nil {
+		return err
+	}
+	for _, page := range pages {
+		data, readErr := os.ReadFile(page)
+		if readErr != nil {
+			return fmt.Errorf("failed to read %s: %w", page, readErr)
+		}
+		unescaped := strings.ReplaceAll(string(data), "&lt;", "<")
+		if writeErr := os.WriteFile(page, []byte(unescaped), 0o644); writeErr != nil {
+			return fmt.Errorf("failed to write %s: %w", page, writeErr)
+		}
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(genDocsCmd)
+	genDocsCmd.Flags().BoolVar(&genDocsMan, "man", false, "Generate man pages in <dir>/man")
+	genDocsCmd.Flags().BoolVar(&genDocsMarkdown, "markdown", false, "Generate Markdown pages in <dir>/markdown")
+}
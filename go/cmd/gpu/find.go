@@ -0,0 +1,117 @@
+package gpu
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var (
+	findType    string
+	findRegion  string
+	findProject string
+	findCount   int
+	findSpot    bool
+)
+
+var findCmd = &cobra.Command{
+	Use:   "find",
+	Short: "Show the zones of a region where a GPU type is likely obtainable",
+	Long: `Show the zones of a region that offer accelerator types matching --type,
+with the project's regional quota for each. A zone is marked likely when a VM
+with --count cards fits both the type's per-VM maximum and the free quota; with
+--spot the quota of Spot (preemptible) GPUs is checked instead.
+
+GCE does not publish spare capacity, so a likely zone can still be out of
+resources; list several of them as fallback-zones of the VM in config.yaml.
+
+The region defaults to the region of default-zone and the project to
+default-project from config.yaml.
+
+Example:
+  gcectl gpu find --type a100 --region us-central1
+  gcectl gpu find --type t4 --count 2 --spot`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		project := findProject
+		if project == "" {
+			project = session.Config.DefaultProject
+		}
+		region := findRegion
+		if region == "" {
+			region = model.RegionOfZone(session.Config.DefaultZone)
+		}
+		if project == "" || region == "" {
+			console.Error("--project and --region are required when config.yaml has no default-project or default-zone")
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+
+		if err = session.OpenRegionRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if err = session.OpenAcceleratorTypeRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		findUseCase := usecase.NewFindGPUUseCase(session.RegionRepository, session.AcceleratorTypeRepository, infraLog.DefaultLogger)
+		found, err := findUseCase.Execute(ctx, project, region, findType, findCount, findSpot)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if len(found) == 0 {
+			console.Error(fmt.Sprintf("No zone of %s offers a GPU matching %q", region, findType))
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+
+		rows := make([]presenter.GPUAvailabilityRow, len(found))
+		for i, f := range found {
+			rows[i] = presenter.GPUAvailabilityRow{
+				Zone:     f.Type.Zone,
+				Type:     f.Type.Name,
+				MaxCards: f.Type.MaxCardsPerInstance,
+				Quota:    f.Quota,
+				Likely:   f.Likely,
+			}
+		}
+		console.RenderGPUAvailability(rows)
+	},
+}
+
+func init() {
+	GPUCmd.AddCommand(findCmd)
+	findCmd.Flags().StringVar(&findType, "type", "", "Part of the accelerator type name to look for (e.g. a100, t4)")
+	findCmd.Flags().StringVar(&findRegion, "region", "", "Region to search (default: region of default-zone)")
+	findCmd.Flags().StringVar(&findProject, "project", "", "Project whose quota is checked (default: default-project)")
+	findCmd.Flags().IntVar(&findCount, "count", 1, "Number of cards one VM needs")
+	findCmd.Flags().BoolVar(&findSpot, "spot", false, "Check the quota of Spot (preemptible) GPUs")
+	_ = findCmd.MarkFlagRequired("type")
+}
@@ -0,0 +1,28 @@
+package gpu
+
+import (
+	"os"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+var GPUCmd = &cobra.Command{
+	Use:   "gpu <command>",
+	Short: "Find where GPUs can be obtained",
+	Long: `Find the zones where a GPU type is offered and the project's quota leaves
+room for it.
+
+Example:
+  gcectl gpu find --type a100 --region us-central1`,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		infraLog.DefaultLogger.Debugf("run gpu command")
+		if err := cmd.Help(); err != nil {
+			console.Error("Failed to run help command")
+			os.Exit(cli.ExitFailure)
+		}
+	},
+}
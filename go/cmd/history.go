@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// historyLimit is the maximum number of operations to show
+var historyLimit int
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history [vm_name...]",
+	Short: "Show the operations gcectl started",
+	Long: `Show the operations gcectl started and waited for, newest first, with who ran
+them (the GCP principal and the local user@host), the command, the result and
+how long they took; of the given VMs, or of all when none are given.
+
+Operations are recorded in ~/.local/state/gcectl/operations.log, or in the file
+set as operation-history in config.yaml, e.g. on a drive shared by a team:
+
+config.yaml:
+  operation-history: /mnt/team/gcectl/operations.log
+
+Unlike operations list, the history is kept after GCE drops old operations, but
+only covers operations started by gcectl.
+
+Example:
+  gcectl history
+  gcectl history my-vm --limit 50`,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		session, _, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		history, err := cli.OpenOperationHistory(session.Config)
+		if err != nil {
+			console.Error(err.Error())
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+		records, err := usecase.NewListOperationHistoryUseCase(history).Execute(args, historyLimit)
+		if err != nil {
+			console.Error(err.Error())
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+		if len(records) == 0 {
+			console.Success("No operations recorded yet")
+			return
+		}
+		console.RenderOperationHistory(records)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 20, "Maximum number of operations (0 for all)")
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var (
+	idleWindow     time.Duration
+	idleCPUPercent float64
+	idleNetworkKiB float64
+	idleStop       bool
+)
+
+// idleCmd represents the idle command
+var idleCmd = &cobra.Command{
+	Use:   "idle",
+	Short: "Find running VMs that look idle",
+	Long: `Find running VMs in settings whose average CPU utilization and network
+traffic (received plus sent) over --window stayed below the thresholds, using
+Cloud Monitoring metrics. Idle VMs are listed most expensive first, with their
+estimated hourly cost from the hourly-cost table in config.yaml.
+
+With --stop, the idle VMs are stopped after confirmation.
+
+Example:
+  gcectl idle
+  gcectl idle --window 3h --cpu 2 --network 5
+  gcectl idle --stop`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		err = session.OpenVMRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		err = session.OpenMetricsRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		thresholds := usecase.IdleThresholds{
+			CPU:                idleCPUPercent / 100,
+			NetworkBytesPerSec: idleNetworkKiB * 1024,
+		}
+		findIdleUseCase := usecase.NewFindIdleVMsUseCase(session.VMRepository, session.MetricsRepository, infraLog.DefaultLogger)
+		report, err := findIdleUseCase.Execute(ctx, session.Config.VMs, idleWindow, thresholds, session.Config.HourlyCost)
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to find idle VMs: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		rows := make([]presenter.VMMetricsRow, len(report.VMs))
+		vms := make([]*model.VM, len(report.VMs))
+		vmNames := make([]string, len(report.VMs))
+		for i, r := range report.VMs {
+			rows[i] = presenter.VMMetricsRow{
+				Name:        r.VM.Name,
+				Zone:        r.VM.Zone,
+				MachineType: r.VM.MachineType,
+				Metrics:     r.Metrics,
+			}
+			vms[i] = r.VM
+			vmNames[i] = r.VM.Name
+		}
+		console.RenderIdleVMs(presenter.IdleSummary{
+			VMs:                  rows,
+			UnpricedMachineTypes: report.UnpricedMachineTypes,
+			HourlyCost:           report.HourlyCost,
+			Window:               idleWindow,
+		})
+
+		if !idleStop || len(vms) == 0 {
+			return
+		}
+
+		answer, err := console.Prompt(fmt.Sprintf("Stop %s? [y/N]:", strings.Join(vmNames, ", ")))
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to read confirmation: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if !strings.EqualFold(answer, "y") && !strings.EqualFold(answer, "yes") {
+			console.Success("Canceled; no VMs were stopped")
+			return
+		}
+
+		stopVMUseCase := usecase.NewStopVMUseCase(session.VMRepository, infraLog.DefaultLogger).
+			WithMaxConcurrency(session.Config.MaxConcurrency)
+		err = console.ExecuteWithProgress(
+			ctx,
+			fmt.Sprintf("Stopping VMs %s", strings.Join(vmNames, ", ")),
+			func(ctx context.Context) error {
+				return stopVMUseCase.Execute(ctx, vms)
+			},
+		)
+		if err != nil {
+			session.Notify(operationEvents(model.EventOperationFailure, vmNames, fmt.Sprintf("failed to stop idle VM: %v", err))...)
+			console.ErrorWithHint(withCapabilityHint(fmt.Sprintf("Failed to turn off the instance(s): %v", err), err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		session.Notify(operationEvents(model.EventOperationSuccess, vmNames, "stopped (idle)")...)
+		console.Success(fmt.Sprintf("Turned off the idle instances: %v", strings.Join(vmNames, ", ")))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(idleCmd)
+	idleCmd.Flags().DurationVar(&idleWindow, "window", time.Hour, "Time window to average metrics over")
+	idleCmd.Flags().Float64Var(&idleCPUPercent, "cpu", 5, "CPU utilization (%) below which a VM is idle")
+	idleCmd.Flags().Float64Var(&idleNetworkKiB, "network", 10, "Network traffic (KiB/s, received plus sent) below which a VM is idle")
+	idleCmd.Flags().BoolVar(&idleStop, "stop", false, "Stop the idle VMs after confirmation")
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/haru-256/gcectl/internal/infrastructure/clipboard"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ipInternal bool
+	ipCopy     bool
+)
+
+// ipCmd represents the ip command
+var ipCmd = &cobra.Command{
+	Use:   "ip <vm_name>",
+	Short: "Print the IP address of the instance",
+	Long: `Print the external (or internal) IP address of the instance.
+
+Only the address is printed, so the output can be used in scripts.
+
+Example:
+  gcectl ip <vm_name>
+  gcectl ip <vm_name> --internal
+  gcectl ip <vm_name> --copy
+  ssh user@$(gcectl ip <vm_name>)`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		vmName := args[0]
+		infraLog.DefaultLogger.Debugf("Get IP of instance %s (internal=%t)", vmName, ipInternal)
+
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(vmName)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		err = session.OpenVMRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		getVMIPUseCase := usecase.NewGetVMIPUseCase(session.VMRepository)
+
+		ip, err := getVMIPUseCase.Execute(ctx, vm.Project, vm.Zone, vm.Name, ipInternal)
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to get IP: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		if !ipCopy {
+			console.RenderIP(ip)
+			return
+		}
+
+		if copyErr := clipboard.Copy(ip); copyErr != nil {
+			console.ErrorWithHint(copyErr.Error(), copyErr)
+			session.Close()
+			os.Exit(cli.ExitCode(copyErr))
+		}
+		console.Success(fmt.Sprintf("Copied %s to clipboard", ip))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(ipCmd)
+	ipCmd.Flags().BoolVar(&ipInternal, "internal", false, "Print the internal IP instead of the external IP")
+	ipCmd.Flags().BoolVar(&ipCopy, "copy", false, "Copy the IP to the system clipboard")
+}
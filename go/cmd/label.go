@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var labelRemove []string
+
+// labelCmd represents the label command
+var labelCmd = &cobra.Command{
+	Use:   "label <vm_name> [key=value...]",
+	Short: "Set or remove labels of a VM",
+	Long: `Set or remove labels of a VM.
+
+Labels given as key=value are added or overwritten; labels named with --remove are
+removed. All other labels are kept. The current labels are shown by describe.
+
+Example:
+  gcectl label <vm_name> team=ml env=dev
+  gcectl label <vm_name> --remove owner
+  gcectl label <vm_name> env=prod --remove tmp --remove owner`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		vmName := args[0]
+		infraLog.DefaultLogger.Debugf("Update labels of instance %s", vmName)
+
+		set, err := parseLabelAssignments(args[1:])
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(vmName)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		if err = session.OpenVMRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		updateLabelsUseCase := usecase.NewUpdateLabelsUseCase(session.VMRepository, infraLog.DefaultLogger)
+		labels, err := updateLabelsUseCase.Execute(ctx, vm.Project, vm.Zone, vm.Name, set, labelRemove)
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to update labels: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		if len(labels) == 0 {
+			console.Success(fmt.Sprintf("Updated labels of %s, it has no labels now", vm.Name))
+			return
+		}
+		console.Success(fmt.Sprintf("Updated labels of %s: %s", vm.Name, model.FormatLabels(labels)))
+	},
+}
+
+// parseLabelAssignments parses "key=value" arguments into a label map.
+// A value may be empty ("key="), but the "=" is required.
+func parseLabelAssignments(args []string) (map[string]string, error) {
+	labels := make(map[string]string, len(args))
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid label %q: expected key=value", arg)
+		}
+		if _, dup := labels[key]; dup {
+			return nil, fmt.Errorf("label %s is given more than once", key)
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
+
+func init() {
+	rootCmd.AddCommand(labelCmd)
+	labelCmd.Flags().StringSliceVar(&labelRemove, "remove", nil, "Label key to remove (repeatable or comma-separated)")
+}
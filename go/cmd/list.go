@@ -3,66 +3,245 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"os/exec"
 
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/cache"
 	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/infrastructure/scheduler"
 	"github.com/haru-256/gcectl/internal/interface/cli"
 	"github.com/haru-256/gcectl/internal/interface/presenter"
 	"github.com/haru-256/gcectl/internal/usecase"
 	"github.com/spf13/cobra"
 )
 
+var (
+	listCached  bool
+	listRefresh bool
+	// listOutput is the --output format: table, csv or tsv
+	listOutput string
+	// listLongRunning shows only VMs running past the uptime-warning threshold
+	listLongRunning bool
+	// outputFormat and outputQuery replace the table of list and describe
+	outputFormat string
+	outputQuery  string
+)
+
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all VM in settings",
 	Long: `List all VM in settings.
 
+When stdout is a terminal, the table is drawn immediately with the VM names
+from the config and each row is filled in as soon as its details are fetched.
+
+Every live listing is saved to a local cache (~/.cache/gcectl/state.json).
+With --cached the table is served from that cache instantly; entries older than
+cache-ttl (default 5m) are refreshed in the background for the next run.
+--refresh forces live data even when --cached is set.
+
+The Uptime of a VM running longer than uptime-warning (default 72h) is shown in
+yellow, and in red past twice that. --long-running lists only those VMs.
+
+--format prints each VM with a Go template instead of the table, and --query
+prints the fields a jq-like path selects from the list of VMs. --output csv or
+--output tsv prints a header row plus one row per VM for spreadsheets.
+
 Example:
-  gcectl list`,
+  gcectl list
+  gcectl list --cached
+  gcectl list --cached --refresh
+  gcectl list --long-running
+  gcectl list --format '{{.Name}} {{.Status}}'
+  gcectl list --query '.[].Name'
+  gcectl list --output csv > vms.csv`,
 	Run: func(cmd *cobra.Command, args []string) {
 		console := presenter.NewConsolePresenter()
+		if _, ok := listDelimiters[listOutput]; !ok && listOutput != "table" {
+			console.Error(fmt.Sprintf("invalid --output %q: must be table, csv or tsv", listOutput))
+			os.Exit(cli.ExitFailure)
+		}
 		session, ctx, err := cli.NewSession(cmd, CnfPath)
 		if err != nil {
-			console.Error(err.Error())
-			os.Exit(1)
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
 		}
 		defer session.Close()
 
 		err = session.OpenVMRepository(ctx)
 		if err != nil {
-			console.Error(err.Error())
+			console.ErrorWithHint(err.Error(), err)
 			session.Close()
-			os.Exit(1)
+			os.Exit(cli.ExitCode(err))
 		}
 
-		listVMsUC := usecase.NewListVMsUseCase(session.VMRepository)
-
-		items, err := listVMsUC.Execute(ctx, session.Config.VMs)
-		infraLog.DefaultLogger.Debugf("Found %d VMs", len(items))
+		listVMsUC := usecase.NewListVMsUseCase(session.VMRepository).
+			WithMaxConcurrency(session.Config.MaxConcurrency).
+			WithUptimeWarning(session.Config.UptimeWarning)
+		if cachePath, pathErr := cache.DefaultPath(); pathErr == nil {
+			listVMsUC.WithCache(cache.Open(cachePath))
+		} else {
+			infraLog.DefaultLogger.Debugf("VM state cache disabled: %v", pathErr)
+		}
+		if store, storeErr := scheduler.OpenDefault(); storeErr == nil {
+			listVMsUC.WithScheduledActions(store)
+		} else {
+			infraLog.DefaultLogger.Debugf("Pending stops not shown: %v", storeErr)
+		}
 
-		presenterItems := make([]presenter.VMListItem, len(items))
-		for i, item := range items {
-			presenterItems[i] = presenter.VMListItem{
-				Name:           item.VM.Name,
-				Project:        item.VM.Project,
-				Zone:           item.VM.Zone,
-				MachineType:    item.VM.MachineType,
-				Status:         item.VM.Status,
-				SchedulePolicy: item.VM.SchedulePolicy,
-				Uptime:         item.Uptime,
+		if listCached && !listRefresh {
+			items, stale, cachedErr := listVMsUC.ExecuteCached(ctx, session.Config.VMs, session.Config.CacheTTL)
+			renderListItems(console, session, items)
+			if stale {
+				refreshCacheInBackground(cmd)
+			}
+			if cachedErr != nil {
+				console.ErrorWithHint(fmt.Sprintf("Failed to list some VMs: %v", cachedErr), cachedErr)
+				session.Close()
+				os.Exit(cli.ExitCode(cachedErr))
 			}
+			return
 		}
 
-		if len(presenterItems) > 0 {
-			console.RenderVMList(presenterItems)
+		var items []usecase.VMListItem
+		if console.IsInteractive() && len(session.Config.VMs) > 0 && !listLongRunning && listOutput == "table" && outputFormat == "" && outputQuery == "" {
+			skeleton := make([]presenter.VMListItem, len(session.Config.VMs))
+			for i, vm := range session.Config.VMs {
+				skeleton[i] = presenter.VMListItem{Name: vm.Name, Project: vm.Project, Zone: vm.Zone, Loading: true}
+			}
+			live := console.NewLiveVMList(skeleton)
+
+			items, err = listVMsUC.Stream(ctx, session.Config.VMs, func(i int, item usecase.VMListItem, itemErr error) {
+				if itemErr != nil {
+					failed := skeleton[i]
+					failed.Loading = false
+					failed.Failed = true
+					live.Update(i, failed)
+					return
+				}
+				live.Update(i, toPresenterListItem(item))
+			})
+		} else {
+			items, err = listVMsUC.Execute(ctx, session.Config.VMs)
+			renderListItems(console, session, items)
 		}
+		infraLog.DefaultLogger.Debugf("Found %d VMs", len(items))
+
 		if err != nil {
-			console.Error(fmt.Sprintf("Failed to list some VMs: %v", err))
+			console.ErrorWithHint(fmt.Sprintf("Failed to list some VMs: %v", err), err)
 			session.Close()
-			os.Exit(1)
+			os.Exit(cli.ExitCode(err))
 		}
 	},
 }
 
+// listDelimiters are the field delimiters of the delimited --output formats of list.
+var listDelimiters = map[string]rune{"csv": ',', "tsv": '\t'}
+
+// renderListItems prints items as a table, or nothing if there are none, or
+// with --output, --format or --query when set. With --long-running only the VMs
+// past the uptime-warning threshold are printed.
+func renderListItems(console *presenter.ConsolePresenter, session *cli.Session, items []usecase.VMListItem) {
+	if listLongRunning {
+		items = usecase.LongRunning(items)
+	}
+	presenterItems := make([]presenter.VMListItem, len(items))
+	for i, item := range items {
+		presenterItems[i] = toPresenterListItem(item)
+	}
+	if outputFormat != "" || outputQuery != "" {
+		renderFormatted(console, session, presenterItems, presenterItems)
+		return
+	}
+	if comma, ok := listDelimiters[listOutput]; ok {
+		if err := presenter.WriteVMListCSV(os.Stdout, presenterItems, comma); err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to write %s: %v", listOutput, err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		return
+	}
+	if len(presenterItems) > 0 {
+		console.RenderVMList(presenterItems)
+	}
+}
+
+// renderFormatted prints items with --format, or data with --query, and exits
+// when either is invalid.
+func renderFormatted(console *presenter.ConsolePresenter, session *cli.Session, items []presenter.VMListItem, data any) {
+	var err error
+	if outputFormat != "" {
+		err = console.RenderTemplate(outputFormat, items)
+	} else {
+		err = console.RenderQuery(outputQuery, data)
+	}
+	if err != nil {
+		console.ErrorWithHint(err.Error(), err)
+		session.Close()
+		os.Exit(cli.ExitCode(err))
+	}
+}
+
+// addOutputFlags adds --format and --query to cmd.
+func addOutputFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&outputFormat, "format", "", "print each VM with a Go template instead of the table, e.g. '{{.Name}} {{.Status}}'")
+	cmd.Flags().StringVar(&outputQuery, "query", "", "print the values a jq-like path selects, e.g. '.[].Name' for list or '.Labels.env' for describe")
+	cmd.MarkFlagsMutuallyExclusive("format", "query")
+}
+
+// refreshCacheInBackground starts a detached `gcectl list --refresh` whose output is
+// discarded, so stale cache entries are updated without delaying the current command.
+func refreshCacheInBackground(cmd *cobra.Command) {
+	exe, err := os.Executable()
+	if err != nil {
+		infraLog.DefaultLogger.Debugf("Skipping background cache refresh: %v", err)
+		return
+	}
+	args := []string{"list", "--refresh", "--config", configArg()}
+	// The refresh talks to GCP like this run did, e.g. with its
+	// --credentials-file, --endpoint or --replay.
+	args = append(args, cli.GlobalFlagArgs(cmd, "config")...)
+	refresh := exec.Command(exe, args...)
+	if startErr := refresh.Start(); startErr != nil {
+		infraLog.DefaultLogger.Debugf("Skipping background cache refresh: %v", startErr)
+		return
+	}
+	_ = refresh.Process.Release()
+}
+
+// toPresenterListItem converts a use case list item into a presenter row.
+func toPresenterListItem(item usecase.VMListItem) presenter.VMListItem {
+	return presenter.VMListItem{
+		Name:             item.VM.Name,
+		Project:          item.VM.Project,
+		Zone:             item.VM.Zone,
+		MachineType:      item.VM.MachineType,
+		Status:           item.VM.Status,
+		SchedulePolicy:   item.VM.SchedulePolicy,
+		ScheduleTimeZone: scheduleTimeZone(item.VM),
+		Uptime:           item.Uptime,
+		StoppedFor:       item.StoppedFor,
+		NextSchedule:     item.NextSchedule,
+		UptimeLevel:      item.UptimeLevel,
+		Maintenance:      formatTimestamp(item.VM.UpcomingMaintenance),
+	}
+}
+
+// scheduleTimeZone returns the time zone of the VM's schedule policy, or "" if it has none.
+func scheduleTimeZone(vm *model.VM) string {
+	if vm.Schedule == nil {
+		return ""
+	}
+	return vm.Schedule.TimeZone
+}
+
 func init() {
+	listCmd.Flags().BoolVar(&listCached, "cached", false, "serve the list from the local cache and refresh stale entries in the background")
+	listCmd.Flags().BoolVar(&listRefresh, "refresh", false, "force live data from the API, ignoring the cache")
+	listCmd.Flags().BoolVar(&listLongRunning, "long-running", false, "show only VMs running longer than uptime-warning (default 72h)")
+	listCmd.Flags().StringVarP(&listOutput, "output", "o", "table", "output format: table, csv or tsv")
+	addOutputFlags(listCmd)
+	listCmd.MarkFlagsMutuallyExclusive("output", "format")
+	listCmd.MarkFlagsMutuallyExclusive("output", "query")
 	rootCmd.AddCommand(listCmd)
 }
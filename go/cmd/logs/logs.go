@@ -0,0 +1,99 @@
+package logs
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var LogsCmd = &cobra.Command{
+	Use:   "logs [vm_name]",
+	Short: "Show the logs of a VM",
+	Long: `Show the Cloud Logging entries of a configured VM, oldest first.
+
+Entries are looked up by the VM's instance ID and include startup-script, syslog
+and application logs shipped by the Ops Agent, as well as audit logs. Use --filter
+to narrow them down with the Cloud Logging query language.
+
+Use "gcectl logs serial" for the serial port output, which is available even when
+the VM does not boot far enough to ship logs.
+
+Example:
+  gcectl logs sandbox
+  gcectl logs sandbox --since 24h --filter 'severity>=ERROR'
+  gcectl logs serial sandbox --follow`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		if len(args) == 0 {
+			infraLog.DefaultLogger.Debugf("run logs command")
+			if err := cmd.Help(); err != nil {
+				console.Error("Failed to run help command")
+				os.Exit(cli.ExitFailure)
+			}
+			return
+		}
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(args[0])
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		if err = session.OpenVMRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if err = session.OpenLogRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		listLogsUseCase := usecase.NewListLogsUseCase(session.VMRepository, session.LogRepository, infraLog.DefaultLogger)
+		entries, err := listLogsUseCase.Execute(ctx, vm.Project, vm.Zone, vm.Name, logsSince, logsFilter, logsLimit)
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to get logs: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if len(entries) == 0 {
+			console.Success(fmt.Sprintf("No log entries for %s in the last %s", vm.Name, logsSince))
+			return
+		}
+		console.RenderLogEntries(entries)
+	},
+}
+
+var (
+	logsSince  time.Duration
+	logsFilter string
+	logsLimit  int
+)
+
+func init() {
+	LogsCmd.Flags().DurationVar(&logsSince, "since", time.Hour, "Show entries written within this duration (e.g. 30m, 24h)")
+	LogsCmd.Flags().StringVar(&logsFilter, "filter", "", `Additional Cloud Logging filter (e.g. 'severity>=ERROR')`)
+	LogsCmd.Flags().IntVar(&logsLimit, "limit", 100, "Maximum number of entries to show (the most recent ones)")
+}
@@ -0,0 +1,75 @@
+package logs
+
+import (
+	"fmt"
+	"os"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var serialCmd = &cobra.Command{
+	Use:   "serial <vm_name>",
+	Short: "Print the serial port output of a VM",
+	Long: `Print the serial port output of a VM, e.g. to debug a VM that does not boot
+after a machine type or GPU change.
+
+The VM keeps the last 1 MB of output. With --follow, new output is printed as it
+arrives until interrupted with Ctrl-C.
+
+Example:
+  gcectl logs serial sandbox
+  gcectl logs serial sandbox --follow
+  gcectl logs serial sandbox --port 2`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(args[0])
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		if err = session.OpenSerialPortRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		readUseCase := usecase.NewReadSerialOutputUseCase(session.SerialPortRepository, infraLog.DefaultLogger)
+		if err = readUseCase.Execute(ctx, vm.Project, vm.Zone, vm.Name, serialPort, serialFollow, console.RenderText); err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to get serial port output: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+	},
+}
+
+var (
+	serialFollow bool
+	serialPort   int32
+)
+
+func init() {
+	LogsCmd.AddCommand(serialCmd)
+	serialCmd.Flags().BoolVarP(&serialFollow, "follow", "f", false, "Keep printing new output until interrupted")
+	serialCmd.Flags().Int32Var(&serialPort, "port", 1, "Serial port number (1-4)")
+}
@@ -0,0 +1,69 @@
+package metadata
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var getCmd = &cobra.Command{
+	Use:   "get <vm_name> <key>",
+	Short: "Print a metadata item of a VM",
+	Long: `Print the value of a metadata item of a VM without styling, so it can be piped.
+
+Example:
+  gcectl metadata get sandbox enable-oslogin
+  gcectl metadata get sandbox startup-script > startup.sh`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(args[0])
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		if err = session.OpenVMRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		metadataUseCase := usecase.NewMetadataUseCase(session.VMRepository, infraLog.DefaultLogger)
+		value, err := metadataUseCase.Get(ctx, vm.Project, vm.Zone, vm.Name, args[1])
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to get metadata: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if !strings.HasSuffix(value, "\n") {
+			value += "\n"
+		}
+		console.RenderText(value)
+	},
+}
+
+func init() {
+	MetadataCmd.AddCommand(getCmd)
+}
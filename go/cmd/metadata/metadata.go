@@ -0,0 +1,51 @@
+package metadata
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+var MetadataCmd = &cobra.Command{
+	Use:   "metadata <command>",
+	Short: "Manage the metadata of a VM",
+	Long: `Get, set and remove metadata items of a configured VM, and set its startup script.
+
+Only the items named are changed; all other items are kept.
+
+Example:
+  gcectl metadata get sandbox enable-oslogin
+  gcectl metadata set sandbox enable-oslogin=TRUE
+  gcectl metadata remove sandbox enable-oslogin
+  gcectl metadata set-startup-script sandbox --file startup.sh`,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		infraLog.DefaultLogger.Debugf("run metadata command")
+		if err := cmd.Help(); err != nil {
+			console.Error("Failed to run help command")
+			os.Exit(cli.ExitFailure)
+		}
+	},
+}
+
+// parseItems parses "key=value" arguments into metadata items.
+// Values may be empty or contain "=", but the first "=" is required.
+func parseItems(args []string) (map[string]string, error) {
+	items := make(map[string]string, len(args))
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid metadata %q: expected key=value", arg)
+		}
+		if _, dup := items[key]; dup {
+			return nil, fmt.Errorf("metadata %s is given more than once", key)
+		}
+		items[key] = value
+	}
+	return items, nil
+}
@@ -0,0 +1,70 @@
+package metadata
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var removeCmd = &cobra.Command{
+	Use:   "remove <vm_name> <key...>",
+	Short: "Remove metadata items of a VM",
+	Long: `Remove metadata items of a VM. All other items are kept.
+
+Example:
+  gcectl metadata remove sandbox enable-oslogin
+  gcectl metadata remove sandbox startup-script team`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		keys := args[1:]
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(args[0])
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		if err = session.OpenVMRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		metadataUseCase := usecase.NewMetadataUseCase(session.VMRepository, infraLog.DefaultLogger)
+		err = console.ExecuteWithProgress(ctx, fmt.Sprintf("Removing metadata of %s", vm.Name), func(ctx context.Context) error {
+			return metadataUseCase.Remove(ctx, vm.Project, vm.Zone, vm.Name, keys)
+		})
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to remove metadata: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		console.Success(fmt.Sprintf("Removed metadata %s of %s", strings.Join(keys, ", "), vm.Name))
+	},
+}
+
+func init() {
+	MetadataCmd.AddCommand(removeCmd)
+}
@@ -0,0 +1,82 @@
+package metadata
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var setCmd = &cobra.Command{
+	Use:   "set <vm_name> <key=value...>",
+	Short: "Set metadata items of a VM",
+	Long: `Add or overwrite metadata items of a VM. All other items are kept.
+
+Example:
+  gcectl metadata set sandbox enable-oslogin=TRUE
+  gcectl metadata set sandbox team=ml env=dev`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		items, err := parseItems(args[1:])
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(args[0])
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		if err = session.OpenVMRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		metadataUseCase := usecase.NewMetadataUseCase(session.VMRepository, infraLog.DefaultLogger)
+		err = console.ExecuteWithProgress(ctx, fmt.Sprintf("Setting metadata of %s", vm.Name), func(ctx context.Context) error {
+			return metadataUseCase.Set(ctx, vm.Project, vm.Zone, vm.Name, items)
+		})
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to set metadata: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		keys := make([]string, 0, len(items))
+		for k := range items {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		console.Success(fmt.Sprintf("Set metadata %s of %s", strings.Join(keys, ", "), vm.Name))
+	},
+}
+
+func init() {
+	MetadataCmd.AddCommand(setCmd)
+}
@@ -0,0 +1,84 @@
+package metadata
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var setStartupScriptCmd = &cobra.Command{
+	Use:   "set-startup-script <vm_name> --file <script>",
+	Short: "Set the startup script of a VM from a file",
+	Long: `Set the startup-script metadata item of a VM to the contents of a file.
+The script runs on every boot of the VM; remove it with
+"gcectl metadata remove <vm_name> startup-script".
+
+Example:
+  gcectl metadata set-startup-script sandbox --file startup.sh`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		if startupScriptFile == "" {
+			console.Error("--file is required")
+			os.Exit(cli.ExitFailure)
+		}
+		script, err := os.ReadFile(startupScriptFile)
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to read startup script: %v", err), err)
+			os.Exit(cli.ExitCode(err))
+		}
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(args[0])
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		if err = session.OpenVMRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		metadataUseCase := usecase.NewMetadataUseCase(session.VMRepository, infraLog.DefaultLogger)
+		items := map[string]string{model.StartupScriptKey: string(script)}
+		err = console.ExecuteWithProgress(ctx, fmt.Sprintf("Setting startup script of %s", vm.Name), func(ctx context.Context) error {
+			return metadataUseCase.Set(ctx, vm.Project, vm.Zone, vm.Name, items)
+		})
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to set startup script: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		console.Success(fmt.Sprintf("Set startup script of %s from %s; it runs on the next boot", vm.Name, startupScriptFile))
+	},
+}
+
+var startupScriptFile string
+
+func init() {
+	MetadataCmd.AddCommand(setStartupScriptCmd)
+	setStartupScriptCmd.Flags().StringVar(&startupScriptFile, "file", "", "Path to the startup script")
+}
@@ -0,0 +1,12 @@
+package cmd
+
+import "github.com/haru-256/gcectl/internal/domain/model"
+
+// operationEvents builds one notification event per VM for the outcome of an operation.
+func operationEvents(eventType model.EventType, vmNames []string, message string) []model.Event {
+	events := make([]model.Event, 0, len(vmNames))
+	for _, name := range vmNames {
+		events = append(events, model.Event{Type: eventType, VMName: name, Message: message})
+	}
+	return events
+}
@@ -2,10 +2,13 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/haru-256/gcectl/internal/domain/model"
 	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
 	"github.com/haru-256/gcectl/internal/interface/cli"
 	"github.com/haru-256/gcectl/internal/interface/presenter"
@@ -13,16 +16,51 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	offNoWait bool
+	offAll    bool
+	offYes    bool
+	offAt     string
+
+	offGraceful        bool
+	offGracefulTimeout time.Duration
+)
+
 // offCmd represents the off command
 var offCmd = &cobra.Command{
-	Use:   "off <vm_name>...",
+	Use:   "off <vm_name>... | --selector <labels> | --all",
 	Short: "Turn off one or more instances",
 	Long: `Turn off one or more instances
 
 Example:
   gcectl off <vm_name>
-  gcectl off <vm_name1> <vm_name2> <vm_name3>`,
-	Args: cobra.MinimumNArgs(1),
+  gcectl off <vm_name1> <vm_name2> <vm_name3>
+  gcectl off <vm_name> --no-wait
+  gcectl off <vm_name> --at 19:00
+  gcectl off <vm_name> --graceful
+  gcectl off --selector env=dev
+  gcectl off --all --yes
+  gcectl describe --all --output json | jq -r '.[] | select(.Status == "RUNNING") | .Name' | gcectl off - --yes
+
+With --selector the instances are chosen by their GCP labels in the default
+project and the projects of the config, whether or not they are in the config.
+A VM name of "-" reads the names from stdin, one per line; as stdin is then
+not a terminal, pass --yes when the stop asks for confirmation.
+
+With --at the stop is deferred to the given local time (HH:MM for its next
+occurrence, or YYYY-MM-DD HH:MM) and performed by a background
+'gcectl schedule run --wait'. See 'gcectl schedule pending'.
+
+With --graceful the shutdown-command of config.yaml (default
+"sudo shutdown -h now") is first run in the VM over ssh, e.g. to drain its
+work, and the VM is stopped through the API only when the command fails or the
+VM is not TERMINATED within shutdown-timeout (default 5m) or --graceful-timeout.
+--iap and --internal choose how to connect, as for 'gcectl exec'.
+
+With --all, or when more VMs than confirm-stop-threshold in config.yaml
+(default 3) are targeted, the VMs are listed and the stop asks for
+confirmation. Pass --yes to stop without asking, e.g. in scripts.`,
+	Args: offArgs,
 	Run:  offRun,
 }
 
@@ -33,43 +71,169 @@ func offRun(cmd *cobra.Command, args []string) {
 
 	session, ctx, err := cli.NewSession(cmd, CnfPath)
 	if err != nil {
-		console.Error(err.Error())
-		os.Exit(1)
+		console.ErrorWithHint(err.Error(), err)
+		os.Exit(cli.ExitCode(err))
 	}
 	defer session.Close()
 
-	vms, err := session.Config.ResolveVMs(vmNames)
+	err = session.OpenVMRepository(ctx)
 	if err != nil {
-		console.Error(err.Error())
+		console.ErrorWithHint(err.Error(), err)
 		session.Close()
-		os.Exit(1)
+		os.Exit(cli.ExitCode(err))
 	}
 
-	err = session.OpenVMRepository(ctx)
-	if err != nil {
-		console.Error(err.Error())
+	vms := session.Config.VMs
+	if !offAll {
+		vms, err = resolveTargetVMs(ctx, session, args)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+	}
+	if len(vms) == 0 {
+		console.Error("No VM to turn off")
 		session.Close()
-		os.Exit(1)
+		os.Exit(cli.ExitFailure)
 	}
+	vmNames = vmNamesOf(vms)
 
-	stopVMUseCase := usecase.NewStopVMUseCase(session.VMRepository, infraLog.DefaultLogger)
+	if !offYes && !confirmStop(console, session, vms) {
+		console.Success("Canceled; no VMs were stopped")
+		return
+	}
+
+	if offAt != "" {
+		if offNoWait {
+			console.Error("--no-wait cannot be combined with --at")
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+		at, parseErr := usecase.ParseStopTime(offAt, time.Now())
+		if parseErr != nil {
+			console.ErrorWithHint(parseErr.Error(), parseErr)
+			session.Close()
+			os.Exit(cli.ExitCode(parseErr))
+		}
+		if scheduleErr := scheduleStops(ctx, cmd, session, vms, at, "at "+offAt); scheduleErr != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to schedule the stop: %v", scheduleErr), scheduleErr)
+			session.Close()
+			os.Exit(cli.ExitCode(scheduleErr))
+		}
+		console.Success(fmt.Sprintf("Scheduled stop of %s at %s", strings.Join(vmNames, ", "), at.Format("2006-01-02 15:04 MST")))
+		return
+	}
+
+	stopVMUseCase := usecase.NewStopVMUseCase(session.VMRepository, infraLog.DefaultLogger).
+		WithMaxConcurrency(session.Config.MaxConcurrency)
+	if offGraceful {
+		stopVMUseCase.WithGracefulShutdown(gracefulShutdown(session))
+	}
+
+	if offNoWait {
+		ops, noWaitErr := stopVMUseCase.ExecuteNoWait(ctx, vms)
+		if noWaitErr != nil {
+			console.ErrorWithHint(withCapabilityHint(fmt.Sprintf("Failed to turn off the instance(s): %v", noWaitErr), noWaitErr), noWaitErr)
+			session.Close()
+			os.Exit(cli.ExitCode(noWaitErr))
+		}
+		console.RenderOperations(ops)
+		return
+	}
 
-	err = console.ExecuteWithProgress(
+	results := newVMResults()
+	err = console.ExecuteWithVMProgress(
 		ctx,
 		fmt.Sprintf("Stopping VMs %s", strings.Join(vmNames, ", ")),
-		func(ctx context.Context) error {
-			return stopVMUseCase.Execute(ctx, vms)
+		vmNames,
+		func(ctx context.Context, report func(name, phase string, finished bool)) error {
+			return stopVMUseCase.WithProgress(report).WithResults(results.add).Execute(ctx, vms)
 		},
 	)
+	if err != nil && session.Detached() {
+		console.Success(fmt.Sprintf("Detached; the instances keep stopping on GCP: %s", strings.Join(vmNames, ", ")))
+		return
+	}
+	shown := results.show(console, vms)
 	if err != nil {
-		console.Error(fmt.Sprintf("Failed to turn off the instance(s): %v", err))
+		session.Notify(operationEvents(model.EventOperationFailure, vmNames, fmt.Sprintf("failed to stop: %v", err))...)
+		msg := fmt.Sprintf("Failed to turn off the instance(s): %v", err)
+		if shown {
+			msg = fmt.Sprintf("Failed to turn off %d of %d instances", results.failed(), len(vms))
+		}
+		console.ErrorWithHint(withCapabilityHint(msg, err), err)
 		session.Close()
-		os.Exit(1)
+		os.Exit(cli.ExitCode(err))
+	}
+
+	session.Notify(operationEvents(model.EventOperationSuccess, vmNames, "stopped")...)
+	if !shown {
+		console.Success(fmt.Sprintf("Turned off the instances: %v (took %s)", strings.Join(vmNames, ", "), results.elapsed().Round(time.Second)))
+	}
+}
+
+// gracefulShutdown returns how off --graceful shuts the VMs down: with their
+// shutdown-command over ssh, waiting --graceful-timeout or shutdown-timeout.
+func gracefulShutdown(session *cli.Session) *usecase.GracefulShutdown {
+	timeout := session.Config.ShutdownTimeout
+	if offGracefulTimeout > 0 {
+		timeout = offGracefulTimeout
+	}
+	return &usecase.GracefulShutdown{
+		Runner:  sshGuestRunner{session: session},
+		Command: session.Config.ShutdownCommandFor,
+		Lock:    session.LockVM,
+		Events:  session.EmitOperationEvent,
+		Timeout: timeout,
+	}
+}
+
+// offArgs accepts vmArgs, or no VM names with --all.
+func offArgs(cmd *cobra.Command, args []string) error {
+	if !offAll {
+		return vmArgs(cmd, args)
+	}
+	if vmSelector != "" {
+		return errors.New("--all cannot be combined with --selector")
 	}
+	return cobra.NoArgs(cmd, args)
+}
 
-	console.Success(fmt.Sprintf("Turned off the instances: %v", strings.Join(vmNames, ", ")))
+// confirmStop lists vms and asks whether to stop them when --all is given or
+// they are more than confirm-stop-threshold, and reports whether to go on. It
+// exits when stdin cannot be read.
+func confirmStop(console *presenter.ConsolePresenter, session *cli.Session, vms []*model.VM) bool {
+	threshold := session.Config.ConfirmStopThreshold
+	if !offAll && (threshold == 0 || len(vms) <= threshold) {
+		return true
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Turning off %d VMs:\n", len(vms))
+	for _, vm := range vms {
+		fmt.Fprintf(&b, "  %s (%s, %s)\n", vm.Name, vm.Project, vm.Zone)
+	}
+	console.RenderText(b.String())
+
+	answer, err := console.Prompt(fmt.Sprintf("Stop these %d VMs? [y/N]:", len(vms)))
+	if err != nil {
+		console.ErrorWithHint(fmt.Sprintf("Failed to read confirmation: %v; pass --yes to stop without it", err), err)
+		session.Close()
+		os.Exit(cli.ExitCode(err))
+	}
+	return strings.EqualFold(answer, "y") || strings.EqualFold(answer, "yes")
 }
 
 func init() {
 	rootCmd.AddCommand(offCmd)
+	addSelectorFlag(offCmd)
+	offCmd.Flags().BoolVar(&offAll, "all", false, "Turn off every VM in the config file")
+	offCmd.Flags().BoolVarP(&offYes, "yes", "y", false, "Turn off without asking for confirmation")
+	offCmd.Flags().StringVar(&offAt, "at", "", "Defer the stop to this local time (HH:MM or YYYY-MM-DD HH:MM)")
+	offCmd.Flags().BoolVar(&offNoWait, "no-wait", false, "Return immediately after the stop request is accepted and print the operation names")
+	offCmd.Flags().BoolVar(&offGraceful, "graceful", false, "Run the shutdown-command in the VM over ssh first and stop it through the API only after a timeout")
+	offCmd.Flags().DurationVar(&offGracefulTimeout, "graceful-timeout", 0, "How long --graceful waits for the VM to shut down (default: shutdown-timeout in config.yaml)")
+	offCmd.MarkFlagsMutuallyExclusive("graceful", "no-wait")
+	offCmd.MarkFlagsMutuallyExclusive("graceful", "at")
+	addSSHTargetFlags(offCmd)
 }
@@ -5,24 +5,57 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/haru-256/gcectl/internal/domain/model"
 	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/infrastructure/netprobe"
 	"github.com/haru-256/gcectl/internal/interface/cli"
 	"github.com/haru-256/gcectl/internal/interface/presenter"
 	"github.com/haru-256/gcectl/internal/usecase"
 	"github.com/spf13/cobra"
 )
 
+var (
+	onNoWait         bool
+	onWaitSSH        bool
+	onSSHPort        int
+	onWaitSSHTimeout time.Duration
+	onForce          bool
+	onTTL            time.Duration
+)
+
 // onCmd represents the on command
 var onCmd = &cobra.Command{
-	Use:   "on <vm_name...>",
+	Use:   "on <vm_name...> | --selector <labels>",
 	Short: "Turn on the instances",
 	Long: `Turn on the instances
 
 Example:
   gcectl on <vm_name>
-  gcectl on <vm_name1> <vm_name2> <vm_name3>`,
-	Args: cobra.MinimumNArgs(1),
+  gcectl on <vm_name1> <vm_name2> <vm_name3>
+  gcectl on <vm_name> --wait-ssh
+  gcectl on <vm_name> --wait-ssh --ssh-port 2222 --wait-ssh-timeout 10m
+  gcectl on <vm_name> --no-wait
+  gcectl on <vm_name> --ttl 3h
+  gcectl on --selector env=dev,team=ml
+  gcectl list --query '.[].Name' | grep gpu | gcectl on -
+
+With --selector the instances are chosen by their GCP labels in the default
+project and the projects of the config, whether or not they are in the config.
+A VM name of "-" reads the names from stdin, one per line.
+
+With --ttl the VMs are stopped again after the given duration by a background
+'gcectl schedule run --wait'; the pending stop is shown by 'gcectl list'.
+
+When a budget is set in config.yaml, the start is refused if this month's
+projected spend would exceed it; pass --force to start anyway with a warning.
+
+When a VM's zone is out of capacity (ZONE_RESOURCE_POOL_EXHAUSTED) and config.yaml
+lists fallback-zones for it, the start is retried in those zones in order,
+recreating the VM there from a machine image if needed. --no-wait does not fail
+over.`,
+	Args: vmArgs,
 	Run:  onRun,
 }
 
@@ -33,43 +66,183 @@ func onRun(cmd *cobra.Command, args []string) {
 
 	session, ctx, err := cli.NewSession(cmd, CnfPath)
 	if err != nil {
-		console.Error(err.Error())
-		os.Exit(1)
+		console.ErrorWithHint(err.Error(), err)
+		os.Exit(cli.ExitCode(err))
 	}
 	defer session.Close()
 
-	vms, err := session.Config.ResolveVMs(vmNames)
+	err = session.OpenVMRepository(ctx)
 	if err != nil {
-		console.Error(err.Error())
+		console.ErrorWithHint(err.Error(), err)
 		session.Close()
-		os.Exit(1)
+		os.Exit(cli.ExitCode(err))
 	}
 
-	err = session.OpenVMRepository(ctx)
+	vms, err := resolveTargetVMs(ctx, session, args)
 	if err != nil {
-		console.Error(err.Error())
+		console.ErrorWithHint(err.Error(), err)
 		session.Close()
-		os.Exit(1)
+		os.Exit(cli.ExitCode(err))
 	}
+	vmNames = vmNamesOf(vms)
 
-	startVMUseCase := usecase.NewStartVMUseCase(session.VMRepository, infraLog.DefaultLogger)
+	startVMUseCase := usecase.NewStartVMUseCase(session.VMRepository, infraLog.DefaultLogger).
+		WithMaxConcurrency(session.Config.MaxConcurrency)
 
-	err = console.ExecuteWithProgress(
+	if session.Config.Budget.IsSet() {
+		checkBudgetUseCase, budgetErr := newCheckBudgetUseCase(ctx, session)
+		if budgetErr != nil {
+			console.ErrorWithHint(budgetErr.Error(), budgetErr)
+			session.Close()
+			os.Exit(cli.ExitCode(budgetErr))
+		}
+		startVMUseCase.WithBudgetCheck(checkBudgetUseCase.Execute, onForce)
+	}
+
+	if onNoWait {
+		if onWaitSSH || onTTL > 0 {
+			console.Error("--no-wait cannot be combined with --wait-ssh or --ttl")
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+		ops, noWaitErr := startVMUseCase.ExecuteNoWait(ctx, vms)
+		if noWaitErr != nil {
+			console.ErrorWithHint(withCapabilityHint(fmt.Sprintf("Failed to turn on the instances: %v", noWaitErr), noWaitErr), noWaitErr)
+			session.Close()
+			os.Exit(cli.ExitCode(noWaitErr))
+		}
+		console.RenderOperations(ops)
+		return
+	}
+
+	fallbackZones := make(map[string][]string)
+	for _, vm := range vms {
+		if zones := session.Config.FallbackZonesFor(vm.Name); len(zones) > 0 {
+			fallbackZones[vm.Name] = zones
+		}
+	}
+
+	var placements []*usecase.Placement
+	if len(fallbackZones) > 0 {
+		err = session.OpenMachineImageRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+	}
+	results := newVMResults()
+	err = console.ExecuteWithVMProgress(
 		ctx,
 		fmt.Sprintf("Starting VMs %s", strings.Join(vmNames, ", ")),
-		func(ctx context.Context) error {
-			return startVMUseCase.Execute(ctx, vms)
+		vmNames,
+		func(ctx context.Context, report func(name, phase string, finished bool)) error {
+			startVMUseCase.WithProgress(report).WithResults(results.add)
+			if len(fallbackZones) == 0 {
+				return startVMUseCase.Execute(ctx, vms)
+			}
+			failoverUseCase := usecase.NewStartWithFailoverUseCase(startVMUseCase, session.VMRepository, session.MachineImageRepository, infraLog.DefaultLogger)
+			var startErr error
+			placements, startErr = failoverUseCase.Execute(ctx, vms, fallbackZones)
+			return startErr
 		},
 	)
+	if err != nil && session.Detached() {
+		console.Success(fmt.Sprintf("Detached; the instances keep starting on GCP: %s", strings.Join(vmNames, ", ")))
+		return
+	}
+	shown := results.show(console, vms)
 	if err != nil {
-		console.Error(fmt.Sprintf("Failed to turn on the instances: %v", err))
+		session.Notify(operationEvents(model.EventOperationFailure, vmNames, fmt.Sprintf("failed to start: %v", err))...)
+		msg := fmt.Sprintf("Failed to turn on the instances: %v", err)
+		if shown {
+			msg = fmt.Sprintf("Failed to turn on %d of %d instances", results.failed(), len(vms))
+		}
+		console.ErrorWithHint(withCapabilityHint(msg, err), err)
 		session.Close()
-		os.Exit(1)
+		os.Exit(cli.ExitCode(err))
+	}
+	session.Notify(operationEvents(model.EventOperationSuccess, vmNames, "started")...)
+
+	// Follow VMs that landed in a fallback zone for --ttl and --wait-ssh
+	for i, placement := range placements {
+		if placement.VM.Zone == vms[i].Zone {
+			continue
+		}
+		msg := fmt.Sprintf("%s started in fallback zone %s because %s has no capacity", placement.VM.Name, placement.VM.Zone, vms[i].Zone)
+		if placement.MachineImage != "" {
+			msg += fmt.Sprintf(" (recreated from machine image %s; the original instance is kept stopped)", placement.MachineImage)
+		}
+		console.Success(msg + "; set its zone in config to manage it there")
+		vms[i] = placement.VM
+	}
+
+	stopAt := time.Now().Add(onTTL)
+	if onTTL > 0 {
+		if ttlErr := scheduleStops(ctx, cmd, session, vms, stopAt, fmt.Sprintf("ttl %s", onTTL)); ttlErr != nil {
+			console.ErrorWithHint(fmt.Sprintf("Instances started but the stop could not be scheduled: %v", ttlErr), ttlErr)
+			session.Close()
+			os.Exit(cli.ExitCode(ttlErr))
+		}
+	}
+
+	if onWaitSSH {
+		ports := make(map[string]int, len(vms))
+		for _, vm := range vms {
+			ports[vm.Name] = session.Config.SSHOptionsFor(vm.Name).Port
+			if cmd.Flags().Changed("ssh-port") {
+				ports[vm.Name] = onSSHPort
+			}
+		}
+
+		waitSSHUseCase := usecase.NewWaitSSHUseCase(session.VMRepository, netprobe.NewTCPProber(), infraLog.DefaultLogger)
+		err = console.ExecuteWithProgress(
+			ctx,
+			fmt.Sprintf("Waiting for SSH on %s", strings.Join(vmNames, ", ")),
+			func(ctx context.Context) error {
+				waitCtx, cancel := context.WithTimeout(ctx, onWaitSSHTimeout)
+				defer cancel()
+				return waitSSHUseCase.Execute(waitCtx, vms, ports)
+			},
+		)
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Instances started but SSH is not reachable: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
 	}
 
-	console.Success(fmt.Sprintf("Turned on the instances: %v", strings.Join(vmNames, ", ")))
+	msg := fmt.Sprintf("Turned on the instances: %v (took %s", strings.Join(vmNames, ", "), results.elapsed().Round(time.Second))
+	if onTTL > 0 {
+		msg += fmt.Sprintf(", stopping at %s", stopAt.Format("15:04"))
+	}
+	msg += ")"
+	// The results table replaces the line, unless it has the time of the stop.
+	if !shown || onTTL > 0 {
+		console.Success(msg)
+	}
+}
+
+// newCheckBudgetUseCase opens what the budget check needs to compute this month's spend.
+func newCheckBudgetUseCase(ctx context.Context, session *cli.Session) (*usecase.CheckBudgetUseCase, error) {
+	if err := session.OpenOperationRepository(ctx); err != nil {
+		return nil, err
+	}
+	store, err := openHistoryStore()
+	if err != nil {
+		return nil, err
+	}
+	report := usecase.NewUsageReportUseCase(session.VMRepository, session.OperationRepository, store, infraLog.DefaultLogger)
+	return usecase.NewCheckBudgetUseCase(report, session.Config.VMs, session.Config.Budget, session.Config.HourlyCost), nil
 }
 
 func init() {
 	rootCmd.AddCommand(onCmd)
+	addSelectorFlag(onCmd)
+	onCmd.Flags().BoolVar(&onNoWait, "no-wait", false, "Return immediately after the start request is accepted and print the operation names")
+	onCmd.Flags().BoolVar(&onWaitSSH, "wait-ssh", false, "Wait until the SSH port is reachable before reporting success")
+	onCmd.Flags().IntVar(&onSSHPort, "ssh-port", usecase.DefaultSSHPort, "SSH port to probe with --wait-ssh (default: ssh.port from config, or 22)")
+	onCmd.Flags().DurationVar(&onTTL, "ttl", 0, "Stop the instances again after this duration (e.g. 3h)")
+	onCmd.Flags().BoolVar(&onForce, "force", false, "Start even if the projected spend exceeds the budget")
+	onCmd.Flags().DurationVar(&onWaitSSHTimeout, "wait-ssh-timeout", 5*time.Minute, "Maximum time to wait for SSH with --wait-ssh")
 }
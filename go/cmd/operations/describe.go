@@ -0,0 +1,70 @@
+package operations
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var describeCmd = &cobra.Command{
+	Use:   "describe <operation>",
+	Short: "Show the status, progress and error of an operation",
+	Long: `Show the status, progress and error of an operation without waiting for it.
+
+The operation can be given as a full path or as a bare name, resolved like
+"gcectl operations wait".
+
+Example:
+  gcectl operations describe projects/my-project/zones/us-central1-a/operations/operation-123
+  gcectl operations describe operation-123 --zone us-west1-a`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		op, err := resolveOperation(session, args[0])
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		err = session.OpenOperationRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		describeUseCase := usecase.NewDescribeOperationUseCase(session.OperationRepository)
+		current, err := describeUseCase.Execute(ctx, op)
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to describe operation: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		console.RenderOperationDetail(current)
+	},
+}
+
+func init() {
+	OperationsCmd.AddCommand(describeCmd)
+	addOperationLocationFlags(describeCmd)
+}
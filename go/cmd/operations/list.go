@@ -0,0 +1,75 @@
+package operations
+
+import (
+	"os"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var limit int
+
+var listCmd = &cobra.Command{
+	Use:   "list [vm_name...]",
+	Short: "List recent operations of configured VMs",
+	Long: `List recent zone operations (start, stop, setMachineType, ...) of the given VMs,
+or of every VM in the config when none are given, newest first.
+
+Example:
+  gcectl operations list
+  gcectl operations list <vm_name> --limit 20`,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vms := session.Config.VMs
+		if len(args) > 0 {
+			vms, err = session.Config.ResolveVMs(args)
+			if err != nil {
+				console.ErrorWithHint(err.Error(), err)
+				session.Close()
+				os.Exit(cli.ExitCode(err))
+			}
+		}
+
+		err = session.OpenOperationRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		listUseCase := usecase.NewListOperationsUseCase(session.OperationRepository).WithMaxConcurrency(session.Config.MaxConcurrency)
+		ops, listErr := listUseCase.Execute(ctx, vms, limit)
+		if listErr != nil {
+			if len(ops) == 0 {
+				console.ErrorWithHint(listErr.Error(), listErr)
+				session.Close()
+				os.Exit(cli.ExitCode(listErr))
+			}
+			infraLog.DefaultLogger.Warnf("Some VMs were skipped: %v", listErr)
+		}
+
+		console.RenderOperationList(ops)
+	},
+}
+
+func init() {
+	OperationsCmd.AddCommand(listCmd)
+	listCmd.Flags().IntVarP(&limit, "limit", "n", 10, "Maximum number of operations per VM")
+}
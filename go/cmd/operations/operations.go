@@ -0,0 +1,55 @@
+package operations
+
+import (
+	"os"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+var OperationsCmd = &cobra.Command{
+	Use:   "operations <command>",
+	Short: "Inspect and wait for GCE operations",
+	Long: `Inspect and wait for GCE operations started by gcectl.
+
+Example:
+  gcectl operations list
+  gcectl operations list <vm_name> --limit 20
+  gcectl operations describe operation-123
+  gcectl operations wait projects/my-project/zones/us-central1-a/operations/operation-123`,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		infraLog.DefaultLogger.Debugf("run operations command")
+		if err := cmd.Help(); err != nil {
+			console.Error("Failed to run help command")
+			os.Exit(cli.ExitFailure)
+		}
+	},
+}
+
+var (
+	project string
+	zone    string
+)
+
+// addOperationLocationFlags registers --project and --zone used to resolve bare operation names.
+func addOperationLocationFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&project, "project", "", "Project of the operation when a bare name is given (default: default-project from config)")
+	cmd.Flags().StringVar(&zone, "zone", "", "Zone of the operation when a bare name is given (default: default-zone from config)")
+}
+
+// resolveOperation parses an operation reference, resolving bare names against
+// --project/--zone and then the config defaults.
+func resolveOperation(session *cli.Session, ref string) (*model.Operation, error) {
+	defaultProject, defaultZone := session.Config.DefaultProject, session.Config.DefaultZone
+	if project != "" {
+		defaultProject = project
+	}
+	if zone != "" {
+		defaultZone = zone
+	}
+	return model.ParseOperationPath(ref, defaultProject, defaultZone)
+}
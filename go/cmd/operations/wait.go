@@ -0,0 +1,85 @@
+package operations
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var waitCmd = &cobra.Command{
+	Use:   "wait <operation>",
+	Short: "Wait for an operation to finish",
+	Long: `Wait for an operation to finish, e.g. one returned by "gcectl on --no-wait".
+
+The operation can be given as the full path printed by --no-wait, or as a bare
+operation name. Bare names are resolved against --project and --zone, falling
+back to default-project and default-zone from the config.
+
+Example:
+  gcectl operations wait projects/my-project/zones/us-central1-a/operations/operation-123
+  gcectl operations wait operation-123 --zone us-west1-a`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		op, err := resolveOperation(session, args[0])
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		err = session.OpenOperationRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		waitUseCase := usecase.NewWaitOperationUseCase(session.OperationRepository, infraLog.DefaultLogger)
+		var done *model.Operation
+		err = console.ExecuteWithProgress(
+			ctx,
+			fmt.Sprintf("Waiting for operation %s", op.Name),
+			func(ctx context.Context) error {
+				var waitErr error
+				done, waitErr = waitUseCase.Execute(ctx, op)
+				return waitErr
+			},
+		)
+		if err != nil {
+			session.Notify(model.Event{Type: model.EventOperationFailure, VMName: op.Target, Message: fmt.Sprintf("operation %s did not succeed: %v", op.Name, err)})
+			console.ErrorWithHint(fmt.Sprintf("Operation did not succeed: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		session.Notify(model.Event{Type: model.EventOperationSuccess, VMName: done.Target, Message: fmt.Sprintf("%s operation %s finished", done.Type, done.Name)})
+		console.Success(fmt.Sprintf("Operation %s (%s %s) finished", done.Name, done.Type, done.Target))
+	},
+}
+
+func init() {
+	OperationsCmd.AddCommand(waitCmd)
+	addOperationLocationFlags(waitCmd)
+}
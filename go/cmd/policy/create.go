@@ -0,0 +1,137 @@
+package policy
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/config"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// defaultTimeZone is used when neither --timezone nor the spec file sets one.
+const defaultTimeZone = "UTC"
+
+var (
+	startCron   string
+	stopCron    string
+	timeZone    string
+	description string
+	specFile    string
+)
+
+var createCmd = &cobra.Command{
+	Use:   "create [policy_name]",
+	Short: "Create an instance schedule policy",
+	Long: `Create an instance schedule policy from flags or a YAML spec file.
+Flags override values from the spec file. Attach the policy to a VM afterwards
+with "gcectl set schedule-policy".
+
+Spec file format:
+  name: weekday-office-hours
+  description: Run during office hours
+  start-cron: "0 9 * * 1-5"
+  stop-cron: "0 19 * * 1-5"
+  timezone: Asia/Tokyo
+
+Example:
+  gcectl policy create weekday-stop --stop-cron "0 19 * * 1-5" --timezone Asia/Tokyo
+  gcectl policy create office-hours --start-cron "0 9 * * 1-5" --stop-cron "0 19 * * 1-5"
+  gcectl policy create -f policy.yaml`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		policy, err := policyFromFlags(cmd, args)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		// The spec file location wins over config defaults, but not over --project/--region.
+		defaultProject, defaultRegion := resolveLocation(session)
+		if policy.Project == "" || project != "" {
+			policy.Project = defaultProject
+		}
+		if policy.Region == "" || region != "" {
+			policy.Region = defaultRegion
+		}
+
+		err = session.OpenSchedulePolicyRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		createUseCase := usecase.NewCreateSchedulePolicyUseCase(session.SchedulePolicyRepository)
+		if err = createUseCase.Execute(ctx, policy); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		console.Success(fmt.Sprintf("Created schedule policy %s in %s/%s", policy.Name, policy.Project, policy.Region))
+	},
+}
+
+// policyFromFlags builds the policy from the spec file, if any, overlaid with the name argument and flags.
+func policyFromFlags(cmd *cobra.Command, args []string) (*model.SchedulePolicy, error) {
+	policy := &model.SchedulePolicy{}
+	if specFile != "" {
+		spec, err := config.LoadSchedulePolicySpec(specFile)
+		if err != nil {
+			return nil, err
+		}
+		policy = spec
+	}
+
+	if len(args) == 1 {
+		policy.Name = args[0]
+	}
+	if cmd.Flags().Changed("start-cron") {
+		policy.StartCron = startCron
+	}
+	if cmd.Flags().Changed("stop-cron") {
+		policy.StopCron = stopCron
+	}
+	if cmd.Flags().Changed("timezone") {
+		policy.TimeZone = timeZone
+	}
+	if cmd.Flags().Changed("description") {
+		policy.Description = description
+	}
+	if policy.Name == "" {
+		return nil, fmt.Errorf("policy name is required (pass it as an argument or set name in the spec file)")
+	}
+	if policy.TimeZone == "" {
+		policy.TimeZone = defaultTimeZone
+	}
+	return policy, nil
+}
+
+func init() {
+	PolicyCmd.AddCommand(createCmd)
+	addPolicyLocationFlags(createCmd)
+	createCmd.Flags().StringVar(&startCron, "start-cron", "", `Cron schedule to start attached VMs (e.g. "0 9 * * 1-5")`)
+	createCmd.Flags().StringVar(&stopCron, "stop-cron", "", `Cron schedule to stop attached VMs (e.g. "0 19 * * 1-5")`)
+	createCmd.Flags().StringVar(&timeZone, "timezone", "", "IANA time zone of the schedules (default UTC)")
+	createCmd.Flags().StringVar(&description, "description", "", "Policy description")
+	createCmd.Flags().StringVarP(&specFile, "file", "f", "", "YAML spec file describing the policy")
+}
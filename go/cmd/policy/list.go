@@ -0,0 +1,61 @@
+package policy
+
+import (
+	"os"
+
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List instance schedule policies",
+	Long: `List the instance schedule policies in the default project and region with
+their start/stop cron schedules, time zone and number of attached VMs.
+
+Example:
+  gcectl policy list
+  gcectl policy list --project my-project --region us-central1`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		err = session.OpenSchedulePolicyRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		p, r := resolveLocation(session)
+		listUseCase := usecase.NewListSchedulePoliciesUseCase(session.SchedulePolicyRepository)
+		policies, err := listUseCase.Execute(ctx, p, r)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		console.RenderSchedulePolicies(policies)
+	},
+}
+
+func init() {
+	PolicyCmd.AddCommand(listCmd)
+	addPolicyLocationFlags(listCmd)
+}
@@ -0,0 +1,57 @@
+package policy
+
+import (
+	"os"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+var PolicyCmd = &cobra.Command{
+	Use:   "policy <command>",
+	Short: "Manage instance schedule policies",
+	Long: `Manage instance schedule resource policies, which start and stop the VMs
+attached to them on a cron schedule.
+
+Example:
+  gcectl policy list
+  gcectl policy list --project my-project --region us-central1
+  gcectl policy create weekday-stop --stop-cron "0 19 * * 1-5" --timezone Asia/Tokyo
+  gcectl policy create -f policy.yaml
+  gcectl policy snooze my-vm --for 4h
+  gcectl policy resume my-vm`,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		infraLog.DefaultLogger.Debugf("run policy command")
+		if err := cmd.Help(); err != nil {
+			console.Error("Failed to run help command")
+			os.Exit(cli.ExitFailure)
+		}
+	},
+}
+
+var (
+	project string
+	region  string
+)
+
+// addPolicyLocationFlags registers --project and --region.
+func addPolicyLocationFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&project, "project", "", "Project of the policies (default: default-project from config)")
+	cmd.Flags().StringVar(&region, "region", "", "Region of the policies (default: region of default-zone from config)")
+}
+
+// resolveLocation returns --project/--region, falling back to the config defaults.
+func resolveLocation(session *cli.Session) (string, string) {
+	p, r := project, region
+	if p == "" {
+		p = session.Config.DefaultProject
+	}
+	if r == "" && session.Config.DefaultZone != "" {
+		r = model.RegionOf(session.Config.DefaultZone)
+	}
+	return p, r
+}
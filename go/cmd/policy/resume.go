@@ -0,0 +1,106 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var resumeExpired bool
+
+var resumeCmd = &cobra.Command{
+	Use:   "resume [vm_name]",
+	Short: "Re-attach schedule policies detached by snooze",
+	Long: `Re-attach a snoozed schedule policy.
+
+With a VM name the snooze ends now. With --expired every snooze whose time is
+up is ended, which is meant to be run periodically (e.g. from cron).
+
+Example:
+  gcectl policy resume my-vm
+  gcectl policy resume --expired`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		if (len(args) == 1) == resumeExpired {
+			console.Error("specify either a VM name or --expired")
+			os.Exit(cli.ExitFailure)
+		}
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		store, err := openSnoozeStore()
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		err = session.OpenVMRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		resumeUseCase := usecase.NewResumeScheduleUseCase(session.VMRepository, store, infraLog.DefaultLogger)
+
+		if resumeExpired {
+			resumed, resumeErr := resumeUseCase.ExecuteExpired(ctx, time.Now())
+			for _, s := range resumed {
+				console.Success(fmt.Sprintf("Re-attached %s to %s", s.Policy, s.VMName))
+			}
+			if resumeErr != nil {
+				console.ErrorWithHint(fmt.Sprintf("Failed to resume some snoozes: %v", resumeErr), resumeErr)
+				session.Close()
+				os.Exit(cli.ExitCode(resumeErr))
+			}
+			return
+		}
+
+		vm, err := session.Config.ResolveVM(args[0])
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		var result *model.Snooze
+		message := fmt.Sprintf("Re-attaching schedule policy of VM %s", vm.Name)
+		err = console.ExecuteWithProgress(ctx, message, func(ctx context.Context) error {
+			var resumeErr error
+			result, resumeErr = resumeUseCase.Execute(ctx, vm)
+			return resumeErr
+		})
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to resume schedule policy: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		console.Success(fmt.Sprintf("Re-attached %s to %s", result.Policy, vm.Name))
+	},
+}
+
+func init() {
+	PolicyCmd.AddCommand(resumeCmd)
+	resumeCmd.Flags().BoolVar(&resumeExpired, "expired", false, "Resume every snooze whose time is up")
+}
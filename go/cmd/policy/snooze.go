@@ -0,0 +1,102 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/infrastructure/snooze"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var snoozeFor time.Duration
+
+var snoozeCmd = &cobra.Command{
+	Use:   "snooze <vm_name>",
+	Short: "Temporarily detach a VM's stop schedule policy",
+	Long: `Detach the stop schedule policy of a VM for a while, so late work is not
+interrupted by the scheduled stop. The snooze is recorded locally and the policy
+is re-attached by 'gcectl policy resume'.
+
+Snoozing an already snoozed VM moves the end of the snooze.
+
+Example:
+  gcectl policy snooze my-vm --for 4h
+  gcectl policy resume --expired   # e.g. from cron, re-attaches ended snoozes`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		vmName := args[0]
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(vmName)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		store, err := openSnoozeStore()
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		err = session.OpenVMRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		snoozeUseCase := usecase.NewSnoozeScheduleUseCase(session.VMRepository, store, infraLog.DefaultLogger)
+		var result *model.Snooze
+		message := fmt.Sprintf("Snoozing schedule policy of VM %s for %s", vmName, snoozeFor)
+		err = console.ExecuteWithProgress(ctx, message, func(ctx context.Context) error {
+			var snoozeErr error
+			result, snoozeErr = snoozeUseCase.Execute(ctx, vm.Project, vm.Zone, vm.Name, snoozeFor)
+			return snoozeErr
+		})
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to snooze schedule policy: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		console.Success(fmt.Sprintf("Snoozed %s on %s until %s; run 'gcectl policy resume' to re-attach it",
+			result.Policy, vmName, result.Until.Local().Format("2006-01-02 15:04 MST")))
+	},
+}
+
+// openSnoozeStore opens the snooze file at its default location.
+func openSnoozeStore() (*snooze.File, error) {
+	path, err := snooze.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return snooze.Open(path)
+}
+
+func init() {
+	PolicyCmd.AddCommand(snoozeCmd)
+	snoozeCmd.Flags().DurationVar(&snoozeFor, "for", 0, "How long to detach the stop schedule policy (e.g. 4h, 90m)")
+	_ = snoozeCmd.MarkFlagRequired("for")
+}
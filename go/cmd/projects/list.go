@@ -0,0 +1,72 @@
+package projects
+
+import (
+	"os"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var listNames bool
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the active projects you can access",
+	Long: `List the active projects the credentials can access, with their display
+names and numbers.
+
+With --names only the project IDs are printed, one per line, for shell
+completion and pickers.
+
+Example:
+  gcectl projects list
+  gcectl projects list --names | fzf`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		if err = session.OpenProjectRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		projects, err := usecase.NewListProjectsUseCase(session.ProjectRepository).Execute(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		if listNames {
+			var b strings.Builder
+			for _, project := range projects {
+				b.WriteString(project.ID + "\n")
+			}
+			console.RenderText(b.String())
+			return
+		}
+		console.RenderProjects(projects)
+	},
+}
+
+func init() {
+	ProjectsCmd.AddCommand(listCmd)
+	listCmd.Flags().BoolVar(&listNames, "names", false, "Print only the project IDs, one per line")
+}
@@ -0,0 +1,28 @@
+package projects
+
+import (
+	"os"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+var ProjectsCmd = &cobra.Command{
+	Use:   "projects <command>",
+	Short: "Discover the GCP projects you can access",
+	Long: `Discover the GCP projects your credentials can access, e.g. to pick the
+project of a VM added to config.yaml.
+
+Example:
+  gcectl projects list`,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		infraLog.DefaultLogger.Debugf("run projects command")
+		if err := cmd.Help(); err != nil {
+			console.Error("Failed to run help command")
+			os.Exit(cli.ExitFailure)
+		}
+	},
+}
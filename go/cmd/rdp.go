@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/haru-256/gcectl/internal/infrastructure/rdp"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// rdpPort is the port Remote Desktop listens on in Windows instances
+const rdpPort = 3389
+
+var (
+	// rdpLocalPort is the local port to listen on
+	rdpLocalPort int
+	// rdpAddress is the local address to listen on
+	rdpAddress string
+	// rdpUser is the Windows user name passed to the RDP client
+	rdpUser string
+	// rdpNoLaunch only opens the tunnel without starting an RDP client
+	rdpNoLaunch bool
+)
+
+// rdpCmd represents the rdp command
+var rdpCmd = &cobra.Command{
+	Use:   "rdp <vm_name>",
+	Short: "Open a Remote Desktop session to a Windows instance through Identity-Aware Proxy",
+	Long: `Forward a local port to Remote Desktop (port 3389) of a running Windows
+instance through an Identity-Aware Proxy tunnel, like tunnel, and start the local
+RDP client on it: Microsoft Remote Desktop on macOS, mstsc on Windows, and
+xfreerdp or remmina on Linux. The tunnel stays open until interrupted.
+
+Set a password first with reset-windows-password.
+
+Example:
+  gcectl rdp my-win-vm --user alice
+  gcectl rdp my-win-vm --no-launch --local-port 13389`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(args[0])
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if err = session.OpenVMRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		found, _, err := usecase.NewDescribeVMUseCase(session.VMRepository).Execute(ctx, vm.Project, vm.Zone, vm.Name)
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to describe %s: %v", vm.Name, err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if !found.Windows {
+			console.Error(fmt.Sprintf("VM %s is not a Windows instance; use ssh instead", vm.Name))
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+
+		err = serveTunnel(ctx, cmd, session, vm, rdpPort, rdpAddress, rdpLocalPort, func(addr net.Addr) {
+			console.Success(fmt.Sprintf("Remote Desktop of %s on %s, press Ctrl-C to stop", vm.Name, addr))
+			if rdpNoLaunch {
+				return
+			}
+			if launchErr := rdp.Launch(addr.String(), rdpUser); launchErr != nil {
+				msg := launchErr.Error()
+				if errors.Is(launchErr, rdp.ErrUnavailable) {
+					msg += fmt.Sprintf("; connect your RDP client to %s", addr)
+				}
+				console.Error(msg)
+			}
+		})
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rdpCmd)
+	rdpCmd.Flags().IntVar(&rdpLocalPort, "local-port", 0, "Local port to listen on (default: a free port)")
+	rdpCmd.Flags().StringVar(&rdpAddress, "address", "localhost", "Local address to listen on")
+	rdpCmd.Flags().StringVar(&rdpUser, "user", "", "Windows user name to log in as")
+	rdpCmd.Flags().BoolVar(&rdpNoLaunch, "no-launch", false, "Only open the tunnel, without starting an RDP client")
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/infrastructure/config"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reconcileAdd   bool
+	reconcilePrune bool
+)
+
+// reconcileCmd represents the reconcile command
+var reconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Compare the config file with the instances in GCP",
+	Long: `Compare the config file with the instances in GCP.
+
+All instances in the default project and the projects of the configured VMs are
+listed and compared with the config file by project, zone and name. Instances
+that are not in the config file are reported as unmanaged, and config entries
+without an instance as stale.
+
+With --add each unmanaged instance is offered for adding to the config file,
+and with --prune each stale entry is offered for removal. A shared config is
+edited at its source, not here.
+
+Example:
+  gcectl reconcile
+  gcectl reconcile --add --prune`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		infraLog.DefaultLogger.Debug("Reconcile config with instances")
+		if reconcileAdd || reconcilePrune {
+			refuseSharedConfigEdit(console, "add or remove the VMs")
+		}
+
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		if err = session.OpenVMRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		reconcileUseCase := usecase.NewReconcileVMsUseCase(session.VMRepository)
+		report, listErr := reconcileUseCase.Execute(ctx, session.Config.DefaultProject, session.Config.VMs)
+		if len(report.Projects) == 0 && listErr != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to list instances: %v", listErr), listErr)
+			session.Close()
+			os.Exit(cli.ExitCode(listErr))
+		}
+
+		console.RenderReconcile(presenter.ReconcileSummary{
+			Projects:  report.Projects,
+			Unmanaged: report.Unmanaged,
+			Stale:     report.Stale,
+		})
+
+		if reconcileAdd {
+			for _, vm := range report.Unmanaged {
+				if !confirmReconcile(console, session, fmt.Sprintf("Add %s (%s, %s) to the config file? [y/N]:", vm.Name, vm.Project, vm.Zone)) {
+					continue
+				}
+				if err = config.RegisterVM(CnfPath, vm); err != nil {
+					console.ErrorWithHint(fmt.Sprintf("Failed to add %s to the config file: %v", vm.Name, err), err)
+					session.Close()
+					os.Exit(cli.ExitCode(err))
+				}
+				console.Success(fmt.Sprintf("Added %s to the config file", vm.Name))
+			}
+		}
+		if reconcilePrune {
+			for _, vm := range report.Stale {
+				if !confirmReconcile(console, session, fmt.Sprintf("Remove %s (%s, %s) from the config file? [y/N]:", vm.Name, vm.Project, vm.Zone)) {
+					continue
+				}
+				if err = config.UnregisterVM(CnfPath, vm); err != nil {
+					console.ErrorWithHint(fmt.Sprintf("Failed to remove %s from the config file: %v", vm.Name, err), err)
+					session.Close()
+					os.Exit(cli.ExitCode(err))
+				}
+				console.Success(fmt.Sprintf("Removed %s from the config file", vm.Name))
+			}
+		}
+
+		if listErr != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to list instances in some projects: %v", listErr), listErr)
+			session.Close()
+			os.Exit(cli.ExitCode(listErr))
+		}
+	},
+}
+
+// confirmReconcile asks label and reports whether the answer is yes. It exits when stdin cannot be read.
+func confirmReconcile(console *presenter.ConsolePresenter, session *cli.Session, label string) bool {
+	answer, err := console.Prompt(label)
+	if err != nil {
+		console.ErrorWithHint(fmt.Sprintf("Failed to read confirmation: %v", err), err)
+		session.Close()
+		os.Exit(cli.ExitCode(err))
+	}
+	return strings.EqualFold(answer, "y") || strings.EqualFold(answer, "yes")
+}
+
+func init() {
+	rootCmd.AddCommand(reconcileCmd)
+	reconcileCmd.Flags().BoolVar(&reconcileAdd, "add", false, "Ask to add each unmanaged instance to the config file")
+	reconcileCmd.Flags().BoolVar(&reconcilePrune, "prune", false, "Ask to remove each stale entry from the config file")
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/haru-256/gcectl/internal/infrastructure/remoteconfig"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+// configRefreshCmd represents the config refresh command
+var configRefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Fetch the shared config again, replacing its local copy",
+	Long: `Fetch the config named by --config or the current context again when it is a
+remote one, replacing the local copy the other commands read. The local copy
+is kept when the fetch fails or the fetched config is invalid.
+
+Example:
+  gcectl config refresh
+  gcectl config refresh --config gs://team-bucket/gcectl.yaml`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		if remoteConfigSource == nil {
+			console.Error(fmt.Sprintf("The config %s is a local file; refresh applies to gs://, https:// and git configs", CnfPath))
+			os.Exit(cli.ExitFailure)
+		}
+		fetcher, err := newRemoteConfigFetcher(cmd)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		path, err := fetcher.Refresh(cmd.Context(), *remoteConfigSource)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		console.Success(fmt.Sprintf("Fetched %s into %s", remoteConfigSource.Raw, path))
+	},
+}
+
+// remoteConfigSource is the remote config named by --config, or nil when it names a local file.
+var remoteConfigSource *remoteconfig.Source
+
+// remoteConfigCopy is the local copy of remoteConfigSource, empty when the config is a local file.
+var remoteConfigCopy string
+
+// useRemoteConfig points --config at the local copy of the config when it
+// names a remote one, fetching the config unless it was fetched before.
+// config refresh fetches it itself, so --config keeps the remote config.
+func useRemoteConfig(cmd *cobra.Command) error {
+	flag := cmd.Flags().Lookup("config")
+	if flag == nil {
+		return nil
+	}
+	src, remote, err := remoteconfig.ParseSource(flag.Value.String())
+	if err != nil || !remote {
+		return err
+	}
+	remoteConfigSource = &src
+	cli.UseSharedConfig(src.Raw)
+
+	fetcher, err := newRemoteConfigFetcher(cmd)
+	if err != nil {
+		return err
+	}
+	if cmd == configRefreshCmd {
+		remoteConfigCopy = fetcher.CachePath(src)
+		return nil
+	}
+	path, err := fetcher.Resolve(cmd.Context(), src)
+	if err != nil {
+		return err
+	}
+	remoteConfigCopy = path
+	return flag.Value.Set(path)
+}
+
+// localConfigPath returns the config file gcectl reads: the local copy of a
+// remote config, or else the file --config names.
+func localConfigPath() string {
+	if remoteConfigCopy != "" {
+		return remoteConfigCopy
+	}
+	return CnfPath
+}
+
+// configArg returns the --config value for a gcectl started by this one: the
+// remote config rather than its local copy, so that it keeps the current context.
+func configArg() string {
+	if remoteConfigSource != nil {
+		return remoteConfigSource.Raw
+	}
+	return CnfPath
+}
+
+// refuseSharedConfigEdit exits when the config is a shared one, whose local copy
+// the next refresh replaces, telling the user to make the edit at its source.
+func refuseSharedConfigEdit(console *presenter.ConsolePresenter, edit string) {
+	if remoteConfigSource == nil {
+		return
+	}
+	console.Error(fmt.Sprintf("%s is a shared config; %s at its source and run gcectl config refresh", remoteConfigSource.Raw, edit))
+	os.Exit(cli.ExitFailure)
+}
+
+// newRemoteConfigFetcher returns a fetcher authenticating to Cloud Storage like
+// the other GCP clients, with --credentials-file or Application Default
+// Credentials, and accepting only configs gcectl can load.
+func newRemoteConfigFetcher(cmd *cobra.Command) (*remoteconfig.Fetcher, error) {
+	dir, err := remoteconfig.DefaultCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	settings, err := cli.ClientSettings(cmd, nil)
+	if err != nil {
+		return nil, err
+	}
+	// A cassette holds Compute Engine interactions, not the config.
+	settings.Cassette = nil
+	opts, err := settings.ClientOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	fetcher := remoteconfig.NewFetcher(dir)
+	fetcher.GCSOptions = opts
+	fetcher.Validate = func(path string) error {
+		_, loadErr := cli.LoadConfig(path)
+		return loadErr
+	}
+	return fetcher, nil
+}
+
+func init() {
+	configCmd.AddCommand(configRefreshCmd)
+}
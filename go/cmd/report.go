@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/infrastructure/history"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportMonth string
+	reportCSV   string
+)
+
+// reportCmd represents the report command
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Summarize runtime hours and estimated cost per VM for a month",
+	Long: `Summarize how many hours each VM in settings ran during a month (the
+current month by default) and its estimated cost from the hourly-cost table in
+config.yaml.
+
+Start and stop operations are copied from the GCE operations history into
+~/.local/state/gcectl/history.json each time the report runs. GCE only keeps
+operations for a limited time, so run the report regularly (e.g. weekly) to
+keep the history complete.
+
+Example:
+  gcectl report
+  gcectl report --month 2025-01 --csv usage-2025-01.csv`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		month, err := parseReportMonth(reportMonth, time.Now())
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		err = session.OpenVMRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		err = session.OpenOperationRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		store, err := openHistoryStore()
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		reportUseCase := usecase.NewUsageReportUseCase(session.VMRepository, session.OperationRepository, store, infraLog.DefaultLogger)
+		report, err := reportUseCase.Execute(ctx, session.Config.VMs, month, session.Config.HourlyCost)
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to build usage report: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		summary := presenter.UsageSummary{
+			From:                 report.From,
+			To:                   report.To,
+			UnpricedMachineTypes: report.UnpricedMachineTypes,
+			TotalHours:           report.TotalHours,
+			TotalCost:            report.TotalCost,
+		}
+		for _, u := range report.VMs {
+			summary.Rows = append(summary.Rows, presenter.UsageRow{
+				Name:        u.VM.Name,
+				Zone:        u.VM.Zone,
+				MachineType: u.VM.MachineType,
+				Hours:       u.Hours,
+				Cost:        u.Cost,
+			})
+		}
+		console.RenderUsageReport(summary)
+
+		if reportCSV == "" {
+			return
+		}
+		if err = writeUsageCSV(reportCSV, summary); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		console.Success(fmt.Sprintf("Wrote usage report to %s", reportCSV))
+	},
+}
+
+// parseReportMonth parses a YYYY-MM month in local time, defaulting to the month of now.
+func parseReportMonth(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()), nil
+	}
+	month, err := time.ParseInLocation("2006-01", s, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --month %q: expected YYYY-MM", s)
+	}
+	return month, nil
+}
+
+// openHistoryStore opens the start/stop history file at its default location.
+func openHistoryStore() (*history.File, error) {
+	path, err := history.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return history.Open(path)
+}
+
+// writeUsageCSV writes summary as CSV to path.
+func writeUsageCSV(path string, summary presenter.UsageSummary) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if err = presenter.WriteUsageCSV(f, summary); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.Flags().StringVar(&reportMonth, "month", "", "Month to report as YYYY-MM (default: current month)")
+	reportCmd.Flags().StringVar(&reportCSV, "csv", "", "Also write the report as CSV to this file")
+}
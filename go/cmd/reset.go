@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// resetYes skips the confirmation prompt
+var resetYes bool
+
+// resetCmd represents the reset command
+var resetCmd = &cobra.Command{
+	Use:   "reset <vm_name>",
+	Short: "Hard reset a running instance",
+	Long: `Hard reset a running instance, like pressing its reset button, for when it is
+wedged and a stop and start would take too long. The guest OS is not shut down,
+so data not yet written to disk is lost; the VM keeps its IP addresses and disks.
+
+The VM must be RUNNING. gcectl asks for confirmation first unless --yes is given.
+
+Example:
+  gcectl reset my-vm
+  gcectl reset my-vm --yes`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		vmName := args[0]
+
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(vmName)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		err = session.OpenVMRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		resetVMUseCase := usecase.NewResetVMUseCase(session.VMRepository, infraLog.DefaultLogger)
+		// Reject a VM that cannot be reset before asking to confirm it.
+		if _, err = resetVMUseCase.Check(ctx, vm); err != nil {
+			console.ErrorWithHint(withCapabilityHint(fmt.Sprintf("Failed to reset the instance: %v", err), err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		if !resetYes {
+			answer, promptErr := console.Prompt(fmt.Sprintf("Hard reset %s (%s, %s)? Unsaved data in memory is lost [y/N]:", vm.Name, vm.Project, vm.Zone))
+			if promptErr != nil {
+				console.ErrorWithHint(fmt.Sprintf("Failed to read confirmation: %v; pass --yes to reset without it", promptErr), promptErr)
+				session.Close()
+				os.Exit(cli.ExitCode(promptErr))
+			}
+			if !strings.EqualFold(answer, "y") && !strings.EqualFold(answer, "yes") {
+				console.Success("Canceled; the VM was not reset")
+				return
+			}
+		}
+
+		started := time.Now()
+		err = console.ExecuteWithProgress(ctx, fmt.Sprintf("Resetting VM %s", vm.Name), func(ctx context.Context) error {
+			return resetVMUseCase.Execute(ctx, vm)
+		})
+		if err != nil {
+			session.Notify(model.Event{Type: model.EventOperationFailure, VMName: vm.Name, Message: fmt.Sprintf("failed to reset: %v", err)})
+			console.ErrorWithHint(withCapabilityHint(fmt.Sprintf("Failed to reset the instance: %v", err), err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		session.Notify(model.Event{Type: model.EventOperationSuccess, VMName: vm.Name, Message: "reset"})
+		console.Success(fmt.Sprintf("Reset the instance: %s (took %s)", vm.Name, time.Since(started).Round(time.Second)))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(resetCmd)
+	resetCmd.Flags().BoolVarP(&resetYes, "yes", "y", false, "Reset without asking for confirmation")
+}
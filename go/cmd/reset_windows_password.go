@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// resetWindowsPasswordUser is the Windows user whose password is reset
+var resetWindowsPasswordUser string
+
+// resetWindowsPasswordCmd represents the reset-windows-password command
+var resetWindowsPasswordCmd = &cobra.Command{
+	Use:   "reset-windows-password <vm_name> --user <user>",
+	Short: "Reset the password of a Windows user on an instance",
+	Long: `Ask the guest agent of a running Windows instance for a new password of the
+user, creating the user as an administrator when it does not exist, like
+gcloud compute reset-windows-password, and print the password.
+
+The request is encrypted with a one-time key, so the password never appears in
+metadata or serial port output in clear text.
+
+Example:
+  gcectl reset-windows-password my-win-vm --user alice
+  gcectl rdp my-win-vm --user alice`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(args[0])
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if err = session.OpenVMRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if err = session.OpenSerialPortRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		resetUseCase := usecase.NewResetWindowsPasswordUseCase(session.VMRepository, session.SerialPortRepository, infraLog.DefaultLogger)
+		var password string
+		err = console.ExecuteWithProgress(ctx, fmt.Sprintf("Resetting the password of %s on %s", resetWindowsPasswordUser, vm.Name), func(ctx context.Context) error {
+			var resetErr error
+			password, resetErr = resetUseCase.Execute(ctx, vm, resetWindowsPasswordUser)
+			return resetErr
+		})
+		if err != nil {
+			console.ErrorWithHint(withCapabilityHint(fmt.Sprintf("Failed to reset the password on %s: %v", vm.Name, err), err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		console.Success(fmt.Sprintf("Reset the password of %s on %s", resetWindowsPasswordUser, vm.Name))
+		console.RenderText(password + "\n")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(resetWindowsPasswordCmd)
+	resetWindowsPasswordCmd.Flags().StringVar(&resetWindowsPasswordUser, "user", "", "Windows user name")
+	_ = resetWindowsPasswordCmd.MarkFlagRequired("user")
+}
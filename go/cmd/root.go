@@ -25,8 +25,24 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/haru-256/gcectl/cmd/contexts"
+	"github.com/haru-256/gcectl/cmd/disk"
+	"github.com/haru-256/gcectl/cmd/dockercontext"
+	"github.com/haru-256/gcectl/cmd/gpu"
+	"github.com/haru-256/gcectl/cmd/logs"
+	"github.com/haru-256/gcectl/cmd/metadata"
+	"github.com/haru-256/gcectl/cmd/operations"
+	"github.com/haru-256/gcectl/cmd/policy"
+	"github.com/haru-256/gcectl/cmd/projects"
+	"github.com/haru-256/gcectl/cmd/schedule"
 	"github.com/haru-256/gcectl/cmd/set"
+	"github.com/haru-256/gcectl/cmd/snapshot"
+	"github.com/haru-256/gcectl/cmd/sshconfig"
+	"github.com/haru-256/gcectl/cmd/zones"
 	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/infrastructure/profile"
+	"github.com/haru-256/gcectl/internal/infrastructure/xdg"
+	"github.com/haru-256/gcectl/internal/interface/cli"
 	"github.com/haru-256/gcectl/internal/interface/presenter"
 	"github.com/spf13/cobra"
 )
@@ -38,6 +54,18 @@ var (
 	appVersion string
 	appCommit  string
 	appDate    string
+	// plainOutput disables the progress spinner
+	plainOutput bool
+	// noColor disables colored output
+	noColor bool
+	// asciiOutput replaces emoji and box-drawing characters with plain text
+	asciiOutput bool
+	// quiet suppresses everything but errors and the primary output
+	quiet bool
+	// debug sets the log level to DEBUG
+	debug bool
+	// logFormat overrides the log format from GCE_COMMANDS_LOG_FORMAT
+	logFormat string
 )
 
 // SetVersionInfo is called from main.go to set the version information.
@@ -53,11 +81,19 @@ var rootCmd = &cobra.Command{
 	Use:   "gcectl [command]",
 	Short: "Google Compute Engine commands to control VMs",
 	Long:  `Google Compute Engine commands to control VMs such as listing vm and updating vm-spec, attach vm with stop-scheduler.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if err := useRemoteConfig(cmd); err != nil {
+			console := presenter.NewConsolePresenter()
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		startUpdateCheck(cmd)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		infraLog.DefaultLogger.Debugf("run root command")
 		if err := cmd.Help(); err != nil {
 			infraLog.DefaultLogger.Fatalf("failed to show help: %v", err)
-			os.Exit(1)
+			os.Exit(cli.ExitFailure)
 		}
 	},
 }
@@ -70,8 +106,9 @@ func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
 		infraLog.DefaultLogger.Fatalf("failed to execute command: %v", err)
-		os.Exit(1)
+		os.Exit(cli.ExitFailure)
 	}
+	printUpdateNotice()
 }
 
 func init() {
@@ -79,14 +116,89 @@ func init() {
 	// Here you will define your flags and configuration settings.
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
-	home, err := os.UserHomeDir()
+	defaultCnfPath, err := xdg.ConfigFile("config.yaml")
 	if err != nil {
-		console.Error(fmt.Sprintf("failed to get user home directory: %v", err))
-		os.Exit(1)
+		console.ErrorWithHint(fmt.Sprintf("failed to locate the config file: %v", err), err)
+		os.Exit(cli.ExitCode(err))
 	}
-	defaultCnfPath := home + "/.config/gcectl/config.yaml"
-	rootCmd.PersistentFlags().StringVarP(&CnfPath, "config", "c", defaultCnfPath, "config file path")
+	rootCmd.PersistentFlags().StringVarP(&CnfPath, "config", "c", defaultCnfPath, "config file path, or the gs://, https:// or git URL of a shared config (see gcectl config)")
+	rootCmd.PersistentFlags().Int("max-concurrency", 0, "maximum number of concurrent GCP API calls (overrides config max-concurrency)")
+	rootCmd.PersistentFlags().String("credentials-file", "", "service account key file used instead of Application Default Credentials (overrides config credentials)")
+	rootCmd.PersistentFlags().String("endpoint", "", "Compute Engine API endpoint, e.g. http://localhost:8080 served by gcectl fake-compute (overrides config compute-endpoint)")
+	rootCmd.PersistentFlags().Duration("timeout", 0, "timeout of each GCP API call, e.g. 30s (0 means no limit)")
+	rootCmd.PersistentFlags().Duration("operation-timeout", 0, "timeout of waiting for each GCP operation such as a start, e.g. 10m (0 means no limit)")
+	rootCmd.PersistentFlags().Bool("trace", false, "log every GCP API request with its status and latency")
+	rootCmd.PersistentFlags().Bool("trace-bodies", false, "like --trace, also logging the response bodies with secrets redacted")
+	rootCmd.PersistentFlags().String("record", "", "record the GCP API interactions to a cassette file, with secrets redacted, e.g. for test fixtures")
+	rootCmd.PersistentFlags().String("replay", "", "answer the GCP API requests from a cassette file recorded with --record, without credentials or network")
+	rootCmd.MarkFlagsMutuallyExclusive("record", "replay")
+	rootCmd.PersistentFlags().BoolVar(&plainOutput, "plain", false, "print progress dots instead of a spinner (the default when stdout is not a terminal)")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable colored output (also set by the NO_COLOR environment variable)")
+	rootCmd.PersistentFlags().BoolVar(&asciiOutput, "ascii", false, "print only ASCII characters, e.g. [RUN] instead of status emoji")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "print only errors and the primary output such as tables")
+	rootCmd.PersistentFlags().BoolVarP(&debug, "debug", "v", false, "print debug logs (same as GCE_COMMANDS_LOG_LEVEL=DEBUG)")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "", "log format: text, json or logfmt (same as GCE_COMMANDS_LOG_FORMAT, default text)")
+	rootCmd.MarkFlagsMutuallyExclusive("quiet", "debug")
+	cobra.OnInitialize(func() {
+		infraLog.SetVerbosity(quiet, debug)
+		if logFormat != "" {
+			if err := infraLog.SetFormat(logFormat); err != nil {
+				console.Error(err.Error())
+				os.Exit(cli.ExitFailure)
+			}
+		}
+		presenter.SetQuiet(quiet)
+		presenter.SetPlainProgress(plainOutput)
+		presenter.SetNoColor(noColor || os.Getenv("NO_COLOR") != "")
+		presenter.SetASCII(asciiOutput)
+		if err := applyContext(); err != nil {
+			infraLog.DefaultLogger.Warnf("Ignoring the current context: %v", err)
+		}
+	})
 
 	// set sub command
 	rootCmd.AddCommand(set.SetCmd)
+	rootCmd.AddCommand(sshconfig.SSHConfigCmd)
+	rootCmd.AddCommand(operations.OperationsCmd)
+	rootCmd.AddCommand(policy.PolicyCmd)
+	rootCmd.AddCommand(disk.DiskCmd)
+	rootCmd.AddCommand(snapshot.SnapshotCmd)
+	rootCmd.AddCommand(metadata.MetadataCmd)
+	rootCmd.AddCommand(logs.LogsCmd)
+	rootCmd.AddCommand(schedule.ScheduleCmd)
+	rootCmd.AddCommand(gpu.GPUCmd)
+	rootCmd.AddCommand(projects.ProjectsCmd)
+	rootCmd.AddCommand(zones.ZonesCmd)
+	rootCmd.AddCommand(contexts.ContextCmd)
+	rootCmd.AddCommand(dockercontext.DockerContextCmd)
+}
+
+// applyContext makes commands use the config file and the default project and
+// zone of the profile chosen with `gcectl context use`, unless --config names
+// another config file.
+func applyContext() error {
+	path, err := profile.DefaultPath()
+	if err != nil {
+		return err
+	}
+	f, err := profile.Open(path)
+	if err != nil {
+		return err
+	}
+	current := f.Current()
+	flag := rootCmd.PersistentFlags().Lookup("config")
+	configPath := current.ConfigPath
+	if configPath == "" {
+		configPath = flag.DefValue
+	}
+	// --config naming the profile's own file keeps the profile, so the
+	// background refresh of list, which passes --config, runs in the same context.
+	if flag.Changed && flag.Value.String() != configPath {
+		return nil
+	}
+	if err := flag.Value.Set(configPath); err != nil {
+		return err
+	}
+	cli.UseProfile(current)
+	return nil
 }
@@ -0,0 +1,67 @@
+package schedule
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/haru-256/gcectl/internal/infrastructure/scheduler"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+var cancelCmd = &cobra.Command{
+	Use:   "cancel <vm_name>",
+	Short: "Cancel the pending stop of a VM",
+	Long: `Cancel the pending stop of a VM, e.g. to keep a VM started with --ttl running.
+
+Example:
+  gcectl schedule cancel my-vm`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		vmName := args[0]
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, _, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(vmName)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		store, err := scheduler.OpenDefault()
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if _, ok := store.Get(vm); !ok {
+			console.Error(fmt.Sprintf("VM %s has no pending stop", vmName))
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+		if err = store.Delete(vm); err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to cancel pending stop: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		console.Success(fmt.Sprintf("Canceled the pending stop of %s", vmName))
+	},
+}
+
+func init() {
+	ScheduleCmd.AddCommand(cancelCmd)
+}
@@ -0,0 +1,40 @@
+package schedule
+
+import (
+	"os"
+
+	"github.com/haru-256/gcectl/internal/infrastructure/scheduler"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+var pendingCmd = &cobra.Command{
+	Use:   "pending",
+	Short: "List pending stops",
+	Long: `List the stops deferred by 'gcectl on --ttl' and 'gcectl off --at' that
+have not been performed yet, earliest first.
+
+Example:
+  gcectl schedule pending`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		store, err := scheduler.OpenDefault()
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+
+		actions := store.List()
+		if len(actions) == 0 {
+			console.Success("No pending stops")
+			return
+		}
+		console.RenderScheduledActions(actions)
+	},
+}
+
+func init() {
+	ScheduleCmd.AddCommand(pendingCmd)
+}
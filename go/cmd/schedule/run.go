@@ -0,0 +1,112 @@
+package schedule
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/infrastructure/scheduler"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// runPollInterval is how often `run --wait` re-reads the scheduler file, so actions
+// added or canceled by other commands are picked up.
+const runPollInterval = time.Minute
+
+var runWait bool
+
+var runCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Perform pending stops that are due",
+	Long: `Perform the pending stops whose time has come.
+
+With --wait it keeps running until no stop is pending, which is how the
+background scheduler started by 'gcectl on --ttl' works. Without it, due stops
+are performed once, which suits running from cron instead.
+
+Example:
+  gcectl schedule run
+  gcectl schedule run --wait`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		if runWait {
+			// Keep waiting after the terminal that started us is closed
+			signal.Ignore(syscall.SIGHUP)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		err = session.OpenVMRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		for {
+			// Re-open the file every round to see changes made by other commands
+			store, openErr := scheduler.OpenDefault()
+			if openErr != nil {
+				console.ErrorWithHint(openErr.Error(), openErr)
+				session.Close()
+				os.Exit(cli.ExitCode(openErr))
+			}
+
+			runUseCase := usecase.NewRunScheduledActionsUseCase(session.VMRepository, store, infraLog.DefaultLogger)
+			done, runErr := runUseCase.Execute(ctx)
+			events := make([]model.Event, 0, len(done))
+			for _, action := range done {
+				console.Success(fmt.Sprintf("Ran scheduled %s of %s", action.Action, action.VMName))
+				events = append(events, model.Event{Type: model.EventAutoStop, VMName: action.VMName, Message: "ran " + action.Description()})
+			}
+			session.Notify(events...)
+			if runErr != nil {
+				console.ErrorWithHint(fmt.Sprintf("Failed to run some scheduled actions: %v", runErr), runErr)
+				if !runWait {
+					session.Close()
+					os.Exit(cli.ExitFailure)
+				}
+			}
+
+			pending := store.List()
+			if !runWait || len(pending) == 0 {
+				return
+			}
+
+			wait := time.Until(pending[0].At)
+			if wait > runPollInterval {
+				wait = runPollInterval
+			}
+			infraLog.DefaultLogger.Debugf("Next scheduled action of %s at %s", pending[0].VMName, pending[0].At.Format(time.RFC3339))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+			}
+		}
+	},
+}
+
+func init() {
+	ScheduleCmd.AddCommand(runCmd)
+	runCmd.Flags().BoolVar(&runWait, "wait", false, "Keep running until no stop is pending")
+}
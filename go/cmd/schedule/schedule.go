@@ -0,0 +1,32 @@
+package schedule
+
+import (
+	"os"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+var ScheduleCmd = &cobra.Command{
+	Use:   "schedule <command>",
+	Short: "Manage VM stops deferred by the local scheduler",
+	Long: `Manage VM stops deferred by the local scheduler, such as those of
+'gcectl on --ttl' and 'gcectl off --at'. Pending stops are kept in
+~/.local/state/gcectl/scheduled.json and performed by a background
+'gcectl schedule run --wait' started alongside.
+
+Example:
+  gcectl schedule pending
+  gcectl schedule cancel my-vm
+  gcectl schedule run`,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		infraLog.DefaultLogger.Debugf("run schedule command")
+		if err := cmd.Help(); err != nil {
+			console.Error("Failed to run help command")
+			os.Exit(cli.ExitFailure)
+		}
+	},
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/infrastructure/scheduler"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// scheduleStops records a stop of each VM at at and starts the background
+// scheduler that performs it with the global flags of cmd.
+func scheduleStops(ctx context.Context, cmd *cobra.Command, session *cli.Session, vms []*model.VM, at time.Time, reason string) error {
+	store, err := scheduler.OpenDefault()
+	if err != nil {
+		return err
+	}
+	scheduleStopUseCase := usecase.NewScheduleStopUseCase(session.VMRepository, store, infraLog.DefaultLogger)
+	for _, vm := range vms {
+		if _, scheduleErr := scheduleStopUseCase.Execute(ctx, vm.Project, vm.Zone, vm.Name, at, reason); scheduleErr != nil {
+			return scheduleErr
+		}
+	}
+	return startSchedulerInBackground(cmd)
+}
+
+// startSchedulerInBackground starts a detached `gcectl schedule run --wait`, which
+// performs pending stops when they are due and exits once none is left. It
+// talks to GCP like cmd, e.g. with its --credentials-file or --endpoint.
+func startSchedulerInBackground(cmd *cobra.Command) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	args := append([]string{"schedule", "run", "--wait", "--config", configArg()}, cli.GlobalFlagArgs(cmd, "config")...)
+	worker := exec.Command(exe, args...)
+	if startErr := worker.Start(); startErr != nil {
+		return startErr
+	}
+	return worker.Process.Release()
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// vmSelector is the --selector of the commands acting on several VMs.
+var vmSelector string
+
+// addSelectorFlag adds --selector to cmd, whose Args should be vmArgs.
+func addSelectorFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&vmSelector, "selector", "", "Act on the VMs with all these GCP labels instead of named VMs, e.g. env=dev,team=ml")
+}
+
+// vmArgs requires at least one VM name, or none with --selector.
+func vmArgs(cmd *cobra.Command, args []string) error {
+	if vmSelector != "" {
+		return cobra.NoArgs(cmd, args)
+	}
+	return cobra.MinimumNArgs(1)(cmd, args)
+}
+
+// resolveTargetVMs returns the config VMs named in args, where "-" stands for
+// the names read from stdin, or with --selector the VMs in the default project
+// and the projects of the config whose labels match.
+// The VM repository of session must be open when --selector is given.
+func resolveTargetVMs(ctx context.Context, session *cli.Session, args []string) ([]*model.VM, error) {
+	if vmSelector == "" {
+		names, err := cli.ExpandStdinArgs(args, os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		return session.Config.ResolveVMs(names)
+	}
+	selector, err := model.ParseLabelSelector(vmSelector)
+	if err != nil {
+		return nil, err
+	}
+	return usecase.NewSelectVMsUseCase(session.VMRepository).Execute(ctx, session.Config.DefaultProject, session.Config.VMs, selector)
+}
+
+// vmNamesOf returns the names of vms.
+func vmNamesOf(vms []*model.VM) []string {
+	names := make([]string, len(vms))
+	for i, vm := range vms {
+		names[i] = vm.Name
+	}
+	return names
+}
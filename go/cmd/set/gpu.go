@@ -0,0 +1,110 @@
+package set
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var gpuCmd = &cobra.Command{
+	Use:   "gpu <vm_name>",
+	Short: "Attach or detach GPUs",
+	Long: `Attach GPUs to or detach GPUs from a VM.
+
+The VM must be stopped. The GPU type must be available in the VM's zone and the
+count must not exceed the per-VM limit of that type. Attaching GPUs also sets the
+VM's on-host-maintenance policy to TERMINATE, since GPU VMs cannot live-migrate.
+
+Example:
+  gcectl set gpu sandbox --type nvidia-tesla-t4
+  gcectl set gpu sandbox --type nvidia-l4 --count 2
+  gcectl set gpu sandbox --detach`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		vmName := args[0]
+		if vmName == "" {
+			console.Error("vm_name is required")
+			os.Exit(cli.ExitFailure)
+		}
+		if gpuDetach == (gpuType != "") {
+			console.Error("exactly one of --type or --detach is required")
+			os.Exit(cli.ExitFailure)
+		}
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(vmName)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		if err = session.OpenVMRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if err = session.OpenAcceleratorTypeRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		setGPUUseCase := usecase.NewSetGPUUseCase(session.VMRepository, session.AcceleratorTypeRepository, infraLog.DefaultLogger)
+		if gpuDetach {
+			err = console.ExecuteWithProgress(ctx, fmt.Sprintf("Detaching GPUs from VM %s", vmName), func(ctx context.Context) error {
+				return setGPUUseCase.Detach(ctx, vm.Project, vm.Zone, vm.Name)
+			})
+			if err != nil {
+				console.ErrorWithHint(fmt.Sprintf("Failed to detach GPUs: %v", err), err)
+				session.Close()
+				os.Exit(cli.ExitCode(err))
+			}
+			console.Success(fmt.Sprintf("Detached GPUs from %s", vmName))
+			return
+		}
+
+		message := fmt.Sprintf("Attaching %d x %s to VM %s", gpuCount, gpuType, vmName)
+		err = console.ExecuteWithProgress(ctx, message, func(ctx context.Context) error {
+			return setGPUUseCase.Attach(ctx, vm.Project, vm.Zone, vm.Name, gpuType, gpuCount)
+		})
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to attach GPUs: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		console.Success(fmt.Sprintf("Attached %d x %s to %s", gpuCount, gpuType, vmName))
+	},
+}
+
+var (
+	gpuType   string
+	gpuCount  int
+	gpuDetach bool
+)
+
+func init() {
+	SetCmd.AddCommand(gpuCmd)
+	gpuCmd.Flags().StringVar(&gpuType, "type", "", "GPU type to attach (e.g. nvidia-tesla-t4)")
+	gpuCmd.Flags().IntVar(&gpuCount, "count", 1, "Number of GPUs to attach")
+	gpuCmd.Flags().BoolVar(&gpuDetach, "detach", false, "Detach all GPUs from the VM")
+}
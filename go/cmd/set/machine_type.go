@@ -2,9 +2,15 @@ package set
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/haru-256/gcectl/internal/domain/model"
 	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
 	"github.com/haru-256/gcectl/internal/interface/cli"
 	"github.com/haru-256/gcectl/internal/interface/presenter"
@@ -13,64 +19,205 @@ import (
 )
 
 var machineTypeCmd = &cobra.Command{
-	Use:   "machine-type <vm_name> <machine-type>",
+	Use:   "machine-type <vm_name> [machine-type]",
 	Short: "Set machine-type",
 	Long: `Set machine-type for the application.
 
+When the machine type is omitted, the machine types available in the VM's zone
+are listed grouped by family and one can be picked by number or name.
+
+The VM must be stopped unless --restart is given, in which case a running VM is
+stopped, updated and started again.
+
+A custom machine type can be built with --vcpu and --memory (and --family for
+families other than N1); its shape is validated before the VM is touched.
+
 Example:
-  gcectl set machine-type sandbox n1-standard-1`,
-	Args: cobra.ExactArgs(2),
+  gcectl set machine-type sandbox n1-standard-1
+  gcectl set machine-type sandbox
+  gcectl set machine-type sandbox --vcpu 8 --memory 32GB
+  gcectl set machine-type sandbox --family e2 --vcpu 4 --memory 16GB
+  gcectl set machine-type sandbox e2-standard-4 --restart`,
+	Args: cobra.RangeArgs(1, 2),
 	Run: func(cmd *cobra.Command, args []string) {
 		console := presenter.NewConsolePresenter()
 		vmName := args[0]
-		machineType := args[1]
-		if machineType == "" || vmName == "" {
-			console.Error("machine-type and vm_name are required")
-			os.Exit(1)
+		if vmName == "" {
+			console.Error("vm_name is required")
+			os.Exit(cli.ExitFailure)
+		}
+		custom, isCustom, err := customMachineTypeFromFlags(cmd, args)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
 		}
 
 		cnfPath, err := cmd.Flags().GetString("config")
 		if err != nil {
 			console.Error("config is required")
-			os.Exit(1)
+			os.Exit(cli.ExitConfig)
 		}
 
 		session, ctx, err := cli.NewSession(cmd, cnfPath)
 		if err != nil {
-			console.Error(err.Error())
-			os.Exit(1)
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
 		}
 		defer session.Close()
 
 		vm, err := session.Config.ResolveVM(vmName)
 		if err != nil {
-			console.Error(err.Error())
+			console.ErrorWithHint(err.Error(), err)
 			session.Close()
-			os.Exit(1)
+			os.Exit(cli.ExitCode(err))
 		}
 
 		err = session.OpenVMRepository(ctx)
 		if err != nil {
-			console.Error(err.Error())
+			console.ErrorWithHint(err.Error(), err)
 			session.Close()
-			os.Exit(1)
+			os.Exit(cli.ExitCode(err))
 		}
 
-		updateMachineTypeUseCase := usecase.NewUpdateMachineTypeUseCase(session.VMRepository, infraLog.DefaultLogger)
+		var machineType string
+		switch {
+		case isCustom:
+			machineType = custom.Name()
+		case len(args) == 2:
+			machineType = args[1]
+		default:
+			machineType, err = pickMachineType(ctx, session, console, vm)
+			if err != nil {
+				console.ErrorWithHint(err.Error(), err)
+				session.Close()
+				os.Exit(cli.ExitCode(err))
+			}
+		}
+		if machineType == "" {
+			console.Error("machine-type is required")
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
 
-		message := fmt.Sprintf("Updating machine type for VM %s", vmName)
-		err = console.ExecuteWithProgress(ctx, message, func(ctx context.Context) error {
-			return updateMachineTypeUseCase.Execute(ctx, vm.Project, vm.Zone, vm.Name, machineType)
-		})
+		started := time.Now()
+		if restart {
+			// 各フェーズ(停止・待機・更新・起動)ごとに進捗を表示する
+			restartUseCase := usecase.NewChangeMachineTypeWithRestartUseCase(session.VMRepository, infraLog.DefaultLogger).
+				WithPhaseRunner(console.ExecuteWithProgress)
+			if isCustom {
+				_, err = restartUseCase.ExecuteCustom(ctx, vm.Project, vm.Zone, vm.Name, custom)
+			} else {
+				err = restartUseCase.Execute(ctx, vm.Project, vm.Zone, vm.Name, machineType)
+			}
+		} else {
+			updateMachineTypeUseCase := usecase.NewUpdateMachineTypeUseCase(session.VMRepository, infraLog.DefaultLogger)
+			message := fmt.Sprintf("Updating machine type for VM %s to %s", vmName, machineType)
+			err = console.ExecuteWithProgress(ctx, message, func(ctx context.Context) error {
+				if isCustom {
+					_, customErr := updateMachineTypeUseCase.ExecuteCustom(ctx, vm.Project, vm.Zone, vm.Name, custom)
+					return customErr
+				}
+				return updateMachineTypeUseCase.Execute(ctx, vm.Project, vm.Zone, vm.Name, machineType)
+			})
+		}
 		if err != nil {
-			console.Error(fmt.Sprintf("Failed to set machine-type: %v", err))
+			console.ErrorWithHint(fmt.Sprintf("Failed to set machine-type: %v", err), err)
 			session.Close()
-			os.Exit(1)
+			os.Exit(cli.ExitCode(err))
 		}
-		console.Success(fmt.Sprintf("Set machine-type to %v", machineType))
+		console.Success(fmt.Sprintf("Set machine-type to %v (took %s)", machineType, time.Since(started).Round(time.Second)))
 	},
 }
 
+var (
+	restart      bool
+	customVCPUs  int
+	customMemory string
+	customFamily string
+)
+
+// customMachineTypeFromFlags builds a custom machine type from --vcpu, --memory
+// and --family. isCustom is false when none of them is set.
+func customMachineTypeFromFlags(cmd *cobra.Command, args []string) (custom model.CustomMachineType, isCustom bool, err error) {
+	flags := cmd.Flags()
+	if !flags.Changed("vcpu") && !flags.Changed("memory") && !flags.Changed("family") {
+		return model.CustomMachineType{}, false, nil
+	}
+	if len(args) == 2 {
+		return model.CustomMachineType{}, false, errors.New("a machine type argument cannot be combined with --vcpu/--memory")
+	}
+	if !flags.Changed("vcpu") || !flags.Changed("memory") {
+		return model.CustomMachineType{}, false, errors.New("--vcpu and --memory are both required for a custom machine type")
+	}
+	memoryMB, err := parseMemoryMB(customMemory)
+	if err != nil {
+		return model.CustomMachineType{}, false, err
+	}
+	return model.CustomMachineType{Family: customFamily, VCPUs: customVCPUs, MemoryMB: memoryMB}, true, nil
+}
+
+// parseMemoryMB parses a memory size such as "32GB", "7.5G" or "32768MB" into MB.
+// A bare number is taken as GB. 1 GB is 1024 MB, as in GCE machine type names.
+func parseMemoryMB(s string) (int, error) {
+	value := strings.ToUpper(strings.TrimSpace(s))
+	multiplier := 1024.0
+	switch {
+	case strings.HasSuffix(value, "MB"):
+		value, multiplier = strings.TrimSuffix(value, "MB"), 1
+	case strings.HasSuffix(value, "M"):
+		value, multiplier = strings.TrimSuffix(value, "M"), 1
+	case strings.HasSuffix(value, "GB"):
+		value = strings.TrimSuffix(value, "GB")
+	case strings.HasSuffix(value, "G"):
+		value = strings.TrimSuffix(value, "G")
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid --memory %q: use a size such as 32GB or 32768MB", s)
+	}
+	mb := n * multiplier
+	if mb != math.Trunc(mb) {
+		return 0, fmt.Errorf("invalid --memory %q: must be a whole number of MB", s)
+	}
+	return int(mb), nil
+}
+
+// pickMachineType lists the machine types available in the VM's zone and prompts
+// until a valid one is chosen. An empty answer cancels the picker.
+func pickMachineType(ctx context.Context, session *cli.Session, console *presenter.ConsolePresenter, vm *model.VM) (string, error) {
+	if err := session.OpenMachineTypeRepository(ctx); err != nil {
+		return "", err
+	}
+	machineTypes, err := usecase.NewListMachineTypesUseCase(session.MachineTypeRepository).Execute(ctx, vm.Project, vm.Zone)
+	if err != nil {
+		return "", err
+	}
+	if len(machineTypes) == 0 {
+		return "", fmt.Errorf("no machine types are available in %s", vm.Zone)
+	}
+
+	console.RenderMachineTypes(machineTypes)
+	for {
+		input, promptErr := console.Prompt(fmt.Sprintf("Machine type for %s (number or name, empty to cancel):", vm.Name))
+		if promptErr != nil {
+			return "", fmt.Errorf("failed to read machine type: %w", promptErr)
+		}
+		if input == "" {
+			return "", errors.New("canceled")
+		}
+		mt, selectErr := usecase.SelectMachineType(machineTypes, input)
+		if selectErr != nil {
+			console.ErrorWithHint(selectErr.Error(), selectErr)
+			continue
+		}
+		return mt.Name, nil
+	}
+}
+
 func init() {
 	SetCmd.AddCommand(machineTypeCmd)
+	machineTypeCmd.Flags().BoolVar(&restart, "restart", false, "Stop a running VM, change its machine type and start it again")
+	machineTypeCmd.Flags().IntVar(&customVCPUs, "vcpu", 0, "Number of vCPUs of a custom machine type")
+	machineTypeCmd.Flags().StringVar(&customMemory, "memory", "", "Memory of a custom machine type (e.g. 32GB, 7.5GB, 32768MB)")
+	machineTypeCmd.Flags().StringVar(&customFamily, "family", "", "Machine family of a custom machine type: n1, n2, n2d or e2 (default n1)")
 }
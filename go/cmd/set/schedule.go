@@ -26,34 +26,42 @@ Example:
 		policyName := args[1]
 		if policyName == "" || vmName == "" {
 			console.Error("schedule-policy and vm_name are required")
-			os.Exit(1)
+			os.Exit(cli.ExitFailure)
 		}
 
 		cnfPath, err := cmd.Flags().GetString("config")
 		if err != nil {
 			console.Error("config is required")
-			os.Exit(1)
+			os.Exit(cli.ExitConfig)
 		}
 
 		session, ctx, err := cli.NewSession(cmd, cnfPath)
 		if err != nil {
-			console.Error(err.Error())
-			os.Exit(1)
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
 		}
 		defer session.Close()
 
 		vm, err := session.Config.ResolveVM(vmName)
 		if err != nil {
-			console.Error(err.Error())
+			console.ErrorWithHint(err.Error(), err)
 			session.Close()
-			os.Exit(1)
+			os.Exit(cli.ExitCode(err))
 		}
 
 		err = session.OpenVMRepository(ctx)
 		if err != nil {
-			console.Error(err.Error())
+			console.ErrorWithHint(err.Error(), err)
 			session.Close()
-			os.Exit(1)
+			os.Exit(cli.ExitCode(err))
+		}
+		if !unset {
+			err = session.OpenSchedulePolicyRepository(ctx)
+			if err != nil {
+				console.ErrorWithHint(err.Error(), err)
+				session.Close()
+				os.Exit(cli.ExitCode(err))
+			}
 		}
 
 		if unset {
@@ -72,14 +80,14 @@ Example:
 			})
 
 			if err != nil {
-				console.Error(fmt.Sprintf("Failed to unset schedule-policy: %v", err))
+				console.ErrorWithHint(fmt.Sprintf("Failed to unset schedule-policy: %v", err), err)
 				session.Close()
-				os.Exit(1)
+				os.Exit(cli.ExitCode(err))
 			}
 			console.Success(fmt.Sprintf("Unset schedule-policy: %v", policyName))
 		} else {
 			infraLog.DefaultLogger.Debugf("Set schedule-policy")
-			setSchedulePolicyUseCase := usecase.NewSetSchedulePolicyUseCase(session.VMRepository, infraLog.DefaultLogger)
+			setSchedulePolicyUseCase := usecase.NewSetSchedulePolicyUseCase(session.VMRepository, session.SchedulePolicyRepository, infraLog.DefaultLogger)
 
 			message := fmt.Sprintf("Setting schedule policy %s for VM %s", policyName, vmName)
 
@@ -88,9 +96,9 @@ Example:
 			})
 
 			if err != nil {
-				console.Error(fmt.Sprintf("Failed to set schedule-policy: %v", err))
+				console.ErrorWithHint(fmt.Sprintf("Failed to set schedule-policy: %v", err), err)
 				session.Close()
-				os.Exit(1)
+				os.Exit(cli.ExitCode(err))
 			}
 			console.Success(fmt.Sprintf("Set schedule-policy: %v", policyName))
 		}
@@ -0,0 +1,97 @@
+package set
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var schedulingCmd = &cobra.Command{
+	Use:   "scheduling <vm_name>",
+	Short: "Set automatic restart and on-host-maintenance",
+	Long: `Set the automatic restart and on-host-maintenance options of a VM.
+
+Only the given options are changed; the others keep their current value. The
+current values are shown by "gcectl describe". Spot VMs and VMs with GPUs cannot
+use MIGRATE, and Spot VMs cannot be restarted automatically.
+
+Example:
+  gcectl set scheduling sandbox --automatic-restart=false
+  gcectl set scheduling sandbox --on-host-maintenance TERMINATE
+  gcectl set scheduling sandbox --automatic-restart=true --on-host-maintenance MIGRATE`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		vmName := args[0]
+		if vmName == "" {
+			console.Error("vm_name is required")
+			os.Exit(cli.ExitFailure)
+		}
+
+		opts := model.SchedulingOptions{OnHostMaintenance: strings.ToUpper(onHostMaintenance)}
+		if cmd.Flags().Changed("automatic-restart") {
+			opts.AutomaticRestart = &automaticRestart
+		}
+		if opts.IsEmpty() {
+			console.Error("at least one of --automatic-restart or --on-host-maintenance is required")
+			os.Exit(cli.ExitFailure)
+		}
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(vmName)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		if err = session.OpenVMRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		setSchedulingOptionsUseCase := usecase.NewSetSchedulingOptionsUseCase(session.VMRepository, infraLog.DefaultLogger)
+		message := fmt.Sprintf("Updating scheduling options for VM %s", vmName)
+		err = console.ExecuteWithProgress(ctx, message, func(ctx context.Context) error {
+			return setSchedulingOptionsUseCase.Execute(ctx, vm.Project, vm.Zone, vm.Name, opts)
+		})
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to set scheduling: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		console.Success(fmt.Sprintf("Set scheduling options of %s", vmName))
+	},
+}
+
+var (
+	automaticRestart  bool
+	onHostMaintenance string
+)
+
+func init() {
+	SetCmd.AddCommand(schedulingCmd)
+	schedulingCmd.Flags().BoolVar(&automaticRestart, "automatic-restart", true, "Restart the VM automatically after a host failure")
+	schedulingCmd.Flags().StringVar(&onHostMaintenance, "on-host-maintenance", "", "Behaviour during host maintenance: MIGRATE or TERMINATE")
+}
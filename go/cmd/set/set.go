@@ -4,6 +4,7 @@ import (
 	"os"
 
 	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
 	"github.com/haru-256/gcectl/internal/interface/presenter"
 	"github.com/spf13/cobra"
 )
@@ -15,13 +16,15 @@ var SetCmd = &cobra.Command{
 
 Example:
   gcectl set machine-type sandbox n1-standard-1
+  gcectl set gpu sandbox --type nvidia-tesla-t4
+  gcectl set scheduling sandbox --automatic-restart=false
   gcectl set schedule-policy sandbox stop`,
 	Run: func(cmd *cobra.Command, args []string) {
 		console := presenter.NewConsolePresenter()
 		infraLog.DefaultLogger.Debugf("run root command")
 		if err := cmd.Help(); err != nil {
 			console.Error("Failed to run help command")
-			os.Exit(1)
+			os.Exit(cli.ExitFailure)
 		}
 	},
 }
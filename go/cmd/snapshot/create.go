@@ -0,0 +1,85 @@
+package snapshot
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var createCmd = &cobra.Command{
+	Use:   "create <vm_name>",
+	Short: "Snapshot the disks of a VM",
+	Long: `Snapshot the disks attached to a VM and wait until the snapshots are done.
+
+All attached disks are snapshotted unless --disk names one of them. Snapshots are
+named after the disk and the current UTC time (e.g. data-1-20250102-150405).
+
+Example:
+  gcectl snapshot create sandbox
+  gcectl snapshot create sandbox --disk data-1`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(args[0])
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		if err = session.OpenDiskRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if err = session.OpenSnapshotRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if err = session.OpenOperationRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		// スナップショットごとに完了待ちの進捗を表示する
+		createUseCase := usecase.NewCreateSnapshotUseCase(
+			session.DiskRepository, session.SnapshotRepository, session.OperationRepository, infraLog.DefaultLogger,
+		).WithPhaseRunner(console.ExecuteWithProgress)
+		created, err := createUseCase.Execute(ctx, vm.Project, vm.Zone, vm.Name, disk)
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to create snapshot: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		console.Success(fmt.Sprintf("Created snapshot(s): %s", strings.Join(created, ", ")))
+	},
+}
+
+var disk string
+
+func init() {
+	SnapshotCmd.AddCommand(createCmd)
+	createCmd.Flags().StringVar(&disk, "disk", "", "Disk to snapshot, by name or device name (default: all attached disks)")
+}
@@ -0,0 +1,30 @@
+package snapshot
+
+import (
+	"os"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+var SnapshotCmd = &cobra.Command{
+	Use:   "snapshot <command>",
+	Short: "Create and list disk snapshots of a VM",
+	Long: `Create and list snapshots of the disks of a configured VM, e.g. to
+checkpoint a VM before a risky upgrade.
+
+Example:
+  gcectl snapshot create sandbox
+  gcectl snapshot create sandbox --disk data-1
+  gcectl snapshot list sandbox`,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		infraLog.DefaultLogger.Debugf("run snapshot command")
+		if err := cmd.Help(); err != nil {
+			console.Error("Failed to run help command")
+			os.Exit(cli.ExitFailure)
+		}
+	},
+}
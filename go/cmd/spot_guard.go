@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var (
+	spotGuardInterval    time.Duration
+	spotGuardBackoff     time.Duration
+	spotGuardMaxBackoff  time.Duration
+	spotGuardMaxRestarts int
+	spotGuardAuditLog    string
+)
+
+// spotGuardCmd represents the spot-guard command
+var spotGuardCmd = &cobra.Command{
+	Use:   "spot-guard",
+	Short: "Restart preempted Spot VMs until interrupted",
+	Long: `Watch the VMs marked spot: true in settings and start them again when GCE
+preempts them, until interrupted.
+
+Every --interval, a TERMINATED Spot VM whose latest operation is GCE's
+preemption event is started; VMs stopped on purpose are left alone. After
+each restart attempt the VM is not restarted again for --backoff, doubling per
+attempt up to --max-backoff, and after --max-restarts attempts it is left
+terminated. Restarts are notified as preemption events and written to the audit
+log (--audit-log, default ~/.local/state/gcectl/audit.log).
+
+Example:
+  gcectl spot-guard
+  gcectl spot-guard --interval 30s --max-restarts 10`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		if spotGuardBackoff <= 0 || spotGuardMaxBackoff < spotGuardBackoff {
+			console.Error("--backoff must be positive and not greater than --max-backoff")
+			os.Exit(cli.ExitFailure)
+		}
+
+		// Keep watching after the terminal that started us is closed
+		signal.Ignore(syscall.SIGHUP)
+
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		spotVMs := session.Config.SpotVMs()
+		if len(spotVMs) == 0 {
+			console.Error("No VM is marked spot: true in config")
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+
+		err = session.OpenVMRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		err = session.OpenOperationRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		auditLog, err := cli.OpenAuditLog(session.Config, spotGuardAuditLog)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		restartUseCase := usecase.NewRestartPreemptedUseCase(session.VMRepository, session.OperationRepository, infraLog.DefaultLogger).
+			WithRestartLimits(spotGuardBackoff, spotGuardMaxBackoff, spotGuardMaxRestarts)
+		task := usecase.DaemonTask{Name: "restart-preempted", Interval: spotGuardInterval, Run: restartPreemptedTask(session, restartUseCase)}
+
+		names := make([]string, len(spotVMs))
+		for i, vm := range spotVMs {
+			names[i] = vm.Name
+		}
+		console.Success(fmt.Sprintf("Guarding Spot VMs: %s", strings.Join(names, ", ")))
+		guard := usecase.NewDaemonUseCase([]usecase.DaemonTask{task}, auditLog, infraLog.DefaultLogger)
+		if runErr := guard.Run(ctx); runErr != nil {
+			console.ErrorWithHint(runErr.Error(), runErr)
+			session.Close()
+			os.Exit(cli.ExitCode(runErr))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(spotGuardCmd)
+	spotGuardCmd.Flags().DurationVar(&spotGuardInterval, "interval", time.Minute, "How often to check for preempted VMs")
+	spotGuardCmd.Flags().DurationVar(&spotGuardBackoff, "backoff", usecase.DefaultRestartBackoff, "Wait after a restart attempt before restarting the same VM again")
+	spotGuardCmd.Flags().DurationVar(&spotGuardMaxBackoff, "max-backoff", usecase.DefaultMaxRestartBackoff, "Upper bound of the doubling wait between restarts")
+	spotGuardCmd.Flags().IntVar(&spotGuardMaxRestarts, "max-restarts", usecase.DefaultMaxRestarts, "Restart attempts per VM before giving up (0 for no limit)")
+	spotGuardCmd.Flags().StringVar(&spotGuardAuditLog, "audit-log", "", "Path of the audit log (default ~/.local/state/gcectl/audit.log)")
+}
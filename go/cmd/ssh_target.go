@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/ssh"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// sshIAP tunnels the ssh connections of exec, cp and off --graceful through Identity-Aware Proxy.
+	sshIAP bool
+	// sshInternal connects exec, cp and off --graceful to the internal IP of the VM.
+	sshInternal bool
+)
+
+// addSSHTargetFlags adds the flags choosing how cmd connects to a VM.
+func addSSHTargetFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&sshIAP, "iap", false, "Tunnel through Identity-Aware Proxy with gcloud, for VMs without an external IP")
+	cmd.Flags().BoolVar(&sshInternal, "internal", false, "Connect to the internal IP, e.g. over a VPN")
+	cmd.MarkFlagsMutuallyExclusive("iap", "internal")
+}
+
+// resolveSSHTarget returns how to connect to vm with the ssh options of the
+// config: through IAP with --iap, otherwise to its external or internal IP. The
+// VM repository of session must be open unless --iap is given.
+func resolveSSHTarget(ctx context.Context, session *cli.Session, vm *model.VM) (ssh.Target, error) {
+	options := session.Config.SSHOptionsFor(vm.Name)
+	if sshIAP {
+		return ssh.Target{Host: vm.Name, Options: options, IAP: vm}, nil
+	}
+	ip, err := usecase.NewGetVMIPUseCase(session.VMRepository).Execute(ctx, vm.Project, vm.Zone, vm.Name, sshInternal)
+	if err != nil {
+		return ssh.Target{}, err
+	}
+	return ssh.Target{Host: ip, Options: options}, nil
+}
+
+// guestSessionMarker is echoed before the shutdown command, so that the ssh
+// exit status 255 of a connection closed by the shutdown can be told apart from
+// ssh failing to connect or to authenticate.
+const guestSessionMarker = "gcectl: guest session started"
+
+// sshGuestRunner runs the in-guest shutdown command of off --graceful over ssh.
+type sshGuestRunner struct {
+	session *cli.Session
+}
+
+// Run runs command in vm. The connection closing once the command started is
+// not an error, as it is what a shutdown command does to it.
+func (r sshGuestRunner) Run(ctx context.Context, vm *model.VM, command string) error {
+	target, err := resolveSSHTarget(ctx, r.session, vm)
+	if err != nil {
+		return err
+	}
+	out, err := ssh.Output(ctx, target, fmt.Sprintf("echo %q; %s", guestSessionMarker, command))
+	started := bytes.Contains(out, []byte(guestSessionMarker))
+	var exitErr *ssh.ExitError
+	if errors.As(err, &exitErr) && exitErr.Code == ssh.ExitConnectionClosed && started {
+		return nil
+	}
+	if err != nil {
+		msg := strings.TrimSpace(strings.Replace(string(out), guestSessionMarker, "", 1))
+		if msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package sshconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/infrastructure/sshconfig"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var (
+	write      bool
+	outputPath string
+)
+
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate Host entries for configured VMs",
+	Long: `Generate OpenSSH Host entries for every VM in the config, using their current external IPs.
+
+Per-VM user, port and identity file can be set in config.yaml:
+
+  vm:
+    - name: sandbox
+      ssh:
+        user: alice
+        port: 22
+        identity-file: ~/.ssh/id_ed25519
+
+By default the entries are printed to stdout. With --write they are merged into
+the ssh config file inside a block delimited by gcectl markers; the rest of the
+file is left untouched.
+
+Example:
+  gcectl ssh-config generate
+  gcectl ssh-config generate --write
+  gcectl ssh-config generate --write --path ~/.ssh/config.d/gcectl`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		err = session.OpenVMRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		generateUseCase := usecase.NewGenerateSSHConfigUseCase(session.VMRepository).WithMaxConcurrency(session.Config.MaxConcurrency)
+		hosts, genErr := generateUseCase.Execute(ctx, session.Config.VMs, session.Config.SSH)
+		infraLog.DefaultLogger.Debugf("Generated %d ssh host entries", len(hosts))
+		if genErr != nil {
+			// Skipped VMs are reported but do not prevent writing the rest.
+			infraLog.DefaultLogger.Warnf("Some VMs were skipped: %v", genErr)
+		}
+
+		if !write {
+			console.RenderText(sshconfig.Render(hosts))
+			return
+		}
+
+		sshConfigPath, err := resolvePath(outputPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if mergeErr := sshconfig.MergeFile(sshConfigPath, hosts); mergeErr != nil {
+			console.ErrorWithHint(mergeErr.Error(), mergeErr)
+			session.Close()
+			os.Exit(cli.ExitCode(mergeErr))
+		}
+		console.Success(fmt.Sprintf("Wrote %d host entries to %s", len(hosts), sshConfigPath))
+	},
+}
+
+// resolvePath expands a leading "~/" and falls back to ~/.ssh/config when p is empty.
+func resolvePath(p string) (string, error) {
+	if p == "" {
+		return sshconfig.DefaultPath()
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	if len(p) >= 2 && p[:2] == "~/" {
+		return filepath.Join(home, p[2:]), nil
+	}
+	return p, nil
+}
+
+func init() {
+	SSHConfigCmd.AddCommand(generateCmd)
+	generateCmd.Flags().BoolVarP(&write, "write", "w", false, "Merge the entries into the ssh config file instead of printing them")
+	generateCmd.Flags().StringVar(&outputPath, "path", "", "ssh config file to merge into (default: ~/.ssh/config)")
+}
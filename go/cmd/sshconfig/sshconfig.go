@@ -0,0 +1,28 @@
+package sshconfig
+
+import (
+	"os"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+var SSHConfigCmd = &cobra.Command{
+	Use:   "ssh-config <command>",
+	Short: "Manage OpenSSH client config entries for VMs",
+	Long: `Manage OpenSSH client config entries for VMs.
+
+Example:
+  gcectl ssh-config generate
+  gcectl ssh-config generate --write`,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		infraLog.DefaultLogger.Debugf("run ssh-config command")
+		if err := cmd.Help(); err != nil {
+			console.Error("Failed to run help command")
+			os.Exit(cli.ExitFailure)
+		}
+	},
+}
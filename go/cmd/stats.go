@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"os"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// statsTypes are the operation types to summarize
+	statsTypes []string
+	// statsSince is how far back operations are summarized
+	statsSince time.Duration
+)
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:   "stats [vm_name...]",
+	Short: "Summarize how long operations took",
+	Long: `Summarize how long the operations gcectl started took, e.g. how long each VM
+takes to start, from the operation history (see gcectl history): the number of
+operations that succeeded and failed, the average, median, shortest and longest
+duration, and when the last one ran, per VM and operation type. Only
+operations that succeeded are timed.
+
+Example:
+  gcectl stats
+  gcectl stats my-vm --type start
+  gcectl stats --type start --type stop --since 720h`,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		session, _, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		history, err := cli.OpenOperationHistory(session.Config)
+		if err != nil {
+			console.Error(err.Error())
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+		var since time.Time
+		if statsSince > 0 {
+			since = time.Now().Add(-statsSince)
+		}
+		stats, err := usecase.NewOperationStatsUseCase(history).Execute(args, statsTypes, since)
+		if err != nil {
+			console.Error(err.Error())
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+		if len(stats) == 0 {
+			console.Success("No operations recorded yet")
+			return
+		}
+		console.RenderOperationStats(stats)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.Flags().StringArrayVar(&statsTypes, "type", nil, "Operation type to summarize, e.g. start, stop or setMachineType (repeatable; default all)")
+	statsCmd.Flags().DurationVar(&statsSince, "since", 0, "Only summarize operations started within this duration (e.g. 720h; default all)")
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var (
+	topWindow   time.Duration
+	topInterval time.Duration
+	topOnce     bool
+)
+
+// topCmd represents the top command
+var topCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Show CPU, memory, disk and network usage of running VMs",
+	Long: `Show CPU, memory, disk and network usage of the running VMs in settings,
+averaged over --window and read from Cloud Monitoring. The table is refreshed
+every --interval until interrupted; pass --once to print it a single time.
+
+Memory utilization is only reported by VMs running the Ops Agent; other
+metrics without data are shown as "-". The Cloud Monitoring API must be
+enabled in each project.
+
+Example:
+  gcectl top
+  gcectl top --window 30m --interval 2m
+  gcectl top --once`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		err = session.OpenVMRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		err = session.OpenMetricsRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		metricsUC := usecase.NewFleetMetricsUseCase(session.VMRepository, session.MetricsRepository, infraLog.DefaultLogger)
+
+		if topOnce {
+			if renderErr := renderTop(ctx, console, metricsUC, session.Config.VMs); renderErr != nil {
+				console.ErrorWithHint(fmt.Sprintf("Failed to get metrics: %v", renderErr), renderErr)
+				session.Close()
+				os.Exit(cli.ExitCode(renderErr))
+			}
+			return
+		}
+
+		ticker := time.NewTicker(topInterval)
+		defer ticker.Stop()
+		for {
+			console.ClearScreen()
+			if renderErr := renderTop(ctx, console, metricsUC, session.Config.VMs); renderErr != nil {
+				// Keep refreshing; transient API errors should not end the watch
+				console.ErrorWithHint(fmt.Sprintf("Failed to get metrics: %v", renderErr), renderErr)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	},
+}
+
+// renderTop fetches and renders one snapshot of the VM metrics.
+func renderTop(ctx context.Context, console *presenter.ConsolePresenter, uc *usecase.FleetMetricsUseCase, vms []*model.VM) error {
+	results, err := uc.Execute(ctx, vms, topWindow)
+	if err != nil {
+		return err
+	}
+
+	rows := make([]presenter.VMMetricsRow, len(results))
+	for i, r := range results {
+		rows[i] = presenter.VMMetricsRow{
+			Name:        r.VM.Name,
+			Zone:        r.VM.Zone,
+			MachineType: r.VM.MachineType,
+			Metrics:     r.Metrics,
+		}
+	}
+	console.RenderTop(rows, topWindow, time.Now())
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(topCmd)
+	topCmd.Flags().DurationVar(&topWindow, "window", 10*time.Minute, "Time window to average metrics over")
+	topCmd.Flags().DurationVar(&topInterval, "interval", time.Minute, "Refresh interval")
+	topCmd.Flags().BoolVar(&topOnce, "once", false, "Print the table once and exit")
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/iap"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// tunnelPort is the port on the instance to tunnel to
+	tunnelPort int
+	// tunnelLocalPort is the local port to listen on
+	tunnelLocalPort int
+	// tunnelAddress is the local address to listen on
+	tunnelAddress string
+)
+
+// tunnelCmd represents the tunnel command
+var tunnelCmd = &cobra.Command{
+	Use:   "tunnel <vm_name> --port <port>",
+	Short: "Forward a local port to the instance through Identity-Aware Proxy",
+	Long: `Open an Identity-Aware Proxy TCP tunnel to a port of the instance and forward
+a local port to it until interrupted, so VMs without an external IP are
+reachable without gcloud. Every connection to the local port gets its own tunnel.
+
+The local port defaults to the instance port; --local-port 0 picks a free one.
+The credentials need roles/iap.tunnelResourceAccessor, and the VPC a firewall
+rule allowing ingress from 35.235.240.0/20 to the port.
+
+Example:
+  gcectl tunnel my-vm --port 8888
+  gcectl tunnel my-vm --port 22 --local-port 2222   # then: ssh -p 2222 localhost`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		vmName := args[0]
+
+		if tunnelPort < 1 || tunnelPort > 65535 {
+			console.Error(fmt.Sprintf("--port must be between 1 and 65535: %d", tunnelPort))
+			os.Exit(cli.ExitFailure)
+		}
+		localPort := tunnelPort
+		if cmd.Flags().Changed("local-port") {
+			localPort = tunnelLocalPort
+		}
+
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		vm, err := session.Config.ResolveVM(vmName)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		// Opening the VM repository looks up the zone of VMs configured without one.
+		if err = session.OpenVMRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		err = serveTunnel(ctx, cmd, session, vm, tunnelPort, tunnelAddress, localPort, func(addr net.Addr) {
+			console.Success(fmt.Sprintf("Forwarding %s to %s:%d, press Ctrl-C to stop", addr, vm.Name, tunnelPort))
+		})
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+	},
+}
+
+// serveTunnel listens on localPort of address and forwards the connections to
+// port of vm through IAP until ctx is done. listening is called with the local
+// address once connections are accepted.
+func serveTunnel(ctx context.Context, cmd *cobra.Command, session *cli.Session, vm *model.VM, port int, address string, localPort int, listening func(net.Addr)) error {
+	settings, err := cli.ClientSettings(cmd, session.Config)
+	if err != nil {
+		return err
+	}
+	creds, err := settings.Credentials(ctx)
+	if err != nil {
+		return err
+	}
+	ln, err := net.Listen("tcp", net.JoinHostPort(address, strconv.Itoa(localPort)))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %d: %w", localPort, err)
+	}
+
+	listening(ln.Addr())
+	dialer := iap.NewDialer(creds.TokenSource, settings.ProxyURL)
+	target := iap.Target{Project: vm.Project, Zone: vm.Zone, Instance: vm.Name, Port: port}
+	return dialer.Serve(ctx, ln, target, func(err error) {
+		infraLog.DefaultLogger.Warnf("%v", err)
+	})
+}
+
+func init() {
+	rootCmd.AddCommand(tunnelCmd)
+	tunnelCmd.Flags().IntVar(&tunnelPort, "port", 0, "Port on the instance to tunnel to")
+	tunnelCmd.Flags().IntVar(&tunnelLocalPort, "local-port", 0, "Local port to listen on (default: the instance port)")
+	tunnelCmd.Flags().StringVar(&tunnelAddress, "address", "localhost", "Local address to listen on")
+	_ = tunnelCmd.MarkFlagRequired("port")
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/infrastructure/config"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/infrastructure/update"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+// updateNoticeWait is how long a finished command waits for a slow update check.
+const updateNoticeWait = time.Second
+
+// updateNotice receives the result of the update check started for the command,
+// or is nil when none was started.
+var updateNotice chan *update.Release
+
+// startUpdateCheck looks for a newer release in the background while cmd runs,
+// unless the config sets update-check: false, the output is quiet or not a
+// terminal, or cmd is run by shell completion. A shared config is read from its
+// local copy, so it must be resolved first.
+func startUpdateCheck(cmd *cobra.Command) {
+	if quiet || !stderrIsTerminal() || isCompletionCmd(cmd) {
+		return
+	}
+	if cfg, err := config.NewConfig(localConfigPath()); err == nil && !cfg.UpdateCheck {
+		return
+	}
+	path, err := update.DefaultStatePath()
+	if err != nil {
+		infraLog.DefaultLogger.Debugf("Skipping the update check: %v", err)
+		return
+	}
+
+	found := make(chan *update.Release, 1)
+	updateNotice = found
+	go func() {
+		defer close(found)
+		release, checkErr := update.NewChecker(path).Check(context.Background(), appVersion)
+		if checkErr != nil {
+			infraLog.DefaultLogger.Debugf("Update check failed: %v", checkErr)
+			return
+		}
+		found <- release
+	}()
+}
+
+// printUpdateNotice prints a notice when the update check found a newer
+// release, waiting at most updateNoticeWait for the check to finish.
+func printUpdateNotice() {
+	if updateNotice == nil {
+		return
+	}
+	select {
+	case release := <-updateNotice:
+		if release != nil {
+			presenter.NewConsolePresenter().UpdateNotice(appVersion, release.Version, release.URL)
+		}
+	case <-time.After(updateNoticeWait):
+	}
+}
+
+// isCompletionCmd reports whether cmd prints or serves shell completions.
+func isCompletionCmd(cmd *cobra.Command) bool {
+	if strings.HasPrefix(cmd.Name(), cobra.ShellCompRequestCmd) {
+		return true
+	}
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Name() == "completion" {
+			return true
+		}
+	}
+	return false
+}
+
+func stderrIsTerminal() bool {
+	info, err := os.Stderr.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"sync"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+)
+
+// vmResults collects the results a start or stop reports for each VM, so that
+// a command acting on several VMs can show them as a table.
+type vmResults struct {
+	byName map[string]model.VMResult
+	mu     sync.Mutex
+}
+
+func newVMResults() *vmResults {
+	return &vmResults{byName: make(map[string]model.VMResult)}
+}
+
+// add records result. It satisfies usecase.VMResults.
+func (r *vmResults) add(result model.VMResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byName[result.VM] = result
+}
+
+// show renders the results of vms, in their order, when there are several and
+// reports whether it did.
+func (r *vmResults) show(console *presenter.ConsolePresenter, vms []*model.VM) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(vms) < 2 || len(r.byName) == 0 {
+		return false
+	}
+	results := make([]model.VMResult, 0, len(vms))
+	for _, vm := range vms {
+		if result, ok := r.byName[vm.Name]; ok {
+			results = append(results, result)
+		}
+	}
+	console.RenderVMResults(results)
+	return true
+}
+
+// elapsed returns how long the slowest VM took, which is how long the VMs took
+// together as they run in parallel.
+func (r *vmResults) elapsed() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var longest time.Duration
+	for _, result := range r.byName {
+		longest = max(longest, result.Duration)
+	}
+	return longest
+}
+
+// failed returns how many of the VMs failed.
+func (r *vmResults) failed() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	failed := 0
+	for _, result := range r.byName {
+		if !result.Succeeded() {
+			failed++
+		}
+	}
+	return failed
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// waitFor is the state to wait for, running or stopped
+	waitFor string
+	// waitTimeout bounds the wait; 0 waits indefinitely. It is not the global
+	// --timeout, which bounds each API call.
+	waitTimeout time.Duration
+)
+
+// waitCmd represents the wait command
+var waitCmd = &cobra.Command{
+	Use:   "wait <vm_name...> --for running|stopped | --selector <labels> --for running|stopped",
+	Short: "Wait until the instances are running or stopped",
+	Long: `Wait until the instances are running or stopped, whoever changes them, e.g. a
+schedule policy, so a script can run its next step once a VM is up or down.
+"stopped" matches STOPPED and TERMINATED.
+
+The VMs are polled every 2 seconds at first, backing off to every 30 seconds.
+The command exits with 0 once every VM is in the state, or with 8 when
+--wait-timeout passes first. The global --timeout still bounds each API call.
+
+Example:
+  gcectl wait my-vm --for running
+  gcectl wait vm1 vm2 --for stopped --wait-timeout 2h
+  gcectl wait my-vm --for stopped && ./backup-disk.sh`,
+	Args: vmArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		target, err := usecase.ParseWaitTarget(waitFor)
+		if err != nil {
+			console.Error(fmt.Sprintf("invalid --for: %v", err))
+			os.Exit(cli.ExitFailure)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, CnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		err = session.OpenVMRepository(ctx)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		vms, err := resolveTargetVMs(ctx, session, args)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		vmNames := vmNamesOf(vms)
+
+		waitUseCase := usecase.NewWaitVMStatusUseCase(session.VMRepository, infraLog.DefaultLogger)
+		err = console.ExecuteWithVMProgress(
+			ctx,
+			fmt.Sprintf("Waiting for %s to be %s", strings.Join(vmNames, ", "), target),
+			vmNames,
+			func(ctx context.Context, report func(name, phase string, finished bool)) error {
+				if waitTimeout > 0 {
+					var cancel context.CancelFunc
+					ctx, cancel = context.WithTimeoutCause(ctx, waitTimeout, fmt.Errorf("%w after %s", model.ErrWaitTimeout, waitTimeout))
+					defer cancel()
+				}
+				return waitUseCase.WithProgress(report).Execute(ctx, vms, target)
+			},
+		)
+		if err != nil {
+			console.ErrorWithHint(fmt.Sprintf("Failed to wait for the instances: %v", err), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		console.Success(fmt.Sprintf("The instances are %s: %s", target, strings.Join(vmNames, ", ")))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(waitCmd)
+	addSelectorFlag(waitCmd)
+	waitCmd.Flags().StringVar(&waitFor, "for", "", "State to wait for: running or stopped")
+	waitCmd.Flags().DurationVar(&waitTimeout, "wait-timeout", 30*time.Minute, "Maximum time to wait (0 waits indefinitely)")
+	_ = waitCmd.MarkFlagRequired("for")
+}
@@ -0,0 +1,93 @@
+package zones
+
+import (
+	"os"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var (
+	listRegion  string
+	listProject string
+	listNames   bool
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the zones of a project",
+	Long: `List the Compute Engine zones of a project with their regions and status,
+only those of --region when it is given.
+
+The project defaults to default-project from config.yaml. With --names only the
+zone names are printed, one per line, for shell completion and pickers.
+
+Example:
+  gcectl zones list
+  gcectl zones list --region us-central1 --names`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+
+		cnfPath, err := cmd.Flags().GetString("config")
+		if err != nil {
+			console.Error("config is required")
+			os.Exit(cli.ExitConfig)
+		}
+
+		session, ctx, err := cli.NewSession(cmd, cnfPath)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			os.Exit(cli.ExitCode(err))
+		}
+		defer session.Close()
+
+		project := listProject
+		if project == "" {
+			project = session.Config.DefaultProject
+		}
+		if project == "" {
+			console.Error("--project is required when config.yaml has no default-project")
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+
+		if err = session.OpenRegionRepository(ctx); err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+
+		zones, err := usecase.NewListZonesUseCase(session.RegionRepository).Execute(ctx, project, listRegion)
+		if err != nil {
+			console.ErrorWithHint(err.Error(), err)
+			session.Close()
+			os.Exit(cli.ExitCode(err))
+		}
+		if len(zones) == 0 && listRegion != "" {
+			console.Error("No zones found in region " + listRegion)
+			session.Close()
+			os.Exit(cli.ExitFailure)
+		}
+
+		if listNames {
+			var b strings.Builder
+			for _, zone := range zones {
+				b.WriteString(zone.Name + "\n")
+			}
+			console.RenderText(b.String())
+			return
+		}
+		console.RenderZones(zones)
+	},
+}
+
+func init() {
+	ZonesCmd.AddCommand(listCmd)
+	listCmd.Flags().StringVar(&listRegion, "region", "", "Only list the zones of this region (e.g. us-central1)")
+	listCmd.Flags().StringVar(&listProject, "project", "", "Project to list the zones of (default: default-project)")
+	listCmd.Flags().BoolVar(&listNames, "names", false, "Print only the zone names, one per line")
+}
@@ -0,0 +1,28 @@
+package zones
+
+import (
+	"os"
+
+	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/cli"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/spf13/cobra"
+)
+
+var ZonesCmd = &cobra.Command{
+	Use:   "zones <command>",
+	Short: "Discover the zones of a project",
+	Long: `Discover the Compute Engine zones of a project, e.g. to pick the zone of a
+VM added to config.yaml.
+
+Example:
+  gcectl zones list --region us-central1`,
+	Run: func(cmd *cobra.Command, args []string) {
+		console := presenter.NewConsolePresenter()
+		infraLog.DefaultLogger.Debugf("run zones command")
+		if err := cmd.Help(); err != nil {
+			console.Error("Failed to run help command")
+			os.Exit(cli.ExitFailure)
+		}
+	},
+}
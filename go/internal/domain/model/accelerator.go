@@ -0,0 +1,32 @@
+package model
+
+import "strings"
+
+// Accelerator is a GPU attached to a VM.
+type Accelerator struct {
+	// Type is the accelerator type name (e.g., "nvidia-tesla-t4").
+	Type  string
+	Count int
+}
+
+// AcceleratorType is a GPU type available in a zone.
+type AcceleratorType struct {
+	Name        string
+	Zone        string
+	Description string
+	// MaxCardsPerInstance is the largest number of cards of this type one VM can have.
+	MaxCardsPerInstance int
+}
+
+// QuotaMetric returns the regional quota metric that limits how many cards of
+// this type a project can use, e.g. "NVIDIA_A100_GPUS" for nvidia-tesla-a100, or
+// "PREEMPTIBLE_NVIDIA_A100_GPUS" for Spot VMs.
+func (t *AcceleratorType) QuotaMetric(spot bool) string {
+	name := strings.TrimPrefix(t.Name, "nvidia-")
+	name = strings.TrimPrefix(name, "tesla-")
+	metric := "NVIDIA_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_GPUS"
+	if spot {
+		metric = "PREEMPTIBLE_" + metric
+	}
+	return metric
+}
@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// AuditTaskCommand is the task of the entries of operations started by a command.
+const AuditTaskCommand = "command"
+
+// AuditEntry records one thing gcectl did, or failed to do.
+type AuditEntry struct {
+	Time time.Time
+	// Task is the daemon task that acted (e.g., "scheduled-actions"), or
+	// AuditTaskCommand for an operation started by a command.
+	Task string
+	// VMName is the VM acted on, empty for fleet-wide tasks.
+	VMName string
+	// Message describes what was done.
+	Message string
+	// Error is the failure message, empty on success.
+	Error string
+	// Operation, Command, User, LocalUser and Duration describe an operation
+	// started by a command, as in OperationRecord. They are empty otherwise.
+	Operation string
+	Command   string
+	User      string
+	LocalUser string
+	Duration  time.Duration
+}
+
+// AuditEntryFromRecord converts an operation started by a command into an audit entry.
+func AuditEntryFromRecord(r *OperationRecord) *AuditEntry {
+	message := r.Type
+	if r.Running {
+		message += " (detached, still running)"
+	}
+	return &AuditEntry{
+		Time:      r.Time,
+		Task:      AuditTaskCommand,
+		VMName:    r.Target,
+		Message:   message,
+		Error:     r.Error,
+		Operation: (&Operation{Name: r.Operation, Project: r.Project, Zone: r.Zone}).Path(),
+		Command:   r.Command,
+		User:      r.User,
+		LocalUser: r.LocalUser,
+		Duration:  r.Duration,
+	}
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultBudgetProjection is how long a started VM is assumed to run when projecting spend.
+const DefaultBudgetProjection = 8 * time.Hour
+
+// ErrBudgetExceeded is matched by every BudgetExceededError via errors.Is.
+var ErrBudgetExceeded = errors.New("budget exceeded")
+
+// Budget caps the estimated monthly spend of configured VMs.
+type Budget struct {
+	// MonthlyPerVM is the cap per VM keyed by VM name.
+	MonthlyPerVM map[string]float64
+	// MonthlyTotal is the cap for all configured VMs together. Zero means no cap.
+	MonthlyTotal float64
+	// Projection is how long a started VM is assumed to run.
+	Projection time.Duration
+}
+
+// IsSet reports whether any cap is configured.
+func (b Budget) IsSet() bool {
+	return b.MonthlyTotal > 0 || len(b.MonthlyPerVM) > 0
+}
+
+// BudgetExceededError reports that starting VMs is projected to exceed a monthly cap.
+type BudgetExceededError struct {
+	// VMName is the capped VM, or empty for the total cap.
+	VMName    string
+	Cap       float64
+	Projected float64
+}
+
+func (e *BudgetExceededError) Error() string {
+	scope := "all VMs"
+	if e.VMName != "" {
+		scope = "VM " + e.VMName
+	}
+	return fmt.Sprintf("projected spend of %s this month is $%.2f, over the $%.2f budget", scope, e.Projected, e.Cap)
+}
+
+// Is makes errors.Is(err, ErrBudgetExceeded) true for any BudgetExceededError.
+func (e *BudgetExceededError) Is(target error) bool {
+	return target == ErrBudgetExceeded
+}
@@ -0,0 +1,125 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Capability identifies a gcectl operation whose availability depends on the instance.
+type Capability string
+
+const (
+	CapabilityStart             Capability = "start"
+	CapabilityStop              Capability = "stop"
+	CapabilityReset             Capability = "reset"
+	CapabilityChangeMachineType Capability = "set-machine-type"
+	CapabilityGPU               Capability = "set-gpu"
+	CapabilitySchedulePolicy    Capability = "schedule-policy"
+	CapabilityEdit              Capability = "edit"
+	CapabilityIP                Capability = "ip"
+	CapabilitySSH               Capability = "ssh"
+)
+
+// capabilityCommands maps each capability to the gcectl command that uses it, in display order.
+var capabilityCommands = []struct {
+	capability Capability
+	command    string
+}{
+	{CapabilityStart, "gcectl on"},
+	{CapabilityStop, "gcectl off"},
+	{CapabilityReset, "gcectl reset"},
+	{CapabilityChangeMachineType, "gcectl set machine-type"},
+	{CapabilityGPU, "gcectl set gpu"},
+	{CapabilitySchedulePolicy, "gcectl set schedule-policy"},
+	{CapabilityEdit, "gcectl edit"},
+	{CapabilityIP, "gcectl ip"},
+	{CapabilitySSH, "gcectl ssh-config / on --wait-ssh"},
+}
+
+// ErrUnsupported is matched by every CapabilityError via errors.Is.
+var ErrUnsupported = errors.New("operation not supported")
+
+// CapabilityError reports that an operation is not available for a VM,
+// either because of its current state or because GCE rejected it as unsupported.
+type CapabilityError struct {
+	VMName     string
+	Capability Capability
+	Reason     string
+}
+
+func (e *CapabilityError) Error() string {
+	return fmt.Sprintf("VM %s: %s is not supported: %s", e.VMName, e.Capability, e.Reason)
+}
+
+// Is makes errors.Is(err, ErrUnsupported) true for any CapabilityError.
+func (e *CapabilityError) Is(target error) bool {
+	return target == ErrUnsupported
+}
+
+// CapabilityStatus describes whether a single capability is currently available.
+type CapabilityStatus struct {
+	Capability Capability
+	Command    string
+	// Reason explains why the capability is unavailable. Empty when Supported is true.
+	Reason    string
+	Supported bool
+}
+
+// Capabilities evaluates every known capability against the VM's current state.
+//
+// Returns:
+//   - []CapabilityStatus: One entry per capability, in a stable display order
+func (v *VM) Capabilities() []CapabilityStatus {
+	statuses := make([]CapabilityStatus, 0, len(capabilityCommands))
+	for _, c := range capabilityCommands {
+		reason := v.unsupportedReason(c.capability)
+		statuses = append(statuses, CapabilityStatus{
+			Capability: c.capability,
+			Command:    c.command,
+			Reason:     reason,
+			Supported:  reason == "",
+		})
+	}
+	return statuses
+}
+
+// Check returns a *CapabilityError if the capability is not available for the VM.
+func (v *VM) Check(c Capability) error {
+	if reason := v.unsupportedReason(c); reason != "" {
+		return &CapabilityError{VMName: v.Name, Capability: c, Reason: reason}
+	}
+	return nil
+}
+
+// unsupportedReason returns why c is unavailable, or "" if it is available.
+func (v *VM) unsupportedReason(c Capability) string {
+	switch c {
+	case CapabilityStart:
+		if !v.CanStart() {
+			return fmt.Sprintf("VM is %s, must be STOPPED or TERMINATED", v.Status)
+		}
+	case CapabilityStop, CapabilityReset:
+		if !v.CanStop() {
+			return fmt.Sprintf("VM is %s, must be RUNNING", v.Status)
+		}
+	case CapabilityChangeMachineType, CapabilityGPU:
+		if !v.CanChangeMachineType() {
+			return fmt.Sprintf("VM is %s, must be stopped first", v.Status)
+		}
+	case CapabilitySchedulePolicy, CapabilityEdit:
+		if v.Status == StatusUnknown {
+			return "VM status is unknown"
+		}
+	case CapabilityIP:
+		if v.InternalIP == "" && v.ExternalIP == "" {
+			return "VM has no IP address"
+		}
+	case CapabilitySSH:
+		if v.ExternalIP == "" {
+			return "VM has no external IP"
+		}
+	default:
+		return "unknown capability"
+	}
+	return ""
+}
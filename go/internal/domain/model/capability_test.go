@@ -0,0 +1,74 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVM_Capabilities(t *testing.T) {
+	tests := []struct {
+		name          string
+		vm            *VM
+		wantSupported map[Capability]bool
+	}{
+		{
+			name: "running VM with external IP",
+			vm:   &VM{Name: "vm", Status: StatusRunning, InternalIP: "10.0.0.2", ExternalIP: "34.1.2.3"},
+			wantSupported: map[Capability]bool{
+				CapabilityStart:             false,
+				CapabilityStop:              true,
+				CapabilityReset:             true,
+				CapabilityChangeMachineType: false,
+				CapabilityGPU:               false,
+				CapabilitySchedulePolicy:    true,
+				CapabilityEdit:              true,
+				CapabilityIP:                true,
+				CapabilitySSH:               true,
+			},
+		},
+		{
+			name: "stopped VM without IPs",
+			vm:   &VM{Name: "vm", Status: StatusTerminated},
+			wantSupported: map[Capability]bool{
+				CapabilityStart:             true,
+				CapabilityStop:              false,
+				CapabilityReset:             false,
+				CapabilityChangeMachineType: true,
+				CapabilityGPU:               true,
+				CapabilitySchedulePolicy:    true,
+				CapabilityEdit:              true,
+				CapabilityIP:                false,
+				CapabilitySSH:               false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.vm.Capabilities()
+			require.Len(t, got, len(tt.wantSupported))
+			for _, status := range got {
+				assert.Equal(t, tt.wantSupported[status.Capability], status.Supported, "capability %s", status.Capability)
+				assert.NotEmpty(t, status.Command)
+				assert.Equal(t, status.Supported, status.Reason == "", "reason must be set only when unsupported")
+			}
+		})
+	}
+}
+
+func TestVM_Check(t *testing.T) {
+	vm := &VM{Name: "sandbox", Status: StatusRunning}
+
+	require.NoError(t, vm.Check(CapabilityStop))
+
+	err := vm.Check(CapabilityChangeMachineType)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrUnsupported))
+	var capErr *CapabilityError
+	require.ErrorAs(t, err, &capErr)
+	assert.Equal(t, CapabilityChangeMachineType, capErr.Capability)
+	assert.Equal(t, "VM sandbox: set-machine-type is not supported: VM is RUNNING, must be stopped first", err.Error())
+}
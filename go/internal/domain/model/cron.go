@@ -0,0 +1,191 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchDays bounds how far ahead Next looks for a match. Every valid
+// expression (even "0 0 29 2 *") matches within this window.
+const cronSearchDays = 5 * 366
+
+// CronSchedule is a parsed five-field cron expression:
+// minute, hour, day of month, month and day of week.
+//
+// Each field accepts "*", single values, ranges ("1-5"), steps ("*/15", "0-30/10")
+// and comma-separated lists. Months and days of week also accept three-letter
+// names (JAN-DEC, SUN-SAT); day of week 7 is Sunday.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domAny and dowAny record whether the day fields were "*". When both day
+	// fields are restricted a day matches if either does, as in standard cron.
+	domAny, dowAny bool
+}
+
+type cronField struct {
+	names    map[string]int
+	label    string
+	min, max int
+}
+
+var (
+	cronMinute = cronField{label: "minute", min: 0, max: 59}
+	cronHour   = cronField{label: "hour", min: 0, max: 23}
+	cronDom    = cronField{label: "day-of-month", min: 1, max: 31}
+	cronMonth  = cronField{label: "month", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}}
+	cronDow = cronField{label: "day-of-week", min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}}
+)
+
+// ParseCron parses a five-field cron expression.
+//
+// Parameters:
+//   - expr: The cron expression (e.g., "0 19 * * 1-5")
+//
+// Returns:
+//   - *CronSchedule: The parsed schedule
+//   - error: An error describing the first invalid field
+func ParseCron(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields (minute hour day-of-month month day-of-week), got %d", len(fields))
+	}
+
+	c := &CronSchedule{
+		domAny: strings.HasPrefix(fields[2], "*"),
+		dowAny: strings.HasPrefix(fields[4], "*"),
+	}
+	var err error
+	if c.minute, err = cronMinute.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if c.hour, err = cronHour.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if c.dom, err = cronDom.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if c.month, err = cronMonth.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if c.dow, err = cronDow.parse(fields[4]); err != nil {
+		return nil, err
+	}
+	// 7 is an alias for Sunday.
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	return c, nil
+}
+
+// Next returns the first time strictly after t that matches the schedule, in t's location.
+// It returns the zero time if there is no match within the search window.
+func (c *CronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	for i := 0; i < cronSearchDays; i++ {
+		d := day.AddDate(0, 0, i)
+		if !c.matchesDay(d) {
+			continue
+		}
+		for h := 0; h < 24; h++ {
+			if c.hour&(1<<uint(h)) == 0 {
+				continue
+			}
+			for m := 0; m < 60; m++ {
+				if c.minute&(1<<uint(m)) == 0 {
+					continue
+				}
+				candidate := time.Date(d.Year(), d.Month(), d.Day(), h, m, 0, 0, loc)
+				if candidate.After(t) {
+					return candidate
+				}
+			}
+		}
+	}
+	return time.Time{}
+}
+
+func (c *CronSchedule) matchesDay(d time.Time) bool {
+	if c.month&(1<<uint(d.Month())) == 0 {
+		return false
+	}
+	domMatch := c.dom&(1<<uint(d.Day())) != 0
+	dowMatch := c.dow&(1<<uint(d.Weekday())) != 0
+	if c.domAny || c.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// parse converts one field into a bitset of the values it matches.
+func (f cronField) parse(field string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		lo, hi, step, err := f.parseRange(part)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s %q: %w", f.label, field, err)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// parseRange parses "*", "v", "a-b" with an optional "/step".
+func (f cronField) parseRange(part string) (lo, hi, step int, err error) {
+	rangePart, stepPart, hasStep := strings.Cut(part, "/")
+	step = 1
+	if hasStep {
+		if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+			return 0, 0, 0, fmt.Errorf("invalid step %q", stepPart)
+		}
+	}
+
+	switch {
+	case rangePart == "*":
+		return f.min, f.max, step, nil
+	case strings.Contains(rangePart, "-"):
+		loPart, hiPart, _ := strings.Cut(rangePart, "-")
+		if lo, err = f.value(loPart); err != nil {
+			return 0, 0, 0, err
+		}
+		if hi, err = f.value(hiPart); err != nil {
+			return 0, 0, 0, err
+		}
+		if lo > hi {
+			return 0, 0, 0, fmt.Errorf("range %q is reversed", rangePart)
+		}
+		return lo, hi, step, nil
+	default:
+		if lo, err = f.value(rangePart); err != nil {
+			return 0, 0, 0, err
+		}
+		// "5/15" means from 5 to the end of the range in steps of 15.
+		if hasStep {
+			return lo, f.max, step, nil
+		}
+		return lo, lo, step, nil
+	}
+}
+
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToUpper(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", v, f.min, f.max)
+	}
+	return v, nil
+}
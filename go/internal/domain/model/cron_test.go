@@ -0,0 +1,79 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr string
+	}{
+		{name: "too few fields", expr: "0 9 * *", wantErr: "expected 5 fields"},
+		{name: "minute out of range", expr: "60 9 * * *", wantErr: "invalid minute"},
+		{name: "hour out of range", expr: "0 24 * * *", wantErr: "invalid hour"},
+		{name: "day of month zero", expr: "0 9 0 * *", wantErr: "invalid day-of-month"},
+		{name: "unknown month name", expr: "0 9 * FOO *", wantErr: "invalid month"},
+		{name: "reversed range", expr: "0 9 * * 5-1", wantErr: "reversed"},
+		{name: "zero step", expr: "*/0 9 * * *", wantErr: "invalid step"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCron(tt.expr)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	// Wednesday 2024-01-10 15:48 UTC.
+	now := time.Date(2024, 1, 10, 15, 48, 30, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		expr string
+		want time.Time
+	}{
+		{name: "later today", expr: "0 19 * * *", want: time.Date(2024, 1, 10, 19, 0, 0, 0, time.UTC)},
+		{name: "tomorrow", expr: "0 9 * * *", want: time.Date(2024, 1, 11, 9, 0, 0, 0, time.UTC)},
+		{name: "next minute excludes now", expr: "* * * * *", want: time.Date(2024, 1, 10, 15, 49, 0, 0, time.UTC)},
+		{name: "step", expr: "*/15 * * * *", want: time.Date(2024, 1, 10, 16, 0, 0, 0, time.UTC)},
+		{name: "weekdays skip weekend", expr: "0 9 * * MON-FRI", want: time.Date(2024, 1, 11, 9, 0, 0, 0, time.UTC)},
+		{name: "sunday as 7", expr: "0 9 * * 7", want: time.Date(2024, 1, 14, 9, 0, 0, 0, time.UTC)},
+		{name: "list", expr: "0 8,20 * * *", want: time.Date(2024, 1, 10, 20, 0, 0, 0, time.UTC)},
+		{name: "month name", expr: "0 0 1 MAR *", want: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "leap day", expr: "0 0 29 2 *", want: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{name: "day of month or day of week", expr: "0 9 15 * SAT", want: time.Date(2024, 1, 13, 9, 0, 0, 0, time.UTC)},
+		{name: "impossible date", expr: "0 0 31 2 *", want: time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseCron(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c.Next(now))
+		})
+	}
+}
+
+func TestSchedulePolicy_NextStop_UsesTimeZone(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	p := &SchedulePolicy{StopCron: "0 19 * * *", TimeZone: "Asia/Tokyo"}
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC) // 09:00 in Tokyo
+
+	next, ok := p.NextStop(now)
+	require.True(t, ok)
+	assert.True(t, next.Equal(time.Date(2024, 1, 10, 19, 0, 0, 0, tokyo)))
+
+	_, ok = p.NextStart(now)
+	assert.False(t, ok, "policy without start-cron has no next start")
+}
@@ -0,0 +1,144 @@
+package model
+
+import "fmt"
+
+// DesiredStatus is the lifecycle state `gcectl apply` keeps a VM in.
+type DesiredStatus string
+
+const (
+	// DesiredRunning keeps the VM running.
+	DesiredRunning DesiredStatus = "running"
+	// DesiredStopped keeps the VM stopped.
+	DesiredStopped DesiredStatus = "stopped"
+)
+
+// ParseDesiredStatus validates s as a DesiredStatus. An empty s leaves the status unmanaged.
+func ParseDesiredStatus(s string) (DesiredStatus, error) {
+	switch DesiredStatus(s) {
+	case "", DesiredRunning, DesiredStopped:
+		return DesiredStatus(s), nil
+	}
+	return "", fmt.Errorf("desired-state must be %s or %s: %q", DesiredRunning, DesiredStopped, s)
+}
+
+// DesiredState is the state config declares for a VM. Empty fields are not managed.
+type DesiredState struct {
+	Status      DesiredStatus
+	MachineType string
+	// SchedulePolicy is the name of the instance schedule policy attached to the VM.
+	SchedulePolicy string
+}
+
+// IsZero reports whether the state manages nothing.
+func (d DesiredState) IsZero() bool {
+	return d.Status == "" && d.MachineType == "" && d.SchedulePolicy == ""
+}
+
+// ApplyActionType is the operation an ApplyAction performs.
+type ApplyActionType string
+
+const (
+	// ApplyStart starts the VM.
+	ApplyStart ApplyActionType = "start"
+	// ApplyStop stops the VM.
+	ApplyStop ApplyActionType = "stop"
+	// ApplySetMachineType changes the machine type of the VM, restarting it if it is running.
+	ApplySetMachineType ApplyActionType = "set-machine-type"
+	// ApplySetSchedulePolicy attaches the schedule policy to the VM in place of its current one.
+	ApplySetSchedulePolicy ApplyActionType = "set-schedule-policy"
+)
+
+// ApplyAction is one step that brings a VM closer to its DesiredState.
+type ApplyAction struct {
+	VM   *VM
+	Type ApplyActionType
+	// From and To are the current and desired values, e.g. the machine types.
+	From string
+	To   string
+}
+
+// Plan returns the actions that bring vm, in its current state, to d, in the
+// order they must run.
+//
+// A VM that should stop is stopped before its machine type is changed, and one
+// that should run is started after, so it is never restarted needlessly. A VM
+// in a transitional state such as PROVISIONING is left to settle and gets no
+// start or stop.
+//
+// Parameters:
+//   - vm: The VM as it currently is
+//
+// Returns:
+//   - []ApplyAction: The actions to run; empty when vm already matches d
+func (d DesiredState) Plan(vm *VM) []ApplyAction {
+	var actions []ApplyAction
+	if d.Status == DesiredStopped && vm.CanStop() {
+		actions = append(actions, ApplyAction{VM: vm, Type: ApplyStop, From: vm.Status.String(), To: string(DesiredStopped)})
+	}
+	if d.MachineType != "" && d.MachineType != vm.MachineType {
+		actions = append(actions, ApplyAction{VM: vm, Type: ApplySetMachineType, From: vm.MachineType, To: d.MachineType})
+	}
+	if current := schedulePolicyName(vm); d.SchedulePolicy != "" && d.SchedulePolicy != current {
+		actions = append(actions, ApplyAction{VM: vm, Type: ApplySetSchedulePolicy, From: current, To: d.SchedulePolicy})
+	}
+	if d.Status == DesiredRunning && vm.CanStart() {
+		actions = append(actions, ApplyAction{VM: vm, Type: ApplyStart, From: vm.Status.String(), To: string(DesiredRunning)})
+	}
+	return actions
+}
+
+// FieldDrift is a setting of a VM that differs from its DesiredState.
+type FieldDrift struct {
+	// Field is the config key, e.g. "machine-type".
+	Field   string
+	Current string
+	Desired string
+}
+
+// VMDrift lists how a VM differs from its DesiredState. Fields is empty when it matches.
+type VMDrift struct {
+	VM     *VM
+	Fields []FieldDrift
+}
+
+// Drift returns the settings of vm that differ from d, in the order status,
+// machine-type, schedule-policy. Unlike Plan it also reports a VM in a
+// transitional state such as PROVISIONING as drifted from its desired status.
+//
+// Parameters:
+//   - vm: The VM as it currently is
+//
+// Returns:
+//   - VMDrift: The differences of vm; empty Fields when it matches d
+func (d DesiredState) Drift(vm *VM) VMDrift {
+	drift := VMDrift{VM: vm}
+	if d.Status != "" && !d.Status.matches(vm.Status) {
+		drift.Fields = append(drift.Fields, FieldDrift{Field: "desired-state", Current: vm.Status.String(), Desired: string(d.Status)})
+	}
+	if d.MachineType != "" && d.MachineType != vm.MachineType {
+		drift.Fields = append(drift.Fields, FieldDrift{Field: "machine-type", Current: vm.MachineType, Desired: d.MachineType})
+	}
+	if current := schedulePolicyName(vm); d.SchedulePolicy != "" && d.SchedulePolicy != current {
+		drift.Fields = append(drift.Fields, FieldDrift{Field: "schedule-policy", Current: current, Desired: d.SchedulePolicy})
+	}
+	return drift
+}
+
+// matches reports whether a VM in status is in the desired status.
+func (s DesiredStatus) matches(status Status) bool {
+	switch s {
+	case DesiredRunning:
+		return status == StatusRunning
+	case DesiredStopped:
+		return status == StatusStopped || status == StatusTerminated
+	}
+	return true
+}
+
+// schedulePolicyName returns the name of the schedule policy attached to vm, or "".
+func schedulePolicyName(vm *VM) string {
+	if vm.Schedule == nil {
+		return ""
+	}
+	return vm.Schedule.Name
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDesiredStatus(t *testing.T) {
+	for _, s := range []string{"", "running", "stopped"} {
+		status, err := ParseDesiredStatus(s)
+		require.NoError(t, err)
+		assert.Equal(t, DesiredStatus(s), status)
+	}
+	_, err := ParseDesiredStatus("RUNNING")
+	require.Error(t, err)
+}
+
+func TestDesiredState_Plan(t *testing.T) {
+	actionTypes := func(actions []ApplyAction) []ApplyActionType {
+		var types []ApplyActionType
+		for _, a := range actions {
+			types = append(types, a.Type)
+		}
+		return types
+	}
+	tests := []struct {
+		name    string
+		desired DesiredState
+		vm      *VM
+		want    []ApplyActionType
+	}{
+		{name: "unmanaged", desired: DesiredState{}, vm: &VM{Status: StatusRunning, MachineType: "e2-medium"}},
+		{name: "already running", desired: DesiredState{Status: DesiredRunning}, vm: &VM{Status: StatusRunning}},
+		{name: "start", desired: DesiredState{Status: DesiredRunning}, vm: &VM{Status: StatusTerminated}, want: []ApplyActionType{ApplyStart}},
+		{name: "stop", desired: DesiredState{Status: DesiredStopped}, vm: &VM{Status: StatusRunning}, want: []ApplyActionType{ApplyStop}},
+		{name: "provisioning is left alone", desired: DesiredState{Status: DesiredStopped}, vm: &VM{Status: StatusProvisioning}},
+		{
+			name:    "stop before resize",
+			desired: DesiredState{Status: DesiredStopped, MachineType: "e2-small"},
+			vm:      &VM{Status: StatusRunning, MachineType: "e2-medium"},
+			want:    []ApplyActionType{ApplyStop, ApplySetMachineType},
+		},
+		{
+			name:    "resize before start",
+			desired: DesiredState{Status: DesiredRunning, MachineType: "e2-small"},
+			vm:      &VM{Status: StatusTerminated, MachineType: "e2-medium"},
+			want:    []ApplyActionType{ApplySetMachineType, ApplyStart},
+		},
+		{
+			name:    "replace schedule policy",
+			desired: DesiredState{SchedulePolicy: "weekday-stop"},
+			vm:      &VM{Status: StatusRunning, Schedule: &SchedulePolicy{Name: "nightly-stop"}},
+			want:    []ApplyActionType{ApplySetSchedulePolicy},
+		},
+		{
+			name:    "resize keeps status",
+			desired: DesiredState{MachineType: "e2-small"},
+			vm:      &VM{Status: StatusRunning, MachineType: "e2-medium"},
+			want:    []ApplyActionType{ApplySetMachineType},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, actionTypes(tt.desired.Plan(tt.vm)))
+		})
+	}
+
+	actions := DesiredState{MachineType: "e2-small"}.Plan(&VM{Status: StatusRunning, MachineType: "e2-medium"})
+	assert.Equal(t, "e2-medium", actions[0].From)
+	assert.Equal(t, "e2-small", actions[0].To)
+}
+
+func TestDesiredState_Drift(t *testing.T) {
+	desired := DesiredState{Status: DesiredRunning, MachineType: "e2-small", SchedulePolicy: "weekday-stop"}
+
+	drift := desired.Drift(&VM{Status: StatusProvisioning, MachineType: "e2-medium"})
+	assert.Equal(t, []FieldDrift{
+		{Field: "desired-state", Current: "PROVISIONING", Desired: "running"},
+		{Field: "machine-type", Current: "e2-medium", Desired: "e2-small"},
+		{Field: "schedule-policy", Current: "", Desired: "weekday-stop"},
+	}, drift.Fields)
+
+	inSync := desired.Drift(&VM{Status: StatusRunning, MachineType: "e2-small", Schedule: &SchedulePolicy{Name: "weekday-stop"}})
+	assert.Empty(t, inSync.Fields)
+	assert.Empty(t, DesiredState{Status: DesiredStopped}.Drift(&VM{Status: StatusStopped}).Fields)
+}
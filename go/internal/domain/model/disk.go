@@ -0,0 +1,28 @@
+package model
+
+// Disk is a persistent disk attached to a VM.
+//
+//nolint:govet // Field order optimized for readability over memory alignment
+type Disk struct {
+	Name string
+	Zone string
+	// DeviceName is the name the guest sees the disk as (/dev/disk/by-id/google-<DeviceName>).
+	DeviceName string
+	// Type is the disk type (e.g., "pd-balanced"), empty when only the attachment is known.
+	Type string
+	// Mode is READ_WRITE or READ_ONLY.
+	Mode       string
+	SizeGB     int64
+	Boot       bool
+	AutoDelete bool
+}
+
+// FindDisk returns the disk whose name or device name is nameOrDevice, or nil.
+func FindDisk(disks []*Disk, nameOrDevice string) *Disk {
+	for _, d := range disks {
+		if d.Name == nameOrDevice || d.DeviceName == nameOrDevice {
+			return d
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors the CLI gives a remediation hint for. Repositories and the config wrap
+// the underlying failure with them, so errors.Is identifies the failure type
+// while the message keeps the details.
+var (
+	// ErrVMNotFoundInConfig means a VM name given on the command line is not in the config file.
+	ErrVMNotFoundInConfig = errors.New("VM not found in config")
+	// ErrVMNotFound means a VM does not exist on GCP, e.g. it was deleted or is
+	// in another zone than the config says.
+	ErrVMNotFound = errors.New("not found")
+	// ErrInvalidState means a VM is not in a status the action accepts, e.g. a
+	// RUNNING VM asked to start. See InvalidStateErrorf.
+	ErrInvalidState = errors.New("VM is in a state the action cannot act on")
+	// ErrPermissionDenied means the credentials lack an IAM permission the request needs.
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrQuotaExceeded means the request would exceed a project or regional quota, e.g. CPUs or GPUs.
+	ErrQuotaExceeded = errors.New("quota exceeded")
+	// ErrWaitTimeout means a command gave up waiting for VMs to reach a state,
+	// e.g. `gcectl wait` past its --wait-timeout.
+	ErrWaitTimeout = errors.New("gave up waiting")
+)
+
+// InvalidStateErrorf formats an error naming the VM and its status, e.g.
+// "VM my-vm: cannot be started (current status: RUNNING)", that errors.Is
+// matches with ErrInvalidState.
+func InvalidStateErrorf(format string, args ...any) error {
+	return &invalidStateError{msg: fmt.Sprintf(format, args...)}
+}
+
+type invalidStateError struct {
+	msg string
+}
+
+func (e *invalidStateError) Error() string {
+	return e.msg
+}
+
+func (e *invalidStateError) Is(target error) bool {
+	return target == ErrInvalidState
+}
@@ -0,0 +1,17 @@
+package model
+
+// InstanceMetrics is the average resource usage of a VM over a time window.
+// A nil field means no data point was reported in the window.
+type InstanceMetrics struct {
+	// CPUUtilization is the fraction (0-1) of the VM's vCPUs in use.
+	CPUUtilization *float64
+	// MemoryUtilization is the fraction (0-1) of memory in use. It is only
+	// reported by VMs running the Ops Agent.
+	MemoryUtilization *float64
+	// DiskReadBytesPerSec and DiskWriteBytesPerSec are summed over all disks.
+	DiskReadBytesPerSec  *float64
+	DiskWriteBytesPerSec *float64
+	// NetworkInBytesPerSec and NetworkOutBytesPerSec are summed over all interfaces.
+	NetworkInBytesPerSec  *float64
+	NetworkOutBytesPerSec *float64
+}
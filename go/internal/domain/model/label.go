@@ -0,0 +1,70 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MaxLabels is the maximum number of labels a GCE resource can have.
+const MaxLabels = 64
+
+var (
+	// labelKeyPattern matches valid GCE label keys.
+	labelKeyPattern = regexp.MustCompile(`^[a-z][-_a-z0-9]{0,62}$`)
+	// labelValuePattern matches valid GCE label values, which may be empty.
+	labelValuePattern = regexp.MustCompile(`^[-_a-z0-9]{0,63}$`)
+)
+
+// ValidateLabel returns an error if key or value is not valid for a GCE label.
+func ValidateLabel(key, value string) error {
+	if !labelKeyPattern.MatchString(key) {
+		return fmt.Errorf("invalid label key %q: must be 1-63 lowercase letters, digits, underscores or hyphens and start with a letter", key)
+	}
+	if !labelValuePattern.MatchString(value) {
+		return fmt.Errorf("invalid value for label %s: %q must be at most 63 lowercase letters, digits, underscores or hyphens", key, value)
+	}
+	return nil
+}
+
+// FormatLabels returns labels as "key=value" pairs sorted by key and joined with ", ".
+func FormatLabels(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+labels[k])
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// ParseLabelSelector parses a selector such as "env=dev,team=ml" into the labels a
+// VM must all have to match it.
+//
+// Parameters:
+//   - selector: Comma-separated key=value pairs
+//
+// Returns:
+//   - map[string]string: The required labels keyed by label key
+//   - error: An error if a pair is malformed, invalid for a GCE label or repeats a key
+func ParseLabelSelector(selector string) (map[string]string, error) {
+	labels := make(map[string]string)
+	for _, pair := range strings.Split(selector, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid selector %q: %q is not key=value", selector, pair)
+		}
+		if err := ValidateLabel(key, value); err != nil {
+			return nil, fmt.Errorf("invalid selector %q: %w", selector, err)
+		}
+		if _, dup := labels[key]; dup {
+			return nil, fmt.Errorf("invalid selector %q: label %s is given twice", selector, key)
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateLabel(t *testing.T) {
+	tests := []struct {
+		key     string
+		value   string
+		wantErr bool
+	}{
+		{key: "team", value: "ml-platform"},
+		{key: "cost_center", value: ""},
+		{key: "env", value: "dev_01"},
+		{key: "Team", value: "ml", wantErr: true},
+		{key: "1team", value: "ml", wantErr: true},
+		{key: "", value: "ml", wantErr: true},
+		{key: "team", value: "ML", wantErr: true},
+		{key: "team", value: "a.b", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateLabel(tt.key, tt.value)
+		if tt.wantErr {
+			assert.Error(t, err, "%s=%s", tt.key, tt.value)
+		} else {
+			assert.NoError(t, err, "%s=%s", tt.key, tt.value)
+		}
+	}
+}
+
+func TestFormatLabels(t *testing.T) {
+	assert.Equal(t, "", FormatLabels(nil))
+	assert.Equal(t, "env=dev, team=ml", FormatLabels(map[string]string{"team": "ml", "env": "dev"}))
+}
+
+func TestParseLabelSelector(t *testing.T) {
+	labels, err := ParseLabelSelector("env=dev, team=ml")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "dev", "team": "ml"}, labels)
+
+	labels, err = ParseLabelSelector("spot=")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"spot": ""}, labels)
+
+	for _, selector := range []string{"", "env", "env=dev,env=prod", "Env=dev", "env=Dev"} {
+		_, err := ParseLabelSelector(selector)
+		assert.Error(t, err, selector)
+	}
+}
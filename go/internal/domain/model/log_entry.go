@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// LogEntry is a log entry written by or about a VM.
+type LogEntry struct {
+	Timestamp time.Time
+	// Severity is the Cloud Logging severity (e.g. "INFO", "ERROR"), or "DEFAULT".
+	Severity string
+	// Log is the short log name (e.g. "syslog").
+	Log string
+	// Message is the text of the entry, or its structured payload as JSON.
+	Message string
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// resourceNamePattern matches valid GCE resource names (RFC 1035 labels).
+var resourceNamePattern = regexp.MustCompile(`^[a-z]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+// ValidateResourceName returns an error if name is not a valid GCE resource name.
+func ValidateResourceName(name string) error {
+	if !resourceNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid name %q: must be 1-63 lowercase letters, digits or hyphens, start with a letter and not end with a hyphen", name)
+	}
+	return nil
+}
+
+// MachineImageName returns a machine image name for cloning vm at t,
+// e.g. "sandbox-20250102-150405".
+func MachineImageName(vm string, t time.Time) string {
+	return timestampedName(vm, t)
+}
@@ -0,0 +1,17 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateResourceName(t *testing.T) {
+	assert.NoError(t, ValidateResourceName("sandbox-clone"))
+	assert.NoError(t, ValidateResourceName("a"))
+	assert.Error(t, ValidateResourceName("Sandbox"))
+	assert.Error(t, ValidateResourceName("1sandbox"))
+	assert.Error(t, ValidateResourceName("sandbox-"))
+	assert.Error(t, ValidateResourceName(strings.Repeat("a", 64)))
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MachineType is a machine type available in a zone.
+type MachineType struct {
+	Name        string
+	Zone        string
+	Description string
+	GuestCPUs   int
+	MemoryMB    int
+}
+
+// Family returns the machine family, the part of the name before the first hyphen
+// (e.g., "e2" for "e2-standard-4").
+func (m *MachineType) Family() string {
+	family, _, _ := strings.Cut(m.Name, "-")
+	return family
+}
+
+// CustomMachineType is a custom machine type built from a vCPU count and memory size.
+type CustomMachineType struct {
+	// Family is the machine family (e.g., "n1", "n2", "e2"). Empty means n1.
+	Family   string
+	VCPUs    int
+	MemoryMB int
+}
+
+// Name returns the GCE machine type name, e.g. "custom-8-32768" for N1 or
+// "e2-custom-8-32768" for other families.
+func (c CustomMachineType) Name() string {
+	if c.Family == "" || c.Family == "n1" {
+		return fmt.Sprintf("custom-%d-%d", c.VCPUs, c.MemoryMB)
+	}
+	return fmt.Sprintf("%s-custom-%d-%d", c.Family, c.VCPUs, c.MemoryMB)
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+)
+
+const (
+	// StartupScriptKey is the metadata key of the script a VM runs on every boot.
+	StartupScriptKey = "startup-script"
+	// MaxMetadataValueBytes is the maximum size of a single metadata value.
+	MaxMetadataValueBytes = 256 * 1024
+	// MaxMetadataBytes is the maximum total size of all metadata keys and values of a VM.
+	MaxMetadataBytes = 512 * 1024
+)
+
+// metadataKeyPattern matches valid GCE metadata keys.
+var metadataKeyPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,128}$`)
+
+// ValidateMetadataItem returns an error if key or value is not valid for a GCE metadata item.
+func ValidateMetadataItem(key, value string) error {
+	if !metadataKeyPattern.MatchString(key) {
+		return fmt.Errorf("invalid metadata key %q: must be 1-128 letters, digits, underscores or hyphens", key)
+	}
+	if len(value) > MaxMetadataValueBytes {
+		return fmt.Errorf("value of metadata %s is %d bytes, at most %d are allowed", key, len(value), MaxMetadataValueBytes)
+	}
+	return nil
+}
+
+// MetadataSize returns the total size of the metadata keys and values in bytes,
+// as counted against MaxMetadataBytes.
+func MetadataSize(items map[string]string) int {
+	size := 0
+	for k, v := range items {
+		size += len(k) + len(v)
+	}
+	return size
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMetadataItem(t *testing.T) {
+	assert.NoError(t, ValidateMetadataItem("enable-oslogin", "TRUE"))
+	assert.NoError(t, ValidateMetadataItem("startup_script_URL", ""))
+	assert.Error(t, ValidateMetadataItem("", "x"))
+	assert.Error(t, ValidateMetadataItem("team.name", "x"))
+	assert.Error(t, ValidateMetadataItem(strings.Repeat("k", 129), "x"))
+	assert.Error(t, ValidateMetadataItem(StartupScriptKey, strings.Repeat("x", MaxMetadataValueBytes+1)))
+}
+
+func TestMetadataSize(t *testing.T) {
+	assert.Equal(t, 0, MetadataSize(nil))
+	assert.Equal(t, 9, MetadataSize(map[string]string{"team": "ml", "env": ""}))
+}
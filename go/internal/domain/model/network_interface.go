@@ -0,0 +1,20 @@
+package model
+
+// NetworkInterface is a network interface of a VM.
+type NetworkInterface struct {
+	// Name is the interface name GCE assigns, e.g. "nic0".
+	Name string
+	// Network and Subnetwork are the short names of the VPC network and subnet.
+	Network    string
+	Subnetwork string
+	InternalIP string
+	// ExternalIP is empty when the interface has no external address.
+	ExternalIP string
+}
+
+// ServiceAccount is the service account a VM runs as, with its OAuth scopes.
+type ServiceAccount struct {
+	Email string
+	// Scopes are the OAuth scope URLs granted to the VM.
+	Scopes []string
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventType identifies a kind of event that can be routed to notifier backends.
+type EventType string
+
+const (
+	// EventOperationSuccess is emitted when a VM operation (start, stop, ...) succeeds.
+	EventOperationSuccess EventType = "operation-success"
+	// EventOperationFailure is emitted when a VM operation fails.
+	EventOperationFailure EventType = "operation-failure"
+	// EventGuardAction is emitted when a safety guard blocks or alters an action.
+	EventGuardAction EventType = "guard-action"
+	// EventPreemption is emitted when a Spot/preemptible VM is preempted.
+	EventPreemption EventType = "preemption"
+	// EventAutoStop is emitted when a scheduled stop, e.g. from 'on --ttl', stops a VM.
+	EventAutoStop EventType = "auto-stop"
+)
+
+// EventTypes lists every known event type.
+var EventTypes = []EventType{
+	EventOperationSuccess,
+	EventOperationFailure,
+	EventGuardAction,
+	EventPreemption,
+	EventAutoStop,
+}
+
+// IsValid reports whether t is one of the known event types.
+func (t EventType) IsValid() bool {
+	for _, known := range EventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is a single notification payload.
+type Event struct {
+	Time    time.Time
+	Type    EventType
+	VMName  string
+	Message string
+}
+
+// Summary returns a one-line description of the event suitable for a chat message or mail subject.
+func (e Event) Summary() string {
+	if e.VMName == "" {
+		return fmt.Sprintf("[gcectl] %s: %s", e.Type, e.Message)
+	}
+	return fmt.Sprintf("[gcectl] %s: %s: %s", e.Type, e.VMName, e.Message)
+}
+
+// Notifier backend types accepted in the config file.
+const (
+	NotifierTypeSlack   = "slack"
+	NotifierTypeDiscord = "discord"
+	NotifierTypeEmail   = "email"
+	NotifierTypeWebhook = "webhook"
+)
+
+// NotifierConfig holds the settings of a single named notifier backend.
+// Only the fields relevant to Type are used.
+type NotifierConfig struct {
+	Type string
+	// WebhookURL is used by the slack, discord and webhook backends.
+	WebhookURL string
+	// Headers are added to the requests of the webhook backend, e.g. for authorization.
+	Headers map[string]string
+	// SMTP settings are used by the email backend.
+	SMTPHost string
+	Username string
+	// PasswordEnv names the environment variable holding the SMTP password.
+	PasswordEnv string
+	From        string
+	To          []string
+	SMTPPort    int
+}
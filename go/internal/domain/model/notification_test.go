@@ -0,0 +1,23 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventType_IsValid(t *testing.T) {
+	assert.True(t, EventOperationSuccess.IsValid())
+	assert.True(t, EventPreemption.IsValid())
+	assert.True(t, EventAutoStop.IsValid())
+	assert.False(t, EventType("vm-deleted").IsValid())
+	assert.False(t, EventType("").IsValid())
+}
+
+func TestEvent_Summary(t *testing.T) {
+	withVM := Event{Type: EventOperationFailure, VMName: "sandbox", Message: "failed to start"}
+	assert.Equal(t, "[gcectl] operation-failure: sandbox: failed to start", withVM.Summary())
+
+	withoutVM := Event{Type: EventGuardAction, Message: "start blocked"}
+	assert.Equal(t, "[gcectl] guard-action: start blocked", withoutVM.Summary())
+}
@@ -0,0 +1,120 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Operation represents a zonal long-running GCE operation, such as starting an instance.
+type Operation struct {
+	// InsertTime and EndTime are nil when unknown or, for EndTime, when not finished yet.
+	InsertTime *time.Time
+	EndTime    *time.Time
+	Name       string
+	Project    string
+	Zone       string
+	// Type is the API operation type (e.g., "start", "stop").
+	Type string
+	// Target is the name of the instance the operation acts on.
+	Target string
+	// Status is PENDING, RUNNING or DONE.
+	Status string
+	// Error is the operation error message, empty if the operation succeeded or is not done.
+	Error string
+	// User is the principal that requested the operation.
+	User string
+	// Progress is an optional completion percentage reported by GCE (0-100).
+	Progress int
+}
+
+// Path returns the fully-qualified operation path that ParseOperationPath accepts.
+func (o *Operation) Path() string {
+	return fmt.Sprintf("projects/%s/zones/%s/operations/%s", o.Project, o.Zone, o.Name)
+}
+
+// IsDone reports whether the operation has finished, successfully or not.
+func (o *Operation) IsDone() bool {
+	return o.Status == "DONE"
+}
+
+// OperationTypePreempted is the type of the system event GCE records when it preempts a Spot VM.
+const OperationTypePreempted = "compute.instances.preempted"
+
+// IsPreemption reports whether the operation is GCE preempting the instance.
+func (o *Operation) IsPreemption() bool {
+	return o.Type == OperationTypePreempted
+}
+
+// systemEvents describes the operation types GCE records for events on an
+// instance that it initiates itself rather than a user.
+var systemEvents = map[string]string{
+	"compute.instances.hostError":                  "host error, the VM crashed and was restarted if automatic restart is on",
+	"compute.instances.migrateOnHostMaintenance":   "live migrated for host maintenance",
+	"compute.instances.terminateOnHostMaintenance": "stopped for host maintenance",
+	OperationTypePreempted:                         "preempted",
+	"compute.instances.automaticRestart":           "restarted automatically after GCE stopped it",
+	"compute.instances.guestTerminate":             "shut down from inside the guest OS",
+}
+
+// SystemEventTypes returns the operation types of system events, sorted.
+func SystemEventTypes() []string {
+	types := make([]string, 0, len(systemEvents))
+	for t := range systemEvents {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// IsSystemEvent reports whether the operation is a system event, e.g. a host
+// maintenance or preemption, rather than an operation requested by a user.
+func (o *Operation) IsSystemEvent() bool {
+	_, ok := systemEvents[o.Type]
+	return ok
+}
+
+// SystemEventDescription returns what happened to the instance in a system event,
+// or "" when the operation is not one.
+func (o *Operation) SystemEventDescription() string {
+	return systemEvents[o.Type]
+}
+
+// ParseOperationPath parses an operation reference.
+//
+// Accepted forms:
+//   - "projects/PROJECT/zones/ZONE/operations/NAME" (as printed by Operation.Path)
+//   - "NAME", in which case defaultProject and defaultZone are used
+//
+// Parameters:
+//   - ref: The operation reference
+//   - defaultProject: Project used when ref is a bare name
+//   - defaultZone: Zone used when ref is a bare name
+//
+// Returns:
+//   - *Operation: An operation with Name, Project and Zone set
+//   - error: Error if ref is malformed or a bare name is given without defaults
+func ParseOperationPath(ref, defaultProject, defaultZone string) (*Operation, error) {
+	ref = strings.Trim(ref, "/")
+	if ref == "" {
+		return nil, fmt.Errorf("operation reference is empty")
+	}
+
+	if !strings.Contains(ref, "/") {
+		if defaultProject == "" || defaultZone == "" {
+			return nil, fmt.Errorf("operation %s: project and zone are required for a bare operation name", ref)
+		}
+		return &Operation{Name: ref, Project: defaultProject, Zone: defaultZone}, nil
+	}
+
+	// Full URLs (https://www.googleapis.com/compute/v1/projects/...) are also accepted
+	if i := strings.Index(ref, "projects/"); i > 0 {
+		ref = ref[i:]
+	}
+	parts := strings.Split(ref, "/")
+	if len(parts) != 6 || parts[0] != "projects" || parts[2] != "zones" || parts[4] != "operations" {
+		return nil, fmt.Errorf("invalid operation path %q: expected projects/PROJECT/zones/ZONE/operations/NAME", ref)
+	}
+	return &Operation{Name: parts[5], Project: parts[1], Zone: parts[3]}, nil
+}
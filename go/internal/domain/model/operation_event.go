@@ -0,0 +1,37 @@
+package model
+
+// OperationEvent is emitted while gcectl waits for a long-running GCE operation.
+// It is one of OperationStarted, OperationProgress or OperationDone.
+type OperationEvent interface {
+	// Operation returns the operation the event is about.
+	Operation() *Operation
+	isOperationEvent()
+}
+
+// OperationStarted is emitted when gcectl starts waiting for Op.
+type OperationStarted struct {
+	Op *Operation
+}
+
+// OperationProgress is emitted with the state of Op after every poll while it runs.
+type OperationProgress struct {
+	Op *Operation
+	// Percent is the completion percentage reported by GCE (0-100).
+	Percent int
+}
+
+// OperationDone is emitted when gcectl stops waiting for Op. Err is nil when the
+// operation succeeded; otherwise it may also be a cancelled or timed out wait,
+// in which case the operation can still be running.
+type OperationDone struct {
+	Err error
+	Op  *Operation
+}
+
+func (e OperationStarted) Operation() *Operation  { return e.Op }
+func (e OperationProgress) Operation() *Operation { return e.Op }
+func (e OperationDone) Operation() *Operation     { return e.Op }
+
+func (OperationStarted) isOperationEvent()  {}
+func (OperationProgress) isOperationEvent() {}
+func (OperationDone) isOperationEvent()     {}
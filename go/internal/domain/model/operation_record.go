@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// OperationRecord is an entry of the local operation history: one GCE operation
+// gcectl started and waited for.
+type OperationRecord struct {
+	// Time is when the operation started.
+	Time time.Time
+	// Duration is how long the operation ran, or how long gcectl waited for it
+	// when the wait was interrupted.
+	Duration time.Duration
+	// Target is the name of the resource the operation acted on, usually a VM.
+	Target  string
+	Project string
+	Zone    string
+	// Type is the API operation type (e.g., "start", "stop").
+	Type string
+	// Operation is the operation name.
+	Operation string
+	// User is the principal GCE ran the operation as.
+	User string
+	// LocalUser is the user and host gcectl ran on, as user@host.
+	LocalUser string
+	// Command is the gcectl command line that started the operation.
+	Command string
+	// Error is the failure message, empty on success.
+	Error string
+	// Running is set when the user detached from the operation while it was
+	// still running on GCP, so its outcome is not known.
+	Running bool
+}
+
+// Succeeded reports whether the operation finished without an error.
+func (r *OperationRecord) Succeeded() bool {
+	return r.Error == "" && !r.Running
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// OperationStats summarizes how long the recorded operations of one type on one
+// VM took, e.g. every start of a VM.
+type OperationStats struct {
+	// Last is when the most recent of the operations started.
+	Last time.Time
+	// Target is the name of the resource the operations acted on, usually a VM.
+	Target string
+	// Type is the API operation type (e.g., "start", "stop").
+	Type string
+	// Count is how many of the operations succeeded; the durations are of those.
+	Count int
+	// Failed is how many of the operations failed.
+	Failed  int
+	Average time.Duration
+	Median  time.Duration
+	Min     time.Duration
+	Max     time.Duration
+}
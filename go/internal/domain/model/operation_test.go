@@ -0,0 +1,89 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOperationPath(t *testing.T) {
+	//nolint:govet // field alignment is less important than readability in tests
+	tests := []struct {
+		name    string
+		ref     string
+		want    *Operation
+		wantErr bool
+	}{
+		{
+			name: "full path",
+			ref:  "projects/p1/zones/us-central1-a/operations/operation-123",
+			want: &Operation{Name: "operation-123", Project: "p1", Zone: "us-central1-a"},
+		},
+		{
+			name: "self link",
+			ref:  "https://www.googleapis.com/compute/v1/projects/p1/zones/us-central1-a/operations/operation-123",
+			want: &Operation{Name: "operation-123", Project: "p1", Zone: "us-central1-a"},
+		},
+		{
+			name: "bare name uses defaults",
+			ref:  "operation-123",
+			want: &Operation{Name: "operation-123", Project: "default-p", Zone: "default-z"},
+		},
+		{
+			name:    "malformed path",
+			ref:     "projects/p1/operations/operation-123",
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			ref:     "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOperationPath(tt.ref, "default-p", "default-z")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseOperationPathRequiresDefaultsForBareName(t *testing.T) {
+	_, err := ParseOperationPath("operation-123", "", "")
+	assert.Error(t, err)
+}
+
+func TestOperation_Path(t *testing.T) {
+	op := &Operation{Name: "operation-123", Project: "p1", Zone: "us-central1-a"}
+	assert.Equal(t, "projects/p1/zones/us-central1-a/operations/operation-123", op.Path())
+
+	parsed, err := ParseOperationPath(op.Path(), "", "")
+	require.NoError(t, err)
+	assert.Equal(t, op, parsed)
+}
+
+func TestOperation_IsPreemption(t *testing.T) {
+	assert.True(t, (&Operation{Type: OperationTypePreempted}).IsPreemption())
+	assert.False(t, (&Operation{Type: "stop"}).IsPreemption())
+}
+
+func TestOperationSystemEvent(t *testing.T) {
+	preempted := &Operation{Type: OperationTypePreempted}
+	assert.True(t, preempted.IsSystemEvent())
+	assert.Equal(t, "preempted", preempted.SystemEventDescription())
+
+	stop := &Operation{Type: "stop"}
+	assert.False(t, stop.IsSystemEvent())
+	assert.Empty(t, stop.SystemEventDescription())
+
+	types := SystemEventTypes()
+	assert.Contains(t, types, "compute.instances.migrateOnHostMaintenance")
+	assert.IsIncreasing(t, types)
+}
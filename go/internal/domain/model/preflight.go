@@ -0,0 +1,41 @@
+package model
+
+// RequiredPermissions are the IAM permissions gcectl's commands need on a project.
+var RequiredPermissions = []string{
+	"compute.instances.get",
+	"compute.instances.list",
+	"compute.instances.start",
+	"compute.instances.stop",
+	"compute.instances.setMachineType",
+	"compute.resourcePolicies.use",
+}
+
+// CheckStatus is the outcome of a single preflight check.
+type CheckStatus string
+
+const (
+	CheckPassed  CheckStatus = "ok"
+	CheckFailed  CheckStatus = "failed"
+	CheckSkipped CheckStatus = "skipped"
+)
+
+// CheckResult is the outcome of a single preflight check with how to fix a failure.
+type CheckResult struct {
+	// Name is what was checked (e.g., "Compute API in my-project").
+	Name   string
+	Status CheckStatus
+	// Detail describes what was found.
+	Detail string
+	// Fix is an actionable hint for a failed check, or "".
+	Fix string
+}
+
+// Failed reports whether any of results failed.
+func Failed(results []CheckResult) bool {
+	for _, r := range results {
+		if r.Status == CheckFailed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,17 @@
+package model
+
+// DefaultProfileName is the profile in use when none was chosen with
+// `gcectl context use`: the default config file without overrides.
+const DefaultProfileName = "default"
+
+// Profile is a named context of gcectl: the config file to load and the
+// default project and zone to use instead of those in it.
+type Profile struct {
+	Name string
+	// ConfigPath is the config file of the profile. Empty means the default config file.
+	ConfigPath string
+	// Project replaces default-project of the config file when set.
+	Project string
+	// Zone replaces default-zone of the config file when set.
+	Zone string
+}
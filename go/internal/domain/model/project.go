@@ -0,0 +1,10 @@
+package model
+
+// Project is a GCP project visible to the caller.
+type Project struct {
+	// ID is the project ID used in API calls and config.yaml (e.g., "my-project").
+	ID string
+	// Name is the display name of the project.
+	Name   string
+	Number int64
+}
@@ -0,0 +1,52 @@
+package model
+
+import "strings"
+
+// Quota is the usage of a regional quota metric.
+type Quota struct {
+	// Metric is the quota name (e.g., "NVIDIA_T4_GPUS").
+	Metric string
+	Limit  float64
+	Usage  float64
+}
+
+// Available returns how much of the quota is left, never less than zero.
+func (q Quota) Available() float64 {
+	return max(q.Limit-q.Usage, 0)
+}
+
+// Region is a GCE region with its zones and quotas.
+type Region struct {
+	Name string
+	// Zones are the names of the region's zones.
+	Zones  []string
+	Quotas []Quota
+}
+
+// Quota returns the quota of the given metric, or nil if the region does not report it.
+func (r *Region) Quota(metric string) *Quota {
+	for i := range r.Quotas {
+		if r.Quotas[i].Metric == metric {
+			return &r.Quotas[i]
+		}
+	}
+	return nil
+}
+
+// RegionOfZone returns the region a zone belongs to, e.g. "us-central1" for
+// "us-central1-a", or "" if zone is not a zone name.
+func RegionOfZone(zone string) string {
+	i := strings.LastIndex(zone, "-")
+	if i <= 0 {
+		return ""
+	}
+	return zone[:i]
+}
+
+// Zone is a GCE zone.
+type Zone struct {
+	Name   string
+	Region string
+	// Status is UP or DOWN.
+	Status string
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcceleratorType_QuotaMetric(t *testing.T) {
+	tests := []struct {
+		name string
+		spot bool
+		want string
+	}{
+		{name: "nvidia-tesla-a100", want: "NVIDIA_A100_GPUS"},
+		{name: "nvidia-a100-80gb", want: "NVIDIA_A100_80GB_GPUS"},
+		{name: "nvidia-l4", want: "NVIDIA_L4_GPUS"},
+		{name: "nvidia-tesla-t4", spot: true, want: "PREEMPTIBLE_NVIDIA_T4_GPUS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, (&AcceleratorType{Name: tt.name}).QuotaMetric(tt.spot))
+		})
+	}
+}
+
+func TestRegion_Quota(t *testing.T) {
+	region := &Region{Quotas: []Quota{{Metric: "NVIDIA_T4_GPUS", Limit: 4, Usage: 6}}}
+
+	quota := region.Quota("NVIDIA_T4_GPUS")
+	if assert.NotNil(t, quota) {
+		assert.Zero(t, quota.Available(), "usage above the limit leaves nothing")
+	}
+	assert.Nil(t, region.Quota("NVIDIA_A100_GPUS"))
+}
+
+func TestRegionOfZone(t *testing.T) {
+	assert.Equal(t, "us-central1", RegionOfZone("us-central1-a"))
+	assert.Equal(t, "", RegionOfZone("zone"))
+}
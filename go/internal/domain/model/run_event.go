@@ -0,0 +1,103 @@
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// RunEventType is whether a VM started or stopped running.
+type RunEventType string
+
+const (
+	// RunEventStart is recorded when a VM starts (or resumes) running.
+	RunEventStart RunEventType = "start"
+	// RunEventStop is recorded when a VM stops (or suspends) running.
+	RunEventStop RunEventType = "stop"
+)
+
+// RunEvent records a VM starting or stopping, used to compute runtime hours.
+type RunEvent struct {
+	Time    time.Time
+	VMName  string
+	Project string
+	Zone    string
+	// ID identifies the event so it is only recorded once (the operation name).
+	ID   string
+	Type RunEventType
+}
+
+// RunEventFromOperation converts a successful start/stop operation into a RunEvent.
+//
+// Returns:
+//   - *RunEvent: The event, timed at the end of the operation
+//   - bool: false if op is not a finished, successful start, resume, stop or suspend
+func RunEventFromOperation(op *Operation) (*RunEvent, bool) {
+	var eventType RunEventType
+	switch op.Type {
+	case "start", "resume":
+		eventType = RunEventStart
+	case "stop", "suspend":
+		eventType = RunEventStop
+	default:
+		return nil, false
+	}
+	if !op.IsDone() || op.Error != "" || op.EndTime == nil {
+		return nil, false
+	}
+	return &RunEvent{
+		Time:    *op.EndTime,
+		VMName:  op.Target,
+		Project: op.Project,
+		Zone:    op.Zone,
+		ID:      op.Name,
+		Type:    eventType,
+	}, true
+}
+
+// RunningDuration returns how long a VM ran between from and to, given its run events.
+//
+// Whether the VM was running at from is taken from the last event before from. Without
+// one, it is inferred from the first later event (a stop means it was running), and
+// without any event, runningNow (the VM's current state) is used.
+//
+// Parameters:
+//   - events: The VM's run events, in any order
+//   - from: Start of the period
+//   - to: End of the period
+//   - runningNow: Whether the VM is running now
+func RunningDuration(events []*RunEvent, from, to time.Time, runningNow bool) time.Duration {
+	sorted := make([]*RunEvent, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.Before(sorted[j].Time)
+	})
+
+	running := runningNow
+	i := 0
+	for i < len(sorted) && !sorted[i].Time.After(from) {
+		i++
+	}
+	switch {
+	case i > 0:
+		running = sorted[i-1].Type == RunEventStart
+	case len(sorted) > 0:
+		running = sorted[0].Type == RunEventStop
+	}
+
+	var total time.Duration
+	since := from
+	for ; i < len(sorted) && sorted[i].Time.Before(to); i++ {
+		e := sorted[i]
+		if running && e.Type == RunEventStop {
+			total += e.Time.Sub(since)
+		}
+		if !running && e.Type == RunEventStart {
+			since = e.Time
+		}
+		running = e.Type == RunEventStart
+	}
+	if running && to.After(since) {
+		total += to.Sub(since)
+	}
+	return total
+}
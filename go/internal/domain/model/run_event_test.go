@@ -0,0 +1,84 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunEventFromOperation(t *testing.T) {
+	end := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+
+	e, ok := RunEventFromOperation(&Operation{Name: "op-1", Type: "resume", Target: "vm", Project: "p", Zone: "z", Status: "DONE", EndTime: &end})
+	assert.True(t, ok)
+	assert.Equal(t, &RunEvent{Time: end, VMName: "vm", Project: "p", Zone: "z", ID: "op-1", Type: RunEventStart}, e)
+
+	_, ok = RunEventFromOperation(&Operation{Type: "stop", Status: "DONE", Error: "quota", EndTime: &end})
+	assert.False(t, ok, "failed operations are not events")
+	_, ok = RunEventFromOperation(&Operation{Type: "stop", Status: "RUNNING"})
+	assert.False(t, ok, "unfinished operations are not events")
+	_, ok = RunEventFromOperation(&Operation{Type: "setLabels", Status: "DONE", EndTime: &end})
+	assert.False(t, ok, "other operation types are not events")
+}
+
+func TestRunningDuration(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	at := func(day, hour int) time.Time { return time.Date(2025, 1, day, hour, 0, 0, 0, time.UTC) }
+	ev := func(typ RunEventType, tm time.Time) *RunEvent { return &RunEvent{Type: typ, Time: tm} }
+
+	tests := []struct {
+		name       string
+		events     []*RunEvent
+		runningNow bool
+		want       time.Duration
+	}{
+		{
+			name:   "start and stop within the period",
+			events: []*RunEvent{ev(RunEventStop, at(2, 17)), ev(RunEventStart, at(2, 9))},
+			want:   8 * time.Hour,
+		},
+		{
+			name:   "running since before the period",
+			events: []*RunEvent{ev(RunEventStart, at(1, 0).Add(-time.Hour)), ev(RunEventStop, at(1, 5))},
+			want:   5 * time.Hour,
+		},
+		{
+			name:   "first event is a stop",
+			events: []*RunEvent{ev(RunEventStop, at(1, 3))},
+			want:   3 * time.Hour,
+		},
+		{
+			name:   "still running at the end",
+			events: []*RunEvent{ev(RunEventStart, at(31, 20))},
+			want:   4 * time.Hour,
+		},
+		{
+			name:   "repeated start is not counted twice",
+			events: []*RunEvent{ev(RunEventStart, at(2, 0)), ev(RunEventStart, at(2, 1)), ev(RunEventStop, at(2, 2))},
+			want:   2 * time.Hour,
+		},
+		{
+			name:       "no events and running now",
+			runningNow: true,
+			want:       31 * 24 * time.Hour,
+		},
+		{
+			name: "no events and stopped now",
+			want: 0,
+		},
+		{
+			name:       "stopped before the period",
+			events:     []*RunEvent{ev(RunEventStop, at(1, 0).Add(-time.Hour)), ev(RunEventStart, to.Add(time.Hour))},
+			runningNow: true,
+			want:       0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RunningDuration(tt.events, from, to, tt.runningNow))
+		})
+	}
+}
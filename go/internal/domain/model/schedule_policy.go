@@ -0,0 +1,113 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ErrSchedulePolicyNotFound is returned when a schedule policy does not exist in a region.
+var ErrSchedulePolicyNotFound = errors.New("schedule policy not found")
+
+// policyNamePattern is the GCE naming rule for resource policies (RFC 1035 label).
+var policyNamePattern = regexp.MustCompile(`^[a-z]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+// SchedulePolicy represents an instance schedule resource policy, which starts and
+// stops the VMs attached to it on a cron schedule.
+type SchedulePolicy struct {
+	Name    string
+	Project string
+	Region  string
+	// StartCron and StopCron are cron expressions; either may be empty.
+	StartCron string
+	StopCron  string
+	// TimeZone is the IANA time zone the cron expressions are evaluated in.
+	TimeZone    string
+	Description string
+	// AttachedVMs is the number of instances the policy is attached to.
+	AttachedVMs int
+}
+
+// Validate checks that the policy can be created: a valid name, at least one
+// five-field cron schedule, and a known IANA time zone.
+func (p *SchedulePolicy) Validate() error {
+	if !policyNamePattern.MatchString(p.Name) {
+		return fmt.Errorf("invalid policy name %q: must be 1-63 lowercase letters, digits or hyphens, starting with a letter", p.Name)
+	}
+	if p.StartCron == "" && p.StopCron == "" {
+		return errors.New("at least one of start-cron or stop-cron is required")
+	}
+	if err := validateCron("start-cron", p.StartCron); err != nil {
+		return err
+	}
+	if err := validateCron("stop-cron", p.StopCron); err != nil {
+		return err
+	}
+	if p.TimeZone == "" {
+		return errors.New("time zone is required")
+	}
+	if _, err := time.LoadLocation(p.TimeZone); err != nil {
+		return fmt.Errorf("invalid time zone %q: %w", p.TimeZone, err)
+	}
+	return nil
+}
+
+// validateCron checks that a non-empty cron expression parses.
+func validateCron(label, cron string) error {
+	if cron == "" {
+		return nil
+	}
+	if _, err := ParseCron(cron); err != nil {
+		return fmt.Errorf("invalid %s %q: %w", label, cron, err)
+	}
+	return nil
+}
+
+// NextStart returns the next time after now at which the policy starts its VMs.
+// ok is false when the policy has no valid start schedule.
+func (p *SchedulePolicy) NextStart(now time.Time) (next time.Time, ok bool) {
+	return p.next(p.StartCron, now)
+}
+
+// NextStop returns the next time after now at which the policy stops its VMs.
+// ok is false when the policy has no valid stop schedule.
+func (p *SchedulePolicy) NextStop(now time.Time) (next time.Time, ok bool) {
+	return p.next(p.StopCron, now)
+}
+
+// Location returns the time zone the policy's schedules are evaluated in.
+// UTC is used when the time zone is empty or unknown.
+func (p *SchedulePolicy) Location() *time.Location {
+	if p.TimeZone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(p.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func (p *SchedulePolicy) next(cron string, now time.Time) (time.Time, bool) {
+	if cron == "" {
+		return time.Time{}, false
+	}
+	schedule, err := ParseCron(cron)
+	if err != nil {
+		return time.Time{}, false
+	}
+	next := schedule.Next(now.In(p.Location()))
+	return next, !next.IsZero()
+}
+
+// RegionOf returns the region of a zone name, e.g. "us-central1" for "us-central1-a".
+// The zone is returned unchanged if it has no zone suffix.
+func RegionOf(zone string) string {
+	i := strings.LastIndex(zone, "-")
+	if i == -1 {
+		return zone
+	}
+	return zone[:i]
+}
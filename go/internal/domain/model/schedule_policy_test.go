@@ -0,0 +1,44 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegionOf(t *testing.T) {
+	assert.Equal(t, "us-central1", RegionOf("us-central1-a"))
+	assert.Equal(t, "asia-northeast1", RegionOf("asia-northeast1-b"))
+	assert.Equal(t, "global", RegionOf("global"))
+}
+
+func TestSchedulePolicy_Validate(t *testing.T) {
+	valid := SchedulePolicy{Name: "weekday-stop", StopCron: "0 19 * * 1-5", TimeZone: "Asia/Tokyo"}
+
+	//nolint:govet // field alignment is less important than readability in tests
+	tests := []struct {
+		name    string
+		modify  func(p *SchedulePolicy)
+		wantErr string
+	}{
+		{name: "valid", modify: func(p *SchedulePolicy) {}},
+		{name: "invalid name", modify: func(p *SchedulePolicy) { p.Name = "Weekday_Stop" }, wantErr: "invalid policy name"},
+		{name: "no schedule", modify: func(p *SchedulePolicy) { p.StopCron = "" }, wantErr: "at least one of"},
+		{name: "malformed cron", modify: func(p *SchedulePolicy) { p.StartCron = "0 9 * *" }, wantErr: "invalid start-cron"},
+		{name: "missing time zone", modify: func(p *SchedulePolicy) { p.TimeZone = "" }, wantErr: "time zone is required"},
+		{name: "unknown time zone", modify: func(p *SchedulePolicy) { p.TimeZone = "Mars/Base" }, wantErr: "invalid time zone"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valid
+			tt.modify(&p)
+			err := p.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
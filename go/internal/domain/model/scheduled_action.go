@@ -0,0 +1,52 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// ScheduledActionType is the operation a ScheduledAction performs.
+type ScheduledActionType string
+
+const (
+	// ScheduledStop stops the VM.
+	ScheduledStop ScheduledActionType = "stop"
+)
+
+// ScheduledAction is a VM operation deferred to a given time by the local scheduler.
+type ScheduledAction struct {
+	// At is when the action should run.
+	At time.Time
+	// CreatedAt is when the action was scheduled. An action is skipped if the VM
+	// was (re)started after it, since it then belongs to an earlier run.
+	CreatedAt time.Time
+	VMName    string
+	Project   string
+	Zone      string
+	Action    ScheduledActionType
+	// Reason explains where the action came from (e.g., "ttl 3h0m0s").
+	Reason string
+}
+
+// VM returns the target VM, identified by project, zone and name.
+func (a *ScheduledAction) VM() *VM {
+	return &VM{Name: a.VMName, Project: a.Project, Zone: a.Zone}
+}
+
+// Due reports whether the action should run at now.
+func (a *ScheduledAction) Due(now time.Time) bool {
+	return !now.Before(a.At)
+}
+
+// Stale reports whether vm was started after the action was scheduled.
+func (a *ScheduledAction) Stale(vm *VM) bool {
+	return vm.LastStartTime != nil && vm.LastStartTime.After(a.CreatedAt)
+}
+
+// Description returns what ran, e.g. "scheduled stop (ttl 3h0m0s)".
+func (a *ScheduledAction) Description() string {
+	if a.Reason == "" {
+		return fmt.Sprintf("scheduled %s", a.Action)
+	}
+	return fmt.Sprintf("scheduled %s (%s)", a.Action, a.Reason)
+}
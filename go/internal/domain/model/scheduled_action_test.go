@@ -0,0 +1,12 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduledAction_Description(t *testing.T) {
+	assert.Equal(t, "scheduled stop (ttl 3h0m0s)", (&ScheduledAction{Action: ScheduledStop, Reason: "ttl 3h0m0s"}).Description())
+	assert.Equal(t, "scheduled stop", (&ScheduledAction{Action: ScheduledStop}).Description())
+}
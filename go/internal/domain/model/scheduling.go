@@ -0,0 +1,47 @@
+package model
+
+// Scheduling holds the mutable scheduling options of a VM.
+// Field names and JSON tags follow the Compute Engine API representation so the
+// struct can be decoded directly from an instance resource.
+type Scheduling struct {
+	// AutomaticRestart reports whether the VM is restarted after a host failure.
+	// nil means the API default (true for standard VMs).
+	AutomaticRestart *bool `json:"automaticRestart,omitempty"`
+	// OnHostMaintenance is MIGRATE or TERMINATE.
+	OnHostMaintenance string `json:"onHostMaintenance,omitempty"`
+	// ProvisioningModel is STANDARD or SPOT.
+	ProvisioningModel string `json:"provisioningModel,omitempty"`
+	// InstanceTerminationAction is STOP or DELETE (Spot VMs only).
+	InstanceTerminationAction string `json:"instanceTerminationAction,omitempty"`
+	Preemptible               bool   `json:"preemptible,omitempty"`
+}
+
+// On-host-maintenance policies of a VM.
+const (
+	OnHostMaintenanceMigrate   = "MIGRATE"
+	OnHostMaintenanceTerminate = "TERMINATE"
+)
+
+// AutomaticRestartEnabled reports whether the VM is restarted after a host failure,
+// applying the API default when AutomaticRestart is unset.
+func (s *Scheduling) AutomaticRestartEnabled() bool {
+	return s.AutomaticRestart == nil || *s.AutomaticRestart
+}
+
+// IsSpot reports whether the VM is a Spot or preemptible VM.
+func (s *Scheduling) IsSpot() bool {
+	return s.ProvisioningModel == "SPOT" || s.Preemptible
+}
+
+// SchedulingOptions is a partial update of a VM's host maintenance behaviour.
+// Unset fields keep their current value.
+type SchedulingOptions struct {
+	AutomaticRestart *bool
+	// OnHostMaintenance is MIGRATE, TERMINATE or empty to keep the current policy.
+	OnHostMaintenance string
+}
+
+// IsEmpty reports whether the options change nothing.
+func (o SchedulingOptions) IsEmpty() bool {
+	return o.AutomaticRestart == nil && o.OnHostMaintenance == ""
+}
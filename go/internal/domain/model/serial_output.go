@@ -0,0 +1,12 @@
+package model
+
+// SerialOutput is a chunk of the output written to a VM's serial port.
+type SerialOutput struct {
+	// Contents is the output starting at byte Start.
+	Contents string
+	// Start is the byte position of Contents. It is later than the requested position
+	// when older output has already been discarded from the VM's buffer (1 MB).
+	Start int64
+	// Next is the byte position to request to continue after Contents.
+	Next int64
+}
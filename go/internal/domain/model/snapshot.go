@@ -0,0 +1,43 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// resourceNameMaxLen is the maximum length of a GCE resource name.
+const resourceNameMaxLen = 63
+
+// Snapshot is a point-in-time copy of a persistent disk.
+//
+//nolint:govet // Field order optimized for readability over memory alignment
+type Snapshot struct {
+	// CreationTime is nil when unknown.
+	CreationTime *time.Time
+	Name         string
+	// SourceDisk is the name of the disk the snapshot was taken from.
+	SourceDisk string
+	// Status is CREATING, UPLOADING, READY, FAILED or DELETING.
+	Status     string
+	DiskSizeGB int64
+	// StorageBytes is the size of the snapshot in storage.
+	StorageBytes int64
+}
+
+// SnapshotName returns a snapshot name for disk taken at t, e.g. "data-1-20250102-150405".
+// The disk name is truncated so the result stays a valid resource name.
+func SnapshotName(disk string, t time.Time) string {
+	return timestampedName(disk, t)
+}
+
+// timestampedName appends the UTC time t to base, truncating base so the result
+// stays within the resource name length limit.
+func timestampedName(base string, t time.Time) string {
+	suffix := t.UTC().Format("-20060102-150405")
+	base = strings.TrimRight(strings.ToLower(base), "-")
+	if maxBase := resourceNameMaxLen - len(suffix); len(base) > maxBase {
+		base = strings.TrimRight(base[:maxBase], "-")
+	}
+	return fmt.Sprintf("%s%s", base, suffix)
+}
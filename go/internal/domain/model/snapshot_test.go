@@ -0,0 +1,21 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotName(t *testing.T) {
+	at := time.Date(2025, 1, 2, 15, 4, 5, 0, time.FixedZone("JST", 9*60*60))
+
+	assert.Equal(t, "data-1-20250102-060405", SnapshotName("data-1", at), "timestamp is in UTC")
+
+	long := SnapshotName(strings.Repeat("a", 60)+"-disk", at)
+	assert.Len(t, long, 63)
+	assert.True(t, strings.HasSuffix(long, "-20250102-060405"))
+
+	assert.Equal(t, "data-20250102-060405", SnapshotName(strings.Repeat("data-", 1), at), "no double dash")
+}
@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// Snooze records a schedule policy temporarily detached from a VM so that it can
+// be re-attached once the snooze ends.
+type Snooze struct {
+	// Until is when the policy should be re-attached.
+	Until   time.Time
+	VMName  string
+	Project string
+	Zone    string
+	// Policy is the name of the detached schedule policy.
+	Policy string
+}
+
+// VM returns the snoozed VM, identified by project, zone and name.
+func (s *Snooze) VM() *VM {
+	return &VM{Name: s.VMName, Project: s.Project, Zone: s.Zone}
+}
+
+// Expired reports whether the snooze has ended at now.
+func (s *Snooze) Expired(now time.Time) bool {
+	return !now.Before(s.Until)
+}
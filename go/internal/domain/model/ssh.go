@@ -0,0 +1,16 @@
+package model
+
+// SSHOptions holds per-VM SSH connection overrides declared in the config file.
+// Zero values mean "use the ssh client default".
+type SSHOptions struct {
+	User         string
+	IdentityFile string
+	Port         int
+}
+
+// SSHHost represents a single Host entry for an OpenSSH client config file.
+type SSHHost struct {
+	Alias    string
+	HostName string
+	SSHOptions
+}
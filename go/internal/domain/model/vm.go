@@ -2,6 +2,7 @@ package model
 
 import (
 	"errors"
+	"strings"
 	"time"
 )
 
@@ -73,13 +74,67 @@ func StatusFromString(s string) Status {
 // This is the core domain model that encapsulates VM state and behavior.
 // It is used throughout the application to represent VM instances consistently.
 type VM struct {
-	LastStartTime  *time.Time
+	LastStartTime *time.Time
+	// CreationTime is when the VM was created, or nil when unknown.
+	CreationTime *time.Time
+	// LastStopTime is when the VM was last stopped, or nil when it never was.
+	LastStopTime *time.Time
+	// UpcomingMaintenance is the start of a scheduled host maintenance window, or nil when none is scheduled.
+	UpcomingMaintenance *time.Time
+	// Schedule is the attached instance schedule policy, or nil when none is attached.
+	Schedule *SchedulePolicy
+	// Scheduling is the VM's host maintenance and provisioning configuration, or nil when unknown.
+	Scheduling *Scheduling
+	// Disks are the disks attached to the VM, boot disk first. Their Type is not known.
+	Disks []Disk
+	// Labels are the VM's labels.
+	Labels map[string]string
+	// Accelerators are the GPUs attached to the VM.
+	Accelerators []Accelerator
+	// NetworkInterfaces are the VM's network interfaces, primary first.
+	NetworkInterfaces []NetworkInterface
+	// ServiceAccount is the service account the VM runs as, or nil when it has none.
+	ServiceAccount *ServiceAccount
+	// ID is the numeric instance ID assigned by GCE, or "" when unknown.
+	ID             string
 	Name           string
 	Project        string
 	Zone           string
 	MachineType    string
 	SchedulePolicy string
+	InternalIP     string
+	ExternalIP     string
 	Status         Status
+	// Provider names the backend that manages the VM, e.g. ProviderGCP.
+	// Empty means ProviderGCP.
+	Provider string
+	// Windows is true when the boot disk of the VM runs Windows.
+	Windows bool
+}
+
+// ProviderGCP is the provider of Compute Engine VMs, the default.
+const ProviderGCP = "gcp"
+
+// ProviderName returns the provider of the VM, ProviderGCP when none is set.
+func (v *VM) ProviderName() string {
+	if v.Provider == "" {
+		return ProviderGCP
+	}
+	return v.Provider
+}
+
+// QualifiedName returns the name of the VM qualified with its project, e.g. "proj-a/vm1".
+func (v *VM) QualifiedName() string {
+	return v.Project + "/" + v.Name
+}
+
+// SplitQualifiedName splits a VM name qualified as "project/name" into its
+// project and name. The project is empty for an unqualified name.
+func SplitQualifiedName(name string) (project, vmName string) {
+	if project, vmName, ok := strings.Cut(name, "/"); ok {
+		return project, vmName
+	}
+	return "", name
 }
 
 // Uptime calculates the current uptime of the VM if it is running.
@@ -113,6 +168,63 @@ func (v *VM) Uptime(now time.Time) (time.Duration, error) {
 	return now.Sub(*v.LastStartTime), nil
 }
 
+// UptimeLevel says how far the uptime of a running VM is past a warning threshold.
+type UptimeLevel int
+
+const (
+	// UptimeNormal means the VM is not running, or has run for less than the threshold.
+	UptimeNormal UptimeLevel = iota
+	// UptimeWarning means the VM has run for longer than the threshold.
+	UptimeWarning
+	// UptimeCritical means the VM has run for longer than twice the threshold.
+	UptimeCritical
+)
+
+// UptimeLevel classifies the VM's uptime against threshold, for spotting VMs
+// left running by mistake. A zero threshold disables the check.
+//
+// Parameters:
+//   - now: The current time to calculate uptime against
+//   - threshold: The uptime past which the VM counts as long-running
+//
+// Returns:
+//   - UptimeLevel: UptimeNormal if the VM is not running, its start time is
+//     unknown or threshold is zero
+func (v *VM) UptimeLevel(now time.Time, threshold time.Duration) UptimeLevel {
+	if threshold <= 0 {
+		return UptimeNormal
+	}
+	uptime, err := v.Uptime(now)
+	switch {
+	case err != nil:
+		return UptimeNormal
+	case uptime > 2*threshold:
+		return UptimeCritical
+	case uptime > threshold:
+		return UptimeWarning
+	}
+	return UptimeNormal
+}
+
+// StoppedFor calculates how long the VM has been stopped since its last stop.
+//
+// Parameters:
+//   - now: The current time to calculate the stopped duration against
+//
+// Returns:
+//   - time.Duration: The duration since the VM was stopped
+//   - error: ErrVMNotStopped if the VM is not in STOPPED or TERMINATED status,
+//     ErrNoStopTime if LastStopTime is nil
+func (v *VM) StoppedFor(now time.Time) (time.Duration, error) {
+	if !v.CanStart() {
+		return 0, ErrVMNotStopped
+	}
+	if v.LastStopTime == nil {
+		return 0, ErrNoStopTime
+	}
+	return now.Sub(*v.LastStopTime), nil
+}
+
 // CanStart checks if the VM can be started based on its current status.
 //
 // A VM can be started only if it is in STOPPED or TERMINATED status.
@@ -144,7 +256,36 @@ func (v *VM) CanChangeMachineType() bool {
 	return v.Status == StatusStopped || v.Status == StatusTerminated
 }
 
+// IPAddress returns the external IP of the VM, or the internal IP when internal is true.
+//
+// Parameters:
+//   - internal: Whether to return the internal (VPC) IP instead of the external IP
+//
+// Returns:
+//   - string: The requested IP address
+//   - error: ErrNoExternalIP or ErrNoInternalIP if the VM has no such address
+//     (e.g., a stopped VM with an ephemeral external IP)
+func (v *VM) IPAddress(internal bool) (string, error) {
+	if internal {
+		if v.InternalIP == "" {
+			return "", ErrNoInternalIP
+		}
+		return v.InternalIP, nil
+	}
+	if v.ExternalIP == "" {
+		return "", ErrNoExternalIP
+	}
+	return v.ExternalIP, nil
+}
+
 var (
 	ErrVMNotRunning = errors.New("VM is not running")
 	ErrNoStartTime  = errors.New("VM start time is not available")
+	ErrVMNotStopped = errors.New("VM is not stopped")
+	ErrNoStopTime   = errors.New("VM stop time is not available")
+	ErrNoExternalIP = errors.New("VM has no external IP")
+	ErrNoInternalIP = errors.New("VM has no internal IP")
+	// ErrZoneResourceExhausted means the zone has no capacity for the VM right now
+	// (ZONE_RESOURCE_POOL_EXHAUSTED); the same VM may start in another zone.
+	ErrZoneResourceExhausted = errors.New("zone resources exhausted")
 )
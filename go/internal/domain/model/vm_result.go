@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// VMResult is the outcome of an action on one VM of a command acting on
+// several, such as `gcectl off vm1 vm2`.
+type VMResult struct {
+	// Err is why the action failed, nil on success.
+	Err error
+	// VM is the name of the VM.
+	VM string
+	// Action is what was done to the VM (e.g., "start", "stop").
+	Action string
+	// Duration is how long the action took, checks included.
+	Duration time.Duration
+}
+
+// Succeeded reports whether the action finished without an error.
+func (r VMResult) Succeeded() bool {
+	return r.Err == nil
+}
@@ -275,3 +275,105 @@ func TestVM_Uptime(t *testing.T) {
 		})
 	}
 }
+
+func TestVM_UptimeLevel(t *testing.T) {
+	now := time.Date(2025, 10, 11, 12, 0, 0, 0, time.UTC)
+	running := func(uptime time.Duration) *VM {
+		start := now.Add(-uptime)
+		return &VM{Status: StatusRunning, LastStartTime: &start}
+	}
+
+	assert.Equal(t, UptimeNormal, running(71*time.Hour).UptimeLevel(now, 72*time.Hour))
+	assert.Equal(t, UptimeWarning, running(73*time.Hour).UptimeLevel(now, 72*time.Hour))
+	assert.Equal(t, UptimeCritical, running(145*time.Hour).UptimeLevel(now, 72*time.Hour))
+	assert.Equal(t, UptimeNormal, running(145*time.Hour).UptimeLevel(now, 0), "a zero threshold disables the check")
+	assert.Equal(t, UptimeNormal, (&VM{Status: StatusTerminated}).UptimeLevel(now, time.Hour))
+}
+
+func TestVM_StoppedFor(t *testing.T) {
+	stopTime := time.Date(2025, 10, 1, 19, 0, 0, 0, time.UTC)
+	now := time.Date(2025, 10, 11, 19, 0, 0, 0, time.UTC)
+
+	//nolint:govet // field alignment is less important than readability in tests
+	tests := []struct {
+		name         string
+		vm           *VM
+		wantDuration time.Duration
+		wantErr      error
+	}{
+		{
+			name:         "success: terminated VM with stop time",
+			vm:           &VM{Status: StatusTerminated, LastStopTime: &stopTime},
+			wantDuration: 10 * 24 * time.Hour,
+		},
+		{
+			name:    "error: VM running",
+			vm:      &VM{Status: StatusRunning, LastStopTime: &stopTime},
+			wantErr: ErrVMNotStopped,
+		},
+		{
+			name:    "error: no stop time",
+			vm:      &VM{Status: StatusTerminated},
+			wantErr: ErrNoStopTime,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			duration, err := tt.vm.StoppedFor(now)
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantDuration, duration)
+		})
+	}
+}
+
+func TestVM_IPAddress(t *testing.T) {
+	//nolint:govet // field alignment is less important than readability in tests
+	tests := []struct {
+		name     string
+		vm       *VM
+		internal bool
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "success: external IP",
+			vm:       &VM{ExternalIP: "34.0.0.1", InternalIP: "10.0.0.2"},
+			internal: false,
+			want:     "34.0.0.1",
+		},
+		{
+			name:     "success: internal IP",
+			vm:       &VM{ExternalIP: "34.0.0.1", InternalIP: "10.0.0.2"},
+			internal: true,
+			want:     "10.0.0.2",
+		},
+		{
+			name:     "error: no external IP",
+			vm:       &VM{InternalIP: "10.0.0.2"},
+			internal: false,
+			wantErr:  ErrNoExternalIP,
+		},
+		{
+			name:     "error: no internal IP",
+			vm:       &VM{},
+			internal: true,
+			wantErr:  ErrNoInternalIP,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.vm.IPAddress(tt.internal)
+
+			assert.Equal(t, tt.wantErr, err, "VM.IPAddress() error should be %v", tt.wantErr)
+			assert.Equal(t, tt.want, got, "VM.IPAddress() should return %v", tt.want)
+		})
+	}
+}
+
+func TestVM_ProviderName(t *testing.T) {
+	assert.Equal(t, ProviderGCP, (&VM{}).ProviderName())
+	assert.Equal(t, "aws", (&VM{Provider: "aws"}).ProviderName())
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// AcceleratorTypeRepository defines the interface for looking up available GPU types
+//
+//go:generate go tool mockgen -source=$GOFILE -destination=../../mock/repository/accelerator_type_repository_mock.go -package=mock_repository
+type AcceleratorTypeRepository interface {
+	// List returns the accelerator types available in a zone, excluding deprecated ones
+	List(ctx context.Context, project, zone string) ([]*model.AcceleratorType, error)
+}
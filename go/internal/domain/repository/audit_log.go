@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// AuditLog records what unattended commands such as the daemon did
+//
+//go:generate go tool mockgen -source=$GOFILE -destination=../../mock/repository/audit_log_mock.go -package=mock_repository
+type AuditLog interface {
+	// Append adds entries to the end of the log
+	Append(entries ...*model.AuditEntry) error
+}
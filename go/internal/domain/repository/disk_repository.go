@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// DiskRepository defines the interface for managing the persistent disks of a VM
+//
+//go:generate go tool mockgen -source=$GOFILE -destination=../../mock/repository/disk_repository_mock.go -package=mock_repository
+type DiskRepository interface {
+	// List returns the disks attached to a VM, boot disk first
+	List(ctx context.Context, vm *model.VM) ([]*model.Disk, error)
+
+	// Resize grows a persistent disk in the VM's zone to sizeGB
+	Resize(ctx context.Context, vm *model.VM, diskName string, sizeGB int64) error
+
+	// Attach attaches an existing persistent disk in the VM's zone as a secondary disk
+	Attach(ctx context.Context, vm *model.VM, diskName string, readOnly bool) error
+
+	// Detach detaches the disk with the given device name from a VM
+	Detach(ctx context.Context, vm *model.VM, deviceName string) error
+}
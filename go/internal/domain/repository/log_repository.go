@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// LogRepository defines the interface for reading the logs of VMs
+//
+//go:generate go tool mockgen -source=$GOFILE -destination=../../mock/repository/log_repository_mock.go -package=mock_repository
+type LogRepository interface {
+	// List returns up to limit of the most recent log entries of a VM (identified by its ID)
+	// written since since, oldest first. filter is an additional Cloud Logging filter, or ""
+	List(ctx context.Context, vm *model.VM, since time.Time, filter string, limit int) ([]*model.LogEntry, error)
+}
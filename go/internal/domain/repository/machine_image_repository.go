@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// MachineImageRepository defines the interface for cloning VMs through machine images
+//
+//go:generate go tool mockgen -source=$GOFILE -destination=../../mock/repository/machine_image_repository_mock.go -package=mock_repository
+type MachineImageRepository interface {
+	// Create creates a machine image of a VM in the VM's project and waits for it to be ready
+	Create(ctx context.Context, source *model.VM, imageName string) error
+
+	// CreateInstance creates the VM target (Name, Project and Zone) from a machine image in the same project
+	CreateInstance(ctx context.Context, imageName string, target *model.VM) error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// MachineTypeRepository defines the interface for looking up available machine types
+//
+//go:generate go tool mockgen -source=$GOFILE -destination=../../mock/repository/machine_type_repository_mock.go -package=mock_repository
+type MachineTypeRepository interface {
+	// List returns the machine types available in a zone, excluding deprecated ones
+	List(ctx context.Context, project, zone string) ([]*model.MachineType, error)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// MetricsRepository defines the interface for reading resource usage metrics of VMs
+//
+//go:generate go tool mockgen -source=$GOFILE -destination=../../mock/repository/metrics_repository_mock.go -package=mock_repository
+type MetricsRepository interface {
+	// Average returns the average resource usage between start and end of the VMs with
+	// the given instance IDs in project, keyed by instance ID.
+	// VMs without any data point are missing from the result
+	Average(ctx context.Context, project string, instanceIDs []string, start, end time.Time) (map[string]*model.InstanceMetrics, error)
+}
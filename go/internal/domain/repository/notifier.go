@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// Notifier delivers events to an external channel such as a chat webhook or email
+//
+//go:generate go tool mockgen -source=$GOFILE -destination=../../mock/repository/notifier_mock.go -package=mock_repository
+type Notifier interface {
+	// Notify sends a single event
+	Notify(ctx context.Context, event model.Event) error
+}
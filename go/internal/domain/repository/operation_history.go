@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// OperationHistory persists the operations gcectl started, so they can be reviewed
+// after GCE has dropped them
+//
+//go:generate go tool mockgen -source=$GOFILE -destination=../../mock/repository/operation_history_mock.go -package=mock_repository
+type OperationHistory interface {
+	// Append adds records to the end of the history
+	Append(records ...*model.OperationRecord) error
+
+	// List returns all recorded operations, oldest first
+	List() ([]*model.OperationRecord, error)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// OperationRepository defines the interface for long-running operation access
+//
+//go:generate go tool mockgen -source=$GOFILE -destination=../../mock/repository/operation_repository_mock.go -package=mock_repository
+type OperationRepository interface {
+	// Get retrieves the current state of an operation
+	Get(ctx context.Context, op *model.Operation) (*model.Operation, error)
+
+	// ListByTarget returns the most recent operations on a VM, newest first, up to limit
+	ListByTarget(ctx context.Context, vm *model.VM, limit int) ([]*model.Operation, error)
+
+	// ListSystemEvents returns the most recent system events on a VM, such as host
+	// maintenance or preemption, newest first, up to limit
+	ListSystemEvents(ctx context.Context, vm *model.VM, limit int) ([]*model.Operation, error)
+
+	// Wait blocks until the operation is done and returns its final state.
+	// An error is returned if the operation finished with an error.
+	Wait(ctx context.Context, op *model.Operation) (*model.Operation, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+)
+
+// PreflightRepository defines the interface for checking that gcectl can reach and use GCP
+//
+//go:generate go tool mockgen -source=$GOFILE -destination=../../mock/repository/preflight_repository_mock.go -package=mock_repository
+type PreflightRepository interface {
+	// Credentials loads Application Default Credentials, obtains a token and describes the principal
+	Credentials(ctx context.Context) (string, error)
+
+	// ComputeAPIEnabled reports whether the Compute Engine API is enabled in a project
+	ComputeAPIEnabled(ctx context.Context, project string) (bool, error)
+
+	// TestPermissions returns which of permissions the caller holds on a project
+	TestPermissions(ctx context.Context, project string, permissions []string) ([]string, error)
+}
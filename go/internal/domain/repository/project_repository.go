@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// ProjectRepository defines the interface for looking up the projects the caller can access
+//
+//go:generate go tool mockgen -source=$GOFILE -destination=../../mock/repository/project_repository_mock.go -package=mock_repository
+type ProjectRepository interface {
+	// List returns the active projects the caller has access to
+	List(ctx context.Context) ([]*model.Project, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// RegionRepository defines the interface for looking up regions, their zones and quotas
+//
+//go:generate go tool mockgen -source=$GOFILE -destination=../../mock/repository/region_repository_mock.go -package=mock_repository
+type RegionRepository interface {
+	// Get returns the region with its zones and quota usage in a project
+	Get(ctx context.Context, project, region string) (*model.Region, error)
+	// ListZones returns the zones of a project, only those of region unless it is empty
+	ListZones(ctx context.Context, project, region string) ([]*model.Zone, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// RunEventStore persists VM start/stop events beyond the retention of the operations history
+//
+//go:generate go tool mockgen -source=$GOFILE -destination=../../mock/repository/run_event_store_mock.go -package=mock_repository
+type RunEventStore interface {
+	// Add records events, skipping those whose ID is already recorded
+	Add(events ...*model.RunEvent) error
+
+	// List returns the recorded events of a VM, looked up by project, zone and name, oldest first
+	List(vm *model.VM) []*model.RunEvent
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// SchedulePolicyRepository defines the interface for instance schedule policy access
+//
+//go:generate go tool mockgen -source=$GOFILE -destination=../../mock/repository/schedule_policy_repository_mock.go -package=mock_repository
+type SchedulePolicyRepository interface {
+	// List returns the instance schedule policies of a project region with their attached VM counts
+	List(ctx context.Context, project, region string) ([]*model.SchedulePolicy, error)
+
+	// Create creates an instance schedule policy and waits for the operation to finish
+	Create(ctx context.Context, policy *model.SchedulePolicy) error
+}
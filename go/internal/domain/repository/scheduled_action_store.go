@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// ScheduledActionStore persists VM operations deferred by the local scheduler
+//
+//go:generate go tool mockgen -source=$GOFILE -destination=../../mock/repository/scheduled_action_store_mock.go -package=mock_repository
+type ScheduledActionStore interface {
+	// Get returns the pending action of a VM, looked up by project, zone and name
+	Get(vm *model.VM) (*model.ScheduledAction, bool)
+
+	// List returns all pending actions, earliest first
+	List() []*model.ScheduledAction
+
+	// Put records an action, replacing any pending action of the same VM
+	Put(action *model.ScheduledAction) error
+
+	// Delete removes the pending action of a VM
+	Delete(vm *model.VM) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// SerialPortRepository defines the interface for reading the serial port output of VMs
+//
+//go:generate go tool mockgen -source=$GOFILE -destination=../../mock/repository/serial_port_repository_mock.go -package=mock_repository
+type SerialPortRepository interface {
+	// Read returns the output of serial port port (1-4) of a VM from byte position start.
+	// A negative start returns the most recent -start bytes
+	Read(ctx context.Context, vm *model.VM, port int32, start int64) (*model.SerialOutput, error)
+}
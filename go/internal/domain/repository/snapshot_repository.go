@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// SnapshotRepository defines the interface for creating and listing disk snapshots
+//
+//go:generate go tool mockgen -source=$GOFILE -destination=../../mock/repository/snapshot_repository_mock.go -package=mock_repository
+type SnapshotRepository interface {
+	// CreateAsync requests a snapshot of a disk in the VM's zone and returns the zonal operation without waiting
+	CreateAsync(ctx context.Context, vm *model.VM, diskName, snapshotName string) (*model.Operation, error)
+
+	// List returns the snapshots in the VM's project taken from the given disks of the VM's zone
+	List(ctx context.Context, vm *model.VM, diskNames []string) ([]*model.Snapshot, error)
+}
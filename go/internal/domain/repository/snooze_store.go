@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// SnoozeStore persists schedule policy snoozes until their policies are re-attached
+//
+//go:generate go tool mockgen -source=$GOFILE -destination=../../mock/repository/snooze_store_mock.go -package=mock_repository
+type SnoozeStore interface {
+	// Get returns the active snooze of a VM, looked up by project, zone and name
+	Get(vm *model.VM) (*model.Snooze, bool)
+
+	// List returns all recorded snoozes
+	List() []*model.Snooze
+
+	// Put records a snooze, replacing any existing snooze of the same VM
+	Put(snooze *model.Snooze) error
+
+	// Delete removes the snooze of a VM
+	Delete(vm *model.VM) error
+}
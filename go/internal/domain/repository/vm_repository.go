@@ -13,18 +13,63 @@ type VMRepository interface {
 	// FindByName retrieves a VM by its name, project, and zone
 	FindByName(ctx context.Context, vm *model.VM) (*model.VM, error)
 
+	// FindAll retrieves several VMs in as few API calls as possible.
+	// The result is aligned with vms; entries for VMs that do not exist are nil
+	FindAll(ctx context.Context, vms []*model.VM) ([]*model.VM, error)
+
+	// ListByProject retrieves the instances of a project that have all labels in selector,
+	// or every instance when selector is empty, sorted by zone and name.
+	// Only identity, status, machine type and labels are filled; schedule policies are not fetched
+	ListByProject(ctx context.Context, project string, selector map[string]string) ([]*model.VM, error)
+
 	// Start starts a VM instance
 	Start(ctx context.Context, vm *model.VM) error
 
 	// Stop stops a VM instance
 	Stop(ctx context.Context, vm *model.VM) error
 
+	// Reset hard resets a running VM instance, like pressing its reset button
+	Reset(ctx context.Context, vm *model.VM) error
+
+	// StartAsync requests a VM start and returns the operation without waiting for it
+	StartAsync(ctx context.Context, vm *model.VM) (*model.Operation, error)
+
+	// StopAsync requests a VM stop and returns the operation without waiting for it
+	StopAsync(ctx context.Context, vm *model.VM) (*model.Operation, error)
+
 	// UpdateMachineType changes the machine type of a VM
 	UpdateMachineType(ctx context.Context, vm *model.VM, machineType string) error
 
+	// SetAccelerators replaces the GPUs attached to a VM; an empty slice detaches all of them
+	SetAccelerators(ctx context.Context, vm *model.VM, accelerators []model.Accelerator) error
+
 	// SetSchedulePolicy attaches a schedule policy to a VM
 	SetSchedulePolicy(ctx context.Context, vm *model.VM, policyName string) error
 
 	// UnsetSchedulePolicy removes a schedule policy from a VM
 	UnsetSchedulePolicy(ctx context.Context, vm *model.VM, policyName string) error
+
+	// GetRaw retrieves the full instance resource of a VM as indented JSON
+	GetRaw(ctx context.Context, vm *model.VM) ([]byte, error)
+
+	// SetLabels replaces all labels of a VM
+	SetLabels(ctx context.Context, vm *model.VM, labels map[string]string) error
+
+	// UpdateLabels sets and removes the given labels of a VM, keeping all others
+	UpdateLabels(ctx context.Context, vm *model.VM, set map[string]string, remove []string) error
+
+	// GetMetadata retrieves the metadata items of a VM
+	GetMetadata(ctx context.Context, vm *model.VM) (map[string]string, error)
+
+	// SetMetadata replaces all metadata items of a VM
+	SetMetadata(ctx context.Context, vm *model.VM, items map[string]string) error
+
+	// UpdateMetadata sets and removes the given metadata items of a VM, keeping all others
+	UpdateMetadata(ctx context.Context, vm *model.VM, set map[string]string, remove []string) error
+
+	// SetScheduling updates the scheduling options of a VM
+	SetScheduling(ctx context.Context, vm *model.VM, scheduling model.Scheduling) error
+
+	// SetSchedulingOptions updates only the scheduling options set in opts, keeping the others
+	SetSchedulingOptions(ctx context.Context, vm *model.VM, opts model.SchedulingOptions) error
 }
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// VMStateCache stores the last-fetched state of VMs so listings can be served without API calls
+//
+//go:generate go tool mockgen -source=$GOFILE -destination=../../mock/repository/vm_state_cache_mock.go -package=mock_repository
+type VMStateCache interface {
+	// Get returns the cached state of a VM and the time it was fetched
+	Get(vm *model.VM) (*model.VM, time.Time, bool)
+
+	// Put stores the state of VMs fetched at the given time
+	Put(vms []*model.VM, fetchedAt time.Time) error
+}
@@ -0,0 +1,167 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/xdg"
+)
+
+const (
+	// DefaultMaxSize is the size past which the audit log is rotated by default.
+	DefaultMaxSize int64 = 10 << 20
+	// DefaultBackups is how many rotated audit logs are kept by default.
+	DefaultBackups = 5
+)
+
+// File is an AuditLog that appends one JSON object per line to a file.
+// The file is rotated to path.1, path.2, ... when it would grow past its maximum size.
+type File struct {
+	path    string
+	maxSize int64
+	backups int
+	mu      sync.Mutex
+}
+
+// record is the on-disk layout of one audit entry.
+type record struct {
+	Time      time.Time `json:"time"`
+	Task      string    `json:"task"`
+	VMName    string    `json:"vm,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Operation string    `json:"operation,omitempty"`
+	Command   string    `json:"command,omitempty"`
+	User      string    `json:"user,omitempty"`
+	LocalUser string    `json:"local-user,omitempty"`
+	Duration  string    `json:"duration,omitempty"`
+}
+
+// DefaultPath returns the default audit log location, $XDG_STATE_HOME/gcectl/audit.log,
+// which is ~/.local/state/gcectl/audit.log when XDG_STATE_HOME is not set.
+func DefaultPath() (string, error) {
+	return xdg.StateFile("audit.log")
+}
+
+// NewFile returns an audit log appending to path, rotated after DefaultMaxSize
+// keeping DefaultBackups old files. The file is created on the first Append.
+func NewFile(path string) *File {
+	return &File{path: path, maxSize: DefaultMaxSize, backups: DefaultBackups}
+}
+
+// WithRotation sets the size past which the file is rotated and how many rotated
+// files are kept. A maxSize <= 0 disables rotation; backups < 0 keeps the default.
+func (f *File) WithRotation(maxSize int64, backups int) *File {
+	f.maxSize = maxSize
+	if backups >= 0 {
+		f.backups = backups
+	}
+	return f
+}
+
+// Path returns the path of the audit log.
+func (f *File) Path() string {
+	return f.path
+}
+
+// Append writes entries to the end of the file, rotating it first when they
+// would not fit.
+func (f *File) Append(entries ...*model.AuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range entries {
+		if err := enc.Encode(toRecord(e)); err != nil {
+			return fmt.Errorf("failed to encode audit entry: %w", err)
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	if err := f.rotate(int64(buf.Len())); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	if _, writeErr := out.Write(buf.Bytes()); writeErr != nil {
+		_ = out.Close()
+		return fmt.Errorf("failed to write audit log: %w", writeErr)
+	}
+	if closeErr := out.Close(); closeErr != nil {
+		return fmt.Errorf("failed to write audit log: %w", closeErr)
+	}
+	return nil
+}
+
+// rotate shifts path to path.1, path.1 to path.2 and so on, dropping the oldest,
+// when adding n bytes would grow a non-empty file past the maximum size.
+func (f *File) rotate(n int64) error {
+	if f.maxSize <= 0 {
+		return nil
+	}
+	info, err := os.Stat(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to stat audit log: %w", err)
+	}
+	if info.Size() == 0 || info.Size()+n <= f.maxSize {
+		return nil
+	}
+
+	if f.backups == 0 {
+		if removeErr := os.Remove(f.path); removeErr != nil {
+			return fmt.Errorf("failed to rotate audit log: %w", removeErr)
+		}
+		return nil
+	}
+	for i := f.backups - 1; i >= 1; i-- {
+		renameErr := os.Rename(backupPath(f.path, i), backupPath(f.path, i+1))
+		if renameErr != nil && !errors.Is(renameErr, os.ErrNotExist) {
+			return fmt.Errorf("failed to rotate audit log: %w", renameErr)
+		}
+	}
+	if renameErr := os.Rename(f.path, backupPath(f.path, 1)); renameErr != nil {
+		return fmt.Errorf("failed to rotate audit log: %w", renameErr)
+	}
+	return nil
+}
+
+// backupPath returns the path of the i-th rotated audit log.
+func backupPath(path string, i int) string {
+	return fmt.Sprintf("%s.%d", path, i)
+}
+
+func toRecord(e *model.AuditEntry) record {
+	r := record{
+		Time:      e.Time,
+		Task:      e.Task,
+		VMName:    e.VMName,
+		Message:   e.Message,
+		Error:     e.Error,
+		Operation: e.Operation,
+		Command:   e.Command,
+		User:      e.User,
+		LocalUser: e.LocalUser,
+	}
+	if e.Duration > 0 {
+		r.Duration = e.Duration.String()
+	}
+	return r
+}
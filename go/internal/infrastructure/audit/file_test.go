@@ -0,0 +1,93 @@
+package audit
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_Append(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gcectl", "audit.log")
+	at := time.Date(2025, 1, 2, 19, 0, 0, 0, time.UTC)
+	f := NewFile(path)
+
+	require.NoError(t, f.Append(&model.AuditEntry{Time: at, Task: "scheduled-actions", VMName: "vm-1", Message: "ran scheduled stop"}))
+	require.NoError(t, f.Append(&model.AuditEntry{Time: at, Task: "refresh-cache", Error: "denied"}))
+	require.NoError(t, f.Append(), "appending nothing is a no-op")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"time":"2025-01-02T19:00:00Z","task":"scheduled-actions","vm":"vm-1","message":"ran scheduled stop"}`+"\n"+
+			`{"time":"2025-01-02T19:00:00Z","task":"refresh-cache","error":"denied"}`+"\n",
+		string(data))
+}
+
+func TestFile_AppendCommandEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	at := time.Date(2025, 1, 2, 19, 0, 0, 0, time.UTC)
+
+	entry := model.AuditEntryFromRecord(&model.OperationRecord{
+		Time: at, Duration: 30 * time.Second, Target: "vm-1", Project: "p", Zone: "z", Type: "stop",
+		Operation: "operation-1", User: "alice@example.com", LocalUser: "alice@laptop", Command: "gcectl off vm-1",
+	})
+	require.NoError(t, NewFile(path).Append(entry))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"time":"2025-01-02T19:00:00Z","task":"command","vm":"vm-1","message":"stop","operation":"projects/p/zones/z/operations/operation-1",`+
+			`"command":"gcectl off vm-1","user":"alice@example.com","local-user":"alice@laptop","duration":"30s"}`+"\n",
+		string(data))
+}
+
+func TestFile_AppendRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	at := time.Date(2025, 1, 2, 19, 0, 0, 0, time.UTC)
+	line, err := json.Marshal(record{Time: at, Task: "refresh-cache", Message: "m1"})
+	require.NoError(t, err)
+	// Two entries fit in a file, a third does not.
+	f := NewFile(path).WithRotation(int64(2*(len(line)+1)+1), 2)
+	appendEntry := func(message string) {
+		require.NoError(t, f.Append(&model.AuditEntry{Time: at, Task: "refresh-cache", Message: message}))
+	}
+	messagesIn := func(p string) []string {
+		data, err := os.ReadFile(p)
+		require.NoError(t, err)
+		var messages []string
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			var r record
+			require.NoError(t, json.Unmarshal([]byte(line), &r))
+			messages = append(messages, r.Message)
+		}
+		return messages
+	}
+
+	for _, m := range []string{"m1", "m2", "m3", "m4", "m5", "m6", "m7"} {
+		appendEntry(m)
+	}
+
+	assert.Equal(t, []string{"m7"}, messagesIn(path))
+	assert.Equal(t, []string{"m5", "m6"}, messagesIn(path+".1"))
+	assert.Equal(t, []string{"m3", "m4"}, messagesIn(path+".2"))
+	assert.NoFileExists(t, path+".3", "only two backups are kept")
+}
+
+func TestDefaultPath(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/state")
+	path, err := DefaultPath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/state", "gcectl", "audit.log"), path)
+
+	t.Setenv("XDG_STATE_HOME", "")
+	path, err = DefaultPath()
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(path, filepath.Join(".local", "state", "gcectl", "audit.log")), path)
+}
@@ -0,0 +1,202 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/xdg"
+)
+
+// stateFileVersion is bumped whenever the on-disk format changes incompatibly.
+// Files with a different version are ignored and overwritten on the next Put.
+const stateFileVersion = 1
+
+// StateFile is a VMStateCache backed by a single JSON file.
+// Reads are served from memory; every Put rewrites the file atomically.
+type StateFile struct {
+	entries map[string]stateEntry
+	path    string
+	mu      sync.Mutex
+}
+
+// stateDocument is the on-disk layout of the cache file.
+type stateDocument struct {
+	Entries map[string]stateEntry `json:"entries"`
+	Version int                   `json:"version"`
+}
+
+// stateEntry is the cached state of a single VM.
+type stateEntry struct {
+	FetchedAt      time.Time      `json:"fetched_at"`
+	LastStartTime  *time.Time     `json:"last_start_time,omitempty"`
+	LastStopTime   *time.Time     `json:"last_stop_time,omitempty"`
+	CreationTime   *time.Time     `json:"creation_time,omitempty"`
+	Schedule       *stateSchedule `json:"schedule,omitempty"`
+	Name           string         `json:"name"`
+	Project        string         `json:"project"`
+	Zone           string         `json:"zone"`
+	MachineType    string         `json:"machine_type"`
+	SchedulePolicy string         `json:"schedule_policy,omitempty"`
+	InternalIP     string         `json:"internal_ip,omitempty"`
+	ExternalIP     string         `json:"external_ip,omitempty"`
+	Status         string         `json:"status"`
+}
+
+// stateSchedule is the cached schedule policy attached to a VM, kept so cached
+// listings can still compute the next start or stop time.
+type stateSchedule struct {
+	Name      string `json:"name"`
+	Region    string `json:"region"`
+	StartCron string `json:"start_cron,omitempty"`
+	StopCron  string `json:"stop_cron,omitempty"`
+	TimeZone  string `json:"time_zone,omitempty"`
+}
+
+// DefaultPath returns the default cache file location, $XDG_CACHE_HOME/gcectl/state.json
+// (~/.cache/gcectl/state.json).
+func DefaultPath() (string, error) {
+	return xdg.CacheFile("state.json")
+}
+
+// Open loads the cache file at path. A missing, unreadable or outdated file
+// yields an empty cache rather than an error, since the cache can always be rebuilt.
+//
+// Parameters:
+//   - path: The cache file path
+//
+// Returns:
+//   - *StateFile: The loaded cache
+func Open(path string) *StateFile {
+	c := &StateFile{path: path, entries: make(map[string]stateEntry)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c
+	}
+	var doc stateDocument
+	if json.Unmarshal(data, &doc) != nil || doc.Version != stateFileVersion {
+		return c
+	}
+	for k, e := range doc.Entries {
+		c.entries[k] = e
+	}
+	return c
+}
+
+// Get returns the cached state of vm, looked up by project, zone and name.
+func (c *StateFile) Get(vm *model.VM) (*model.VM, time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key(vm)]
+	if !ok {
+		return nil, time.Time{}, false
+	}
+	return &model.VM{
+		LastStartTime:  e.LastStartTime,
+		LastStopTime:   e.LastStopTime,
+		CreationTime:   e.CreationTime,
+		Schedule:       e.Schedule.toModel(e.Project),
+		Name:           e.Name,
+		Project:        e.Project,
+		Zone:           e.Zone,
+		MachineType:    e.MachineType,
+		SchedulePolicy: e.SchedulePolicy,
+		InternalIP:     e.InternalIP,
+		ExternalIP:     e.ExternalIP,
+		Status:         model.StatusFromString(e.Status),
+	}, e.FetchedAt, true
+}
+
+// Put records vms as fetched at fetchedAt and rewrites the cache file.
+// Entries for other VMs are kept.
+func (c *StateFile) Put(vms []*model.VM, fetchedAt time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, vm := range vms {
+		c.entries[key(vm)] = stateEntry{
+			FetchedAt:      fetchedAt,
+			LastStartTime:  vm.LastStartTime,
+			LastStopTime:   vm.LastStopTime,
+			CreationTime:   vm.CreationTime,
+			Schedule:       newStateSchedule(vm.Schedule),
+			Name:           vm.Name,
+			Project:        vm.Project,
+			Zone:           vm.Zone,
+			MachineType:    vm.MachineType,
+			SchedulePolicy: vm.SchedulePolicy,
+			InternalIP:     vm.InternalIP,
+			ExternalIP:     vm.ExternalIP,
+			Status:         vm.Status.String(),
+		}
+	}
+	return c.write()
+}
+
+func newStateSchedule(p *model.SchedulePolicy) *stateSchedule {
+	if p == nil {
+		return nil
+	}
+	return &stateSchedule{
+		Name:      p.Name,
+		Region:    p.Region,
+		StartCron: p.StartCron,
+		StopCron:  p.StopCron,
+		TimeZone:  p.TimeZone,
+	}
+}
+
+func (s *stateSchedule) toModel(project string) *model.SchedulePolicy {
+	if s == nil {
+		return nil
+	}
+	return &model.SchedulePolicy{
+		Name:      s.Name,
+		Project:   project,
+		Region:    s.Region,
+		StartCron: s.StartCron,
+		StopCron:  s.StopCron,
+		TimeZone:  s.TimeZone,
+	}
+}
+
+// write replaces the cache file via a temporary file so concurrent readers
+// never observe a partially written document.
+func (c *StateFile) write() error {
+	data, err := json.MarshalIndent(stateDocument{Version: stateFileVersion, Entries: c.entries}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cache: %w", err)
+	}
+
+	dir := filepath.Dir(c.path)
+	if mkErr := os.MkdirAll(dir, 0o700); mkErr != nil {
+		return fmt.Errorf("failed to create cache directory: %w", mkErr)
+	}
+	tmp, err := os.CreateTemp(dir, ".state-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create cache file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, writeErr := tmp.Write(data); writeErr != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write cache file: %w", writeErr)
+	}
+	if closeErr := tmp.Close(); closeErr != nil {
+		return fmt.Errorf("failed to write cache file: %w", closeErr)
+	}
+	if renameErr := os.Rename(tmp.Name(), c.path); renameErr != nil {
+		return fmt.Errorf("failed to replace cache file: %w", renameErr)
+	}
+	return nil
+}
+
+func key(vm *model.VM) string {
+	return vm.Project + "/" + vm.Zone + "/" + vm.Name
+}
@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateFile_PutAndGet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gcectl", "state.json")
+	started := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	fetchedAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	created := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	stopped := time.Date(2023, 12, 31, 19, 0, 0, 0, time.UTC)
+	vm := &model.VM{
+		LastStartTime: &started,
+		LastStopTime:  &stopped,
+		CreationTime:  &created,
+		Schedule: &model.SchedulePolicy{
+			Name:     "weekday-stop",
+			Project:  "proj",
+			Region:   "us-central1",
+			StopCron: "0 19 * * 1-5",
+			TimeZone: "Asia/Tokyo",
+		},
+		Name:        "vm-1",
+		Project:     "proj",
+		Zone:        "us-central1-a",
+		MachineType: "e2-medium",
+		ExternalIP:  "34.1.2.3",
+		Status:      model.StatusRunning,
+	}
+
+	require.NoError(t, Open(path).Put([]*model.VM{vm}, fetchedAt))
+
+	reopened := Open(path)
+	got, gotFetchedAt, ok := reopened.Get(&model.VM{Name: "vm-1", Project: "proj", Zone: "us-central1-a"})
+	require.True(t, ok)
+	assert.Equal(t, vm, got)
+	assert.True(t, fetchedAt.Equal(gotFetchedAt))
+
+	_, _, ok = reopened.Get(&model.VM{Name: "vm-1", Project: "proj", Zone: "us-central1-b"})
+	assert.False(t, ok, "entries are keyed by project, zone and name")
+}
+
+func TestStateFile_PutKeepsOtherEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	c := Open(path)
+	require.NoError(t, c.Put([]*model.VM{{Name: "vm-1", Project: "p", Zone: "z"}}, time.Now()))
+	require.NoError(t, c.Put([]*model.VM{{Name: "vm-2", Project: "p", Zone: "z"}}, time.Now()))
+
+	reopened := Open(path)
+	_, _, ok1 := reopened.Get(&model.VM{Name: "vm-1", Project: "p", Zone: "z"})
+	_, _, ok2 := reopened.Get(&model.VM{Name: "vm-2", Project: "p", Zone: "z"})
+	assert.True(t, ok1)
+	assert.True(t, ok2)
+}
+
+func TestOpen_IgnoresUnusableFiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "invalid JSON", content: "{"},
+		{name: "other version", content: `{"version": 99, "entries": {"p/z/vm-1": {"name": "vm-1"}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "state.json")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			_, _, ok := Open(path).Get(&model.VM{Name: "vm-1", Project: "p", Zone: "z"})
+			assert.False(t, ok)
+		})
+	}
+
+	_, _, ok := Open(filepath.Join(t.TempDir(), "missing.json")).Get(&model.VM{Name: "vm-1"})
+	assert.False(t, ok, "a missing file is an empty cache")
+}
@@ -0,0 +1,66 @@
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrUnavailable is returned when no supported clipboard command is found.
+var ErrUnavailable = errors.New("no clipboard command found (install pbcopy, wl-copy, xclip or xsel)")
+
+// candidate is a clipboard command and its arguments.
+type candidate struct {
+	name string
+	args []string
+}
+
+// candidates returns the clipboard commands to try for the given OS, in order of preference.
+func candidates(goos string) []candidate {
+	switch goos {
+	case "darwin":
+		return []candidate{{name: "pbcopy"}}
+	case "windows":
+		return []candidate{{name: "clip.exe"}}
+	default:
+		return []candidate{
+			{name: "wl-copy"},
+			{name: "xclip", args: []string{"-selection", "clipboard"}},
+			{name: "xsel", args: []string{"--clipboard", "--input"}},
+			// WSL exposes the Windows clipboard through clip.exe
+			{name: "clip.exe"},
+		}
+	}
+}
+
+// findCommand returns the first candidate available on PATH.
+func findCommand(goos string, lookPath func(string) (string, error)) (candidate, error) {
+	for _, c := range candidates(goos) {
+		if _, err := lookPath(c.name); err == nil {
+			return c, nil
+		}
+	}
+	return candidate{}, ErrUnavailable
+}
+
+// Copy places text on the system clipboard using the platform clipboard command.
+//
+// Parameters:
+//   - text: The text to copy
+//
+// Returns:
+//   - error: ErrUnavailable if no clipboard command is installed, or the command's error
+func Copy(text string) error {
+	c, err := findCommand(runtime.GOOS, exec.LookPath)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(c.name, c.args...)
+	cmd.Stdin = strings.NewReader(text)
+	if out, runErr := cmd.CombinedOutput(); runErr != nil {
+		return fmt.Errorf("failed to copy to clipboard with %s: %w: %s", c.name, runErr, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
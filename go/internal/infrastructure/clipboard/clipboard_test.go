@@ -0,0 +1,66 @@
+package clipboard
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindCommand(t *testing.T) {
+	//nolint:govet // field alignment is less important than readability in tests
+	tests := []struct {
+		name      string
+		goos      string
+		available []string
+		want      string
+		wantErr   error
+	}{
+		{
+			name:      "darwin uses pbcopy",
+			goos:      "darwin",
+			available: []string{"pbcopy"},
+			want:      "pbcopy",
+		},
+		{
+			name:      "linux prefers wl-copy",
+			goos:      "linux",
+			available: []string{"xclip", "wl-copy"},
+			want:      "wl-copy",
+		},
+		{
+			name:      "linux falls back to xsel",
+			goos:      "linux",
+			available: []string{"xsel"},
+			want:      "xsel",
+		},
+		{
+			name:      "no command available",
+			goos:      "linux",
+			available: nil,
+			wantErr:   ErrUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookPath := func(name string) (string, error) {
+				for _, a := range tt.available {
+					if a == name {
+						return "/usr/bin/" + name, nil
+					}
+				}
+				return "", errors.New("not found")
+			}
+
+			got, err := findCommand(tt.goos, lookPath)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.name)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// Package completion installs the gcectl shell completion scripts where the
+// shells load them from.
+package completion
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/infrastructure/xdg"
+)
+
+// Shells lists the shells whose completion can be installed.
+var Shells = []string{"bash", "zsh", "fish"}
+
+// rcMarker precedes the lines appended to a startup file.
+const rcMarker = "# gcectl shell completion"
+
+// Target is where the completion of a shell is installed.
+type Target struct {
+	Shell string
+	// ScriptPath is the file the completion script is written to.
+	ScriptPath string
+	// RCPath is the startup file loading the script, or empty when the shell
+	// loads ScriptPath by itself, as fish does.
+	RCPath string
+	// RCLines are appended to RCPath unless it already contains them.
+	RCLines []string
+}
+
+// Result reports what Install changed.
+type Result struct {
+	// RCUpdated is true when the lines were appended to the startup file.
+	RCUpdated bool
+}
+
+// DetectShell returns the shell named by $SHELL, e.g. "zsh" for /bin/zsh.
+//
+// Returns:
+//   - string: One of Shells
+//   - error: An error if $SHELL is not set or names an unsupported shell
+func DetectShell() (string, error) {
+	path := os.Getenv("SHELL")
+	if path == "" {
+		return "", errors.New("cannot detect the shell: SHELL is not set; name it, e.g. gcectl completion install zsh")
+	}
+	shell := filepath.Base(path)
+	if !slices.Contains(Shells, shell) {
+		return "", fmt.Errorf("unsupported shell %q; supported shells are %s", shell, strings.Join(Shells, ", "))
+	}
+	return shell, nil
+}
+
+// TargetFor returns where the completion of shell is installed for the current
+// user. Scripts go under $XDG_DATA_HOME (~/.local/share), except that fish
+// reads its completions from $XDG_CONFIG_HOME/fish/completions (~/.config).
+//
+// Parameters:
+//   - shell: One of Shells
+//
+// Returns:
+//   - Target: The script and startup file locations
+//   - error: An error if shell is unsupported or the home directory is unknown
+func TargetFor(shell string) (Target, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return Target{}, fmt.Errorf("failed to get home directory: %w", err)
+	}
+	dataHome, err := xdg.DataHome()
+	if err != nil {
+		return Target{}, err
+	}
+
+	switch shell {
+	case "bash":
+		script := filepath.Join(dataHome, "bash-completion", "completions", "gcectl")
+		return Target{
+			Shell:      shell,
+			ScriptPath: script,
+			RCPath:     filepath.Join(home, ".bashrc"),
+			RCLines:    []string{fmt.Sprintf("source %q", script)},
+		}, nil
+	case "zsh":
+		dir := filepath.Join(dataHome, "gcectl", "zsh")
+		return Target{
+			Shell:      shell,
+			ScriptPath: filepath.Join(dir, "_gcectl"),
+			RCPath:     filepath.Join(zdotdir(home), ".zshrc"),
+			RCLines:    []string{fmt.Sprintf("fpath=(%q $fpath)", dir), "autoload -U compinit && compinit"},
+		}, nil
+	case "fish":
+		configHome, configErr := xdg.ConfigHome()
+		if configErr != nil {
+			return Target{}, configErr
+		}
+		return Target{
+			Shell:      shell,
+			ScriptPath: filepath.Join(configHome, "fish", "completions", "gcectl.fish"),
+		}, nil
+	}
+	return Target{}, fmt.Errorf("unsupported shell %q; supported shells are %s", shell, strings.Join(Shells, ", "))
+}
+
+// Install writes script to t.ScriptPath, replacing an older one, and appends
+// t.RCLines to t.RCPath unless their first line is already there, so running
+// it again only refreshes the script.
+//
+// Parameters:
+//   - t: Where to install
+//   - script: The completion script
+//
+// Returns:
+//   - Result: Whether the startup file was changed
+//   - error: An error if a file could not be written
+func Install(t Target, script []byte) (Result, error) {
+	if err := os.MkdirAll(filepath.Dir(t.ScriptPath), 0o755); err != nil {
+		return Result{}, fmt.Errorf("failed to create %s: %w", filepath.Dir(t.ScriptPath), err)
+	}
+	if err := os.WriteFile(t.ScriptPath, script, 0o644); err != nil {
+		return Result{}, fmt.Errorf("failed to write %s: %w", t.ScriptPath, err)
+	}
+	if t.RCPath == "" || len(t.RCLines) == 0 {
+		return Result{}, nil
+	}
+
+	rc, err := os.ReadFile(t.RCPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Result{}, fmt.Errorf("failed to read %s: %w", t.RCPath, err)
+	}
+	if containsLine(rc, t.RCLines[0]) {
+		return Result{}, nil
+	}
+
+	var block strings.Builder
+	if len(rc) > 0 && !bytes.HasSuffix(rc, []byte("\n")) {
+		block.WriteString("\n")
+	}
+	block.WriteString("\n" + rcMarker + "\n")
+	for _, line := range t.RCLines {
+		block.WriteString(line + "\n")
+	}
+	f, err := os.OpenFile(t.RCPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to open %s: %w", t.RCPath, err)
+	}
+	if _, writeErr := f.WriteString(block.String()); writeErr != nil {
+		_ = f.Close()
+		return Result{}, fmt.Errorf("failed to write %s: %w", t.RCPath, writeErr)
+	}
+	if closeErr := f.Close(); closeErr != nil {
+		return Result{}, fmt.Errorf("failed to write %s: %w", t.RCPath, closeErr)
+	}
+	return Result{RCUpdated: true}, nil
+}
+
+// containsLine reports whether data has a line equal to line, ignoring surrounding spaces.
+func containsLine(data []byte, line string) bool {
+	for _, l := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(l) == line {
+			return true
+		}
+	}
+	return false
+}
+
+// zdotdir returns the directory of the zsh startup files, $ZDOTDIR or home.
+func zdotdir(home string) string {
+	if dir := os.Getenv("ZDOTDIR"); dir != "" {
+		return dir
+	}
+	return home
+}
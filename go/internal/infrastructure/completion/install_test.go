@@ -0,0 +1,93 @@
+package completion
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectShell(t *testing.T) {
+	t.Setenv("SHELL", "/usr/local/bin/fish")
+	shell, err := DetectShell()
+	require.NoError(t, err)
+	assert.Equal(t, "fish", shell)
+
+	t.Setenv("SHELL", "/bin/tcsh")
+	_, err = DetectShell()
+	assert.ErrorContains(t, err, `unsupported shell "tcsh"`)
+
+	t.Setenv("SHELL", "")
+	_, err = DetectShell()
+	assert.ErrorContains(t, err, "SHELL is not set")
+}
+
+func TestTargetFor(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("ZDOTDIR", "")
+
+	bash, err := TargetFor("bash")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".local", "share", "bash-completion", "completions", "gcectl"), bash.ScriptPath)
+	assert.Equal(t, filepath.Join(home, ".bashrc"), bash.RCPath)
+
+	t.Setenv("ZDOTDIR", "/zdot")
+	zsh, err := TargetFor("zsh")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/zdot", ".zshrc"), zsh.RCPath)
+
+	t.Setenv("XDG_CONFIG_HOME", "/config")
+	fish, err := TargetFor("fish")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/config", "fish", "completions", "gcectl.fish"), fish.ScriptPath)
+	assert.Empty(t, fish.RCPath, "fish loads its completions by itself")
+
+	_, err = TargetFor("powershell")
+	assert.Error(t, err)
+}
+
+func TestInstall(t *testing.T) {
+	dir := t.TempDir()
+	rc := filepath.Join(dir, ".zshrc")
+	require.NoError(t, os.WriteFile(rc, []byte("export EDITOR=vim"), 0o644))
+	target := Target{
+		Shell:      "zsh",
+		ScriptPath: filepath.Join(dir, "share", "gcectl", "zsh", "_gcectl"),
+		RCPath:     rc,
+		RCLines:    []string{`fpath=("/share/gcectl/zsh" $fpath)`, "autoload -U compinit && compinit"},
+	}
+
+	result, err := Install(target, []byte("#compdef gcectl\n"))
+	require.NoError(t, err)
+	assert.True(t, result.RCUpdated)
+	script, err := os.ReadFile(target.ScriptPath)
+	require.NoError(t, err)
+	assert.Equal(t, "#compdef gcectl\n", string(script))
+	data, err := os.ReadFile(rc)
+	require.NoError(t, err)
+	assert.Equal(t, "export EDITOR=vim\n\n"+rcMarker+"\n"+strings.Join(target.RCLines, "\n")+"\n", string(data))
+
+	result, err = Install(target, []byte("#compdef gcectl v2\n"))
+	require.NoError(t, err)
+	assert.False(t, result.RCUpdated, "the startup file is changed once")
+	again, err := os.ReadFile(rc)
+	require.NoError(t, err)
+	assert.Equal(t, string(data), string(again))
+	script, err = os.ReadFile(target.ScriptPath)
+	require.NoError(t, err)
+	assert.Equal(t, "#compdef gcectl v2\n", string(script), "the script is refreshed")
+}
+
+func TestInstallWithoutRC(t *testing.T) {
+	target := Target{Shell: "fish", ScriptPath: filepath.Join(t.TempDir(), "fish", "completions", "gcectl.fish")}
+	result, err := Install(target, []byte("complete -c gcectl\n"))
+	require.NoError(t, err)
+	assert.False(t, result.RCUpdated)
+	assert.FileExists(t, target.ScriptPath)
+}
@@ -2,10 +2,14 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/haru-256/gcectl/internal/domain/model" // ドメインモデルをインポート
-	"gopkg.in/yaml.v3"
+	"github.com/haru-256/gcectl/internal/infrastructure/retry"
 )
 
 // Config holds the application-wide configuration settings.
@@ -15,22 +19,198 @@ type Config struct {
 	DefaultProject string
 	DefaultZone    string
 	VMs            []*model.VM // ドメインモデルのVMを参照
+	// SSH holds per-VM SSH overrides keyed by VM name.
+	SSH map[string]model.SSHOptions
+	// HourlyCost holds the estimated on-demand price per hour keyed by machine type.
+	HourlyCost map[string]float64
+	// NotificationBackends holds notifier backend settings keyed by backend name.
+	NotificationBackends map[string]model.NotifierConfig
+	// NotificationRoutes holds the backend names to notify keyed by event type.
+	NotificationRoutes map[model.EventType][]string
+	// Retry is the policy for retrying transient GCP API errors.
+	Retry retry.Policy
+	// MaxConcurrency caps concurrent per-VM API calls. Zero means the built-in default.
+	MaxConcurrency int
+	// CacheTTL is how long cached VM state is served by `list --cached` before it is refreshed.
+	CacheTTL time.Duration
+	// UptimeWarning is the uptime past which `list` highlights a running VM. Zero disables it.
+	UptimeWarning time.Duration
+	// ShutdownCommand is the in-guest command `off --graceful` runs over SSH to
+	// shut a VM down.
+	ShutdownCommand string
+	// ShutdownTimeout is how long `off --graceful` waits for a VM to shut down
+	// from inside before stopping it through the API.
+	ShutdownTimeout time.Duration
+	// ConfirmStopThreshold is the number of VMs past which `off` asks for
+	// confirmation before stopping them. Zero disables the confirmation.
+	ConfirmStopThreshold int
+	// Budget caps the estimated monthly spend checked before VMs are started.
+	Budget model.Budget
+	// Spot holds the VMs marked spot: true, which spot-guard restarts after preemption.
+	Spot map[string]bool
+	// FallbackZones holds per-VM zones to start in, in order, when the VM's zone is out of capacity.
+	FallbackZones map[string][]string
+	// Forwards holds per-VM named ports for `gcectl forward`, e.g. jupyter: 8888.
+	Forwards map[string]map[string]int
+	// Workspaces holds per-VM remote folders `gcectl code` opens by default.
+	Workspaces map[string]string
+	// ShutdownCommands holds per-VM in-guest commands `off --graceful` runs
+	// instead of ShutdownCommand.
+	ShutdownCommands map[string]string
+	// DesiredStates holds the per-VM state `gcectl apply` keeps, keyed by VM name.
+	// VMs without desired-state, machine-type or schedule-policy are absent.
+	DesiredStates map[string]model.DesiredState
+	// CredentialsFile is the service account key the GCP clients use instead of
+	// Application Default Credentials. Empty means ADC.
+	CredentialsFile string
+	// ComputeEndpoint replaces the Compute Engine API endpoint. Empty means the public one.
+	ComputeEndpoint string
+	// AuditLog configures where and how the audit log is written.
+	AuditLog AuditLog
+	// OperationHistory is the file operations started by gcectl are recorded in,
+	// e.g. on a drive shared by the users of the config. Empty means the default.
+	OperationHistory string
+	// Proxy is the HTTP proxy all GCP requests go through. Nil means the proxy
+	// environment variables are honored.
+	Proxy *url.URL
+	// PersistZones writes the zones looked up for VMs without one back to config.yaml.
+	PersistZones bool
+	// UpdateCheck makes gcectl check once a day for a newer release and print a notice.
+	UpdateCheck bool
+	// LockVMs makes changes to a VM hold a lock file, so that two gcectl
+	// processes of the machine do not change it at the same time.
+	LockVMs bool
 }
 
+// AuditLog configures the audit log. Nil fields keep the defaults of the audit package.
+type AuditLog struct {
+	// MaxSize is the size in bytes past which the file is rotated; 0 disables rotation.
+	MaxSize *int64
+	// Backups is how many rotated files are kept.
+	Backups *int
+	// Path is the audit log file, empty for the default location.
+	Path string
+}
+
+// AutoZone is the zone of a VM whose zone is looked up in GCP, like a VM
+// without a zone and default-zone.
+const AutoZone = "auto"
+
+// DefaultCacheTTL is used when config.yaml does not set cache-ttl.
+const DefaultCacheTTL = 5 * time.Minute
+
+// DefaultUptimeWarning is used when config.yaml does not set uptime-warning.
+const DefaultUptimeWarning = 72 * time.Hour
+
+// DefaultConfirmStopThreshold is used when config.yaml does not set confirm-stop-threshold.
+const DefaultConfirmStopThreshold = 3
+
+// DefaultShutdownCommand is used when config.yaml does not set shutdown-command.
+const DefaultShutdownCommand = "sudo shutdown -h now"
+
+// DefaultShutdownTimeout is used when config.yaml does not set shutdown-timeout.
+const DefaultShutdownTimeout = 5 * time.Minute
+
 // yamlConfig is a temporary structure that directly maps the config.yaml file format.
 // This structure is used only within this package for unmarshaling YAML content.
 type yamlConfig struct {
-	DefaultProject string   `yaml:"default-project"`
-	DefaultZone    string   `yaml:"default-zone"`
-	VMs            []yamlVM `yaml:"vm"`
+	Notifications    *yamlNotifications `yaml:"notifications"`
+	Retry            *yamlRetry         `yaml:"retry"`
+	Budget           *yamlBudget        `yaml:"budget"`
+	AuditLog         *yamlAuditLog      `yaml:"audit-log"`
+	CacheTTL         *time.Duration     `yaml:"cache-ttl"`
+	UptimeWarning    *time.Duration     `yaml:"uptime-warning"`
+	ShutdownTimeout  *time.Duration     `yaml:"shutdown-timeout"`
+	ConfirmStop      *int               `yaml:"confirm-stop-threshold"`
+	UpdateCheck      *bool              `yaml:"update-check"`
+	LockVMs          *bool              `yaml:"lock-vms"`
+	HourlyCost       map[string]float64 `yaml:"hourly-cost"`
+	Credentials      string             `yaml:"credentials"`
+	ComputeEndpoint  string             `yaml:"compute-endpoint"`
+	OperationHistory string             `yaml:"operation-history"`
+	ShutdownCommand  string             `yaml:"shutdown-command"`
+	Proxy            string             `yaml:"proxy"`
+	DefaultProject   string             `yaml:"default-project"`
+	DefaultZone      string             `yaml:"default-zone"`
+	VMs              []yamlVM           `yaml:"vm"`
+	Projects         []yamlProject      `yaml:"projects"`
+	MaxConcurrency   int                `yaml:"max-concurrency"`
+	Version          int                `yaml:"version"`
+	PersistZones     bool               `yaml:"persist-zones"`
 }
 
 // yamlVM is a temporary structure that maps a VM entry in config.yaml.
 // This structure is used only within this package for unmarshaling YAML content.
 type yamlVM struct {
-	Name    string `yaml:"name"`
-	Project string `yaml:"project"`
-	Zone    string `yaml:"zone"`
+	SSH             *yamlSSH       `yaml:"ssh"`
+	Forwards        map[string]int `yaml:"forwards"`
+	Name            string         `yaml:"name"`
+	Workspace       string         `yaml:"workspace"`
+	Project         string         `yaml:"project"`
+	Zone            string         `yaml:"zone"`
+	DesiredState    string         `yaml:"desired-state"`
+	MachineType     string         `yaml:"machine-type"`
+	SchedulePolicy  string         `yaml:"schedule-policy"`
+	Provider        string         `yaml:"provider"`
+	ShutdownCommand string         `yaml:"shutdown-command"`
+	FallbackZones   []string       `yaml:"fallback-zones"`
+	Spot            bool           `yaml:"spot"`
+}
+
+// yamlProject maps an entry of the projects list, a project with its own VM list.
+type yamlProject struct {
+	Name        string   `yaml:"name"`
+	DefaultZone string   `yaml:"default-zone"`
+	VMs         []yamlVM `yaml:"vm"`
+}
+
+// yamlSSH maps the optional ssh block of a VM entry in config.yaml.
+type yamlSSH struct {
+	User         string `yaml:"user"`
+	IdentityFile string `yaml:"identity-file"`
+	Port         int    `yaml:"port"`
+}
+
+// yamlNotifications maps the optional notifications block of config.yaml.
+type yamlNotifications struct {
+	Backends map[string]yamlNotifier `yaml:"backends"`
+	Events   map[string][]string     `yaml:"events"`
+}
+
+// yamlNotifier maps a single named notifier backend.
+type yamlNotifier struct {
+	Type        string            `yaml:"type"`
+	WebhookURL  string            `yaml:"webhook-url"`
+	Headers     map[string]string `yaml:"headers"`
+	SMTPHost    string            `yaml:"smtp-host"`
+	Username    string            `yaml:"username"`
+	PasswordEnv string            `yaml:"password-env"`
+	From        string            `yaml:"from"`
+	To          []string          `yaml:"to"`
+	SMTPPort    int               `yaml:"smtp-port"`
+}
+
+// yamlRetry maps the optional retry block of config.yaml.
+// Omitted fields keep their default values.
+type yamlRetry struct {
+	MaxAttempts    *int           `yaml:"max-attempts"`
+	InitialBackoff *time.Duration `yaml:"initial-backoff"`
+	MaxBackoff     *time.Duration `yaml:"max-backoff"`
+	Jitter         *float64       `yaml:"jitter"`
+}
+
+// yamlAuditLog maps the optional audit-log block of config.yaml.
+type yamlAuditLog struct {
+	MaxSizeMB *int   `yaml:"max-size-mb"`
+	Backups   *int   `yaml:"backups"`
+	Path      string `yaml:"path"`
+}
+
+// yamlBudget maps the optional budget block of config.yaml.
+type yamlBudget struct {
+	Projection   *time.Duration     `yaml:"projection"`
+	MonthlyPerVM map[string]float64 `yaml:"monthly-per-vm"`
+	MonthlyTotal float64            `yaml:"monthly-total"`
 }
 
 // NewConfig reads a YAML configuration file and converts it to a Config structure.
@@ -48,71 +228,481 @@ type yamlVM struct {
 //   - *Config: The parsed configuration with domain model VMs
 //   - error: An error if file reading or YAML parsing fails
 func NewConfig(confPath string) (*Config, error) {
+	return NewConfigWithDefaults(confPath, "", "")
+}
+
+// NewConfigWithDefaults reads a YAML configuration file like NewConfig, with
+// project and zone, when not empty, replacing its default-project and default-zone.
+//
+// Parameters:
+//   - confPath: The file path to the YAML configuration file
+//   - project: The default project to use instead of default-project, or ""
+//   - zone: The default zone to use instead of default-zone, or ""
+//
+// Returns:
+//   - *Config: The parsed configuration with domain model VMs
+//   - error: An error if file reading or YAML parsing fails
+func NewConfigWithDefaults(confPath, project, zone string) (*Config, error) {
 	data, err := os.ReadFile(confPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	var ymlCnf yamlConfig
-	if unmarshalErr := yaml.Unmarshal(data, &ymlCnf); unmarshalErr != nil {
-		return nil, fmt.Errorf("failed to parse config YAML: %w", unmarshalErr)
+	ymlCnf, err := parseConfig(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if project != "" {
+		ymlCnf.DefaultProject = project
+	}
+	if zone != "" {
+		ymlCnf.DefaultZone = zone
+	}
+
+	if ymlCnf.MaxConcurrency < 0 {
+		return nil, fmt.Errorf("max-concurrency must not be negative: %d", ymlCnf.MaxConcurrency)
 	}
 
 	cnf := &Config{
-		DefaultProject: ymlCnf.DefaultProject,
-		DefaultZone:    ymlCnf.DefaultZone,
+		DefaultProject:       ymlCnf.DefaultProject,
+		DefaultZone:          ymlCnf.DefaultZone,
+		SSH:                  make(map[string]model.SSHOptions),
+		Spot:                 make(map[string]bool),
+		FallbackZones:        make(map[string][]string),
+		Forwards:             make(map[string]map[string]int),
+		Workspaces:           make(map[string]string),
+		ShutdownCommands:     make(map[string]string),
+		DesiredStates:        make(map[string]model.DesiredState),
+		HourlyCost:           ymlCnf.HourlyCost,
+		Retry:                retryPolicy(ymlCnf.Retry),
+		MaxConcurrency:       ymlCnf.MaxConcurrency,
+		CacheTTL:             DefaultCacheTTL,
+		UptimeWarning:        DefaultUptimeWarning,
+		PersistZones:         ymlCnf.PersistZones,
+		UpdateCheck:          ymlCnf.UpdateCheck == nil || *ymlCnf.UpdateCheck,
+		LockVMs:              ymlCnf.LockVMs == nil || *ymlCnf.LockVMs,
+		ConfirmStopThreshold: DefaultConfirmStopThreshold,
+		ShutdownCommand:      DefaultShutdownCommand,
+		ShutdownTimeout:      DefaultShutdownTimeout,
+	}
+	if ymlCnf.ShutdownCommand != "" {
+		cnf.ShutdownCommand = ymlCnf.ShutdownCommand
+	}
+	if ymlCnf.ShutdownTimeout != nil {
+		if *ymlCnf.ShutdownTimeout <= 0 {
+			return nil, fmt.Errorf("shutdown-timeout must be positive: %s", *ymlCnf.ShutdownTimeout)
+		}
+		cnf.ShutdownTimeout = *ymlCnf.ShutdownTimeout
+	}
+	if ymlCnf.CacheTTL != nil {
+		cnf.CacheTTL = *ymlCnf.CacheTTL
+	}
+	if ymlCnf.UptimeWarning != nil {
+		if *ymlCnf.UptimeWarning < 0 {
+			return nil, fmt.Errorf("uptime-warning must not be negative: %s", *ymlCnf.UptimeWarning)
+		}
+		cnf.UptimeWarning = *ymlCnf.UptimeWarning
+	}
+	if ymlCnf.ConfirmStop != nil {
+		if *ymlCnf.ConfirmStop < 0 {
+			return nil, fmt.Errorf("confirm-stop-threshold must not be negative: %d", *ymlCnf.ConfirmStop)
+		}
+		cnf.ConfirmStopThreshold = *ymlCnf.ConfirmStop
+	}
+	if ymlCnf.Credentials != "" {
+		cnf.CredentialsFile = ymlCnf.Credentials
+		if !filepath.IsAbs(cnf.CredentialsFile) {
+			// A relative key path is relative to the config file, not the working directory.
+			cnf.CredentialsFile = filepath.Join(filepath.Dir(confPath), cnf.CredentialsFile)
+		}
+	}
+	if a := ymlCnf.AuditLog; a != nil {
+		cnf.AuditLog.Path = a.Path
+		if cnf.AuditLog.Path != "" && !filepath.IsAbs(cnf.AuditLog.Path) {
+			cnf.AuditLog.Path = filepath.Join(filepath.Dir(confPath), cnf.AuditLog.Path)
+		}
+		if a.MaxSizeMB != nil {
+			if *a.MaxSizeMB < 0 {
+				return nil, fmt.Errorf("audit-log.max-size-mb must not be negative: %d", *a.MaxSizeMB)
+			}
+			maxSize := int64(*a.MaxSizeMB) << 20
+			cnf.AuditLog.MaxSize = &maxSize
+		}
+		if a.Backups != nil {
+			if *a.Backups < 0 {
+				return nil, fmt.Errorf("audit-log.backups must not be negative: %d", *a.Backups)
+			}
+			cnf.AuditLog.Backups = a.Backups
+		}
+	}
+	if ymlCnf.OperationHistory != "" {
+		cnf.OperationHistory = ymlCnf.OperationHistory
+		if !filepath.IsAbs(cnf.OperationHistory) {
+			cnf.OperationHistory = filepath.Join(filepath.Dir(confPath), cnf.OperationHistory)
+		}
+	}
+
+	if ymlCnf.ComputeEndpoint != "" {
+		if _, urlErr := parseAbsoluteURL(ymlCnf.ComputeEndpoint); urlErr != nil {
+			return nil, fmt.Errorf("compute-endpoint: %w", urlErr)
+		}
+		cnf.ComputeEndpoint = ymlCnf.ComputeEndpoint
+	}
+	if ymlCnf.Proxy != "" {
+		proxy, urlErr := parseAbsoluteURL(ymlCnf.Proxy)
+		if urlErr != nil {
+			return nil, fmt.Errorf("proxy: %w", urlErr)
+		}
+		cnf.Proxy = proxy
+	}
+
+	entries, err := vmEntries(&ymlCnf)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		ymlVm, vm := entry.yml, entry.vm
+		if seen[vm.QualifiedName()] {
+			return nil, fmt.Errorf("vm %s is listed twice", vm.QualifiedName())
+		}
+		seen[vm.QualifiedName()] = true
+		cnf.VMs = append(cnf.VMs, vm)
+
+		if ymlVm.SSH != nil {
+			cnf.SSH[ymlVm.Name] = model.SSHOptions{
+				User:         ymlVm.SSH.User,
+				IdentityFile: ymlVm.SSH.IdentityFile,
+				Port:         ymlVm.SSH.Port,
+			}
+		}
+		if ymlVm.Spot {
+			cnf.Spot[ymlVm.Name] = true
+		}
+		if len(ymlVm.FallbackZones) > 0 {
+			if zoneErr := validateFallbackZones(vm, ymlVm.FallbackZones); zoneErr != nil {
+				return nil, zoneErr
+			}
+			cnf.FallbackZones[ymlVm.Name] = ymlVm.FallbackZones
+		}
+		for name, port := range ymlVm.Forwards {
+			if port < 1 || port > 65535 {
+				return nil, fmt.Errorf("vm %s: forward %s: port must be between 1 and 65535: %d", ymlVm.Name, name, port)
+			}
+		}
+		if len(ymlVm.Forwards) > 0 {
+			cnf.Forwards[ymlVm.Name] = ymlVm.Forwards
+		}
+		if ymlVm.Workspace != "" {
+			cnf.Workspaces[ymlVm.Name] = ymlVm.Workspace
+		}
+		if ymlVm.ShutdownCommand != "" {
+			cnf.ShutdownCommands[ymlVm.Name] = ymlVm.ShutdownCommand
+		}
+		status, statusErr := model.ParseDesiredStatus(ymlVm.DesiredState)
+		if statusErr != nil {
+			return nil, fmt.Errorf("vm %s: %w", ymlVm.Name, statusErr)
+		}
+		desired := model.DesiredState{Status: status, MachineType: ymlVm.MachineType, SchedulePolicy: ymlVm.SchedulePolicy}
+		if !desired.IsZero() {
+			cnf.DesiredStates[ymlVm.Name] = desired
+		}
+	}
+
+	if ymlCnf.Budget != nil {
+		if budgetErr := cnf.setBudget(ymlCnf.Budget); budgetErr != nil {
+			return nil, budgetErr
+		}
+	}
+
+	if ymlCnf.Notifications != nil {
+		if notifErr := cnf.setNotifications(ymlCnf.Notifications); notifErr != nil {
+			return nil, notifErr
+		}
+	}
+
+	return cnf, nil
+}
+
+// vmEntry is a VM entry of config.yaml together with the VM it resolves to.
+type vmEntry struct {
+	yml yamlVM
+	vm  *model.VM
+}
+
+// vmEntries resolves the project and zone of every VM in the vm list and in the
+// vm lists of projects, in that order.
+//
+// A VM in the vm list takes its project from a "project/name" name, its project
+// key or default-project. A VM in a projects entry takes the project of the entry
+// and falls back to the entry's default-zone before the global one.
+func vmEntries(ymlCnf *yamlConfig) ([]vmEntry, error) {
+	var entries []vmEntry
+	add := func(ymlVm yamlVM, project, zone string) error {
+		qualifier, name := model.SplitQualifiedName(ymlVm.Name)
+		if name == "" {
+			return fmt.Errorf("vm %q: name must not be empty", ymlVm.Name)
+		}
+		for _, p := range []string{qualifier, ymlVm.Project} {
+			if p == "" {
+				continue
+			}
+			if project != "" && p != project {
+				return fmt.Errorf("vm %s: project %s conflicts with %s", ymlVm.Name, p, project)
+			}
+			project = p
+		}
+		if ymlVm.Zone != "" {
+			zone = ymlVm.Zone
+		}
+		if zone == AutoZone {
+			// An empty zone is looked up when the VM repository is opened.
+			zone = ""
+		}
+		ymlVm.Name = name
+		entries = append(entries, vmEntry{yml: ymlVm, vm: &model.VM{Name: name, Project: project, Zone: zone, Provider: ymlVm.Provider}})
+		return nil
 	}
 
 	for _, ymlVm := range ymlCnf.VMs {
-		project := ymlVm.Project
-		if project == "" {
+		// default-project only applies when neither the name nor the project key sets one
+		project := ""
+		if qualifier, _ := model.SplitQualifiedName(ymlVm.Name); qualifier == "" && ymlVm.Project == "" {
 			project = ymlCnf.DefaultProject
 		}
-		zone := ymlVm.Zone
+		if err := add(ymlVm, project, ymlCnf.DefaultZone); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range ymlCnf.Projects {
+		if p.Name == "" {
+			return nil, fmt.Errorf("projects: every entry needs a name")
+		}
+		zone := p.DefaultZone
 		if zone == "" {
 			zone = ymlCnf.DefaultZone
 		}
+		for _, ymlVm := range p.VMs {
+			if err := add(ymlVm, p.Name, zone); err != nil {
+				return nil, fmt.Errorf("projects: %w", err)
+			}
+		}
+	}
+	return entries, nil
+}
+
+// parseAbsoluteURL parses raw and requires a scheme and a host, as endpoints and proxies need both.
+func parseAbsoluteURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("%q must be an absolute URL such as https://host:port", raw)
+	}
+	return u, nil
+}
 
-		vm := &model.VM{
-			Name:    ymlVm.Name,
-			Project: project,
-			Zone:    zone,
+// validateFallbackZones checks that the fallback zones of vm are distinct and differ from its zone.
+func validateFallbackZones(vm *model.VM, zones []string) error {
+	seen := map[string]bool{vm.Zone: true}
+	for _, zone := range zones {
+		if zone == "" {
+			return fmt.Errorf("vm %s: fallback-zones must not contain empty zones", vm.Name)
 		}
-		cnf.VMs = append(cnf.VMs, vm)
+		if seen[zone] {
+			return fmt.Errorf("vm %s: fallback zone %s is listed twice or is the VM's zone", vm.Name, zone)
+		}
+		seen[zone] = true
 	}
+	return nil
+}
 
-	return cnf, nil
+// retryPolicy overlays the retry block on the default policy.
+func retryPolicy(r *yamlRetry) retry.Policy {
+	policy := retry.DefaultPolicy()
+	if r == nil {
+		return policy
+	}
+	if r.MaxAttempts != nil {
+		policy.MaxAttempts = *r.MaxAttempts
+	}
+	if r.InitialBackoff != nil {
+		policy.InitialBackoff = *r.InitialBackoff
+	}
+	if r.MaxBackoff != nil {
+		policy.MaxBackoff = *r.MaxBackoff
+	}
+	if r.Jitter != nil {
+		policy.Jitter = *r.Jitter
+	}
+	return policy
 }
 
-// getVMByName searches for a VM with the specified name in the configuration.
-func (c *Config) getVMByName(name string) *model.VM {
-	for _, vm := range c.VMs {
-		if vm.Name == name {
-			return vm
+// setNotifications converts the notifications block and validates that every route
+// refers to a known event type and a defined backend.
+func (c *Config) setNotifications(n *yamlNotifications) error {
+	c.NotificationBackends = make(map[string]model.NotifierConfig, len(n.Backends))
+	for name, b := range n.Backends {
+		c.NotificationBackends[name] = model.NotifierConfig{
+			Type:        b.Type,
+			WebhookURL:  b.WebhookURL,
+			Headers:     b.Headers,
+			SMTPHost:    b.SMTPHost,
+			Username:    b.Username,
+			PasswordEnv: b.PasswordEnv,
+			From:        b.From,
+			To:          b.To,
+			SMTPPort:    b.SMTPPort,
 		}
 	}
+
+	c.NotificationRoutes = make(map[model.EventType][]string, len(n.Events))
+	for event, names := range n.Events {
+		eventType := model.EventType(event)
+		if !eventType.IsValid() {
+			return fmt.Errorf("notifications: unknown event type %q", event)
+		}
+		for _, name := range names {
+			if _, ok := c.NotificationBackends[name]; !ok {
+				return fmt.Errorf("notifications: event %s refers to undefined backend %q", event, name)
+			}
+		}
+		c.NotificationRoutes[eventType] = names
+	}
 	return nil
 }
 
-// ResolveVMs returns VM domain models matching the given names.
-// It maintains the order of names requested.
+// setBudget converts the budget block and validates that caps are positive and
+// refer to configured VMs.
+func (c *Config) setBudget(b *yamlBudget) error {
+	if b.MonthlyTotal < 0 {
+		return fmt.Errorf("budget: monthly-total must not be negative: %v", b.MonthlyTotal)
+	}
+	c.Budget = model.Budget{
+		MonthlyTotal: b.MonthlyTotal,
+		MonthlyPerVM: make(map[string]float64, len(b.MonthlyPerVM)),
+		Projection:   model.DefaultBudgetProjection,
+	}
+	if b.Projection != nil {
+		if *b.Projection <= 0 {
+			return fmt.Errorf("budget: projection must be positive: %s", *b.Projection)
+		}
+		c.Budget.Projection = *b.Projection
+	}
+	for name, limit := range b.MonthlyPerVM {
+		if _, err := c.ResolveVM(name); err != nil {
+			return fmt.Errorf("budget: monthly-per-vm: %w", err)
+		}
+		if limit <= 0 {
+			return fmt.Errorf("budget: monthly-per-vm of %s must be positive: %v", name, limit)
+		}
+		c.Budget.MonthlyPerVM[name] = limit
+	}
+	return nil
+}
+
+// ResolveVMs returns VM domain models matching the given names, which may be
+// qualified as "project/name". It maintains the order of names requested.
 func (c *Config) ResolveVMs(names []string) ([]*model.VM, error) {
 	vms := make([]*model.VM, 0, len(names))
 	for _, name := range names {
-		vm := c.getVMByName(name)
-		if vm == nil {
-			return nil, fmt.Errorf("VM %s not found in config", name)
+		vm, err := c.ResolveVM(name)
+		if err != nil {
+			return nil, err
 		}
 		vms = append(vms, vm)
 	}
 	return vms, nil
 }
 
-// ResolveVM returns a single VM domain model matching the given name.
+// SSHOptionsFor returns the SSH overrides configured for the named VM.
+// A zero value is returned when the VM has no ssh block.
+func (c *Config) SSHOptionsFor(name string) model.SSHOptions {
+	return c.SSH[name]
+}
+
+// FallbackZonesFor returns the zones to try, in order, when the named VM's zone is out of capacity.
+func (c *Config) FallbackZonesFor(name string) []string {
+	return c.FallbackZones[name]
+}
+
+// ForwardsFor returns the named ports configured for the named VM, or nil when it has none.
+func (c *Config) ForwardsFor(name string) map[string]int {
+	return c.Forwards[name]
+}
+
+// ShutdownCommandFor returns the in-guest shutdown command of the named VM: its
+// own shutdown-command, or else the global one.
+func (c *Config) ShutdownCommandFor(name string) string {
+	if command, ok := c.ShutdownCommands[name]; ok {
+		return command
+	}
+	return c.ShutdownCommand
+}
+
+// WorkspaceFor returns the remote folder configured for the named VM, or "" when it has none.
+func (c *Config) WorkspaceFor(name string) string {
+	return c.Workspaces[name]
+}
+
+// ManagedVMs returns the VMs with a desired-state, machine-type or schedule-policy, in config order.
+func (c *Config) ManagedVMs() []*model.VM {
+	var vms []*model.VM
+	for _, vm := range c.VMs {
+		if _, ok := c.DesiredStates[vm.Name]; ok {
+			vms = append(vms, vm)
+		}
+	}
+	return vms
+}
+
+// SpotVMs returns the VMs marked spot: true, in config order.
+func (c *Config) SpotVMs() []*model.VM {
+	var vms []*model.VM
+	for _, vm := range c.VMs {
+		if c.Spot[vm.Name] {
+			vms = append(vms, vm)
+		}
+	}
+	return vms
+}
+
+// Providers returns the providers of the VMs, each once, in config order.
+func (c *Config) Providers() []string {
+	var providers []string
+	seen := make(map[string]bool)
+	for _, vm := range c.VMs {
+		name := vm.ProviderName()
+		if !seen[name] {
+			seen[name] = true
+			providers = append(providers, name)
+		}
+	}
+	return providers
+}
+
+// ResolveVM returns a single VM domain model matching the given name. A name
+// qualified as "project/name" only matches the VM in that project; an
+// unqualified name that is in several projects is an error.
 func (c *Config) ResolveVM(name string) (*model.VM, error) {
-	vm := c.getVMByName(name)
-	if vm == nil {
-		return nil, fmt.Errorf("VM %s not found in config", name)
+	project, bare := model.SplitQualifiedName(name)
+	var matches []*model.VM
+	for _, vm := range c.VMs {
+		if vm.Name == bare && (project == "" || vm.Project == project) {
+			matches = append(matches, vm)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%w: %s", model.ErrVMNotFoundInConfig, name)
+	case 1:
+		return matches[0], nil
+	}
+	qualified := make([]string, len(matches))
+	for i, vm := range matches {
+		qualified[i] = vm.QualifiedName()
 	}
-	return vm, nil
+	return nil, fmt.Errorf("VM %s is in several projects, use one of %s", name, strings.Join(qualified, ", "))
 }
@@ -4,8 +4,10 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/retry"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -39,6 +41,8 @@ vm:
 				assert.Equal(t, "vm1", cfg.VMs[0].Name, "VM[0].Name should be vm1")
 				assert.Equal(t, "project1", cfg.VMs[0].Project, "VM[0].Project should be project1")
 				assert.Equal(t, "zone1", cfg.VMs[0].Zone, "VM[0].Zone should be zone1")
+				assert.True(t, cfg.UpdateCheck, "the update check is on by default")
+				assert.True(t, cfg.LockVMs, "VMs are locked by default")
 				assert.Equal(t, "vm2", cfg.VMs[1].Name, "VM[1].Name should be vm2")
 				assert.Equal(t, "project2", cfg.VMs[1].Project, "VM[1].Project should be project2")
 				assert.Equal(t, "zone2", cfg.VMs[1].Zone, "VM[1].Zone should be zone2")
@@ -69,6 +73,568 @@ vm:
 				assert.Equal(t, "custom-zone", cfg.VMs[2].Zone, "VM[2].Zone should be custom-zone")
 			},
 		},
+		{
+			name: "success: zone auto is looked up later",
+			yamlContent: `default-project: default-proj
+default-zone: default-zone
+persist-zones: true
+vm:
+  - name: vm1
+    zone: auto
+`,
+			wantErr: false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				require.Len(t, cfg.VMs, 1)
+				assert.Empty(t, cfg.VMs[0].Zone, "zone auto should override default-zone")
+				assert.True(t, cfg.PersistZones)
+			},
+		},
+		{
+			name: "success: update check opt-out",
+			yamlContent: `default-project: default-proj
+default-zone: default-zone
+update-check: false
+vm:
+  - name: vm1
+`,
+			wantErr: false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				assert.False(t, cfg.UpdateCheck)
+			},
+		},
+		{
+			name:        "success: lock vms opt-out",
+			yamlContent: "lock-vms: false\n",
+			wantErr:     false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				assert.False(t, cfg.LockVMs)
+			},
+		},
+		{
+			name: "success: per-VM ssh overrides",
+			yamlContent: `default-project: default-proj
+default-zone: default-zone
+vm:
+  - name: vm1
+    ssh:
+      user: alice
+      port: 2222
+      identity-file: ~/.ssh/id_ed25519
+  - name: vm2
+`,
+			wantErr: false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, model.SSHOptions{User: "alice", Port: 2222, IdentityFile: "~/.ssh/id_ed25519"}, cfg.SSHOptionsFor("vm1"))
+				assert.Equal(t, model.SSHOptions{}, cfg.SSHOptionsFor("vm2"), "VM without ssh block should have zero options")
+			},
+		},
+		{
+			name: "success: spot VMs",
+			yamlContent: `default-project: default-proj
+default-zone: default-zone
+vm:
+  - name: vm1
+  - name: vm2
+    spot: true
+`,
+			wantErr: false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				spot := cfg.SpotVMs()
+				require.Len(t, spot, 1)
+				assert.Equal(t, "vm2", spot[0].Name)
+			},
+		},
+		{
+			name: "success: VM providers",
+			yamlContent: `default-project: default-proj
+default-zone: default-zone
+vm:
+  - name: vm1
+  - name: builder
+    project: ci-account
+    zone: us-east-1a
+    provider: aws
+  - name: vm2
+    provider: gcp
+`,
+			wantErr: false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				require.Len(t, cfg.VMs, 3)
+				assert.Equal(t, "", cfg.VMs[0].Provider)
+				assert.Equal(t, "aws", cfg.VMs[1].Provider)
+				assert.Equal(t, []string{"gcp", "aws"}, cfg.Providers())
+			},
+		},
+		{
+			name: "success: fallback zones",
+			yamlContent: `default-project: default-proj
+default-zone: us-central1-a
+vm:
+  - name: vm1
+    fallback-zones: [us-central1-b, us-central1-f]
+  - name: vm2
+`,
+			wantErr: false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, []string{"us-central1-b", "us-central1-f"}, cfg.FallbackZonesFor("vm1"))
+				assert.Empty(t, cfg.FallbackZonesFor("vm2"))
+			},
+		},
+		{
+			name: "error: fallback zone equal to the VM's zone",
+			yamlContent: `default-project: default-proj
+default-zone: us-central1-a
+vm:
+  - name: vm1
+    fallback-zones: [us-central1-b, us-central1-a]
+`,
+			wantErr:      true,
+			validateFunc: nil,
+		},
+		{
+			name: "success: forwards and workspace",
+			yamlContent: `default-project: default-proj
+default-zone: us-central1-a
+vm:
+  - name: vm1
+    forwards:
+      jupyter: 8888
+      tensorboard: 6006
+    workspace: /home/alice/project
+  - name: vm2
+`,
+			wantErr: false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, map[string]int{"jupyter": 8888, "tensorboard": 6006}, cfg.ForwardsFor("vm1"))
+				assert.Empty(t, cfg.ForwardsFor("vm2"))
+				assert.Equal(t, "/home/alice/project", cfg.WorkspaceFor("vm1"))
+				assert.Empty(t, cfg.WorkspaceFor("vm2"))
+			},
+		},
+		{
+			name: "error: forward port out of range",
+			yamlContent: `default-project: default-proj
+default-zone: us-central1-a
+vm:
+  - name: vm1
+    forwards:
+      jupyter: 70000
+`,
+			wantErr:      true,
+			validateFunc: nil,
+		},
+		{
+			name: "success: projects with their own VM lists",
+			yamlContent: `default-project: proj-a
+default-zone: us-central1-a
+vm:
+  - name: web
+  - name: proj-c/batch
+projects:
+  - name: proj-b
+    default-zone: asia-northeast1-a
+    vm:
+      - name: web
+      - name: db
+        zone: asia-northeast1-b
+`,
+			wantErr: false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				require.Len(t, cfg.VMs, 4)
+				assert.Equal(t, &model.VM{Name: "web", Project: "proj-a", Zone: "us-central1-a"}, cfg.VMs[0])
+				assert.Equal(t, &model.VM{Name: "batch", Project: "proj-c", Zone: "us-central1-a"}, cfg.VMs[1])
+				assert.Equal(t, &model.VM{Name: "web", Project: "proj-b", Zone: "asia-northeast1-a"}, cfg.VMs[2])
+				assert.Equal(t, &model.VM{Name: "db", Project: "proj-b", Zone: "asia-northeast1-b"}, cfg.VMs[3])
+			},
+		},
+		{
+			name: "error: qualified name conflicting with project",
+			yamlContent: `vm:
+  - name: proj-a/web
+    project: proj-b
+`,
+			wantErr:      true,
+			validateFunc: nil,
+		},
+		{
+			name: "error: VM listed twice in a project",
+			yamlContent: `default-project: proj-a
+vm:
+  - name: web
+projects:
+  - name: proj-a
+    vm:
+      - name: web
+`,
+			wantErr:      true,
+			validateFunc: nil,
+		},
+		{
+			name: "success: desired state",
+			yamlContent: `default-project: default-proj
+default-zone: us-central1-a
+vm:
+  - name: vm1
+    desired-state: running
+    machine-type: e2-standard-4
+  - name: vm2
+  - name: vm3
+    desired-state: stopped
+    schedule-policy: weekday-stop
+`,
+			wantErr: false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, model.DesiredState{Status: model.DesiredRunning, MachineType: "e2-standard-4"}, cfg.DesiredStates["vm1"])
+				assert.Equal(t, model.DesiredState{Status: model.DesiredStopped, SchedulePolicy: "weekday-stop"}, cfg.DesiredStates["vm3"])
+				managed := cfg.ManagedVMs()
+				require.Len(t, managed, 2)
+				assert.Equal(t, "vm1", managed[0].Name)
+				assert.Equal(t, "vm3", managed[1].Name)
+			},
+		},
+		{
+			name: "error: unknown desired state",
+			yamlContent: `default-project: default-proj
+default-zone: us-central1-a
+vm:
+  - name: vm1
+    desired-state: paused
+`,
+			wantErr:      true,
+			validateFunc: nil,
+		},
+		{
+			name: "success: absolute credentials file",
+			yamlContent: `default-project: default-proj
+default-zone: us-central1-a
+credentials: /keys/gcectl.json
+vm:
+  - name: vm1
+`,
+			wantErr: false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "/keys/gcectl.json", cfg.CredentialsFile)
+			},
+		},
+		{
+			name: "success: credentials file relative to the config file",
+			yamlContent: `default-project: default-proj
+default-zone: us-central1-a
+credentials: keys/gcectl.json
+vm:
+  - name: vm1
+`,
+			wantErr: false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				assert.True(t, filepath.IsAbs(cfg.CredentialsFile), "relative path should be resolved against the config directory")
+				assert.Equal(t, "keys", filepath.Base(filepath.Dir(cfg.CredentialsFile)))
+				assert.Equal(t, "gcectl.json", filepath.Base(cfg.CredentialsFile))
+			},
+		},
+		{
+			name: "success: audit log",
+			yamlContent: `default-project: default-proj
+default-zone: us-central1-a
+audit-log:
+  path: /var/log/gcectl/audit.log
+  max-size-mb: 50
+  backups: 0
+vm:
+  - name: vm1
+`,
+			wantErr: false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "/var/log/gcectl/audit.log", cfg.AuditLog.Path)
+				require.NotNil(t, cfg.AuditLog.MaxSize)
+				assert.Equal(t, int64(50<<20), *cfg.AuditLog.MaxSize)
+				require.NotNil(t, cfg.AuditLog.Backups)
+				assert.Equal(t, 0, *cfg.AuditLog.Backups)
+			},
+		},
+		{
+			name: "error: negative audit log backups",
+			yamlContent: `default-project: default-proj
+default-zone: us-central1-a
+audit-log:
+  backups: -1
+vm:
+  - name: vm1
+`,
+			wantErr:      true,
+			validateFunc: nil,
+		},
+		{
+			name: "success: operation history relative to the config file",
+			yamlContent: `default-project: default-proj
+default-zone: us-central1-a
+operation-history: shared/operations.log
+vm:
+  - name: vm1
+`,
+			wantErr: false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				assert.True(t, filepath.IsAbs(cfg.OperationHistory), "relative path should be resolved against the config directory")
+				assert.Equal(t, "operations.log", filepath.Base(cfg.OperationHistory))
+			},
+		},
+		{
+			name: "success: compute endpoint and proxy",
+			yamlContent: `default-project: default-proj
+default-zone: us-central1-a
+compute-endpoint: https://compute-private.p.googleapis.com
+proxy: http://proxy.example.com:3128
+vm:
+  - name: vm1
+`,
+			wantErr: false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "https://compute-private.p.googleapis.com", cfg.ComputeEndpoint)
+				require.NotNil(t, cfg.Proxy)
+				assert.Equal(t, "proxy.example.com:3128", cfg.Proxy.Host)
+			},
+		},
+		{
+			name: "error: proxy without scheme",
+			yamlContent: `default-project: default-proj
+default-zone: us-central1-a
+proxy: proxy.example.com:3128
+vm:
+  - name: vm1
+`,
+			wantErr:      true,
+			validateFunc: nil,
+		},
+		{
+			name: "error: relative compute endpoint",
+			yamlContent: `default-project: default-proj
+default-zone: us-central1-a
+compute-endpoint: compute-private.p.googleapis.com
+vm:
+  - name: vm1
+`,
+			wantErr:      true,
+			validateFunc: nil,
+		},
+		{
+			name: "success: hourly cost table",
+			yamlContent: `default-project: default-proj
+default-zone: default-zone
+hourly-cost:
+  e2-medium: 0.0335
+  n1-standard-4: 0.19
+vm:
+  - name: vm1
+`,
+			wantErr: false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, map[string]float64{"e2-medium": 0.0335, "n1-standard-4": 0.19}, cfg.HourlyCost)
+			},
+		},
+		{
+			name: "success: notification backends and routes",
+			yamlContent: `default-project: default-proj
+default-zone: default-zone
+notifications:
+  backends:
+    team-slack:
+      type: slack
+      webhook-url: https://hooks.slack.com/services/x
+    ops-mail:
+      type: email
+      smtp-host: smtp.example.com
+      smtp-port: 465
+      username: bot
+      password-env: GCECTL_SMTP_PASSWORD
+      from: gcectl@example.com
+      to: [ops@example.com]
+    ops-hook:
+      type: webhook
+      webhook-url: https://ops.example.com/hooks/gcectl
+      headers:
+        Authorization: Bearer ${OPS_TOKEN}
+  events:
+    operation-failure: [team-slack, ops-mail]
+    preemption: [team-slack]
+    auto-stop: [ops-hook]
+vm:
+  - name: vm1
+`,
+			wantErr: false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				require.Len(t, cfg.NotificationBackends, 3)
+				assert.Equal(t, model.NotifierConfig{Type: "slack", WebhookURL: "https://hooks.slack.com/services/x"}, cfg.NotificationBackends["team-slack"])
+				assert.Equal(t, 465, cfg.NotificationBackends["ops-mail"].SMTPPort)
+				assert.Equal(t, "GCECTL_SMTP_PASSWORD", cfg.NotificationBackends["ops-mail"].PasswordEnv)
+				assert.Equal(t, []string{"team-slack", "ops-mail"}, cfg.NotificationRoutes[model.EventOperationFailure])
+				assert.Equal(t, []string{"team-slack"}, cfg.NotificationRoutes[model.EventPreemption])
+				assert.Equal(t, map[string]string{"Authorization": "Bearer ${OPS_TOKEN}"}, cfg.NotificationBackends["ops-hook"].Headers)
+				assert.Equal(t, []string{"ops-hook"}, cfg.NotificationRoutes[model.EventAutoStop])
+			},
+		},
+		{
+			name: "error: notification route to undefined backend",
+			yamlContent: `notifications:
+  events:
+    operation-failure: [team-slack]
+`,
+			wantErr:      true,
+			validateFunc: nil,
+		},
+		{
+			name: "error: unknown notification event",
+			yamlContent: `notifications:
+  backends:
+    team-slack:
+      type: slack
+      webhook-url: https://hooks.slack.com/services/x
+  events:
+    vm-deleted: [team-slack]
+`,
+			wantErr:      true,
+			validateFunc: nil,
+		},
+		{
+			name: "success: default retry policy",
+			yamlContent: `default-project: default-proj
+default-zone: default-zone
+`,
+			wantErr: false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, retry.DefaultPolicy(), cfg.Retry)
+				assert.Equal(t, DefaultCacheTTL, cfg.CacheTTL)
+				assert.Equal(t, DefaultUptimeWarning, cfg.UptimeWarning)
+				assert.Equal(t, DefaultConfirmStopThreshold, cfg.ConfirmStopThreshold)
+				assert.Equal(t, DefaultShutdownCommand, cfg.ShutdownCommandFor("any-vm"))
+				assert.Equal(t, DefaultShutdownTimeout, cfg.ShutdownTimeout)
+			},
+		},
+		{
+			name: "success: retry overrides",
+			yamlContent: `retry:
+  max-attempts: 1
+  initial-backoff: 250ms
+`,
+			wantErr: false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, 1, cfg.Retry.MaxAttempts)
+				assert.Equal(t, 250*time.Millisecond, cfg.Retry.InitialBackoff)
+				assert.Equal(t, retry.DefaultPolicy().MaxBackoff, cfg.Retry.MaxBackoff, "omitted fields keep defaults")
+				assert.False(t, cfg.Retry.Enabled())
+			},
+		},
+		{
+			name:        "success: max concurrency",
+			yamlContent: "max-concurrency: 4\n",
+			wantErr:     false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, 4, cfg.MaxConcurrency)
+			},
+		},
+		{
+			name:        "success: cache ttl",
+			yamlContent: "cache-ttl: 30s\n",
+			wantErr:     false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, 30*time.Second, cfg.CacheTTL)
+			},
+		},
+		{
+			name:        "success: uptime warning",
+			yamlContent: "uptime-warning: 24h\n",
+			wantErr:     false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, 24*time.Hour, cfg.UptimeWarning)
+			},
+		},
+		{
+			name:        "error: negative uptime warning",
+			yamlContent: "uptime-warning: -1h\n",
+			wantErr:     true,
+		},
+		{
+			name:        "success: confirm stop threshold",
+			yamlContent: "confirm-stop-threshold: 0\n",
+			wantErr:     false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, 0, cfg.ConfirmStopThreshold)
+			},
+		},
+		{
+			name:        "error: negative confirm stop threshold",
+			yamlContent: "confirm-stop-threshold: -1\n",
+			wantErr:     true,
+		},
+		{
+			name: "success: shutdown command and timeout",
+			yamlContent: `shutdown-command: sudo systemctl poweroff
+shutdown-timeout: 2m
+vm:
+  - name: vm1
+  - name: vm2
+    shutdown-command: /opt/drain.sh && sudo shutdown -h now
+`,
+			wantErr: false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, "sudo systemctl poweroff", cfg.ShutdownCommandFor("vm1"))
+				assert.Equal(t, "/opt/drain.sh && sudo shutdown -h now", cfg.ShutdownCommandFor("vm2"))
+				assert.Equal(t, 2*time.Minute, cfg.ShutdownTimeout)
+			},
+		},
+		{
+			name:        "error: zero shutdown timeout",
+			yamlContent: "shutdown-timeout: 0s\n",
+			wantErr:     true,
+		},
+		{
+			name: "success: budget",
+			yamlContent: `budget:
+  monthly-total: 500
+  monthly-per-vm:
+    vm1: 200
+vm:
+  - name: vm1
+`,
+			wantErr: false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, model.Budget{
+					MonthlyTotal: 500,
+					MonthlyPerVM: map[string]float64{"vm1": 200},
+					Projection:   model.DefaultBudgetProjection,
+				}, cfg.Budget)
+				assert.True(t, cfg.Budget.IsSet())
+			},
+		},
+		{
+			name:        "success: no budget",
+			yamlContent: "default-project: default-proj\n",
+			wantErr:     false,
+			validateFunc: func(t *testing.T, cfg *Config) {
+				assert.False(t, cfg.Budget.IsSet())
+			},
+		},
+		{
+			name: "error: budget for unknown VM",
+			yamlContent: `budget:
+  monthly-per-vm:
+    vm2: 200
+vm:
+  - name: vm1
+`,
+			wantErr:      true,
+			validateFunc: nil,
+		},
+		{
+			name:         "error: non-positive budget projection",
+			yamlContent:  "budget:\n  monthly-total: 100\n  projection: 0s\n",
+			wantErr:      true,
+			validateFunc: nil,
+		},
+		{
+			name:         "error: negative max concurrency",
+			yamlContent:  "max-concurrency: -1\n",
+			wantErr:      true,
+			validateFunc: nil,
+		},
 		{
 			name:         "error: file not found",
 			yamlContent:  "",
@@ -174,12 +740,12 @@ func TestConfig_ResolveVM(t *testing.T) {
 
 	t.Run("not found", func(t *testing.T) {
 		vm, err := cfg.ResolveVM("missing")
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, model.ErrVMNotFoundInConfig)
 		assert.Nil(t, vm)
 	})
 }
 
-func TestConfig_getVMByName(t *testing.T) {
+func TestConfig_ResolveVMByName(t *testing.T) {
 	cfg := &Config{
 		DefaultProject: "test-project",
 		DefaultZone:    "us-central1-a",
@@ -219,17 +785,62 @@ func TestConfig_getVMByName(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			vm := cfg.getVMByName(tt.vmName)
+			vm, _ := cfg.ResolveVM(tt.vmName)
 
 			if tt.wantNil {
-				assert.Nil(t, vm, "getVMByName() should return nil")
+				assert.Nil(t, vm, "ResolveVM() should return nil")
 				return
 			}
 
-			require.NotNil(t, vm, "getVMByName() should not return nil")
+			require.NotNil(t, vm, "ResolveVM() should not return nil")
 			assert.Equal(t, tt.wantVM.Name, vm.Name, "VM.Name should match")
 			assert.Equal(t, tt.wantVM.Project, vm.Project, "VM.Project should match")
 			assert.Equal(t, tt.wantVM.Zone, vm.Zone, "VM.Zone should match")
 		})
 	}
 }
+
+func TestConfig_ResolveVMQualified(t *testing.T) {
+	cfg := &Config{
+		VMs: []*model.VM{
+			{Name: "dev", Project: "proj-a", Zone: "zone1"},
+			{Name: "dev", Project: "proj-b", Zone: "zone2"},
+			{Name: "web", Project: "proj-a", Zone: "zone1"},
+		},
+	}
+
+	vm, err := cfg.ResolveVM("proj-b/dev")
+	require.NoError(t, err)
+	assert.Equal(t, "zone2", vm.Zone)
+
+	vm, err = cfg.ResolveVM("web")
+	require.NoError(t, err)
+	assert.Equal(t, "proj-a", vm.Project)
+
+	_, err = cfg.ResolveVM("dev")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "proj-a/dev, proj-b/dev")
+
+	_, err = cfg.ResolveVM("proj-b/web")
+	assert.ErrorIs(t, err, model.ErrVMNotFoundInConfig)
+}
+
+func TestNewConfigWithDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`default-project: file-project
+default-zone: file-zone
+vm:
+  - name: vm1
+  - name: vm2
+    project: own-project
+    zone: own-zone
+`), 0o600))
+
+	cfg, err := NewConfigWithDefaults(path, "profile-project", "")
+	require.NoError(t, err)
+	assert.Equal(t, "profile-project", cfg.DefaultProject)
+	assert.Equal(t, "file-zone", cfg.DefaultZone, "an empty zone keeps default-zone")
+	assert.Equal(t, "profile-project", cfg.VMs[0].Project)
+	assert.Equal(t, "own-project", cfg.VMs[1].Project, "a VM's own project wins")
+	assert.Equal(t, "own-zone", cfg.VMs[1].Zone)
+}
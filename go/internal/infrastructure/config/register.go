@@ -0,0 +1,248 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"gopkg.in/yaml.v3"
+)
+
+// RegisterVM appends vm to the vm list of the config file at confPath.
+//
+// The file is edited as a YAML document so comments and unrelated settings are
+// kept. Project and zone are only written when they differ from the defaults.
+// The file is replaced atomically.
+//
+// Parameters:
+//   - confPath: The file path to the YAML configuration file
+//   - vm: The VM to register (Name, Project and Zone are used)
+//
+// Returns:
+//   - error: An error if the file cannot be parsed or written, or a VM with the
+//     same name is already registered in the same project
+func RegisterVM(confPath string, vm *model.VM) error {
+	doc, ymlCnf, err := readConfigDocument(confPath)
+	if err != nil {
+		return err
+	}
+	root := doc.Content[0]
+	entries, err := vmEntries(&ymlCnf)
+	if err != nil {
+		return fmt.Errorf("failed to parse config YAML: %w", err)
+	}
+	for _, existing := range entries {
+		if existing.vm.QualifiedName() == vm.QualifiedName() {
+			return fmt.Errorf("VM %s is already registered in config", vm.Name)
+		}
+	}
+
+	entry := &yaml.Node{Kind: yaml.MappingNode}
+	appendScalar := func(key, value string) {
+		entry.Content = append(entry.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: key},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: value},
+		)
+	}
+	appendScalar("name", vm.Name)
+	if vm.Project != ymlCnf.DefaultProject {
+		appendScalar("project", vm.Project)
+	}
+	if vm.Zone != ymlCnf.DefaultZone {
+		appendScalar("zone", vm.Zone)
+	}
+
+	vms := mappingValue(root, "vm")
+	if vms == nil || vms.Kind != yaml.SequenceNode {
+		vms = &yaml.Node{Kind: yaml.SequenceNode}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "vm"}, vms)
+	}
+	vms.Content = append(vms.Content, entry)
+	return writeConfigDocument(confPath, doc)
+}
+
+// UnregisterVM removes vm from the vm list, or the vm list of its entry in
+// projects, of the config file at confPath, keeping comments and all other
+// settings. The file is replaced atomically.
+//
+// Parameters:
+//   - confPath: The file path to the YAML configuration file
+//   - vm: The VM to remove (Name and Project are used)
+//
+// Returns:
+//   - error: An error if the file cannot be parsed or written, or the VM is not registered
+func UnregisterVM(confPath string, vm *model.VM) error {
+	doc, ymlCnf, err := readConfigDocument(confPath)
+	if err != nil {
+		return err
+	}
+	vms, i := findVMEntry(doc.Content[0], ymlCnf.DefaultProject, vm)
+	if vms == nil {
+		return fmt.Errorf("VM %s is not registered in config", vm.QualifiedName())
+	}
+	vms.Content = append(vms.Content[:i], vms.Content[i+1:]...)
+	return writeConfigDocument(confPath, doc)
+}
+
+// SetVMZone writes the zone of vm to its entry in the config file at confPath,
+// replacing a missing zone or "zone: auto", and keeping comments and all other
+// settings. The file is replaced atomically.
+//
+// Parameters:
+//   - confPath: The file path to the YAML configuration file
+//   - vm: The VM whose Zone to write (Name and Project identify the entry)
+//
+// Returns:
+//   - error: An error if the file cannot be parsed or written, or the VM is not registered
+func SetVMZone(confPath string, vm *model.VM) error {
+	doc, ymlCnf, err := readConfigDocument(confPath)
+	if err != nil {
+		return err
+	}
+	vms, i := findVMEntry(doc.Content[0], ymlCnf.DefaultProject, vm)
+	if vms == nil {
+		return fmt.Errorf("VM %s is not registered in config", vm.QualifiedName())
+	}
+	entry := vms.Content[i]
+	if zone := mappingValue(entry, "zone"); zone != nil {
+		zone.Value = vm.Zone
+	} else {
+		entry.Content = append(entry.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: "zone"},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: vm.Zone},
+		)
+	}
+	return writeConfigDocument(confPath, doc)
+}
+
+// findVMEntry returns the vm list node holding the entry of vm, searching the
+// top-level vm list and then the vm lists of projects, and the index of the
+// entry in it. It returns nil if vm has no entry.
+func findVMEntry(root *yaml.Node, defaultProject string, vm *model.VM) (*yaml.Node, int) {
+	flatProject := func(entry *yaml.Node) string {
+		qualifier, _ := model.SplitQualifiedName(scalarValue(entry, "name"))
+		switch {
+		case qualifier != "":
+			return qualifier
+		case scalarValue(entry, "project") != "":
+			return scalarValue(entry, "project")
+		}
+		return defaultProject
+	}
+	if i := indexVMEntry(mappingValue(root, "vm"), vm, flatProject); i >= 0 {
+		return mappingValue(root, "vm"), i
+	}
+	if projects := mappingValue(root, "projects"); projects != nil && projects.Kind == yaml.SequenceNode {
+		for _, p := range projects.Content {
+			if scalarValue(p, "name") != vm.Project {
+				continue
+			}
+			blockProject := func(*yaml.Node) string { return vm.Project }
+			if i := indexVMEntry(mappingValue(p, "vm"), vm, blockProject); i >= 0 {
+				return mappingValue(p, "vm"), i
+			}
+		}
+	}
+	return nil, -1
+}
+
+// indexVMEntry returns the index of the entry for vm in the vm list node vms,
+// or -1. projectOf returns the project an entry resolves to.
+func indexVMEntry(vms *yaml.Node, vm *model.VM, projectOf func(entry *yaml.Node) string) int {
+	if vms == nil || vms.Kind != yaml.SequenceNode {
+		return -1
+	}
+	for i, entry := range vms.Content {
+		_, name := model.SplitQualifiedName(scalarValue(entry, "name"))
+		if name == vm.Name && projectOf(entry) == vm.Project {
+			return i
+		}
+	}
+	return -1
+}
+
+// scalarValue returns the value of key in a mapping node, or "".
+func scalarValue(mapping *yaml.Node, key string) string {
+	if v := mappingValue(mapping, key); v != nil {
+		return v.Value
+	}
+	return ""
+}
+
+// readConfigDocument parses the config file at confPath both as a YAML document,
+// whose top level is guaranteed to be a mapping, and as settings of the current
+// schema version.
+func readConfigDocument(confPath string) (*yaml.Node, yamlConfig, error) {
+	var ymlCnf yamlConfig
+	data, err := os.ReadFile(confPath)
+	if err != nil {
+		return nil, ymlCnf, fmt.Errorf("failed to read config file: %w", err)
+	}
+	var doc yaml.Node
+	if unmarshalErr := yaml.Unmarshal(data, &doc); unmarshalErr != nil {
+		return nil, ymlCnf, fmt.Errorf("failed to parse config YAML: %w", unmarshalErr)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, ymlCnf, fmt.Errorf("failed to parse config YAML: top level is not a mapping")
+	}
+	// The settings are parsed from a migrated copy, leaving doc as written.
+	ymlCnf, err = parseConfig(data)
+	if err != nil {
+		return nil, ymlCnf, err
+	}
+	return &doc, ymlCnf, nil
+}
+
+// writeConfigDocument encodes doc and replaces the config file at confPath with it.
+func writeConfigDocument(confPath string, doc *yaml.Node) error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if encodeErr := enc.Encode(doc); encodeErr != nil {
+		return fmt.Errorf("failed to encode config YAML: %w", encodeErr)
+	}
+	if closeErr := enc.Close(); closeErr != nil {
+		return fmt.Errorf("failed to encode config YAML: %w", closeErr)
+	}
+	return replaceFile(confPath, buf.Bytes())
+}
+
+// mappingValue returns the value node of key in a mapping node, or nil.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// replaceFile atomically replaces path with data, keeping its permissions.
+func replaceFile(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat config file: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".config-*.yaml")
+	if err != nil {
+		return fmt.Errorf("failed to create config file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, writeErr := tmp.Write(data); writeErr != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write config file: %w", writeErr)
+	}
+	if closeErr := tmp.Close(); closeErr != nil {
+		return fmt.Errorf("failed to write config file: %w", closeErr)
+	}
+	if chmodErr := os.Chmod(tmp.Name(), info.Mode().Perm()); chmodErr != nil {
+		return fmt.Errorf("failed to write config file: %w", chmodErr)
+	}
+	if renameErr := os.Rename(tmp.Name(), path); renameErr != nil {
+		return fmt.Errorf("failed to replace config file: %w", renameErr)
+	}
+	return nil
+}
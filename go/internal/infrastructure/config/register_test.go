@@ -0,0 +1,134 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterVM(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`default-project: test-project
+default-zone: us-central1-a
+# VMs managed by gcectl
+vm:
+  - name: sandbox
+    ssh:
+      user: alice
+cache-ttl: 10m
+`), 0o600))
+
+	require.NoError(t, RegisterVM(path, &model.VM{Name: "sandbox-clone", Project: "test-project", Zone: "us-central1-a"}))
+	require.NoError(t, RegisterVM(path, &model.VM{Name: "sandbox-tokyo", Project: "test-project", Zone: "asia-northeast1-a"}))
+
+	cfg, err := NewConfig(path)
+	require.NoError(t, err)
+	require.Len(t, cfg.VMs, 3)
+	assert.Equal(t, &model.VM{Name: "sandbox-clone", Project: "test-project", Zone: "us-central1-a"}, cfg.VMs[1])
+	assert.Equal(t, &model.VM{Name: "sandbox-tokyo", Project: "test-project", Zone: "asia-northeast1-a"}, cfg.VMs[2])
+	assert.Equal(t, "alice", cfg.SSHOptionsFor("sandbox").User, "existing entries are kept")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# VMs managed by gcectl", "comments are kept")
+	assert.NotContains(t, string(data), "    project: test-project", "default project is not repeated")
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	err = RegisterVM(path, &model.VM{Name: "sandbox", Project: "test-project", Zone: "us-central1-a"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already registered")
+}
+
+func TestRegisterVM_NoVMList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("default-project: test-project\n"), 0o600))
+
+	require.NoError(t, RegisterVM(path, &model.VM{Name: "vm1", Project: "other", Zone: "us-east1-b"}))
+
+	cfg, err := NewConfig(path)
+	require.NoError(t, err)
+	require.Len(t, cfg.VMs, 1)
+	assert.Equal(t, &model.VM{Name: "vm1", Project: "other", Zone: "us-east1-b"}, cfg.VMs[0])
+}
+
+func TestUnregisterVM(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`default-project: test-project
+default-zone: us-central1-a
+# VMs managed by gcectl
+vm:
+  - name: sandbox
+  - name: old-vm
+    zone: us-east1-b
+  - name: other/old-vm
+  - name: worker
+projects:
+  - name: proj-b
+    vm:
+      - name: old-vm
+`), 0o600))
+
+	require.NoError(t, UnregisterVM(path, &model.VM{Name: "old-vm", Project: "test-project"}))
+	require.NoError(t, UnregisterVM(path, &model.VM{Name: "old-vm", Project: "proj-b"}))
+
+	cfg, err := NewConfig(path)
+	require.NoError(t, err)
+	require.Len(t, cfg.VMs, 3)
+	assert.Equal(t, "sandbox", cfg.VMs[0].Name)
+	assert.Equal(t, "other/old-vm", cfg.VMs[1].QualifiedName())
+	assert.Equal(t, "worker", cfg.VMs[2].Name)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# VMs managed by gcectl", "comments are kept")
+
+	err = UnregisterVM(path, &model.VM{Name: "old-vm", Project: "test-project"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not registered")
+}
+
+func TestSetVMZone(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`default-project: test-project
+# VMs managed by gcectl
+vm:
+  - name: sandbox
+  - name: worker
+    zone: auto
+projects:
+  - name: proj-b
+    vm:
+      - name: sandbox
+`), 0o600))
+
+	cfg, err := NewConfig(path)
+	require.NoError(t, err)
+	for _, vm := range cfg.VMs {
+		assert.Empty(t, vm.Zone, "%s has no zone to look up before", vm.QualifiedName())
+	}
+
+	require.NoError(t, SetVMZone(path, &model.VM{Name: "worker", Project: "test-project", Zone: "us-east1-b"}))
+	require.NoError(t, SetVMZone(path, &model.VM{Name: "sandbox", Project: "proj-b", Zone: "asia-northeast1-a"}))
+
+	cfg, err = NewConfig(path)
+	require.NoError(t, err)
+	require.Len(t, cfg.VMs, 3)
+	assert.Empty(t, cfg.VMs[0].Zone)
+	assert.Equal(t, "us-east1-b", cfg.VMs[1].Zone)
+	assert.Equal(t, "asia-northeast1-a", cfg.VMs[2].Zone)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# VMs managed by gcectl", "comments are kept")
+
+	err = SetVMZone(path, &model.VM{Name: "missing", Project: "test-project", Zone: "z"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not registered")
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"gopkg.in/yaml.v3"
+)
+
+// yamlSchedulePolicy maps a schedule policy spec file used by `gcectl policy create -f`.
+type yamlSchedulePolicy struct {
+	Name        string `yaml:"name"`
+	Project     string `yaml:"project"`
+	Region      string `yaml:"region"`
+	Description string `yaml:"description"`
+	StartCron   string `yaml:"start-cron"`
+	StopCron    string `yaml:"stop-cron"`
+	TimeZone    string `yaml:"timezone"`
+}
+
+// LoadSchedulePolicySpec reads a schedule policy spec file. Omitted fields are left
+// empty so that command-line flags and config defaults can fill them in.
+//
+// Example spec:
+//
+//	name: weekday-office-hours
+//	start-cron: "0 9 * * 1-5"
+//	stop-cron: "0 19 * * 1-5"
+//	timezone: Asia/Tokyo
+func LoadSchedulePolicySpec(path string) (*model.SchedulePolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy spec: %w", err)
+	}
+
+	var spec yamlSchedulePolicy
+	if unmarshalErr := yaml.Unmarshal(data, &spec); unmarshalErr != nil {
+		return nil, fmt.Errorf("failed to parse policy spec YAML: %w", unmarshalErr)
+	}
+
+	return &model.SchedulePolicy{
+		Name:        spec.Name,
+		Project:     spec.Project,
+		Region:      spec.Region,
+		Description: spec.Description,
+		StartCron:   spec.StartCron,
+		StopCron:    spec.StopCron,
+		TimeZone:    spec.TimeZone,
+	}, nil
+}
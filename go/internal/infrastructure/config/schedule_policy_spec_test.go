@@ -0,0 +1,34 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSchedulePolicySpec(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`name: weekday
+region: asia-northeast1
+start-cron: "0 9 * * 1-5"
+stop-cron: "0 19 * * 1-5"
+timezone: Asia/Tokyo
+`), 0o600))
+
+	policy, err := LoadSchedulePolicySpec(path)
+	require.NoError(t, err)
+	assert.Equal(t, &model.SchedulePolicy{
+		Name:      "weekday",
+		Region:    "asia-northeast1",
+		StartCron: "0 9 * * 1-5",
+		StopCron:  "0 19 * * 1-5",
+		TimeZone:  "Asia/Tokyo",
+	}, policy)
+
+	_, err = LoadSchedulePolicySpec(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
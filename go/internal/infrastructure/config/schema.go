@@ -0,0 +1,393 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentVersion is the schema version of config.yaml this gcectl reads and
+// writes. A config without a version key is version 0, the format used before
+// the key existed.
+const CurrentVersion = 1
+
+// migration upgrades a config document from version from to from+1 in place.
+// It returns a description of every change, empty when the document already
+// had the form of the next version.
+type migration struct {
+	apply func(root *yaml.Node) ([]string, error)
+	from  int
+}
+
+// migrations upgrade configs one version at a time, in order. A format change
+// adds a migration here and increments CurrentVersion, so configs written for
+// older versions keep loading and gcectl config migrate can rewrite them.
+var migrations = []migration{
+	{from: 0, apply: migrateV0},
+}
+
+// Problem is a finding of Validate.
+type Problem struct {
+	// Path locates the offending key, e.g. vm[1].machine_type.
+	Path    string
+	Message string
+	// Line is the line of the key in the file, or 0 when unknown.
+	Line int
+}
+
+// String formats p as "line <n>: <path>: <message>".
+func (p Problem) String() string {
+	s := p.Message
+	if p.Path != "" {
+		s = p.Path + ": " + s
+	}
+	if p.Line > 0 {
+		s = fmt.Sprintf("line %d: %s", p.Line, s)
+	}
+	return s
+}
+
+// Validate checks the config file at confPath against the schema of
+// CurrentVersion: its version, unknown keys, the types of values and the
+// checks NewConfig makes.
+//
+// Parameters:
+//   - confPath: The file path to the YAML configuration file
+//
+// Returns:
+//   - []Problem: The problems found, empty for a valid config
+//   - error: An error if the file cannot be read or is not YAML
+func Validate(confPath string) ([]Problem, error) {
+	data, err := os.ReadFile(confPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	var doc yaml.Node
+	if unmarshalErr := yaml.Unmarshal(data, &doc); unmarshalErr != nil {
+		return nil, fmt.Errorf("failed to parse config YAML: %w", unmarshalErr)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return []Problem{{Line: root.Line, Message: "the top level must be a mapping"}}, nil
+	}
+
+	var problems []Problem
+	version, err := schemaVersion(root)
+	if err != nil {
+		return []Problem{{Path: "version", Line: mappingValue(root, "version").Line, Message: err.Error()}}, nil
+	}
+	if version < CurrentVersion {
+		problems = append(problems, Problem{
+			Path:    "version",
+			Message: fmt.Sprintf("the config is version %d, the latest is %d; run gcectl config migrate", version, CurrentVersion),
+		})
+	}
+
+	// Unknown keys are looked for after the migrations, so keys a migration
+	// renames are reported once, by the version problem above.
+	if _, migrateErr := migrate(root, version); migrateErr != nil {
+		return append(problems, Problem{Message: migrateErr.Error()}), nil
+	}
+	walkSchema(root, reflect.TypeOf(yamlConfig{}), "", func(key *yaml.Node, path string, known []string) {
+		msg := "unknown key"
+		if suggestion := closestKey(key.Value, known); suggestion != "" {
+			msg += fmt.Sprintf(", did you mean %s?", suggestion)
+		}
+		problems = append(problems, Problem{Path: path, Line: key.Line, Message: msg})
+	})
+	if len(problems) > 0 {
+		return problems, nil
+	}
+
+	if _, loadErr := NewConfig(confPath); loadErr != nil {
+		problems = append(problems, Problem{Message: loadErr.Error()})
+	}
+	return problems, nil
+}
+
+// Migrate rewrites the config file at confPath in the format of
+// CurrentVersion, keeping comments and unrelated settings. The original is
+// kept as confPath + ".bak".
+//
+// Parameters:
+//   - confPath: The file path to the YAML configuration file
+//   - dryRun: Only report the changes, leaving the file as it is
+//
+// Returns:
+//   - []string: A description of every change, empty when the config is up to date
+//   - error: An error if the file cannot be parsed, migrated or written
+func Migrate(confPath string, dryRun bool) ([]string, error) {
+	data, err := os.ReadFile(confPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	doc, _, err := readConfigDocument(confPath)
+	if err != nil {
+		return nil, err
+	}
+	root := doc.Content[0]
+	version, err := schemaVersion(root)
+	if err != nil {
+		return nil, err
+	}
+	if version == CurrentVersion {
+		return nil, nil
+	}
+	changes, err := migrate(root, version)
+	if err != nil {
+		return nil, err
+	}
+	setVersion(root, CurrentVersion)
+	changes = append(changes, fmt.Sprintf("set version to %d", CurrentVersion))
+	if dryRun {
+		return changes, nil
+	}
+
+	if writeErr := os.WriteFile(confPath+".bak", data, 0o600); writeErr != nil {
+		return nil, fmt.Errorf("failed to back up config file: %w", writeErr)
+	}
+	if writeErr := writeConfigDocument(confPath, doc); writeErr != nil {
+		return nil, writeErr
+	}
+	return changes, nil
+}
+
+// schemaVersion returns the version of the config document root.
+func schemaVersion(root *yaml.Node) (int, error) {
+	node := mappingValue(root, "version")
+	if node == nil {
+		return 0, nil
+	}
+	version, err := strconv.Atoi(node.Value)
+	if err != nil || node.Kind != yaml.ScalarNode || version < 0 {
+		return 0, fmt.Errorf("version must be a non-negative integer: %q", node.Value)
+	}
+	if version > CurrentVersion {
+		return 0, fmt.Errorf("the config is version %d, newer than the version %d this gcectl reads; upgrade gcectl", version, CurrentVersion)
+	}
+	return version, nil
+}
+
+// migrate applies the migrations from version to CurrentVersion to root.
+func migrate(root *yaml.Node, version int) ([]string, error) {
+	var changes []string
+	for _, m := range migrations {
+		if m.from < version {
+			continue
+		}
+		applied, err := m.apply(root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to migrate config from version %d: %w", m.from, err)
+		}
+		changes = append(changes, applied...)
+	}
+	return changes, nil
+}
+
+// setVersion sets the version key of root, adding it first when missing.
+func setVersion(root *yaml.Node, version int) {
+	value := strconv.Itoa(version)
+	if node := mappingValue(root, "version"); node != nil {
+		node.Value = value
+		return
+	}
+	root.Content = append([]*yaml.Node{
+		{Kind: yaml.ScalarNode, Value: "version"},
+		{Kind: yaml.ScalarNode, Tag: "!!int", Value: value},
+	}, root.Content...)
+}
+
+// migrateV0 renames the keys of unversioned configs written with underscores
+// or capitals, e.g. default_project or machineType, which version 0 silently
+// ignored, to the keys they were meant to be.
+func migrateV0(root *yaml.Node) ([]string, error) {
+	var changes []string
+	walkSchema(root, reflect.TypeOf(yamlConfig{}), "", func(key *yaml.Node, path string, known []string) {
+		if canonical := canonicalKey(key.Value, known); canonical != "" {
+			changes = append(changes, fmt.Sprintf("renamed %s to %s", path, strings.TrimSuffix(path, key.Value)+canonical))
+			key.Value = canonical
+		}
+	})
+	if err := checkDuplicateKeys(root); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// canonicalKey returns the key of known that key spells differently, with
+// underscores or capitals, e.g. default-project for default_project or
+// defaultProject, or "" when there is none.
+func canonicalKey(key string, known []string) string {
+	normalized := normalizeKey(key)
+	for _, k := range known {
+		if normalizeKey(k) == normalized {
+			return k
+		}
+	}
+	return ""
+}
+
+// normalizeKey lowercases key and drops its separators.
+func normalizeKey(key string) string {
+	return strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(key))
+}
+
+// checkDuplicateKeys reports a mapping in node that has a key twice, e.g.
+// after default_project was renamed next to default-project.
+func checkDuplicateKeys(node *yaml.Node) error {
+	if node.Kind == yaml.MappingNode {
+		seen := make(map[string]bool)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			if seen[key.Value] {
+				return fmt.Errorf("line %d: %s is set twice, with different spellings", key.Line, key.Value)
+			}
+			seen[key.Value] = true
+		}
+	}
+	for _, child := range node.Content {
+		if err := checkDuplicateKeys(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkSchema calls unknown for every key of the mappings in node that the
+// YAML structure t has no field for, with the path of the key and the keys t
+// knows at that place. Mappings decoded into maps, such as hourly-cost, accept
+// any key, but their values are walked when they are structures.
+func walkSchema(node *yaml.Node, t reflect.Type, path string, unknown func(key *yaml.Node, path string, known []string)) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case node.Kind == yaml.MappingNode && t.Kind() == reflect.Struct:
+		fields := yamlFields(t)
+		known := make([]string, 0, len(fields))
+		for k := range fields {
+			known = append(known, k)
+		}
+		sort.Strings(known)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := joinPath(path, key.Value)
+			field, ok := fields[key.Value]
+			if !ok {
+				unknown(key, keyPath, known)
+				// The key may have been renamed to a known one.
+				field, ok = fields[key.Value]
+			}
+			if ok {
+				walkSchema(value, field, joinPath(path, key.Value), unknown)
+			}
+		}
+	case node.Kind == yaml.MappingNode && t.Kind() == reflect.Map:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			walkSchema(node.Content[i+1], t.Elem(), joinPath(path, node.Content[i].Value), unknown)
+		}
+	case node.Kind == yaml.SequenceNode && t.Kind() == reflect.Slice:
+		for i, item := range node.Content {
+			walkSchema(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i), unknown)
+		}
+	}
+}
+
+// yamlFields returns the types of the fields of the YAML structure t keyed by their YAML key.
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name != "" && name != "-" {
+			fields[name] = f.Type
+		}
+	}
+	return fields
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// closestKey returns the key of known that key most likely misspells, or "".
+func closestKey(key string, known []string) string {
+	if canonical := canonicalKey(key, known); canonical != "" {
+		return canonical
+	}
+	best, bestDistance := "", 3
+	for _, k := range known {
+		if d := editDistance(key, k); d < bestDistance {
+			best, bestDistance = k, d
+		}
+	}
+	if best != "" {
+		return best
+	}
+	// A key cut short, e.g. webhook for webhook-url.
+	for _, k := range known {
+		if strings.HasPrefix(k, key+"-") {
+			return k
+		}
+	}
+	return ""
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// parseConfig parses config.yaml data of any version up to CurrentVersion,
+// migrating it in memory to the format of CurrentVersion.
+func parseConfig(data []byte) (yamlConfig, error) {
+	var ymlCnf yamlConfig
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return ymlCnf, fmt.Errorf("failed to parse config YAML: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return ymlCnf, nil
+	}
+	root := doc.Content[0]
+	if root.Kind == yaml.MappingNode {
+		version, err := schemaVersion(root)
+		if err != nil {
+			return ymlCnf, err
+		}
+		if _, err = migrate(root, version); err != nil {
+			return ymlCnf, err
+		}
+	}
+	if err := root.Decode(&ymlCnf); err != nil {
+		return ymlCnf, fmt.Errorf("failed to parse config YAML: %w", err)
+	}
+	return ymlCnf, nil
+}
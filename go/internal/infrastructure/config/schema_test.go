@@ -0,0 +1,135 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "current version",
+			content: "version: 1\ndefault-project: proj\ndefault-zone: us-central1-a\nvm:\n  - name: sandbox\n",
+		},
+		{
+			name:    "unversioned",
+			content: "default-project: proj\nvm:\n  - name: sandbox\n    zone: us-central1-a\n",
+			want:    []string{"version: the config is version 0, the latest is 1; run gcectl config migrate"},
+		},
+		{
+			name:    "misspelled keys",
+			content: "version: 1\ndefault-project: proj\nvm:\n  - name: sandbox\n    zone: us-central1-a\n    machine-typ: e2-small\nretry:\n  max_attempts: 3\n",
+			want: []string{
+				"line 6: vm[0].machine-typ: unknown key, did you mean machine-type?",
+				"line 8: retry.max_attempts: unknown key, did you mean max-attempts?",
+			},
+		},
+		{
+			name:    "map keys are free",
+			content: "version: 1\nhourly-cost:\n  e2-anything: 0.1\nnotifications:\n  backends:\n    team:\n      type: slack\n      webhook: https://hooks.example.com\n",
+			want:    []string{"line 8: notifications.backends.team.webhook: unknown key, did you mean webhook-url?"},
+		},
+		{
+			name:    "newer version",
+			content: "version: 2\n",
+			want:    []string{"line 1: version: the config is version 2, newer than the version 1 this gcectl reads; upgrade gcectl"},
+		},
+		{
+			name:    "invalid setting",
+			content: "version: 1\nmax-concurrency: -1\n",
+			want:    []string{"max-concurrency must not be negative: -1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems, err := Validate(writeConfig(t, tt.content))
+			require.NoError(t, err)
+			got := make([]string, len(problems))
+			for i, p := range problems {
+				got[i] = p.String()
+			}
+			if len(tt.want) == 0 {
+				assert.Empty(t, got)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	const old = `# team VMs
+default_project: proj
+defaultZone: us-central1-a
+vm:
+  - name: sandbox # the dev box
+    machine_type: e2-small
+`
+	path := writeConfig(t, old)
+
+	changes, err := Migrate(path, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"renamed default_project to default-project",
+		"renamed defaultZone to default-zone",
+		"renamed vm[0].machine_type to vm[0].machine-type",
+		"set version to 1",
+	}, changes)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, old, string(data), "a dry run leaves the file as it is")
+
+	_, err = Migrate(path, false)
+	require.NoError(t, err)
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `version: 1
+# team VMs
+default-project: proj
+default-zone: us-central1-a
+vm:
+  - name: sandbox # the dev box
+    machine-type: e2-small
+`, string(data))
+	backup, err := os.ReadFile(path + ".bak")
+	require.NoError(t, err)
+	assert.Equal(t, old, string(backup))
+
+	problems, err := Validate(path)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+	changes, err = Migrate(path, false)
+	require.NoError(t, err)
+	assert.Empty(t, changes, "an up to date config is left alone")
+}
+
+func TestMigrate_ConflictingSpellings(t *testing.T) {
+	path := writeConfig(t, "default-project: a\ndefault_project: b\n")
+	_, err := Migrate(path, false)
+	assert.ErrorContains(t, err, "default-project is set twice")
+}
+
+func TestNewConfig_ReadsOlderVersions(t *testing.T) {
+	cfg, err := NewConfig(writeConfig(t, "default_project: proj\ndefault-zone: us-central1-a\nvm:\n  - name: sandbox\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "proj", cfg.DefaultProject, "unversioned configs are migrated when loaded")
+	assert.Equal(t, "proj", cfg.VMs[0].Project)
+
+	_, err = NewConfig(writeConfig(t, "version: 2\n"))
+	assert.ErrorContains(t, err, "upgrade gcectl")
+}
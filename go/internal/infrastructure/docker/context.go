@@ -0,0 +1,78 @@
+// Package docker manages docker CLI contexts with the docker command.
+package docker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// dockerCommand is the docker CLI.
+const dockerCommand = "docker"
+
+// SaveContext points the docker context name at the docker daemon of sshHost,
+// reached over ssh, creating the context or updating it when it exists.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - name: The name of the docker context
+//   - sshHost: An alias of the ssh config or [user@]hostname
+//   - description: The description shown by docker context ls
+//
+// Returns:
+//   - bool: true when the context was created, false when it was updated
+//   - error: Error if docker is not installed or fails
+func SaveContext(ctx context.Context, name, sshHost, description string) (bool, error) {
+	exists, err := contextExists(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	verb := "create"
+	if exists {
+		verb = "update"
+	}
+	if _, err = run(ctx, contextArgs(verb, name, sshHost, description)...); err != nil {
+		return false, err
+	}
+	return !exists, nil
+}
+
+// UseContext makes name the current docker context.
+func UseContext(ctx context.Context, name string) error {
+	_, err := run(ctx, "context", "use", name)
+	return err
+}
+
+// contextExists reports whether the docker context name exists.
+func contextExists(ctx context.Context, name string) (bool, error) {
+	_, err := run(ctx, "context", "inspect", name)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// contextArgs builds the arguments of docker context create or update.
+func contextArgs(verb, name, sshHost, description string) []string {
+	return []string{"context", verb, name, "--description", description, "--docker", "host=ssh://" + sshHost}
+}
+
+// run runs docker with args and returns its stdout, or an error carrying its stderr.
+func run(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, dockerCommand, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", fmt.Errorf("docker %s failed: %s: %w", strings.Join(args[:2], " "), strings.TrimSpace(stderr.String()), err)
+		}
+		return "", fmt.Errorf("failed to run docker (is it installed?): %w", err)
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,70 @@
+//go:build !windows
+
+package docker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextArgs(t *testing.T) {
+	assert.Equal(t,
+		[]string{"context", "create", "gpu-box", "--description", "gcectl: gpu-box", "--docker", "host=ssh://gpu-box"},
+		contextArgs("create", "gpu-box", "gpu-box", "gcectl: gpu-box"))
+}
+
+// fakeDocker puts a docker script on PATH that logs its arguments to the returned
+// file and fails context inspect unless exists is true.
+func fakeDocker(t *testing.T, exists bool) string {
+	t.Helper()
+	dir := t.TempDir()
+	log := filepath.Join(dir, "args")
+	inspect := "exit 1"
+	if exists {
+		inspect = "exit 0"
+	}
+	script := "#!/bin/sh\necho \"$@\" >> " + log + "\nif [ \"$2\" = inspect ]; then " + inspect + "; fi\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "docker"), []byte(script), 0o755))
+	t.Setenv("PATH", dir)
+	return log
+}
+
+func TestSaveContext(t *testing.T) {
+	tests := []struct {
+		name        string
+		exists      bool
+		wantCreated bool
+		wantVerb    string
+	}{
+		{name: "creates a new context", exists: false, wantCreated: true, wantVerb: "create"},
+		{name: "updates an existing context", exists: true, wantCreated: false, wantVerb: "update"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := fakeDocker(t, tt.exists)
+
+			created, err := SaveContext(context.Background(), "gpu-box", "gpu-box", "gcectl: gpu-box")
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCreated, created)
+			data, err := os.ReadFile(log)
+			require.NoError(t, err)
+			assert.Equal(t, "context inspect gpu-box\ncontext "+tt.wantVerb+" gpu-box --description gcectl: gpu-box --docker host=ssh://gpu-box\n", string(data))
+		})
+	}
+}
+
+func TestSaveContext_DockerMissing(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	_, err := SaveContext(context.Background(), "gpu-box", "gpu-box", "")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is it installed")
+}
@@ -0,0 +1,74 @@
+package editor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Editor opens content in the user's editor, kubectl-style.
+type Editor struct {
+	command []string
+}
+
+// NewEditor creates an Editor from $VISUAL or $EDITOR, falling back to vi (notepad on Windows).
+// The variable may contain arguments, e.g. EDITOR="code --wait".
+func NewEditor() *Editor {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return &Editor{command: fields}
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return &Editor{command: []string{"notepad"}}
+	}
+	return &Editor{command: []string{"vi"}}
+}
+
+// Edit writes content to a temporary file, opens it in the editor attached to the
+// current terminal and returns the saved content once the editor exits.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - name: Base name for the temporary file; its extension enables editor syntax highlighting
+//   - content: The initial document
+//
+// Returns:
+//   - []byte: The edited document
+//   - error: Error if the temporary file cannot be written or the editor fails
+func (e *Editor) Edit(ctx context.Context, name string, content []byte) ([]byte, error) {
+	f, err := os.CreateTemp("", "gcectl-edit-*-"+name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	path := f.Name()
+	defer func() {
+		_ = os.Remove(path)
+	}()
+
+	if _, err = f.Write(content); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close temporary file: %w", err)
+	}
+
+	args := append(append([]string{}, e.command[1:]...), path)
+	cmd := exec.CommandContext(ctx, e.command[0], args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err = cmd.Run(); err != nil {
+		return nil, fmt.Errorf("editor %q failed: %w", strings.Join(e.command, " "), err)
+	}
+
+	edited, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read edited file: %w", err)
+	}
+	return edited, nil
+}
@@ -0,0 +1,41 @@
+//go:build !windows
+
+package editor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEditorPrefersVisual(t *testing.T) {
+	t.Setenv("VISUAL", "code --wait")
+	t.Setenv("EDITOR", "nano")
+
+	assert.Equal(t, []string{"code", "--wait"}, NewEditor().command)
+}
+
+func TestNewEditorFallsBackToVi(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+
+	assert.Equal(t, []string{"vi"}, NewEditor().command)
+}
+
+func TestEditReturnsSavedContent(t *testing.T) {
+	e := &Editor{command: []string{"sh", "-c", `printf '{"edited": true}' > "$0"`}}
+
+	edited, err := e.Edit(context.Background(), "vm.json", []byte(`{"edited": false}`))
+	require.NoError(t, err)
+	assert.Equal(t, `{"edited": true}`, string(edited))
+}
+
+func TestEditReturnsEditorError(t *testing.T) {
+	e := &Editor{command: []string{"false"}}
+
+	_, err := e.Edit(context.Background(), "vm.json", []byte("{}"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "editor \"false\" failed")
+}
@@ -0,0 +1,41 @@
+package editor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// vscodeCommand is the VS Code command line launcher.
+const vscodeCommand = "code"
+
+// OpenRemoteSSH opens folder on the ssh host in VS Code with the Remote-SSH
+// extension, or an empty remote window when folder is "". host is an alias of
+// the ssh config or [user@]hostname.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - host: The ssh host to connect to
+//   - folder: The remote folder to open, or ""
+//
+// Returns:
+//   - error: Error if code is not installed or fails
+func OpenRemoteSSH(ctx context.Context, host, folder string) error {
+	cmd := exec.CommandContext(ctx, vscodeCommand, remoteSSHArgs(host, folder)...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed (is the VS Code command line launcher on PATH?): %w", vscodeCommand, err)
+	}
+	return nil
+}
+
+// remoteSSHArgs returns the arguments of code opening folder on host.
+func remoteSSHArgs(host, folder string) []string {
+	args := []string{"--remote", "ssh-remote+" + host}
+	if folder != "" {
+		args = append(args, folder)
+	}
+	return args
+}
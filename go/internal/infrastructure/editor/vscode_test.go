@@ -0,0 +1,12 @@
+package editor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoteSSHArgs(t *testing.T) {
+	assert.Equal(t, []string{"--remote", "ssh-remote+my-vm", "/home/alice/project"}, remoteSSHArgs("my-vm", "/home/alice/project"))
+	assert.Equal(t, []string{"--remote", "ssh-remote+my-vm"}, remoteSSHArgs("my-vm", ""))
+}
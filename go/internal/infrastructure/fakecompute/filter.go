@@ -0,0 +1,206 @@
+package fakecompute
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// filter is a parsed list filter: comparisons of fields with "=", "!=", or the
+// regular expression operators "eq" and "ne", grouped by parentheses and joined
+// by AND (also implied by juxtaposition) and OR. A nil filter matches everything.
+type filter interface {
+	match(fields map[string]string) bool
+}
+
+type allFilter struct{}
+
+func (allFilter) match(map[string]string) bool { return true }
+
+type andFilter []filter
+
+func (f andFilter) match(fields map[string]string) bool {
+	for _, term := range f {
+		if !term.match(fields) {
+			return false
+		}
+	}
+	return true
+}
+
+type orFilter []filter
+
+func (f orFilter) match(fields map[string]string) bool {
+	for _, term := range f {
+		if term.match(fields) {
+			return true
+		}
+	}
+	return false
+}
+
+type comparison struct {
+	pattern *regexp.Regexp
+	field   string
+	value   string
+	negate  bool
+}
+
+func (c comparison) match(fields map[string]string) bool {
+	value := fields[c.field]
+	matched := value == c.value
+	if c.pattern != nil {
+		matched = c.pattern.MatchString(value)
+	}
+	return matched != c.negate
+}
+
+// parseFilter parses a list filter such as `(labels.env = "dev") (status = "RUNNING")`.
+func parseFilter(s string) (filter, error) {
+	p := &filterParser{tokens: tokenizeFilter(s)}
+	if len(p.tokens) == 0 {
+		return allFilter{}, nil
+	}
+	f, err := p.or()
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %w", s, err)
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("invalid filter %q: unexpected %q", s, p.tokens[p.pos])
+	}
+	return f, nil
+}
+
+type filterParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *filterParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *filterParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *filterParser) or() (filter, error) {
+	terms := orFilter{}
+	for {
+		term, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+		if p.peek() != "OR" {
+			break
+		}
+		p.next()
+	}
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+	return terms, nil
+}
+
+func (p *filterParser) and() (filter, error) {
+	terms := andFilter{}
+	for {
+		term, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+		if p.peek() == "AND" {
+			p.next()
+			continue
+		}
+		if next := p.peek(); next == "" || next == ")" || next == "OR" {
+			break
+		}
+	}
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+	return terms, nil
+}
+
+func (p *filterParser) term() (filter, error) {
+	if p.peek() == "(" {
+		p.next()
+		f, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing )")
+		}
+		return f, nil
+	}
+
+	field, op, value := p.next(), p.next(), p.next()
+	if field == "" || value == "" {
+		return nil, fmt.Errorf("incomplete comparison")
+	}
+	c := comparison{field: field, value: unquote(value)}
+	switch op {
+	case "=":
+	case "!=":
+		c.negate = true
+	case "eq", "ne":
+		pattern, err := regexp.Compile("^(?:" + c.value + ")$")
+		if err != nil {
+			return nil, err
+		}
+		c.pattern = pattern
+		c.negate = op == "ne"
+	default:
+		return nil, fmt.Errorf("unsupported operator %q", op)
+	}
+	return c, nil
+}
+
+// tokenizeFilter splits s into parentheses, operators, quoted strings and words.
+func tokenizeFilter(s string) []string {
+	var tokens []string
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case c == '(' || c == ')' || c == '=':
+			tokens = append(tokens, string(c))
+			i++
+		case c == '!' && strings.HasPrefix(s[i:], "!="):
+			tokens = append(tokens, "!=")
+			i += 2
+		case c == '"':
+			end := strings.IndexByte(s[i+1:], '"')
+			if end < 0 {
+				tokens = append(tokens, s[i:])
+				return tokens
+			}
+			tokens = append(tokens, s[i:i+end+2])
+			i += end + 2
+		default:
+			start := i
+			for i < len(s) && !unicode.IsSpace(rune(s[i])) && !strings.ContainsRune("()=!\"", rune(s[i])) {
+				i++
+			}
+			tokens = append(tokens, s[start:i])
+		}
+	}
+	return tokens
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
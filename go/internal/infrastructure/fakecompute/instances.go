@@ -0,0 +1,256 @@
+package fakecompute
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"google.golang.org/protobuf/proto"
+)
+
+func (s *Server) getInstance(w http.ResponseWriter, r *http.Request) {
+	instance := s.Instance(r.PathValue("project"), r.PathValue("zone"), r.PathValue("instance"))
+	if instance == nil {
+		writeNotFound(w, "projects/"+r.PathValue("project")+"/zones/"+r.PathValue("zone")+"/instances", r.PathValue("instance"))
+		return
+	}
+	writeMessage(w, instance)
+}
+
+// matchingInstances returns copies of the instances of project, in zone when it
+// is not empty, that match the filter of r, sorted by zone and name.
+func (s *Server) matchingInstances(r *http.Request, project, zone string) ([]*computepb.Instance, error) {
+	f, err := parseFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var instances []*computepb.Instance
+	for key, instance := range s.instances {
+		if !strings.HasPrefix(key, project+"/") {
+			continue
+		}
+		if zone != "" && lastSegment(instance.GetZone()) != zone {
+			continue
+		}
+		if f.match(instanceFields(instance)) {
+			instances = append(instances, proto.Clone(instance).(*computepb.Instance))
+		}
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		if instances[i].GetZone() != instances[j].GetZone() {
+			return instances[i].GetZone() < instances[j].GetZone()
+		}
+		return instances[i].GetName() < instances[j].GetName()
+	})
+	return instances, nil
+}
+
+func (s *Server) listInstances(w http.ResponseWriter, r *http.Request) {
+	instances, err := s.matchingInstances(r, r.PathValue("project"), r.PathValue("zone"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid", err.Error())
+		return
+	}
+	writeMessage(w, &computepb.InstanceList{Kind: proto.String("compute#instanceList"), Items: instances})
+}
+
+func (s *Server) aggregatedListInstances(w http.ResponseWriter, r *http.Request) {
+	instances, err := s.matchingInstances(r, r.PathValue("project"), "")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid", err.Error())
+		return
+	}
+	list := &computepb.InstanceAggregatedList{
+		Kind:  proto.String("compute#instanceAggregatedList"),
+		Items: make(map[string]*computepb.InstancesScopedList),
+	}
+	for _, instance := range instances {
+		key := "zones/" + lastSegment(instance.GetZone())
+		if list.Items[key] == nil {
+			list.Items[key] = &computepb.InstancesScopedList{}
+		}
+		list.Items[key].Instances = append(list.Items[key].Instances, instance)
+	}
+	writeMessage(w, list)
+}
+
+// instanceFields returns the fields of instance a filter can refer to.
+func instanceFields(instance *computepb.Instance) map[string]string {
+	fields := map[string]string{
+		"name":        instance.GetName(),
+		"status":      instance.GetStatus(),
+		"zone":        instance.GetZone(),
+		"machineType": instance.GetMachineType(),
+	}
+	for key, value := range instance.GetLabels() {
+		fields["labels."+key] = value
+	}
+	return fields
+}
+
+// instanceAction applies a POST .../instances/NAME/ACTION request, e.g. start.
+func (s *Server) instanceAction(w http.ResponseWriter, r *http.Request) {
+	project, zone, name, action := r.PathValue("project"), r.PathValue("zone"), r.PathValue("instance"), r.PathValue("action")
+	apply, ok := instanceActions[action]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "invalid", fmt.Sprintf("instance action %q is not implemented by the fake Compute API", action))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	instance, ok := s.instances[instanceKey(project, zone, name)]
+	if !ok {
+		writeNotFound(w, "projects/"+project+"/zones/"+zone+"/instances", name)
+		return
+	}
+	if err := apply(s, r, project, instance); err != nil {
+		writeError(w, err.code, err.reason, err.message)
+		return
+	}
+	writeMessage(w, s.newZoneOperation(project, zone, action, instance.GetSelfLink()))
+}
+
+// apiError is a failed request in the terms of the Google APIs.
+type apiError struct {
+	code    int
+	reason  string
+	message string
+}
+
+func badRequest(format string, args ...any) *apiError {
+	return &apiError{code: http.StatusBadRequest, reason: "invalid", message: fmt.Sprintf(format, args...)}
+}
+
+func conditionNotMet(kind string) *apiError {
+	return &apiError{
+		code:    http.StatusPreconditionFailed,
+		reason:  "conditionNotMet",
+		message: fmt.Sprintf("Supplied fingerprint does not match current %s fingerprint.", kind),
+	}
+}
+
+// instanceActions apply the instance actions the fake supports to an instance.
+// They are called with s.mu held.
+var instanceActions = map[string]func(s *Server, r *http.Request, project string, instance *computepb.Instance) *apiError{
+	"start": func(s *Server, _ *http.Request, _ string, instance *computepb.Instance) *apiError {
+		if instance.GetStatus() != "RUNNING" {
+			instance.Status = proto.String("RUNNING")
+			instance.LastStartTimestamp = proto.String(s.timestamp())
+		}
+		return nil
+	},
+	"stop": func(s *Server, _ *http.Request, _ string, instance *computepb.Instance) *apiError {
+		if instance.GetStatus() != "TERMINATED" {
+			instance.Status = proto.String("TERMINATED")
+			instance.LastStopTimestamp = proto.String(s.timestamp())
+		}
+		return nil
+	},
+	"reset": func(s *Server, _ *http.Request, _ string, instance *computepb.Instance) *apiError {
+		if instance.GetStatus() != "RUNNING" {
+			return badRequest("The resource '%s' is not ready: the instance must be running to be reset", instance.GetName())
+		}
+		instance.LastStartTimestamp = proto.String(s.timestamp())
+		return nil
+	},
+	"setMachineType": func(s *Server, r *http.Request, _ string, instance *computepb.Instance) *apiError {
+		var req computepb.InstancesSetMachineTypeRequest
+		if err := readMessage(r, &req); err != nil {
+			return badRequest("%v", err)
+		}
+		if instance.GetStatus() != "TERMINATED" {
+			return badRequest("The resource '%s' is not ready: the instance must be stopped to change its machine type", instance.GetName())
+		}
+		instance.MachineType = proto.String(instance.GetZone() + "/machineTypes/" + lastSegment(req.GetMachineType()))
+		s.refreshFingerprints(instance)
+		return nil
+	},
+	"setMachineResources": func(s *Server, r *http.Request, _ string, instance *computepb.Instance) *apiError {
+		var req computepb.InstancesSetMachineResourcesRequest
+		if err := readMessage(r, &req); err != nil {
+			return badRequest("%v", err)
+		}
+		instance.GuestAccelerators = req.GetGuestAccelerators()
+		s.refreshFingerprints(instance)
+		return nil
+	},
+	"setLabels": func(s *Server, r *http.Request, _ string, instance *computepb.Instance) *apiError {
+		var req computepb.InstancesSetLabelsRequest
+		if err := readMessage(r, &req); err != nil {
+			return badRequest("%v", err)
+		}
+		if req.GetLabelFingerprint() != instance.GetLabelFingerprint() {
+			return conditionNotMet("label")
+		}
+		instance.Labels = req.GetLabels()
+		s.refreshFingerprints(instance)
+		return nil
+	},
+	"setMetadata": func(s *Server, r *http.Request, _ string, instance *computepb.Instance) *apiError {
+		var req computepb.Metadata
+		if err := readMessage(r, &req); err != nil {
+			return badRequest("%v", err)
+		}
+		if req.GetFingerprint() != instance.GetMetadata().GetFingerprint() {
+			return conditionNotMet("metadata")
+		}
+		instance.Metadata = &computepb.Metadata{Items: req.GetItems()}
+		s.refreshFingerprints(instance)
+		return nil
+	},
+	"setScheduling": func(s *Server, r *http.Request, _ string, instance *computepb.Instance) *apiError {
+		var req computepb.Scheduling
+		if err := readMessage(r, &req); err != nil {
+			return badRequest("%v", err)
+		}
+		instance.Scheduling = &req
+		s.refreshFingerprints(instance)
+		return nil
+	},
+	"addResourcePolicies": func(s *Server, r *http.Request, project string, instance *computepb.Instance) *apiError {
+		var req computepb.InstancesAddResourcePoliciesRequest
+		if err := readMessage(r, &req); err != nil {
+			return badRequest("%v", err)
+		}
+		for _, link := range req.GetResourcePolicies() {
+			policy, ok := s.policies[policyKey(project, regionOf(instance), lastSegment(link))]
+			if !ok {
+				return &apiError{code: http.StatusNotFound, reason: "notFound", message: fmt.Sprintf("The resource '%s' was not found", link)}
+			}
+			instance.ResourcePolicies = append(instance.ResourcePolicies, policy.GetSelfLink())
+		}
+		s.refreshFingerprints(instance)
+		return nil
+	},
+	"removeResourcePolicies": func(s *Server, r *http.Request, _ string, instance *computepb.Instance) *apiError {
+		var req computepb.InstancesRemoveResourcePoliciesRequest
+		if err := readMessage(r, &req); err != nil {
+			return badRequest("%v", err)
+		}
+		remove := make(map[string]bool, len(req.GetResourcePolicies()))
+		for _, link := range req.GetResourcePolicies() {
+			remove[lastSegment(link)] = true
+		}
+		kept := instance.ResourcePolicies[:0]
+		for _, link := range instance.GetResourcePolicies() {
+			if !remove[lastSegment(link)] {
+				kept = append(kept, link)
+			}
+		}
+		instance.ResourcePolicies = kept
+		s.refreshFingerprints(instance)
+		return nil
+	},
+}
+
+// regionOf returns the region of the zone of instance, e.g. us-central1 for us-central1-a.
+func regionOf(instance *computepb.Instance) string {
+	zone := lastSegment(instance.GetZone())
+	return zone[:strings.LastIndex(zone, "-")]
+}
@@ -0,0 +1,131 @@
+package fakecompute
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"google.golang.org/protobuf/proto"
+)
+
+// newZoneOperation records a finished operation of opType on targetLink in zone
+// of project and returns a copy of it. s.mu must be held.
+func (s *Server) newZoneOperation(project, zone, opType, targetLink string) *computepb.Operation {
+	op := s.newOperation(opType, targetLink)
+	zoneLink := apiBase + fmt.Sprintf("projects/%s/zones/%s", project, zone)
+	op.Zone = proto.String(zoneLink)
+	op.SelfLink = proto.String(zoneLink + "/operations/" + op.GetName())
+	s.operations[operationKey(project, "zones", zone, op.GetName())] = op
+	return proto.Clone(op).(*computepb.Operation)
+}
+
+// newRegionOperation is newZoneOperation for a regional resource. s.mu must be held.
+func (s *Server) newRegionOperation(project, region, opType, targetLink string) *computepb.Operation {
+	op := s.newOperation(opType, targetLink)
+	regionLink := apiBase + fmt.Sprintf("projects/%s/regions/%s", project, region)
+	op.Region = proto.String(regionLink)
+	op.SelfLink = proto.String(regionLink + "/operations/" + op.GetName())
+	s.operations[operationKey(project, "regions", region, op.GetName())] = op
+	return proto.Clone(op).(*computepb.Operation)
+}
+
+func (s *Server) newOperation(opType, targetLink string) *computepb.Operation {
+	id := s.newID()
+	now := s.timestamp()
+	done := computepb.Operation_DONE
+	return &computepb.Operation{
+		Kind:              proto.String("compute#operation"),
+		Id:                proto.Uint64(id),
+		Name:              proto.String("operation-" + strconv.FormatUint(id, 10)),
+		OperationType:     proto.String(opType),
+		TargetLink:        proto.String(targetLink),
+		TargetId:          proto.Uint64(id),
+		Status:            &done,
+		Progress:          proto.Int32(100),
+		User:              proto.String(User),
+		CreationTimestamp: proto.String(now),
+		InsertTime:        proto.String(now),
+		StartTime:         proto.String(now),
+		EndTime:           proto.String(now),
+	}
+}
+
+func operationKey(project, scope, location, name string) string {
+	return project + "/" + scope + "/" + location + "/" + name
+}
+
+// zoneOperations returns copies of the operations of zone in project, oldest first.
+// s.mu must be held.
+func (s *Server) zoneOperations(project, zone string) []*computepb.Operation {
+	var ops []*computepb.Operation
+	for _, op := range s.operations {
+		if op.GetZone() == apiBase+fmt.Sprintf("projects/%s/zones/%s", project, zone) {
+			ops = append(ops, proto.Clone(op).(*computepb.Operation))
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].GetId() < ops[j].GetId() })
+	return ops
+}
+
+func (s *Server) getZoneOperation(w http.ResponseWriter, r *http.Request) {
+	s.getOperation(w, r, "zones", r.PathValue("zone"))
+}
+
+func (s *Server) getRegionOperation(w http.ResponseWriter, r *http.Request) {
+	s.getOperation(w, r, "regions", r.PathValue("region"))
+}
+
+func (s *Server) getOperation(w http.ResponseWriter, r *http.Request, scope, location string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	name := r.PathValue("operation")
+	op, ok := s.operations[operationKey(r.PathValue("project"), scope, location, name)]
+	if !ok {
+		writeNotFound(w, "operation", name)
+		return
+	}
+	writeMessage(w, op)
+}
+
+// listZoneOperations lists the operations of a zone, honoring filter, the
+// "creationTimestamp desc" order and maxResults.
+func (s *Server) listZoneOperations(w http.ResponseWriter, r *http.Request) {
+	f, err := parseFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	ops := s.zoneOperations(r.PathValue("project"), r.PathValue("zone"))
+	s.mu.Unlock()
+
+	if r.URL.Query().Get("orderBy") == "creationTimestamp desc" {
+		for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+			ops[i], ops[j] = ops[j], ops[i]
+		}
+	}
+	list := &computepb.OperationList{Kind: proto.String("compute#operationList")}
+	for _, op := range ops {
+		if f.match(operationFields(op)) {
+			list.Items = append(list.Items, op)
+		}
+	}
+	if maxResults, convErr := strconv.Atoi(r.URL.Query().Get("maxResults")); convErr == nil && maxResults > 0 && len(list.Items) > maxResults {
+		list.Items = list.Items[:maxResults]
+	}
+	writeMessage(w, list)
+}
+
+// operationFields returns the fields of op a filter can refer to.
+func operationFields(op *computepb.Operation) map[string]string {
+	return map[string]string{
+		"name":          op.GetName(),
+		"operationType": op.GetOperationType(),
+		"targetLink":    op.GetTargetLink(),
+		"status":        op.GetStatus().String(),
+		"user":          op.GetUser(),
+	}
+}
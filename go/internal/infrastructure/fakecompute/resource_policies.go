@@ -0,0 +1,64 @@
+package fakecompute
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"google.golang.org/protobuf/proto"
+)
+
+func (s *Server) getResourcePolicy(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	name := r.PathValue("policy")
+	policy, ok := s.policies[policyKey(r.PathValue("project"), r.PathValue("region"), name)]
+	if !ok {
+		writeNotFound(w, "projects/"+r.PathValue("project")+"/regions/"+r.PathValue("region")+"/resourcePolicies", name)
+		return
+	}
+	writeMessage(w, policy)
+}
+
+func (s *Server) listResourcePolicies(w http.ResponseWriter, r *http.Request) {
+	f, err := parseFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := &computepb.ResourcePolicyList{Kind: proto.String("compute#resourcePolicyList")}
+	region := apiBase + fmt.Sprintf("projects/%s/regions/%s", r.PathValue("project"), r.PathValue("region"))
+	for _, policy := range s.policies {
+		if policy.GetRegion() == region && f.match(map[string]string{"name": policy.GetName(), "status": policy.GetStatus()}) {
+			list.Items = append(list.Items, proto.Clone(policy).(*computepb.ResourcePolicy))
+		}
+	}
+	sort.Slice(list.Items, func(i, j int) bool { return list.Items[i].GetName() < list.Items[j].GetName() })
+	writeMessage(w, list)
+}
+
+func (s *Server) insertResourcePolicy(w http.ResponseWriter, r *http.Request) {
+	var policy computepb.ResourcePolicy
+	if err := readMessage(r, &policy); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid", err.Error())
+		return
+	}
+	if policy.GetName() == "" {
+		writeError(w, http.StatusBadRequest, "required", "Required field 'resource.name' not specified")
+		return
+	}
+
+	project, region := r.PathValue("project"), r.PathValue("region")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.policies[policyKey(project, region, policy.GetName())]; exists {
+		writeError(w, http.StatusConflict, "alreadyExists", fmt.Sprintf("The resource '%s' already exists", policy.GetName()))
+		return
+	}
+	s.addResourcePolicy(project, region, &policy)
+	writeMessage(w, s.newRegionOperation(project, region, "insert", policy.GetSelfLink()))
+}
@@ -0,0 +1,229 @@
+// Package fakecompute implements an in-memory fake of the Compute Engine REST API:
+// instances, zone and region operations, and resource policies.
+//
+// It serves the requests gcectl's repositories send, so the repositories and
+// commands can be tested end to end without credentials, and users can try
+// gcectl against it with --endpoint. Operations finish immediately.
+package fakecompute
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// apiBase prefixes the resource links the server returns, like the real API does.
+const apiBase = "https://www.googleapis.com/compute/v1/"
+
+// User is the account operations of the fake are attributed to.
+const User = "fake@gcectl.local"
+
+// Server is a fake Compute Engine API. It is safe for concurrent use.
+type Server struct {
+	mux *http.ServeMux
+	now func() time.Time
+
+	mu         sync.Mutex
+	instances  map[string]*computepb.Instance
+	policies   map[string]*computepb.ResourcePolicy
+	operations map[string]*computepb.Operation
+	nextID     uint64
+}
+
+// New returns an empty Server. Serve it with httptest.NewServer or http.ListenAndServe
+// and point the clients at its URL, e.g. with option.WithEndpoint.
+func New() *Server {
+	s := &Server{
+		mux:        http.NewServeMux(),
+		now:        time.Now,
+		instances:  make(map[string]*computepb.Instance),
+		policies:   make(map[string]*computepb.ResourcePolicy),
+		operations: make(map[string]*computepb.Operation),
+		nextID:     1000,
+	}
+	s.routes()
+	return s
+}
+
+func (s *Server) routes() {
+	const project = "/compute/v1/projects/{project}"
+	s.mux.HandleFunc("GET "+project+"/aggregated/instances", s.aggregatedListInstances)
+	s.mux.HandleFunc("GET "+project+"/zones/{zone}/instances", s.listInstances)
+	s.mux.HandleFunc("GET "+project+"/zones/{zone}/instances/{instance}", s.getInstance)
+	s.mux.HandleFunc("POST "+project+"/zones/{zone}/instances/{instance}/{action}", s.instanceAction)
+	s.mux.HandleFunc("GET "+project+"/zones/{zone}/operations", s.listZoneOperations)
+	s.mux.HandleFunc("GET "+project+"/zones/{zone}/operations/{operation}", s.getZoneOperation)
+	s.mux.HandleFunc("POST "+project+"/zones/{zone}/operations/{operation}/wait", s.getZoneOperation)
+	s.mux.HandleFunc("GET "+project+"/regions/{region}/operations/{operation}", s.getRegionOperation)
+	s.mux.HandleFunc("POST "+project+"/regions/{region}/operations/{operation}/wait", s.getRegionOperation)
+	s.mux.HandleFunc("GET "+project+"/regions/{region}/resourcePolicies", s.listResourcePolicies)
+	s.mux.HandleFunc("POST "+project+"/regions/{region}/resourcePolicies", s.insertResourcePolicy)
+	s.mux.HandleFunc("GET "+project+"/regions/{region}/resourcePolicies/{policy}", s.getResourcePolicy)
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if _, pattern := s.mux.Handler(r); pattern == "" {
+		writeError(w, http.StatusNotFound, "notFound", fmt.Sprintf("%s %s is not implemented by the fake Compute API", r.Method, r.URL.Path))
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// AddInstance adds instance to zone of project, filling in what the API would:
+// the links, an ID, a creation time and fingerprints. An instance without a status
+// is TERMINATED and one without a machine type is an e2-medium.
+func (s *Server) AddInstance(project, zone string, instance *computepb.Instance) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instance = proto.Clone(instance).(*computepb.Instance)
+	zoneLink := apiBase + fmt.Sprintf("projects/%s/zones/%s", project, zone)
+	instance.Zone = proto.String(zoneLink)
+	instance.SelfLink = proto.String(zoneLink + "/instances/" + instance.GetName())
+	instance.Kind = proto.String("compute#instance")
+	if instance.Id == nil {
+		instance.Id = proto.Uint64(s.newID())
+	}
+	if instance.CreationTimestamp == nil {
+		instance.CreationTimestamp = proto.String(s.timestamp())
+	}
+	if instance.Status == nil {
+		instance.Status = proto.String("TERMINATED")
+	}
+	if instance.MachineType == nil {
+		instance.MachineType = proto.String(zoneLink + "/machineTypes/e2-medium")
+	} else if !strings.Contains(instance.GetMachineType(), "/") {
+		instance.MachineType = proto.String(zoneLink + "/machineTypes/" + instance.GetMachineType())
+	}
+	if instance.Metadata == nil {
+		instance.Metadata = &computepb.Metadata{}
+	}
+	if instance.Scheduling == nil {
+		instance.Scheduling = &computepb.Scheduling{OnHostMaintenance: proto.String("MIGRATE"), AutomaticRestart: proto.Bool(true)}
+	}
+	s.refreshFingerprints(instance)
+	s.instances[instanceKey(project, zone, instance.GetName())] = instance
+}
+
+// Instance returns a copy of the named instance, or nil when there is none.
+func (s *Server) Instance(project, zone, name string) *computepb.Instance {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	instance, ok := s.instances[instanceKey(project, zone, name)]
+	if !ok {
+		return nil
+	}
+	return proto.Clone(instance).(*computepb.Instance)
+}
+
+// AddResourcePolicy adds policy to region of project, filling in its links and status.
+func (s *Server) AddResourcePolicy(project, region string, policy *computepb.ResourcePolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addResourcePolicy(project, region, proto.Clone(policy).(*computepb.ResourcePolicy))
+}
+
+func (s *Server) addResourcePolicy(project, region string, policy *computepb.ResourcePolicy) {
+	regionLink := apiBase + fmt.Sprintf("projects/%s/regions/%s", project, region)
+	policy.Region = proto.String(regionLink)
+	policy.SelfLink = proto.String(regionLink + "/resourcePolicies/" + policy.GetName())
+	policy.Kind = proto.String("compute#resourcePolicy")
+	if policy.Id == nil {
+		policy.Id = proto.Uint64(s.newID())
+	}
+	if policy.Status == nil {
+		policy.Status = proto.String("READY")
+	}
+	if policy.CreationTimestamp == nil {
+		policy.CreationTimestamp = proto.String(s.timestamp())
+	}
+	s.policies[policyKey(project, region, policy.GetName())] = policy
+}
+
+// Operations returns copies of the operations recorded in zone of project, oldest first.
+func (s *Server) Operations(project, zone string) []*computepb.Operation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.zoneOperations(project, zone)
+}
+
+func instanceKey(project, zone, name string) string {
+	return project + "/" + zone + "/" + name
+}
+
+func policyKey(project, region, name string) string {
+	return project + "/" + region + "/" + name
+}
+
+// newID returns a unique numeric ID. s.mu must be held.
+func (s *Server) newID() uint64 {
+	s.nextID++
+	return s.nextID
+}
+
+func (s *Server) timestamp() string {
+	return s.now().Format(time.RFC3339Nano)
+}
+
+// refreshFingerprints changes the fingerprints of instance, as every update does.
+// s.mu must be held.
+func (s *Server) refreshFingerprints(instance *computepb.Instance) {
+	id := fmt.Sprintf("%x", s.newID())
+	instance.Fingerprint = proto.String(id)
+	instance.LabelFingerprint = proto.String("l" + id)
+	instance.Metadata.Fingerprint = proto.String("m" + id)
+}
+
+// lastSegment returns what follows the last "/" of a resource link.
+func lastSegment(link string) string {
+	return link[strings.LastIndex(link, "/")+1:]
+}
+
+// writeMessage writes m as the JSON response, in the encoding the REST clients expect.
+func writeMessage(w http.ResponseWriter, m proto.Message) {
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internalError", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// readMessage decodes the JSON request body into m. An empty body leaves m unchanged.
+func readMessage(r *http.Request, m proto.Message) error {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, m)
+}
+
+// writeError writes an error in the format of the Google APIs.
+func writeError(w http.ResponseWriter, code int, reason, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"error": map[string]any{
+			"code":    code,
+			"message": message,
+			"errors":  []map[string]string{{"domain": "global", "reason": reason, "message": message}},
+		},
+	})
+}
+
+func writeNotFound(w http.ResponseWriter, kind, name string) {
+	writeError(w, http.StatusNotFound, "notFound", fmt.Sprintf("The resource '%s %s' was not found", kind, name))
+}
@@ -0,0 +1,102 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/iterator"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+type acceleratorTypesClient interface {
+	List(context.Context, *computepb.ListAcceleratorTypesRequest, ...gax.CallOption) *compute.AcceleratorTypeIterator
+	Close() error
+}
+
+// AcceleratorTypeRepository implements the repository.AcceleratorTypeRepository interface for GCP.
+//
+//nolint:govet // Field order optimized for readability over memory alignment
+type AcceleratorTypeRepository struct {
+	logger   log.Logger
+	timeouts Timeouts
+
+	acceleratorTypesClient acceleratorTypesClient
+}
+
+// NewAcceleratorTypeRepository creates a AcceleratorTypeRepository with a GCP client initialized from ctx and settings.
+// The returned repository owns the client and must be closed by the caller.
+func NewAcceleratorTypeRepository(ctx context.Context, logger log.Logger, settings ClientSettings) (*AcceleratorTypeRepository, error) {
+	opts, err := settings.ComputeClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	acceleratorTypesClient, err := compute.NewAcceleratorTypesRESTClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AcceleratorTypes client: %w", err)
+	}
+	repo := newAcceleratorTypeRepository(logger, acceleratorTypesClient)
+	repo.timeouts = settings.Timeouts
+	return repo, nil
+}
+
+// newAcceleratorTypeRepository allows tests to inject GCP clients.
+func newAcceleratorTypeRepository(logger log.Logger, acceleratorTypesClient acceleratorTypesClient) *AcceleratorTypeRepository {
+	return &AcceleratorTypeRepository{logger: logger, acceleratorTypesClient: acceleratorTypesClient}
+}
+
+// Close releases the GCP client held by the repository.
+func (r *AcceleratorTypeRepository) Close() error {
+	if err := r.acceleratorTypesClient.Close(); err != nil {
+		r.logger.Errorf("Failed to close AcceleratorTypes client: %v", err)
+		return err
+	}
+	return nil
+}
+
+// List returns the non-deprecated accelerator types available in project/zone.
+func (r *AcceleratorTypeRepository) List(ctx context.Context, project, zone string) ([]*model.AcceleratorType, error) {
+	ctx, cancel := r.timeouts.callContext(ctx)
+	defer cancel()
+	it := r.acceleratorTypesClient.List(ctx, &computepb.ListAcceleratorTypesRequest{
+		Project: project,
+		Zone:    zone,
+	})
+	acceleratorTypes, err := collectAcceleratorTypes(it.Next, zone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accelerator types in %s/%s: %w", project, zone, asAPIError(err))
+	}
+	return acceleratorTypes, nil
+}
+
+// collectAcceleratorTypes drains a accelerator type iterator, skipping deprecated accelerator types.
+func collectAcceleratorTypes(next func() (*computepb.AcceleratorType, error), zone string) ([]*model.AcceleratorType, error) {
+	acceleratorTypes := make([]*model.AcceleratorType, 0)
+	for {
+		at, err := next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if at.GetDeprecated().GetState() != "" {
+			continue
+		}
+		acceleratorTypes = append(acceleratorTypes, &model.AcceleratorType{
+			Name:                at.GetName(),
+			Zone:                zone,
+			Description:         at.GetDescription(),
+			MaxCardsPerInstance: int(at.GetMaximumCardsPerInstance()),
+		})
+	}
+	return acceleratorTypes, nil
+}
+
+var _ repository.AcceleratorTypeRepository = (*AcceleratorTypeRepository)(nil)
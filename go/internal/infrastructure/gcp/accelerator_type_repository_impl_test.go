@@ -0,0 +1,38 @@
+package gcp
+
+import (
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCollectAcceleratorTypes(t *testing.T) {
+	acceleratorTypes := []*computepb.AcceleratorType{
+		{Name: stringPtr("nvidia-tesla-t4"), Description: stringPtr("NVIDIA T4"), MaximumCardsPerInstance: proto.Int32(4)},
+		{Name: stringPtr("nvidia-tesla-k80"), Deprecated: &computepb.DeprecationStatus{State: stringPtr("DEPRECATED")}},
+	}
+	i := 0
+	next := func() (*computepb.AcceleratorType, error) {
+		if i < len(acceleratorTypes) {
+			i++
+			return acceleratorTypes[i-1], nil
+		}
+		return nil, iterator.Done
+	}
+
+	got, err := collectAcceleratorTypes(next, "us-central1-a")
+	require.NoError(t, err)
+	require.Equal(t, []*model.AcceleratorType{
+		{Name: "nvidia-tesla-t4", Zone: "us-central1-a", Description: "NVIDIA T4", MaxCardsPerInstance: 4},
+	}, got, "deprecated accelerator types are skipped")
+
+	_, err = collectAcceleratorTypes(func() (*computepb.AcceleratorType, error) {
+		return nil, errors.New("denied")
+	}, "us-central1-a")
+	require.Error(t, err)
+}
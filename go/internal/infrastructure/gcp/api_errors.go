@@ -0,0 +1,66 @@
+package gcp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/googleapis/gax-go/v2/apierror"
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"google.golang.org/grpc/codes"
+)
+
+// quotaExceededCode is the error code GCE reports in operations that would exceed a quota.
+const quotaExceededCode = "QUOTA_EXCEEDED"
+
+// asAPIError marks API errors the CLI can give a remediation hint for with the
+// matching model error: model.ErrQuotaExceeded for an exhausted quota and
+// model.ErrPermissionDenied for missing IAM permissions. Other errors are
+// returned unchanged.
+//
+// Both arrive as 403 Forbidden, either from the request or as the error of the
+// operation, so quotas are told apart by the message.
+func asAPIError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var apiErr *apierror.APIError
+	if !errors.As(err, &apiErr) {
+		var ok bool
+		if apiErr, ok = apierror.FromError(err); !ok {
+			return err
+		}
+	}
+
+	switch {
+	case isQuotaExceeded(apiErr):
+		return fmt.Errorf("%w: %w", model.ErrQuotaExceeded, err)
+	case isPermissionDenied(apiErr):
+		return fmt.Errorf("%w: %w", model.ErrPermissionDenied, err)
+	}
+	return err
+}
+
+func isQuotaExceeded(apiErr *apierror.APIError) bool {
+	if strings.EqualFold(apiErr.Reason(), "quotaExceeded") || strings.EqualFold(apiErr.Reason(), quotaExceededCode) {
+		return true
+	}
+	if s := apiErr.GRPCStatus(); s != nil && s.Code() == codes.ResourceExhausted {
+		return true
+	}
+	msg := apiErr.Error()
+	if strings.Contains(msg, quotaExceededCode) {
+		return true
+	}
+	lower := strings.ToLower(msg)
+	return apiErr.HTTPCode() == http.StatusForbidden && strings.Contains(lower, "quota") && strings.Contains(lower, "exceeded")
+}
+
+func isPermissionDenied(apiErr *apierror.APIError) bool {
+	if apiErr.HTTPCode() == http.StatusForbidden {
+		return true
+	}
+	s := apiErr.GRPCStatus()
+	return s != nil && s.Code() == codes.PermissionDenied
+}
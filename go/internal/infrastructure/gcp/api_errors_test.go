@@ -0,0 +1,58 @@
+package gcp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/googleapi"
+)
+
+func TestAsAPIError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want error
+		name string
+	}{
+		{
+			name: "403 is permission denied",
+			err:  &googleapi.Error{Code: http.StatusForbidden, Message: "Required 'compute.instances.start' permission for 'projects/p/zones/z/instances/vm'"},
+			want: model.ErrPermissionDenied,
+		},
+		{
+			name: "403 quota message is quota exceeded",
+			err:  &googleapi.Error{Code: http.StatusForbidden, Message: "Quota 'CPUS' exceeded.  Limit: 24.0 in region us-central1."},
+			want: model.ErrQuotaExceeded,
+		},
+		{
+			name: "operation error with quota code is quota exceeded",
+			err:  fmt.Errorf("operation failed: %w", &googleapi.Error{Code: http.StatusForbidden, Message: "Forbidden: errors:{code:\"QUOTA_EXCEEDED\"}"}),
+			want: model.ErrQuotaExceeded,
+		},
+		{
+			name: "404 is kept",
+			err:  &googleapi.Error{Code: http.StatusNotFound, Message: "The resource was not found"},
+		},
+		{
+			name: "non API error is kept",
+			err:  errors.New("connection reset"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := asAPIError(tt.err)
+			if tt.want == nil {
+				assert.Same(t, tt.err, got)
+				return
+			}
+			assert.ErrorIs(t, got, tt.want)
+			assert.ErrorIs(t, got, tt.err)
+		})
+	}
+
+	assert.NoError(t, asAPIError(nil))
+}
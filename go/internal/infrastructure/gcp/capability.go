@@ -0,0 +1,70 @@
+package gcp
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/googleapis/gax-go/v2/apierror"
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"google.golang.org/grpc/codes"
+)
+
+// asCapabilityError converts an API error that signals an unsupported feature into a
+// *model.CapabilityError, so callers can tell "not possible for this instance" apart
+// from transient failures. Other errors are returned unchanged.
+//
+// GCE reports unsupported features either as 501 Not Implemented (the API or the
+// installed client does not know the method) or as 400 Bad Request whose message
+// says the operation is not supported (e.g. for a machine family).
+func asCapabilityError(err error, vm *model.VM, capability model.Capability) error {
+	if err == nil {
+		return nil
+	}
+	var apiErr *apierror.APIError
+	if !errors.As(err, &apiErr) {
+		var ok bool
+		if apiErr, ok = apierror.FromError(err); !ok {
+			return err
+		}
+	}
+	if !isUnsupported(apiErr) {
+		return err
+	}
+
+	reason := apiErr.Message()
+	if reason == "" {
+		reason = err.Error()
+	}
+	return &model.CapabilityError{VMName: vm.Name, Capability: capability, Reason: reason}
+}
+
+func isUnsupported(apiErr *apierror.APIError) bool {
+	if apiErr.HTTPCode() == http.StatusNotImplemented {
+		return true
+	}
+	if s := apiErr.GRPCStatus(); s != nil && s.Code() == codes.Unimplemented {
+		return true
+	}
+	if apiErr.HTTPCode() != http.StatusBadRequest {
+		return false
+	}
+	if strings.EqualFold(apiErr.Reason(), "UNSUPPORTED_OPERATION") {
+		return true
+	}
+	msg := strings.ToLower(apiErr.Message())
+	return strings.Contains(msg, "not supported") || strings.Contains(msg, "unsupported")
+}
+
+// isFingerprintConflict reports whether err is the API's rejection of an outdated
+// fingerprint (412 Precondition Failed), i.e. the resource was changed concurrently.
+func isFingerprintConflict(err error) bool {
+	var apiErr *apierror.APIError
+	if !errors.As(err, &apiErr) {
+		var ok bool
+		if apiErr, ok = apierror.FromError(err); !ok {
+			return false
+		}
+	}
+	return apiErr.HTTPCode() == http.StatusPreconditionFailed
+}
@@ -0,0 +1,68 @@
+package gcp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/googleapi"
+)
+
+func TestAsCapabilityError(t *testing.T) {
+	vm := &model.VM{Name: "sandbox"}
+
+	tests := []struct {
+		err             error
+		name            string
+		wantUnsupported bool
+	}{
+		{
+			name:            "501 is unsupported",
+			err:             &googleapi.Error{Code: http.StatusNotImplemented, Message: "Method not implemented"},
+			wantUnsupported: true,
+		},
+		{
+			name:            "400 with not supported message",
+			err:             &googleapi.Error{Code: http.StatusBadRequest, Message: "Suspend is not supported for machine family a2"},
+			wantUnsupported: true,
+		},
+		{
+			name:            "wrapped 400 with not supported message",
+			err:             fmt.Errorf("request failed: %w", &googleapi.Error{Code: http.StatusBadRequest, Message: "Operation unsupported"}),
+			wantUnsupported: true,
+		},
+		{
+			name: "other 400 is kept",
+			err:  &googleapi.Error{Code: http.StatusBadRequest, Message: "Invalid value for field"},
+		},
+		{
+			name: "403 is kept",
+			err:  &googleapi.Error{Code: http.StatusForbidden, Message: "Permission denied"},
+		},
+		{
+			name: "non API error is kept",
+			err:  errors.New("connection reset"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := asCapabilityError(tt.err, vm, model.CapabilityStart)
+			if !tt.wantUnsupported {
+				assert.Same(t, tt.err, got)
+				return
+			}
+			var capErr *model.CapabilityError
+			require.ErrorAs(t, got, &capErr)
+			assert.Equal(t, "sandbox", capErr.VMName)
+			assert.Equal(t, model.CapabilityStart, capErr.Capability)
+			assert.ErrorIs(t, got, model.ErrUnsupported)
+		})
+	}
+
+	assert.NoError(t, asCapabilityError(nil, vm, model.CapabilityStart))
+}
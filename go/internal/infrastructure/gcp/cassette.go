@@ -0,0 +1,208 @@
+package gcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Interaction is one API request and the response it got, as a cassette stores it.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest identifies a request by its method and its path and query,
+// so a cassette replays against any endpoint.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+}
+
+// RecordedResponse is a response with a JSON body, or a JSON string for other bodies.
+type RecordedResponse struct {
+	Status      int             `json:"status"`
+	ContentType string          `json:"contentType,omitempty"`
+	Body        json.RawMessage `json:"body,omitempty"`
+}
+
+// Cassette either records the API interactions of the clients to a fixture file,
+// or replays the interactions of one without a network.
+//
+// Recorded bodies have secrets redacted like traces, so cassettes can be committed
+// as test fixtures. A replayed request gets the first unused response recorded
+// for the same method, path and query, or the last one when all are used, so
+// polling replays the final state. It is safe for concurrent use.
+type Cassette struct {
+	path string
+
+	mu           sync.Mutex
+	replaying    bool
+	interactions []Interaction
+	used         []bool
+}
+
+// NewRecordingCassette returns a cassette writing every interaction to path,
+// replacing the file on the first one.
+func NewRecordingCassette(path string) *Cassette {
+	return &Cassette{path: path}
+}
+
+// LoadCassette returns a cassette replaying the interactions recorded in path.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+	var interactions []Interaction
+	if err := json.Unmarshal(data, &interactions); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+	return &Cassette{path: path, replaying: true, interactions: interactions, used: make([]bool, len(interactions))}, nil
+}
+
+// Replaying reports whether c replays a fixture rather than recording one.
+func (c *Cassette) Replaying() bool {
+	return c.replaying
+}
+
+// Transport returns a transport recording the requests it sends through base,
+// or replaying them when c was loaded from a fixture.
+func (c *Cassette) Transport(base http.RoundTripper) http.RoundTripper {
+	return &cassetteTransport{cassette: c, base: base}
+}
+
+type cassetteTransport struct {
+	cassette *Cassette
+	base     http.RoundTripper
+}
+
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c := t.cassette
+	key := RecordedRequest{Method: req.Method, URL: cassetteURL(req.URL)}
+	if c.replaying {
+		return c.replay(req, key)
+	}
+
+	res, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	if err := c.record(Interaction{Request: key, Response: recordResponse(res, body)}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *Cassette) replay(req *http.Request, key RecordedRequest) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	last := -1
+	for i, interaction := range c.interactions {
+		if interaction.Request != key {
+			continue
+		}
+		last = i
+		if !c.used[i] {
+			break
+		}
+	}
+	if last < 0 {
+		return nil, fmt.Errorf("cassette %s has no response to %s %s", c.path, key.Method, key.URL)
+	}
+	c.used[last] = true
+
+	recorded := c.interactions[last].Response
+	body := []byte(recorded.Body)
+	var text string
+	if json.Unmarshal(body, &text) == nil {
+		body = []byte(text)
+	}
+	header := make(http.Header)
+	if recorded.ContentType != "" {
+		header.Set("Content-Type", recorded.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// record appends interaction and rewrites the fixture, so it is complete
+// whenever the command exits.
+func (c *Cassette) record(interaction Interaction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interactions = append(c.interactions, interaction)
+	data, err := marshalJSON(c.interactions, "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+func recordResponse(res *http.Response, body []byte) RecordedResponse {
+	recorded := RecordedResponse{Status: res.StatusCode, ContentType: res.Header.Get("Content-Type")}
+	if len(body) == 0 {
+		return recorded
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err == nil {
+		if redactedBody, err := marshalJSON(redactValue(v), ""); err == nil {
+			recorded.Body = redactedBody
+			return recorded
+		}
+	}
+	recorded.Body, _ = marshalJSON(string(body), "")
+	return recorded
+}
+
+// marshalJSON encodes v, indented by indent when it is not empty, without
+// escaping "&", "<" and ">" so that recorded URLs stay readable.
+func marshalJSON(v any, indent string) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", indent)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// cassetteURL returns the path and query of u with sensitive parameters
+// dropped and the parameters sorted, so equal requests match on replay.
+func cassetteURL(u *url.URL) string {
+	query := u.Query()
+	for name := range query {
+		if sensitiveParams[strings.ToLower(name)] {
+			query.Del(name)
+		}
+	}
+	if len(query) == 0 {
+		return u.EscapedPath()
+	}
+	return u.EscapedPath() + "?" + query.Encode()
+}
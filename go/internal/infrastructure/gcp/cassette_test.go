@@ -0,0 +1,110 @@
+package gcp
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/fakecompute"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func TestCassetteRecordsAndReplays(t *testing.T) {
+	ctx := context.Background()
+	fake := fakecompute.New()
+	fake.AddInstance("proj", "us-central1-a", &computepb.Instance{
+		Name:     proto.String("sandbox"),
+		Metadata: &computepb.Metadata{Items: []*computepb.Items{{Key: proto.String("ssh-keys"), Value: proto.String("alice:ssh-ed25519 AAAA")}}},
+	})
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	sandbox := &model.VM{Project: "proj", Zone: "us-central1-a", Name: "sandbox"}
+
+	recorder, err := NewVMRepository(ctx, log.NewLogger(), ClientSettings{ComputeEndpoint: server.URL, Cassette: NewRecordingCassette(path)})
+	require.NoError(t, err)
+	recorded, err := recorder.FindByName(ctx, sandbox)
+	require.NoError(t, err)
+	_ = recorder.Close()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "/compute/v1/projects/proj/zones/us-central1-a/instances/sandbox")
+	assert.NotContains(t, string(data), "ssh-ed25519", "metadata values are redacted")
+
+	server.Close()
+	cassette, err := LoadCassette(path)
+	require.NoError(t, err)
+	replayer, err := NewVMRepository(ctx, log.NewLogger(), ClientSettings{Cassette: cassette})
+	require.NoError(t, err)
+	defer func() { _ = replayer.Close() }()
+	replayed, err := replayer.FindByName(ctx, sandbox)
+	require.NoError(t, err)
+	assert.Equal(t, recorded.ID, replayed.ID)
+	assert.Equal(t, recorded.Status, replayed.Status)
+
+	_, err = replayer.FindByName(ctx, &model.VM{Project: "proj", Zone: "us-central1-a", Name: "missing"})
+	require.ErrorContains(t, err, "has no response to GET /compute/v1/projects/proj/zones/us-central1-a/instances/missing")
+}
+
+// TestReplayedListAndDescribe replays realistic list and describe payloads and
+// compares what list shows with testdata/cassettes/list_describe.golden.csv.
+// Run with -update to rewrite the golden file after an intended change.
+func TestReplayedListAndDescribe(t *testing.T) {
+	ctx := context.Background()
+	cassette, err := LoadCassette("testdata/cassettes/list_describe.json")
+	require.NoError(t, err)
+	repo, err := NewVMRepository(ctx, log.NewLogger(), ClientSettings{Cassette: cassette})
+	require.NoError(t, err)
+	defer func() { _ = repo.Close() }()
+
+	const project = "my-sandbox-project"
+	vms, err := repo.FindAll(ctx, []*model.VM{
+		{Project: project, Zone: "us-central1-a", Name: "sandbox"},
+		{Project: project, Zone: "asia-northeast1-a", Name: "trainer"},
+	})
+	require.NoError(t, err)
+	items := make([]presenter.VMListItem, len(vms))
+	for i, vm := range vms {
+		require.NotNil(t, vm)
+		items[i] = presenter.VMListItem{Name: vm.Name, Project: vm.Project, Zone: vm.Zone, MachineType: vm.MachineType, Status: vm.Status, SchedulePolicy: vm.SchedulePolicy}
+		if vm.Schedule != nil {
+			items[i].ScheduleTimeZone = vm.Schedule.TimeZone
+		}
+	}
+	var got bytes.Buffer
+	require.NoError(t, presenter.WriteVMListCSV(&got, items, ','))
+	const golden = "testdata/cassettes/list_describe.golden.csv"
+	if *updateGolden {
+		require.NoError(t, os.WriteFile(golden, got.Bytes(), 0o644))
+	}
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), got.String())
+
+	vm, err := repo.FindByName(ctx, &model.VM{Project: project, Zone: "us-central1-a", Name: "sandbox"})
+	require.NoError(t, err)
+	assert.Equal(t, "5893124809271615201", vm.ID)
+	assert.Equal(t, "34.121.87.203", vm.ExternalIP)
+	assert.Equal(t, "10.128.0.12", vm.InternalIP)
+	require.Len(t, vm.Disks, 2)
+	assert.True(t, vm.Disks[0].Boot)
+	assert.Equal(t, int64(500), vm.Disks[1].SizeGB)
+	require.NotNil(t, vm.ServiceAccount)
+	assert.Equal(t, "123456789012-compute@developer.gserviceaccount.com", vm.ServiceAccount.Email)
+	assert.Equal(t, map[string]string{"env": "dev", "owner": "haru"}, vm.Labels)
+	require.NotNil(t, vm.UpcomingMaintenance)
+	assert.True(t, vm.UpcomingMaintenance.Equal(time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,153 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
+)
+
+// ClientSettings configures how the GCP clients authenticate and connect, and how
+// long the repositories wait for them.
+// The zero value uses Application Default Credentials and the public endpoints
+// without timeouts.
+type ClientSettings struct {
+	// ProxyURL is the HTTP proxy every API and token request goes through.
+	// Nil means the proxy environment variables are honored as usual.
+	ProxyURL *url.URL
+	// CredentialsFile is a service account key file used instead of Application Default Credentials.
+	CredentialsFile string
+	// ComputeEndpoint replaces the Compute Engine API endpoint, e.g. with a Private Google Access one.
+	ComputeEndpoint string
+	// Timeouts bound the API calls and operation waits of the repositories.
+	Timeouts Timeouts
+	// OperationEvents receives the events of the operations the repositories wait for.
+	// It may be nil; otherwise it must be drained while the repositories are used.
+	OperationEvents chan<- model.OperationEvent
+	// Tracer logs every API request with its status and latency when set.
+	Tracer log.Logger
+	// TraceBodies makes Tracer also log the response bodies, with secrets redacted.
+	TraceBodies bool
+	// Cassette records the API interactions to a fixture file, or replays them
+	// without credentials or a network when it was loaded from one.
+	Cassette *Cassette
+}
+
+// ClientOptions converts s to options for the repository constructors.
+//
+// With a proxy, a tracer or a cassette the options carry an authenticated HTTP client, so
+// the credentials are looked up here rather than when a client is created.
+func (s ClientSettings) ClientOptions() ([]option.ClientOption, error) {
+	if s.replaying() {
+		return []option.ClientOption{option.WithoutAuthentication(), option.WithHTTPClient(&http.Client{Transport: s.baseTransport()})}, nil
+	}
+	var opts []option.ClientOption
+	if s.CredentialsFile != "" {
+		opts = append(opts, option.WithAuthCredentialsFile(option.ServiceAccount, s.CredentialsFile))
+	}
+	if s.ProxyURL == nil && s.Tracer == nil && s.Cassette == nil {
+		return opts, nil
+	}
+
+	// A client passed with option.WithHTTPClient is used as is, so the transport
+	// has to add the credentials and scope the API clients would otherwise add.
+	opts = append(opts, option.WithScopes(cloudPlatformScope))
+	transport, err := htransport.NewTransport(s.TokenContext(context.Background()), s.baseTransport(), opts...)
+	if err != nil {
+		if s.ProxyURL == nil {
+			return nil, fmt.Errorf("failed to create transport: %w", err)
+		}
+		return nil, fmt.Errorf("failed to create transport through proxy %s: %w", s.ProxyURL.Redacted(), err)
+	}
+	return []option.ClientOption{option.WithHTTPClient(&http.Client{Transport: transport})}, nil
+}
+
+// ComputeClientOptions is ClientOptions plus the Compute Engine endpoint override.
+//
+// A plain HTTP endpoint, such as a local fake of the API, is used without
+// authentication so that credentials never travel unencrypted.
+func (s ClientSettings) ComputeClientOptions() ([]option.ClientOption, error) {
+	if strings.HasPrefix(s.ComputeEndpoint, "http://") && !s.replaying() {
+		opts := []option.ClientOption{option.WithEndpoint(s.ComputeEndpoint), option.WithoutAuthentication()}
+		if s.Tracer != nil || s.Cassette != nil {
+			opts = append(opts, option.WithHTTPClient(&http.Client{Transport: s.baseTransport()}))
+		}
+		return opts, nil
+	}
+	opts, err := s.ClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	if s.ComputeEndpoint != "" {
+		opts = append(opts, option.WithEndpoint(s.ComputeEndpoint))
+	}
+	return opts, nil
+}
+
+// TokenContext returns ctx carrying the HTTP client OAuth2 token requests go through.
+func (s ClientSettings) TokenContext(ctx context.Context) context.Context {
+	if s.ProxyURL == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: s.proxyTransport()})
+}
+
+// Credentials loads the credentials the GCP clients authenticate with: the
+// credentials file when one is configured, otherwise Application Default Credentials.
+func (s ClientSettings) Credentials(ctx context.Context) (*google.Credentials, error) {
+	ctx = s.TokenContext(ctx)
+	if s.CredentialsFile == "" {
+		creds, err := google.FindDefaultCredentials(ctx, cloudPlatformScope)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find default credentials: %w", err)
+		}
+		return creds, nil
+	}
+	data, err := os.ReadFile(s.CredentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+	creds, err := google.CredentialsFromJSONWithType(ctx, data, google.ServiceAccount, cloudPlatformScope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load credentials file %s: %w", s.CredentialsFile, err)
+	}
+	return creds, nil
+}
+
+// replaying reports whether the API requests are answered by a replayed cassette.
+func (s ClientSettings) replaying() bool {
+	return s.Cassette != nil && s.Cassette.Replaying()
+}
+
+// baseTransport returns the transport API requests are sent with before
+// authentication: through the proxy when one is set, recorded or replayed by the
+// cassette when one is set, traced when a tracer is set.
+func (s ClientSettings) baseTransport() http.RoundTripper {
+	var transport http.RoundTripper = http.DefaultTransport
+	if s.ProxyURL != nil {
+		transport = s.proxyTransport()
+	}
+	if s.Cassette != nil {
+		transport = s.Cassette.Transport(transport)
+	}
+	if s.Tracer != nil {
+		transport = &tracingTransport{base: transport, logger: s.Tracer, bodies: s.TraceBodies}
+	}
+	return transport
+}
+
+// proxyTransport returns a copy of the default transport sending requests through the proxy.
+func (s ClientSettings) proxyTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyURL(s.ProxyURL)
+	return transport
+}
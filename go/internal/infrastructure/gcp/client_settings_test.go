@@ -0,0 +1,63 @@
+package gcp
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestClientSettingsClientOptions(t *testing.T) {
+	opts, err := ClientSettings{}.ClientOptions()
+	require.NoError(t, err)
+	assert.Empty(t, opts)
+
+	opts, err = ClientSettings{CredentialsFile: "/keys/sa.json"}.ClientOptions()
+	require.NoError(t, err)
+	assert.Len(t, opts, 1)
+
+	// A replayed cassette needs no credentials, even when a key file is configured.
+	opts, err = ClientSettings{CredentialsFile: "/keys/missing.json", Cassette: &Cassette{replaying: true}}.ClientOptions()
+	require.NoError(t, err)
+	assert.Len(t, opts, 2)
+}
+
+func TestClientSettingsComputeClientOptions(t *testing.T) {
+	opts, err := ClientSettings{}.ComputeClientOptions()
+	require.NoError(t, err)
+	assert.Empty(t, opts)
+
+	opts, err = ClientSettings{
+		CredentialsFile: "/keys/sa.json",
+		ComputeEndpoint: "https://compute-private.p.googleapis.com",
+	}.ComputeClientOptions()
+	require.NoError(t, err)
+	assert.Len(t, opts, 2)
+
+	opts, err = ClientSettings{
+		CredentialsFile: "/keys/sa.json",
+		ComputeEndpoint: "http://127.0.0.1:8080",
+	}.ComputeClientOptions()
+	require.NoError(t, err)
+	assert.Len(t, opts, 2, "plain HTTP endpoints get no credentials")
+}
+
+func TestClientSettingsTokenContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, ctx, ClientSettings{}.TokenContext(ctx))
+
+	proxy, err := url.Parse("http://proxy.example.com:3128")
+	require.NoError(t, err)
+	client, ok := ClientSettings{ProxyURL: proxy}.TokenContext(ctx).Value(oauth2.HTTPClient).(*http.Client)
+	require.True(t, ok)
+
+	req, err := http.NewRequest(http.MethodPost, "https://oauth2.googleapis.com/token", nil)
+	require.NoError(t, err)
+	got, err := client.Transport.(*http.Transport).Proxy(req)
+	require.NoError(t, err)
+	assert.Equal(t, proxy, got)
+}
@@ -0,0 +1,228 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+type diskInstancesClient interface {
+	Get(context.Context, *computepb.GetInstanceRequest, ...gax.CallOption) (*computepb.Instance, error)
+	AttachDisk(context.Context, *computepb.AttachDiskInstanceRequest, ...gax.CallOption) (*compute.Operation, error)
+	DetachDisk(context.Context, *computepb.DetachDiskInstanceRequest, ...gax.CallOption) (*compute.Operation, error)
+	Close() error
+}
+
+type disksClient interface {
+	Get(context.Context, *computepb.GetDiskRequest, ...gax.CallOption) (*computepb.Disk, error)
+	Resize(context.Context, *computepb.ResizeDiskRequest, ...gax.CallOption) (*compute.Operation, error)
+	Close() error
+}
+
+// DiskRepository implements the repository.DiskRepository interface for GCP.
+//
+//nolint:govet // Field order optimized for readability over memory alignment
+type DiskRepository struct {
+	logger   log.Logger
+	timeouts Timeouts
+	events   chan<- model.OperationEvent
+
+	instancesClient diskInstancesClient
+	disksClient     disksClient
+}
+
+// NewDiskRepository creates a DiskRepository with GCP clients initialized from ctx and settings.
+// The returned repository owns the clients and must be closed by the caller.
+func NewDiskRepository(ctx context.Context, logger log.Logger, settings ClientSettings) (*DiskRepository, error) {
+	opts, err := settings.ComputeClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	instancesClient, err := compute.NewInstancesRESTClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Instances client: %w", err)
+	}
+	disksClient, err := compute.NewDisksRESTClient(ctx, opts...)
+	if err != nil {
+		_ = instancesClient.Close()
+		return nil, fmt.Errorf("failed to create Disks client: %w", err)
+	}
+	repo := newDiskRepository(logger, instancesClient, disksClient)
+	repo.timeouts = settings.Timeouts
+	repo.events = settings.OperationEvents
+	return repo, nil
+}
+
+// newDiskRepository allows tests to inject GCP clients.
+func newDiskRepository(logger log.Logger, instancesClient diskInstancesClient, disksClient disksClient) *DiskRepository {
+	return &DiskRepository{logger: logger, instancesClient: instancesClient, disksClient: disksClient}
+}
+
+// Close releases the GCP clients held by the repository.
+func (r *DiskRepository) Close() error {
+	var firstErr error
+	if err := r.instancesClient.Close(); err != nil {
+		r.logger.Errorf("Failed to close Instances client: %v", err)
+		firstErr = err
+	}
+	if err := r.disksClient.Close(); err != nil {
+		r.logger.Errorf("Failed to close Disks client: %v", err)
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// List returns the disks attached to vm in attachment order (boot disk first),
+// with the disk type looked up for each persistent disk.
+func (r *DiskRepository) List(ctx context.Context, vm *model.VM) ([]*model.Disk, error) {
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	instance, err := r.instancesClient.Get(callCtx, &computepb.GetInstanceRequest{
+		Project:  vm.Project,
+		Zone:     vm.Zone,
+		Instance: vm.Name,
+	})
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get instance %s: %w", vm.Name, asAPIError(err))
+	}
+
+	attached := extractDisks(instance, vm.Zone)
+	disks := make([]*model.Disk, 0, len(attached))
+	for i := range attached {
+		d := attached[i]
+		if d.Name != "" {
+			callCtx, cancel := r.timeouts.callContext(ctx)
+			disk, getErr := r.disksClient.Get(callCtx, &computepb.GetDiskRequest{
+				Project: vm.Project,
+				Zone:    vm.Zone,
+				Disk:    d.Name,
+			})
+			cancel()
+			if getErr != nil {
+				return nil, fmt.Errorf("failed to get disk %s: %w", d.Name, asAPIError(getErr))
+			}
+			d.Type = policyNameOf(disk.GetType())
+			d.SizeGB = disk.GetSizeGb()
+		}
+		disks = append(disks, &d)
+	}
+	return disks, nil
+}
+
+// Resize grows the persistent disk diskName in vm's zone to sizeGB.
+func (r *DiskRepository) Resize(ctx context.Context, vm *model.VM, diskName string, sizeGB int64) error {
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	op, err := r.disksClient.Resize(callCtx, &computepb.ResizeDiskRequest{
+		Project: vm.Project,
+		Zone:    vm.Zone,
+		Disk:    diskName,
+		DisksResizeRequestResource: &computepb.DisksResizeRequest{
+			SizeGb: proto.Int64(sizeGB),
+		},
+	})
+	cancel()
+	if err != nil {
+		r.logger.Errorf("Failed to resize disk: %v", err)
+		return fmt.Errorf("failed to resize disk %s: %w", diskName, asAPIError(err))
+	}
+
+	r.logger.Infof("Resizing disk %s to %d GB", diskName, sizeGB)
+
+	return r.waitOperator(ctx, op)
+}
+
+// Attach attaches the existing persistent disk diskName in vm's zone to vm.
+// The disk's name is used as its device name.
+func (r *DiskRepository) Attach(ctx context.Context, vm *model.VM, diskName string, readOnly bool) error {
+	mode := "READ_WRITE"
+	if readOnly {
+		mode = "READ_ONLY"
+	}
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	op, err := r.instancesClient.AttachDisk(callCtx, &computepb.AttachDiskInstanceRequest{
+		Project:  vm.Project,
+		Zone:     vm.Zone,
+		Instance: vm.Name,
+		AttachedDiskResource: &computepb.AttachedDisk{
+			Source:     proto.String(fmt.Sprintf("projects/%s/zones/%s/disks/%s", vm.Project, vm.Zone, diskName)),
+			DeviceName: proto.String(diskName),
+			Mode:       proto.String(mode),
+		},
+	})
+	cancel()
+	if err != nil {
+		r.logger.Errorf("Failed to attach disk: %v", err)
+		return fmt.Errorf("failed to attach disk %s: %w", diskName, asAPIError(err))
+	}
+
+	r.logger.Infof("Attaching disk %s to instance %s", diskName, vm.Name)
+
+	return r.waitOperator(ctx, op)
+}
+
+// Detach detaches the disk with deviceName from vm.
+func (r *DiskRepository) Detach(ctx context.Context, vm *model.VM, deviceName string) error {
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	op, err := r.instancesClient.DetachDisk(callCtx, &computepb.DetachDiskInstanceRequest{
+		Project:    vm.Project,
+		Zone:       vm.Zone,
+		Instance:   vm.Name,
+		DeviceName: deviceName,
+	})
+	cancel()
+	if err != nil {
+		r.logger.Errorf("Failed to detach disk: %v", err)
+		return fmt.Errorf("failed to detach disk %s: %w", deviceName, asAPIError(err))
+	}
+
+	r.logger.Infof("Detaching disk %s from instance %s", deviceName, vm.Name)
+
+	return r.waitOperator(ctx, op)
+}
+
+func (r *DiskRepository) waitOperator(ctx context.Context, op *compute.Operation) error {
+	if op == nil {
+		return fmt.Errorf("operation is nil")
+	}
+	ctx, cancel := r.timeouts.operationContext(ctx)
+	defer cancel()
+	if err := waitObserved(ctx, r.events, op); err != nil {
+		r.logger.Errorf("failed to wait for operation: %v", err)
+		return fmt.Errorf("operation failed: %w", asAPIError(err))
+	}
+	return nil
+}
+
+// extractDisks returns the disks attached to the instance as recorded on the
+// instance resource. Disk types are not part of it and are left empty.
+func extractDisks(instance *computepb.Instance, zone string) []model.Disk {
+	attached := instance.GetDisks()
+	if len(attached) == 0 {
+		return nil
+	}
+	disks := make([]model.Disk, 0, len(attached))
+	for _, ad := range attached {
+		disks = append(disks, model.Disk{
+			Name:       policyNameOf(ad.GetSource()),
+			Zone:       zone,
+			DeviceName: ad.GetDeviceName(),
+			Mode:       ad.GetMode(),
+			SizeGB:     ad.GetDiskSizeGb(),
+			Boot:       ad.GetBoot(),
+			AutoDelete: ad.GetAutoDelete(),
+		})
+	}
+	return disks
+}
+
+var _ repository.DiskRepository = (*DiskRepository)(nil)
@@ -0,0 +1,96 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+type fakeDiskInstancesClient struct {
+	instance *computepb.Instance
+}
+
+func (c *fakeDiskInstancesClient) Get(context.Context, *computepb.GetInstanceRequest, ...gax.CallOption) (*computepb.Instance, error) {
+	return c.instance, nil
+}
+
+func (c *fakeDiskInstancesClient) AttachDisk(context.Context, *computepb.AttachDiskInstanceRequest, ...gax.CallOption) (*compute.Operation, error) {
+	return nil, nil
+}
+
+func (c *fakeDiskInstancesClient) DetachDisk(context.Context, *computepb.DetachDiskInstanceRequest, ...gax.CallOption) (*compute.Operation, error) {
+	return nil, nil
+}
+
+func (c *fakeDiskInstancesClient) Close() error {
+	return nil
+}
+
+type fakeDisksClient struct {
+	disks  map[string]*computepb.Disk
+	closed bool
+}
+
+func (c *fakeDisksClient) Get(_ context.Context, req *computepb.GetDiskRequest, _ ...gax.CallOption) (*computepb.Disk, error) {
+	disk, ok := c.disks[req.GetDisk()]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return disk, nil
+}
+
+func (c *fakeDisksClient) Resize(context.Context, *computepb.ResizeDiskRequest, ...gax.CallOption) (*compute.Operation, error) {
+	return nil, nil
+}
+
+func (c *fakeDisksClient) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestDiskRepositoryList(t *testing.T) {
+	instancesClient := &fakeDiskInstancesClient{instance: &computepb.Instance{
+		Disks: []*computepb.AttachedDisk{
+			{
+				Source:     stringPtr("https://www.googleapis.com/compute/v1/projects/proj/zones/us-central1-a/disks/vm-1"),
+				DeviceName: stringPtr("persistent-disk-0"),
+				Mode:       stringPtr("READ_WRITE"),
+				DiskSizeGb: proto.Int64(10),
+				Boot:       proto.Bool(true),
+				AutoDelete: proto.Bool(true),
+			},
+			{
+				Source:     stringPtr("projects/proj/zones/us-central1-a/disks/data-1"),
+				DeviceName: stringPtr("data-1"),
+				Mode:       stringPtr("READ_ONLY"),
+			},
+		},
+	}}
+	disksClient := &fakeDisksClient{disks: map[string]*computepb.Disk{
+		"vm-1":   {Type: stringPtr("projects/proj/zones/us-central1-a/diskTypes/pd-balanced"), SizeGb: proto.Int64(20)},
+		"data-1": {Type: stringPtr("projects/proj/zones/us-central1-a/diskTypes/pd-ssd"), SizeGb: proto.Int64(200)},
+	}}
+	repo := newDiskRepository(log.NewLogger(), instancesClient, disksClient)
+
+	got, err := repo.List(context.Background(), &model.VM{Name: "vm-1", Project: "proj", Zone: "us-central1-a"})
+	require.NoError(t, err)
+	require.Equal(t, []*model.Disk{
+		{Name: "vm-1", Zone: "us-central1-a", DeviceName: "persistent-disk-0", Type: "pd-balanced", Mode: "READ_WRITE", SizeGB: 20, Boot: true, AutoDelete: true},
+		{Name: "data-1", Zone: "us-central1-a", DeviceName: "data-1", Type: "pd-ssd", Mode: "READ_ONLY", SizeGB: 200},
+	}, got, "type and size come from the disk resource")
+
+	delete(disksClient.disks, "data-1")
+	_, err = repo.List(context.Background(), &model.VM{Name: "vm-1", Project: "proj", Zone: "us-central1-a"})
+	require.Error(t, err)
+
+	require.NoError(t, repo.Close())
+	require.True(t, disksClient.closed)
+}
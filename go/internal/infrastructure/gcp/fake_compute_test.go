@@ -0,0 +1,118 @@
+package gcp
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/fakecompute"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// newFakeCompute serves a fake Compute API with two VMs and a schedule policy
+// and returns settings pointing the repositories at it.
+func newFakeCompute(t *testing.T) (*fakecompute.Server, ClientSettings) {
+	t.Helper()
+	fake := fakecompute.New()
+	fake.AddInstance("proj", "us-central1-a", &computepb.Instance{Name: proto.String("sandbox"), Labels: map[string]string{"env": "dev"}})
+	fake.AddInstance("proj", "us-central1-b", &computepb.Instance{Name: proto.String("trainer"), Status: proto.String("RUNNING"), MachineType: proto.String("n1-standard-8")})
+	fake.AddResourcePolicy("proj", "us-central1", &computepb.ResourcePolicy{
+		Name: proto.String("weekday-stop"),
+		InstanceSchedulePolicy: &computepb.ResourcePolicyInstanceSchedulePolicy{
+			TimeZone:       proto.String("Asia/Tokyo"),
+			VmStopSchedule: &computepb.ResourcePolicyInstanceSchedulePolicySchedule{Schedule: proto.String("0 20 * * 1-5")},
+		},
+	})
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return fake, ClientSettings{ComputeEndpoint: server.URL}
+}
+
+func TestVMRepositoryAgainstFakeCompute(t *testing.T) {
+	ctx := context.Background()
+	fake, settings := newFakeCompute(t)
+	repo, err := NewVMRepository(ctx, log.NewLogger(), settings)
+	require.NoError(t, err)
+	defer func() { _ = repo.Close() }()
+
+	sandbox := &model.VM{Project: "proj", Zone: "us-central1-a", Name: "sandbox"}
+	found, err := repo.FindByName(ctx, sandbox)
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusTerminated, found.Status)
+	assert.Equal(t, "e2-medium", found.MachineType)
+
+	require.NoError(t, repo.Start(ctx, sandbox))
+	require.NoError(t, repo.SetSchedulePolicy(ctx, sandbox, "weekday-stop"))
+	require.NoError(t, repo.UpdateLabels(ctx, sandbox, map[string]string{"owner": "alice"}, nil))
+	found, err = repo.FindByName(ctx, sandbox)
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusRunning, found.Status)
+	assert.NotNil(t, found.LastStartTime)
+	assert.Equal(t, "weekday-stop(0 20 * * 1-5)", found.SchedulePolicy)
+	assert.Equal(t, map[string]string{"env": "dev", "owner": "alice"}, found.Labels)
+
+	all, err := repo.FindAll(ctx, []*model.VM{sandbox, {Project: "proj", Zone: "us-central1-b", Name: "trainer"}, {Project: "proj", Zone: "us-central1-a", Name: "missing"}})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, "sandbox", all[0].Name)
+	assert.Equal(t, "n1-standard-8", all[1].MachineType)
+	assert.Nil(t, all[2])
+
+	listed, err := repo.ListByProject(ctx, "proj", map[string]string{"env": "dev"})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, "sandbox", listed[0].Name)
+
+	require.NoError(t, repo.Stop(ctx, sandbox))
+	assert.Equal(t, "TERMINATED", fake.Instance("proj", "us-central1-a", "sandbox").GetStatus())
+
+	_, err = repo.FindByName(ctx, &model.VM{Project: "proj", Zone: "us-central1-a", Name: "missing"})
+	require.Error(t, err)
+}
+
+func TestOperationRepositoryAgainstFakeCompute(t *testing.T) {
+	ctx := context.Background()
+	_, settings := newFakeCompute(t)
+	vms, err := NewVMRepository(ctx, log.NewLogger(), settings)
+	require.NoError(t, err)
+	defer func() { _ = vms.Close() }()
+	ops, err := NewOperationRepository(ctx, log.NewLogger(), settings)
+	require.NoError(t, err)
+	defer func() { _ = ops.Close() }()
+
+	trainer := &model.VM{Project: "proj", Zone: "us-central1-b", Name: "trainer"}
+	require.NoError(t, vms.Stop(ctx, trainer))
+	require.NoError(t, vms.Start(ctx, trainer))
+
+	listed, err := ops.ListByTarget(ctx, trainer, 10)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Equal(t, "start", listed[0].Type, "newest first")
+	assert.Equal(t, "stop", listed[1].Type)
+	assert.Equal(t, fakecompute.User, listed[0].User)
+
+	events, err := ops.ListSystemEvents(ctx, trainer, 10)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestSchedulePolicyRepositoryAgainstFakeCompute(t *testing.T) {
+	ctx := context.Background()
+	_, settings := newFakeCompute(t)
+	repo, err := NewSchedulePolicyRepository(ctx, log.NewLogger(), settings)
+	require.NoError(t, err)
+	defer func() { _ = repo.Close() }()
+
+	require.NoError(t, repo.Create(ctx, &model.SchedulePolicy{Name: "nightly", Project: "proj", Region: "us-central1", StopCron: "0 0 * * *", TimeZone: "UTC"}))
+	policies, err := repo.List(ctx, "proj", "us-central1")
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	assert.Equal(t, "nightly", policies[0].Name)
+	assert.Equal(t, "0 0 * * *", policies[0].StopCron)
+	assert.Equal(t, "weekday-stop", policies[1].Name)
+}
@@ -0,0 +1,175 @@
+package gcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	logging "google.golang.org/api/logging/v2"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// maxLogPageSize is the largest page size accepted by entries.list.
+const maxLogPageSize = 1000
+
+type logEntriesClient interface {
+	List(ctx context.Context, req *logging.ListLogEntriesRequest) (*logging.ListLogEntriesResponse, error)
+}
+
+// entriesService adapts the generated Cloud Logging entries service to logEntriesClient.
+type entriesService struct {
+	entries *logging.EntriesService
+}
+
+func (s entriesService) List(ctx context.Context, req *logging.ListLogEntriesRequest) (*logging.ListLogEntriesResponse, error) {
+	return s.entries.List(req).Context(ctx).Do()
+}
+
+// LogRepository implements the repository.LogRepository interface with Cloud Logging.
+//
+//nolint:govet // Field order optimized for readability over memory alignment
+type LogRepository struct {
+	logger   log.Logger
+	timeouts Timeouts
+
+	entriesClient logEntriesClient
+}
+
+// NewLogRepository creates a LogRepository with a Cloud Logging client initialized from ctx and settings.
+func NewLogRepository(ctx context.Context, logger log.Logger, settings ClientSettings) (*LogRepository, error) {
+	opts, err := settings.ClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	service, err := logging.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Logging client: %w", err)
+	}
+	repo := newLogRepository(logger, entriesService{entries: service.Entries})
+	repo.timeouts = settings.Timeouts
+	return repo, nil
+}
+
+// newLogRepository allows tests to inject GCP clients.
+func newLogRepository(logger log.Logger, entriesClient logEntriesClient) *LogRepository {
+	return &LogRepository{logger: logger, entriesClient: entriesClient}
+}
+
+// Close is a no-op; the Cloud Logging REST client holds no resources to release.
+func (r *LogRepository) Close() error {
+	return nil
+}
+
+// List returns up to limit of the most recent log entries of the gce_instance
+// resource with vm.ID written since since, oldest first.
+func (r *LogRepository) List(ctx context.Context, vm *model.VM, since time.Time, filter string, limit int) ([]*model.LogEntry, error) {
+	if vm.ID == "" {
+		return nil, fmt.Errorf("instance ID of %s is unknown", vm.Name)
+	}
+
+	// 新しい順に取得して limit 件で打ち切り、最後に古い順へ並べ替える
+	req := &logging.ListLogEntriesRequest{
+		ResourceNames: []string{"projects/" + vm.Project},
+		Filter:        instanceLogFilter(vm.ID, since, filter),
+		OrderBy:       "timestamp desc",
+		PageSize:      int64(min(limit, maxLogPageSize)),
+	}
+	entries := make([]*model.LogEntry, 0, req.PageSize)
+	for len(entries) < limit {
+		callCtx, cancel := r.timeouts.callContext(ctx)
+		resp, err := r.entriesClient.List(callCtx, req)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list log entries of %s: %w", vm.Name, asAPIError(err))
+		}
+		for _, e := range resp.Entries {
+			if len(entries) == limit {
+				break
+			}
+			entries = append(entries, toLogEntry(e))
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
+
+// instanceLogFilter returns the Cloud Logging filter for the entries of instance
+// instanceID since since, narrowed by the user's filter if given.
+func instanceLogFilter(instanceID string, since time.Time, filter string) string {
+	f := fmt.Sprintf(`resource.type="gce_instance" AND resource.labels.instance_id="%s" AND timestamp>="%s"`,
+		instanceID, since.UTC().Format(time.RFC3339))
+	if filter = strings.TrimSpace(filter); filter != "" {
+		f += " AND (" + filter + ")"
+	}
+	return f
+}
+
+// toLogEntry converts a Cloud Logging entry. The message is the text payload, the
+// "message" field of a JSON payload, or the whole JSON payload.
+func toLogEntry(e *logging.LogEntry) *model.LogEntry {
+	entry := &model.LogEntry{
+		Severity: e.Severity,
+		Log:      logNameOf(e.LogName),
+		Message:  e.TextPayload,
+	}
+	if entry.Severity == "" {
+		entry.Severity = "DEFAULT"
+	}
+	if t, err := time.Parse(time.RFC3339Nano, e.Timestamp); err == nil {
+		entry.Timestamp = t
+	}
+	if entry.Message == "" {
+		entry.Message = payloadMessage(e.JsonPayload)
+	}
+	if entry.Message == "" {
+		entry.Message = payloadMessage(e.ProtoPayload)
+	}
+	return entry
+}
+
+// payloadMessage returns the "message" field of a JSON payload, or the compacted payload.
+func payloadMessage(payload []byte) string {
+	if len(payload) == 0 {
+		return ""
+	}
+	var fields struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(payload, &fields); err == nil && fields.Message != "" {
+		return fields.Message
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, payload); err != nil {
+		return string(payload)
+	}
+	return compact.String()
+}
+
+// logNameOf returns the short name of a log, e.g. "syslog" for
+// "projects/p/logs/syslog" or "cloudaudit.googleapis.com/activity" for its URL-encoded form.
+func logNameOf(logName string) string {
+	_, name, found := strings.Cut(logName, "/logs/")
+	if !found {
+		return logName
+	}
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		return unescaped
+	}
+	return name
+}
+
+var _ repository.LogRepository = (*LogRepository)(nil)
@@ -0,0 +1,69 @@
+package gcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/stretchr/testify/require"
+	logging "google.golang.org/api/logging/v2"
+)
+
+type fakeLogEntriesClient struct {
+	pages []*logging.ListLogEntriesResponse
+	reqs  []logging.ListLogEntriesRequest
+}
+
+func (c *fakeLogEntriesClient) List(_ context.Context, req *logging.ListLogEntriesRequest) (*logging.ListLogEntriesResponse, error) {
+	c.reqs = append(c.reqs, *req)
+	page := c.pages[0]
+	c.pages = c.pages[1:]
+	return page, nil
+}
+
+func TestInstanceLogFilter(t *testing.T) {
+	since := time.Date(2025, 1, 2, 15, 4, 5, 0, time.FixedZone("JST", 9*60*60))
+	require.Equal(t,
+		`resource.type="gce_instance" AND resource.labels.instance_id="123" AND timestamp>="2025-01-02T06:04:05Z"`,
+		instanceLogFilter("123", since, " "))
+	require.Equal(t,
+		`resource.type="gce_instance" AND resource.labels.instance_id="123" AND timestamp>="2025-01-02T06:04:05Z" AND (severity>=ERROR OR textPayload:"oom")`,
+		instanceLogFilter("123", since, `severity>=ERROR OR textPayload:"oom"`))
+}
+
+func TestLogRepositoryList(t *testing.T) {
+	client := &fakeLogEntriesClient{pages: []*logging.ListLogEntriesResponse{
+		{
+			Entries: []*logging.LogEntry{
+				{Timestamp: "2025-01-02T06:00:03.5Z", Severity: "ERROR", LogName: "projects/proj/logs/syslog", TextPayload: "disk full"},
+				{Timestamp: "2025-01-02T06:00:02Z", LogName: "projects/proj/logs/app", JsonPayload: []byte(`{"message": "started", "port": 8080}`)},
+			},
+			NextPageToken: "next",
+		},
+		{
+			Entries: []*logging.LogEntry{
+				{Timestamp: "2025-01-02T06:00:01Z", Severity: "NOTICE", LogName: "projects/proj/logs/cloudaudit.googleapis.com%2Factivity", ProtoPayload: []byte(`{"methodName": "v1.compute.instances.start"}`)},
+				{Timestamp: "2025-01-02T06:00:00Z", Severity: "INFO", LogName: "projects/proj/logs/syslog", TextPayload: "beyond the limit"},
+			},
+		},
+	}}
+	repo := newLogRepository(log.NewLogger(), client)
+
+	got, err := repo.List(context.Background(), &model.VM{ID: "123", Name: "sandbox", Project: "proj"}, time.Now(), "", 3)
+	require.NoError(t, err)
+	require.Equal(t, []*model.LogEntry{
+		{Timestamp: time.Date(2025, 1, 2, 6, 0, 1, 0, time.UTC), Severity: "NOTICE", Log: "cloudaudit.googleapis.com/activity", Message: `{"methodName":"v1.compute.instances.start"}`},
+		{Timestamp: time.Date(2025, 1, 2, 6, 0, 2, 0, time.UTC), Severity: "DEFAULT", Log: "app", Message: "started"},
+		{Timestamp: time.Date(2025, 1, 2, 6, 0, 3, 500000000, time.UTC), Severity: "ERROR", Log: "syslog", Message: "disk full"},
+	}, got, "the most recent entries up to the limit, oldest first")
+	require.Len(t, client.reqs, 2)
+	require.Equal(t, []string{"projects/proj"}, client.reqs[0].ResourceNames)
+	require.Equal(t, "timestamp desc", client.reqs[0].OrderBy)
+	require.Equal(t, int64(3), client.reqs[0].PageSize)
+	require.Equal(t, "next", client.reqs[1].PageToken)
+
+	_, err = repo.List(context.Background(), &model.VM{Name: "sandbox", Project: "proj"}, time.Now(), "", 3)
+	require.ErrorContains(t, err, "instance ID of sandbox is unknown")
+}
@@ -0,0 +1,57 @@
+package gcp
+
+import (
+	"context"
+	"testing"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMachineImagesClient struct {
+	req *computepb.InsertMachineImageRequest
+}
+
+func (c *fakeMachineImagesClient) Insert(_ context.Context, req *computepb.InsertMachineImageRequest, _ ...gax.CallOption) (*compute.Operation, error) {
+	c.req = req
+	return nil, nil
+}
+
+func (c *fakeMachineImagesClient) Close() error {
+	return nil
+}
+
+type fakeInstanceInserter struct {
+	req *computepb.InsertInstanceRequest
+}
+
+func (c *fakeInstanceInserter) Insert(_ context.Context, req *computepb.InsertInstanceRequest, _ ...gax.CallOption) (*compute.Operation, error) {
+	c.req = req
+	return nil, nil
+}
+
+func (c *fakeInstanceInserter) Close() error {
+	return nil
+}
+
+func TestMachineImageRepositoryRequests(t *testing.T) {
+	images := &fakeMachineImagesClient{}
+	instances := &fakeInstanceInserter{}
+	repo := newMachineImageRepository(log.NewLogger(), images, instances)
+
+	source := &model.VM{Name: "sandbox", Project: "proj", Zone: "us-central1-a"}
+	require.Error(t, repo.Create(context.Background(), source, "sandbox-20250102-060405"), "a nil operation cannot be waited for")
+	require.Equal(t, "proj", images.req.GetProject())
+	require.Equal(t, "sandbox-20250102-060405", images.req.GetMachineImageResource().GetName())
+	require.Equal(t, "projects/proj/zones/us-central1-a/instances/sandbox", images.req.GetMachineImageResource().GetSourceInstance())
+
+	target := &model.VM{Name: "sandbox-copy", Project: "proj", Zone: "asia-northeast1-a"}
+	require.Error(t, repo.CreateInstance(context.Background(), "sandbox-20250102-060405", target))
+	require.Equal(t, "asia-northeast1-a", instances.req.GetZone())
+	require.Equal(t, "sandbox-copy", instances.req.GetInstanceResource().GetName())
+	require.Equal(t, "projects/proj/global/machineImages/sandbox-20250102-060405", instances.req.GetSourceMachineImage())
+}
@@ -0,0 +1,103 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/iterator"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+type machineTypesClient interface {
+	List(context.Context, *computepb.ListMachineTypesRequest, ...gax.CallOption) *compute.MachineTypeIterator
+	Close() error
+}
+
+// MachineTypeRepository implements the repository.MachineTypeRepository interface for GCP.
+//
+//nolint:govet // Field order optimized for readability over memory alignment
+type MachineTypeRepository struct {
+	logger   log.Logger
+	timeouts Timeouts
+
+	machineTypesClient machineTypesClient
+}
+
+// NewMachineTypeRepository creates a MachineTypeRepository with a GCP client initialized from ctx and settings.
+// The returned repository owns the client and must be closed by the caller.
+func NewMachineTypeRepository(ctx context.Context, logger log.Logger, settings ClientSettings) (*MachineTypeRepository, error) {
+	opts, err := settings.ComputeClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	machineTypesClient, err := compute.NewMachineTypesRESTClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create MachineTypes client: %w", err)
+	}
+	repo := newMachineTypeRepository(logger, machineTypesClient)
+	repo.timeouts = settings.Timeouts
+	return repo, nil
+}
+
+// newMachineTypeRepository allows tests to inject GCP clients.
+func newMachineTypeRepository(logger log.Logger, machineTypesClient machineTypesClient) *MachineTypeRepository {
+	return &MachineTypeRepository{logger: logger, machineTypesClient: machineTypesClient}
+}
+
+// Close releases the GCP client held by the repository.
+func (r *MachineTypeRepository) Close() error {
+	if err := r.machineTypesClient.Close(); err != nil {
+		r.logger.Errorf("Failed to close MachineTypes client: %v", err)
+		return err
+	}
+	return nil
+}
+
+// List returns the non-deprecated machine types available in project/zone.
+func (r *MachineTypeRepository) List(ctx context.Context, project, zone string) ([]*model.MachineType, error) {
+	ctx, cancel := r.timeouts.callContext(ctx)
+	defer cancel()
+	it := r.machineTypesClient.List(ctx, &computepb.ListMachineTypesRequest{
+		Project: project,
+		Zone:    zone,
+	})
+	machineTypes, err := collectMachineTypes(it.Next, zone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list machine types in %s/%s: %w", project, zone, asAPIError(err))
+	}
+	return machineTypes, nil
+}
+
+// collectMachineTypes drains a machine type iterator, skipping deprecated machine types.
+func collectMachineTypes(next func() (*computepb.MachineType, error), zone string) ([]*model.MachineType, error) {
+	machineTypes := make([]*model.MachineType, 0)
+	for {
+		mt, err := next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if mt.GetDeprecated().GetState() != "" {
+			continue
+		}
+		machineTypes = append(machineTypes, &model.MachineType{
+			Name:        mt.GetName(),
+			Zone:        zone,
+			Description: mt.GetDescription(),
+			GuestCPUs:   int(mt.GetGuestCpus()),
+			MemoryMB:    int(mt.GetMemoryMb()),
+		})
+	}
+	return machineTypes, nil
+}
+
+var _ repository.MachineTypeRepository = (*MachineTypeRepository)(nil)
@@ -0,0 +1,38 @@
+package gcp
+
+import (
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCollectMachineTypes(t *testing.T) {
+	machineTypes := []*computepb.MachineType{
+		{Name: stringPtr("e2-medium"), Description: stringPtr("2 vCPUs 4 GB RAM"), GuestCpus: proto.Int32(2), MemoryMb: proto.Int32(4096)},
+		{Name: stringPtr("n1-old"), Deprecated: &computepb.DeprecationStatus{State: stringPtr("DEPRECATED")}},
+	}
+	i := 0
+	next := func() (*computepb.MachineType, error) {
+		if i < len(machineTypes) {
+			i++
+			return machineTypes[i-1], nil
+		}
+		return nil, iterator.Done
+	}
+
+	got, err := collectMachineTypes(next, "us-central1-a")
+	require.NoError(t, err)
+	require.Equal(t, []*model.MachineType{
+		{Name: "e2-medium", Zone: "us-central1-a", Description: "2 vCPUs 4 GB RAM", GuestCPUs: 2, MemoryMB: 4096},
+	}, got, "deprecated machine types are skipped")
+
+	_, err = collectMachineTypes(func() (*computepb.MachineType, error) {
+		return nil, errors.New("denied")
+	}, "us-central1-a")
+	require.Error(t, err)
+}
@@ -0,0 +1,220 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	monitoring "google.golang.org/api/monitoring/v3"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// maxAlignmentPeriod caps the alignment period. Longer windows are split into several
+// aligned points that are averaged, as timeSeries.list accepts periods up to 25 hours only.
+const maxAlignmentPeriod = time.Hour
+
+// timeSeriesQuery is a timeSeries.list request that aligns each series with aligner
+// and combines the series of an instance with reducer.
+type timeSeriesQuery struct {
+	start   time.Time
+	end     time.Time
+	project string
+	filter  string
+	aligner string
+	reducer string
+	period  time.Duration
+}
+
+type timeSeriesClient interface {
+	List(ctx context.Context, q timeSeriesQuery) ([]*monitoring.TimeSeries, error)
+}
+
+// timeSeriesService adapts the generated Cloud Monitoring time series service to timeSeriesClient.
+type timeSeriesService struct {
+	timeSeries *monitoring.ProjectsTimeSeriesService
+}
+
+func (s timeSeriesService) List(ctx context.Context, q timeSeriesQuery) ([]*monitoring.TimeSeries, error) {
+	var series []*monitoring.TimeSeries
+	err := s.timeSeries.List("projects/"+q.project).
+		Filter(q.filter).
+		IntervalStartTime(q.start.UTC().Format(time.RFC3339)).
+		IntervalEndTime(q.end.UTC().Format(time.RFC3339)).
+		AggregationAlignmentPeriod(fmt.Sprintf("%ds", int64(q.period.Seconds()))).
+		AggregationPerSeriesAligner(q.aligner).
+		AggregationCrossSeriesReducer(q.reducer).
+		AggregationGroupByFields("resource.label.instance_id").
+		Pages(ctx, func(resp *monitoring.ListTimeSeriesResponse) error {
+			series = append(series, resp.TimeSeries...)
+			return nil
+		})
+	return series, err
+}
+
+// instanceMetric describes how one field of model.InstanceMetrics is queried.
+type instanceMetric struct {
+	metricType string
+	// extraFilter narrows the series further, e.g. to one label value.
+	extraFilter string
+	aligner     string
+	reducer     string
+	// scale converts the metric's unit to the unit of the field.
+	scale  float64
+	target func(*model.InstanceMetrics) **float64
+}
+
+var instanceMetrics = []instanceMetric{
+	{
+		metricType: "compute.googleapis.com/instance/cpu/utilization",
+		aligner:    "ALIGN_MEAN", reducer: "REDUCE_MEAN", scale: 1,
+		target: func(m *model.InstanceMetrics) **float64 { return &m.CPUUtilization },
+	},
+	{
+		metricType:  "agent.googleapis.com/memory/percent_used",
+		extraFilter: `metric.label.state="used"`,
+		aligner:     "ALIGN_MEAN", reducer: "REDUCE_MEAN", scale: 0.01,
+		target: func(m *model.InstanceMetrics) **float64 { return &m.MemoryUtilization },
+	},
+	{
+		metricType: "compute.googleapis.com/instance/disk/read_bytes_count",
+		aligner:    "ALIGN_RATE", reducer: "REDUCE_SUM", scale: 1,
+		target: func(m *model.InstanceMetrics) **float64 { return &m.DiskReadBytesPerSec },
+	},
+	{
+		metricType: "compute.googleapis.com/instance/disk/write_bytes_count",
+		aligner:    "ALIGN_RATE", reducer: "REDUCE_SUM", scale: 1,
+		target: func(m *model.InstanceMetrics) **float64 { return &m.DiskWriteBytesPerSec },
+	},
+	{
+		metricType: "compute.googleapis.com/instance/network/received_bytes_count",
+		aligner:    "ALIGN_RATE", reducer: "REDUCE_SUM", scale: 1,
+		target: func(m *model.InstanceMetrics) **float64 { return &m.NetworkInBytesPerSec },
+	},
+	{
+		metricType: "compute.googleapis.com/instance/network/sent_bytes_count",
+		aligner:    "ALIGN_RATE", reducer: "REDUCE_SUM", scale: 1,
+		target: func(m *model.InstanceMetrics) **float64 { return &m.NetworkOutBytesPerSec },
+	},
+}
+
+// MetricsRepository implements the repository.MetricsRepository interface with Cloud Monitoring.
+//
+//nolint:govet // Field order optimized for readability over memory alignment
+type MetricsRepository struct {
+	logger   log.Logger
+	timeouts Timeouts
+
+	timeSeriesClient timeSeriesClient
+}
+
+// NewMetricsRepository creates a MetricsRepository with a Cloud Monitoring client initialized from ctx and settings.
+func NewMetricsRepository(ctx context.Context, logger log.Logger, settings ClientSettings) (*MetricsRepository, error) {
+	opts, err := settings.ClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	service, err := monitoring.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Monitoring client: %w", err)
+	}
+	repo := newMetricsRepository(logger, timeSeriesService{timeSeries: service.Projects.TimeSeries})
+	repo.timeouts = settings.Timeouts
+	return repo, nil
+}
+
+// newMetricsRepository allows tests to inject GCP clients.
+func newMetricsRepository(logger log.Logger, timeSeriesClient timeSeriesClient) *MetricsRepository {
+	return &MetricsRepository{logger: logger, timeSeriesClient: timeSeriesClient}
+}
+
+// Close is a no-op; the Cloud Monitoring REST client holds no resources to release.
+func (r *MetricsRepository) Close() error {
+	return nil
+}
+
+// Average returns the average resource usage between start and end of the gce_instance
+// resources with the given instance IDs, keyed by instance ID.
+func (r *MetricsRepository) Average(ctx context.Context, project string, instanceIDs []string, start, end time.Time) (map[string]*model.InstanceMetrics, error) {
+	result := make(map[string]*model.InstanceMetrics)
+	if len(instanceIDs) == 0 {
+		return result, nil
+	}
+
+	period := min(end.Sub(start), maxAlignmentPeriod).Truncate(time.Minute)
+	if period < time.Minute {
+		period = time.Minute
+	}
+	for _, metric := range instanceMetrics {
+		callCtx, cancel := r.timeouts.callContext(ctx)
+		series, err := r.timeSeriesClient.List(callCtx, timeSeriesQuery{
+			start:   start,
+			end:     end,
+			project: project,
+			filter:  instanceMetricFilter(metric, instanceIDs),
+			aligner: metric.aligner,
+			reducer: metric.reducer,
+			period:  period,
+		})
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to query %s: %w", metric.metricType, asAPIError(err))
+		}
+		for _, ts := range series {
+			avg, ok := averagePoints(ts.Points)
+			if !ok {
+				continue
+			}
+			id := ts.Resource.Labels["instance_id"]
+			if result[id] == nil {
+				result[id] = &model.InstanceMetrics{}
+			}
+			value := avg * metric.scale
+			*metric.target(result[id]) = &value
+		}
+	}
+	return result, nil
+}
+
+// instanceMetricFilter returns the Cloud Monitoring filter for metric of the given instances.
+func instanceMetricFilter(metric instanceMetric, instanceIDs []string) string {
+	quoted := make([]string, len(instanceIDs))
+	for i, id := range instanceIDs {
+		quoted[i] = fmt.Sprintf("%q", id)
+	}
+	f := fmt.Sprintf(`metric.type="%s" AND resource.type="gce_instance" AND resource.label.instance_id=one_of(%s)`,
+		metric.metricType, strings.Join(quoted, ","))
+	if metric.extraFilter != "" {
+		f += " AND " + metric.extraFilter
+	}
+	return f
+}
+
+// averagePoints returns the mean of the numeric values of points.
+func averagePoints(points []*monitoring.Point) (float64, bool) {
+	var sum float64
+	var n int
+	for _, p := range points {
+		if p.Value == nil {
+			continue
+		}
+		switch {
+		case p.Value.DoubleValue != nil:
+			sum += *p.Value.DoubleValue
+		case p.Value.Int64Value != nil:
+			sum += float64(*p.Value.Int64Value)
+		default:
+			continue
+		}
+		n++
+	}
+	if n == 0 {
+		return 0, false
+	}
+	return sum / float64(n), true
+}
+
+var _ repository.MetricsRepository = (*MetricsRepository)(nil)
@@ -0,0 +1,81 @@
+package gcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/stretchr/testify/require"
+	monitoring "google.golang.org/api/monitoring/v3"
+)
+
+type fakeTimeSeriesClient struct {
+	series  map[string][]*monitoring.TimeSeries
+	queries []timeSeriesQuery
+}
+
+func (c *fakeTimeSeriesClient) List(_ context.Context, q timeSeriesQuery) ([]*monitoring.TimeSeries, error) {
+	c.queries = append(c.queries, q)
+	for metricType, series := range c.series {
+		if strings.Contains(q.filter, `metric.type="`+metricType+`"`) {
+			return series, nil
+		}
+	}
+	return nil, nil
+}
+
+func doublePoints(values ...float64) []*monitoring.Point {
+	points := make([]*monitoring.Point, len(values))
+	for i := range values {
+		points[i] = &monitoring.Point{Value: &monitoring.TypedValue{DoubleValue: &values[i]}}
+	}
+	return points
+}
+
+func instanceSeries(id string, points []*monitoring.Point) *monitoring.TimeSeries {
+	return &monitoring.TimeSeries{
+		Resource: &monitoring.MonitoredResource{Labels: map[string]string{"instance_id": id}},
+		Points:   points,
+	}
+}
+
+func TestMetricsRepositoryAverage(t *testing.T) {
+	client := &fakeTimeSeriesClient{series: map[string][]*monitoring.TimeSeries{
+		"compute.googleapis.com/instance/cpu/utilization": {
+			instanceSeries("1", doublePoints(0.5, 0.7)),
+			instanceSeries("2", doublePoints(0.01)),
+		},
+		"agent.googleapis.com/memory/percent_used": {
+			instanceSeries("1", doublePoints(40)),
+		},
+		"compute.googleapis.com/instance/network/received_bytes_count": {
+			instanceSeries("2", nil),
+		},
+	}}
+	repo := newMetricsRepository(log.NewLogger(), client)
+
+	end := time.Date(2025, 1, 2, 6, 0, 0, 0, time.UTC)
+	got, err := repo.Average(context.Background(), "proj", []string{"1", "2"}, end.Add(-3*time.Hour), end)
+	require.NoError(t, err)
+
+	cpu1, mem1, cpu2 := 0.6, 0.4, 0.01
+	require.InDelta(t, cpu1, *got["1"].CPUUtilization, 1e-9)
+	require.InDelta(t, mem1, *got["1"].MemoryUtilization, 1e-9, "percent is converted to a fraction")
+	require.Equal(t, &model.InstanceMetrics{CPUUtilization: &cpu2}, got["2"], "series without points are left unknown")
+
+	require.Len(t, client.queries, len(instanceMetrics))
+	require.Equal(t, time.Hour, client.queries[0].period, "long windows are aligned hourly")
+	require.Equal(t,
+		`metric.type="agent.googleapis.com/memory/percent_used" AND resource.type="gce_instance" AND resource.label.instance_id=one_of("1","2") AND metric.label.state="used"`,
+		client.queries[1].filter)
+	require.Equal(t, "ALIGN_RATE", client.queries[2].aligner)
+	require.Equal(t, "REDUCE_SUM", client.queries[2].reducer)
+
+	got, err = repo.Average(context.Background(), "proj", nil, end.Add(-time.Hour), end)
+	require.NoError(t, err)
+	require.Empty(t, got)
+	require.Len(t, client.queries, len(instanceMetrics), "nothing is queried without instances")
+}
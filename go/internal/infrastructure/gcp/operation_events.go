@@ -0,0 +1,86 @@
+package gcp
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"github.com/googleapis/gax-go/v2"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// observedPollInterval caps the wait between polls of an operation whose events
+// are emitted, so its progress is reported more often than op.Wait would poll.
+const observedPollInterval = 5 * time.Second
+
+// emit sends ev to events unless events is nil. Once ctx is done, ev is only
+// sent when events has room, so that the command can still record an
+// interrupted wait from the buffer without blocking on a receiver that stopped.
+// The receiver is expected to drain events for as long as the repositories are used.
+func emit(ctx context.Context, events chan<- model.OperationEvent, ev model.OperationEvent) {
+	if events == nil || ev.Operation() == nil {
+		return
+	}
+	select {
+	case events <- ev:
+	case <-ctx.Done():
+		select {
+		case events <- ev:
+		default:
+		}
+	}
+}
+
+// waitObserved waits for op like op.Wait, emitting its events to events.
+func waitObserved(ctx context.Context, events chan<- model.OperationEvent, op *compute.Operation) (err error) {
+	if events == nil {
+		return op.Wait(ctx)
+	}
+	emit(ctx, events, model.OperationStarted{Op: observedOperation(op)})
+	defer func() {
+		emit(ctx, events, model.OperationDone{Op: observedOperation(op), Err: err})
+	}()
+
+	bo := gax.Backoff{Initial: time.Second, Max: observedPollInterval}
+	for {
+		if err := op.Poll(ctx); err != nil {
+			return err
+		}
+		if op.Done() {
+			return nil
+		}
+		current := observedOperation(op)
+		emit(ctx, events, model.OperationProgress{Op: current, Percent: current.Progress})
+		if err := gax.Sleep(ctx, bo.Pause()); err != nil {
+			return err
+		}
+	}
+}
+
+// observedOperation converts op for its events.
+// Regional operations report their region as the zone.
+func observedOperation(op *compute.Operation) *model.Operation {
+	if op == nil || op.Proto() == nil {
+		return nil
+	}
+	pb := op.Proto()
+	location := pb.GetZone()
+	if location == "" {
+		location = pb.GetRegion()
+	}
+	return operationToModel(pb, linkSegment(pb.GetSelfLink(), "projects"), location[strings.LastIndex(location, "/")+1:])
+}
+
+// linkSegment returns the path segment following key in a resource link such as
+// https://www.googleapis.com/compute/v1/projects/P/zones/Z/operations/O.
+func linkSegment(link, key string) string {
+	parts := strings.Split(link, "/")
+	for i := 0; i < len(parts)-1; i++ {
+		if parts[i] == key {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,42 @@
+package gcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmit(t *testing.T) {
+	op := &model.Operation{Name: "operation-1"}
+	events := make(chan model.OperationEvent, 1)
+
+	emit(context.Background(), events, model.OperationStarted{Op: op})
+	assert.Equal(t, model.OperationStarted{Op: op}, <-events)
+
+	// Nil channels and operations are ignored.
+	emit(context.Background(), nil, model.OperationStarted{Op: op})
+	emit(context.Background(), events, model.OperationStarted{})
+	assert.Empty(t, events)
+
+	// A full channel does not block a done context.
+	events <- model.OperationStarted{Op: op}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	emit(ctx, events, model.OperationDone{Op: op})
+	assert.Len(t, events, 1)
+
+	// A done context still buffers the event when there is room, so that an
+	// interrupted wait is recorded.
+	<-events
+	emit(ctx, events, model.OperationDone{Op: op})
+	assert.Equal(t, model.OperationDone{Op: op}, <-events)
+}
+
+func TestLinkSegment(t *testing.T) {
+	link := "https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/operations/operation-1"
+	assert.Equal(t, "my-project", linkSegment(link, "projects"))
+	assert.Equal(t, "us-central1-a", linkSegment(link, "zones"))
+	assert.Empty(t, linkSegment(link, "regions"))
+}
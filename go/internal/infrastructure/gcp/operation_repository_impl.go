@@ -0,0 +1,250 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/iterator"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+type zoneOperationsClient interface {
+	Get(context.Context, *computepb.GetZoneOperationRequest, ...gax.CallOption) (*computepb.Operation, error)
+	Wait(context.Context, *computepb.WaitZoneOperationRequest, ...gax.CallOption) (*computepb.Operation, error)
+	List(context.Context, *computepb.ListZoneOperationsRequest, ...gax.CallOption) *compute.OperationIterator
+	Close() error
+}
+
+// OperationRepository implements the repository.OperationRepository interface for GCP zonal operations.
+//
+//nolint:govet // Field order optimized for readability over memory alignment
+type OperationRepository struct {
+	logger   log.Logger
+	timeouts Timeouts
+	events   chan<- model.OperationEvent
+
+	zoneOperationsClient zoneOperationsClient
+}
+
+// NewOperationRepository creates an OperationRepository with a GCP client initialized from ctx and settings.
+// The returned repository owns the client and must be closed by the caller.
+func NewOperationRepository(ctx context.Context, logger log.Logger, settings ClientSettings) (*OperationRepository, error) {
+	opts, err := settings.ComputeClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	client, err := compute.NewZoneOperationsRESTClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ZoneOperations client: %w", err)
+	}
+	repo := newOperationRepository(logger, client)
+	repo.timeouts = settings.Timeouts
+	repo.events = settings.OperationEvents
+	return repo, nil
+}
+
+// newOperationRepository allows tests to inject GCP clients.
+func newOperationRepository(logger log.Logger, client zoneOperationsClient) *OperationRepository {
+	return &OperationRepository{logger: logger, zoneOperationsClient: client}
+}
+
+// Close releases the GCP client held by the repository.
+func (r *OperationRepository) Close() error {
+	if err := r.zoneOperationsClient.Close(); err != nil {
+		r.logger.Errorf("Failed to close ZoneOperations client: %v", err)
+		return err
+	}
+	return nil
+}
+
+// Wait blocks until the operation is DONE.
+// The server-side wait returns after at most ~2 minutes, so it is called repeatedly.
+func (r *OperationRepository) Wait(ctx context.Context, op *model.Operation) (*model.Operation, error) {
+	ctx, cancel := r.timeouts.operationContext(ctx)
+	defer cancel()
+	emit(ctx, r.events, model.OperationStarted{Op: op})
+	current, err := r.wait(ctx, op)
+	done := current
+	if done == nil {
+		done = op
+	}
+	emit(ctx, r.events, model.OperationDone{Op: done, Err: err})
+	return current, err
+}
+
+// wait polls op until it is DONE, emitting its progress.
+func (r *OperationRepository) wait(ctx context.Context, op *model.Operation) (*model.Operation, error) {
+	req := &computepb.WaitZoneOperationRequest{
+		Project:   op.Project,
+		Zone:      op.Zone,
+		Operation: op.Name,
+	}
+
+	for {
+		res, err := r.zoneOperationsClient.Wait(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to wait for operation %s: %w", op.Name, asAPIError(err))
+		}
+
+		current := operationToModel(res, op.Project, op.Zone)
+		r.logger.Debugf("Operation %s status: %s", current.Name, current.Status)
+		if current.IsDone() {
+			if current.Error != "" {
+				return current, fmt.Errorf("operation %s failed: %s", current.Name, current.Error)
+			}
+			return current, nil
+		}
+		emit(ctx, r.events, model.OperationProgress{Op: current, Percent: current.Progress})
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return current, ctxErr
+		}
+	}
+}
+
+// Get retrieves the current state of an operation.
+func (r *OperationRepository) Get(ctx context.Context, op *model.Operation) (*model.Operation, error) {
+	req := &computepb.GetZoneOperationRequest{
+		Project:   op.Project,
+		Zone:      op.Zone,
+		Operation: op.Name,
+	}
+
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	res, err := r.zoneOperationsClient.Get(callCtx, req)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operation %s: %w", op.Name, asAPIError(err))
+	}
+	return operationToModel(res, op.Project, op.Zone), nil
+}
+
+// ListByTarget returns the most recent operations whose target is the given instance, newest first.
+func (r *OperationRepository) ListByTarget(ctx context.Context, vm *model.VM, limit int) ([]*model.Operation, error) {
+	req := listByTargetRequest(vm, limit)
+	r.logger.Debugf("Listing operations with filter %s", req.GetFilter())
+
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	defer cancel()
+	it := r.zoneOperationsClient.List(callCtx, req)
+	ops, err := collectOperations(it.Next, vm.Project, vm.Zone, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list operations for VM %s: %w", vm.Name, asAPIError(err))
+	}
+	return ops, nil
+}
+
+// ListSystemEvents returns the most recent system events of the given instance, newest first.
+func (r *OperationRepository) ListSystemEvents(ctx context.Context, vm *model.VM, limit int) ([]*model.Operation, error) {
+	req := listSystemEventsRequest(vm, limit)
+	r.logger.Debugf("Listing system events with filter %s", req.GetFilter())
+
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	defer cancel()
+	it := r.zoneOperationsClient.List(callCtx, req)
+	ops, err := collectOperations(it.Next, vm.Project, vm.Zone, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list system events for VM %s: %w", vm.Name, asAPIError(err))
+	}
+	return ops, nil
+}
+
+// listByTargetRequest builds a request for the newest operations targeting vm.
+func listByTargetRequest(vm *model.VM, limit int) *computepb.ListZoneOperationsRequest {
+	return listOperationsRequest(vm, targetFilter(vm), limit)
+}
+
+// listSystemEventsRequest builds a request for the newest system events of vm.
+func listSystemEventsRequest(vm *model.VM, limit int) *computepb.ListZoneOperationsRequest {
+	types := model.SystemEventTypes()
+	for i, t := range types {
+		types[i] = fmt.Sprintf(`(operationType = "%s")`, t)
+	}
+	filter := fmt.Sprintf("(%s) AND (%s)", targetFilter(vm), strings.Join(types, " OR "))
+	return listOperationsRequest(vm, filter, limit)
+}
+
+// targetFilter returns a list filter matching the operations targeting vm.
+func targetFilter(vm *model.VM) string {
+	return fmt.Sprintf(`targetLink = "https://www.googleapis.com/compute/v1/projects/%s/zones/%s/instances/%s"`, vm.Project, vm.Zone, vm.Name)
+}
+
+// listOperationsRequest builds a request for the newest operations in the zone of vm matching filter.
+func listOperationsRequest(vm *model.VM, filter string, limit int) *computepb.ListZoneOperationsRequest {
+	orderBy := "creationTimestamp desc"
+	req := &computepb.ListZoneOperationsRequest{
+		Project: vm.Project,
+		Zone:    vm.Zone,
+		Filter:  &filter,
+		OrderBy: &orderBy,
+	}
+	if limit > 0 {
+		maxResults := uint32(limit)
+		req.MaxResults = &maxResults
+	}
+	return req
+}
+
+// collectOperations drains an operation iterator, stopping after limit items when limit > 0.
+func collectOperations(next func() (*computepb.Operation, error), project, zone string, limit int) ([]*model.Operation, error) {
+	var ops []*model.Operation
+	for limit <= 0 || len(ops) < limit {
+		op, err := next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, operationToModel(op, project, zone))
+	}
+	return ops, nil
+}
+
+// operationToModel converts a GCP operation to the domain model.
+func operationToModel(op *computepb.Operation, project, zone string) *model.Operation {
+	var messages []string
+	for _, e := range op.GetError().GetErrors() {
+		messages = append(messages, e.GetMessage())
+	}
+
+	target := op.GetTargetLink()
+	target = target[strings.LastIndex(target, "/")+1:]
+
+	return &model.Operation{
+		InsertTime: parseTimestamp(op.GetInsertTime()),
+		EndTime:    parseTimestamp(op.GetEndTime()),
+		Name:       op.GetName(),
+		Project:    project,
+		Zone:       zone,
+		Type:       op.GetOperationType(),
+		Target:     target,
+		Status:     op.GetStatus().String(),
+		Error:      strings.Join(messages, "; "),
+		User:       op.GetUser(),
+		Progress:   int(op.GetProgress()),
+	}
+}
+
+// parseTimestamp parses an RFC3339 API timestamp, returning nil if it is empty or malformed.
+func parseTimestamp(s string) *time.Time {
+	if s == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+var _ repository.OperationRepository = (*OperationRepository)(nil)
@@ -0,0 +1,195 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/iterator"
+)
+
+type fakeZoneOperationsClient struct {
+	waitResults []*computepb.Operation
+	waitCalls   int
+	closed      bool
+}
+
+func (c *fakeZoneOperationsClient) Get(context.Context, *computepb.GetZoneOperationRequest, ...gax.CallOption) (*computepb.Operation, error) {
+	return c.waitResults[len(c.waitResults)-1], nil
+}
+
+func (c *fakeZoneOperationsClient) Wait(context.Context, *computepb.WaitZoneOperationRequest, ...gax.CallOption) (*computepb.Operation, error) {
+	res := c.waitResults[c.waitCalls]
+	c.waitCalls++
+	return res, nil
+}
+
+func (c *fakeZoneOperationsClient) List(context.Context, *computepb.ListZoneOperationsRequest, ...gax.CallOption) *compute.OperationIterator {
+	return nil
+}
+
+func (c *fakeZoneOperationsClient) Close() error {
+	c.closed = true
+	return nil
+}
+
+func operationPb(status computepb.Operation_Status, errMessages ...string) *computepb.Operation {
+	op := &computepb.Operation{
+		Name:          stringPtr("operation-123"),
+		OperationType: stringPtr("start"),
+		TargetLink:    stringPtr("https://www.googleapis.com/compute/v1/projects/p/zones/z/instances/sandbox-1"),
+		Status:        &status,
+	}
+	if len(errMessages) > 0 {
+		op.Error = &computepb.Error{}
+		for _, m := range errMessages {
+			op.Error.Errors = append(op.Error.Errors, &computepb.Errors{Message: stringPtr(m)})
+		}
+	}
+	return op
+}
+
+func TestOperationRepositoryWaitPollsUntilDone(t *testing.T) {
+	client := &fakeZoneOperationsClient{waitResults: []*computepb.Operation{
+		operationPb(computepb.Operation_RUNNING),
+		operationPb(computepb.Operation_DONE),
+	}}
+	repo := newOperationRepository(log.NewLogger(), client)
+
+	op, err := repo.Wait(context.Background(), &model.Operation{Name: "operation-123", Project: "p", Zone: "z"})
+	require.NoError(t, err)
+	require.Equal(t, 2, client.waitCalls)
+	require.Equal(t, &model.Operation{
+		Name:    "operation-123",
+		Project: "p",
+		Zone:    "z",
+		Type:    "start",
+		Target:  "sandbox-1",
+		Status:  "DONE",
+	}, op)
+
+	require.NoError(t, repo.Close())
+	require.True(t, client.closed)
+}
+
+func TestOperationRepositoryWaitEmitsEvents(t *testing.T) {
+	client := &fakeZoneOperationsClient{waitResults: []*computepb.Operation{
+		operationPb(computepb.Operation_RUNNING),
+		operationPb(computepb.Operation_DONE),
+	}}
+	repo := newOperationRepository(log.NewLogger(), client)
+	events := make(chan model.OperationEvent, 3)
+	repo.events = events
+
+	pending := &model.Operation{Name: "operation-123", Project: "p", Zone: "z"}
+	op, err := repo.Wait(context.Background(), pending)
+	require.NoError(t, err)
+
+	require.Equal(t, model.OperationStarted{Op: pending}, <-events)
+	progress, ok := (<-events).(model.OperationProgress)
+	require.True(t, ok)
+	require.Equal(t, "RUNNING", progress.Op.Status)
+	require.Equal(t, model.OperationDone{Op: op}, <-events)
+}
+
+func TestOperationRepositoryWaitReturnsOperationError(t *testing.T) {
+	client := &fakeZoneOperationsClient{waitResults: []*computepb.Operation{
+		operationPb(computepb.Operation_DONE, "ZONE_RESOURCE_POOL_EXHAUSTED"),
+	}}
+	repo := newOperationRepository(log.NewLogger(), client)
+
+	op, err := repo.Wait(context.Background(), &model.Operation{Name: "operation-123", Project: "p", Zone: "z"})
+	require.ErrorContains(t, err, "ZONE_RESOURCE_POOL_EXHAUSTED")
+	require.Equal(t, "ZONE_RESOURCE_POOL_EXHAUSTED", op.Error)
+}
+
+func TestOperationRepositoryGetReturnsCurrentState(t *testing.T) {
+	client := &fakeZoneOperationsClient{waitResults: []*computepb.Operation{
+		operationPb(computepb.Operation_RUNNING),
+	}}
+	repo := newOperationRepository(log.NewLogger(), client)
+
+	op, err := repo.Get(context.Background(), &model.Operation{Name: "operation-123", Project: "p", Zone: "z"})
+	require.NoError(t, err)
+	require.Equal(t, "RUNNING", op.Status)
+	require.Equal(t, "sandbox-1", op.Target)
+	require.Equal(t, 0, client.waitCalls, "Get must not wait")
+}
+
+func TestListByTargetRequest(t *testing.T) {
+	req := listByTargetRequest(&model.VM{Project: "p", Zone: "z", Name: "sandbox-1"}, 5)
+	require.Equal(t, "p", req.GetProject())
+	require.Equal(t, "z", req.GetZone())
+	require.Equal(t, `targetLink = "https://www.googleapis.com/compute/v1/projects/p/zones/z/instances/sandbox-1"`, req.GetFilter())
+	require.Equal(t, "creationTimestamp desc", req.GetOrderBy())
+	require.Equal(t, uint32(5), req.GetMaxResults())
+
+	require.Nil(t, listByTargetRequest(&model.VM{}, 0).MaxResults, "no limit means no maxResults")
+}
+
+func TestListSystemEventsRequest(t *testing.T) {
+	req := listSystemEventsRequest(&model.VM{Project: "p", Zone: "z", Name: "sandbox-1"}, 5)
+	require.Equal(t, "p", req.GetProject())
+	require.Equal(t, "z", req.GetZone())
+	require.True(t, strings.HasPrefix(req.GetFilter(), `(targetLink = "https://www.googleapis.com/compute/v1/projects/p/zones/z/instances/sandbox-1") AND ((operationType = "`), req.GetFilter())
+	require.Contains(t, req.GetFilter(), `(operationType = "compute.instances.preempted") OR `)
+	require.Equal(t, "creationTimestamp desc", req.GetOrderBy())
+	require.Equal(t, uint32(5), req.GetMaxResults())
+}
+
+func TestCollectOperations(t *testing.T) {
+	newNext := func(ops []*computepb.Operation, err error) func() (*computepb.Operation, error) {
+		i := 0
+		return func() (*computepb.Operation, error) {
+			if i < len(ops) {
+				i++
+				return ops[i-1], nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			return nil, iterator.Done
+		}
+	}
+	three := []*computepb.Operation{
+		operationPb(computepb.Operation_DONE),
+		operationPb(computepb.Operation_DONE),
+		operationPb(computepb.Operation_RUNNING),
+	}
+
+	ops, err := collectOperations(newNext(three, nil), "p", "z", 0)
+	require.NoError(t, err)
+	require.Len(t, ops, 3)
+	require.Equal(t, "p", ops[0].Project)
+
+	ops, err = collectOperations(newNext(three, nil), "p", "z", 2)
+	require.NoError(t, err)
+	require.Len(t, ops, 2)
+
+	_, err = collectOperations(newNext(three[:1], errors.New("page failed")), "p", "z", 0)
+	require.Error(t, err)
+}
+
+func TestOperationToModelParsesTimesAndProgress(t *testing.T) {
+	op := operationPb(computepb.Operation_DONE)
+	op.InsertTime = stringPtr("2025-01-02T03:04:05.000-08:00")
+	op.EndTime = stringPtr("not-a-time")
+	progress := int32(100)
+	op.Progress = &progress
+	op.User = stringPtr("alice@example.com")
+
+	got := operationToModel(op, "p", "z")
+	require.NotNil(t, got.InsertTime)
+	require.True(t, got.InsertTime.Equal(time.Date(2025, 1, 2, 11, 4, 5, 0, time.UTC)))
+	require.Nil(t, got.EndTime, "malformed timestamps are dropped")
+	require.Equal(t, 100, got.Progress)
+	require.Equal(t, "alice@example.com", got.User)
+}
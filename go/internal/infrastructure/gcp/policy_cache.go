@@ -0,0 +1,57 @@
+package gcp
+
+import (
+	"sync"
+	"time"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"golang.org/x/sync/singleflight"
+)
+
+// policyCacheTTL bounds how long a fetched resource policy is reused. Policies rarely
+// change, but long-running commands such as `dashboard --watch` should still see edits.
+const policyCacheTTL = time.Minute
+
+// policyCache deduplicates resource policy lookups, since many VMs usually share the
+// same schedule policy. Concurrent lookups of one self-link share a single API call,
+// and successful results are reused for policyCacheTTL. Errors are not cached.
+type policyCache struct {
+	entries map[string]policyCacheEntry
+	now     func() time.Time
+	group   singleflight.Group
+	mu      sync.Mutex
+}
+
+type policyCacheEntry struct {
+	fetchedAt time.Time
+	policy    *computepb.ResourcePolicy
+}
+
+func newPolicyCache() *policyCache {
+	return &policyCache{entries: make(map[string]policyCacheEntry), now: time.Now}
+}
+
+// get returns the cached policy for selfLink, calling fetch when it is missing or expired.
+func (c *policyCache) get(selfLink string, fetch func() (*computepb.ResourcePolicy, error)) (*computepb.ResourcePolicy, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[selfLink]
+	c.mu.Unlock()
+	if ok && c.now().Sub(entry.fetchedAt) < policyCacheTTL {
+		return entry.policy, nil
+	}
+
+	v, err, _ := c.group.Do(selfLink, func() (interface{}, error) {
+		policy, fetchErr := fetch()
+		if fetchErr != nil {
+			return nil, fetchErr
+		}
+		c.mu.Lock()
+		c.entries[selfLink] = policyCacheEntry{fetchedAt: c.now(), policy: policy}
+		c.mu.Unlock()
+		return policy, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*computepb.ResourcePolicy), nil
+}
@@ -0,0 +1,77 @@
+package gcp
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyCacheReusesFetchedPolicyUntilTTL(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := newPolicyCache()
+	c.now = func() time.Time { return now }
+
+	var calls int32
+	fetch := func() (*computepb.ResourcePolicy, error) {
+		atomic.AddInt32(&calls, 1)
+		return &computepb.ResourcePolicy{Name: stringPtr("stop-at-night")}, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		policy, err := c.get("projects/p/regions/r/resourcePolicies/stop-at-night", fetch)
+		require.NoError(t, err)
+		require.Equal(t, "stop-at-night", policy.GetName())
+	}
+	require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+
+	now = now.Add(policyCacheTTL)
+	_, err := c.get("projects/p/regions/r/resourcePolicies/stop-at-night", fetch)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, atomic.LoadInt32(&calls), "expired entries are fetched again")
+}
+
+func TestPolicyCacheDeduplicatesConcurrentLookups(t *testing.T) {
+	c := newPolicyCache()
+	release := make(chan struct{})
+	var calls int32
+	fetch := func() (*computepb.ResourcePolicy, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return &computepb.ResourcePolicy{}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.get("shared", fetch)
+			require.NoError(t, err)
+		}()
+	}
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	require.LessOrEqual(t, atomic.LoadInt32(&calls), int32(2))
+}
+
+func TestPolicyCacheDoesNotCacheErrors(t *testing.T) {
+	c := newPolicyCache()
+	_, err := c.get("broken", func() (*computepb.ResourcePolicy, error) {
+		return nil, errors.New("boom")
+	})
+	require.Error(t, err)
+
+	policy, err := c.get("broken", func() (*computepb.ResourcePolicy, error) {
+		return &computepb.ResourcePolicy{Name: stringPtr("ok")}, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "ok", policy.GetName())
+}
@@ -0,0 +1,111 @@
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/serviceusage/v1"
+
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// cloudPlatformScope is the OAuth scope the GCP clients use.
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// PreflightRepository implements the repository.PreflightRepository interface for GCP.
+//
+// Unlike the other repositories it creates its clients per call, so it can be
+// constructed, and report what is wrong, when credentials are missing.
+type PreflightRepository struct {
+	logger   log.Logger
+	settings ClientSettings
+}
+
+// NewPreflightRepository creates a PreflightRepository checking the credentials
+// the other repositories would use with settings.
+func NewPreflightRepository(logger log.Logger, settings ClientSettings) *PreflightRepository {
+	return &PreflightRepository{logger: logger, settings: settings}
+}
+
+// Credentials loads the configured credentials file, or Application Default
+// Credentials without one, obtains a token with them and describes the principal
+// they belong to.
+func (r *PreflightRepository) Credentials(ctx context.Context) (string, error) {
+	creds, err := r.settings.Credentials(ctx)
+	if err != nil {
+		return "", err
+	}
+	if _, tokenErr := creds.TokenSource.Token(); tokenErr != nil {
+		return "", fmt.Errorf("failed to obtain an access token: %w", tokenErr)
+	}
+	return describeCredentials(creds.JSON), nil
+}
+
+// describeCredentials names the principal of a credentials file, or the metadata
+// server when there is no file.
+func describeCredentials(data []byte) string {
+	if len(data) == 0 {
+		return "metadata server of the current GCE/GKE environment"
+	}
+	var f struct {
+		Type        string `json:"type"`
+		ClientEmail string `json:"client_email"`
+	}
+	if err := json.Unmarshal(data, &f); err != nil {
+		return "credentials file"
+	}
+	switch {
+	case f.ClientEmail != "":
+		return fmt.Sprintf("%s (%s)", f.ClientEmail, f.Type)
+	case f.Type == "authorized_user":
+		return "user credentials from gcloud auth application-default login"
+	default:
+		return f.Type
+	}
+}
+
+// ComputeAPIEnabled reports whether compute.googleapis.com is enabled in project.
+func (r *PreflightRepository) ComputeAPIEnabled(ctx context.Context, project string) (bool, error) {
+	ctx, cancel := r.settings.Timeouts.callContext(ctx)
+	defer cancel()
+	opts, err := r.settings.ClientOptions()
+	if err != nil {
+		return false, err
+	}
+	svc, err := serviceusage.NewService(ctx, opts...)
+	if err != nil {
+		return false, fmt.Errorf("failed to create Service Usage client: %w", err)
+	}
+	res, err := svc.Services.Get(fmt.Sprintf("projects/%s/services/compute.googleapis.com", project)).Context(ctx).Do()
+	if err != nil {
+		return false, fmt.Errorf("failed to get the state of the Compute Engine API in %s: %w", project, err)
+	}
+	r.logger.Debugf("Compute Engine API in %s is %s", project, res.State)
+	return res.State == "ENABLED", nil
+}
+
+// TestPermissions returns which of permissions the caller holds on project.
+func (r *PreflightRepository) TestPermissions(ctx context.Context, project string, permissions []string) ([]string, error) {
+	ctx, cancel := r.settings.Timeouts.callContext(ctx)
+	defer cancel()
+	opts, err := r.settings.ClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	svc, err := cloudresourcemanager.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Resource Manager client: %w", err)
+	}
+	res, err := svc.Projects.TestIamPermissions(project, &cloudresourcemanager.TestIamPermissionsRequest{
+		Permissions: permissions,
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to test IAM permissions on %s: %w", project, err)
+	}
+	return res.Permissions, nil
+}
+
+var _ repository.PreflightRepository = (*PreflightRepository)(nil)
@@ -0,0 +1,16 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeCredentials(t *testing.T) {
+	assert.Equal(t, "metadata server of the current GCE/GKE environment", describeCredentials(nil))
+	assert.Equal(t, "gcectl@p.iam.gserviceaccount.com (service_account)",
+		describeCredentials([]byte(`{"type":"service_account","client_email":"gcectl@p.iam.gserviceaccount.com"}`)))
+	assert.Equal(t, "user credentials from gcloud auth application-default login",
+		describeCredentials([]byte(`{"type":"authorized_user","client_id":"x"}`)))
+	assert.Equal(t, "credentials file", describeCredentials([]byte(`not json`)))
+}
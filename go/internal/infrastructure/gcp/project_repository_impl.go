@@ -0,0 +1,68 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/cloudresourcemanager/v1"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// ProjectRepository implements the repository.ProjectRepository interface for GCP.
+//
+//nolint:govet // Field order optimized for readability over memory alignment
+type ProjectRepository struct {
+	logger   log.Logger
+	timeouts Timeouts
+
+	projectsService *cloudresourcemanager.ProjectsService
+}
+
+// NewProjectRepository creates a ProjectRepository with a Resource Manager client initialized from ctx and settings.
+// The returned repository must be closed by the caller.
+func NewProjectRepository(ctx context.Context, logger log.Logger, settings ClientSettings) (*ProjectRepository, error) {
+	opts, err := settings.ClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	svc, err := cloudresourcemanager.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Resource Manager client: %w", err)
+	}
+	return &ProjectRepository{logger: logger, timeouts: settings.Timeouts, projectsService: svc.Projects}, nil
+}
+
+// Close releases the repository. The Resource Manager client holds no connection of its own.
+func (r *ProjectRepository) Close() error {
+	return nil
+}
+
+// List returns the active projects the caller has access to.
+func (r *ProjectRepository) List(ctx context.Context) ([]*model.Project, error) {
+	ctx, cancel := r.timeouts.callContext(ctx)
+	defer cancel()
+	projects := make([]*model.Project, 0)
+	err := r.projectsService.List().Filter("lifecycleState:ACTIVE").Pages(ctx, func(res *cloudresourcemanager.ListProjectsResponse) error {
+		projects = append(projects, projectsToModel(res.Projects)...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	r.logger.Debugf("Found %d projects", len(projects))
+	return projects, nil
+}
+
+// projectsToModel converts Resource Manager projects.
+func projectsToModel(res []*cloudresourcemanager.Project) []*model.Project {
+	projects := make([]*model.Project, 0, len(res))
+	for _, p := range res {
+		projects = append(projects, &model.Project{ID: p.ProjectId, Name: p.Name, Number: p.ProjectNumber})
+	}
+	return projects
+}
+
+var _ repository.ProjectRepository = (*ProjectRepository)(nil)
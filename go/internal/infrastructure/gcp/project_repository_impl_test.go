@@ -0,0 +1,17 @@
+package gcp
+
+import (
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/cloudresourcemanager/v1"
+)
+
+func TestProjectsToModel(t *testing.T) {
+	got := projectsToModel([]*cloudresourcemanager.Project{
+		{ProjectId: "my-project", Name: "My Project", ProjectNumber: 123456789012},
+	})
+
+	require.Equal(t, []*model.Project{{ID: "my-project", Name: "My Project", Number: 123456789012}}, got)
+}
@@ -0,0 +1,141 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/iterator"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+type regionsClient interface {
+	Get(context.Context, *computepb.GetRegionRequest, ...gax.CallOption) (*computepb.Region, error)
+	Close() error
+}
+
+type zonesClient interface {
+	List(context.Context, *computepb.ListZonesRequest, ...gax.CallOption) *compute.ZoneIterator
+	Close() error
+}
+
+// RegionRepository implements the repository.RegionRepository interface for GCP.
+//
+//nolint:govet // Field order optimized for readability over memory alignment
+type RegionRepository struct {
+	logger   log.Logger
+	timeouts Timeouts
+
+	regionsClient regionsClient
+	zonesClient   zonesClient
+}
+
+// NewRegionRepository creates a RegionRepository with GCP clients initialized from ctx and settings.
+// The returned repository owns the clients and must be closed by the caller.
+func NewRegionRepository(ctx context.Context, logger log.Logger, settings ClientSettings) (*RegionRepository, error) {
+	opts, err := settings.ComputeClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	regionsClient, err := compute.NewRegionsRESTClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Regions client: %w", err)
+	}
+	zonesClient, err := compute.NewZonesRESTClient(ctx, opts...)
+	if err != nil {
+		_ = regionsClient.Close()
+		return nil, fmt.Errorf("failed to create Zones client: %w", err)
+	}
+	repo := newRegionRepository(logger, regionsClient, zonesClient)
+	repo.timeouts = settings.Timeouts
+	return repo, nil
+}
+
+// newRegionRepository allows tests to inject GCP clients.
+func newRegionRepository(logger log.Logger, regionsClient regionsClient, zonesClient zonesClient) *RegionRepository {
+	return &RegionRepository{logger: logger, regionsClient: regionsClient, zonesClient: zonesClient}
+}
+
+// Close releases the GCP clients held by the repository.
+func (r *RegionRepository) Close() error {
+	var firstErr error
+	if err := r.regionsClient.Close(); err != nil {
+		r.logger.Errorf("Failed to close Regions client: %v", err)
+		firstErr = err
+	}
+	if err := r.zonesClient.Close(); err != nil {
+		r.logger.Errorf("Failed to close Zones client: %v", err)
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Get returns project/region with its zones and quota usage.
+func (r *RegionRepository) Get(ctx context.Context, project, region string) (*model.Region, error) {
+	ctx, cancel := r.timeouts.callContext(ctx)
+	defer cancel()
+	res, err := r.regionsClient.Get(ctx, &computepb.GetRegionRequest{
+		Project: project,
+		Region:  region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get region %s/%s: %w", project, region, asAPIError(err))
+	}
+	return regionToModel(res), nil
+}
+
+// ListZones returns the zones of project, only those of region unless it is empty.
+func (r *RegionRepository) ListZones(ctx context.Context, project, region string) ([]*model.Zone, error) {
+	ctx, cancel := r.timeouts.callContext(ctx)
+	defer cancel()
+	it := r.zonesClient.List(ctx, &computepb.ListZonesRequest{Project: project})
+	zones, err := collectZones(it.Next, region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zones of %s: %w", project, asAPIError(err))
+	}
+	return zones, nil
+}
+
+// collectZones drains a zone iterator, keeping the zones of region unless it is empty.
+func collectZones(next func() (*computepb.Zone, error), region string) ([]*model.Zone, error) {
+	zones := make([]*model.Zone, 0)
+	for {
+		z, err := next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		zoneRegion := z.GetRegion()
+		zoneRegion = zoneRegion[strings.LastIndex(zoneRegion, "/")+1:]
+		if region != "" && zoneRegion != region {
+			continue
+		}
+		zones = append(zones, &model.Zone{Name: z.GetName(), Region: zoneRegion, Status: z.GetStatus()})
+	}
+	return zones, nil
+}
+
+// regionToModel converts a region resource, reducing zone URLs to zone names.
+func regionToModel(res *computepb.Region) *model.Region {
+	region := &model.Region{Name: res.GetName()}
+	for _, zone := range res.GetZones() {
+		region.Zones = append(region.Zones, zone[strings.LastIndex(zone, "/")+1:])
+	}
+	for _, q := range res.GetQuotas() {
+		region.Quotas = append(region.Quotas, model.Quota{Metric: q.GetMetric(), Limit: q.GetLimit(), Usage: q.GetUsage()})
+	}
+	return region
+}
+
+var _ repository.RegionRepository = (*RegionRepository)(nil)
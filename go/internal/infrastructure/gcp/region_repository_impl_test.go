@@ -0,0 +1,64 @@
+package gcp
+
+import (
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRegionToModel(t *testing.T) {
+	got := regionToModel(&computepb.Region{
+		Name: stringPtr("us-central1"),
+		Zones: []string{
+			"https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a",
+			"https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-b",
+		},
+		Quotas: []*computepb.Quota{
+			{Metric: stringPtr("NVIDIA_T4_GPUS"), Limit: proto.Float64(4), Usage: proto.Float64(1)},
+		},
+	})
+
+	require.Equal(t, &model.Region{
+		Name:   "us-central1",
+		Zones:  []string{"us-central1-a", "us-central1-b"},
+		Quotas: []model.Quota{{Metric: "NVIDIA_T4_GPUS", Limit: 4, Usage: 1}},
+	}, got)
+}
+
+func TestCollectZones(t *testing.T) {
+	zones := []*computepb.Zone{
+		{Name: stringPtr("us-central1-a"), Region: stringPtr("https://www.googleapis.com/compute/v1/projects/p/regions/us-central1"), Status: stringPtr("UP")},
+		{Name: stringPtr("asia-northeast1-b"), Region: stringPtr("https://www.googleapis.com/compute/v1/projects/p/regions/asia-northeast1"), Status: stringPtr("DOWN")},
+	}
+	iterate := func() func() (*computepb.Zone, error) {
+		i := 0
+		return func() (*computepb.Zone, error) {
+			if i < len(zones) {
+				i++
+				return zones[i-1], nil
+			}
+			return nil, iterator.Done
+		}
+	}
+
+	got, err := collectZones(iterate(), "")
+	require.NoError(t, err)
+	require.Equal(t, []*model.Zone{
+		{Name: "us-central1-a", Region: "us-central1", Status: "UP"},
+		{Name: "asia-northeast1-b", Region: "asia-northeast1", Status: "DOWN"},
+	}, got)
+
+	got, err = collectZones(iterate(), "asia-northeast1")
+	require.NoError(t, err)
+	require.Equal(t, []*model.Zone{{Name: "asia-northeast1-b", Region: "asia-northeast1", Status: "DOWN"}}, got)
+
+	_, err = collectZones(func() (*computepb.Zone, error) {
+		return nil, errors.New("denied")
+	}, "")
+	require.Error(t, err)
+}
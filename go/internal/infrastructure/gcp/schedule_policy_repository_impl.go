@@ -0,0 +1,231 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+type resourcePolicyListClient interface {
+	List(context.Context, *computepb.ListResourcePoliciesRequest, ...gax.CallOption) *compute.ResourcePolicyIterator
+	Insert(context.Context, *computepb.InsertResourcePolicyRequest, ...gax.CallOption) (*compute.Operation, error)
+	Close() error
+}
+
+type instanceListClient interface {
+	AggregatedList(context.Context, *computepb.AggregatedListInstancesRequest, ...gax.CallOption) *compute.InstancesScopedListPairIterator
+	Close() error
+}
+
+// SchedulePolicyRepository implements the repository.SchedulePolicyRepository interface for GCP.
+//
+//nolint:govet // Field order optimized for readability over memory alignment
+type SchedulePolicyRepository struct {
+	logger   log.Logger
+	timeouts Timeouts
+	events   chan<- model.OperationEvent
+
+	resourcePoliciesClient resourcePolicyListClient
+	instancesClient        instanceListClient
+}
+
+// NewSchedulePolicyRepository creates a SchedulePolicyRepository with GCP clients initialized from ctx and settings.
+// The returned repository owns the clients and must be closed by the caller.
+func NewSchedulePolicyRepository(ctx context.Context, logger log.Logger, settings ClientSettings) (*SchedulePolicyRepository, error) {
+	opts, err := settings.ComputeClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	resourcePoliciesClient, err := compute.NewResourcePoliciesRESTClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ResourcePolicies client: %w", err)
+	}
+
+	instancesClient, err := compute.NewInstancesRESTClient(ctx, opts...)
+	if err != nil {
+		if closeErr := resourcePoliciesClient.Close(); closeErr != nil {
+			logger.Errorf("Failed to close ResourcePolicies client after Instances client creation failed: %v", closeErr)
+		}
+		return nil, fmt.Errorf("failed to create Instances client: %w", err)
+	}
+
+	repo := newSchedulePolicyRepository(logger, resourcePoliciesClient, instancesClient)
+	repo.timeouts = settings.Timeouts
+	repo.events = settings.OperationEvents
+	return repo, nil
+}
+
+// newSchedulePolicyRepository allows tests to inject GCP clients.
+func newSchedulePolicyRepository(logger log.Logger, resourcePoliciesClient resourcePolicyListClient, instancesClient instanceListClient) *SchedulePolicyRepository {
+	return &SchedulePolicyRepository{
+		logger:                 logger,
+		resourcePoliciesClient: resourcePoliciesClient,
+		instancesClient:        instancesClient,
+	}
+}
+
+// Close releases the GCP clients held by the repository.
+func (r *SchedulePolicyRepository) Close() error {
+	var closeErrs []error
+	if err := r.resourcePoliciesClient.Close(); err != nil {
+		r.logger.Errorf("Failed to close ResourcePolicies client: %v", err)
+		closeErrs = append(closeErrs, err)
+	}
+	if err := r.instancesClient.Close(); err != nil {
+		r.logger.Errorf("Failed to close Instances client: %v", err)
+		closeErrs = append(closeErrs, err)
+	}
+	return errors.Join(closeErrs...)
+}
+
+// List returns the instance schedule policies in project/region. Attached VMs are
+// counted from one AggregatedList call over the project's instances.
+func (r *SchedulePolicyRepository) List(ctx context.Context, project, region string) ([]*model.SchedulePolicy, error) {
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	policyIt := r.resourcePoliciesClient.List(callCtx, &computepb.ListResourcePoliciesRequest{
+		Project: project,
+		Region:  region,
+	})
+	policies, err := collectSchedulePolicies(policyIt.Next, project, region)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resource policies in %s/%s: %w", project, region, asAPIError(err))
+	}
+	if len(policies) == 0 {
+		return policies, nil
+	}
+
+	partial := true
+	callCtx, cancel = r.timeouts.callContext(ctx)
+	instanceIt := r.instancesClient.AggregatedList(callCtx, &computepb.AggregatedListInstancesRequest{
+		Project:              project,
+		ReturnPartialSuccess: &partial,
+	})
+	counts, err := countAttachedVMs(instanceIt.Next, region)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list instances in project %s: %w", project, asAPIError(err))
+	}
+	for _, p := range policies {
+		p.AttachedVMs = counts[p.Name]
+	}
+	return policies, nil
+}
+
+// Create inserts policy as an instance schedule resource policy and waits for completion.
+func (r *SchedulePolicyRepository) Create(ctx context.Context, policy *model.SchedulePolicy) error {
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	op, err := r.resourcePoliciesClient.Insert(callCtx, insertSchedulePolicyRequest(policy))
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to create resource policy %s: %w", policy.Name, asAPIError(err))
+	}
+	if op == nil {
+		return fmt.Errorf("operation is nil")
+	}
+	waitCtx, cancelWait := r.timeouts.operationContext(ctx)
+	defer cancelWait()
+	if waitErr := waitObserved(waitCtx, r.events, op); waitErr != nil {
+		return fmt.Errorf("failed to create resource policy %s: %w", policy.Name, asAPIError(waitErr))
+	}
+	return nil
+}
+
+// insertSchedulePolicyRequest builds the insert request for policy. Unset schedules are omitted.
+func insertSchedulePolicyRequest(policy *model.SchedulePolicy) *computepb.InsertResourcePolicyRequest {
+	schedule := &computepb.ResourcePolicyInstanceSchedulePolicy{
+		TimeZone: proto.String(policy.TimeZone),
+	}
+	if policy.StartCron != "" {
+		schedule.VmStartSchedule = &computepb.ResourcePolicyInstanceSchedulePolicySchedule{Schedule: proto.String(policy.StartCron)}
+	}
+	if policy.StopCron != "" {
+		schedule.VmStopSchedule = &computepb.ResourcePolicyInstanceSchedulePolicySchedule{Schedule: proto.String(policy.StopCron)}
+	}
+
+	resource := &computepb.ResourcePolicy{
+		Name:                   proto.String(policy.Name),
+		InstanceSchedulePolicy: schedule,
+	}
+	if policy.Description != "" {
+		resource.Description = proto.String(policy.Description)
+	}
+	return &computepb.InsertResourcePolicyRequest{
+		Project:                policy.Project,
+		Region:                 policy.Region,
+		ResourcePolicyResource: resource,
+	}
+}
+
+// collectSchedulePolicies drains a resource policy iterator, keeping only instance schedule policies.
+func collectSchedulePolicies(next func() (*computepb.ResourcePolicy, error), project, region string) ([]*model.SchedulePolicy, error) {
+	policies := make([]*model.SchedulePolicy, 0)
+	for {
+		policy, err := next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if schedulePolicy := toSchedulePolicy(policy, project, region); schedulePolicy != nil {
+			policies = append(policies, schedulePolicy)
+		}
+	}
+	return policies, nil
+}
+
+// toSchedulePolicy converts a resource policy to the domain model.
+// It returns nil when the resource policy is not an instance schedule policy.
+func toSchedulePolicy(policy *computepb.ResourcePolicy, project, region string) *model.SchedulePolicy {
+	schedule := policy.GetInstanceSchedulePolicy()
+	if schedule == nil {
+		return nil
+	}
+	return &model.SchedulePolicy{
+		Name:        policy.GetName(),
+		Project:     project,
+		Region:      region,
+		StartCron:   schedule.GetVmStartSchedule().GetSchedule(),
+		StopCron:    schedule.GetVmStopSchedule().GetSchedule(),
+		TimeZone:    schedule.GetTimeZone(),
+		Description: policy.GetDescription(),
+	}
+}
+
+// countAttachedVMs drains an aggregated instance iterator and counts, per policy name,
+// the instances attached to a resource policy of region.
+func countAttachedVMs(next func() (compute.InstancesScopedListPair, error), region string) (map[string]int, error) {
+	regionPath := "/regions/" + region + "/resourcePolicies/"
+	counts := make(map[string]int)
+	for {
+		pair, err := next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, instance := range pair.Value.GetInstances() {
+			for _, link := range instance.GetResourcePolicies() {
+				if strings.Contains(link, regionPath) {
+					counts[policyNameOf(link)]++
+				}
+			}
+		}
+	}
+	return counts, nil
+}
+
+var _ repository.SchedulePolicyRepository = (*SchedulePolicyRepository)(nil)
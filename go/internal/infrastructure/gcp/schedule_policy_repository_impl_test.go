@@ -0,0 +1,96 @@
+package gcp
+
+import (
+	"errors"
+	"testing"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/iterator"
+)
+
+func TestCollectSchedulePolicies(t *testing.T) {
+	policies := []*computepb.ResourcePolicy{
+		{
+			Name:        stringPtr("weekday"),
+			Description: stringPtr("office hours"),
+			InstanceSchedulePolicy: &computepb.ResourcePolicyInstanceSchedulePolicy{
+				TimeZone:        stringPtr("Asia/Tokyo"),
+				VmStartSchedule: &computepb.ResourcePolicyInstanceSchedulePolicySchedule{Schedule: stringPtr("0 9 * * 1-5")},
+				VmStopSchedule:  &computepb.ResourcePolicyInstanceSchedulePolicySchedule{Schedule: stringPtr("0 19 * * 1-5")},
+			},
+		},
+		{Name: stringPtr("daily-snapshot")},
+	}
+	i := 0
+	next := func() (*computepb.ResourcePolicy, error) {
+		if i < len(policies) {
+			i++
+			return policies[i-1], nil
+		}
+		return nil, iterator.Done
+	}
+
+	got, err := collectSchedulePolicies(next, "proj", "asia-northeast1")
+	require.NoError(t, err)
+	require.Len(t, got, 1, "policies without an instance schedule are skipped")
+	require.Equal(t, "weekday", got[0].Name)
+	require.Equal(t, "0 9 * * 1-5", got[0].StartCron)
+	require.Equal(t, "0 19 * * 1-5", got[0].StopCron)
+	require.Equal(t, "Asia/Tokyo", got[0].TimeZone)
+	require.Equal(t, "asia-northeast1", got[0].Region)
+
+	_, err = collectSchedulePolicies(func() (*computepb.ResourcePolicy, error) {
+		return nil, errors.New("denied")
+	}, "proj", "asia-northeast1")
+	require.Error(t, err)
+}
+
+func TestCountAttachedVMs(t *testing.T) {
+	link := func(region, name string) string {
+		return "https://www.googleapis.com/compute/v1/projects/proj/regions/" + region + "/resourcePolicies/" + name
+	}
+	pairs := []compute.InstancesScopedListPair{
+		{Key: "zones/us-central1-a", Value: &computepb.InstancesScopedList{Instances: []*computepb.Instance{
+			{ResourcePolicies: []string{link("us-central1", "weekday")}},
+			{ResourcePolicies: []string{link("us-central1", "weekday"), link("us-central1", "snapshots")}},
+		}}},
+		{Key: "zones/us-east1-b", Value: &computepb.InstancesScopedList{Instances: []*computepb.Instance{
+			{ResourcePolicies: []string{link("us-east1", "weekday")}},
+		}}},
+	}
+	i := 0
+	next := func() (compute.InstancesScopedListPair, error) {
+		if i < len(pairs) {
+			i++
+			return pairs[i-1], nil
+		}
+		return compute.InstancesScopedListPair{}, iterator.Done
+	}
+
+	counts, err := countAttachedVMs(next, "us-central1")
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"weekday": 2, "snapshots": 1}, counts)
+}
+
+func TestInsertSchedulePolicyRequest(t *testing.T) {
+	req := insertSchedulePolicyRequest(&model.SchedulePolicy{
+		Name:     "weekday-stop",
+		Project:  "proj",
+		Region:   "us-central1",
+		StopCron: "0 19 * * 1-5",
+		TimeZone: "Asia/Tokyo",
+	})
+
+	require.Equal(t, "proj", req.GetProject())
+	require.Equal(t, "us-central1", req.GetRegion())
+	resource := req.GetResourcePolicyResource()
+	require.Equal(t, "weekday-stop", resource.GetName())
+	require.Nil(t, resource.Description, "empty description is omitted")
+	schedule := resource.GetInstanceSchedulePolicy()
+	require.Equal(t, "Asia/Tokyo", schedule.GetTimeZone())
+	require.Equal(t, "0 19 * * 1-5", schedule.GetVmStopSchedule().GetSchedule())
+	require.Nil(t, schedule.GetVmStartSchedule(), "unset start schedule is omitted")
+}
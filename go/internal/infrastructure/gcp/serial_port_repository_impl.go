@@ -0,0 +1,83 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+type serialPortClient interface {
+	GetSerialPortOutput(context.Context, *computepb.GetSerialPortOutputInstanceRequest, ...gax.CallOption) (*computepb.SerialPortOutput, error)
+	Close() error
+}
+
+// SerialPortRepository implements the repository.SerialPortRepository interface for GCP.
+//
+//nolint:govet // Field order optimized for readability over memory alignment
+type SerialPortRepository struct {
+	logger   log.Logger
+	timeouts Timeouts
+
+	instancesClient serialPortClient
+}
+
+// NewSerialPortRepository creates a SerialPortRepository with a GCP client initialized from ctx and settings.
+// The returned repository owns the client and must be closed by the caller.
+func NewSerialPortRepository(ctx context.Context, logger log.Logger, settings ClientSettings) (*SerialPortRepository, error) {
+	opts, err := settings.ComputeClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	instancesClient, err := compute.NewInstancesRESTClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Instances client: %w", err)
+	}
+	repo := newSerialPortRepository(logger, instancesClient)
+	repo.timeouts = settings.Timeouts
+	return repo, nil
+}
+
+// newSerialPortRepository allows tests to inject GCP clients.
+func newSerialPortRepository(logger log.Logger, instancesClient serialPortClient) *SerialPortRepository {
+	return &SerialPortRepository{logger: logger, instancesClient: instancesClient}
+}
+
+// Close releases the GCP client held by the repository.
+func (r *SerialPortRepository) Close() error {
+	if err := r.instancesClient.Close(); err != nil {
+		r.logger.Errorf("Failed to close Instances client: %v", err)
+		return err
+	}
+	return nil
+}
+
+// Read returns the output of the serial port of vm from byte position start.
+func (r *SerialPortRepository) Read(ctx context.Context, vm *model.VM, port int32, start int64) (*model.SerialOutput, error) {
+	ctx, cancel := r.timeouts.callContext(ctx)
+	defer cancel()
+	output, err := r.instancesClient.GetSerialPortOutput(ctx, &computepb.GetSerialPortOutputInstanceRequest{
+		Project:  vm.Project,
+		Zone:     vm.Zone,
+		Instance: vm.Name,
+		Port:     proto.Int32(port),
+		Start:    proto.Int64(start),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get serial port output of %s: %w", vm.Name, asAPIError(err))
+	}
+	return &model.SerialOutput{
+		Contents: output.GetContents(),
+		Start:    output.GetStart(),
+		Next:     output.GetNext(),
+	}, nil
+}
+
+var _ repository.SerialPortRepository = (*SerialPortRepository)(nil)
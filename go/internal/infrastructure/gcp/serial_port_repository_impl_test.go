@@ -0,0 +1,42 @@
+package gcp
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+type fakeSerialPortClient struct {
+	req *computepb.GetSerialPortOutputInstanceRequest
+}
+
+func (c *fakeSerialPortClient) GetSerialPortOutput(_ context.Context, req *computepb.GetSerialPortOutputInstanceRequest, _ ...gax.CallOption) (*computepb.SerialPortOutput, error) {
+	c.req = req
+	return &computepb.SerialPortOutput{
+		Contents: stringPtr("Booting...\n"),
+		Start:    proto.Int64(2048),
+		Next:     proto.Int64(2059),
+	}, nil
+}
+
+func (c *fakeSerialPortClient) Close() error {
+	return nil
+}
+
+func TestSerialPortRepositoryRead(t *testing.T) {
+	client := &fakeSerialPortClient{}
+	repo := newSerialPortRepository(log.NewLogger(), client)
+
+	got, err := repo.Read(context.Background(), &model.VM{Name: "sandbox", Project: "proj", Zone: "us-central1-a"}, 1, 1024)
+	require.NoError(t, err)
+	require.Equal(t, &model.SerialOutput{Contents: "Booting...\n", Start: 2048, Next: 2059}, got)
+	require.Equal(t, "sandbox", client.req.GetInstance())
+	require.Equal(t, int32(1), client.req.GetPort())
+	require.Equal(t, int64(1024), client.req.GetStart())
+}
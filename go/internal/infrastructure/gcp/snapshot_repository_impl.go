@@ -0,0 +1,162 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+type snapshotDisksClient interface {
+	CreateSnapshot(context.Context, *computepb.CreateSnapshotDiskRequest, ...gax.CallOption) (*compute.Operation, error)
+	Close() error
+}
+
+type snapshotsClient interface {
+	List(context.Context, *computepb.ListSnapshotsRequest, ...gax.CallOption) *compute.SnapshotIterator
+	Close() error
+}
+
+// SnapshotRepository implements the repository.SnapshotRepository interface for GCP.
+//
+//nolint:govet // Field order optimized for readability over memory alignment
+type SnapshotRepository struct {
+	logger   log.Logger
+	timeouts Timeouts
+
+	disksClient     snapshotDisksClient
+	snapshotsClient snapshotsClient
+}
+
+// NewSnapshotRepository creates a SnapshotRepository with GCP clients initialized from ctx and settings.
+// The returned repository owns the clients and must be closed by the caller.
+func NewSnapshotRepository(ctx context.Context, logger log.Logger, settings ClientSettings) (*SnapshotRepository, error) {
+	opts, err := settings.ComputeClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	disksClient, err := compute.NewDisksRESTClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Disks client: %w", err)
+	}
+	snapshotsClient, err := compute.NewSnapshotsRESTClient(ctx, opts...)
+	if err != nil {
+		_ = disksClient.Close()
+		return nil, fmt.Errorf("failed to create Snapshots client: %w", err)
+	}
+	repo := newSnapshotRepository(logger, disksClient, snapshotsClient)
+	repo.timeouts = settings.Timeouts
+	return repo, nil
+}
+
+// newSnapshotRepository allows tests to inject GCP clients.
+func newSnapshotRepository(logger log.Logger, disksClient snapshotDisksClient, snapshotsClient snapshotsClient) *SnapshotRepository {
+	return &SnapshotRepository{logger: logger, disksClient: disksClient, snapshotsClient: snapshotsClient}
+}
+
+// Close releases the GCP clients held by the repository.
+func (r *SnapshotRepository) Close() error {
+	var firstErr error
+	if err := r.disksClient.Close(); err != nil {
+		r.logger.Errorf("Failed to close Disks client: %v", err)
+		firstErr = err
+	}
+	if err := r.snapshotsClient.Close(); err != nil {
+		r.logger.Errorf("Failed to close Snapshots client: %v", err)
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// CreateAsync requests a snapshot of diskName and returns the zonal disk operation.
+// The snapshot is labelled with the VM it was taken for.
+func (r *SnapshotRepository) CreateAsync(ctx context.Context, vm *model.VM, diskName, snapshotName string) (*model.Operation, error) {
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	op, err := r.disksClient.CreateSnapshot(callCtx, &computepb.CreateSnapshotDiskRequest{
+		Project: vm.Project,
+		Zone:    vm.Zone,
+		Disk:    diskName,
+		SnapshotResource: &computepb.Snapshot{
+			Name:   proto.String(snapshotName),
+			Labels: map[string]string{"gcectl-vm": vm.Name},
+		},
+	})
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot of disk %s: %w", diskName, asAPIError(err))
+	}
+
+	r.logger.Infof("Creating snapshot %s of disk %s", snapshotName, diskName)
+
+	pending, err := pendingOperation(op, vm, "createSnapshot")
+	if err != nil {
+		return nil, err
+	}
+	pending.Target = diskName
+	return pending, nil
+}
+
+// List returns the snapshots in the VM's project whose source is one of diskNames in the VM's zone.
+func (r *SnapshotRepository) List(ctx context.Context, vm *model.VM, diskNames []string) ([]*model.Snapshot, error) {
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	defer cancel()
+	it := r.snapshotsClient.List(callCtx, &computepb.ListSnapshotsRequest{Project: vm.Project})
+	snapshots, err := collectSnapshots(it.Next, vm.Zone, diskNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots in %s: %w", vm.Project, asAPIError(err))
+	}
+	return snapshots, nil
+}
+
+// collectSnapshots drains a snapshot iterator, keeping snapshots of diskNames in zone.
+func collectSnapshots(next func() (*computepb.Snapshot, error), zone string, diskNames []string) ([]*model.Snapshot, error) {
+	wanted := make(map[string]bool, len(diskNames))
+	for _, name := range diskNames {
+		wanted[fmt.Sprintf("zones/%s/disks/%s", zone, name)] = true
+	}
+
+	snapshots := make([]*model.Snapshot, 0)
+	for {
+		s, err := next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !wanted[zonalDiskPath(s.GetSourceDisk())] {
+			continue
+		}
+		snapshots = append(snapshots, &model.Snapshot{
+			CreationTime: parseTimestamp(s.GetCreationTimestamp()),
+			Name:         s.GetName(),
+			SourceDisk:   policyNameOf(s.GetSourceDisk()),
+			Status:       s.GetStatus(),
+			DiskSizeGB:   s.GetDiskSizeGb(),
+			StorageBytes: s.GetStorageBytes(),
+		})
+	}
+	return snapshots, nil
+}
+
+// zonalDiskPath returns the "zones/ZONE/disks/NAME" suffix of a disk URL.
+func zonalDiskPath(diskURL string) string {
+	if i := strings.Index(diskURL, "zones/"); i >= 0 {
+		return diskURL[i:]
+	}
+	return diskURL
+}
+
+var _ repository.SnapshotRepository = (*SnapshotRepository)(nil)
@@ -0,0 +1,48 @@
+package gcp
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCollectSnapshots(t *testing.T) {
+	snapshots := []*computepb.Snapshot{
+		{
+			Name:              stringPtr("vm-1-20250102-060405"),
+			SourceDisk:        stringPtr("https://www.googleapis.com/compute/v1/projects/proj/zones/us-central1-a/disks/vm-1"),
+			Status:            stringPtr("READY"),
+			CreationTimestamp: stringPtr("2025-01-02T06:04:05Z"),
+			DiskSizeGb:        proto.Int64(10),
+			StorageBytes:      proto.Int64(1 << 30),
+		},
+		{Name: stringPtr("other-disk"), SourceDisk: stringPtr("projects/proj/zones/us-central1-a/disks/other")},
+		{Name: stringPtr("other-zone"), SourceDisk: stringPtr("projects/proj/zones/us-east1-b/disks/vm-1")},
+	}
+	i := 0
+	next := func() (*computepb.Snapshot, error) {
+		if i < len(snapshots) {
+			i++
+			return snapshots[i-1], nil
+		}
+		return nil, iterator.Done
+	}
+
+	got, err := collectSnapshots(next, "us-central1-a", []string{"vm-1", "data-1"})
+	require.NoError(t, err)
+	created := time.Date(2025, 1, 2, 6, 4, 5, 0, time.UTC)
+	require.Equal(t, []*model.Snapshot{
+		{CreationTime: &created, Name: "vm-1-20250102-060405", SourceDisk: "vm-1", Status: "READY", DiskSizeGB: 10, StorageBytes: 1 << 30},
+	}, got, "snapshots of other disks and zones are skipped")
+
+	_, err = collectSnapshots(func() (*computepb.Snapshot, error) {
+		return nil, errors.New("denied")
+	}, "us-central1-a", []string{"vm-1"})
+	require.Error(t, err)
+}
@@ -0,0 +1,33 @@
+package gcp
+
+import (
+	"context"
+	"time"
+)
+
+// Timeouts bound how long the repositories wait for GCP, so an unreachable
+// project fails the command instead of hanging it. Zero values mean no limit.
+type Timeouts struct {
+	// Call bounds a single API call, or all pages of a listing.
+	Call time.Duration
+	// Operation bounds waiting for a long-running operation to finish.
+	Operation time.Duration
+}
+
+// callContext derives the context of a single API call from ctx.
+func (t Timeouts) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, t.Call)
+}
+
+// operationContext derives the context of an operation wait from ctx.
+func (t Timeouts) operationContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, t.Operation)
+}
+
+// withTimeout is context.WithTimeout treating a non-positive timeout as no limit.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,24 @@
+package gcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeoutsContexts(t *testing.T) {
+	timeouts := Timeouts{Call: time.Minute}
+
+	ctx, cancel := timeouts.callContext(context.Background())
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+
+	ctx, cancel = timeouts.operationContext(context.Background())
+	defer cancel()
+	_, ok = ctx.Deadline()
+	assert.False(t, ok, "a zero operation timeout must not set a deadline")
+}
@@ -0,0 +1,136 @@
+package gcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// maxTracedBody bounds how much of a response body a trace line shows.
+const maxTracedBody = 4 << 10
+
+// redacted replaces the values traces must not show.
+const redacted = "REDACTED"
+
+// sensitiveParams are the query parameters whose values are redacted from traces.
+var sensitiveParams = map[string]bool{
+	"access_token": true,
+	"key":          true,
+}
+
+// sensitiveKeys are the JSON keys whose values are redacted from traces,
+// compared case-insensitively. Metadata item values are included since they
+// often hold startup scripts and SSH keys.
+var sensitiveKeys = map[string]bool{
+	"access_token":      true,
+	"encryptedpassword": true,
+	"id_token":          true,
+	"modulus":           true,
+	"password":          true,
+	"privatekey":        true,
+	"refresh_token":     true,
+	"secret":            true,
+	"signature":         true,
+	"value":             true,
+}
+
+// tracingTransport logs every request sent through base with its status and
+// latency, and optionally the redacted response body.
+type tracingTransport struct {
+	base   http.RoundTripper
+	logger log.Logger
+	bodies bool
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	res, err := t.base.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	target := redactURL(req.URL)
+	if err != nil {
+		t.logger.Infof("API %s %s failed after %s: %v", req.Method, target, elapsed, err)
+		return nil, err
+	}
+	t.logger.Infof("API %s %s %s %s", req.Method, target, res.Status, elapsed)
+	if !t.bodies || res.Body == nil {
+		return res, nil
+	}
+
+	body, readErr := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	if readErr != nil {
+		t.logger.Infof("API %s %s body: failed to read: %v", req.Method, target, readErr)
+		return res, nil
+	}
+	t.logger.Infof("API %s %s body: %s", req.Method, target, redactBody(body))
+	return res, nil
+}
+
+// redactURL returns u as a string with credentials and sensitive query parameters redacted.
+func redactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	c := *u
+	query := c.Query()
+	changed := false
+	for name := range query {
+		if sensitiveParams[strings.ToLower(name)] {
+			query.Set(name, redacted)
+			changed = true
+		}
+	}
+	if changed {
+		c.RawQuery = query.Encode()
+	}
+	return c.Redacted()
+}
+
+// redactBody returns a JSON body with the values of sensitive keys redacted,
+// truncated to maxTracedBody. Other bodies are summarized by their size only.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return "(empty)"
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return fmt.Sprintf("(%d bytes, not JSON)", len(body))
+	}
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return fmt.Sprintf("(%d bytes)", len(body))
+	}
+	if len(out) > maxTracedBody {
+		return string(out[:maxTracedBody]) + "... (truncated)"
+	}
+	return string(out)
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if sensitiveKeys[strings.ToLower(key)] {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactValue(value)
+		}
+		return v
+	case []any:
+		for i, value := range v {
+			v[i] = redactValue(value)
+		}
+		return v
+	default:
+		return v
+	}
+}
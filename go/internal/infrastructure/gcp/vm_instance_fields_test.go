@@ -0,0 +1,104 @@
+package gcp
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractNetworkInterfaces(t *testing.T) {
+	instance := &computepb.Instance{
+		NetworkInterfaces: []*computepb.NetworkInterface{
+			{
+				Name:          stringPtr("nic0"),
+				Network:       stringPtr("https://www.googleapis.com/compute/v1/projects/proj/global/networks/default"),
+				Subnetwork:    stringPtr("https://www.googleapis.com/compute/v1/projects/proj/regions/us-central1/subnetworks/default"),
+				NetworkIP:     stringPtr("10.128.0.2"),
+				AccessConfigs: []*computepb.AccessConfig{{NatIP: stringPtr("34.1.2.3")}},
+			},
+			{
+				Name:      stringPtr("nic1"),
+				Network:   stringPtr("projects/proj/global/networks/backend"),
+				NetworkIP: stringPtr("10.10.0.5"),
+			},
+		},
+	}
+
+	assert.Equal(t, []model.NetworkInterface{
+		{Name: "nic0", Network: "default", Subnetwork: "default", InternalIP: "10.128.0.2", ExternalIP: "34.1.2.3"},
+		{Name: "nic1", Network: "backend", InternalIP: "10.10.0.5"},
+	}, extractNetworkInterfaces(instance))
+	assert.Nil(t, extractNetworkInterfaces(&computepb.Instance{}))
+}
+
+func TestExtractServiceAccount(t *testing.T) {
+	instance := &computepb.Instance{
+		ServiceAccounts: []*computepb.ServiceAccount{{
+			Email:  stringPtr("vm@proj.iam.gserviceaccount.com"),
+			Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+		}},
+	}
+
+	assert.Equal(t, &model.ServiceAccount{
+		Email:  "vm@proj.iam.gserviceaccount.com",
+		Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+	}, extractServiceAccount(instance))
+	assert.Nil(t, extractServiceAccount(&computepb.Instance{}))
+}
+
+func TestIsWindows(t *testing.T) {
+	boot := true
+	tests := []struct {
+		name string
+		disk *computepb.AttachedDisk
+		want bool
+	}{
+		{
+			name: "guest OS feature",
+			disk: &computepb.AttachedDisk{Boot: &boot, GuestOsFeatures: []*computepb.GuestOsFeature{{Type: stringPtr("WINDOWS")}}},
+			want: true,
+		},
+		{
+			name: "windows-cloud license",
+			disk: &computepb.AttachedDisk{Boot: &boot, Licenses: []string{"https://www.googleapis.com/compute/v1/projects/windows-cloud/global/licenses/windows-server-2022-dc"}},
+			want: true,
+		},
+		{
+			name: "linux boot disk",
+			disk: &computepb.AttachedDisk{Boot: &boot, Licenses: []string{"https://www.googleapis.com/compute/v1/projects/debian-cloud/global/licenses/debian-12-bookworm"}},
+			want: false,
+		},
+		{
+			name: "windows data disk",
+			disk: &computepb.AttachedDisk{GuestOsFeatures: []*computepb.GuestOsFeature{{Type: stringPtr("WINDOWS")}}},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isWindows(&computepb.Instance{Disks: []*computepb.AttachedDisk{tt.disk}}))
+		})
+	}
+}
+
+func TestUpcomingMaintenance(t *testing.T) {
+	assert.Nil(t, upcomingMaintenance(&computepb.Instance{}))
+
+	instance := &computepb.Instance{ResourceStatus: &computepb.ResourceStatus{UpcomingMaintenance: &computepb.UpcomingMaintenance{
+		WindowStartTime:       stringPtr("2026-05-02T03:00:00Z"),
+		LatestWindowStartTime: stringPtr("2026-05-04T03:00:00Z"),
+	}}}
+	got := upcomingMaintenance(instance)
+	require.NotNil(t, got)
+	assert.Equal(t, time.Date(2026, 5, 2, 3, 0, 0, 0, time.UTC), *got)
+
+	instance.ResourceStatus.UpcomingMaintenance.WindowStartTime = nil
+	got = upcomingMaintenance(instance)
+	require.NotNil(t, got)
+	assert.Equal(t, time.Date(2026, 5, 4, 3, 0, 0, 0, time.UTC), *got)
+}
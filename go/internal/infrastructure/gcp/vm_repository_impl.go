@@ -5,25 +5,45 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	compute "cloud.google.com/go/compute/apiv1"
 	"cloud.google.com/go/compute/apiv1/computepb"
 	"github.com/googleapis/gax-go/v2"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/haru-256/gcectl/internal/domain/model"
 	"github.com/haru-256/gcectl/internal/domain/repository"
 	"github.com/haru-256/gcectl/internal/infrastructure/log"
 )
 
+const (
+	// maxConcurrentConversions caps the instances converted at once by FindAll.
+	maxConcurrentConversions = 10
+	// maxFingerprintAttempts caps the read-modify-write attempts of a fingerprinted update.
+	maxFingerprintAttempts = 3
+)
+
 type instancesClient interface {
 	Get(context.Context, *computepb.GetInstanceRequest, ...gax.CallOption) (*computepb.Instance, error)
+	AggregatedList(context.Context, *computepb.AggregatedListInstancesRequest, ...gax.CallOption) *compute.InstancesScopedListPairIterator
 	Start(context.Context, *computepb.StartInstanceRequest, ...gax.CallOption) (*compute.Operation, error)
 	Stop(context.Context, *computepb.StopInstanceRequest, ...gax.CallOption) (*compute.Operation, error)
+	Reset(context.Context, *computepb.ResetInstanceRequest, ...gax.CallOption) (*compute.Operation, error)
 	AddResourcePolicies(context.Context, *computepb.AddResourcePoliciesInstanceRequest, ...gax.CallOption) (*compute.Operation, error)
 	RemoveResourcePolicies(context.Context, *computepb.RemoveResourcePoliciesInstanceRequest, ...gax.CallOption) (*compute.Operation, error)
 	SetMachineType(context.Context, *computepb.SetMachineTypeInstanceRequest, ...gax.CallOption) (*compute.Operation, error)
+	SetLabels(context.Context, *computepb.SetLabelsInstanceRequest, ...gax.CallOption) (*compute.Operation, error)
+	SetMetadata(context.Context, *computepb.SetMetadataInstanceRequest, ...gax.CallOption) (*compute.Operation, error)
+	SetScheduling(context.Context, *computepb.SetSchedulingInstanceRequest, ...gax.CallOption) (*compute.Operation, error)
+	SetMachineResources(context.Context, *computepb.SetMachineResourcesInstanceRequest, ...gax.CallOption) (*compute.Operation, error)
 	Close() error
 }
 
@@ -36,21 +56,28 @@ type resourcePoliciesClient interface {
 //
 //nolint:govet // Field order optimized for readability over memory alignment
 type VMRepository struct {
-	logger log.Logger
+	logger   log.Logger
+	timeouts Timeouts
+	events   chan<- model.OperationEvent
 
 	instancesClient        instancesClient
 	resourcePoliciesClient resourcePoliciesClient
+	policies               *policyCache
 }
 
-// NewVMRepository creates a VMRepository with GCP clients initialized from ctx.
+// NewVMRepository creates a VMRepository with GCP clients initialized from ctx and settings.
 // The returned repository owns the clients and must be closed by the caller.
-func NewVMRepository(ctx context.Context, logger log.Logger) (*VMRepository, error) {
-	instancesClient, err := compute.NewInstancesRESTClient(ctx)
+func NewVMRepository(ctx context.Context, logger log.Logger, settings ClientSettings) (*VMRepository, error) {
+	opts, err := settings.ComputeClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	instancesClient, err := compute.NewInstancesRESTClient(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Instances client: %w", err)
 	}
 
-	resourcePoliciesClient, err := compute.NewResourcePoliciesRESTClient(ctx)
+	resourcePoliciesClient, err := compute.NewResourcePoliciesRESTClient(ctx, opts...)
 	if err != nil {
 		if closeErr := instancesClient.Close(); closeErr != nil {
 			logger.Errorf("Failed to close Instances client after ResourcePolicies client creation failed: %v", closeErr)
@@ -58,7 +85,10 @@ func NewVMRepository(ctx context.Context, logger log.Logger) (*VMRepository, err
 		return nil, fmt.Errorf("failed to create ResourcePolicies client: %w", err)
 	}
 
-	return newVMRepository(logger, instancesClient, resourcePoliciesClient), nil
+	repo := newVMRepository(logger, instancesClient, resourcePoliciesClient)
+	repo.timeouts = settings.Timeouts
+	repo.events = settings.OperationEvents
+	return repo, nil
 }
 
 // newVMRepository allows tests to inject GCP clients.
@@ -67,6 +97,7 @@ func newVMRepository(logger log.Logger, instancesClient instancesClient, resourc
 		logger:                 logger,
 		instancesClient:        instancesClient,
 		resourcePoliciesClient: resourcePoliciesClient,
+		policies:               newPolicyCache(),
 	}
 }
 
@@ -91,14 +122,174 @@ func (r *VMRepository) FindByName(ctx context.Context, vm *model.VM) (*model.VM,
 		Instance: vm.Name,
 	}
 
-	instance, err := r.instancesClient.Get(ctx, req)
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	instance, err := r.instancesClient.Get(callCtx, req)
+	cancel()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get instance: %w", err)
+		return nil, fmt.Errorf("failed to get instance: %w", asAPIError(err))
 	}
 
 	return r.toModel(ctx, instance)
 }
 
+// FindAll retrieves vms with one AggregatedList call per project instead of one Get per VM.
+// The result is aligned with vms; entries for VMs that do not exist are nil.
+func (r *VMRepository) FindAll(ctx context.Context, vms []*model.VM) ([]*model.VM, error) {
+	found := make([]*model.VM, len(vms))
+	for _, project := range projectsOf(vms) {
+		req := aggregatedListRequest(project, vms)
+		r.logger.Debugf("Listing instances in project %s with filter %s", project, req.GetFilter())
+
+		callCtx, cancel := r.timeouts.callContext(ctx)
+		it := r.instancesClient.AggregatedList(callCtx, req)
+		instances, err := collectInstances(it.Next)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list instances in project %s: %w", project, asAPIError(err))
+		}
+
+		// Conversion fetches schedule policies, so instances are converted in parallel.
+		eg, egCtx := errgroup.WithContext(ctx)
+		eg.SetLimit(maxConcurrentConversions)
+		for i, vm := range vms {
+			if vm.Project != project {
+				continue
+			}
+			instance, ok := instances[instanceKey(vm.Zone, vm.Name)]
+			if !ok {
+				continue
+			}
+			i := i
+			eg.Go(func() error {
+				converted, convErr := r.toModel(egCtx, instance)
+				if convErr != nil {
+					return convErr
+				}
+				found[i] = converted
+				return nil
+			})
+		}
+		if waitErr := eg.Wait(); waitErr != nil {
+			return nil, waitErr
+		}
+	}
+	return found, nil
+}
+
+// ListByProject retrieves the instances of project that have all labels in selector
+// with one AggregatedList call. The VMs only carry identity, status, machine type and
+// labels, so no schedule policy is fetched.
+func (r *VMRepository) ListByProject(ctx context.Context, project string, selector map[string]string) ([]*model.VM, error) {
+	partial := true
+	req := &computepb.AggregatedListInstancesRequest{
+		Project:              project,
+		ReturnPartialSuccess: &partial,
+	}
+	if len(selector) > 0 {
+		filter := labelFilter(selector)
+		req.Filter = &filter
+	}
+	r.logger.Debugf("Listing instances in project %s with filter %q", project, req.GetFilter())
+
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	defer cancel()
+	it := r.instancesClient.AggregatedList(callCtx, req)
+	instances, err := collectInstances(it.Next)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list instances in project %s: %w", project, asAPIError(err))
+	}
+	return summaryModels(project, instances), nil
+}
+
+// labelFilter builds a list filter matching instances with every label in selector.
+// Label values are restricted to lowercase letters, digits, '-' and '_', so they need no escaping.
+func labelFilter(selector map[string]string) string {
+	terms := make([]string, 0, len(selector))
+	for _, key := range sortedKeys(selector) {
+		terms = append(terms, fmt.Sprintf(`(labels.%s = "%s")`, key, selector[key]))
+	}
+	return strings.Join(terms, " ")
+}
+
+// summaryModels converts instances keyed by instanceKey to VMs of project sorted by zone and name.
+func summaryModels(project string, instances map[string]*computepb.Instance) []*model.VM {
+	keys := make([]string, 0, len(instances))
+	for key := range instances {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	vms := make([]*model.VM, 0, len(keys))
+	for _, key := range keys {
+		instance := instances[key]
+		zone, _, _ := strings.Cut(key, "/")
+		vms = append(vms, &model.VM{
+			Name:        instance.GetName(),
+			Project:     project,
+			Zone:        zone,
+			Status:      model.StatusFromString(instance.GetStatus()),
+			MachineType: extractMachineType(instance.GetMachineType()),
+			Labels:      instance.GetLabels(),
+		})
+	}
+	return vms
+}
+
+// projectsOf returns the distinct projects of vms in first-seen order.
+func projectsOf(vms []*model.VM) []string {
+	seen := make(map[string]bool)
+	var projects []string
+	for _, vm := range vms {
+		if !seen[vm.Project] {
+			seen[vm.Project] = true
+			projects = append(projects, vm.Project)
+		}
+	}
+	return projects
+}
+
+// aggregatedListRequest builds a request for the instances of project named in vms.
+// The eq filter takes an RE2 expression that must match the whole name.
+func aggregatedListRequest(project string, vms []*model.VM) *computepb.AggregatedListInstancesRequest {
+	seen := make(map[string]bool)
+	var names []string
+	for _, vm := range vms {
+		if vm.Project == project && !seen[vm.Name] {
+			seen[vm.Name] = true
+			names = append(names, regexp.QuoteMeta(vm.Name))
+		}
+	}
+	filter := fmt.Sprintf(`name eq "(%s)"`, strings.Join(names, "|"))
+	partial := true
+	return &computepb.AggregatedListInstancesRequest{
+		Project:              project,
+		Filter:               &filter,
+		ReturnPartialSuccess: &partial,
+	}
+}
+
+// collectInstances drains an aggregated instance iterator into a map keyed by zone and name.
+func collectInstances(next func() (compute.InstancesScopedListPair, error)) (map[string]*computepb.Instance, error) {
+	instances := make(map[string]*computepb.Instance)
+	for {
+		pair, err := next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		zone := strings.TrimPrefix(pair.Key, "zones/")
+		for _, instance := range pair.Value.GetInstances() {
+			instances[instanceKey(zone, instance.GetName())] = instance
+		}
+	}
+	return instances, nil
+}
+
+func instanceKey(zone, name string) string {
+	return zone + "/" + name
+}
+
 func (r *VMRepository) Start(ctx context.Context, vm *model.VM) error {
 	req := &computepb.StartInstanceRequest{
 		Project:  vm.Project,
@@ -106,12 +297,14 @@ func (r *VMRepository) Start(ctx context.Context, vm *model.VM) error {
 		Instance: vm.Name,
 	}
 
-	op, err := r.instancesClient.Start(ctx, req)
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	op, err := r.instancesClient.Start(callCtx, req)
+	cancel()
 	if err != nil {
-		return fmt.Errorf("failed to start instance: %w", err)
+		return fmt.Errorf("failed to start instance: %w", asAPIError(asZoneExhaustedError(asCapabilityError(err, vm, model.CapabilityStart))))
 	}
 
-	return r.waitOperator(ctx, op)
+	return asZoneExhaustedError(r.waitOperator(ctx, op))
 }
 
 func (r *VMRepository) Stop(ctx context.Context, vm *model.VM) error {
@@ -121,14 +314,90 @@ func (r *VMRepository) Stop(ctx context.Context, vm *model.VM) error {
 		Instance: vm.Name,
 	}
 
-	op, err := r.instancesClient.Stop(ctx, req)
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	op, err := r.instancesClient.Stop(callCtx, req)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to stop instance: %w", asAPIError(asCapabilityError(err, vm, model.CapabilityStop)))
+	}
+
+	return r.waitOperator(ctx, op)
+}
+
+// Reset hard resets a running instance and waits for the operation. The guest
+// is not shut down, so unsaved data in memory is lost.
+func (r *VMRepository) Reset(ctx context.Context, vm *model.VM) error {
+	req := &computepb.ResetInstanceRequest{
+		Project:  vm.Project,
+		Zone:     vm.Zone,
+		Instance: vm.Name,
+	}
+
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	op, err := r.instancesClient.Reset(callCtx, req)
+	cancel()
 	if err != nil {
-		return fmt.Errorf("failed to stop instance: %w", err)
+		return fmt.Errorf("failed to reset instance: %w", asAPIError(asCapabilityError(err, vm, model.CapabilityReset)))
 	}
 
 	return r.waitOperator(ctx, op)
 }
 
+// StartAsync requests an instance start and returns the operation without waiting.
+func (r *VMRepository) StartAsync(ctx context.Context, vm *model.VM) (*model.Operation, error) {
+	req := &computepb.StartInstanceRequest{
+		Project:  vm.Project,
+		Zone:     vm.Zone,
+		Instance: vm.Name,
+	}
+
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	op, err := r.instancesClient.Start(callCtx, req)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start instance: %w", asAPIError(asCapabilityError(err, vm, model.CapabilityStart)))
+	}
+
+	return pendingOperation(op, vm, "start")
+}
+
+// StopAsync requests an instance stop and returns the operation without waiting.
+func (r *VMRepository) StopAsync(ctx context.Context, vm *model.VM) (*model.Operation, error) {
+	req := &computepb.StopInstanceRequest{
+		Project:  vm.Project,
+		Zone:     vm.Zone,
+		Instance: vm.Name,
+	}
+
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	op, err := r.instancesClient.Stop(callCtx, req)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stop instance: %w", asAPIError(asCapabilityError(err, vm, model.CapabilityStop)))
+	}
+
+	return pendingOperation(op, vm, "stop")
+}
+
+// pendingOperation converts a just-issued compute operation to the domain model.
+func pendingOperation(op *compute.Operation, vm *model.VM, opType string) (*model.Operation, error) {
+	if op == nil {
+		return nil, fmt.Errorf("operation is nil")
+	}
+	status := "RUNNING"
+	if op.Done() {
+		status = "DONE"
+	}
+	return &model.Operation{
+		Name:    op.Name(),
+		Project: vm.Project,
+		Zone:    vm.Zone,
+		Type:    opType,
+		Target:  vm.Name,
+		Status:  status,
+	}, nil
+}
+
 // SetSchedulePolicy attaches a schedule policy to a Google Compute Engine instance.
 func (r *VMRepository) SetSchedulePolicy(ctx context.Context, vm *model.VM, policyName string) error {
 	// Get instance details
@@ -138,10 +407,12 @@ func (r *VMRepository) SetSchedulePolicy(ctx context.Context, vm *model.VM, poli
 		Instance: vm.Name,
 	}
 
-	instance, err := r.instancesClient.Get(ctx, req)
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	instance, err := r.instancesClient.Get(callCtx, req)
+	cancel()
 	if err != nil {
 		r.logger.Errorf("failed to get instance: %v", err)
-		return fmt.Errorf("failed to get instance: %w", err)
+		return fmt.Errorf("failed to get instance: %w", asAPIError(err))
 	}
 
 	// Extract region from zone
@@ -162,10 +433,12 @@ func (r *VMRepository) SetSchedulePolicy(ctx context.Context, vm *model.VM, poli
 		},
 	}
 
-	op, err := r.instancesClient.AddResourcePolicies(ctx, addPolicyReq)
+	callCtx, cancel = r.timeouts.callContext(ctx)
+	op, err := r.instancesClient.AddResourcePolicies(callCtx, addPolicyReq)
+	cancel()
 	if err != nil {
 		r.logger.Errorf("Failed to set schedule policy: %v", err)
-		return fmt.Errorf("failed to add resource policy: %w", err)
+		return fmt.Errorf("failed to add resource policy: %w", asAPIError(asCapabilityError(err, vm, model.CapabilitySchedulePolicy)))
 	}
 
 	r.logger.Infof("Setting schedule policy %s for instance %s", policyName, vm.Name)
@@ -187,10 +460,12 @@ func (r *VMRepository) UnsetSchedulePolicy(ctx context.Context, vm *model.VM, po
 		Instance: vm.Name,
 	}
 
-	instance, err := r.instancesClient.Get(ctx, req)
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	instance, err := r.instancesClient.Get(callCtx, req)
+	cancel()
 	if err != nil {
 		r.logger.Errorf("failed to get instance: %v", err)
-		return fmt.Errorf("failed to get instance: %w", err)
+		return fmt.Errorf("failed to get instance: %w", asAPIError(err))
 	}
 
 	// Extract region from zone
@@ -211,10 +486,12 @@ func (r *VMRepository) UnsetSchedulePolicy(ctx context.Context, vm *model.VM, po
 		},
 	}
 
-	op, err := r.instancesClient.RemoveResourcePolicies(ctx, removePolicyReq)
+	callCtx, cancel = r.timeouts.callContext(ctx)
+	op, err := r.instancesClient.RemoveResourcePolicies(callCtx, removePolicyReq)
+	cancel()
 	if err != nil {
 		r.logger.Errorf("Failed to unset schedule policy: %v", err)
-		return fmt.Errorf("failed to remove resource policy: %w", err)
+		return fmt.Errorf("failed to remove resource policy: %w", asAPIError(asCapabilityError(err, vm, model.CapabilitySchedulePolicy)))
 	}
 
 	r.logger.Infof("Removing schedule policy %s from instance %s", policyName, vm.Name)
@@ -241,10 +518,12 @@ func (r *VMRepository) UpdateMachineType(ctx context.Context, vm *model.VM, mach
 		},
 	}
 
-	op, err := r.instancesClient.SetMachineType(ctx, setMachineTypeReq)
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	op, err := r.instancesClient.SetMachineType(callCtx, setMachineTypeReq)
+	cancel()
 	if err != nil {
 		r.logger.Errorf("Failed to set machine type: %v", err)
-		return fmt.Errorf("failed to set machine type: %w", err)
+		return fmt.Errorf("failed to set machine type: %w", asAPIError(asCapabilityError(err, vm, model.CapabilityChangeMachineType)))
 	}
 
 	r.logger.Infof("Setting machine type to %s for instance %s", machineType, vm.Name)
@@ -257,6 +536,340 @@ func (r *VMRepository) UpdateMachineType(ctx context.Context, vm *model.VM, mach
 	return nil
 }
 
+// GetRaw retrieves the full instance resource as indented JSON.
+func (r *VMRepository) GetRaw(ctx context.Context, vm *model.VM) ([]byte, error) {
+	instance, err := r.getInstance(ctx, vm)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(instance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal instance: %w", err)
+	}
+	return data, nil
+}
+
+// SetLabels replaces all labels of a VM instance.
+// The current label fingerprint is fetched first to satisfy the API's optimistic locking.
+func (r *VMRepository) SetLabels(ctx context.Context, vm *model.VM, labels map[string]string) error {
+	return r.updateLabels(ctx, vm, func(map[string]string) map[string]string { return labels })
+}
+
+// UpdateLabels sets the labels in set and removes the keys in remove, keeping all
+// other labels of the VM instance.
+func (r *VMRepository) UpdateLabels(ctx context.Context, vm *model.VM, set map[string]string, remove []string) error {
+	return r.updateLabels(ctx, vm, func(current map[string]string) map[string]string {
+		return mergeItems(current, set, remove)
+	})
+}
+
+// updateLabels sends the labels built by mutate from the instance's current labels,
+// together with the label fingerprint they were read with.
+func (r *VMRepository) updateLabels(ctx context.Context, vm *model.VM, mutate func(current map[string]string) map[string]string) error {
+	return r.setWithFingerprint(ctx, vm, "labels", func(ctx context.Context, instance *computepb.Instance) (*compute.Operation, error) {
+		return r.instancesClient.SetLabels(ctx, &computepb.SetLabelsInstanceRequest{
+			Project:  vm.Project,
+			Zone:     vm.Zone,
+			Instance: vm.Name,
+			InstancesSetLabelsRequestResource: &computepb.InstancesSetLabelsRequest{
+				LabelFingerprint: proto.String(instance.GetLabelFingerprint()),
+				Labels:           mutate(instance.GetLabels()),
+			},
+		})
+	})
+}
+
+// GetMetadata returns the metadata items of a VM instance.
+func (r *VMRepository) GetMetadata(ctx context.Context, vm *model.VM) (map[string]string, error) {
+	instance, err := r.getInstance(ctx, vm)
+	if err != nil {
+		return nil, err
+	}
+	return metadataItems(instance.GetMetadata()), nil
+}
+
+// SetMetadata replaces all metadata items of a VM instance.
+// The current metadata fingerprint is fetched first to satisfy the API's optimistic locking.
+func (r *VMRepository) SetMetadata(ctx context.Context, vm *model.VM, items map[string]string) error {
+	return r.updateMetadata(ctx, vm, func(map[string]string) map[string]string { return items })
+}
+
+// UpdateMetadata sets the items in set and removes the keys in remove, keeping all
+// other metadata items of the VM instance.
+func (r *VMRepository) UpdateMetadata(ctx context.Context, vm *model.VM, set map[string]string, remove []string) error {
+	return r.updateMetadata(ctx, vm, func(current map[string]string) map[string]string {
+		return mergeItems(current, set, remove)
+	})
+}
+
+// updateMetadata sends the metadata items built by mutate from the instance's current
+// items, together with the metadata fingerprint they were read with.
+func (r *VMRepository) updateMetadata(ctx context.Context, vm *model.VM, mutate func(current map[string]string) map[string]string) error {
+	return r.setWithFingerprint(ctx, vm, "metadata", func(ctx context.Context, instance *computepb.Instance) (*compute.Operation, error) {
+		items := mutate(metadataItems(instance.GetMetadata()))
+		metadata := make([]*computepb.Items, 0, len(items))
+		for _, key := range sortedKeys(items) {
+			metadata = append(metadata, &computepb.Items{
+				Key:   proto.String(key),
+				Value: proto.String(items[key]),
+			})
+		}
+		return r.instancesClient.SetMetadata(ctx, &computepb.SetMetadataInstanceRequest{
+			Project:  vm.Project,
+			Zone:     vm.Zone,
+			Instance: vm.Name,
+			MetadataResource: &computepb.Metadata{
+				Fingerprint: proto.String(instance.GetMetadata().GetFingerprint()),
+				Items:       metadata,
+			},
+		})
+	})
+}
+
+// setWithFingerprint fetches the instance and sends the request built from it by send.
+// The request carries the fingerprint of the fetched instance, so the API rejects it if
+// the field was changed concurrently; the instance is then fetched and the request built
+// again, up to maxFingerprintAttempts times.
+func (r *VMRepository) setWithFingerprint(ctx context.Context, vm *model.VM, field string, send func(context.Context, *computepb.Instance) (*compute.Operation, error)) error {
+	var op *compute.Operation
+	for attempt := 1; ; attempt++ {
+		instance, err := r.getInstance(ctx, vm)
+		if err != nil {
+			return err
+		}
+
+		callCtx, cancel := r.timeouts.callContext(ctx)
+		op, err = send(callCtx, instance)
+		cancel()
+		if err == nil {
+			break
+		}
+		if isFingerprintConflict(err) && attempt < maxFingerprintAttempts {
+			r.logger.Warnf("The %s of instance %s changed concurrently, retrying", field, vm.Name)
+			continue
+		}
+		r.logger.Errorf("Failed to set %s: %v", field, err)
+		return fmt.Errorf("failed to set %s: %w", field, asAPIError(err))
+	}
+
+	r.logger.Infof("Setting %s for instance %s", field, vm.Name)
+
+	if err := r.waitOperator(ctx, op); err != nil {
+		r.logger.Errorf("failed to wait for operation: %v", err)
+		return fmt.Errorf("operation failed: %w", err)
+	}
+
+	return nil
+}
+
+// mergeItems returns a copy of current with the entries of set added or overwritten
+// and the keys in remove deleted.
+func mergeItems(current, set map[string]string, remove []string) map[string]string {
+	merged := make(map[string]string, len(current)+len(set))
+	for k, v := range current {
+		merged[k] = v
+	}
+	for k, v := range set {
+		merged[k] = v
+	}
+	for _, k := range remove {
+		delete(merged, k)
+	}
+	return merged
+}
+
+// metadataItems returns the metadata items as a map.
+func metadataItems(metadata *computepb.Metadata) map[string]string {
+	items := make(map[string]string, len(metadata.GetItems()))
+	for _, item := range metadata.GetItems() {
+		items[item.GetKey()] = item.GetValue()
+	}
+	return items
+}
+
+// SetAccelerators replaces the guest accelerators (GPUs) of a VM instance.
+// GPUs cannot live-migrate, so before attaching any the on-host-maintenance
+// policy is switched to TERMINATE if needed.
+func (r *VMRepository) SetAccelerators(ctx context.Context, vm *model.VM, accelerators []model.Accelerator) error {
+	if len(accelerators) > 0 {
+		if err := r.terminateOnHostMaintenance(ctx, vm); err != nil {
+			return err
+		}
+	}
+
+	guestAccelerators := make([]*computepb.AcceleratorConfig, 0, len(accelerators))
+	for _, a := range accelerators {
+		guestAccelerators = append(guestAccelerators, &computepb.AcceleratorConfig{
+			AcceleratorType:  proto.String(fmt.Sprintf("projects/%s/zones/%s/acceleratorTypes/%s", vm.Project, vm.Zone, a.Type)),
+			AcceleratorCount: proto.Int32(int32(a.Count)), //nolint:gosec // counts are validated to be small
+		})
+	}
+
+	req := &computepb.SetMachineResourcesInstanceRequest{
+		Project:  vm.Project,
+		Zone:     vm.Zone,
+		Instance: vm.Name,
+		InstancesSetMachineResourcesRequestResource: &computepb.InstancesSetMachineResourcesRequest{
+			GuestAccelerators: guestAccelerators,
+		},
+	}
+
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	op, err := r.instancesClient.SetMachineResources(callCtx, req)
+	cancel()
+	if err != nil {
+		r.logger.Errorf("Failed to set accelerators: %v", err)
+		return fmt.Errorf("failed to set accelerators: %w", asAPIError(asCapabilityError(err, vm, model.CapabilityGPU)))
+	}
+
+	r.logger.Infof("Setting accelerators for instance %s", vm.Name)
+
+	if err = r.waitOperator(ctx, op); err != nil {
+		r.logger.Errorf("failed to wait for operation: %v", err)
+		return fmt.Errorf("operation failed: %w", err)
+	}
+
+	return nil
+}
+
+// terminateOnHostMaintenance sets the on-host-maintenance policy of a VM to TERMINATE,
+// which GCE requires for VMs with GPUs. Other scheduling options are preserved.
+func (r *VMRepository) terminateOnHostMaintenance(ctx context.Context, vm *model.VM) error {
+	instance, err := r.getInstance(ctx, vm)
+	if err != nil {
+		return err
+	}
+	current := instance.GetScheduling()
+	if current.GetOnHostMaintenance() == "TERMINATE" {
+		return nil
+	}
+
+	desired := &computepb.Scheduling{}
+	if current != nil {
+		desired = proto.Clone(current).(*computepb.Scheduling)
+	}
+	desired.OnHostMaintenance = proto.String("TERMINATE")
+
+	r.logger.Infof("GPUs require on-host-maintenance TERMINATE; updating scheduling of instance %s", vm.Name)
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	op, err := r.instancesClient.SetScheduling(callCtx, &computepb.SetSchedulingInstanceRequest{
+		Project:            vm.Project,
+		Zone:               vm.Zone,
+		Instance:           vm.Name,
+		SchedulingResource: desired,
+	})
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to set on-host-maintenance to TERMINATE: %w", asAPIError(err))
+	}
+	if err = r.waitOperator(ctx, op); err != nil {
+		return fmt.Errorf("operation failed: %w", err)
+	}
+	return nil
+}
+
+// SetScheduling updates the scheduling options of a VM instance.
+// Fields not represented in model.Scheduling (e.g., node affinities) are preserved.
+func (r *VMRepository) SetScheduling(ctx context.Context, vm *model.VM, scheduling model.Scheduling) error {
+	return r.updateScheduling(ctx, vm, func(desired *computepb.Scheduling) {
+		desired.AutomaticRestart = scheduling.AutomaticRestart
+		desired.OnHostMaintenance = optionalString(scheduling.OnHostMaintenance)
+		desired.ProvisioningModel = optionalString(scheduling.ProvisioningModel)
+		desired.InstanceTerminationAction = optionalString(scheduling.InstanceTerminationAction)
+		desired.Preemptible = proto.Bool(scheduling.Preemptible)
+	})
+}
+
+// SetSchedulingOptions updates the automatic restart and on-host-maintenance options
+// set in opts. All other scheduling fields keep their current value.
+func (r *VMRepository) SetSchedulingOptions(ctx context.Context, vm *model.VM, opts model.SchedulingOptions) error {
+	return r.updateScheduling(ctx, vm, func(desired *computepb.Scheduling) {
+		if opts.AutomaticRestart != nil {
+			desired.AutomaticRestart = proto.Bool(*opts.AutomaticRestart)
+		}
+		if opts.OnHostMaintenance != "" {
+			desired.OnHostMaintenance = proto.String(opts.OnHostMaintenance)
+		}
+	})
+}
+
+// updateScheduling applies mutate to a copy of the instance's current scheduling
+// and sends the result back, so fields mutate leaves alone are preserved.
+func (r *VMRepository) updateScheduling(ctx context.Context, vm *model.VM, mutate func(*computepb.Scheduling)) error {
+	instance, err := r.getInstance(ctx, vm)
+	if err != nil {
+		return err
+	}
+
+	current := instance.GetScheduling()
+	if current == nil {
+		current = &computepb.Scheduling{}
+	}
+	desired := proto.Clone(current).(*computepb.Scheduling)
+	mutate(desired)
+
+	req := &computepb.SetSchedulingInstanceRequest{
+		Project:            vm.Project,
+		Zone:               vm.Zone,
+		Instance:           vm.Name,
+		SchedulingResource: desired,
+	}
+
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	op, err := r.instancesClient.SetScheduling(callCtx, req)
+	cancel()
+	if err != nil {
+		r.logger.Errorf("Failed to set scheduling: %v", err)
+		return fmt.Errorf("failed to set scheduling: %w", asAPIError(err))
+	}
+
+	r.logger.Infof("Setting scheduling for instance %s", vm.Name)
+
+	if err = r.waitOperator(ctx, op); err != nil {
+		r.logger.Errorf("failed to wait for operation: %v", err)
+		return fmt.Errorf("operation failed: %w", err)
+	}
+
+	return nil
+}
+
+// getInstance fetches the raw instance resource for vm.
+func (r *VMRepository) getInstance(ctx context.Context, vm *model.VM) (*computepb.Instance, error) {
+	req := &computepb.GetInstanceRequest{
+		Project:  vm.Project,
+		Zone:     vm.Zone,
+		Instance: vm.Name,
+	}
+
+	callCtx, cancel := r.timeouts.callContext(ctx)
+	instance, err := r.instancesClient.Get(callCtx, req)
+	cancel()
+	if err != nil {
+		r.logger.Errorf("failed to get instance: %v", err)
+		return nil, fmt.Errorf("failed to get instance: %w", asAPIError(err))
+	}
+	return instance, nil
+}
+
+// optionalString returns nil for an empty string so the field is omitted from API requests.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// sortedKeys returns the keys of m in ascending order for deterministic requests.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // toModel converts a GCP instance to domain model
 func (r *VMRepository) toModel(ctx context.Context, instance *computepb.Instance) (*model.VM, error) {
 	vm := &model.VM{
@@ -276,71 +889,100 @@ func (r *VMRepository) toModel(ctx context.Context, instance *computepb.Instance
 	}
 	vm.Project = project
 	vm.Zone = zone
-
-	// Parse start time
-	if startTimeStr := instance.GetLastStartTimestamp(); startTimeStr != "" {
-		if startTime, parseErr := time.Parse(time.RFC3339, startTimeStr); parseErr == nil {
-			vm.LastStartTime = &startTime
-		}
+	if id := instance.GetId(); id != 0 {
+		vm.ID = strconv.FormatUint(id, 10)
 	}
+	vm.InternalIP, vm.ExternalIP = extractIPs(instance)
+	vm.NetworkInterfaces = extractNetworkInterfaces(instance)
+	vm.ServiceAccount = extractServiceAccount(instance)
+	vm.Scheduling = extractScheduling(instance)
+	vm.Disks = extractDisks(instance, zone)
+	vm.Accelerators = extractAccelerators(instance)
+	vm.Labels = instance.GetLabels()
+	vm.Windows = isWindows(instance)
+
+	vm.LastStartTime = parseTimestamp(instance.GetLastStartTimestamp())
+	vm.LastStopTime = parseTimestamp(instance.GetLastStopTimestamp())
+	vm.CreationTime = parseTimestamp(instance.GetCreationTimestamp())
+	vm.UpcomingMaintenance = upcomingMaintenance(instance)
 
 	// Get schedule policy (existing logic)
 	r.logger.Debugf("Getting schedule policy for instance %s", vm.Name)
-	schedulePolicy, err := r.getSchedulePolicy(ctx, instance)
+	schedulePolicy, schedule, err := r.getSchedulePolicy(ctx, instance)
 	if err != nil {
 		r.logger.Errorf("Failed to get schedule policy: %v", err)
 		return nil, err
 	}
 	vm.SchedulePolicy = schedulePolicy
+	vm.Schedule = schedule
 
 	return vm, nil
 }
 
-func (r *VMRepository) getSchedulePolicy(ctx context.Context, instance *computepb.Instance) (string, error) {
+// getSchedulePolicy returns the formatted name of the first instance schedule policy
+// attached to instance together with its parsed schedule.
+func (r *VMRepository) getSchedulePolicy(ctx context.Context, instance *computepb.Instance) (string, *model.SchedulePolicy, error) {
 	policies := instance.GetResourcePolicies()
 	if len(policies) == 0 {
-		return "", nil
+		return "", nil, nil
 	}
 
 	project, err := extractProject(instance.GetSelfLink())
 	if err != nil {
 		r.logger.Errorf("Failed to get project from instance: %v", err)
-		return "", err
+		return "", nil, err
 	}
 
 	region, err := extractRegion(instance.GetZone())
 	if err != nil {
 		r.logger.Errorf("Failed to get region from instance: %v", err)
-		return "", err
+		return "", nil, err
 	}
 
-	// 順次処理（ポリシー数は通常少ないため）
-	for _, policy := range policies {
-		r.logger.Debugf("Resource Policy: %s", policy)
-
-		policyParts := strings.Split(policy, "/")
-		policyName := policyParts[len(policyParts)-1]
-
-		policyReq := &computepb.GetResourcePolicyRequest{
-			Project:        project,
-			Region:         region,
-			ResourcePolicy: policyName,
-		}
+	// ポリシーは並列に取得し、同じself-linkの取得はpolicyCacheで重複排除する
+	resourcePolicies := make([]*computepb.ResourcePolicy, len(policies))
+	var wg sync.WaitGroup
+	for i, policy := range policies {
+		wg.Add(1)
+		go func(i int, policy string) {
+			defer wg.Done()
+			r.logger.Debugf("Resource Policy: %s", policy)
+
+			policyReq := &computepb.GetResourcePolicyRequest{
+				Project:        project,
+				Region:         region,
+				ResourcePolicy: policyNameOf(policy),
+			}
+			resourcePolicy, getErr := r.policies.get(policy, func() (*computepb.ResourcePolicy, error) {
+				callCtx, cancel := r.timeouts.callContext(ctx)
+				defer cancel()
+				return r.resourcePoliciesClient.Get(callCtx, policyReq)
+			})
+			if getErr != nil {
+				r.logger.Errorf("Failed to get resource policy details: %v", getErr)
+				return
+			}
+			resourcePolicies[i] = resourcePolicy
+		}(i, policy)
+	}
+	wg.Wait()
 
-		var resourcePolicy *computepb.ResourcePolicy
-		resourcePolicy, err = r.resourcePoliciesClient.Get(ctx, policyReq)
-		if err != nil {
-			r.logger.Errorf("Failed to get resource policy details: %v", err)
+	for i, resourcePolicy := range resourcePolicies {
+		if resourcePolicy == nil {
 			continue
 		}
-
 		schedulePolicy := resourcePolicy.GetInstanceSchedulePolicy()
-		if formattedPolicy := formatInstanceSchedulePolicy(policyName, schedulePolicy); formattedPolicy != "" {
-			return formattedPolicy, nil
+		if formattedPolicy := formatInstanceSchedulePolicy(policyNameOf(policies[i]), schedulePolicy); formattedPolicy != "" {
+			return formattedPolicy, toSchedulePolicy(resourcePolicy, project, region), nil
 		}
 	}
 
-	return "", nil
+	return "", nil, nil
+}
+
+// policyNameOf returns the last path segment of a resource policy self-link.
+func policyNameOf(selfLink string) string {
+	return selfLink[strings.LastIndex(selfLink, "/")+1:]
 }
 
 func formatInstanceSchedulePolicy(policyName string, schedulePolicy *computepb.ResourcePolicyInstanceSchedulePolicy) string {
@@ -357,6 +999,128 @@ func formatInstanceSchedulePolicy(policyName string, schedulePolicy *computepb.R
 	return fmt.Sprintf("%s(%s)", policyName, schedule)
 }
 
+// extractScheduling returns the scheduling options of the instance, or nil when absent.
+func extractScheduling(instance *computepb.Instance) *model.Scheduling {
+	scheduling := instance.GetScheduling()
+	if scheduling == nil {
+		return nil
+	}
+	return &model.Scheduling{
+		AutomaticRestart:          scheduling.AutomaticRestart,
+		OnHostMaintenance:         scheduling.GetOnHostMaintenance(),
+		ProvisioningModel:         scheduling.GetProvisioningModel(),
+		InstanceTerminationAction: scheduling.GetInstanceTerminationAction(),
+		Preemptible:               scheduling.GetPreemptible(),
+	}
+}
+
+// extractAccelerators returns the GPUs attached to the instance.
+func extractAccelerators(instance *computepb.Instance) []model.Accelerator {
+	configs := instance.GetGuestAccelerators()
+	if len(configs) == 0 {
+		return nil
+	}
+	accelerators := make([]model.Accelerator, 0, len(configs))
+	for _, c := range configs {
+		accelerators = append(accelerators, model.Accelerator{
+			Type:  policyNameOf(c.GetAcceleratorType()),
+			Count: int(c.GetAcceleratorCount()),
+		})
+	}
+	return accelerators
+}
+
+// extractIPs returns the internal and external IPs of the instance's primary network interface.
+// The external IP is empty when the interface has no access config or the VM is stopped
+// with an ephemeral address.
+func extractIPs(instance *computepb.Instance) (internalIP, externalIP string) {
+	nics := instance.GetNetworkInterfaces()
+	if len(nics) == 0 {
+		return "", ""
+	}
+	internalIP = nics[0].GetNetworkIP()
+	for _, ac := range nics[0].GetAccessConfigs() {
+		if natIP := ac.GetNatIP(); natIP != "" {
+			externalIP = natIP
+			break
+		}
+	}
+	return internalIP, externalIP
+}
+
+// extractNetworkInterfaces returns the network interfaces of the instance, primary first.
+func extractNetworkInterfaces(instance *computepb.Instance) []model.NetworkInterface {
+	nics := instance.GetNetworkInterfaces()
+	if len(nics) == 0 {
+		return nil
+	}
+	interfaces := make([]model.NetworkInterface, 0, len(nics))
+	for _, nic := range nics {
+		ni := model.NetworkInterface{
+			Name:       nic.GetName(),
+			Network:    policyNameOf(nic.GetNetwork()),
+			Subnetwork: policyNameOf(nic.GetSubnetwork()),
+			InternalIP: nic.GetNetworkIP(),
+		}
+		for _, ac := range nic.GetAccessConfigs() {
+			if natIP := ac.GetNatIP(); natIP != "" {
+				ni.ExternalIP = natIP
+				break
+			}
+		}
+		interfaces = append(interfaces, ni)
+	}
+	return interfaces
+}
+
+// extractServiceAccount returns the first service account of the instance (GCE
+// allows only one), or nil when the instance runs without one.
+func extractServiceAccount(instance *computepb.Instance) *model.ServiceAccount {
+	accounts := instance.GetServiceAccounts()
+	if len(accounts) == 0 || accounts[0].GetEmail() == "" {
+		return nil
+	}
+	return &model.ServiceAccount{
+		Email:  accounts[0].GetEmail(),
+		Scopes: accounts[0].GetScopes(),
+	}
+}
+
+// upcomingMaintenance returns the start of the maintenance window scheduled for
+// the instance, or its latest start when the window is not fixed yet, or nil when
+// no maintenance is scheduled.
+func upcomingMaintenance(instance *computepb.Instance) *time.Time {
+	m := instance.GetResourceStatus().GetUpcomingMaintenance()
+	if m == nil {
+		return nil
+	}
+	if start := parseTimestamp(m.GetWindowStartTime()); start != nil {
+		return start
+	}
+	return parseTimestamp(m.GetLatestWindowStartTime())
+}
+
+// isWindows reports whether the boot disk of the instance runs Windows, from its
+// WINDOWS guest OS feature or a license of the windows-cloud image project.
+func isWindows(instance *computepb.Instance) bool {
+	for _, disk := range instance.GetDisks() {
+		if !disk.GetBoot() {
+			continue
+		}
+		for _, feature := range disk.GetGuestOsFeatures() {
+			if feature.GetType() == "WINDOWS" {
+				return true
+			}
+		}
+		for _, license := range disk.GetLicenses() {
+			if strings.Contains(license, "/projects/windows-cloud/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func extractMachineType(fullURI string) string {
 	pattern := `machineTypes/([^/]+)`
 	re := regexp.MustCompile(pattern)
@@ -404,12 +1168,11 @@ func extractRegion(zoneURI string) (string, error) {
 	return zoneName[:lastHyphen], nil
 }
 
-// waitOperator waits for the operation to complete and optionally reports progress.
+// waitOperator waits for the operation to complete and reports its progress.
 //
-// This method monitors a GCP compute operation until completion. If a progress callback
-// has been set via SetProgressCallback(), it will be called every second during the wait.
-// This allows the presentation layer to display progress (e.g., dots) without violating
-// Clean Architecture principles.
+// While the operation runs its events are sent to the OperationEvents channel of
+// the client settings, which lets the presentation layer show the progress GCE
+// reports without the repository depending on it.
 //
 // Parameters:
 //   - ctx: Context for cancellation and timeout control
@@ -417,16 +1180,13 @@ func extractRegion(zoneURI string) (string, error) {
 //
 // Returns:
 //   - error: Error if the operation fails or context is canceled
-//
-// Example:
-//
-//	repo.SetProgressCallback(console.Progress)
-//	err := repo.waitOperator(ctx, operation)
 func (r *VMRepository) waitOperator(ctx context.Context, op *compute.Operation) error {
 	if op == nil {
 		return fmt.Errorf("operation is nil")
 	}
-	return op.Wait(ctx)
+	ctx, cancel := r.timeouts.operationContext(ctx)
+	defer cancel()
+	return asAPIError(waitObserved(ctx, r.events, op))
 }
 
 var _ repository.VMRepository = (*VMRepository)(nil)
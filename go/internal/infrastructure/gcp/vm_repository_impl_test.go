@@ -40,7 +40,7 @@ func TestVMRepositoryImpl_FindByName(t *testing.T) {
 	cnf, _ := getCnf(t)
 
 	ctx := context.Background()
-	repo, err := gcp.NewVMRepository(ctx, logger)
+	repo, err := gcp.NewVMRepository(ctx, logger, gcp.ClientSettings{})
 	require.NoError(t, err)
 	defer func() {
 		_ = repo.Close()
@@ -98,7 +98,7 @@ func TestVMRepositoryImpl_StartStop(t *testing.T) {
 
 	cnf, _ := getCnf(t)
 	ctx := context.Background()
-	repo, err := gcp.NewVMRepository(ctx, logger)
+	repo, err := gcp.NewVMRepository(ctx, logger, gcp.ClientSettings{})
 	require.NoError(t, err)
 	defer func() {
 		_ = repo.Close()
@@ -183,7 +183,7 @@ func TestVMRepositoryImpl_UpdateMachineType(t *testing.T) {
 
 	cnf, _ := getCnf(t)
 	ctx := context.Background()
-	repo, err := gcp.NewVMRepository(ctx, logger)
+	repo, err := gcp.NewVMRepository(ctx, logger, gcp.ClientSettings{})
 	require.NoError(t, err)
 	defer func() {
 		_ = repo.Close()
@@ -267,7 +267,7 @@ func TestVMRepositoryImpl_SchedulePolicy(t *testing.T) {
 
 	cnf, _ := getCnf(t)
 	ctx := context.Background()
-	repo, err := gcp.NewVMRepository(ctx, logger)
+	repo, err := gcp.NewVMRepository(ctx, logger, gcp.ClientSettings{})
 	require.NoError(t, err)
 	defer func() {
 		_ = repo.Close()
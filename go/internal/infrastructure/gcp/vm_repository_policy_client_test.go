@@ -3,6 +3,8 @@ package gcp
 import (
 	"context"
 	"errors"
+	"net/http"
+	"sync/atomic"
 	"testing"
 
 	compute "cloud.google.com/go/compute/apiv1"
@@ -11,18 +13,30 @@ import (
 	"github.com/haru-256/gcectl/internal/domain/model"
 	"github.com/haru-256/gcectl/internal/infrastructure/log"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
 )
 
 type fakeInstancesClient struct {
 	instance *computepb.Instance
-	closed   bool
 	closeErr error
+	// setLabelsErrs are returned by successive SetLabels calls; later calls succeed.
+	setLabelsErrs []error
+	setLabelsReqs []*computepb.SetLabelsInstanceRequest
+	// setMetadataErrs are returned by successive SetMetadata calls; later calls succeed.
+	setMetadataErrs []error
+	setMetadataReqs []*computepb.SetMetadataInstanceRequest
+	closed          bool
 }
 
 func (c *fakeInstancesClient) Get(context.Context, *computepb.GetInstanceRequest, ...gax.CallOption) (*computepb.Instance, error) {
 	return c.instance, nil
 }
 
+func (c *fakeInstancesClient) AggregatedList(context.Context, *computepb.AggregatedListInstancesRequest, ...gax.CallOption) *compute.InstancesScopedListPairIterator {
+	return nil
+}
+
 func (c *fakeInstancesClient) Start(context.Context, *computepb.StartInstanceRequest, ...gax.CallOption) (*compute.Operation, error) {
 	return nil, nil
 }
@@ -31,6 +45,10 @@ func (c *fakeInstancesClient) Stop(context.Context, *computepb.StopInstanceReque
 	return nil, nil
 }
 
+func (c *fakeInstancesClient) Reset(context.Context, *computepb.ResetInstanceRequest, ...gax.CallOption) (*compute.Operation, error) {
+	return nil, nil
+}
+
 func (c *fakeInstancesClient) AddResourcePolicies(context.Context, *computepb.AddResourcePoliciesInstanceRequest, ...gax.CallOption) (*compute.Operation, error) {
 	return nil, nil
 }
@@ -43,6 +61,34 @@ func (c *fakeInstancesClient) SetMachineType(context.Context, *computepb.SetMach
 	return nil, nil
 }
 
+func (c *fakeInstancesClient) SetLabels(_ context.Context, req *computepb.SetLabelsInstanceRequest, _ ...gax.CallOption) (*compute.Operation, error) {
+	c.setLabelsReqs = append(c.setLabelsReqs, req)
+	if len(c.setLabelsErrs) > 0 {
+		err := c.setLabelsErrs[0]
+		c.setLabelsErrs = c.setLabelsErrs[1:]
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (c *fakeInstancesClient) SetMetadata(_ context.Context, req *computepb.SetMetadataInstanceRequest, _ ...gax.CallOption) (*compute.Operation, error) {
+	c.setMetadataReqs = append(c.setMetadataReqs, req)
+	if len(c.setMetadataErrs) > 0 {
+		err := c.setMetadataErrs[0]
+		c.setMetadataErrs = c.setMetadataErrs[1:]
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (c *fakeInstancesClient) SetScheduling(context.Context, *computepb.SetSchedulingInstanceRequest, ...gax.CallOption) (*compute.Operation, error) {
+	return nil, nil
+}
+
+func (c *fakeInstancesClient) SetMachineResources(context.Context, *computepb.SetMachineResourcesInstanceRequest, ...gax.CallOption) (*compute.Operation, error) {
+	return nil, nil
+}
+
 func (c *fakeInstancesClient) Close() error {
 	c.closed = true
 	return c.closeErr
@@ -50,11 +96,13 @@ func (c *fakeInstancesClient) Close() error {
 
 type fakeResourcePoliciesClient struct {
 	policy   *computepb.ResourcePolicy
-	closed   bool
 	closeErr error
+	gets     int32
+	closed   bool
 }
 
 func (c *fakeResourcePoliciesClient) Get(context.Context, *computepb.GetResourcePolicyRequest, ...gax.CallOption) (*computepb.ResourcePolicy, error) {
+	atomic.AddInt32(&c.gets, 1)
 	return c.policy, nil
 }
 
@@ -110,3 +158,197 @@ func TestVMRepositoryFindByNameUsesInjectedInstancesClient(t *testing.T) {
 	require.Equal(t, "test-project", vm.Project)
 	require.Equal(t, "us-central1-a", vm.Zone)
 }
+
+func TestVMRepositoryFindByNamePopulatesIPs(t *testing.T) {
+	instancesClient := &fakeInstancesClient{
+		instance: &computepb.Instance{
+			Name:     stringPtr("sandbox-1"),
+			SelfLink: stringPtr("https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a/instances/sandbox-1"),
+			Zone:     stringPtr("https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a"),
+			Status:   stringPtr("RUNNING"),
+			NetworkInterfaces: []*computepb.NetworkInterface{
+				{
+					NetworkIP: stringPtr("10.128.0.2"),
+					AccessConfigs: []*computepb.AccessConfig{
+						{NatIP: stringPtr("34.1.2.3")},
+					},
+				},
+			},
+		},
+	}
+	repo := newVMRepository(log.NewLogger(), instancesClient, &fakeResourcePoliciesClient{})
+
+	vm, err := repo.FindByName(context.Background(), &model.VM{
+		Project: "test-project",
+		Zone:    "us-central1-a",
+		Name:    "sandbox-1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "10.128.0.2", vm.InternalIP)
+	require.Equal(t, "34.1.2.3", vm.ExternalIP)
+}
+
+func TestVMRepositoryUpdateLabelsKeepsOtherLabels(t *testing.T) {
+	instancesClient := &fakeInstancesClient{
+		instance: &computepb.Instance{
+			Labels:           map[string]string{"team": "ml", "env": "dev", "owner": "alice"},
+			LabelFingerprint: stringPtr("fp-1"),
+		},
+		setLabelsErrs: []error{&googleapi.Error{Code: http.StatusPreconditionFailed}},
+	}
+	repo := newVMRepository(log.NewLogger(), instancesClient, &fakeResourcePoliciesClient{})
+
+	vm := &model.VM{Project: "test-project", Zone: "us-central1-a", Name: "sandbox-1"}
+	err := repo.UpdateLabels(context.Background(), vm, map[string]string{"env": "prod", "cost": "research"}, []string{"owner"})
+	require.ErrorContains(t, err, "operation is nil")
+
+	require.Len(t, instancesClient.setLabelsReqs, 2, "an outdated fingerprint is retried")
+	req := instancesClient.setLabelsReqs[1]
+	require.Equal(t, "fp-1", req.GetInstancesSetLabelsRequestResource().GetLabelFingerprint())
+	require.Equal(t, map[string]string{"team": "ml", "env": "prod", "cost": "research"}, req.GetInstancesSetLabelsRequestResource().GetLabels())
+	require.Equal(t, map[string]string{"team": "ml", "env": "dev", "owner": "alice"}, instancesClient.instance.GetLabels(), "the fetched labels are not modified")
+
+	instancesClient.setLabelsReqs = nil
+	instancesClient.setLabelsErrs = []error{&googleapi.Error{Code: http.StatusForbidden}}
+	err = repo.UpdateLabels(context.Background(), vm, map[string]string{"env": "prod"}, nil)
+	require.ErrorContains(t, err, "failed to set labels")
+	require.Len(t, instancesClient.setLabelsReqs, 1, "other errors are not retried")
+}
+
+func TestVMRepositoryMetadata(t *testing.T) {
+	instancesClient := &fakeInstancesClient{
+		instance: &computepb.Instance{
+			Metadata: &computepb.Metadata{
+				Fingerprint: stringPtr("fp-1"),
+				Items: []*computepb.Items{
+					{Key: stringPtr("enable-oslogin"), Value: stringPtr("TRUE")},
+					{Key: stringPtr("startup-script"), Value: stringPtr("#!/bin/bash")},
+				},
+			},
+		},
+		setMetadataErrs: []error{&googleapi.Error{Code: http.StatusPreconditionFailed}},
+	}
+	repo := newVMRepository(log.NewLogger(), instancesClient, &fakeResourcePoliciesClient{})
+	vm := &model.VM{Project: "test-project", Zone: "us-central1-a", Name: "sandbox-1"}
+
+	items, err := repo.GetMetadata(context.Background(), vm)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"enable-oslogin": "TRUE", "startup-script": "#!/bin/bash"}, items)
+
+	err = repo.UpdateMetadata(context.Background(), vm, map[string]string{"team": "ml"}, []string{"startup-script"})
+	require.ErrorContains(t, err, "operation is nil")
+	require.Len(t, instancesClient.setMetadataReqs, 2, "an outdated fingerprint is retried")
+	metadata := instancesClient.setMetadataReqs[1].GetMetadataResource()
+	require.Equal(t, "fp-1", metadata.GetFingerprint())
+	require.Len(t, metadata.GetItems(), 2)
+	require.Equal(t, "enable-oslogin", metadata.GetItems()[0].GetKey(), "items are sorted by key")
+	require.Equal(t, "team", metadata.GetItems()[1].GetKey())
+	require.Equal(t, "ml", metadata.GetItems()[1].GetValue())
+}
+
+func TestVMRepositoryGetRawReturnsInstanceJSON(t *testing.T) {
+	instancesClient := &fakeInstancesClient{
+		instance: &computepb.Instance{
+			Name:   stringPtr("sandbox-1"),
+			Labels: map[string]string{"env": "dev"},
+		},
+	}
+	repo := newVMRepository(log.NewLogger(), instancesClient, &fakeResourcePoliciesClient{})
+
+	data, err := repo.GetRaw(context.Background(), &model.VM{Project: "test-project", Zone: "us-central1-a", Name: "sandbox-1"})
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"sandbox-1"`)
+	require.Contains(t, string(data), `"env"`)
+}
+
+func TestAggregatedListRequest(t *testing.T) {
+	vms := []*model.VM{
+		{Project: "p1", Zone: "us-central1-a", Name: "web-1"},
+		{Project: "p2", Zone: "us-central1-a", Name: "db-1"},
+		{Project: "p1", Zone: "us-west1-b", Name: "web.2"},
+		{Project: "p1", Zone: "us-west1-b", Name: "web-1"},
+	}
+
+	req := aggregatedListRequest("p1", vms)
+	require.Equal(t, "p1", req.GetProject())
+	require.Equal(t, `name eq "(web-1|web\.2)"`, req.GetFilter())
+	require.True(t, req.GetReturnPartialSuccess())
+	require.Equal(t, []string{"p1", "p2"}, projectsOf(vms))
+}
+
+func TestCollectInstances(t *testing.T) {
+	pairs := []compute.InstancesScopedListPair{
+		{Key: "zones/us-central1-a", Value: &computepb.InstancesScopedList{Instances: []*computepb.Instance{{Name: stringPtr("web-1")}}}},
+		{Key: "zones/us-west1-b", Value: &computepb.InstancesScopedList{}},
+	}
+	newNext := func(err error) func() (compute.InstancesScopedListPair, error) {
+		i := 0
+		return func() (compute.InstancesScopedListPair, error) {
+			if i < len(pairs) {
+				i++
+				return pairs[i-1], nil
+			}
+			if err != nil {
+				return compute.InstancesScopedListPair{}, err
+			}
+			return compute.InstancesScopedListPair{}, iterator.Done
+		}
+	}
+
+	instances, err := collectInstances(newNext(nil))
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+	require.Equal(t, "web-1", instances["us-central1-a/web-1"].GetName())
+
+	_, err = collectInstances(newNext(errors.New("page failed")))
+	require.Error(t, err)
+}
+
+func TestLabelFilter(t *testing.T) {
+	require.Equal(t, `(labels.env = "dev") (labels.team = "ml")`, labelFilter(map[string]string{"team": "ml", "env": "dev"}))
+}
+
+func TestSummaryModels(t *testing.T) {
+	status := "RUNNING"
+	instances := map[string]*computepb.Instance{
+		"us-west1-b/web-2": {Name: stringPtr("web-2")},
+		"us-central1-a/web-1": {
+			Name:        stringPtr("web-1"),
+			Status:      &status,
+			MachineType: stringPtr("https://www.googleapis.com/compute/v1/projects/p1/zones/us-central1-a/machineTypes/e2-medium"),
+		},
+	}
+
+	vms := summaryModels("p1", instances)
+	require.Equal(t, []*model.VM{
+		{Name: "web-1", Project: "p1", Zone: "us-central1-a", Status: model.StatusRunning, MachineType: "e2-medium"},
+		{Name: "web-2", Project: "p1", Zone: "us-west1-b", Status: model.StatusUnknown, MachineType: "UNKNOWN"},
+	}, vms)
+}
+
+func TestVMRepositoryFindByNameReusesSharedSchedulePolicy(t *testing.T) {
+	policyLink := "https://www.googleapis.com/compute/v1/projects/test-project/regions/us-central1/resourcePolicies/stop-at-night"
+	instancesClient := &fakeInstancesClient{
+		instance: &computepb.Instance{
+			Name:             stringPtr("sandbox-1"),
+			SelfLink:         stringPtr("https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a/instances/sandbox-1"),
+			Zone:             stringPtr("https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a"),
+			ResourcePolicies: []string{policyLink},
+		},
+	}
+	policyClient := &fakeResourcePoliciesClient{
+		policy: &computepb.ResourcePolicy{
+			InstanceSchedulePolicy: &computepb.ResourcePolicyInstanceSchedulePolicy{
+				VmStopSchedule: &computepb.ResourcePolicyInstanceSchedulePolicySchedule{Schedule: stringPtr("0 22 * * *")},
+			},
+		},
+	}
+	repo := newVMRepository(log.NewLogger(), instancesClient, policyClient)
+
+	for i := 0; i < 3; i++ {
+		vm, err := repo.FindByName(context.Background(), &model.VM{Project: "test-project", Zone: "us-central1-a", Name: "sandbox-1"})
+		require.NoError(t, err)
+		require.Equal(t, "stop-at-night(0 22 * * *)", vm.SchedulePolicy)
+	}
+	require.EqualValues(t, 1, atomic.LoadInt32(&policyClient.gets), "the policy is fetched once and then served from the cache")
+}
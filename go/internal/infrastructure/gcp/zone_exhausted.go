@@ -0,0 +1,24 @@
+package gcp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// zoneExhaustedCode is the error code GCE reports when a zone has no capacity for
+// an instance. The _WITH_DETAILS variant shares the prefix.
+const zoneExhaustedCode = "ZONE_RESOURCE_POOL_EXHAUSTED"
+
+// asZoneExhaustedError marks errors caused by a zone running out of capacity with
+// model.ErrZoneResourceExhausted. Other errors are returned unchanged.
+//
+// The code arrives either in the API error of the request or, more often, in the
+// error of the operation, whose message is all the client keeps of it.
+func asZoneExhaustedError(err error) error {
+	if err == nil || !strings.Contains(err.Error(), zoneExhaustedCode) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", model.ErrZoneResourceExhausted, err)
+}
@@ -0,0 +1,21 @@
+package gcp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsZoneExhaustedError(t *testing.T) {
+	assert.NoError(t, asZoneExhaustedError(nil))
+
+	other := errors.New("quota exceeded")
+	assert.Same(t, other, asZoneExhaustedError(other))
+
+	exhausted := errors.New(`googleapi: Error 503: : errors:{code:"ZONE_RESOURCE_POOL_EXHAUSTED_WITH_DETAILS" message:"The zone does not have enough resources"}`)
+	err := asZoneExhaustedError(exhausted)
+	assert.ErrorIs(t, err, model.ErrZoneResourceExhausted)
+	assert.ErrorIs(t, err, exhausted)
+}
@@ -0,0 +1,161 @@
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/xdg"
+)
+
+// File is a RunEventStore backed by a single JSON file.
+// GCE only keeps operations for a limited time, so like snoozes the history
+// cannot be rebuilt and an unreadable file is an error.
+type File struct {
+	entries map[string][]entry
+	path    string
+	mu      sync.Mutex
+}
+
+// document is the on-disk layout of the history file.
+type document struct {
+	// Events are keyed by project/zone/name of the VM.
+	Events map[string][]entry `json:"events"`
+}
+
+// entry is a single recorded start or stop.
+type entry struct {
+	Time time.Time `json:"time"`
+	ID   string    `json:"id"`
+	Type string    `json:"type"`
+}
+
+// DefaultPath returns the default history file location, $XDG_STATE_HOME/gcectl/history.json
+// (~/.local/state/gcectl/history.json), or the history.json of the user config directory
+// when an earlier version of gcectl keeps it there.
+func DefaultPath() (string, error) {
+	return xdg.StateFile("history.json")
+}
+
+// Open loads the history file at path. A missing file yields an empty store.
+//
+// Parameters:
+//   - path: The history file path
+//
+// Returns:
+//   - *File: The loaded store
+//   - error: An error if the file exists but cannot be read or parsed
+func Open(path string) (*File, error) {
+	f := &File{path: path, entries: make(map[string][]entry)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+
+	var doc document
+	if unmarshalErr := json.Unmarshal(data, &doc); unmarshalErr != nil {
+		return nil, fmt.Errorf("failed to parse history file %s: %w", path, unmarshalErr)
+	}
+	for k, es := range doc.Events {
+		f.entries[k] = es
+	}
+	return f, nil
+}
+
+// Add records events whose ID is not recorded yet and rewrites the file if any was new.
+func (f *File) Add(events ...*model.RunEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	added := false
+	for _, e := range events {
+		k := key(e.Project, e.Zone, e.VMName)
+		if containsID(f.entries[k], e.ID) {
+			continue
+		}
+		f.entries[k] = append(f.entries[k], entry{Time: e.Time, ID: e.ID, Type: string(e.Type)})
+		added = true
+	}
+	if !added {
+		return nil
+	}
+	for k := range f.entries {
+		es := f.entries[k]
+		sort.SliceStable(es, func(i, j int) bool { return es[i].Time.Before(es[j].Time) })
+	}
+	return f.write()
+}
+
+// List returns the recorded events of vm, oldest first.
+func (f *File) List(vm *model.VM) []*model.RunEvent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	es := f.entries[key(vm.Project, vm.Zone, vm.Name)]
+	events := make([]*model.RunEvent, 0, len(es))
+	for _, e := range es {
+		events = append(events, &model.RunEvent{
+			Time:    e.Time,
+			VMName:  vm.Name,
+			Project: vm.Project,
+			Zone:    vm.Zone,
+			ID:      e.ID,
+			Type:    model.RunEventType(e.Type),
+		})
+	}
+	return events
+}
+
+func containsID(es []entry, id string) bool {
+	for _, e := range es {
+		if e.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// write replaces the history file via a temporary file so an interrupted write
+// never leaves a truncated document behind.
+func (f *File) write() error {
+	data, err := json.MarshalIndent(document{Events: f.entries}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode history: %w", err)
+	}
+
+	dir := filepath.Dir(f.path)
+	if mkErr := os.MkdirAll(dir, 0o700); mkErr != nil {
+		return fmt.Errorf("failed to create history directory: %w", mkErr)
+	}
+	tmp, err := os.CreateTemp(dir, ".history-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create history file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, writeErr := tmp.Write(data); writeErr != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write history file: %w", writeErr)
+	}
+	if closeErr := tmp.Close(); closeErr != nil {
+		return fmt.Errorf("failed to write history file: %w", closeErr)
+	}
+	if renameErr := os.Rename(tmp.Name(), f.path); renameErr != nil {
+		return fmt.Errorf("failed to replace history file: %w", renameErr)
+	}
+	return nil
+}
+
+func key(project, zone, name string) string {
+	return project + "/" + zone + "/" + name
+}
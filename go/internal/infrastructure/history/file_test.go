@@ -0,0 +1,37 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_AddList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gcectl", "history.json")
+	vm := &model.VM{Name: "vm-1", Project: "proj", Zone: "us-central1-a"}
+	start := &model.RunEvent{Time: time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC), VMName: "vm-1", Project: "proj", Zone: "us-central1-a", ID: "op-1", Type: model.RunEventStart}
+	stop := &model.RunEvent{Time: time.Date(2025, 1, 2, 17, 0, 0, 0, time.UTC), VMName: "vm-1", Project: "proj", Zone: "us-central1-a", ID: "op-2", Type: model.RunEventStop}
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	require.NoError(t, f.Add(stop, start))
+	require.NoError(t, f.Add(start), "already recorded events are skipped")
+
+	reopened, err := Open(path)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.RunEvent{start, stop}, reopened.List(vm), "events are listed oldest first")
+	assert.Empty(t, reopened.List(&model.VM{Name: "vm-1", Project: "proj", Zone: "us-central1-b"}), "events are keyed by project, zone and name")
+}
+
+func TestOpen_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+	_, err := Open(path)
+	assert.ErrorContains(t, err, "failed to parse history file")
+}
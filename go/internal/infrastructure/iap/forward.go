@@ -0,0 +1,71 @@
+package iap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+)
+
+// Serve accepts connections on ln and forwards each through its own tunnel to t
+// until ctx is done, then closes ln and the open connections and returns nil.
+// Failures of single connections are passed to onError and do not stop Serve.
+func (d *Dialer) Serve(ctx context.Context, ln net.Listener, t Target, onError func(error)) error {
+	stop := context.AfterFunc(ctx, func() { _ = ln.Close() })
+	defer stop()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to accept a connection: %w", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := d.forward(ctx, conn, t); err != nil {
+				onError(err)
+			}
+		}()
+	}
+}
+
+// forward copies between conn and a new tunnel to t until either side closes.
+func (d *Dialer) forward(ctx context.Context, conn net.Conn, t Target) error {
+	defer conn.Close()
+	tunnel, err := d.Dial(ctx, t)
+	if err != nil {
+		return err
+	}
+	defer tunnel.Close()
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+		_ = tunnel.Close()
+	})
+	defer stop()
+
+	errs := make(chan error, 2)
+	go func() {
+		_, copyErr := io.Copy(tunnel, conn)
+		errs <- copyErr
+	}()
+	go func() {
+		_, copyErr := io.Copy(conn, tunnel)
+		errs <- copyErr
+	}()
+	// Either direction ending ends the connection; closing both unblocks the other copy.
+	err = <-errs
+	_ = conn.Close()
+	_ = tunnel.Close()
+	<-errs
+	if err == nil || errors.Is(err, net.ErrClosed) || ctx.Err() != nil {
+		return nil
+	}
+	return fmt.Errorf("connection from %s: %w", conn.RemoteAddr(), err)
+}
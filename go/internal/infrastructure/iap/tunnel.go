@@ -0,0 +1,323 @@
+// Package iap opens TCP tunnels to Compute Engine instances through Identity-Aware
+// Proxy, speaking the relay protocol of tunnel.cloudproxy.app that
+// "gcloud compute start-iap-tunnel" uses, so no gcloud installation is needed.
+package iap
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// relayProtocol is the websocket subprotocol of the IAP relay.
+	relayProtocol = "relay.tunnel.cloudproxy.app"
+	// relayOrigin is the origin the IAP relay expects from tunneling clients.
+	relayOrigin = "bot:iap-tunneler"
+	// defaultInterface is the network interface the tunnel connects to.
+	defaultInterface = "nic0"
+
+	tagConnectSuccessSID   = 0x0001
+	tagReconnectSuccessAck = 0x0002
+	tagData                = 0x0004
+	tagAck                 = 0x0007
+
+	// maxDataFrameSize is the largest payload of a data frame the relay accepts.
+	maxDataFrameSize = 16384
+	// ackInterval is how many received bytes are acknowledged at a time.
+	ackInterval = 2 * maxDataFrameSize
+	// handshakeTimeout bounds connecting to the relay and waiting for the tunnel to open.
+	handshakeTimeout = 30 * time.Second
+)
+
+// defaultEndpoint is the connect URL of the IAP relay.
+var defaultEndpoint = &url.URL{Scheme: "wss", Host: "tunnel.cloudproxy.app", Path: "/v4/connect"}
+
+// Target is the instance port a tunnel connects to.
+type Target struct {
+	Project  string
+	Zone     string
+	Instance string
+	Port     int
+}
+
+// Dialer opens tunnels through IAP.
+type Dialer struct {
+	tokens   oauth2.TokenSource
+	proxyURL *url.URL
+	endpoint *url.URL
+}
+
+// NewDialer creates a Dialer authenticating with tokens, which must carry the
+// cloud-platform scope. When proxyURL is not nil the relay is reached through
+// that HTTP proxy with CONNECT.
+func NewDialer(tokens oauth2.TokenSource, proxyURL *url.URL) *Dialer {
+	return &Dialer{tokens: tokens, proxyURL: proxyURL, endpoint: defaultEndpoint}
+}
+
+// Dial opens a tunnel to t and returns it once the relay has connected to the
+// instance. Reads and writes of the returned connection are the byte stream of
+// the instance port.
+func (d *Dialer) Dial(ctx context.Context, t Target) (io.ReadWriteCloser, error) {
+	token, err := d.tokens.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain an access token: %w", err)
+	}
+
+	location := *d.endpoint
+	query := url.Values{}
+	query.Set("project", t.Project)
+	query.Set("zone", t.Zone)
+	query.Set("instance", t.Instance)
+	query.Set("interface", defaultInterface)
+	query.Set("port", strconv.Itoa(t.Port))
+	query.Set("newWebsocket", "true")
+	location.RawQuery = query.Encode()
+
+	config, err := websocket.NewConfig(location.String(), relayOrigin)
+	if err != nil {
+		return nil, err
+	}
+	config.Protocol = []string{relayProtocol}
+	config.Header = http.Header{}
+	config.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	conn, err := d.dialRelay(ctx, &location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", location.Host, err)
+	}
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Now()) })
+	defer stop()
+	if err = conn.SetDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	ws, err := websocket.NewClient(config, conn)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("IAP refused the tunnel to %s:%d (check roles/iap.tunnelResourceAccessor and a firewall rule allowing 35.235.240.0/20): %w", t.Instance, t.Port, err)
+	}
+	tunnel := &tunnel{ws: ws}
+	if err = tunnel.awaitConnect(); err != nil {
+		_ = ws.Close()
+		return nil, fmt.Errorf("failed to open the tunnel to %s:%d: %w", t.Instance, t.Port, err)
+	}
+	if err = conn.SetDeadline(time.Time{}); err != nil {
+		_ = ws.Close()
+		return nil, err
+	}
+	return tunnel, nil
+}
+
+// dialRelay opens the connection the websocket handshake runs over: TCP to the
+// relay or through the proxy, wrapped in TLS for wss.
+func (d *Dialer) dialRelay(ctx context.Context, location *url.URL) (net.Conn, error) {
+	addr := location.Host
+	if location.Port() == "" {
+		addr = net.JoinHostPort(location.Hostname(), "443")
+		if location.Scheme == "ws" {
+			addr = net.JoinHostPort(location.Hostname(), "80")
+		}
+	}
+
+	var dialer net.Dialer
+	var conn net.Conn
+	var err error
+	if d.proxyURL == nil {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = d.dialProxy(ctx, &dialer, addr)
+	}
+	if err != nil || location.Scheme != "wss" {
+		return conn, err
+	}
+
+	tlsConn := tls.Client(conn, &tls.Config{ServerName: location.Hostname(), MinVersion: tls.VersionTLS12})
+	if err = tlsConn.HandshakeContext(ctx); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return tlsConn, nil
+}
+
+// dialProxy connects to addr through the HTTP proxy with a CONNECT request.
+func (d *Dialer) dialProxy(ctx context.Context, dialer *net.Dialer, addr string) (net.Conn, error) {
+	proxyAddr := d.proxyURL.Host
+	if d.proxyURL.Port() == "" {
+		proxyAddr = net.JoinHostPort(d.proxyURL.Hostname(), "80")
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", proxyAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+	if user := d.proxyURL.User; user != nil {
+		password, _ := user.Password()
+		req.SetBasicAuth(user.Username(), password)
+		req.Header.Set("Proxy-Authorization", req.Header.Get("Authorization"))
+		req.Header.Del("Authorization")
+	}
+	if err = req.Write(conn); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_ = conn.Close()
+		return nil, fmt.Errorf("proxy %s refused CONNECT: %s", d.proxyURL.Redacted(), resp.Status)
+	}
+	return conn, nil
+}
+
+// tunnel is an open IAP tunnel. Each websocket message carries one relay frame.
+type tunnel struct {
+	ws *websocket.Conn
+
+	// pending holds the part of the last data frame not yet returned by Read.
+	pending []byte
+	// received and acked count the data bytes received and acknowledged.
+	received, acked uint64
+
+	writeMu sync.Mutex
+}
+
+// awaitConnect waits for the relay to report that it connected to the instance.
+func (t *tunnel) awaitConnect() error {
+	for {
+		tag, _, err := t.readFrame()
+		if err != nil {
+			return err
+		}
+		switch tag {
+		case tagConnectSuccessSID:
+			return nil
+		case tagAck:
+		default:
+			return fmt.Errorf("unexpected frame 0x%04x before the tunnel opened", tag)
+		}
+	}
+}
+
+// Read reads from the instance port, acknowledging the received data to the relay.
+func (t *tunnel) Read(p []byte) (int, error) {
+	for len(t.pending) == 0 {
+		tag, payload, err := t.readFrame()
+		if err != nil {
+			return 0, err
+		}
+		switch tag {
+		case tagData:
+			data, err := lengthPrefixed(payload)
+			if err != nil {
+				return 0, err
+			}
+			t.pending = data
+			t.received += uint64(len(data))
+			if t.received-t.acked >= ackInterval {
+				if err = t.writeFrame(ackFrame(t.received)); err != nil {
+					return 0, err
+				}
+				t.acked = t.received
+			}
+		case tagAck, tagReconnectSuccessAck:
+			// Acknowledgements of our writes; nothing is resent, so they need no action.
+		default:
+			return 0, fmt.Errorf("unexpected frame 0x%04x from the relay", tag)
+		}
+	}
+	n := copy(p, t.pending)
+	t.pending = t.pending[n:]
+	return n, nil
+}
+
+// Write sends p to the instance port in frames of at most maxDataFrameSize bytes.
+func (t *tunnel) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := min(len(p), maxDataFrameSize)
+		if err := t.writeFrame(dataFrame(p[:n])); err != nil {
+			return written, err
+		}
+		written += n
+		p = p[n:]
+	}
+	return written, nil
+}
+
+// Close closes the tunnel.
+func (t *tunnel) Close() error {
+	return t.ws.Close()
+}
+
+// readFrame reads the next relay frame and splits off its tag.
+func (t *tunnel) readFrame() (uint16, []byte, error) {
+	var msg []byte
+	if err := websocket.Message.Receive(t.ws, &msg); err != nil {
+		return 0, nil, err
+	}
+	if len(msg) < 2 {
+		return 0, nil, errors.New("truncated frame from the relay")
+	}
+	return binary.BigEndian.Uint16(msg), msg[2:], nil
+}
+
+// writeFrame sends one relay frame; Read and Write may run concurrently.
+func (t *tunnel) writeFrame(frame []byte) error {
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+	return websocket.Message.Send(t.ws, frame)
+}
+
+// dataFrame builds a data frame carrying data.
+func dataFrame(data []byte) []byte {
+	frame := make([]byte, 6+len(data))
+	binary.BigEndian.PutUint16(frame, tagData)
+	binary.BigEndian.PutUint32(frame[2:], uint32(len(data)))
+	copy(frame[6:], data)
+	return frame
+}
+
+// ackFrame builds a frame acknowledging the first received bytes.
+func ackFrame(received uint64) []byte {
+	frame := make([]byte, 10)
+	binary.BigEndian.PutUint16(frame, tagAck)
+	binary.BigEndian.PutUint64(frame[2:], received)
+	return frame
+}
+
+// lengthPrefixed returns the data of a payload that starts with its 32-bit length.
+func lengthPrefixed(payload []byte) ([]byte, error) {
+	if len(payload) < 4 {
+		return nil, errors.New("truncated frame from the relay")
+	}
+	n := binary.BigEndian.Uint32(payload)
+	if uint64(len(payload)-4) < uint64(n) {
+		return nil, errors.New("truncated frame from the relay")
+	}
+	return payload[4 : 4+n], nil
+}
@@ -0,0 +1,188 @@
+package iap
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+	"golang.org/x/oauth2"
+)
+
+// fakeRelay is an IAP relay echoing the data it receives.
+type fakeRelay struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	acks     []uint64
+	frames   int
+}
+
+func (f *fakeRelay) serve(ws *websocket.Conn) {
+	f.mu.Lock()
+	f.requests = append(f.requests, ws.Request())
+	f.mu.Unlock()
+
+	connect := []byte{0, tagConnectSuccessSID, 0, 0, 0, 3, 's', 'i', 'd'}
+	if err := websocket.Message.Send(ws, connect); err != nil {
+		return
+	}
+	for {
+		var msg []byte
+		if err := websocket.Message.Receive(ws, &msg); err != nil {
+			return
+		}
+		switch binary.BigEndian.Uint16(msg) {
+		case tagData:
+			f.mu.Lock()
+			f.frames++
+			f.mu.Unlock()
+			if err := websocket.Message.Send(ws, msg); err != nil {
+				return
+			}
+		case tagAck:
+			f.mu.Lock()
+			f.acks = append(f.acks, binary.BigEndian.Uint64(msg[2:]))
+			f.mu.Unlock()
+		}
+	}
+}
+
+func newTestDialer(t *testing.T, relay *fakeRelay) *Dialer {
+	t.Helper()
+	server := httptest.NewServer(websocket.Server{
+		Handshake: func(config *websocket.Config, _ *http.Request) error {
+			config.Protocol = []string{relayProtocol}
+			return nil
+		},
+		Handler: relay.serve,
+	})
+	t.Cleanup(server.Close)
+
+	endpoint, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	endpoint.Scheme = "ws"
+	endpoint.Path = "/v4/connect"
+	d := NewDialer(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"}), nil)
+	d.endpoint = endpoint
+	return d
+}
+
+var testTarget = Target{Project: "my-project", Zone: "us-central1-a", Instance: "my-vm", Port: 22}
+
+func TestDial(t *testing.T) {
+	relay := &fakeRelay{}
+	d := newTestDialer(t, relay)
+
+	tunnel, err := d.Dial(context.Background(), testTarget)
+	require.NoError(t, err)
+	defer tunnel.Close()
+
+	_, err = tunnel.Write([]byte("hello"))
+	require.NoError(t, err)
+	buf := make([]byte, 5)
+	_, err = io.ReadFull(tunnel, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(buf))
+
+	relay.mu.Lock()
+	defer relay.mu.Unlock()
+	require.Len(t, relay.requests, 1)
+	req := relay.requests[0]
+	assert.Equal(t, "Bearer test-token", req.Header.Get("Authorization"))
+	assert.Equal(t, relayOrigin, req.Header.Get("Origin"))
+	assert.Equal(t, relayProtocol, req.Header.Get("Sec-WebSocket-Protocol"))
+	query := req.URL.Query()
+	assert.Equal(t, "my-project", query.Get("project"))
+	assert.Equal(t, "us-central1-a", query.Get("zone"))
+	assert.Equal(t, "my-vm", query.Get("instance"))
+	assert.Equal(t, "22", query.Get("port"))
+	assert.Equal(t, "nic0", query.Get("interface"))
+}
+
+func TestDial_TokenError(t *testing.T) {
+	d := NewDialer(errTokenSource{}, nil)
+
+	_, err := d.Dial(context.Background(), testTarget)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to obtain an access token")
+}
+
+type errTokenSource struct{}
+
+func (errTokenSource) Token() (*oauth2.Token, error) {
+	return nil, io.ErrUnexpectedEOF
+}
+
+func TestTunnel_SplitsWritesAndAcknowledgesReads(t *testing.T) {
+	relay := &fakeRelay{}
+	d := newTestDialer(t, relay)
+	tunnel, err := d.Dial(context.Background(), testTarget)
+	require.NoError(t, err)
+	defer tunnel.Close()
+
+	data := bytes.Repeat([]byte("0123456789abcdef"), ackInterval/16+1)
+	go func() { _, _ = tunnel.Write(data) }()
+	got := make([]byte, len(data))
+	_, err = io.ReadFull(tunnel, got)
+	require.NoError(t, err)
+	assert.Equal(t, data, got)
+
+	// The ack is sent from Read, so it reaches the relay after the data; write
+	// once more to make sure it has been processed.
+	_, err = tunnel.Write([]byte("x"))
+	require.NoError(t, err)
+	_, err = io.ReadFull(tunnel, make([]byte, 1))
+	require.NoError(t, err)
+
+	relay.mu.Lock()
+	defer relay.mu.Unlock()
+	assert.Equal(t, 4, relay.frames, "16KiB data frames")
+	assert.Equal(t, []uint64{ackInterval}, relay.acks)
+}
+
+func TestDialerServe(t *testing.T) {
+	relay := &fakeRelay{}
+	d := newTestDialer(t, relay)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- d.Serve(ctx, ln, testTarget, func(err error) { t.Errorf("unexpected error: %v", err) })
+	}()
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("ping\n"))
+	require.NoError(t, err)
+	buf := make([]byte, 5)
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "ping\n", string(buf))
+
+	cancel()
+	require.NoError(t, <-done)
+	_, err = conn.Read(buf)
+	assert.Error(t, err, "connection is closed when Serve stops")
+}
+
+func TestLengthPrefixed(t *testing.T) {
+	data, err := lengthPrefixed([]byte{0, 0, 0, 2, 'o', 'k', 'x'})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(data))
+
+	_, err = lengthPrefixed([]byte{0, 0, 0, 9, 'o'})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "truncated")
+}
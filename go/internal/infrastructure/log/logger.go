@@ -1,7 +1,10 @@
 package log
 
 import (
+	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/charmbracelet/log"
 )
@@ -75,6 +78,7 @@ func (l *charmLogger) Fatal(msg string) {
 // The logger is configured with:
 //   - Output to stderr
 //   - Log level from GCE_COMMANDS_LOG_LEVEL environment variable (default: INFO)
+//   - Log format from GCE_COMMANDS_LOG_FORMAT environment variable (default: text)
 //   - Caller reporting enabled (shows source file and line number)
 //   - Timestamp reporting enabled
 //
@@ -82,18 +86,42 @@ func (l *charmLogger) Fatal(msg string) {
 //
 // Environment variables:
 //   - GCE_COMMANDS_LOG_LEVEL: Sets the log level (INFO or DEBUG, default: INFO)
+//   - GCE_COMMANDS_LOG_FORMAT: Sets the log format (text, json or logfmt, default: text)
 //
 // Returns:
 //   - Logger: A new logger instance ready for use
 func NewLogger() Logger {
-	logger := log.NewWithOptions(os.Stderr, log.Options{
+	return newLogger(os.Stderr)
+}
+
+func newLogger(w io.Writer) *charmLogger {
+	formatter, err := parseFormat(os.Getenv("GCE_COMMANDS_LOG_FORMAT"))
+	if err != nil {
+		formatter = log.TextFormatter // 不明な値の場合はtextをデフォルトとする
+	}
+	logger := log.NewWithOptions(w, log.Options{
 		Level:           getLevel(),
+		Formatter:       formatter,
 		ReportCaller:    true,
 		ReportTimestamp: true,
 	})
 	return &charmLogger{Logger: logger}
 }
 
+// parseFormat returns the formatter for a log format name; "" means text.
+func parseFormat(format string) (log.Formatter, error) {
+	switch strings.ToLower(format) {
+	case "", "text":
+		return log.TextFormatter, nil
+	case "json":
+		return log.JSONFormatter, nil
+	case "logfmt":
+		return log.LogfmtFormatter, nil
+	default:
+		return log.TextFormatter, fmt.Errorf("unknown log format %q: must be text, json or logfmt", format)
+	}
+}
+
 func getLevel() log.Level {
 	level := os.Getenv("GCE_COMMANDS_LOG_LEVEL")
 	if level == "" {
@@ -108,3 +136,42 @@ func getLevel() log.Level {
 		return log.InfoLevel // 不明な値の場合はINFOをデフォルトとする
 	}
 }
+
+// SetVerbosity overrides the level of DefaultLogger: debug enables DEBUG without
+// GCE_COMMANDS_LOG_LEVEL, and quiet only lets errors through. When neither is
+// set the level from the environment variable is kept.
+//
+// Parameters:
+//   - quiet: Log errors only
+//   - debug: Log debug messages
+func SetVerbosity(quiet, debug bool) {
+	logger, ok := DefaultLogger.(*charmLogger)
+	if !ok {
+		return
+	}
+	switch {
+	case debug:
+		logger.SetLevel(log.DebugLevel)
+	case quiet:
+		logger.SetLevel(log.ErrorLevel)
+	}
+}
+
+// SetFormat overrides the format of DefaultLogger, e.g. "json" so logs can be
+// ingested by a log pipeline when gcectl runs inside automation.
+//
+// Parameters:
+//   - format: text, json or logfmt
+//
+// Returns:
+//   - error: An error if the format is unknown
+func SetFormat(format string) error {
+	formatter, err := parseFormat(format)
+	if err != nil {
+		return err
+	}
+	if logger, ok := DefaultLogger.(*charmLogger); ok {
+		logger.SetFormatter(formatter)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormat(t *testing.T) {
+	for format, want := range map[string]log.Formatter{"": log.TextFormatter, "text": log.TextFormatter, "JSON": log.JSONFormatter, "logfmt": log.LogfmtFormatter} {
+		got, err := parseFormat(format)
+		require.NoError(t, err, format)
+		assert.Equal(t, want, got, format)
+	}
+
+	_, err := parseFormat("xml")
+	require.Error(t, err)
+}
+
+func TestNewLogger_JSONFormatFromEnv(t *testing.T) {
+	t.Setenv("GCE_COMMANDS_LOG_FORMAT", "json")
+	var buf bytes.Buffer
+
+	newLogger(&buf).Warnf("VM %s is %s", "vm-1", "stopping")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry), buf.String())
+	assert.Equal(t, "warn", entry["level"])
+	assert.Equal(t, "VM vm-1 is stopping", entry["msg"])
+	assert.Contains(t, entry, "time")
+}
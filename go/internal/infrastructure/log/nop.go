@@ -0,0 +1,22 @@
+package log
+
+// nopLogger discards every message. Unlike a logger writing to io.Discard,
+// Fatal and Fatalf do not exit the program.
+type nopLogger struct{}
+
+// NewNopLogger returns a Logger that discards every message, for callers that
+// embed gcectl and do not want it to log or exit on their behalf.
+func NewNopLogger() Logger {
+	return nopLogger{}
+}
+
+func (nopLogger) Debug(string)          {}
+func (nopLogger) Debugf(string, ...any) {}
+func (nopLogger) Info(string)           {}
+func (nopLogger) Infof(string, ...any)  {}
+func (nopLogger) Warn(string)           {}
+func (nopLogger) Warnf(string, ...any)  {}
+func (nopLogger) Error(string)          {}
+func (nopLogger) Errorf(string, ...any) {}
+func (nopLogger) Fatal(string)          {}
+func (nopLogger) Fatalf(string, ...any) {}
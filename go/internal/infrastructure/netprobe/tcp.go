@@ -0,0 +1,29 @@
+package netprobe
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+const defaultDialTimeout = 3 * time.Second
+
+// TCPProber checks TCP reachability by opening and immediately closing a connection.
+type TCPProber struct {
+	dialTimeout time.Duration
+}
+
+// NewTCPProber creates a TCPProber with a short per-attempt dial timeout.
+func NewTCPProber() *TCPProber {
+	return &TCPProber{dialTimeout: defaultDialTimeout}
+}
+
+// Probe returns nil if a TCP connection to address can be established.
+func (p *TCPProber) Probe(ctx context.Context, address string) error {
+	dialer := net.Dialer{Timeout: p.dialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
@@ -0,0 +1,21 @@
+package netprobe
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTCPProberProbe(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := ln.Addr().String()
+
+	prober := NewTCPProber()
+	require.NoError(t, prober.Probe(context.Background(), address))
+
+	require.NoError(t, ln.Close())
+	require.Error(t, prober.Probe(context.Background(), address))
+}
@@ -0,0 +1,80 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+const defaultSMTPPort = 587
+
+type sendMailFunc func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+
+// EmailNotifier sends events as plain-text mail over SMTP.
+type EmailNotifier struct {
+	sendMail sendMailFunc
+	host     string
+	username string
+	password string
+	from     string
+	to       []string
+	port     int
+}
+
+// NewEmailNotifier creates an EmailNotifier. Authentication is only used when username is set.
+// A zero port defaults to 587.
+func NewEmailNotifier(host string, port int, username, password, from string, to []string) *EmailNotifier {
+	if port == 0 {
+		port = defaultSMTPPort
+	}
+	return &EmailNotifier{
+		sendMail: smtp.SendMail,
+		host:     host,
+		username: username,
+		password: password,
+		from:     from,
+		to:       to,
+		port:     port,
+	}
+}
+
+// Notify sends the event to every configured recipient.
+// net/smtp does not support contexts, so ctx is only checked before sending.
+func (n *EmailNotifier) Notify(ctx context.Context, event model.Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if n.username != "" {
+		auth = smtp.PlainAuth("", n.username, n.password, n.host)
+	}
+	addr := net.JoinHostPort(n.host, strconv.Itoa(n.port))
+	if err := n.sendMail(addr, auth, n.from, n.to, n.message(event)); err != nil {
+		return fmt.Errorf("failed to send mail via %s: %w", addr, err)
+	}
+	return nil
+}
+
+func (n *EmailNotifier) message(event model.Event) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", n.from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(n.to, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", event.Summary())
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	fmt.Fprintf(&b, "Event:   %s\r\n", event.Type)
+	if event.VMName != "" {
+		fmt.Fprintf(&b, "VM:      %s\r\n", event.VMName)
+	}
+	if !event.Time.IsZero() {
+		fmt.Fprintf(&b, "Time:    %s\r\n", event.Time.Format("2006-01-02 15:04:05 MST"))
+	}
+	fmt.Fprintf(&b, "Message: %s\r\n", event.Message)
+	return []byte(b.String())
+}
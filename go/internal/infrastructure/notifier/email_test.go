@@ -0,0 +1,46 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"net/smtp"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailNotifier_Notify(t *testing.T) {
+	n := NewEmailNotifier("smtp.example.com", 0, "bot", "secret", "gcectl@example.com", []string{"ops@example.com"})
+
+	var gotAddr, gotFrom string
+	var gotTo []string
+	var gotMsg []byte
+	var gotAuth smtp.Auth
+	n.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotAuth, gotFrom, gotTo, gotMsg = addr, a, from, to, msg
+		return nil
+	}
+
+	err := n.Notify(context.Background(), model.Event{Type: model.EventOperationFailure, VMName: "sandbox", Message: "failed to start"})
+	require.NoError(t, err)
+	assert.Equal(t, "smtp.example.com:587", gotAddr)
+	assert.NotNil(t, gotAuth)
+	assert.Equal(t, "gcectl@example.com", gotFrom)
+	assert.Equal(t, []string{"ops@example.com"}, gotTo)
+	assert.Contains(t, string(gotMsg), "Subject: [gcectl] operation-failure: sandbox: failed to start\r\n")
+	assert.Contains(t, string(gotMsg), "Message: failed to start\r\n")
+}
+
+func TestEmailNotifier_NotifyWithoutAuthAndError(t *testing.T) {
+	n := NewEmailNotifier("localhost", 25, "", "", "gcectl@example.com", []string{"ops@example.com"})
+	n.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		assert.Nil(t, a)
+		return errors.New("connection refused")
+	}
+
+	err := n.Notify(context.Background(), model.Event{Type: model.EventPreemption})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "localhost:25")
+}
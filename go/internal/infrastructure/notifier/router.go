@@ -0,0 +1,98 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+)
+
+// Router dispatches each event to the backends configured for its type.
+// It implements repository.Notifier, so callers do not need to know which backends exist.
+type Router struct {
+	routes map[model.EventType][]repository.Notifier
+}
+
+// NewRouter builds one notifier per named backend and wires them to event types.
+//
+// Parameters:
+//   - backends: Backend settings keyed by backend name
+//   - routes: Backend names keyed by event type
+//
+// Returns:
+//   - *Router: A router that may have no routes, in which case Notify is a no-op
+//   - error: An error if a route references an unknown backend or a backend is misconfigured
+func NewRouter(backends map[string]model.NotifierConfig, routes map[model.EventType][]string) (*Router, error) {
+	built := make(map[string]repository.Notifier, len(backends))
+	r := &Router{routes: make(map[model.EventType][]repository.Notifier, len(routes))}
+	for eventType, names := range routes {
+		for _, name := range names {
+			n, ok := built[name]
+			if !ok {
+				cfg, found := backends[name]
+				if !found {
+					return nil, fmt.Errorf("notification backend %q is not defined", name)
+				}
+				var err error
+				n, err = New(cfg)
+				if err != nil {
+					return nil, fmt.Errorf("notification backend %q: %w", name, err)
+				}
+				built[name] = n
+			}
+			r.routes[eventType] = append(r.routes[eventType], n)
+		}
+	}
+	return r, nil
+}
+
+// New creates a single notifier from its backend settings.
+func New(cfg model.NotifierConfig) (repository.Notifier, error) {
+	switch cfg.Type {
+	case model.NotifierTypeSlack:
+		if cfg.WebhookURL == "" {
+			return nil, errors.New("webhook-url is required for slack")
+		}
+		return NewSlackNotifier(cfg.WebhookURL), nil
+	case model.NotifierTypeDiscord:
+		if cfg.WebhookURL == "" {
+			return nil, errors.New("webhook-url is required for discord")
+		}
+		return NewDiscordNotifier(cfg.WebhookURL), nil
+	case model.NotifierTypeWebhook:
+		if cfg.WebhookURL == "" {
+			return nil, errors.New("webhook-url is required for webhook")
+		}
+		headers := make(map[string]string, len(cfg.Headers))
+		for name, value := range cfg.Headers {
+			headers[name] = os.ExpandEnv(value)
+		}
+		return NewWebhookNotifier(cfg.WebhookURL, headers), nil
+	case model.NotifierTypeEmail:
+		if cfg.SMTPHost == "" || cfg.From == "" || len(cfg.To) == 0 {
+			return nil, errors.New("smtp-host, from and to are required for email")
+		}
+		password := ""
+		if cfg.PasswordEnv != "" {
+			password = os.Getenv(cfg.PasswordEnv)
+		}
+		return NewEmailNotifier(cfg.SMTPHost, cfg.SMTPPort, cfg.Username, password, cfg.From, cfg.To), nil
+	default:
+		return nil, fmt.Errorf("unsupported notifier type %q", cfg.Type)
+	}
+}
+
+// Notify sends the event to every backend routed for its type.
+// All backends are attempted; their errors are joined.
+func (r *Router) Notify(ctx context.Context, event model.Event) error {
+	var errs []error
+	for _, n := range r.routes[event.Type] {
+		if err := n.Notify(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,71 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     model.NotifierConfig
+		wantErr bool
+	}{
+		{name: "slack", cfg: model.NotifierConfig{Type: "slack", WebhookURL: "https://hooks.slack.com/x"}},
+		{name: "discord", cfg: model.NotifierConfig{Type: "discord", WebhookURL: "https://discord.com/api/webhooks/x"}},
+		{name: "email", cfg: model.NotifierConfig{Type: "email", SMTPHost: "smtp.example.com", From: "a@example.com", To: []string{"b@example.com"}}},
+		{name: "webhook", cfg: model.NotifierConfig{Type: "webhook", WebhookURL: "https://ops.example.com/hooks/gcectl", Headers: map[string]string{"Authorization": "Bearer ${TOKEN}"}}},
+		{name: "slack without url", cfg: model.NotifierConfig{Type: "slack"}, wantErr: true},
+		{name: "webhook without url", cfg: model.NotifierConfig{Type: "webhook"}, wantErr: true},
+		{name: "email without recipients", cfg: model.NotifierConfig{Type: "email", SMTPHost: "smtp.example.com", From: "a@example.com"}, wantErr: true},
+		{name: "unknown type", cfg: model.NotifierConfig{Type: "pager"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := New(tt.cfg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, n)
+		})
+	}
+}
+
+func TestNewRouterRejectsUndefinedBackend(t *testing.T) {
+	_, err := NewRouter(nil, map[model.EventType][]string{model.EventOperationFailure: {"team-slack"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"team-slack" is not defined`)
+}
+
+func TestRouter_Notify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	slack := mock_repository.NewMockNotifier(ctrl)
+	mail := mock_repository.NewMockNotifier(ctrl)
+	failure := model.Event{Type: model.EventOperationFailure, VMName: "sandbox"}
+	slack.EXPECT().Notify(gomock.Any(), failure).Return(errors.New("slack down"))
+	mail.EXPECT().Notify(gomock.Any(), failure).Return(nil)
+
+	r := &Router{routes: map[model.EventType][]repository.Notifier{
+		model.EventOperationFailure: {slack, mail},
+	}}
+
+	err := r.Notify(context.Background(), failure)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "slack down")
+
+	// Events without routes are dropped silently.
+	require.NoError(t, r.Notify(context.Background(), model.Event{Type: model.EventOperationSuccess}))
+}
@@ -0,0 +1,112 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+const defaultHTTPTimeout = 10 * time.Second
+
+// SlackNotifier posts events to a Slack incoming webhook.
+type SlackNotifier struct {
+	client     *http.Client
+	webhookURL string
+}
+
+// NewSlackNotifier creates a SlackNotifier for the given incoming webhook URL.
+func NewSlackNotifier(webhookURL string) *SlackNotifier {
+	return &SlackNotifier{client: &http.Client{Timeout: defaultHTTPTimeout}, webhookURL: webhookURL}
+}
+
+// Notify posts the event summary as a Slack message.
+func (n *SlackNotifier) Notify(ctx context.Context, event model.Event) error {
+	return postJSON(ctx, n.client, n.webhookURL, map[string]string{"text": event.Summary()})
+}
+
+// DiscordNotifier posts events to a Discord channel webhook.
+type DiscordNotifier struct {
+	client     *http.Client
+	webhookURL string
+}
+
+// NewDiscordNotifier creates a DiscordNotifier for the given channel webhook URL.
+func NewDiscordNotifier(webhookURL string) *DiscordNotifier {
+	return &DiscordNotifier{client: &http.Client{Timeout: defaultHTTPTimeout}, webhookURL: webhookURL}
+}
+
+// Notify posts the event summary as a Discord message.
+func (n *DiscordNotifier) Notify(ctx context.Context, event model.Event) error {
+	return postJSON(ctx, n.client, n.webhookURL, map[string]string{"content": event.Summary()})
+}
+
+// WebhookNotifier posts events as JSON to a generic HTTP endpoint.
+type WebhookNotifier struct {
+	client  *http.Client
+	url     string
+	headers map[string]string
+}
+
+// webhookPayload is the JSON body the webhook backend posts for an event.
+type webhookPayload struct {
+	Time    time.Time       `json:"time"`
+	Type    model.EventType `json:"type"`
+	VMName  string          `json:"vm,omitempty"`
+	Message string          `json:"message"`
+	Summary string          `json:"summary"`
+}
+
+// NewWebhookNotifier creates a WebhookNotifier for url. headers are added to every request.
+func NewWebhookNotifier(url string, headers map[string]string) *WebhookNotifier {
+	return &WebhookNotifier{client: &http.Client{Timeout: defaultHTTPTimeout}, url: url, headers: headers}
+}
+
+// Notify posts the event fields and its summary as a JSON object.
+// The event time defaults to now when it is not set.
+func (n *WebhookNotifier) Notify(ctx context.Context, event model.Event) error {
+	at := event.Time
+	if at.IsZero() {
+		at = time.Now()
+	}
+	payload := webhookPayload{Time: at, Type: event.Type, VMName: event.VMName, Message: event.Message, Summary: event.Summary()}
+	return postJSONWithHeaders(ctx, n.client, n.url, n.headers, payload)
+}
+
+// postJSON sends payload as a JSON POST request and treats any non-2xx response as an error.
+func postJSON(ctx context.Context, client *http.Client, url string, payload any) error {
+	return postJSONWithHeaders(ctx, client, url, nil, payload)
+}
+
+// postJSONWithHeaders is postJSON with extra request headers.
+func postJSONWithHeaders(ctx context.Context, client *http.Client, url string, headers map[string]string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned %s: %s", resp.Status, bytes.TrimSpace(respBody))
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookNotifiers(t *testing.T) {
+	event := model.Event{Type: model.EventOperationSuccess, VMName: "sandbox", Message: "started"}
+
+	tests := []struct {
+		notify func(url string) error
+		name   string
+		key    string
+	}{
+		{
+			name:   "slack posts text",
+			key:    "text",
+			notify: func(url string) error { return NewSlackNotifier(url).Notify(context.Background(), event) },
+		},
+		{
+			name:   "discord posts content",
+			key:    "content",
+			notify: func(url string) error { return NewDiscordNotifier(url).Notify(context.Background(), event) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got map[string]string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			require.NoError(t, tt.notify(server.URL))
+			assert.Equal(t, event.Summary(), got[tt.key])
+		})
+	}
+}
+
+func TestWebhookNotifierPostsEvent(t *testing.T) {
+	at := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	event := model.Event{Time: at, Type: model.EventAutoStop, VMName: "sandbox", Message: "ran scheduled stop (ttl 3h0m0s)"}
+
+	var got map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer s3cret", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	n := NewWebhookNotifier(server.URL, map[string]string{"Authorization": "Bearer s3cret"})
+	require.NoError(t, n.Notify(context.Background(), event))
+	assert.Equal(t, map[string]string{
+		"time":    "2025-01-02T03:04:05Z",
+		"type":    "auto-stop",
+		"vm":      "sandbox",
+		"message": "ran scheduled stop (ttl 3h0m0s)",
+		"summary": event.Summary(),
+	}, got)
+}
+
+func TestPostJSONReturnsErrorOnNon2xx(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	err := NewSlackNotifier(server.URL).Notify(context.Background(), model.Event{Type: model.EventOperationFailure})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "403")
+	assert.Contains(t, err.Error(), "invalid_token")
+}
@@ -0,0 +1,150 @@
+package oplog
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/xdg"
+)
+
+// maxLineSize bounds a single line of the history file.
+const maxLineSize = 1 << 20
+
+// File is an OperationHistory that appends one JSON object per line to a file,
+// so several users can share it, e.g. on a network drive.
+type File struct {
+	path string
+	mu   sync.Mutex
+}
+
+// record is the on-disk layout of one operation.
+type record struct {
+	Time      time.Time `json:"time"`
+	Duration  string    `json:"duration"`
+	Target    string    `json:"target"`
+	Project   string    `json:"project"`
+	Zone      string    `json:"zone"`
+	Type      string    `json:"type"`
+	Operation string    `json:"operation"`
+	User      string    `json:"user,omitempty"`
+	LocalUser string    `json:"local-user,omitempty"`
+	Command   string    `json:"command,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Running   bool      `json:"running,omitempty"`
+}
+
+// DefaultPath returns the default history location, $XDG_STATE_HOME/gcectl/operations.log
+// (~/.local/state/gcectl/operations.log), or the operations.log of the user config directory
+// when an earlier version of gcectl keeps it there.
+func DefaultPath() (string, error) {
+	return xdg.StateFile("operations.log")
+}
+
+// NewFile returns an operation history stored in path. The file is created on the first Append.
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+// Append writes records to the end of the file.
+func (f *File) Append(records ...*model.OperationRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create operation history directory: %w", err)
+	}
+	out, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open operation history: %w", err)
+	}
+
+	enc := json.NewEncoder(out)
+	for _, r := range records {
+		if encErr := enc.Encode(toRecord(r)); encErr != nil {
+			_ = out.Close()
+			return fmt.Errorf("failed to write operation history: %w", encErr)
+		}
+	}
+	if closeErr := out.Close(); closeErr != nil {
+		return fmt.Errorf("failed to write operation history: %w", closeErr)
+	}
+	return nil
+}
+
+// List reads all records, oldest first. A missing file yields no records, and
+// lines that cannot be parsed, e.g. left by an interrupted write of another
+// user, are skipped.
+func (f *File) List() ([]*model.OperationRecord, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	in, err := os.Open(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open operation history: %w", err)
+	}
+	defer func() { _ = in.Close() }()
+
+	var records []*model.OperationRecord
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		var r record
+		if json.Unmarshal(scanner.Bytes(), &r) != nil {
+			continue
+		}
+		records = append(records, r.toModel())
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read operation history: %w", err)
+	}
+	return records, nil
+}
+
+func toRecord(r *model.OperationRecord) record {
+	return record{
+		Time:      r.Time,
+		Duration:  r.Duration.String(),
+		Target:    r.Target,
+		Project:   r.Project,
+		Zone:      r.Zone,
+		Type:      r.Type,
+		Operation: r.Operation,
+		User:      r.User,
+		LocalUser: r.LocalUser,
+		Command:   r.Command,
+		Error:     r.Error,
+		Running:   r.Running,
+	}
+}
+
+func (r record) toModel() *model.OperationRecord {
+	// A malformed duration is only shown as 0s
+	d, _ := time.ParseDuration(r.Duration)
+	return &model.OperationRecord{
+		Time:      r.Time,
+		Duration:  d,
+		Target:    r.Target,
+		Project:   r.Project,
+		Zone:      r.Zone,
+		Type:      r.Type,
+		Operation: r.Operation,
+		User:      r.User,
+		LocalUser: r.LocalUser,
+		Command:   r.Command,
+		Error:     r.Error,
+		Running:   r.Running,
+	}
+}
@@ -0,0 +1,50 @@
+package oplog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_AppendAndList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gcectl", "operations.log")
+	at := time.Date(2025, 1, 2, 19, 0, 0, 0, time.UTC)
+	f := NewFile(path)
+
+	records, err := f.List()
+	require.NoError(t, err, "a missing file is an empty history")
+	assert.Empty(t, records)
+
+	stop := &model.OperationRecord{
+		Time: at, Duration: 42 * time.Second, Target: "vm-1", Project: "p", Zone: "z", Type: "stop",
+		Operation: "operation-1", User: "alice@example.com", LocalUser: "alice@laptop", Command: "gcectl off vm-1",
+	}
+	start := &model.OperationRecord{Time: at.Add(time.Hour), Target: "vm-2", Project: "p", Zone: "z", Type: "start", Operation: "operation-2", Error: "quota exceeded"}
+	detached := &model.OperationRecord{Time: at.Add(2 * time.Hour), Target: "vm-3", Project: "p", Zone: "z", Type: "stop", Operation: "operation-3", Running: true}
+	require.NoError(t, f.Append(stop))
+	require.NoError(t, f.Append(start, detached))
+	require.NoError(t, f.Append(), "appending nothing is a no-op")
+
+	records, err = f.List()
+	require.NoError(t, err)
+	assert.Equal(t, []*model.OperationRecord{stop, start, detached}, records)
+}
+
+func TestFile_ListSkipsMalformedLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "operations.log")
+	content := `{"time":"2025-01-02T19:00:00Z","duration":"1m0s","target":"vm-1","project":"p","zone":"z","type":"stop","operation":"op-1"}` + "\n" +
+		`{"time":"2025-01-02T19:` + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	records, err := NewFile(path).List()
+
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, time.Minute, records[0].Duration)
+	assert.Equal(t, "op-1", records[0].Operation)
+}
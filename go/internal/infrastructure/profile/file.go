@@ -0,0 +1,175 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/xdg"
+)
+
+// File stores the profiles of `gcectl context` and which one is current in a
+// single JSON file.
+type File struct {
+	entries map[string]entry
+	current string
+	path    string
+	mu      sync.Mutex
+}
+
+// document is the on-disk layout of the profile file.
+type document struct {
+	Current  string           `json:"current,omitempty"`
+	Profiles map[string]entry `json:"profiles"`
+}
+
+// entry is a single stored profile.
+type entry struct {
+	Config  string `json:"config,omitempty"`
+	Project string `json:"project,omitempty"`
+	Zone    string `json:"zone,omitempty"`
+}
+
+// DefaultPath returns the default profile file location, $XDG_CONFIG_HOME/gcectl/contexts.json
+// (~/.config/gcectl/contexts.json), or the contexts.json an earlier version of gcectl
+// keeps in the platform user config directory.
+func DefaultPath() (string, error) {
+	return xdg.ConfigFile("contexts.json")
+}
+
+// Open loads the profile file at path. A missing file yields no profiles, with
+// the default profile current.
+//
+// Parameters:
+//   - path: The profile file path
+//
+// Returns:
+//   - *File: The loaded store
+//   - error: An error if the file exists but cannot be read or parsed
+func Open(path string) (*File, error) {
+	f := &File{path: path, entries: make(map[string]entry)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read context file: %w", err)
+	}
+
+	var doc document
+	if unmarshalErr := json.Unmarshal(data, &doc); unmarshalErr != nil {
+		return nil, fmt.Errorf("failed to parse context file %s: %w", path, unmarshalErr)
+	}
+	for name, e := range doc.Profiles {
+		f.entries[name] = e
+	}
+	f.current = doc.Current
+	return f, nil
+}
+
+// Current returns the profile chosen with Use, or the default profile.
+func (f *File) Current() *model.Profile {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if e, ok := f.entries[f.current]; ok {
+		return e.toModel(f.current)
+	}
+	return &model.Profile{Name: model.DefaultProfileName}
+}
+
+// Get returns the stored profile name.
+func (f *File) Get(name string) (*model.Profile, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	e, ok := f.entries[name]
+	if !ok {
+		return nil, false
+	}
+	return e.toModel(name), true
+}
+
+// List returns the default profile followed by the stored profiles in name order.
+func (f *File) List() []*model.Profile {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	profiles := make([]*model.Profile, 0, len(f.entries)+1)
+	if _, ok := f.entries[model.DefaultProfileName]; !ok {
+		profiles = append(profiles, &model.Profile{Name: model.DefaultProfileName})
+	}
+	for name, e := range f.entries {
+		profiles = append(profiles, e.toModel(name))
+	}
+	sort.SliceStable(profiles, func(i, j int) bool {
+		if (profiles[i].Name == model.DefaultProfileName) != (profiles[j].Name == model.DefaultProfileName) {
+			return profiles[i].Name == model.DefaultProfileName
+		}
+		return profiles[i].Name < profiles[j].Name
+	})
+	return profiles
+}
+
+// Put stores p, replacing any profile of the same name, and rewrites the file.
+func (f *File) Put(p *model.Profile) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.entries[p.Name] = entry{Config: p.ConfigPath, Project: p.Project, Zone: p.Zone}
+	return f.write()
+}
+
+// Use makes the profile name current and rewrites the file. The default
+// profile can be used without being stored.
+func (f *File) Use(name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.entries[name]; !ok && name != model.DefaultProfileName {
+		return fmt.Errorf("context %s does not exist", name)
+	}
+	f.current = name
+	return f.write()
+}
+
+func (e entry) toModel(name string) *model.Profile {
+	return &model.Profile{Name: name, ConfigPath: e.Config, Project: e.Project, Zone: e.Zone}
+}
+
+// write replaces the profile file via a temporary file so an interrupted write
+// never leaves a truncated document behind.
+func (f *File) write() error {
+	data, err := json.MarshalIndent(document{Current: f.current, Profiles: f.entries}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode contexts: %w", err)
+	}
+
+	dir := filepath.Dir(f.path)
+	if mkErr := os.MkdirAll(dir, 0o700); mkErr != nil {
+		return fmt.Errorf("failed to create context directory: %w", mkErr)
+	}
+	tmp, err := os.CreateTemp(dir, ".contexts-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create context file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, writeErr := tmp.Write(data); writeErr != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write context file: %w", writeErr)
+	}
+	if closeErr := tmp.Close(); closeErr != nil {
+		return fmt.Errorf("failed to write context file: %w", closeErr)
+	}
+	if renameErr := os.Rename(tmp.Name(), f.path); renameErr != nil {
+		return fmt.Errorf("failed to replace context file: %w", renameErr)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_PutUseCurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gcectl", "contexts.json")
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	assert.Equal(t, &model.Profile{Name: model.DefaultProfileName}, f.Current())
+
+	work := &model.Profile{Name: "work", ConfigPath: "/etc/gcectl/work.yaml", Project: "work-proj", Zone: "us-central1-a"}
+	require.NoError(t, f.Put(work))
+	require.NoError(t, f.Use("work"))
+
+	reopened, err := Open(path)
+	require.NoError(t, err)
+	assert.Equal(t, work, reopened.Current())
+	got, ok := reopened.Get("work")
+	require.True(t, ok)
+	assert.Equal(t, work, got)
+
+	require.NoError(t, reopened.Use(model.DefaultProfileName))
+	assert.Equal(t, model.DefaultProfileName, reopened.Current().Name)
+
+	err = reopened.Use("missing")
+	assert.ErrorContains(t, err, "context missing does not exist")
+}
+
+func TestFile_ListStartsWithDefault(t *testing.T) {
+	f, err := Open(filepath.Join(t.TempDir(), "contexts.json"))
+	require.NoError(t, err)
+	require.NoError(t, f.Put(&model.Profile{Name: "work"}))
+	require.NoError(t, f.Put(&model.Profile{Name: "home"}))
+
+	profiles := f.List()
+	require.Len(t, profiles, 3)
+	assert.Equal(t, model.DefaultProfileName, profiles[0].Name)
+	assert.Equal(t, "home", profiles[1].Name)
+	assert.Equal(t, "work", profiles[2].Name)
+}
+
+func TestOpen_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "contexts.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+	_, err := Open(path)
+	assert.ErrorContains(t, err, "failed to parse context file")
+}
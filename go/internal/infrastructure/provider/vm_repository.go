@@ -0,0 +1,247 @@
+// Package provider routes repository calls to the backend of each VM's provider,
+// so VMs managed by different clouds can be configured side by side.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+)
+
+// VMRepository implements repository.VMRepository by sending every call to the
+// backend registered for the provider of the VM it concerns.
+// VMs without a provider go to the model.ProviderGCP backend, as do project
+// listings since projects are a GCP concept.
+type VMRepository struct {
+	backends map[string]repository.VMRepository
+}
+
+// NewVMRepository creates a VMRepository over backends keyed by provider name.
+func NewVMRepository(backends map[string]repository.VMRepository) *VMRepository {
+	return &VMRepository{backends: backends}
+}
+
+// Providers returns the names of the registered providers, sorted.
+func (r *VMRepository) Providers() []string {
+	names := make([]string, 0, len(r.backends))
+	for name := range r.backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// backend returns the backend of the provider of vm.
+func (r *VMRepository) backend(vm *model.VM) (repository.VMRepository, error) {
+	return r.backendFor(vm.ProviderName())
+}
+
+func (r *VMRepository) backendFor(provider string) (repository.VMRepository, error) {
+	backend, ok := r.backends[provider]
+	if !ok {
+		return nil, fmt.Errorf("provider %q is not supported, use one of %v", provider, r.Providers())
+	}
+	return backend, nil
+}
+
+// stamp sets the provider of found to that of the VM it was looked up for,
+// since backends return fresh VMs.
+func stamp(found, vm *model.VM) *model.VM {
+	if found != nil && found.Provider == "" {
+		found.Provider = vm.Provider
+	}
+	return found
+}
+
+func (r *VMRepository) FindByName(ctx context.Context, vm *model.VM) (*model.VM, error) {
+	backend, err := r.backend(vm)
+	if err != nil {
+		return nil, err
+	}
+	found, err := backend.FindByName(ctx, vm)
+	return stamp(found, vm), err
+}
+
+// FindAll looks up the VMs of each provider with its backend, one call per provider.
+func (r *VMRepository) FindAll(ctx context.Context, vms []*model.VM) ([]*model.VM, error) {
+	indexes := make(map[string][]int)
+	var order []string
+	for i, vm := range vms {
+		provider := vm.ProviderName()
+		if _, ok := indexes[provider]; !ok {
+			order = append(order, provider)
+		}
+		indexes[provider] = append(indexes[provider], i)
+	}
+
+	found := make([]*model.VM, len(vms))
+	for _, provider := range order {
+		backend, err := r.backendFor(provider)
+		if err != nil {
+			return nil, err
+		}
+		group := make([]*model.VM, len(indexes[provider]))
+		for j, i := range indexes[provider] {
+			group[j] = vms[i]
+		}
+		groupFound, err := backend.FindAll(ctx, group)
+		if err != nil {
+			return nil, err
+		}
+		for j, i := range indexes[provider] {
+			if j < len(groupFound) {
+				found[i] = stamp(groupFound[j], vms[i])
+			}
+		}
+	}
+	return found, nil
+}
+
+func (r *VMRepository) ListByProject(ctx context.Context, project string, selector map[string]string) ([]*model.VM, error) {
+	backend, err := r.backendFor(model.ProviderGCP)
+	if err != nil {
+		return nil, err
+	}
+	return backend.ListByProject(ctx, project, selector)
+}
+
+func (r *VMRepository) Start(ctx context.Context, vm *model.VM) error {
+	backend, err := r.backend(vm)
+	if err != nil {
+		return err
+	}
+	return backend.Start(ctx, vm)
+}
+
+func (r *VMRepository) Stop(ctx context.Context, vm *model.VM) error {
+	backend, err := r.backend(vm)
+	if err != nil {
+		return err
+	}
+	return backend.Stop(ctx, vm)
+}
+
+func (r *VMRepository) Reset(ctx context.Context, vm *model.VM) error {
+	backend, err := r.backend(vm)
+	if err != nil {
+		return err
+	}
+	return backend.Reset(ctx, vm)
+}
+
+func (r *VMRepository) StartAsync(ctx context.Context, vm *model.VM) (*model.Operation, error) {
+	backend, err := r.backend(vm)
+	if err != nil {
+		return nil, err
+	}
+	return backend.StartAsync(ctx, vm)
+}
+
+func (r *VMRepository) StopAsync(ctx context.Context, vm *model.VM) (*model.Operation, error) {
+	backend, err := r.backend(vm)
+	if err != nil {
+		return nil, err
+	}
+	return backend.StopAsync(ctx, vm)
+}
+
+func (r *VMRepository) UpdateMachineType(ctx context.Context, vm *model.VM, machineType string) error {
+	backend, err := r.backend(vm)
+	if err != nil {
+		return err
+	}
+	return backend.UpdateMachineType(ctx, vm, machineType)
+}
+
+func (r *VMRepository) SetAccelerators(ctx context.Context, vm *model.VM, accelerators []model.Accelerator) error {
+	backend, err := r.backend(vm)
+	if err != nil {
+		return err
+	}
+	return backend.SetAccelerators(ctx, vm, accelerators)
+}
+
+func (r *VMRepository) SetSchedulePolicy(ctx context.Context, vm *model.VM, policyName string) error {
+	backend, err := r.backend(vm)
+	if err != nil {
+		return err
+	}
+	return backend.SetSchedulePolicy(ctx, vm, policyName)
+}
+
+func (r *VMRepository) UnsetSchedulePolicy(ctx context.Context, vm *model.VM, policyName string) error {
+	backend, err := r.backend(vm)
+	if err != nil {
+		return err
+	}
+	return backend.UnsetSchedulePolicy(ctx, vm, policyName)
+}
+
+func (r *VMRepository) GetRaw(ctx context.Context, vm *model.VM) ([]byte, error) {
+	backend, err := r.backend(vm)
+	if err != nil {
+		return nil, err
+	}
+	return backend.GetRaw(ctx, vm)
+}
+
+func (r *VMRepository) SetLabels(ctx context.Context, vm *model.VM, labels map[string]string) error {
+	backend, err := r.backend(vm)
+	if err != nil {
+		return err
+	}
+	return backend.SetLabels(ctx, vm, labels)
+}
+
+func (r *VMRepository) UpdateLabels(ctx context.Context, vm *model.VM, set map[string]string, remove []string) error {
+	backend, err := r.backend(vm)
+	if err != nil {
+		return err
+	}
+	return backend.UpdateLabels(ctx, vm, set, remove)
+}
+
+func (r *VMRepository) GetMetadata(ctx context.Context, vm *model.VM) (map[string]string, error) {
+	backend, err := r.backend(vm)
+	if err != nil {
+		return nil, err
+	}
+	return backend.GetMetadata(ctx, vm)
+}
+
+func (r *VMRepository) SetMetadata(ctx context.Context, vm *model.VM, items map[string]string) error {
+	backend, err := r.backend(vm)
+	if err != nil {
+		return err
+	}
+	return backend.SetMetadata(ctx, vm, items)
+}
+
+func (r *VMRepository) UpdateMetadata(ctx context.Context, vm *model.VM, set map[string]string, remove []string) error {
+	backend, err := r.backend(vm)
+	if err != nil {
+		return err
+	}
+	return backend.UpdateMetadata(ctx, vm, set, remove)
+}
+
+func (r *VMRepository) SetScheduling(ctx context.Context, vm *model.VM, scheduling model.Scheduling) error {
+	backend, err := r.backend(vm)
+	if err != nil {
+		return err
+	}
+	return backend.SetScheduling(ctx, vm, scheduling)
+}
+
+func (r *VMRepository) SetSchedulingOptions(ctx context.Context, vm *model.VM, opts model.SchedulingOptions) error {
+	backend, err := r.backend(vm)
+	if err != nil {
+		return err
+	}
+	return backend.SetSchedulingOptions(ctx, vm, opts)
+}
+
+var _ repository.VMRepository = (*VMRepository)(nil)
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVMRepositoryRoutesByProvider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gce := mock_repository.NewMockVMRepository(ctrl)
+	ec2 := mock_repository.NewMockVMRepository(ctrl)
+	repo := NewVMRepository(map[string]repository.VMRepository{model.ProviderGCP: gce, "aws": ec2})
+
+	sandbox := &model.VM{Project: "p", Zone: "z", Name: "sandbox"}
+	builder := &model.VM{Project: "acct", Zone: "us-east-1a", Name: "builder", Provider: "aws"}
+	gce.EXPECT().Stop(gomock.Any(), sandbox).Return(nil)
+	ec2.EXPECT().Stop(gomock.Any(), builder).Return(nil)
+	ec2.EXPECT().FindByName(gomock.Any(), builder).Return(&model.VM{Name: "builder", Status: model.StatusRunning}, nil)
+	gce.EXPECT().ListByProject(gomock.Any(), "p", nil).Return([]*model.VM{sandbox}, nil)
+
+	require.NoError(t, repo.Stop(context.Background(), sandbox))
+	require.NoError(t, repo.Stop(context.Background(), builder))
+
+	found, err := repo.FindByName(context.Background(), builder)
+	require.NoError(t, err)
+	assert.Equal(t, "aws", found.Provider, "found VMs keep the provider they were looked up with")
+
+	listed, err := repo.ListByProject(context.Background(), "p", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []*model.VM{sandbox}, listed)
+}
+
+func TestVMRepositoryFindAllGroupsByProvider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gce := mock_repository.NewMockVMRepository(ctrl)
+	ec2 := mock_repository.NewMockVMRepository(ctrl)
+	repo := NewVMRepository(map[string]repository.VMRepository{model.ProviderGCP: gce, "aws": ec2})
+
+	vm1 := &model.VM{Name: "vm1"}
+	vm2 := &model.VM{Name: "vm2", Provider: "aws"}
+	vm3 := &model.VM{Name: "vm3", Provider: model.ProviderGCP}
+	gce.EXPECT().FindAll(gomock.Any(), []*model.VM{vm1, vm3}).Return([]*model.VM{{Name: "vm1"}, nil}, nil)
+	ec2.EXPECT().FindAll(gomock.Any(), []*model.VM{vm2}).Return([]*model.VM{{Name: "vm2"}}, nil)
+
+	found, err := repo.FindAll(context.Background(), []*model.VM{vm1, vm2, vm3})
+	require.NoError(t, err)
+	require.Len(t, found, 3)
+	assert.Equal(t, "vm1", found[0].Name)
+	assert.Equal(t, "vm2", found[1].Name)
+	assert.Equal(t, "aws", found[1].Provider)
+	assert.Nil(t, found[2])
+}
+
+func TestVMRepositoryRejectsUnsupportedProvider(t *testing.T) {
+	repo := NewVMRepository(map[string]repository.VMRepository{model.ProviderGCP: nil})
+
+	err := repo.Start(context.Background(), &model.VM{Name: "builder", Provider: "aws"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `provider "aws" is not supported`)
+
+	_, err = repo.FindAll(context.Background(), []*model.VM{{Name: "builder", Provider: "aws"}})
+	require.Error(t, err)
+	assert.Equal(t, []string{model.ProviderGCP}, repo.Providers())
+}
@@ -0,0 +1,84 @@
+package rdp
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"runtime"
+)
+
+// ErrUnavailable is returned when no supported RDP client is found.
+var ErrUnavailable = errors.New("no RDP client found (install Microsoft Remote Desktop, xfreerdp or remmina)")
+
+// candidate is an RDP client command and its arguments.
+type candidate struct {
+	name string
+	args []string
+}
+
+// candidates returns the RDP clients to try for the given OS, in order of
+// preference, connecting to addr as user; user may be empty.
+func candidates(goos, addr, user string) []candidate {
+	switch goos {
+	case "darwin":
+		// Microsoft Remote Desktop (Windows App) handles rdp:// URLs.
+		u := "rdp://full%20address=s:" + addr
+		if user != "" {
+			u += "&username=s:" + url.QueryEscape(user)
+		}
+		return []candidate{{name: "open", args: []string{u}}}
+	case "windows":
+		return []candidate{{name: "mstsc.exe", args: []string{"/v:" + addr}}}
+	default:
+		freerdp := []string{"/v:" + addr}
+		if user != "" {
+			freerdp = append(freerdp, "/u:"+user)
+		}
+		remmina := "rdp://" + addr
+		if user != "" {
+			remmina = "rdp://" + url.User(user).String() + "@" + addr
+		}
+		return []candidate{
+			{name: "xfreerdp3", args: freerdp},
+			{name: "xfreerdp", args: freerdp},
+			{name: "remmina", args: []string{"-c", remmina}},
+			// WSL can start the Windows client
+			{name: "mstsc.exe", args: []string{"/v:" + addr}},
+		}
+	}
+}
+
+// findCommand returns the first candidate available on PATH.
+func findCommand(goos, addr, user string, lookPath func(string) (string, error)) (candidate, error) {
+	for _, c := range candidates(goos, addr, user) {
+		if _, err := lookPath(c.name); err == nil {
+			return c, nil
+		}
+	}
+	return candidate{}, ErrUnavailable
+}
+
+// Launch starts the platform RDP client connecting to addr without waiting for
+// it to exit.
+//
+// Parameters:
+//   - addr: The host:port to connect to, e.g. the local end of a tunnel
+//   - user: The user name to log in as, or "" to be asked by the client
+//
+// Returns:
+//   - error: ErrUnavailable if no RDP client is installed, or the error starting it
+func Launch(addr, user string) error {
+	c, err := findCommand(runtime.GOOS, addr, user, exec.LookPath)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(c.name, c.args...)
+	if err = cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start %s: %w", c.name, err)
+	}
+	go func() {
+		_ = cmd.Wait()
+	}()
+	return nil
+}
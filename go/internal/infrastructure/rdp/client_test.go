@@ -0,0 +1,76 @@
+package rdp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindCommand(t *testing.T) {
+	//nolint:govet // field alignment is less important than readability in tests
+	tests := []struct {
+		name      string
+		goos      string
+		user      string
+		available []string
+		want      candidate
+		wantErr   error
+	}{
+		{
+			name:      "darwin opens an rdp URL",
+			goos:      "darwin",
+			user:      "alice",
+			available: []string{"open"},
+			want:      candidate{name: "open", args: []string{"rdp://full%20address=s:localhost:3389&username=s:alice"}},
+		},
+		{
+			name:      "windows uses mstsc",
+			goos:      "windows",
+			available: []string{"mstsc.exe"},
+			want:      candidate{name: "mstsc.exe", args: []string{"/v:localhost:3389"}},
+		},
+		{
+			name:      "linux prefers xfreerdp3",
+			goos:      "linux",
+			user:      "alice",
+			available: []string{"remmina", "xfreerdp", "xfreerdp3"},
+			want:      candidate{name: "xfreerdp3", args: []string{"/v:localhost:3389", "/u:alice"}},
+		},
+		{
+			name:      "linux falls back to remmina",
+			goos:      "linux",
+			user:      "alice",
+			available: []string{"remmina"},
+			want:      candidate{name: "remmina", args: []string{"-c", "rdp://alice@localhost:3389"}},
+		},
+		{
+			name:      "no client available",
+			goos:      "linux",
+			available: nil,
+			wantErr:   ErrUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookPath := func(name string) (string, error) {
+				for _, a := range tt.available {
+					if a == name {
+						return "/usr/bin/" + name, nil
+					}
+				}
+				return "", errors.New("not found")
+			}
+
+			got, err := findCommand(tt.goos, "localhost:3389", tt.user, lookPath)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
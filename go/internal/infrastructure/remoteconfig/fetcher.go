@@ -0,0 +1,203 @@
+package remoteconfig
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/infrastructure/xdg"
+	"google.golang.org/api/option"
+	storage "google.golang.org/api/storage/v1"
+)
+
+// maxConfigSize bounds the size of a fetched config.
+const maxConfigSize = 10 << 20
+
+// Fetcher fetches remote configs into a cache directory and reuses the cached
+// copy until it is refreshed.
+type Fetcher struct {
+	// HTTPClient fetches HTTP sources.
+	HTTPClient *http.Client
+	// Validate, when set, checks a fetched config before it replaces the cached
+	// copy, so a broken config does not replace a working one.
+	Validate func(path string) error
+	// CacheDir keeps the fetched configs.
+	CacheDir string
+	// GCSOptions configure the Cloud Storage client fetching GCS sources, e.g.
+	// its credentials.
+	GCSOptions []option.ClientOption
+}
+
+// DefaultCacheDir returns the default directory of the fetched configs,
+// $XDG_CACHE_HOME/gcectl/remote-config (~/.cache/gcectl/remote-config).
+func DefaultCacheDir() (string, error) {
+	return xdg.CacheFile("remote-config")
+}
+
+// NewFetcher returns a Fetcher caching the configs in cacheDir.
+func NewFetcher(cacheDir string) *Fetcher {
+	return &Fetcher{HTTPClient: &http.Client{Timeout: 30 * time.Second}, CacheDir: cacheDir}
+}
+
+// CachePath returns the file the config of src is cached in, named after the
+// config and a hash of its location, e.g. 3f2a9c0e5d1b7a48-gcectl.yaml.
+func (f *Fetcher) CachePath(src Source) string {
+	sum := sha256.Sum256([]byte(src.Raw))
+	return filepath.Join(f.CacheDir, hex.EncodeToString(sum[:8])+"-"+src.name())
+}
+
+// Resolve returns the cached copy of the config of src, fetching it first when
+// it has not been fetched yet.
+//
+// Parameters:
+//   - ctx: Bounds the fetch
+//   - src: The remote config
+//
+// Returns:
+//   - string: The path of the cached copy
+//   - error: An error if the config had to be fetched and could not be
+func (f *Fetcher) Resolve(ctx context.Context, src Source) (string, error) {
+	path := f.CachePath(src)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+	return f.Refresh(ctx, src)
+}
+
+// Refresh fetches the config of src and replaces its cached copy. The cached
+// copy is kept when the fetch or the validation fails.
+//
+// Parameters:
+//   - ctx: Bounds the fetch
+//   - src: The remote config
+//
+// Returns:
+//   - string: The path of the cached copy
+//   - error: An error if the config could not be fetched, is invalid or could not be cached
+func (f *Fetcher) Refresh(ctx context.Context, src Source) (string, error) {
+	data, err := f.fetch(ctx, src)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch config %s: %w", src.Raw, err)
+	}
+	if mkdirErr := os.MkdirAll(f.CacheDir, 0o700); mkdirErr != nil {
+		return "", fmt.Errorf("failed to create config cache directory: %w", mkdirErr)
+	}
+
+	path := f.CachePath(src)
+	// The temporary file has the extension of the config, as the validation
+	// and anyone opening the file may look at it.
+	tmp, err := os.CreateTemp(f.CacheDir, ".fetch-*-"+src.name())
+	if err != nil {
+		return "", fmt.Errorf("failed to cache config: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, writeErr := tmp.Write(data); writeErr != nil {
+		_ = tmp.Close()
+		return "", fmt.Errorf("failed to cache config: %w", writeErr)
+	}
+	if closeErr := tmp.Close(); closeErr != nil {
+		return "", fmt.Errorf("failed to cache config: %w", closeErr)
+	}
+	if f.Validate != nil {
+		if validateErr := f.Validate(tmp.Name()); validateErr != nil {
+			return "", fmt.Errorf("fetched config %s is invalid: %w", src.Raw, validateErr)
+		}
+	}
+	if renameErr := os.Rename(tmp.Name(), path); renameErr != nil {
+		return "", fmt.Errorf("failed to cache config: %w", renameErr)
+	}
+	return path, nil
+}
+
+func (f *Fetcher) fetch(ctx context.Context, src Source) ([]byte, error) {
+	switch src.Kind {
+	case GCS:
+		return f.fetchGCS(ctx, src)
+	case HTTP:
+		return f.fetchHTTP(ctx, src)
+	case Git:
+		return f.fetchGit(ctx, src)
+	}
+	return nil, fmt.Errorf("unknown source kind %d", src.Kind)
+}
+
+func (f *Fetcher) fetchGCS(ctx context.Context, src Source) ([]byte, error) {
+	svc, err := storage.NewService(ctx, f.GCSOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Cloud Storage client: %w", err)
+	}
+	res, err := svc.Objects.Get(src.Bucket, src.Object).Context(ctx).Download()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = res.Body.Close() }()
+	return readLimited(res.Body)
+}
+
+func (f *Fetcher) fetchHTTP(ctx context.Context, src Source) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src.URL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	client := f.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.New(res.Status)
+	}
+	return readLimited(res.Body)
+}
+
+// fetchGit reads the file of src from a shallow clone of its repository.
+func (f *Fetcher) fetchGit(ctx context.Context, src Source) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "gcectl-config-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	args := []string{"clone", "--quiet", "--depth", "1"}
+	if src.Ref != "" {
+		args = append(args, "--branch", src.Ref)
+	}
+	args = append(args, "--", src.URL, dir)
+	cmd := exec.CommandContext(ctx, "git", args...)
+	// Fail instead of waiting for a password nobody is asked for.
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if out, runErr := cmd.CombinedOutput(); runErr != nil {
+		return nil, fmt.Errorf("git clone failed: %w: %s", runErr, strings.TrimSpace(string(out)))
+	}
+
+	file, err := os.Open(filepath.Join(dir, filepath.FromSlash(src.File)))
+	if err != nil {
+		return nil, fmt.Errorf("%s not found in %s", src.File, src.URL)
+	}
+	defer func() { _ = file.Close() }()
+	return readLimited(file)
+}
+
+func readLimited(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxConfigSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxConfigSize {
+		return nil, fmt.Errorf("config is larger than %d MiB", maxConfigSize>>20)
+	}
+	return data, nil
+}
@@ -0,0 +1,138 @@
+package remoteconfig
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+)
+
+func TestFetcher_HTTPResolveAndRefresh(t *testing.T) {
+	ctx := context.Background()
+	body := "default-project: team\n"
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+	src, _, err := ParseSource(server.URL + "/gcectl.yaml")
+	require.NoError(t, err)
+	f := NewFetcher(t.TempDir())
+
+	path, err := f.Resolve(ctx, src)
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(path, "-gcectl.yaml"), path)
+	assertFile(t, path, body)
+
+	body = "default-project: other\n"
+	_, err = f.Resolve(ctx, src)
+	require.NoError(t, err)
+	assert.Equal(t, 1, requests, "the cached copy is reused")
+	assertFile(t, path, "default-project: team\n")
+
+	_, err = f.Refresh(ctx, src)
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests)
+	assertFile(t, path, body)
+}
+
+func TestFetcher_RefreshKeepsCacheOnFailure(t *testing.T) {
+	ctx := context.Background()
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("vm: []\n"))
+	}))
+	defer server.Close()
+	src, _, err := ParseSource(server.URL + "/gcectl.yaml")
+	require.NoError(t, err)
+	f := NewFetcher(t.TempDir())
+	path, err := f.Refresh(ctx, src)
+	require.NoError(t, err)
+
+	status = http.StatusNotFound
+	_, err = f.Refresh(ctx, src)
+	require.ErrorContains(t, err, "404 Not Found")
+	assertFile(t, path, "vm: []\n")
+
+	status = http.StatusOK
+	f.Validate = func(string) error { return errors.New("no vm") }
+	_, err = f.Refresh(ctx, src)
+	require.ErrorContains(t, err, "is invalid: no vm")
+	assertFile(t, path, "vm: []\n")
+	entries, err := os.ReadDir(f.CacheDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary file is left behind")
+}
+
+func TestFetcher_GCS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/storage/v1/b/team-bucket/o/gcectl/config.yaml" || r.URL.Query().Get("alt") != "media" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("default-zone: us-central1-a\n"))
+	}))
+	defer server.Close()
+	src, _, err := ParseSource("gs://team-bucket/gcectl/config.yaml")
+	require.NoError(t, err)
+	f := NewFetcher(t.TempDir())
+	f.GCSOptions = []option.ClientOption{option.WithEndpoint(server.URL + "/storage/v1/"), option.WithoutAuthentication()}
+
+	path, err := f.Refresh(context.Background(), src)
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(path, "-config.yaml"), path)
+	assertFile(t, path, "default-zone: us-central1-a\n")
+}
+
+func TestFetcher_Git(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	repo := filepath.Join(t.TempDir(), "infra.git")
+	work := t.TempDir()
+	runGit(t, "", "init", "--quiet", "--bare", repo)
+	runGit(t, "", "clone", "--quiet", repo, work)
+	require.NoError(t, os.MkdirAll(filepath.Join(work, "gcectl"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(work, "gcectl", "config.yaml"), []byte("default-project: shared\n"), 0o644))
+	runGit(t, work, "add", ".")
+	runGit(t, work, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "config")
+	runGit(t, work, "push", "--quiet", "origin", "HEAD:refs/heads/v1")
+
+	f := NewFetcher(t.TempDir())
+	src, _, err := ParseSource("file://" + repo + "//gcectl/config.yaml?ref=v1")
+	require.NoError(t, err)
+	path, err := f.Refresh(context.Background(), src)
+	require.NoError(t, err)
+	assertFile(t, path, "default-project: shared\n")
+
+	src, _, err = ParseSource("file://" + repo + "//missing.yaml?ref=v1")
+	require.NoError(t, err)
+	_, err = f.Refresh(context.Background(), src)
+	assert.ErrorContains(t, err, "missing.yaml not found")
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func assertFile(t *testing.T, path, want string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, want, string(data))
+}
@@ -0,0 +1,110 @@
+// Package remoteconfig fetches a config file shared from a GCS bucket, an
+// HTTPS URL or a git repository, and keeps a local copy of it.
+package remoteconfig
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Kind is where a remote config is fetched from.
+type Kind int
+
+const (
+	// GCS is an object in a Cloud Storage bucket, e.g. gs://team-bucket/gcectl.yaml.
+	GCS Kind = iota + 1
+	// HTTP is a file served over HTTP(S), e.g. https://example.com/gcectl.yaml.
+	HTTP
+	// Git is a file in a git repository, e.g. https://github.com/team/infra.git//gcectl.yaml?ref=main.
+	Git
+)
+
+// gitSeparator separates the repository of a git source from the file in it.
+const gitSeparator = ".git//"
+
+// Source is a remote config location.
+type Source struct {
+	// Raw is the location as given, e.g. to --config.
+	Raw  string
+	Kind Kind
+	// Bucket and Object name the GCS object of a GCS source.
+	Bucket string
+	Object string
+	// URL is the file of an HTTP source, or the repository of a Git source.
+	URL string
+	// File is the path of the config in the repository of a Git source.
+	File string
+	// Ref is the branch or tag of a Git source, or empty for the default branch.
+	Ref string
+}
+
+// ParseSource parses a config location. Local paths are not remote and yield
+// false; remote ones take the forms
+//
+//	gs://<bucket>/<object>
+//	https://<host>/<path>
+//	<repository>.git//<file>[?ref=<branch or tag>]
+//
+// where the repository is any URL git clones, such as
+// https://github.com/team/infra.git or git@github.com:team/infra.git.
+//
+// Parameters:
+//   - raw: The config location
+//
+// Returns:
+//   - Source: The remote location
+//   - bool: Whether raw is remote
+//   - error: An error if raw looks remote but is malformed
+func ParseSource(raw string) (Source, bool, error) {
+	switch {
+	case strings.Contains(raw, gitSeparator) && (strings.Contains(raw, "://") || strings.HasPrefix(raw, "git@")):
+		return parseGitSource(raw)
+	case strings.HasPrefix(raw, "gs://"):
+		bucket, object, _ := strings.Cut(strings.TrimPrefix(raw, "gs://"), "/")
+		if bucket == "" || object == "" || strings.HasSuffix(object, "/") {
+			return Source{}, true, fmt.Errorf("invalid config location %q: want gs://<bucket>/<object>", raw)
+		}
+		return Source{Raw: raw, Kind: GCS, Bucket: bucket, Object: object}, true, nil
+	case strings.HasPrefix(raw, "https://"), strings.HasPrefix(raw, "http://"):
+		u, err := url.Parse(raw)
+		if err != nil || u.Host == "" {
+			return Source{}, true, fmt.Errorf("invalid config location %q: want an absolute URL", raw)
+		}
+		return Source{Raw: raw, Kind: HTTP, URL: raw}, true, nil
+	}
+	return Source{}, false, nil
+}
+
+func parseGitSource(raw string) (Source, bool, error) {
+	i := strings.Index(raw, gitSeparator)
+	repo, file := raw[:i+len(".git")], raw[i+len(gitSeparator):]
+	file, query, _ := strings.Cut(file, "?")
+	var ref string
+	if query != "" {
+		values, err := url.ParseQuery(query)
+		if err != nil {
+			return Source{}, true, fmt.Errorf("invalid config location %q: %w", raw, err)
+		}
+		ref = values.Get("ref")
+	}
+	if file == "" || strings.HasSuffix(file, "/") || path.Clean(file) != file || strings.HasPrefix(file, "..") {
+		return Source{}, true, fmt.Errorf("invalid config location %q: want <repository>.git//<file>[?ref=<branch>]", raw)
+	}
+	return Source{Raw: raw, Kind: Git, URL: repo, File: file, Ref: ref}, true, nil
+}
+
+// name returns the base name of the config file of s, e.g. gcectl.yaml.
+func (s Source) name() string {
+	switch s.Kind {
+	case GCS:
+		return path.Base(s.Object)
+	case Git:
+		return path.Base(s.File)
+	}
+	if u, err := url.Parse(s.URL); err == nil && path.Base(u.Path) != "/" && path.Base(u.Path) != "." {
+		return path.Base(u.Path)
+	}
+	return "config.yaml"
+}
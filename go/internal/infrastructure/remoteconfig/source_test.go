@@ -0,0 +1,52 @@
+package remoteconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    Source
+		remote  bool
+		wantErr bool
+	}{
+		{name: "local path", raw: "/home/me/.config/gcectl/config.yaml"},
+		{name: "relative path", raw: "team.yaml"},
+		{
+			name: "gcs", raw: "gs://team-bucket/gcectl/config.yaml", remote: true,
+			want: Source{Raw: "gs://team-bucket/gcectl/config.yaml", Kind: GCS, Bucket: "team-bucket", Object: "gcectl/config.yaml"},
+		},
+		{name: "gcs without object", raw: "gs://team-bucket", remote: true, wantErr: true},
+		{
+			name: "https", raw: "https://example.com/gcectl.yaml", remote: true,
+			want: Source{Raw: "https://example.com/gcectl.yaml", Kind: HTTP, URL: "https://example.com/gcectl.yaml"},
+		},
+		{
+			name: "git with ref", raw: "https://github.com/team/infra.git//gcectl/config.yaml?ref=v2", remote: true,
+			want: Source{Raw: "https://github.com/team/infra.git//gcectl/config.yaml?ref=v2", Kind: Git, URL: "https://github.com/team/infra.git", File: "gcectl/config.yaml", Ref: "v2"},
+		},
+		{
+			name: "scp-like git", raw: "git@github.com:team/infra.git//gcectl.yaml", remote: true,
+			want: Source{Raw: "git@github.com:team/infra.git//gcectl.yaml", Kind: Git, URL: "git@github.com:team/infra.git", File: "gcectl.yaml"},
+		},
+		{name: "git without file", raw: "https://github.com/team/infra.git//", remote: true, wantErr: true},
+		{name: "git escaping the repository", raw: "https://github.com/team/infra.git//../x.yaml", remote: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, remote, err := ParseSource(tt.raw)
+			assert.Equal(t, tt.remote, remote)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
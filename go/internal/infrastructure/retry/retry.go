@@ -0,0 +1,140 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/googleapis/gax-go/v2/apierror"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	"google.golang.org/grpc/codes"
+)
+
+// Policy controls how transient failures are retried.
+// A MaxAttempts of 1 or less disables retries.
+type Policy struct {
+	// InitialBackoff is the wait before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts.
+	MaxBackoff time.Duration
+	// Multiplier grows the backoff after each attempt.
+	Multiplier float64
+	// Jitter randomizes each wait by up to ±Jitter of its length (0-1).
+	Jitter float64
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+}
+
+// DefaultPolicy returns the policy used when the config file has no retry section.
+func DefaultPolicy() Policy {
+	return Policy{
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		MaxAttempts:    4,
+	}
+}
+
+// Enabled reports whether the policy retries at all.
+func (p Policy) Enabled() bool {
+	return p.MaxAttempts > 1
+}
+
+// backoff returns the wait before retry number attempt (1-based), before jitter.
+func (p Policy) backoff(attempt int) time.Duration {
+	d := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		d *= p.Multiplier
+	}
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	return time.Duration(d)
+}
+
+// withJitter spreads d by up to ±jitter so concurrent callers do not retry in lockstep.
+func withJitter(d time.Duration, jitter float64, rnd func() float64) time.Duration {
+	if jitter <= 0 {
+		return d
+	}
+	return time.Duration(float64(d) * (1 + jitter*(2*rnd()-1)))
+}
+
+// Do calls fn until it succeeds, returns a non-transient error, the policy's attempts
+// are exhausted, or ctx is done. Each retry is logged as a warning.
+//
+// Parameters:
+//   - ctx: Context for cancellation; waiting between attempts stops when it is done
+//   - policy: Retry policy
+//   - logger: Logger for retry activity
+//   - name: Short description of the call for log messages (e.g., "get instance sandbox")
+//   - fn: The call to retry
+//
+// Returns:
+//   - error: nil on success, otherwise the last error from fn or the context error
+func Do(ctx context.Context, policy Policy, logger log.Logger, name string, fn func() error) error {
+	return do(ctx, policy, logger, name, fn, rand.Float64, sleep)
+}
+
+func do(ctx context.Context, policy Policy, logger log.Logger, name string, fn func() error,
+	rnd func() float64, wait func(context.Context, time.Duration) error,
+) error {
+	attempts := max(policy.MaxAttempts, 1)
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn()
+		if err == nil || attempt >= attempts || !IsTransient(err) {
+			return err
+		}
+
+		d := withJitter(policy.backoff(attempt), policy.Jitter, rnd)
+		logger.Warnf("Retrying %s in %v (attempt %d/%d): %v", name, d.Round(time.Millisecond), attempt+1, attempts, err)
+		if waitErr := wait(ctx, d); waitErr != nil {
+			return errors.Join(err, waitErr)
+		}
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// IsTransient reports whether err is a rate-limit (429) or server-side (5xx) API error
+// that is worth retrying.
+func IsTransient(err error) bool {
+	var apiErr *apierror.APIError
+	if !errors.As(err, &apiErr) {
+		var ok bool
+		if apiErr, ok = apierror.FromError(err); !ok {
+			return false
+		}
+	}
+
+	switch apiErr.HTTPCode() {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	case -1:
+		// Not an HTTP error; fall back to the gRPC status.
+		if s := apiErr.GRPCStatus(); s != nil {
+			switch s.Code() {
+			case codes.Unavailable, codes.ResourceExhausted:
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/googleapi"
+)
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		err  error
+		name string
+		want bool
+	}{
+		{name: "429", err: &googleapi.Error{Code: http.StatusTooManyRequests}, want: true},
+		{name: "503", err: &googleapi.Error{Code: http.StatusServiceUnavailable}, want: true},
+		{name: "wrapped 500", err: fmt.Errorf("failed to get instance: %w", &googleapi.Error{Code: http.StatusInternalServerError}), want: true},
+		{name: "404", err: &googleapi.Error{Code: http.StatusNotFound}, want: false},
+		{name: "400", err: &googleapi.Error{Code: http.StatusBadRequest}, want: false},
+		{name: "plain error", err: errors.New("boom"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsTransient(tt.err))
+		})
+	}
+}
+
+func TestPolicyBackoff(t *testing.T) {
+	p := Policy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond, Multiplier: 2, MaxAttempts: 5}
+	assert.Equal(t, 100*time.Millisecond, p.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, p.backoff(2))
+	assert.Equal(t, 300*time.Millisecond, p.backoff(3), "backoff is capped at MaxBackoff")
+
+	assert.Equal(t, 80*time.Millisecond, withJitter(100*time.Millisecond, 0.2, func() float64 { return 0 }))
+	assert.Equal(t, 120*time.Millisecond, withJitter(100*time.Millisecond, 0.2, func() float64 { return 1 }))
+	assert.Equal(t, 100*time.Millisecond, withJitter(100*time.Millisecond, 0, func() float64 { return 1 }))
+}
+
+func TestDo(t *testing.T) {
+	transient := &googleapi.Error{Code: http.StatusTooManyRequests}
+	permanent := &googleapi.Error{Code: http.StatusForbidden}
+	policy := Policy{InitialBackoff: time.Millisecond, Multiplier: 2, MaxAttempts: 3}
+	noJitter := func() float64 { return 0.5 }
+
+	tests := []struct {
+		errs      []error
+		wantErr   error
+		name      string
+		wantCalls int
+		wantWaits int
+	}{
+		{name: "succeeds first time", errs: []error{nil}, wantCalls: 1},
+		{name: "retries transient then succeeds", errs: []error{transient, transient, nil}, wantCalls: 3, wantWaits: 2},
+		{name: "gives up after max attempts", errs: []error{transient, transient, transient}, wantErr: transient, wantCalls: 3, wantWaits: 2},
+		{name: "does not retry permanent errors", errs: []error{permanent}, wantErr: permanent, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls, waits := 0, 0
+			err := do(context.Background(), policy, log.NewLogger(), "get instance", func() error {
+				calls++
+				return tt.errs[calls-1]
+			}, noJitter, func(context.Context, time.Duration) error {
+				waits++
+				return nil
+			})
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCalls, calls)
+			assert.Equal(t, tt.wantWaits, waits)
+		})
+	}
+}
+
+func TestDoStopsWhenContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	err := Do(ctx, Policy{InitialBackoff: time.Hour, MaxAttempts: 3}, log.NewLogger(), "get instance", func() error {
+		calls++
+		return &googleapi.Error{Code: http.StatusServiceUnavailable}
+	})
+
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
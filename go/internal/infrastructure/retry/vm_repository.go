@@ -0,0 +1,154 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// VMRepository decorates a repository.VMRepository so every call is retried
+// on transient API errors according to a Policy.
+type VMRepository struct {
+	inner  repository.VMRepository
+	logger log.Logger
+	policy Policy
+}
+
+// NewVMRepository wraps inner with the given retry policy.
+func NewVMRepository(inner repository.VMRepository, policy Policy, logger log.Logger) *VMRepository {
+	return &VMRepository{inner: inner, logger: logger, policy: policy}
+}
+
+func (r *VMRepository) do(ctx context.Context, name string, vm *model.VM, fn func() error) error {
+	return Do(ctx, r.policy, r.logger, fmt.Sprintf("%s %s", name, vm.Name), fn)
+}
+
+func (r *VMRepository) FindByName(ctx context.Context, vm *model.VM) (*model.VM, error) {
+	var found *model.VM
+	err := r.do(ctx, "get instance", vm, func() error {
+		var err error
+		found, err = r.inner.FindByName(ctx, vm)
+		return err
+	})
+	return found, err
+}
+
+func (r *VMRepository) FindAll(ctx context.Context, vms []*model.VM) ([]*model.VM, error) {
+	var found []*model.VM
+	err := Do(ctx, r.policy, r.logger, fmt.Sprintf("list %d instances", len(vms)), func() error {
+		var err error
+		found, err = r.inner.FindAll(ctx, vms)
+		return err
+	})
+	return found, err
+}
+
+func (r *VMRepository) ListByProject(ctx context.Context, project string, selector map[string]string) ([]*model.VM, error) {
+	var vms []*model.VM
+	err := Do(ctx, r.policy, r.logger, fmt.Sprintf("list instances in project %s", project), func() error {
+		var err error
+		vms, err = r.inner.ListByProject(ctx, project, selector)
+		return err
+	})
+	return vms, err
+}
+
+func (r *VMRepository) Start(ctx context.Context, vm *model.VM) error {
+	return r.do(ctx, "start instance", vm, func() error { return r.inner.Start(ctx, vm) })
+}
+
+func (r *VMRepository) Stop(ctx context.Context, vm *model.VM) error {
+	return r.do(ctx, "stop instance", vm, func() error { return r.inner.Stop(ctx, vm) })
+}
+
+// Reset is not retried: unlike a start or stop, a second reset of a VM whose
+// first one was accepted before the error, e.g. while waiting for its
+// operation, reboots it again.
+func (r *VMRepository) Reset(ctx context.Context, vm *model.VM) error {
+	return r.inner.Reset(ctx, vm)
+}
+
+func (r *VMRepository) StartAsync(ctx context.Context, vm *model.VM) (*model.Operation, error) {
+	var op *model.Operation
+	err := r.do(ctx, "start instance", vm, func() error {
+		var err error
+		op, err = r.inner.StartAsync(ctx, vm)
+		return err
+	})
+	return op, err
+}
+
+func (r *VMRepository) StopAsync(ctx context.Context, vm *model.VM) (*model.Operation, error) {
+	var op *model.Operation
+	err := r.do(ctx, "stop instance", vm, func() error {
+		var err error
+		op, err = r.inner.StopAsync(ctx, vm)
+		return err
+	})
+	return op, err
+}
+
+func (r *VMRepository) UpdateMachineType(ctx context.Context, vm *model.VM, machineType string) error {
+	return r.do(ctx, "set machine type of", vm, func() error { return r.inner.UpdateMachineType(ctx, vm, machineType) })
+}
+
+func (r *VMRepository) SetSchedulePolicy(ctx context.Context, vm *model.VM, policyName string) error {
+	return r.do(ctx, "set schedule policy of", vm, func() error { return r.inner.SetSchedulePolicy(ctx, vm, policyName) })
+}
+
+func (r *VMRepository) UnsetSchedulePolicy(ctx context.Context, vm *model.VM, policyName string) error {
+	return r.do(ctx, "unset schedule policy of", vm, func() error { return r.inner.UnsetSchedulePolicy(ctx, vm, policyName) })
+}
+
+func (r *VMRepository) GetRaw(ctx context.Context, vm *model.VM) ([]byte, error) {
+	var raw []byte
+	err := r.do(ctx, "get raw instance", vm, func() error {
+		var err error
+		raw, err = r.inner.GetRaw(ctx, vm)
+		return err
+	})
+	return raw, err
+}
+
+func (r *VMRepository) SetLabels(ctx context.Context, vm *model.VM, labels map[string]string) error {
+	return r.do(ctx, "set labels of", vm, func() error { return r.inner.SetLabels(ctx, vm, labels) })
+}
+
+func (r *VMRepository) UpdateLabels(ctx context.Context, vm *model.VM, set map[string]string, remove []string) error {
+	return r.do(ctx, "update labels of", vm, func() error { return r.inner.UpdateLabels(ctx, vm, set, remove) })
+}
+
+func (r *VMRepository) SetMetadata(ctx context.Context, vm *model.VM, items map[string]string) error {
+	return r.do(ctx, "set metadata of", vm, func() error { return r.inner.SetMetadata(ctx, vm, items) })
+}
+
+func (r *VMRepository) GetMetadata(ctx context.Context, vm *model.VM) (map[string]string, error) {
+	var items map[string]string
+	err := r.do(ctx, "get metadata of", vm, func() error {
+		var err error
+		items, err = r.inner.GetMetadata(ctx, vm)
+		return err
+	})
+	return items, err
+}
+
+func (r *VMRepository) UpdateMetadata(ctx context.Context, vm *model.VM, set map[string]string, remove []string) error {
+	return r.do(ctx, "update metadata of", vm, func() error { return r.inner.UpdateMetadata(ctx, vm, set, remove) })
+}
+
+func (r *VMRepository) SetAccelerators(ctx context.Context, vm *model.VM, accelerators []model.Accelerator) error {
+	return r.do(ctx, "set accelerators of", vm, func() error { return r.inner.SetAccelerators(ctx, vm, accelerators) })
+}
+
+func (r *VMRepository) SetScheduling(ctx context.Context, vm *model.VM, scheduling model.Scheduling) error {
+	return r.do(ctx, "set scheduling of", vm, func() error { return r.inner.SetScheduling(ctx, vm, scheduling) })
+}
+
+func (r *VMRepository) SetSchedulingOptions(ctx context.Context, vm *model.VM, opts model.SchedulingOptions) error {
+	return r.do(ctx, "set scheduling options of", vm, func() error { return r.inner.SetSchedulingOptions(ctx, vm, opts) })
+}
+
+var _ repository.VMRepository = (*VMRepository)(nil)
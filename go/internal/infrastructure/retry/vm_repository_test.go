@@ -0,0 +1,52 @@
+package retry
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/api/googleapi"
+)
+
+func TestVMRepositoryRetriesTransientErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	vm := &model.VM{Project: "p", Zone: "z", Name: "sandbox"}
+	inner := mock_repository.NewMockVMRepository(ctrl)
+	gomock.InOrder(
+		inner.EXPECT().FindByName(gomock.Any(), vm).Return(nil, &googleapi.Error{Code: http.StatusTooManyRequests}),
+		inner.EXPECT().FindByName(gomock.Any(), vm).Return(&model.VM{Name: "sandbox", Status: model.StatusRunning}, nil),
+	)
+	inner.EXPECT().Stop(gomock.Any(), vm).Return(&googleapi.Error{Code: http.StatusForbidden})
+
+	repo := NewVMRepository(inner, Policy{InitialBackoff: time.Millisecond, MaxAttempts: 3}, log.NewLogger())
+
+	found, err := repo.FindByName(context.Background(), vm)
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusRunning, found.Status)
+
+	err = repo.Stop(context.Background(), vm)
+	require.Error(t, err, "permanent errors are returned without retrying")
+}
+
+func TestVMRepositoryDoesNotRetryReset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	vm := &model.VM{Project: "p", Zone: "z", Name: "sandbox"}
+	inner := mock_repository.NewMockVMRepository(ctrl)
+	inner.EXPECT().Reset(gomock.Any(), vm).Return(&googleapi.Error{Code: http.StatusServiceUnavailable}).Times(1)
+
+	repo := NewVMRepository(inner, Policy{InitialBackoff: time.Millisecond, MaxAttempts: 3}, log.NewLogger())
+
+	err := repo.Reset(context.Background(), vm)
+	require.Error(t, err, "a transient error is returned rather than resetting the VM twice")
+}
@@ -0,0 +1,185 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/xdg"
+)
+
+// File is a ScheduledActionStore backed by a single JSON file.
+// Pending actions cannot be rebuilt from the API, so an unreadable file is an
+// error instead of being silently discarded.
+type File struct {
+	entries map[string]entry
+	path    string
+	mu      sync.Mutex
+}
+
+// document is the on-disk layout of the scheduler file.
+type document struct {
+	Actions map[string]entry `json:"actions"`
+}
+
+// entry is a single pending action.
+type entry struct {
+	At        time.Time `json:"at"`
+	CreatedAt time.Time `json:"created_at"`
+	VMName    string    `json:"vm"`
+	Project   string    `json:"project"`
+	Zone      string    `json:"zone"`
+	Action    string    `json:"action"`
+	Reason    string    `json:"reason,omitempty"`
+}
+
+// DefaultPath returns the default scheduler file location, $XDG_STATE_HOME/gcectl/scheduled.json
+// (~/.local/state/gcectl/scheduled.json), or the scheduled.json of the user config directory
+// when an earlier version of gcectl keeps it there.
+func DefaultPath() (string, error) {
+	return xdg.StateFile("scheduled.json")
+}
+
+// OpenDefault loads the scheduler file at DefaultPath.
+func OpenDefault() (*File, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return Open(path)
+}
+
+// Open loads the scheduler file at path. A missing file yields an empty store.
+//
+// Parameters:
+//   - path: The scheduler file path
+//
+// Returns:
+//   - *File: The loaded store
+//   - error: An error if the file exists but cannot be read or parsed
+func Open(path string) (*File, error) {
+	f := &File{path: path, entries: make(map[string]entry)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scheduler file: %w", err)
+	}
+
+	var doc document
+	if unmarshalErr := json.Unmarshal(data, &doc); unmarshalErr != nil {
+		return nil, fmt.Errorf("failed to parse scheduler file %s: %w", path, unmarshalErr)
+	}
+	for k, e := range doc.Actions {
+		f.entries[k] = e
+	}
+	return f, nil
+}
+
+// Get returns the pending action of vm, looked up by project, zone and name.
+func (f *File) Get(vm *model.VM) (*model.ScheduledAction, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	e, ok := f.entries[key(vm.Project, vm.Zone, vm.Name)]
+	if !ok {
+		return nil, false
+	}
+	return e.toModel(), true
+}
+
+// List returns all pending actions ordered by time.
+func (f *File) List() []*model.ScheduledAction {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	actions := make([]*model.ScheduledAction, 0, len(f.entries))
+	for _, e := range f.entries {
+		actions = append(actions, e.toModel())
+	}
+	sort.Slice(actions, func(i, j int) bool {
+		return actions[i].At.Before(actions[j].At)
+	})
+	return actions
+}
+
+// Put records a and rewrites the file.
+func (f *File) Put(a *model.ScheduledAction) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.entries[key(a.Project, a.Zone, a.VMName)] = entry{
+		At:        a.At,
+		CreatedAt: a.CreatedAt,
+		VMName:    a.VMName,
+		Project:   a.Project,
+		Zone:      a.Zone,
+		Action:    string(a.Action),
+		Reason:    a.Reason,
+	}
+	return f.write()
+}
+
+// Delete removes the pending action of vm and rewrites the file.
+func (f *File) Delete(vm *model.VM) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.entries, key(vm.Project, vm.Zone, vm.Name))
+	return f.write()
+}
+
+func (e entry) toModel() *model.ScheduledAction {
+	return &model.ScheduledAction{
+		At:        e.At,
+		CreatedAt: e.CreatedAt,
+		VMName:    e.VMName,
+		Project:   e.Project,
+		Zone:      e.Zone,
+		Action:    model.ScheduledActionType(e.Action),
+		Reason:    e.Reason,
+	}
+}
+
+// write replaces the scheduler file via a temporary file so an interrupted write
+// never leaves a truncated document behind.
+func (f *File) write() error {
+	data, err := json.MarshalIndent(document{Actions: f.entries}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode scheduled actions: %w", err)
+	}
+
+	dir := filepath.Dir(f.path)
+	if mkErr := os.MkdirAll(dir, 0o700); mkErr != nil {
+		return fmt.Errorf("failed to create scheduler directory: %w", mkErr)
+	}
+	tmp, err := os.CreateTemp(dir, ".scheduled-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create scheduler file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, writeErr := tmp.Write(data); writeErr != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write scheduler file: %w", writeErr)
+	}
+	if closeErr := tmp.Close(); closeErr != nil {
+		return fmt.Errorf("failed to write scheduler file: %w", closeErr)
+	}
+	if renameErr := os.Rename(tmp.Name(), f.path); renameErr != nil {
+		return fmt.Errorf("failed to replace scheduler file: %w", renameErr)
+	}
+	return nil
+}
+
+func key(project, zone, name string) string {
+	return project + "/" + zone + "/" + name
+}
@@ -0,0 +1,57 @@
+package scheduler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_PutGetDelete(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gcectl", "scheduled.json")
+	created := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+	a := &model.ScheduledAction{At: created.Add(3 * time.Hour), CreatedAt: created, VMName: "vm-1", Project: "proj", Zone: "us-central1-a", Action: model.ScheduledStop, Reason: "ttl 3h0m0s"}
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	require.NoError(t, f.Put(a))
+
+	reopened, err := Open(path)
+	require.NoError(t, err)
+	got, ok := reopened.Get(a.VM())
+	require.True(t, ok)
+	assert.Equal(t, a, got)
+
+	_, ok = reopened.Get(&model.VM{Name: "vm-1", Project: "proj", Zone: "us-central1-b"})
+	assert.False(t, ok, "actions are keyed by project, zone and name")
+
+	require.NoError(t, reopened.Delete(a.VM()))
+	again, err := Open(path)
+	require.NoError(t, err)
+	assert.Empty(t, again.List())
+}
+
+func TestFile_ListOrdersByTime(t *testing.T) {
+	f, err := Open(filepath.Join(t.TempDir(), "scheduled.json"))
+	require.NoError(t, err)
+	now := time.Now()
+	require.NoError(t, f.Put(&model.ScheduledAction{At: now.Add(2 * time.Hour), VMName: "later", Project: "p", Zone: "z", Action: model.ScheduledStop}))
+	require.NoError(t, f.Put(&model.ScheduledAction{At: now.Add(time.Hour), VMName: "sooner", Project: "p", Zone: "z", Action: model.ScheduledStop}))
+
+	actions := f.List()
+	require.Len(t, actions, 2)
+	assert.Equal(t, "sooner", actions[0].VMName)
+	assert.Equal(t, "later", actions[1].VMName)
+}
+
+func TestOpen_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scheduled.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+	_, err := Open(path)
+	assert.ErrorContains(t, err, "failed to parse scheduler file")
+}
@@ -0,0 +1,170 @@
+package snooze
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/xdg"
+)
+
+// File is a SnoozeStore backed by a single JSON file.
+// Unlike the VM state cache, snoozes cannot be rebuilt from the API, so an
+// unreadable file is an error instead of being silently discarded.
+type File struct {
+	entries map[string]entry
+	path    string
+	mu      sync.Mutex
+}
+
+// document is the on-disk layout of the snooze file.
+type document struct {
+	Snoozes map[string]entry `json:"snoozes"`
+}
+
+// entry is a single recorded snooze.
+type entry struct {
+	Until   time.Time `json:"until"`
+	VMName  string    `json:"vm"`
+	Project string    `json:"project"`
+	Zone    string    `json:"zone"`
+	Policy  string    `json:"policy"`
+}
+
+// DefaultPath returns the default snooze file location, $XDG_STATE_HOME/gcectl/snoozes.json
+// (~/.local/state/gcectl/snoozes.json), or the snoozes.json of the user config directory
+// when an earlier version of gcectl keeps it there.
+func DefaultPath() (string, error) {
+	return xdg.StateFile("snoozes.json")
+}
+
+// Open loads the snooze file at path. A missing file yields an empty store.
+//
+// Parameters:
+//   - path: The snooze file path
+//
+// Returns:
+//   - *File: The loaded store
+//   - error: An error if the file exists but cannot be read or parsed
+func Open(path string) (*File, error) {
+	f := &File{path: path, entries: make(map[string]entry)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snooze file: %w", err)
+	}
+
+	var doc document
+	if unmarshalErr := json.Unmarshal(data, &doc); unmarshalErr != nil {
+		return nil, fmt.Errorf("failed to parse snooze file %s: %w", path, unmarshalErr)
+	}
+	for k, e := range doc.Snoozes {
+		f.entries[k] = e
+	}
+	return f, nil
+}
+
+// Get returns the snooze of vm, looked up by project, zone and name.
+func (f *File) Get(vm *model.VM) (*model.Snooze, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	e, ok := f.entries[key(vm.Project, vm.Zone, vm.Name)]
+	if !ok {
+		return nil, false
+	}
+	return e.toModel(), true
+}
+
+// List returns all snoozes ordered by end time.
+func (f *File) List() []*model.Snooze {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	snoozes := make([]*model.Snooze, 0, len(f.entries))
+	for _, e := range f.entries {
+		snoozes = append(snoozes, e.toModel())
+	}
+	sort.Slice(snoozes, func(i, j int) bool {
+		return snoozes[i].Until.Before(snoozes[j].Until)
+	})
+	return snoozes
+}
+
+// Put records s and rewrites the file.
+func (f *File) Put(s *model.Snooze) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.entries[key(s.Project, s.Zone, s.VMName)] = entry{
+		Until:   s.Until,
+		VMName:  s.VMName,
+		Project: s.Project,
+		Zone:    s.Zone,
+		Policy:  s.Policy,
+	}
+	return f.write()
+}
+
+// Delete removes the snooze of vm and rewrites the file.
+func (f *File) Delete(vm *model.VM) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.entries, key(vm.Project, vm.Zone, vm.Name))
+	return f.write()
+}
+
+func (e entry) toModel() *model.Snooze {
+	return &model.Snooze{
+		Until:   e.Until,
+		VMName:  e.VMName,
+		Project: e.Project,
+		Zone:    e.Zone,
+		Policy:  e.Policy,
+	}
+}
+
+// write replaces the snooze file via a temporary file so an interrupted write
+// never leaves a truncated document behind.
+func (f *File) write() error {
+	data, err := json.MarshalIndent(document{Snoozes: f.entries}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snoozes: %w", err)
+	}
+
+	dir := filepath.Dir(f.path)
+	if mkErr := os.MkdirAll(dir, 0o700); mkErr != nil {
+		return fmt.Errorf("failed to create snooze directory: %w", mkErr)
+	}
+	tmp, err := os.CreateTemp(dir, ".snoozes-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create snooze file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, writeErr := tmp.Write(data); writeErr != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write snooze file: %w", writeErr)
+	}
+	if closeErr := tmp.Close(); closeErr != nil {
+		return fmt.Errorf("failed to write snooze file: %w", closeErr)
+	}
+	if renameErr := os.Rename(tmp.Name(), f.path); renameErr != nil {
+		return fmt.Errorf("failed to replace snooze file: %w", renameErr)
+	}
+	return nil
+}
+
+func key(project, zone, name string) string {
+	return project + "/" + zone + "/" + name
+}
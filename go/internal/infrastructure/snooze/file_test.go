@@ -0,0 +1,57 @@
+package snooze
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_PutGetDelete(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gcectl", "snoozes.json")
+	until := time.Date(2024, 1, 11, 2, 0, 0, 0, time.UTC)
+	s := &model.Snooze{Until: until, VMName: "vm-1", Project: "proj", Zone: "us-central1-a", Policy: "nightly-stop"}
+
+	f, err := Open(path)
+	require.NoError(t, err)
+	require.NoError(t, f.Put(s))
+
+	reopened, err := Open(path)
+	require.NoError(t, err)
+	got, ok := reopened.Get(&model.VM{Name: "vm-1", Project: "proj", Zone: "us-central1-a"})
+	require.True(t, ok)
+	assert.Equal(t, s, got)
+
+	_, ok = reopened.Get(&model.VM{Name: "vm-1", Project: "proj", Zone: "us-central1-b"})
+	assert.False(t, ok, "snoozes are keyed by project, zone and name")
+
+	require.NoError(t, reopened.Delete(s.VM()))
+	again, err := Open(path)
+	require.NoError(t, err)
+	assert.Empty(t, again.List())
+}
+
+func TestFile_ListOrdersByEndTime(t *testing.T) {
+	f, err := Open(filepath.Join(t.TempDir(), "snoozes.json"))
+	require.NoError(t, err)
+	now := time.Now()
+	require.NoError(t, f.Put(&model.Snooze{Until: now.Add(2 * time.Hour), VMName: "later", Project: "p", Zone: "z"}))
+	require.NoError(t, f.Put(&model.Snooze{Until: now.Add(time.Hour), VMName: "sooner", Project: "p", Zone: "z"}))
+
+	snoozes := f.List()
+	require.Len(t, snoozes, 2)
+	assert.Equal(t, "sooner", snoozes[0].VMName)
+	assert.Equal(t, "later", snoozes[1].VMName)
+}
+
+func TestOpen_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snoozes.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+	_, err := Open(path)
+	assert.ErrorContains(t, err, "failed to parse snooze file")
+}
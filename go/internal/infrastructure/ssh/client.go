@@ -0,0 +1,139 @@
+package ssh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// defaultPort is the port connected to when the config sets none.
+const defaultPort = 22
+
+// ExitConnectionClosed is the status ssh exits with when it cannot connect or
+// the connection is closed, e.g. by the VM shutting down.
+const ExitConnectionClosed = 255
+
+// Target is a VM the OpenSSH client connects to.
+type Target struct {
+	// Host is the address connected to, or the VM name when tunneling through IAP.
+	Host    string
+	Options model.SSHOptions
+	// IAP tunnels the connection to this VM through Identity-Aware Proxy with
+	// gcloud compute start-iap-tunnel instead of connecting to Host directly.
+	IAP *model.VM
+}
+
+// ExitError is returned when the remote command exits with a non-zero status.
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("remote command exited with status %d", e.Code)
+}
+
+// Exec runs command on t with ssh, connected to the standard streams of the
+// current process. The remote shell runs the arguments joined with spaces, as
+// with ssh itself.
+//
+// Parameters:
+//   - ctx: Context for cancellation; cancelling it kills ssh
+//   - t: The VM to run the command on
+//   - command: The command and its arguments
+//   - tty: Whether to allocate a terminal, for interactive commands
+//
+// Returns:
+//   - error: *ExitError with the remote exit status, or an error if ssh cannot be run
+func Exec(ctx context.Context, t Target, command []string, tty bool) error {
+	return run(ctx, "ssh", execArgs(t, command, tty))
+}
+
+// Output runs command on t with ssh in batch mode, without a terminal or any
+// prompt, and returns its combined stdout and stderr. It is meant for commands
+// gcectl runs on the user's behalf, e.g. to shut a VM down from inside.
+//
+// Parameters:
+//   - ctx: Context for cancellation; cancelling it kills ssh
+//   - t: The VM to run the command on
+//   - command: The command line the remote shell runs
+//
+// Returns:
+//   - []byte: The output of the command
+//   - error: *ExitError with the remote exit status, or an error if ssh cannot be run
+func Output(ctx context.Context, t Target, command string) ([]byte, error) {
+	out, err := exec.CommandContext(ctx, "ssh", outputArgs(t, command)...).CombinedOutput()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+		return out, &ExitError{Code: exitErr.ExitCode()}
+	}
+	if err != nil {
+		return out, fmt.Errorf("failed to run ssh: %w", err)
+	}
+	return out, nil
+}
+
+// outputArgs builds the ssh arguments of Output. Batch mode fails instead of
+// asking for a password or to accept a host key nobody is there to answer.
+func outputArgs(t Target, command string) []string {
+	args := append(t.options("-p"), "-o", "BatchMode=yes", "-o", "ConnectTimeout=15")
+	if t.Options.User != "" {
+		args = append(args, "-l", t.Options.User)
+	}
+	return append(args, "--", t.Host, command)
+}
+
+// execArgs builds the ssh arguments of Exec.
+func execArgs(t Target, command []string, tty bool) []string {
+	args := t.options("-p")
+	if t.Options.User != "" {
+		args = append(args, "-l", t.Options.User)
+	}
+	if tty {
+		args = append(args, "-t")
+	}
+	args = append(args, "--", t.Host)
+	return append(args, command...)
+}
+
+// options returns the arguments shared by ssh and scp; portFlag is -p for ssh and -P for scp.
+func (t Target) options(portFlag string) []string {
+	var args []string
+	if t.Options.Port != 0 {
+		args = append(args, portFlag, strconv.Itoa(t.Options.Port))
+	}
+	if t.Options.IdentityFile != "" {
+		args = append(args, "-i", t.Options.IdentityFile)
+	}
+	if t.IAP != nil {
+		port := t.Options.Port
+		if port == 0 {
+			port = defaultPort
+		}
+		proxy := fmt.Sprintf("gcloud compute start-iap-tunnel %s %d --listen-on-stdin --project=%s --zone=%s --verbosity=warning",
+			t.IAP.Name, port, t.IAP.Project, t.IAP.Zone)
+		args = append(args, "-o", "ProxyCommand="+proxy)
+	}
+	return args
+}
+
+// run runs name with args connected to the standard streams of the current process.
+func run(ctx context.Context, name string, args []string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+		return &ExitError{Code: exitErr.ExitCode()}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to run %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package ssh
+
+import (
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  Target
+		command []string
+		tty     bool
+		want    []string
+	}{
+		{
+			name:    "direct",
+			target:  Target{Host: "34.1.2.3"},
+			command: []string{"make", "test"},
+			want:    []string{"--", "34.1.2.3", "make", "test"},
+		},
+		{
+			name:    "config overrides and tty",
+			target:  Target{Host: "34.1.2.3", Options: model.SSHOptions{User: "alice", IdentityFile: "~/.ssh/id", Port: 2222}},
+			command: []string{"top"},
+			tty:     true,
+			want:    []string{"-p", "2222", "-i", "~/.ssh/id", "-l", "alice", "-t", "--", "34.1.2.3", "top"},
+		},
+		{
+			name:    "IAP tunnel",
+			target:  Target{Host: "build-box", IAP: &model.VM{Name: "build-box", Project: "p", Zone: "us-central1-a"}},
+			command: []string{"uptime"},
+			want: []string{
+				"-o", "ProxyCommand=gcloud compute start-iap-tunnel build-box 22 --listen-on-stdin --project=p --zone=us-central1-a --verbosity=warning",
+				"--", "build-box", "uptime",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, execArgs(tt.target, tt.command, tt.tty))
+		})
+	}
+}
+
+func TestOutputArgs(t *testing.T) {
+	target := Target{Host: "34.1.2.3", Options: model.SSHOptions{User: "alice", Port: 2222}}
+	assert.Equal(t,
+		[]string{"-p", "2222", "-o", "BatchMode=yes", "-o", "ConnectTimeout=15", "-l", "alice", "--", "34.1.2.3", "sudo shutdown -h now"},
+		outputArgs(target, "sudo shutdown -h now"))
+}
+
+func TestExitError(t *testing.T) {
+	assert.Equal(t, "remote command exited with status 2", (&ExitError{Code: 2}).Error())
+}
@@ -0,0 +1,75 @@
+package ssh
+
+import (
+	"context"
+	"strings"
+)
+
+// SplitRemotePath splits a cp operand of the form vm:path, where vm may be
+// project-qualified, into the VM name and the remote path. ok is false for local
+// paths; those containing a colon can be written as ./file:name.
+func SplitRemotePath(arg string) (vm, path string, ok bool) {
+	i := strings.Index(arg, ":")
+	if i <= 0 || strings.HasPrefix(arg, ".") || strings.HasPrefix(arg, "/") {
+		return "", "", false
+	}
+	return arg[:i], arg[i+1:], true
+}
+
+// Upload copies the local sources to remotePath on t with scp.
+//
+// Parameters:
+//   - ctx: Context for cancellation; cancelling it kills scp
+//   - t: The VM to copy to
+//   - sources: Local files, or directories with recursive
+//   - remotePath: Destination on the VM; empty means the home directory
+//   - recursive: Whether to copy directories recursively
+//
+// Returns:
+//   - error: An error if scp fails
+func Upload(ctx context.Context, t Target, sources []string, remotePath string, recursive bool) error {
+	return run(ctx, "scp", copyArgs(t, sources, t.remote(remotePath), recursive))
+}
+
+// Download copies the remoteSources on t to the local localPath with scp.
+//
+// Parameters:
+//   - ctx: Context for cancellation; cancelling it kills scp
+//   - t: The VM to copy from
+//   - remoteSources: Files, or directories with recursive, on the VM
+//   - localPath: Local destination
+//   - recursive: Whether to copy directories recursively
+//
+// Returns:
+//   - error: An error if scp fails
+func Download(ctx context.Context, t Target, remoteSources []string, localPath string, recursive bool) error {
+	sources := make([]string, len(remoteSources))
+	for i, path := range remoteSources {
+		sources[i] = t.remote(path)
+	}
+	return run(ctx, "scp", copyArgs(t, sources, localPath, recursive))
+}
+
+// copyArgs builds the scp arguments copying sources to dest.
+func copyArgs(t Target, sources []string, dest string, recursive bool) []string {
+	args := t.options("-P")
+	if recursive {
+		args = append(args, "-r")
+	}
+	args = append(args, "--")
+	args = append(args, sources...)
+	return append(args, dest)
+}
+
+// remote returns path on t as an scp operand, [user@]host:path.
+func (t Target) remote(path string) string {
+	host := t.Host
+	if strings.Contains(host, ":") {
+		// IPv6 addresses are bracketed so that scp does not split them at a colon.
+		host = "[" + host + "]"
+	}
+	if t.Options.User != "" {
+		host = t.Options.User + "@" + host
+	}
+	return host + ":" + path
+}
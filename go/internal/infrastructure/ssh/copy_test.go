@@ -0,0 +1,50 @@
+package ssh
+
+import (
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitRemotePath(t *testing.T) {
+	tests := []struct {
+		arg      string
+		wantVM   string
+		wantPath string
+		wantOK   bool
+	}{
+		{arg: "vm1:/tmp/", wantVM: "vm1", wantPath: "/tmp/", wantOK: true},
+		{arg: "vm1:", wantVM: "vm1", wantPath: "", wantOK: true},
+		{arg: "proj-a/vm1:data", wantVM: "proj-a/vm1", wantPath: "data", wantOK: true},
+		{arg: "local.txt"},
+		{arg: "./file:name"},
+		{arg: "/abs/file:name"},
+		{arg: ":path"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			vm, path, ok := SplitRemotePath(tt.arg)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantVM, vm)
+			assert.Equal(t, tt.wantPath, path)
+		})
+	}
+}
+
+func TestCopyArgs(t *testing.T) {
+	target := Target{Host: "34.1.2.3", Options: model.SSHOptions{User: "alice", Port: 2222}}
+	assert.Equal(t,
+		[]string{"-P", "2222", "-r", "--", "dir", "alice@34.1.2.3:/tmp/"},
+		copyArgs(target, []string{"dir"}, target.remote("/tmp/"), true))
+
+	iap := Target{Host: "vm1", IAP: &model.VM{Name: "vm1", Project: "p", Zone: "z"}}
+	assert.Equal(t,
+		[]string{
+			"-o", "ProxyCommand=gcloud compute start-iap-tunnel vm1 22 --listen-on-stdin --project=p --zone=z --verbosity=warning",
+			"--", "vm1:a.log", "vm1:b.log", ".",
+		},
+		copyArgs(iap, []string{iap.remote("a.log"), iap.remote("b.log")}, ".", false))
+
+	assert.Equal(t, "[2001:db8::1]:/tmp", Target{Host: "2001:db8::1"}.remote("/tmp"))
+}
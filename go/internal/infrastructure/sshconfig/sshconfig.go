@@ -0,0 +1,184 @@
+package sshconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+const (
+	// BeginMarker and EndMarker delimit the block managed by gcectl inside an ssh config file.
+	// Everything between the markers is replaced on every merge; everything outside is preserved.
+	BeginMarker = "# BEGIN gcectl managed hosts"
+	EndMarker   = "# END gcectl managed hosts"
+)
+
+// DefaultPath returns the ssh config file of the current user, ~/.ssh/config.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user home directory: %w", err)
+	}
+	return filepath.Join(home, ".ssh", "config"), nil
+}
+
+// Render formats hosts as OpenSSH client config Host entries.
+//
+// Parameters:
+//   - hosts: Host entries to render
+//
+// Returns:
+//   - string: The rendered entries, separated by blank lines
+func Render(hosts []model.SSHHost) string {
+	var b strings.Builder
+	for i, h := range hosts {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "Host %s\n", h.Alias)
+		fmt.Fprintf(&b, "    HostName %s\n", h.HostName)
+		if h.User != "" {
+			fmt.Fprintf(&b, "    User %s\n", h.User)
+		}
+		if h.Port != 0 {
+			fmt.Fprintf(&b, "    Port %d\n", h.Port)
+		}
+		if h.IdentityFile != "" {
+			fmt.Fprintf(&b, "    IdentityFile %s\n", h.IdentityFile)
+		}
+	}
+	return b.String()
+}
+
+// Merge replaces the gcectl managed block in existing with rendered.
+// If existing has no managed block, the block is appended at the end.
+//
+// Parameters:
+//   - existing: Current content of the ssh config file
+//   - rendered: Host entries produced by Render
+//
+// Returns:
+//   - string: The merged ssh config content
+func Merge(existing, rendered string) string {
+	block := BeginMarker + "\n" + rendered + EndMarker + "\n"
+
+	start := strings.Index(existing, BeginMarker)
+	end := strings.Index(existing, EndMarker)
+	if start == -1 || end == -1 || end < start {
+		if existing == "" {
+			return block
+		}
+		if !strings.HasSuffix(existing, "\n") {
+			existing += "\n"
+		}
+		return existing + "\n" + block
+	}
+
+	rest := existing[end+len(EndMarker):]
+	rest = strings.TrimPrefix(rest, "\n")
+	return existing[:start] + block + rest
+}
+
+// Upsert replaces the entry of host.Alias in the gcectl managed block of existing
+// with host, or adds host to the block, keeping the other managed entries.
+//
+// Parameters:
+//   - existing: Current content of the ssh config file
+//   - host: Host entry to write
+//
+// Returns:
+//   - string: The updated ssh config content
+func Upsert(existing string, host model.SSHHost) string {
+	var entries []string
+	start := strings.Index(existing, BeginMarker)
+	end := strings.Index(existing, EndMarker)
+	if start != -1 && end > start {
+		entries = splitEntries(existing[start+len(BeginMarker) : end])
+	}
+
+	rendered := Render([]model.SSHHost{host})
+	replaced := false
+	for i, entry := range entries {
+		if entryAlias(entry) == host.Alias {
+			entries[i] = rendered
+			replaced = true
+		}
+	}
+	if !replaced {
+		entries = append(entries, rendered)
+	}
+	return Merge(existing, strings.Join(entries, "\n"))
+}
+
+// splitEntries splits the content of a managed block into its Host entries.
+func splitEntries(block string) []string {
+	var entries []string
+	var b strings.Builder
+	for _, line := range strings.Split(block, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "Host ") && b.Len() > 0 {
+			entries = append(entries, b.String())
+			b.Reset()
+		}
+		b.WriteString(line + "\n")
+	}
+	if b.Len() > 0 {
+		entries = append(entries, b.String())
+	}
+	return entries
+}
+
+// entryAlias returns the alias of a Host entry rendered by Render.
+func entryAlias(entry string) string {
+	line, _, _ := strings.Cut(entry, "\n")
+	return strings.TrimSpace(strings.TrimPrefix(line, "Host "))
+}
+
+// MergeFile merges hosts into the ssh config file at path, creating it if needed.
+//
+// Parameters:
+//   - path: Path to the ssh config file (e.g., ~/.ssh/config)
+//   - hosts: Host entries to write into the managed block
+//
+// Returns:
+//   - error: Error if the file cannot be read or written
+func MergeFile(path string, hosts []model.SSHHost) error {
+	return updateFile(path, func(existing string) string {
+		return Merge(existing, Render(hosts))
+	})
+}
+
+// UpsertFile writes host into the managed block of the ssh config file at path
+// like Upsert, creating the file if needed.
+//
+// Parameters:
+//   - path: Path to the ssh config file (e.g., ~/.ssh/config)
+//   - host: Host entry to write
+//
+// Returns:
+//   - error: Error if the file cannot be read or written
+func UpsertFile(path string, host model.SSHHost) error {
+	return updateFile(path, func(existing string) string {
+		return Upsert(existing, host)
+	})
+}
+
+// updateFile rewrites the ssh config file at path with update applied to its content.
+func updateFile(path string, update func(existing string) string) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read ssh config: %w", err)
+	}
+	if mkErr := os.MkdirAll(filepath.Dir(path), 0o700); mkErr != nil {
+		return fmt.Errorf("failed to create ssh config directory: %w", mkErr)
+	}
+	if writeErr := os.WriteFile(path, []byte(update(string(existing))), 0o600); writeErr != nil {
+		return fmt.Errorf("failed to write ssh config: %w", writeErr)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package sshconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	hosts := []model.SSHHost{
+		{Alias: "vm1", HostName: "34.1.2.3", SSHOptions: model.SSHOptions{User: "alice", Port: 2222, IdentityFile: "~/.ssh/id"}},
+		{Alias: "vm2", HostName: "34.1.2.4"},
+	}
+
+	want := `Host vm1
+    HostName 34.1.2.3
+    User alice
+    Port 2222
+    IdentityFile ~/.ssh/id
+
+Host vm2
+    HostName 34.1.2.4
+`
+	assert.Equal(t, want, Render(hosts))
+}
+
+func TestMerge(t *testing.T) {
+	rendered := "Host vm1\n    HostName 34.1.2.3\n"
+
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+	}{
+		{
+			name:     "empty file",
+			existing: "",
+			want:     BeginMarker + "\n" + rendered + EndMarker + "\n",
+		},
+		{
+			name:     "append to existing config",
+			existing: "Host github.com\n    User git",
+			want:     "Host github.com\n    User git\n\n" + BeginMarker + "\n" + rendered + EndMarker + "\n",
+		},
+		{
+			name:     "replace existing managed block",
+			existing: "Host a\n\n" + BeginMarker + "\nHost old\n    HostName 1.1.1.1\n" + EndMarker + "\nHost b\n",
+			want:     "Host a\n\n" + BeginMarker + "\n" + rendered + EndMarker + "\nHost b\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Merge(tt.existing, rendered))
+		})
+	}
+}
+
+func TestUpsert(t *testing.T) {
+	host := model.SSHHost{Alias: "vm1", HostName: "34.9.9.9", SSHOptions: model.SSHOptions{User: "alice"}}
+	rendered := "Host vm1\n    HostName 34.9.9.9\n    User alice\n"
+
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+	}{
+		{
+			name:     "empty file",
+			existing: "",
+			want:     BeginMarker + "\n" + rendered + EndMarker + "\n",
+		},
+		{
+			name:     "replace the entry and keep the others",
+			existing: "Host a\n\n" + BeginMarker + "\nHost vm0\n    HostName 1.1.1.0\n\nHost vm1\n    HostName 1.1.1.1\n\nHost vm2\n    HostName 1.1.1.2\n" + EndMarker + "\n",
+			want:     "Host a\n\n" + BeginMarker + "\nHost vm0\n    HostName 1.1.1.0\n\n" + rendered + "\nHost vm2\n    HostName 1.1.1.2\n" + EndMarker + "\n",
+		},
+		{
+			name:     "add the entry to the block",
+			existing: BeginMarker + "\nHost vm10\n    HostName 1.1.1.10\n" + EndMarker + "\n",
+			want:     BeginMarker + "\nHost vm10\n    HostName 1.1.1.10\n\n" + rendered + EndMarker + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Upsert(tt.existing, host))
+		})
+	}
+}
+
+func TestMergeFileCreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".ssh", "config")
+
+	require.NoError(t, MergeFile(path, []model.SSHHost{{Alias: "vm1", HostName: "34.1.2.3"}}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Host vm1")
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
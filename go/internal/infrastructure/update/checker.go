@@ -0,0 +1,177 @@
+// Package update checks GitHub for gcectl releases newer than the running one.
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/infrastructure/xdg"
+)
+
+// ReleasesURL lists the releases of gcectl, newest first.
+const ReleasesURL = "https://api.github.com/repos/haru-256/gcectl/releases?per_page=20"
+
+// TagPrefix starts the tags of the releases of the Go gcectl, e.g. go-v1.4.0.
+// Releases tagged otherwise belong to other parts of the repository.
+const TagPrefix = "go-"
+
+// DefaultInterval is how long the releases looked up are reused before GitHub is asked again.
+const DefaultInterval = 24 * time.Hour
+
+// Release is a published release of gcectl.
+type Release struct {
+	// Tag is the release tag, e.g. go-v1.4.0.
+	Tag string `json:"tag"`
+	// Version is the tag without TagPrefix, e.g. v1.4.0.
+	Version    string `json:"version"`
+	URL        string `json:"url"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+// state is the on-disk layout of the update check file.
+type state struct {
+	CheckedAt time.Time `json:"checkedAt"`
+	Releases  []Release `json:"releases"`
+}
+
+// githubRelease is the part of a GitHub release the check reads.
+type githubRelease struct {
+	TagName    string `json:"tag_name"`
+	HTMLURL    string `json:"html_url"`
+	Prerelease bool   `json:"prerelease"`
+	Draft      bool   `json:"draft"`
+}
+
+// Checker finds a release newer than the running version, asking GitHub at
+// most once per Interval and remembering the answer in a state file.
+type Checker struct {
+	Client *http.Client
+	Now    func() time.Time
+	// URL lists the releases in the format of the GitHub releases API.
+	URL       string
+	StatePath string
+	Interval  time.Duration
+}
+
+// DefaultStatePath returns the default update check file location,
+// $XDG_CACHE_HOME/gcectl/update-check.json (~/.cache/gcectl/update-check.json).
+func DefaultStatePath() (string, error) {
+	return xdg.CacheFile("update-check.json")
+}
+
+// NewChecker returns a Checker asking GitHub once a day and keeping its state in statePath.
+func NewChecker(statePath string) *Checker {
+	return &Checker{
+		Client:    &http.Client{Timeout: 3 * time.Second},
+		Now:       time.Now,
+		URL:       ReleasesURL,
+		StatePath: statePath,
+		Interval:  DefaultInterval,
+	}
+}
+
+// Check returns the newest release newer than current, or nil when there is
+// none or current is not a released version, e.g. "dev". Pre-releases are
+// only offered to users of a pre-release.
+//
+// Parameters:
+//   - ctx: Bounds the request to GitHub
+//   - current: The version gcectl was built with
+//
+// Returns:
+//   - *Release: The newer release, or nil
+//   - error: An error if the releases could not be fetched
+func (c *Checker) Check(ctx context.Context, current string) (*Release, error) {
+	running, err := ParseVersion(current)
+	if err != nil {
+		return nil, nil
+	}
+	releases, err := c.releases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var newest *Release
+	var newestVersion Version
+	for i, release := range releases {
+		if release.Prerelease && !running.IsPrerelease() {
+			continue
+		}
+		v, parseErr := ParseVersion(release.Version)
+		if parseErr != nil {
+			continue
+		}
+		if v.Compare(running) > 0 && (newest == nil || v.Compare(newestVersion) > 0) {
+			newest, newestVersion = &releases[i], v
+		}
+	}
+	return newest, nil
+}
+
+// releases returns the releases remembered in the state file when they were
+// fetched within the interval, otherwise fetches and remembers them.
+func (c *Checker) releases(ctx context.Context) ([]Release, error) {
+	var st state
+	if data, err := os.ReadFile(c.StatePath); err == nil && json.Unmarshal(data, &st) == nil {
+		if age := c.Now().Sub(st.CheckedAt); age >= 0 && age < c.Interval {
+			return st.Releases, nil
+		}
+	}
+
+	releases, fetchErr := c.fetch(ctx)
+	// Remember failures too, so an offline machine does not retry on every command.
+	st = state{CheckedAt: c.Now(), Releases: releases}
+	if saveErr := c.save(st); saveErr != nil && fetchErr == nil {
+		return releases, saveErr
+	}
+	return releases, fetchErr
+}
+
+func (c *Checker) fetch(ctx context.Context) ([]Release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.URL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	res, err := c.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch releases: %w", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch releases: %s", res.Status)
+	}
+
+	var found []githubRelease
+	if decodeErr := json.NewDecoder(res.Body).Decode(&found); decodeErr != nil {
+		return nil, fmt.Errorf("failed to parse releases: %w", decodeErr)
+	}
+	releases := make([]Release, 0, len(found))
+	for _, r := range found {
+		version, ok := strings.CutPrefix(r.TagName, TagPrefix)
+		if ok && !r.Draft {
+			releases = append(releases, Release{Tag: r.TagName, Version: version, URL: r.HTMLURL, Prerelease: r.Prerelease})
+		}
+	}
+	return releases, nil
+}
+
+func (c *Checker) save(st state) error {
+	data, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	if mkdirErr := os.MkdirAll(filepath.Dir(c.StatePath), 0o755); mkdirErr != nil {
+		return fmt.Errorf("failed to create update check directory: %w", mkdirErr)
+	}
+	if writeErr := os.WriteFile(c.StatePath, data, 0o600); writeErr != nil {
+		return fmt.Errorf("failed to write update check file: %w", writeErr)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// releasesServer serves the GitHub releases API with stable releases, a newer
+// pre-release, a draft and a release of another part of the repository, and
+// counts the requests.
+func releasesServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = fmt.Fprint(w, `[
+			{"tag_name": "go-v2.0.0", "html_url": "https://example.com/v2.0.0", "draft": true},
+			{"tag_name": "rust-v9.0.0", "html_url": "https://example.com/rust-v9.0.0"},
+			{"tag_name": "go-v1.5.0-rc.2", "html_url": "https://example.com/v1.5.0-rc.2", "prerelease": true},
+			{"tag_name": "go-v1.4.0", "html_url": "https://example.com/v1.4.0"},
+			{"tag_name": "go-v1.3.0", "html_url": "https://example.com/v1.3.0"}
+		]`)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func newTestChecker(t *testing.T, url string, now *time.Time) *Checker {
+	t.Helper()
+	c := NewChecker(filepath.Join(t.TempDir(), "update-check.json"))
+	c.URL = url
+	c.Now = func() time.Time { return *now }
+	return c
+}
+
+func TestCheckerCheck(t *testing.T) {
+	server, _ := releasesServer(t)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		current string
+		want    string
+	}{
+		{name: "older release gets the newest release", current: "1.3.0", want: "v1.4.0"},
+		{name: "latest release is up to date", current: "v1.4.0", want: ""},
+		{name: "pre-release gets a newer pre-release", current: "1.5.0-rc.1", want: "v1.5.0-rc.2"},
+		{name: "pre-release gets the newest release or pre-release", current: "1.4.0-beta.1", want: "v1.5.0-rc.2"},
+		{name: "newer than every release", current: "1.6.0", want: ""},
+		{name: "development build is never checked", current: "dev", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newTestChecker(t, server.URL, &now).Check(context.Background(), tt.current)
+			require.NoError(t, err)
+			if tt.want == "" {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, tt.want, got.Version)
+			assert.Equal(t, TagPrefix+tt.want, got.Tag)
+			assert.Equal(t, "https://example.com/"+tt.want, got.URL)
+		})
+	}
+}
+
+func TestCheckerAsksOncePerInterval(t *testing.T) {
+	server, requests := releasesServer(t)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	c := newTestChecker(t, server.URL, &now)
+	ctx := context.Background()
+
+	_, err := c.Check(ctx, "1.3.0")
+	require.NoError(t, err)
+	now = now.Add(23 * time.Hour)
+	got, err := c.Check(ctx, "1.3.0")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "v1.4.0", got.Version, "the remembered releases are used")
+	assert.Equal(t, int32(1), requests.Load())
+
+	now = now.Add(2 * time.Hour)
+	_, err = c.Check(ctx, "1.3.0")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestCheckerRemembersFailures(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	c := newTestChecker(t, server.URL, &now)
+
+	_, err := c.Check(context.Background(), "1.3.0")
+	require.Error(t, err)
+	got, err := c.Check(context.Background(), "1.3.0")
+	require.NoError(t, err, "a failed check is not retried within the interval")
+	assert.Nil(t, got)
+	assert.Equal(t, int32(1), requests.Load())
+}
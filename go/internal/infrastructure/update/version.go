@@ -0,0 +1,121 @@
+package update
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version such as v1.4.0 or 1.5.0-rc.1.
+type Version struct {
+	// Prerelease holds the dot-separated identifiers after "-", e.g. ["rc", "1"].
+	// Empty for a release.
+	Prerelease []string
+	Major      int
+	Minor      int
+	Patch      int
+}
+
+// ParseVersion parses a version with an optional "v" prefix. Missing minor and
+// patch numbers are zero, and build metadata after "+" is ignored, as it is
+// in comparisons.
+//
+// Parameters:
+//   - s: The version, e.g. a release tag or the version gcectl was built with
+//
+// Returns:
+//   - Version: The parsed version
+//   - error: An error if s is not a semantic version, e.g. "dev"
+func ParseVersion(s string) (Version, error) {
+	raw := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	raw, _, _ = strings.Cut(raw, "+")
+	core, prerelease, hasPrerelease := strings.Cut(raw, "-")
+
+	var v Version
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return Version{}, fmt.Errorf("invalid version %q", s)
+	}
+	numbers := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid version %q", s)
+		}
+		*numbers[i] = n
+	}
+	if hasPrerelease {
+		v.Prerelease = strings.Split(prerelease, ".")
+		for _, id := range v.Prerelease {
+			if id == "" {
+				return Version{}, fmt.Errorf("invalid version %q: empty pre-release identifier", s)
+			}
+		}
+	}
+	return v, nil
+}
+
+// IsPrerelease reports whether v is a pre-release such as 1.5.0-rc.1.
+func (v Version) IsPrerelease() bool {
+	return len(v.Prerelease) > 0
+}
+
+// String returns v in the form of the release tags, e.g. v1.5.0-rc.1.
+func (v Version) String() string {
+	s := fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.IsPrerelease() {
+		s += "-" + strings.Join(v.Prerelease, ".")
+	}
+	return s
+}
+
+// Compare returns -1, 0 or +1 as v is older than, the same as or newer than w,
+// by semantic version precedence: a pre-release is older than its release,
+// and pre-release identifiers compare numerically when both are numbers,
+// otherwise lexically, with a longer list newer when one is a prefix of the other.
+func (v Version) Compare(w Version) int {
+	for _, c := range [][2]int{{v.Major, w.Major}, {v.Minor, w.Minor}, {v.Patch, w.Patch}} {
+		if c[0] != c[1] {
+			return compareInts(c[0], c[1])
+		}
+	}
+	switch {
+	case !v.IsPrerelease() && !w.IsPrerelease():
+		return 0
+	case !v.IsPrerelease():
+		return 1
+	case !w.IsPrerelease():
+		return -1
+	}
+	for i := 0; i < len(v.Prerelease) && i < len(w.Prerelease); i++ {
+		if c := comparePrereleaseID(v.Prerelease[i], w.Prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(v.Prerelease), len(w.Prerelease))
+}
+
+// comparePrereleaseID compares two pre-release identifiers; numeric ones are older than others.
+func comparePrereleaseID(a, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return compareInts(na, nb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
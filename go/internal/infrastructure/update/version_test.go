@@ -0,0 +1,69 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "release tag", input: "v1.4.2", want: "v1.4.2"},
+		{name: "without v prefix", input: "1.4.2", want: "v1.4.2"},
+		{name: "missing patch", input: "v1.4", want: "v1.4.0"},
+		{name: "pre-release", input: "v1.5.0-rc.1", want: "v1.5.0-rc.1"},
+		{name: "build metadata is dropped", input: "1.5.0-beta+20250101", want: "v1.5.0-beta"},
+		{name: "development build", input: "dev", wantErr: true},
+		{name: "too many numbers", input: "1.2.3.4", wantErr: true},
+		{name: "empty pre-release identifier", input: "1.2.3-rc..1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseVersion(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	// Each version is older than the next, as in the semantic versioning spec.
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+	}
+	for i := 0; i+1 < len(ordered); i++ {
+		older, err := ParseVersion(ordered[i])
+		require.NoError(t, err)
+		newer, err := ParseVersion(ordered[i+1])
+		require.NoError(t, err)
+		assert.Equal(t, -1, older.Compare(newer), "%s < %s", ordered[i], ordered[i+1])
+		assert.Equal(t, 1, newer.Compare(older), "%s > %s", ordered[i+1], ordered[i])
+	}
+
+	a, err := ParseVersion("v1.2.3+linux")
+	require.NoError(t, err)
+	b, err := ParseVersion("1.2.3")
+	require.NoError(t, err)
+	assert.Equal(t, 0, a.Compare(b), "build metadata does not count")
+}
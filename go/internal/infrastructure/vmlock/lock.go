@@ -0,0 +1,258 @@
+// Package vmlock keeps two gcectl processes on a machine, such as the daemon
+// and a user, from changing the same VM at once, with a lock file per VM.
+package vmlock
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/xdg"
+)
+
+// Holder describes the gcectl process holding a lock.
+type Holder struct {
+	Since   time.Time `json:"since"`
+	Command string    `json:"command"`
+	// User is user@host of the process.
+	User string `json:"user"`
+	Host string `json:"host"`
+	PID  int    `json:"pid"`
+}
+
+// LockedError is returned when another process holds the lock of a VM.
+type LockedError struct {
+	VM     *model.VM
+	Path   string
+	Holder Holder
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("%s is being changed by another gcectl: %q (pid %d, %s) since %s; retry when it finishes, or remove %s if that process is gone",
+		e.VM.QualifiedName(), e.Holder.Command, e.Holder.PID, e.Holder.User, e.Holder.Since.Local().Format("15:04:05"), e.Path)
+}
+
+// Locker takes the locks of VMs for the running process. A VM it already
+// holds can be locked again, and is unlocked when every lock is released.
+type Locker struct {
+	held map[string]int
+	// takingOver is called before a stale lock is taken over.
+	takingOver func()
+	dir        string
+	holder     Holder
+	mu         sync.Mutex
+}
+
+// DefaultDir returns the default directory of the lock files,
+// $XDG_STATE_HOME/gcectl/locks (~/.local/state/gcectl/locks).
+func DefaultDir() (string, error) {
+	return xdg.StateFile("locks")
+}
+
+// NewLocker returns a Locker keeping its lock files in dir, which names the
+// running process with command and user (user@host) to those it blocks.
+func NewLocker(dir, command, user string) *Locker {
+	host, _ := os.Hostname()
+	return &Locker{
+		held:       make(map[string]int),
+		takingOver: func() {},
+		dir:        dir,
+		holder: Holder{
+			Command: command,
+			User:    user,
+			Host:    host,
+			PID:     os.Getpid(),
+		},
+	}
+}
+
+// Lock takes the lock of vm. A lock left by a process of this host that is no
+// longer running is taken over.
+//
+// Parameters:
+//   - vm: The VM to lock, identified by its project, zone and name
+//
+// Returns:
+//   - func(): Releases the lock
+//   - error: A *LockedError if another process holds the lock, or an error if
+//     the lock file could not be written
+func (l *Locker) Lock(vm *model.VM) (func(), error) {
+	path := filepath.Join(l.dir, lockName(vm))
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.held[path] == 0 {
+		if err := l.acquire(vm, path); err != nil {
+			return nil, err
+		}
+	}
+	l.held[path]++
+
+	var once sync.Once
+	return func() { once.Do(func() { l.release(path) }) }, nil
+}
+
+func (l *Locker) acquire(vm *model.VM, path string) error {
+	if err := os.MkdirAll(l.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create lock directory: %w", err)
+	}
+	holder := l.holder
+	holder.Since = time.Now()
+	data, err := json.Marshal(holder)
+	if err != nil {
+		return err
+	}
+
+	// The lock file is linked into place once written, so it is never seen
+	// half-written.
+	tmp, err := os.CreateTemp(l.dir, ".lock-*")
+	if err != nil {
+		return fmt.Errorf("failed to lock %s: %w", vm.QualifiedName(), err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, writeErr := tmp.Write(data); writeErr != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to lock %s: %w", vm.QualifiedName(), writeErr)
+	}
+	if closeErr := tmp.Close(); closeErr != nil {
+		return fmt.Errorf("failed to lock %s: %w", vm.QualifiedName(), closeErr)
+	}
+
+	for range takeOverAttempts {
+		linkErr := os.Link(tmp.Name(), path)
+		if linkErr == nil {
+			return nil
+		}
+		if !errors.Is(linkErr, fs.ErrExist) {
+			return fmt.Errorf("failed to lock %s: %w", vm.QualifiedName(), linkErr)
+		}
+		seen, readErr := os.ReadFile(path)
+		if errors.Is(readErr, fs.ErrNotExist) {
+			continue
+		}
+		if readErr != nil {
+			return fmt.Errorf("failed to lock %s: %w", vm.QualifiedName(), readErr)
+		}
+		var other Holder
+		if json.Unmarshal(seen, &other) == nil && !l.stale(other) {
+			return &LockedError{VM: vm, Path: path, Holder: other}
+		}
+		// The lock of a process that is gone, or a broken lock file, is taken over.
+		l.takingOver()
+		took, takeErr := takeOver(path, seen, tmp.Name())
+		if takeErr != nil {
+			return fmt.Errorf("failed to take over stale lock %s: %w", path, takeErr)
+		}
+		if took {
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to lock %s: %s keeps changing", vm.QualifiedName(), path)
+}
+
+const (
+	// takeOverAttempts bounds how often acquire retries while other processes
+	// take over or release the lock.
+	takeOverAttempts = 10
+	// takeOverWait is how long acquire waits for another process taking over the lock.
+	takeOverWait = 10 * time.Millisecond
+	// takeOverTimeout is the age of a takeover marker left by a process that
+	// died while taking over a lock, after which it is removed.
+	takeOverTimeout = 10 * time.Second
+)
+
+// takeOver replaces the lock file at path, which held seen, with the file lock.
+// Processes taking over the same stale lock at once are serialized with a
+// marker file named after seen, and the lock is only replaced when it still
+// holds seen, so that a lock just taken over is not replaced again.
+//
+// Returns:
+//   - bool: Whether the lock was taken over; if not, the lock changed meanwhile
+//     or another process is taking it over
+//   - error: Error if the lock file could not be replaced
+func takeOver(path string, seen []byte, lock string) (bool, error) {
+	sum := sha256.Sum256(seen)
+	marker := fmt.Sprintf("%s.takeover-%x", path, sum[:8])
+	f, err := os.OpenFile(marker, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if errors.Is(err, fs.ErrExist) {
+		if info, statErr := os.Stat(marker); statErr == nil && time.Since(info.ModTime()) > takeOverTimeout {
+			_ = os.Remove(marker)
+		}
+		time.Sleep(takeOverWait)
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	_ = f.Close()
+	defer func() { _ = os.Remove(marker) }()
+
+	current, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && !bytes.Equal(current, seen)) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, os.Rename(lock, path)
+}
+
+func (l *Locker) release(path string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.held[path]--
+	if l.held[path] > 0 {
+		return
+	}
+	delete(l.held, path)
+	_ = os.Remove(path)
+}
+
+// stale reports whether h is a process of this host that is no longer running.
+// Processes of other hosts sharing the directory cannot be checked.
+func (l *Locker) stale(h Holder) bool {
+	return h.Host == l.holder.Host && h.PID != l.holder.PID && !processAlive(h.PID)
+}
+
+func readHolder(path string) (Holder, error) {
+	var h Holder
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return h, err
+	}
+	err = json.Unmarshal(data, &h)
+	return h, err
+}
+
+// processAlive reports whether the process pid runs. Where signal 0 cannot be
+// sent, as on Windows, a process that can be found is taken to run.
+func processAlive(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return !errors.Is(p.Signal(syscall.Signal(0)), os.ErrProcessDone)
+}
+
+// lockName returns the lock file name of vm, e.g. my-project_us-central1-a_dev.lock.
+func lockName(vm *model.VM) string {
+	clean := func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if r == '-' || r == '.' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+				return r
+			}
+			return '-'
+		}, s)
+	}
+	return clean(vm.Project) + "_" + clean(vm.Zone) + "_" + clean(vm.Name) + ".lock"
+}
@@ -0,0 +1,143 @@
+package vmlock
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeHolder(t *testing.T, path string, h Holder) {
+	t.Helper()
+	data, err := json.Marshal(h)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+// deadPID returns the PID of a process that has exited.
+func deadPID(t *testing.T) int {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	require.NoError(t, cmd.Run())
+	return cmd.Process.Pid
+}
+
+func TestLocker_Lock(t *testing.T) {
+	dir := t.TempDir()
+	vm := &model.VM{Project: "proj", Zone: "us-central1-a", Name: "dev"}
+	path := filepath.Join(dir, "proj_us-central1-a_dev.lock")
+	locker := NewLocker(dir, "gcectl on dev", "me@host")
+
+	unlock, err := locker.Lock(vm)
+	require.NoError(t, err)
+	holder, err := readHolder(path)
+	require.NoError(t, err)
+	assert.Equal(t, "gcectl on dev", holder.Command)
+	assert.Equal(t, os.Getpid(), holder.PID)
+
+	unlockAgain, err := locker.Lock(vm)
+	require.NoError(t, err, "the process holding a lock can take it again")
+	unlockAgain()
+	assert.FileExists(t, path, "the lock is kept until every lock is released")
+	unlock()
+	unlock()
+	assert.NoFileExists(t, path)
+}
+
+func TestLocker_LockHeldByAnotherProcess(t *testing.T) {
+	dir := t.TempDir()
+	vm := &model.VM{Project: "proj", Zone: "us-central1-a", Name: "dev"}
+	path := filepath.Join(dir, lockName(vm))
+	host, err := os.Hostname()
+	require.NoError(t, err)
+	since := time.Date(2025, 1, 2, 19, 0, 0, 0, time.Local)
+	writeHolder(t, path, Holder{Command: "gcectl daemon", User: "ops@box", Host: host, PID: os.Getppid(), Since: since})
+
+	_, err = NewLocker(dir, "gcectl off dev", "me@box").Lock(vm)
+	var locked *LockedError
+	require.ErrorAs(t, err, &locked)
+	assert.Equal(t, "gcectl daemon", locked.Holder.Command)
+	assert.Contains(t, err.Error(), `proj/dev is being changed by another gcectl: "gcectl daemon" (pid`)
+	assert.Contains(t, err.Error(), "ops@box) since 19:00:00")
+}
+
+func TestLocker_TakesOverStaleLocks(t *testing.T) {
+	dir := t.TempDir()
+	vm := &model.VM{Project: "proj", Zone: "us-central1-a", Name: "dev"}
+	path := filepath.Join(dir, lockName(vm))
+	host, err := os.Hostname()
+	require.NoError(t, err)
+	locker := NewLocker(dir, "gcectl off dev", "me@box")
+
+	writeHolder(t, path, Holder{Command: "gcectl on dev", Host: host, PID: deadPID(t)})
+	unlock, err := locker.Lock(vm)
+	require.NoError(t, err, "the lock of a process that is gone is taken over")
+	unlock()
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	unlock, err = locker.Lock(vm)
+	require.NoError(t, err, "a broken lock file is taken over")
+	unlock()
+
+	writeHolder(t, path, Holder{Command: "gcectl on dev", Host: "other-" + host, PID: deadPID(t)})
+	_, err = locker.Lock(vm)
+	assert.ErrorAs(t, err, new(*LockedError), "processes of other hosts cannot be checked")
+}
+
+func TestLocker_TakesOverStaleLocksOnce(t *testing.T) {
+	dir := t.TempDir()
+	vm := &model.VM{Project: "proj", Zone: "us-central1-a", Name: "dev"}
+	path := filepath.Join(dir, lockName(vm))
+	host, err := os.Hostname()
+	require.NoError(t, err)
+	writeHolder(t, path, Holder{Command: "gcectl on dev", Host: host, PID: deadPID(t)})
+
+	// Lockers of this process stand in for processes racing to take over the
+	// stale lock: each sees the lock of another as held, and none takes it
+	// over before all have found it stale.
+	const racers = 8
+	var (
+		arrived sync.WaitGroup
+		done    sync.WaitGroup
+		mu      sync.Mutex
+		unlocks []func()
+	)
+	arrived.Add(racers)
+	for range racers {
+		locker := NewLocker(dir, "gcectl off dev", "me@box")
+		var once sync.Once
+		locker.takingOver = func() {
+			once.Do(func() {
+				arrived.Done()
+				arrived.Wait()
+			})
+		}
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			unlock, lockErr := locker.Lock(vm)
+			if lockErr != nil {
+				assert.ErrorAs(t, lockErr, new(*LockedError))
+				return
+			}
+			mu.Lock()
+			unlocks = append(unlocks, unlock)
+			mu.Unlock()
+		}()
+	}
+	done.Wait()
+
+	require.Len(t, unlocks, 1, "only one process takes over a stale lock")
+	unlocks[0]()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "no lock, temporary or takeover file is left")
+}
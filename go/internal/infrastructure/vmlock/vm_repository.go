@@ -0,0 +1,101 @@
+package vmlock
+
+import (
+	"context"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+)
+
+// VMRepository decorates a repository.VMRepository so every call changing a VM
+// holds the lock of the VM. Reads are not locked.
+type VMRepository struct {
+	repository.VMRepository
+	locker *Locker
+}
+
+// NewVMRepository wraps inner with the locks of locker.
+func NewVMRepository(inner repository.VMRepository, locker *Locker) *VMRepository {
+	return &VMRepository{VMRepository: inner, locker: locker}
+}
+
+func (r *VMRepository) locked(vm *model.VM, fn func() error) error {
+	unlock, err := r.locker.Lock(vm)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return fn()
+}
+
+func (r *VMRepository) Start(ctx context.Context, vm *model.VM) error {
+	return r.locked(vm, func() error { return r.VMRepository.Start(ctx, vm) })
+}
+
+func (r *VMRepository) Stop(ctx context.Context, vm *model.VM) error {
+	return r.locked(vm, func() error { return r.VMRepository.Stop(ctx, vm) })
+}
+
+func (r *VMRepository) Reset(ctx context.Context, vm *model.VM) error {
+	return r.locked(vm, func() error { return r.VMRepository.Reset(ctx, vm) })
+}
+
+func (r *VMRepository) StartAsync(ctx context.Context, vm *model.VM) (*model.Operation, error) {
+	var op *model.Operation
+	err := r.locked(vm, func() error {
+		var err error
+		op, err = r.VMRepository.StartAsync(ctx, vm)
+		return err
+	})
+	return op, err
+}
+
+func (r *VMRepository) StopAsync(ctx context.Context, vm *model.VM) (*model.Operation, error) {
+	var op *model.Operation
+	err := r.locked(vm, func() error {
+		var err error
+		op, err = r.VMRepository.StopAsync(ctx, vm)
+		return err
+	})
+	return op, err
+}
+
+func (r *VMRepository) UpdateMachineType(ctx context.Context, vm *model.VM, machineType string) error {
+	return r.locked(vm, func() error { return r.VMRepository.UpdateMachineType(ctx, vm, machineType) })
+}
+
+func (r *VMRepository) SetAccelerators(ctx context.Context, vm *model.VM, accelerators []model.Accelerator) error {
+	return r.locked(vm, func() error { return r.VMRepository.SetAccelerators(ctx, vm, accelerators) })
+}
+
+func (r *VMRepository) SetSchedulePolicy(ctx context.Context, vm *model.VM, policyName string) error {
+	return r.locked(vm, func() error { return r.VMRepository.SetSchedulePolicy(ctx, vm, policyName) })
+}
+
+func (r *VMRepository) UnsetSchedulePolicy(ctx context.Context, vm *model.VM, policyName string) error {
+	return r.locked(vm, func() error { return r.VMRepository.UnsetSchedulePolicy(ctx, vm, policyName) })
+}
+
+func (r *VMRepository) SetLabels(ctx context.Context, vm *model.VM, labels map[string]string) error {
+	return r.locked(vm, func() error { return r.VMRepository.SetLabels(ctx, vm, labels) })
+}
+
+func (r *VMRepository) UpdateLabels(ctx context.Context, vm *model.VM, set map[string]string, remove []string) error {
+	return r.locked(vm, func() error { return r.VMRepository.UpdateLabels(ctx, vm, set, remove) })
+}
+
+func (r *VMRepository) SetMetadata(ctx context.Context, vm *model.VM, items map[string]string) error {
+	return r.locked(vm, func() error { return r.VMRepository.SetMetadata(ctx, vm, items) })
+}
+
+func (r *VMRepository) UpdateMetadata(ctx context.Context, vm *model.VM, set map[string]string, remove []string) error {
+	return r.locked(vm, func() error { return r.VMRepository.UpdateMetadata(ctx, vm, set, remove) })
+}
+
+func (r *VMRepository) SetScheduling(ctx context.Context, vm *model.VM, scheduling model.Scheduling) error {
+	return r.locked(vm, func() error { return r.VMRepository.SetScheduling(ctx, vm, scheduling) })
+}
+
+func (r *VMRepository) SetSchedulingOptions(ctx context.Context, vm *model.VM, opts model.SchedulingOptions) error {
+	return r.locked(vm, func() error { return r.VMRepository.SetSchedulingOptions(ctx, vm, opts) })
+}
@@ -0,0 +1,43 @@
+package vmlock
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVMRepositoryLocksChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := t.TempDir()
+	vm := &model.VM{Project: "p", Zone: "z", Name: "sandbox"}
+	path := filepath.Join(dir, lockName(vm))
+	inner := mock_repository.NewMockVMRepository(ctrl)
+	inner.EXPECT().FindByName(gomock.Any(), vm).Return(vm, nil)
+	inner.EXPECT().Stop(gomock.Any(), vm).DoAndReturn(func(context.Context, *model.VM) error {
+		assert.FileExists(t, path, "the VM is locked while it is stopped")
+		return nil
+	})
+
+	repo := NewVMRepository(inner, NewLocker(dir, "gcectl off sandbox", "me@box"))
+	_, err := repo.FindByName(context.Background(), vm)
+	require.NoError(t, err)
+	require.NoError(t, repo.Stop(context.Background(), vm))
+	assert.NoFileExists(t, path)
+
+	other := NewVMRepository(inner, NewLocker(dir, "gcectl on sandbox", "you@box"))
+	unlock, err := other.locker.Lock(vm)
+	require.NoError(t, err)
+	defer unlock()
+	// Another process is simulated by a locker of another PID.
+	repo.locker.holder.PID = other.locker.holder.PID + 1
+	err = repo.Stop(context.Background(), vm)
+	assert.ErrorAs(t, err, new(*LockedError), "the stop is refused without calling GCP")
+}
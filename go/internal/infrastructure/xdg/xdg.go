@@ -0,0 +1,120 @@
+// Package xdg locates the files of gcectl following the XDG Base Directory
+// Specification, falling back to where earlier versions kept them.
+//
+// Settings go to $XDG_CONFIG_HOME/gcectl (~/.config/gcectl), records gcectl
+// keeps between runs, such as snoozes and the operation history, to
+// $XDG_STATE_HOME/gcectl (~/.local/state/gcectl) and data that can be
+// rebuilt to $XDG_CACHE_HOME/gcectl (~/.cache/gcectl). The defaults in
+// parentheses apply when a variable is unset or not an absolute path, on every
+// platform.
+package xdg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// appName is the directory of gcectl in each base directory.
+const appName = "gcectl"
+
+// ConfigHome returns $XDG_CONFIG_HOME, or ~/.config.
+func ConfigHome() (string, error) {
+	return baseDir("XDG_CONFIG_HOME", ".config")
+}
+
+// StateHome returns $XDG_STATE_HOME, or ~/.local/state.
+func StateHome() (string, error) {
+	return baseDir("XDG_STATE_HOME", filepath.Join(".local", "state"))
+}
+
+// CacheHome returns $XDG_CACHE_HOME, or ~/.cache.
+func CacheHome() (string, error) {
+	return baseDir("XDG_CACHE_HOME", ".cache")
+}
+
+// DataHome returns $XDG_DATA_HOME, or ~/.local/share.
+func DataHome() (string, error) {
+	return baseDir("XDG_DATA_HOME", filepath.Join(".local", "share"))
+}
+
+// ConfigFile returns the path of the settings file name, e.g. config.yaml:
+// $XDG_CONFIG_HOME/gcectl/name, unless only ~/.config/gcectl/name or the
+// platform user config directory used by earlier versions has the file.
+//
+// Parameters:
+//   - name: The file name
+//
+// Returns:
+//   - string: The path of the file, which may not exist yet
+//   - error: An error if the home directory is unknown
+func ConfigFile(name string) (string, error) {
+	dir, err := ConfigHome()
+	if err != nil {
+		return "", err
+	}
+	var legacy []string
+	if home, homeErr := os.UserHomeDir(); homeErr == nil {
+		legacy = append(legacy, filepath.Join(home, ".config", appName, name))
+	}
+	if userDir, dirErr := os.UserConfigDir(); dirErr == nil {
+		legacy = append(legacy, filepath.Join(userDir, appName, name))
+	}
+	return existingOr(filepath.Join(dir, appName, name), legacy...), nil
+}
+
+// StateFile returns the path of the state file name, e.g. snoozes.json:
+// $XDG_STATE_HOME/gcectl/name, unless only the user config directory, where
+// earlier versions kept state, has the file, so existing records keep being used.
+//
+// Parameters:
+//   - name: The file name
+//
+// Returns:
+//   - string: The path of the file, which may not exist yet
+//   - error: An error if the home directory is unknown
+func StateFile(name string) (string, error) {
+	home, err := StateHome()
+	if err != nil {
+		return "", err
+	}
+	var legacy []string
+	if dir, dirErr := os.UserConfigDir(); dirErr == nil {
+		legacy = append(legacy, filepath.Join(dir, appName, name))
+	}
+	return existingOr(filepath.Join(home, appName, name), legacy...), nil
+}
+
+// CacheFile returns the path of the cache file or directory name under
+// $XDG_CACHE_HOME/gcectl. Caches are rebuilt rather than looked for where
+// earlier versions kept them.
+func CacheFile(name string) (string, error) {
+	home, err := CacheHome()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, appName, name), nil
+}
+
+// baseDir returns the directory in the environment variable key when it is an
+// absolute path, as the specification requires, otherwise ~/fallback.
+func baseDir(key, fallback string) (string, error) {
+	if dir := os.Getenv(key); filepath.IsAbs(dir) {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, fallback), nil
+}
+
+// existingOr returns the first of path and legacy that exists, or path when none does.
+func existingOr(path string, legacy ...string) string {
+	for _, p := range append([]string{path}, legacy...) {
+		if _, err := os.Stat(p); err == nil {
+			return p
+		}
+	}
+	return path
+}
@@ -0,0 +1,86 @@
+package xdg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setHome points the home directory and the XDG variables at a temporary directory.
+func setHome(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	for _, key := range []string{"XDG_CONFIG_HOME", "XDG_STATE_HOME", "XDG_CACHE_HOME", "XDG_DATA_HOME"} {
+		t.Setenv(key, "")
+	}
+	return home
+}
+
+func TestBaseDirs(t *testing.T) {
+	home := setHome(t)
+	dir, err := StateHome()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".local", "state"), dir)
+
+	t.Setenv("XDG_STATE_HOME", "/state")
+	dir, err = StateHome()
+	require.NoError(t, err)
+	assert.Equal(t, "/state", dir)
+
+	t.Setenv("XDG_STATE_HOME", "relative/state")
+	dir, err = StateHome()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".local", "state"), dir, "relative paths are ignored")
+}
+
+func TestConfigFile(t *testing.T) {
+	home := setHome(t)
+	xdgHome := filepath.Join(home, "xdg")
+	t.Setenv("XDG_CONFIG_HOME", xdgHome)
+
+	path, err := ConfigFile("config.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(xdgHome, "gcectl", "config.yaml"), path)
+
+	legacy := filepath.Join(home, ".config", "gcectl", "config.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(legacy), 0o700))
+	require.NoError(t, os.WriteFile(legacy, nil, 0o600))
+	path, err = ConfigFile("config.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, legacy, path, "an existing ~/.config file is kept")
+
+	current := filepath.Join(xdgHome, "gcectl", "config.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(current), 0o700))
+	require.NoError(t, os.WriteFile(current, nil, 0o600))
+	path, err = ConfigFile("config.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, current, path)
+}
+
+func TestStateFile(t *testing.T) {
+	home := setHome(t)
+	path, err := StateFile("snoozes.json")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".local", "state", "gcectl", "snoozes.json"), path)
+
+	userDir, err := os.UserConfigDir()
+	require.NoError(t, err)
+	legacy := filepath.Join(userDir, "gcectl", "snoozes.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(legacy), 0o700))
+	require.NoError(t, os.WriteFile(legacy, nil, 0o600))
+	path, err = StateFile("snoozes.json")
+	require.NoError(t, err)
+	assert.Equal(t, legacy, path, "state kept by earlier versions is still used")
+}
+
+func TestCacheFile(t *testing.T) {
+	setHome(t)
+	t.Setenv("XDG_CACHE_HOME", "/cache")
+	path, err := CacheFile("state.json")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/cache", "gcectl", "state.json"), path)
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// StdinArg is the VM name argument that stands for the names read from stdin.
+const StdinArg = "-"
+
+// ExpandStdinArgs replaces StdinArg in args with the VM names read from stdin,
+// one per line, so a selection can be piped in, e.g. from
+// `gcectl list --query '.[].Name'` or jq. Blank lines are skipped and names
+// quoted as JSON strings are unquoted. args without StdinArg are returned as is.
+//
+// Parameters:
+//   - args: The VM name arguments
+//   - stdin: Where the names are read from
+//
+// Returns:
+//   - []string: args with StdinArg replaced by the names read
+//   - error: An error if StdinArg is given twice, stdin cannot be read or has no names
+func ExpandStdinArgs(args []string, stdin io.Reader) ([]string, error) {
+	i := -1
+	for j, arg := range args {
+		if arg != StdinArg {
+			continue
+		}
+		if i >= 0 {
+			return nil, fmt.Errorf("%s can be given only once", StdinArg)
+		}
+		i = j
+	}
+	if i < 0 {
+		return args, nil
+	}
+
+	var names []string
+	scanner := bufio.NewScanner(stdin)
+	for scanner.Scan() {
+		name := strings.TrimSpace(scanner.Text())
+		if name == "" {
+			continue
+		}
+		if unquoted, err := strconv.Unquote(name); err == nil && strings.HasPrefix(name, `"`) {
+			name = unquoted
+		}
+		names = append(names, name)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read VM names from stdin: %w", err)
+	}
+	if len(names) == 0 {
+		return nil, errors.New("no VM names on stdin")
+	}
+
+	expanded := make([]string, 0, len(args)-1+len(names))
+	expanded = append(expanded, args[:i]...)
+	expanded = append(expanded, names...)
+	return append(expanded, args[i+1:]...), nil
+}
+
+// GlobalFlagArgs returns the global flags set on the command line of cmd, e.g.
+// --credentials-file or --endpoint, as arguments for a gcectl it starts in the
+// background, so that one talks to GCP the same way. Flags named in skip are
+// left out, e.g. config when the caller passes a resolved one.
+//
+// Parameters:
+//   - cmd: The running command, after its flags were parsed
+//   - skip: Names of global flags not to pass on
+//
+// Returns:
+//   - []string: Arguments of the form --name=value, in flag name order
+func GlobalFlagArgs(cmd *cobra.Command, skip ...string) []string {
+	global := cmd.Root().PersistentFlags()
+	var args []string
+	// The flags of cmd include the parsed global ones; InheritedFlags copies
+	// them without their Changed state.
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if global.Lookup(f.Name) == nil || slices.Contains(skip, f.Name) {
+			return
+		}
+		args = append(args, "--"+f.Name+"="+f.Value.String())
+	})
+	return args
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandStdinArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		stdin   string
+		wantErr string
+		args    []string
+		want    []string
+	}{
+		{name: "no stdin argument", args: []string{"vm1", "vm2"}, stdin: "ignored\n", want: []string{"vm1", "vm2"}},
+		{name: "names", args: []string{"-"}, stdin: "vm1\n\n  vm2  \nvm3", want: []string{"vm1", "vm2", "vm3"}},
+		{name: "json strings", args: []string{"-"}, stdin: "\"vm1\"\n\"proj/vm2\"\n", want: []string{"vm1", "proj/vm2"}},
+		{name: "mixed with names", args: []string{"vm0", "-", "vm9"}, stdin: "vm1\n", want: []string{"vm0", "vm1", "vm9"}},
+		{name: "empty stdin", args: []string{"-"}, stdin: "\n", wantErr: "no VM names on stdin"},
+		{name: "twice", args: []string{"-", "-"}, stdin: "vm1\n", wantErr: "- can be given only once"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandStdinArgs(tt.args, strings.NewReader(tt.stdin))
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGlobalFlagArgs(t *testing.T) {
+	root := &cobra.Command{Use: "gcectl"}
+	root.PersistentFlags().String("config", "", "")
+	root.PersistentFlags().String("credentials-file", "", "")
+	root.PersistentFlags().String("endpoint", "", "")
+	root.PersistentFlags().Int("max-concurrency", 0, "")
+	root.PersistentFlags().Bool("plain", false, "")
+	var got []string
+	list := &cobra.Command{Use: "list", Run: func(cmd *cobra.Command, _ []string) {
+		got = GlobalFlagArgs(cmd, "config")
+	}}
+	list.Flags().Bool("cached", false, "")
+	root.AddCommand(list)
+
+	root.SetArgs([]string{"list", "--cached", "--config", "c.yaml", "--endpoint", "http://localhost:8080", "--credentials-file", "key.json", "--max-concurrency", "2", "--plain"})
+	require.NoError(t, root.Execute())
+
+	assert.Equal(t, []string{
+		"--credentials-file=key.json",
+		"--endpoint=http://localhost:8080",
+		"--max-concurrency=2",
+		"--plain=true",
+	}, got, "only the global flags given are passed on, without the skipped ones")
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/googleapis/gax-go/v2/apierror"
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"google.golang.org/api/googleapi"
+)
+
+// Exit codes shared by all commands, so scripts can branch on the failure type.
+const (
+	// ExitOK means the command succeeded.
+	ExitOK = 0
+	// ExitFailure is any failure without a more specific code, e.g. invalid arguments.
+	ExitFailure = 1
+	// ExitConfig means the config file or a flag overriding it is invalid.
+	ExitConfig = 2
+	// ExitVMNotFound means a VM is not in the config file or does not exist on GCP.
+	ExitVMNotFound = 3
+	// ExitInvalidState means the VM is not in a state the command can act on.
+	ExitInvalidState = 4
+	// ExitAPIError means a GCP API request or operation failed.
+	ExitAPIError = 5
+	// ExitCancelled means the command was interrupted.
+	ExitCancelled = 6
+	// ExitDrift means `gcectl diff` found VMs that differ from the config file.
+	ExitDrift = 7
+	// ExitTimeout means the command gave up waiting for VMs, e.g. `gcectl wait`
+	// past its --wait-timeout. A GCP API call or operation timing out is an
+	// ExitAPIError instead.
+	ExitTimeout = 8
+)
+
+// ConfigError wraps a failure to load the config file or to apply the flags overriding it.
+type ConfigError struct {
+	Err error
+}
+
+func (e *ConfigError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the exit code a command failing with err exits with. A
+// command cancelled because the user detached from its operations exits with
+// ExitOK, as they are left running on purpose.
+func ExitCode(err error) int {
+	var configErr *ConfigError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, context.Canceled):
+		if detached.Load() {
+			return ExitOK
+		}
+		return ExitCancelled
+	case errors.As(err, &configErr):
+		return ExitConfig
+	case errors.Is(err, model.ErrWaitTimeout):
+		return ExitTimeout
+	case errors.Is(err, model.ErrVMNotFoundInConfig), errors.Is(err, model.ErrVMNotFound), isAPINotFound(err):
+		return ExitVMNotFound
+	case errors.Is(err, model.ErrVMNotRunning),
+		errors.Is(err, model.ErrInvalidState),
+		errors.Is(err, model.ErrNoStartTime),
+		errors.Is(err, model.ErrNoExternalIP),
+		errors.Is(err, model.ErrNoInternalIP),
+		errors.Is(err, model.ErrUnsupported):
+		return ExitInvalidState
+	case errors.Is(err, model.ErrPermissionDenied),
+		errors.Is(err, model.ErrQuotaExceeded),
+		errors.Is(err, model.ErrZoneResourceExhausted),
+		errors.Is(err, context.DeadlineExceeded),
+		isAPIError(err):
+		return ExitAPIError
+	}
+	return ExitFailure
+}
+
+func isAPIError(err error) bool {
+	var apiErr *apierror.APIError
+	var googleErr *googleapi.Error
+	return errors.As(err, &apiErr) || errors.As(err, &googleErr)
+}
+
+// isAPINotFound reports whether err is a 404 from the API, which for the
+// instance requests of the commands means the VM does not exist.
+func isAPINotFound(err error) bool {
+	var apiErr *apierror.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.HTTPCode() == http.StatusNotFound
+	}
+	var googleErr *googleapi.Error
+	return errors.As(err, &googleErr) && googleErr.Code == http.StatusNotFound
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/haru-256/gcectl/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/api/googleapi"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		name string
+		want int
+	}{
+		{name: "success", err: nil, want: ExitOK},
+		{name: "other failure", err: errors.New("invalid argument"), want: ExitFailure},
+		{name: "config", err: &ConfigError{Err: errors.New("failed to parse config YAML")}, want: ExitConfig},
+		{name: "VM not in config", err: fmt.Errorf("%w: sandbox", model.ErrVMNotFoundInConfig), want: ExitVMNotFound},
+		{name: "VM gone from GCP", err: fmt.Errorf("VM sandbox: %w", model.ErrVMNotFound), want: ExitVMNotFound},
+		{name: "VM not on GCP", err: fmt.Errorf("failed to get instance: %w", &googleapi.Error{Code: http.StatusNotFound}), want: ExitVMNotFound},
+		{name: "VM not running", err: fmt.Errorf("sandbox: %w", model.ErrVMNotRunning), want: ExitInvalidState},
+		{name: "VM already running", err: model.InvalidStateErrorf("VM %s: cannot be started (current status: %s)", "sandbox", model.StatusRunning), want: ExitInvalidState},
+		{name: "VM running for machine type", err: fmt.Errorf("failed: %w", model.InvalidStateErrorf("VM %s must be stopped before changing machine type (current status: %s)", "sandbox", model.StatusRunning)), want: ExitInvalidState},
+		{name: "unsupported", err: &model.CapabilityError{VMName: "sandbox", Capability: model.CapabilityStart}, want: ExitInvalidState},
+		{name: "API error", err: fmt.Errorf("failed to start instance: %w", &googleapi.Error{Code: http.StatusBadRequest}), want: ExitAPIError},
+		{name: "quota", err: fmt.Errorf("operation failed: %w", model.ErrQuotaExceeded), want: ExitAPIError},
+		{name: "timeout", err: fmt.Errorf("operation failed: %w", context.DeadlineExceeded), want: ExitAPIError},
+		{name: "wait timeout", err: fmt.Errorf("VM sandbox: still RUNNING, not stopped: %w", model.ErrWaitTimeout), want: ExitTimeout},
+		{name: "cancelled", err: fmt.Errorf("operation failed: %w", context.Canceled), want: ExitCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExitCode(tt.err))
+		})
+	}
+}
+
+func TestExitCode_Detached(t *testing.T) {
+	detached.Store(true)
+	t.Cleanup(func() { detached.Store(false) })
+
+	assert.Equal(t, ExitOK, ExitCode(fmt.Errorf("operation failed: %w", context.Canceled)))
+	assert.Equal(t, ExitAPIError, ExitCode(fmt.Errorf("operation failed: %w", context.DeadlineExceeded)))
+}
+
+func TestExitCode_VMNotFoundByUseCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mock_repository.NewMockVMRepository(ctrl)
+	repo.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	vms := []*model.VM{{Name: "sandbox", Project: "p", Zone: "z"}}
+
+	_, _, err := usecase.NewDescribeVMUseCase(repo).Execute(context.Background(), "p", "z", "sandbox")
+	assert.Equal(t, ExitVMNotFound, ExitCode(err), "describe: %v", err)
+	err = usecase.NewStopVMUseCase(repo, log.NewNopLogger()).Execute(context.Background(), vms)
+	assert.Equal(t, ExitVMNotFound, ExitCode(err), "off: %v", err)
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/user"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// operationRecorder turns the operation events of a command into records of the
+// operation history.
+type operationRecorder struct {
+	started map[string]time.Time
+	now     func() time.Time
+	// detached reports whether the user detached from the pending operations.
+	detached  func() bool
+	command   string
+	localUser string
+	records   []*model.OperationRecord
+	mu        sync.Mutex
+}
+
+func newOperationRecorder(command, localUser string) *operationRecorder {
+	return &operationRecorder{
+		started:   make(map[string]time.Time),
+		now:       time.Now,
+		detached:  detached.Load,
+		command:   command,
+		localUser: localUser,
+	}
+}
+
+// apply notes when an operation started and records it when it is done.
+// Operations without insert and end times, e.g. when the wait was interrupted,
+// are timed by when their events were seen. An operation whose wait was
+// cancelled by detaching is recorded as still running.
+func (r *operationRecorder) apply(ev model.OperationEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	op := ev.Operation()
+	switch e := ev.(type) {
+	case model.OperationStarted:
+		if _, ok := r.started[op.Path()]; !ok {
+			r.started[op.Path()] = r.now()
+		}
+	case model.OperationDone:
+		started, ok := r.started[op.Path()]
+		if !ok {
+			started = r.now()
+		}
+		delete(r.started, op.Path())
+
+		record := &model.OperationRecord{
+			Time:      started,
+			Duration:  r.now().Sub(started),
+			Target:    op.Target,
+			Project:   op.Project,
+			Zone:      op.Zone,
+			Type:      op.Type,
+			Operation: op.Name,
+			User:      op.User,
+			LocalUser: r.localUser,
+			Command:   r.command,
+			Error:     op.Error,
+		}
+		if op.InsertTime != nil {
+			record.Time = *op.InsertTime
+			if op.EndTime != nil {
+				record.Duration = op.EndTime.Sub(*op.InsertTime)
+			}
+		}
+		switch {
+		case e.Err != nil && r.detached() && errors.Is(e.Err, context.Canceled):
+			record.Running = true
+		case e.Err != nil:
+			record.Error = e.Err.Error()
+		}
+		r.records = append(r.records, record)
+	}
+}
+
+// take returns the records of the finished operations and forgets them.
+func (r *operationRecorder) take() []*model.OperationRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	records := r.records
+	r.records = nil
+	return records
+}
+
+// commandLine returns the command line of the running gcectl.
+func commandLine() string {
+	return strings.Join(append([]string{"gcectl"}, os.Args[1:]...), " ")
+}
+
+// localUser returns user@host for the user running gcectl, leaving out what is unknown.
+func localUser() string {
+	name := ""
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		return name
+	}
+	return name + "@" + host
+}
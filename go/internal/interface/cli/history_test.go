@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationRecorder(t *testing.T) {
+	now := time.Date(2025, 1, 2, 19, 0, 0, 0, time.UTC)
+	recorder := newOperationRecorder("gcectl off vm1", "alice@laptop")
+	recorder.now = func() time.Time { return now }
+
+	op := &model.Operation{Name: "operation-1", Project: "p", Zone: "z", Type: "stop", Target: "vm1"}
+	recorder.apply(model.OperationStarted{Op: op})
+	recorder.apply(model.OperationProgress{Op: op, Percent: 50})
+	assert.Empty(t, recorder.take(), "running operations are not recorded")
+
+	now = now.Add(time.Minute)
+	recorder.apply(model.OperationDone{Op: op, Err: context.Canceled})
+
+	records := recorder.take()
+	require.Len(t, records, 1)
+	assert.Equal(t, &model.OperationRecord{
+		Time:      now.Add(-time.Minute),
+		Duration:  time.Minute,
+		Target:    "vm1",
+		Project:   "p",
+		Zone:      "z",
+		Type:      "stop",
+		Operation: "operation-1",
+		LocalUser: "alice@laptop",
+		Command:   "gcectl off vm1",
+		Error:     "context canceled",
+	}, records[0])
+	assert.Empty(t, recorder.take(), "records are only taken once")
+}
+
+func TestOperationRecorder_Detached(t *testing.T) {
+	recorder := newOperationRecorder("gcectl off vm1", "alice@laptop")
+	recorder.detached = func() bool { return true }
+
+	op := &model.Operation{Name: "operation-1", Project: "p", Zone: "z", Type: "stop", Target: "vm1"}
+	recorder.apply(model.OperationStarted{Op: op})
+	recorder.apply(model.OperationDone{Op: op, Err: context.Canceled})
+	other := &model.Operation{Name: "operation-2", Project: "p", Zone: "z", Type: "stop", Target: "vm2"}
+	recorder.apply(model.OperationDone{Op: other, Err: assert.AnError})
+
+	records := recorder.take()
+	require.Len(t, records, 2)
+	assert.True(t, records[0].Running, "a wait cancelled by detaching leaves the operation running")
+	assert.Empty(t, records[0].Error)
+	assert.False(t, records[0].Succeeded())
+	assert.False(t, records[1].Running, "other errors are failures")
+	assert.Equal(t, assert.AnError.Error(), records[1].Error)
+}
@@ -0,0 +1,207 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// operationTracker records the GCP operations a command is currently waiting for
+// from the operation events of the repositories.
+type operationTracker struct {
+	mu      sync.Mutex
+	pending map[string]*model.Operation
+}
+
+func newOperationTracker() *operationTracker {
+	return &operationTracker{pending: make(map[string]*model.Operation)}
+}
+
+// track applies events until done is closed, passing each to observers too.
+func (t *operationTracker) track(events <-chan model.OperationEvent, done <-chan struct{}, observers ...func(model.OperationEvent)) {
+	for {
+		select {
+		case ev := <-events:
+			t.apply(ev)
+			for _, observe := range observers {
+				observe(ev)
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+// apply records a started operation as pending, updates its state on progress
+// and forgets it when done.
+func (t *operationTracker) apply(ev model.OperationEvent) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	op := ev.Operation()
+	switch ev.(type) {
+	case model.OperationStarted:
+		t.pending[op.Path()] = op
+	case model.OperationProgress:
+		if _, ok := t.pending[op.Path()]; ok {
+			t.pending[op.Path()] = op
+		}
+	case model.OperationDone:
+		delete(t.pending, op.Path())
+	}
+}
+
+// Pending returns the operations still being waited for, ordered by path.
+func (t *operationTracker) Pending() []*model.Operation {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ops := make([]*model.Operation, 0, len(t.pending))
+	for _, op := range t.pending {
+		ops = append(ops, op)
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].Path() < ops[j].Path() })
+	return ops
+}
+
+// Progress returns the average progress percentage of the pending operations.
+// It implements presenter.OperationProgress; ok is false when nothing is pending.
+func (t *operationTracker) Progress() (percent int, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.pending) == 0 {
+		return 0, false
+	}
+	total := 0
+	for _, op := range t.pending {
+		total += op.Progress
+	}
+	return total / len(t.pending), true
+}
+
+// detached is set when the user detached from the pending operations, so that
+// the command cancelled by it exits with ExitOK. See ExitCode.
+var detached atomic.Bool
+
+// interruptHandler decides what a Ctrl-C does while a command runs.
+//
+// Cancelling a wait does not cancel the operation on GCP, so when operations are
+// pending the first Ctrl-C lists them and asks whether to detach from them or to
+// keep waiting. Detaching cancels the command like any interrupt, but marks it
+// detached, so it still records its operations and exits with ExitOK. Any
+// further signal exits immediately.
+//
+//nolint:govet // Field order optimized for readability over memory alignment
+type interruptHandler struct {
+	tracker *operationTracker
+	// interactive reports whether the user can be asked; otherwise the command is cancelled.
+	interactive bool
+	in          *bufio.Reader
+	out         io.Writer
+	cancel      context.CancelFunc
+	detached    *atomic.Bool
+	exit        func(int)
+
+	mu          sync.Mutex
+	interrupted bool
+}
+
+// handle reacts to one received signal.
+func (h *interruptHandler) handle(sig os.Signal) {
+	h.mu.Lock()
+	if h.interrupted {
+		h.mu.Unlock()
+		fmt.Fprintln(h.out, "\nForce exiting.")
+		h.exit(ExitCancelled)
+		return
+	}
+	h.interrupted = true
+	h.mu.Unlock()
+
+	pending := h.tracker.Pending()
+	if len(pending) == 0 || !h.interactive || sig != os.Interrupt {
+		if len(pending) > 0 {
+			h.printPending(pending)
+		}
+		h.cancel()
+		return
+	}
+
+	h.printPending(pending)
+	fmt.Fprint(h.out, "Detach and leave them running [d], or keep waiting [w]? ")
+	answer, err := h.in.ReadString('\n')
+	if err != nil && answer == "" {
+		h.cancel()
+		return
+	}
+	if strings.EqualFold(strings.TrimSpace(answer), "d") {
+		fmt.Fprintln(h.out, "Detached. Check the operations with 'gcectl operations wait <operation>'.")
+		h.detached.Store(true)
+		h.cancel()
+		return
+	}
+
+	fmt.Fprintln(h.out, "Waiting. Press Ctrl-C again to force exit.")
+}
+
+func (h *interruptHandler) printPending(ops []*model.Operation) {
+	fmt.Fprintln(h.out, "\nThe following operations are still running on GCP:")
+	for _, op := range ops {
+		fmt.Fprintf(h.out, "  %s (%s %s)\n", op.Path(), op.Type, op.Target)
+	}
+}
+
+// watchInterrupts routes SIGINT and SIGTERM to a handler cancelling the returned context.
+// The returned function stops watching and cancels the context.
+func watchInterrupts(parent context.Context, tracker *operationTracker) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	h := &interruptHandler{
+		tracker:     tracker,
+		interactive: isTerminal(os.Stdin),
+		in:          bufio.NewReader(os.Stdin),
+		out:         os.Stderr,
+		cancel:      cancel,
+		detached:    &detached,
+		exit:        os.Exit,
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				// The prompt blocks, so it runs aside to let a second Ctrl-C through.
+				go h.handle(sig)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return ctx, func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+			cancel()
+		})
+	}
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestInterruptHandler(input string, interactive bool) (*interruptHandler, context.Context, *bytes.Buffer, *[]int) {
+	ctx, cancel := context.WithCancel(context.Background())
+	out := &bytes.Buffer{}
+	exits := &[]int{}
+	h := &interruptHandler{
+		tracker:     newOperationTracker(),
+		interactive: interactive,
+		in:          bufio.NewReader(strings.NewReader(input)),
+		out:         out,
+		cancel:      cancel,
+		detached:    &atomic.Bool{},
+		exit:        func(code int) { *exits = append(*exits, code) },
+	}
+	return h, ctx, out, exits
+}
+
+func startOperation() *model.Operation {
+	return &model.Operation{Name: "operation-1", Project: "p", Zone: "us-central1-a", Type: "start", Target: "my-vm"}
+}
+
+func TestOperationTracker(t *testing.T) {
+	tracker := newOperationTracker()
+	a := &model.Operation{Name: "operation-a", Project: "p", Zone: "z"}
+	b := &model.Operation{Name: "operation-b", Project: "p", Zone: "z"}
+
+	tracker.apply(model.OperationStarted{Op: b})
+	tracker.apply(model.OperationStarted{Op: a})
+	assert.Equal(t, []*model.Operation{a, b}, tracker.Pending())
+
+	tracker.apply(model.OperationDone{Op: b})
+	assert.Equal(t, []*model.Operation{a}, tracker.Pending())
+}
+
+func TestOperationTrackerTrack(t *testing.T) {
+	tracker := newOperationTracker()
+	events := make(chan model.OperationEvent)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		tracker.track(events, done)
+		close(stopped)
+	}()
+
+	op := &model.Operation{Name: "operation-a", Project: "p", Zone: "z"}
+	events <- model.OperationStarted{Op: op}
+	events <- model.OperationProgress{Op: op, Percent: 0}
+	close(done)
+	<-stopped
+
+	assert.Equal(t, []*model.Operation{op}, tracker.Pending())
+}
+
+func TestOperationTrackerProgress(t *testing.T) {
+	tracker := newOperationTracker()
+	_, ok := tracker.Progress()
+	assert.False(t, ok)
+
+	tracker.apply(model.OperationStarted{Op: &model.Operation{Name: "operation-a", Project: "p", Zone: "z"}})
+	tracker.apply(model.OperationStarted{Op: &model.Operation{Name: "operation-b", Project: "p", Zone: "z"}})
+	a := &model.Operation{Name: "operation-a", Project: "p", Zone: "z", Progress: 80}
+	tracker.apply(model.OperationProgress{Op: a, Percent: a.Progress})
+	// Progress of an operation that is no longer pending is ignored.
+	tracker.apply(model.OperationProgress{Op: &model.Operation{Name: "operation-c", Project: "p", Zone: "z", Progress: 100}, Percent: 100})
+
+	percent, ok := tracker.Progress()
+	assert.True(t, ok)
+	assert.Equal(t, 40, percent)
+	assert.Len(t, tracker.Pending(), 2)
+}
+
+func TestInterruptHandlerCancelsWithoutPendingOperations(t *testing.T) {
+	h, ctx, out, exits := newTestInterruptHandler("", true)
+
+	h.handle(os.Interrupt)
+
+	require.Error(t, ctx.Err())
+	assert.Empty(t, out.String())
+	assert.Empty(t, *exits)
+}
+
+func TestInterruptHandlerDetaches(t *testing.T) {
+	h, ctx, out, exits := newTestInterruptHandler("d\n", true)
+	h.tracker.apply(model.OperationStarted{Op: startOperation()})
+
+	h.handle(os.Interrupt)
+
+	require.Error(t, ctx.Err(), "the command is cancelled to return normally")
+	assert.True(t, h.detached.Load())
+	assert.Contains(t, out.String(), "projects/p/zones/us-central1-a/operations/operation-1 (start my-vm)")
+	assert.Contains(t, out.String(), "Detached")
+	assert.Empty(t, *exits)
+}
+
+func TestInterruptHandlerKeepsWaiting(t *testing.T) {
+	h, ctx, out, exits := newTestInterruptHandler("w\n", true)
+	h.tracker.apply(model.OperationStarted{Op: startOperation()})
+
+	h.handle(os.Interrupt)
+	require.NoError(t, ctx.Err())
+	assert.Empty(t, *exits)
+	assert.Contains(t, out.String(), "Waiting")
+
+	// A second Ctrl-C force exits.
+	h.handle(os.Interrupt)
+	assert.Equal(t, []int{ExitCancelled}, *exits)
+}
+
+func TestInterruptHandlerCancelsWhenNotAsked(t *testing.T) {
+	tests := []struct {
+		name        string
+		sig         os.Signal
+		interactive bool
+	}{
+		{name: "non-interactive", sig: os.Interrupt, interactive: false},
+		{name: "SIGTERM", sig: syscall.SIGTERM, interactive: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, ctx, out, exits := newTestInterruptHandler("d\n", tt.interactive)
+			h.tracker.apply(model.OperationStarted{Op: startOperation()})
+
+			h.handle(tt.sig)
+
+			require.Error(t, ctx.Err())
+			assert.Contains(t, out.String(), "operation-1")
+			assert.NotContains(t, out.String(), "Detach and leave")
+			assert.Empty(t, *exits)
+		})
+	}
+}
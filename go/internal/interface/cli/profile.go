@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/config"
+)
+
+// profileDefaults are the default project and zone of the profile chosen with
+// `gcectl context use`, which replace those of the config file.
+var profileDefaults struct {
+	project string
+	zone    string
+}
+
+// UseProfile makes LoadConfig, and so every session, apply the default project
+// and zone of p. The config file of p is chosen by the caller, as the --config flag.
+func UseProfile(p *model.Profile) {
+	profileDefaults.project = p.Project
+	profileDefaults.zone = p.Zone
+}
+
+// LoadConfig loads the config file at path with the defaults of the profile
+// passed to UseProfile.
+func LoadConfig(path string) (*config.Config, error) {
+	return config.NewConfigWithDefaults(path, profileDefaults.project, profileDefaults.zone)
+}
@@ -0,0 +1,23 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfigAppliesProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("default-project: file-project\ndefault-zone: file-zone\n"), 0o600))
+
+	UseProfile(&model.Profile{Name: "work", Project: "work-project", Zone: "work-zone"})
+	t.Cleanup(func() { UseProfile(&model.Profile{Name: model.DefaultProfileName}) })
+
+	cfg, err := LoadConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, "work-project", cfg.DefaultProject)
+	require.Equal(t, "work-zone", cfg.DefaultZone)
+}
@@ -4,14 +4,25 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
-	"os/signal"
-	"syscall"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/haru-256/gcectl/internal/domain/model"
 	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/audit"
 	"github.com/haru-256/gcectl/internal/infrastructure/config"
 	"github.com/haru-256/gcectl/internal/infrastructure/gcp"
 	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/infrastructure/notifier"
+	"github.com/haru-256/gcectl/internal/infrastructure/oplog"
+	"github.com/haru-256/gcectl/internal/infrastructure/provider"
+	"github.com/haru-256/gcectl/internal/infrastructure/retry"
+	"github.com/haru-256/gcectl/internal/infrastructure/vmlock"
+	"github.com/haru-256/gcectl/internal/interface/presenter"
+	"github.com/haru-256/gcectl/internal/usecase"
 	"github.com/spf13/cobra"
 )
 
@@ -22,36 +33,261 @@ type VMRepositoryCloser interface {
 	Close() error
 }
 
+type OperationRepositoryCloser interface {
+	repository.OperationRepository
+	Close() error
+}
+
+type SchedulePolicyRepositoryCloser interface {
+	repository.SchedulePolicyRepository
+	Close() error
+}
+
+type MachineTypeRepositoryCloser interface {
+	repository.MachineTypeRepository
+	Close() error
+}
+
+type AcceleratorTypeRepositoryCloser interface {
+	repository.AcceleratorTypeRepository
+	Close() error
+}
+
+type DiskRepositoryCloser interface {
+	repository.DiskRepository
+	Close() error
+}
+
+type SnapshotRepositoryCloser interface {
+	repository.SnapshotRepository
+	Close() error
+}
+
+type MachineImageRepositoryCloser interface {
+	repository.MachineImageRepository
+	Close() error
+}
+
+type SerialPortRepositoryCloser interface {
+	repository.SerialPortRepository
+	Close() error
+}
+
+type LogRepositoryCloser interface {
+	repository.LogRepository
+	Close() error
+}
+
+type MetricsRepositoryCloser interface {
+	repository.MetricsRepository
+	Close() error
+}
+
+type RegionRepositoryCloser interface {
+	repository.RegionRepository
+	Close() error
+}
+
+type ProjectRepositoryCloser interface {
+	repository.ProjectRepository
+	Close() error
+}
+
 type ConfigLoader func(string) (*config.Config, error)
 
-type VMRepositoryFactory func(context.Context, infraLog.Logger) (VMRepositoryCloser, error)
+type VMRepositoryFactory func(context.Context, infraLog.Logger, gcp.ClientSettings) (VMRepositoryCloser, error)
+
+type OperationRepositoryFactory func(context.Context, infraLog.Logger, gcp.ClientSettings) (OperationRepositoryCloser, error)
+
+type SchedulePolicyRepositoryFactory func(context.Context, infraLog.Logger, gcp.ClientSettings) (SchedulePolicyRepositoryCloser, error)
+
+type MachineTypeRepositoryFactory func(context.Context, infraLog.Logger, gcp.ClientSettings) (MachineTypeRepositoryCloser, error)
+
+type AcceleratorTypeRepositoryFactory func(context.Context, infraLog.Logger, gcp.ClientSettings) (AcceleratorTypeRepositoryCloser, error)
+
+type DiskRepositoryFactory func(context.Context, infraLog.Logger, gcp.ClientSettings) (DiskRepositoryCloser, error)
+
+type SnapshotRepositoryFactory func(context.Context, infraLog.Logger, gcp.ClientSettings) (SnapshotRepositoryCloser, error)
+
+type MachineImageRepositoryFactory func(context.Context, infraLog.Logger, gcp.ClientSettings) (MachineImageRepositoryCloser, error)
+
+type SerialPortRepositoryFactory func(context.Context, infraLog.Logger, gcp.ClientSettings) (SerialPortRepositoryCloser, error)
 
+type LogRepositoryFactory func(context.Context, infraLog.Logger, gcp.ClientSettings) (LogRepositoryCloser, error)
+
+type MetricsRepositoryFactory func(context.Context, infraLog.Logger, gcp.ClientSettings) (MetricsRepositoryCloser, error)
+
+type RegionRepositoryFactory func(context.Context, infraLog.Logger, gcp.ClientSettings) (RegionRepositoryCloser, error)
+
+type ProjectRepositoryFactory func(context.Context, infraLog.Logger, gcp.ClientSettings) (ProjectRepositoryCloser, error)
+
+type NotifierFactory func(*config.Config) (repository.Notifier, error)
+
+type OperationHistoryFactory func(*config.Config) (repository.OperationHistory, error)
+
+type AuditLogFactory func(*config.Config) (repository.AuditLog, error)
+
+// notifyTimeout bounds notification delivery. It is independent of the command context
+// so failures can still be reported after the command was interrupted.
+const notifyTimeout = 10 * time.Second
+
+// operationEventBuffer is how many operation events the repositories can emit
+// before they wait for the session to apply them.
+const operationEventBuffer = 64
+
+// Options configures NewSessionWithOptions. Nil factories default to the GCP
+// implementations. VMProviders creates the VM repositories of providers other
+// than GCP, keyed by the name VMs set with provider: in the config. LockDir
+// keeps the per-VM locks, vmlock.DefaultDir() when empty.
 type Options struct {
-	LoadConfig      ConfigLoader
-	NewVMRepository VMRepositoryFactory
-	Logger          infraLog.Logger
+	LoadConfig                   ConfigLoader
+	NewVMRepository              VMRepositoryFactory
+	VMProviders                  map[string]VMRepositoryFactory
+	NewOperationRepository       OperationRepositoryFactory
+	NewSchedulePolicyRepository  SchedulePolicyRepositoryFactory
+	NewMachineTypeRepository     MachineTypeRepositoryFactory
+	NewAcceleratorTypeRepository AcceleratorTypeRepositoryFactory
+	NewDiskRepository            DiskRepositoryFactory
+	NewSnapshotRepository        SnapshotRepositoryFactory
+	NewMachineImageRepository    MachineImageRepositoryFactory
+	NewSerialPortRepository      SerialPortRepositoryFactory
+	NewLogRepository             LogRepositoryFactory
+	NewMetricsRepository         MetricsRepositoryFactory
+	NewRegionRepository          RegionRepositoryFactory
+	NewProjectRepository         ProjectRepositoryFactory
+	NewNotifier                  NotifierFactory
+	NewOperationHistory          OperationHistoryFactory
+	NewAuditLog                  AuditLogFactory
+	Logger                       infraLog.Logger
+	LockDir                      string
 }
 
 type Session struct {
-	Config       *config.Config
-	VMRepository repository.VMRepository
+	Config                    *config.Config
+	VMRepository              repository.VMRepository
+	OperationRepository       repository.OperationRepository
+	SchedulePolicyRepository  repository.SchedulePolicyRepository
+	MachineTypeRepository     repository.MachineTypeRepository
+	AcceleratorTypeRepository repository.AcceleratorTypeRepository
+	DiskRepository            repository.DiskRepository
+	SnapshotRepository        repository.SnapshotRepository
+	MachineImageRepository    repository.MachineImageRepository
+	SerialPortRepository      repository.SerialPortRepository
+	LogRepository             repository.LogRepository
+	MetricsRepository         repository.MetricsRepository
+	RegionRepository          repository.RegionRepository
+	ProjectRepository         repository.ProjectRepository
 
-	stop            context.CancelFunc
-	closeRepo       func() error
-	newVMRepository VMRepositoryFactory
-	logger          infraLog.Logger
+	stop                         context.CancelFunc
+	closeRepo                    func() error
+	closeOperationRepo           func() error
+	closeSchedulePolicyRepo      func() error
+	closeMachineTypeRepo         func() error
+	closeAcceleratorTypeRepo     func() error
+	closeDiskRepo                func() error
+	closeSnapshotRepo            func() error
+	closeMachineImageRepo        func() error
+	closeSerialPortRepo          func() error
+	closeLogRepo                 func() error
+	closeMetricsRepo             func() error
+	closeRegionRepo              func() error
+	closeProjectRepo             func() error
+	newVMRepository              VMRepositoryFactory
+	vmProviders                  map[string]VMRepositoryFactory
+	newOperationRepository       OperationRepositoryFactory
+	newSchedulePolicyRepository  SchedulePolicyRepositoryFactory
+	newMachineTypeRepository     MachineTypeRepositoryFactory
+	newAcceleratorTypeRepository AcceleratorTypeRepositoryFactory
+	newDiskRepository            DiskRepositoryFactory
+	newSnapshotRepository        SnapshotRepositoryFactory
+	newMachineImageRepository    MachineImageRepositoryFactory
+	newSerialPortRepository      SerialPortRepositoryFactory
+	newLogRepository             LogRepositoryFactory
+	newMetricsRepository         MetricsRepositoryFactory
+	newRegionRepository          RegionRepositoryFactory
+	newProjectRepository         ProjectRepositoryFactory
+	newNotifier                  NotifierFactory
+	newOperationHistory          OperationHistoryFactory
+	newAuditLog                  AuditLogFactory
+	events                       chan model.OperationEvent
+	tracked                      chan struct{}
+	recorder                     *operationRecorder
+	locker                       *vmlock.Locker
+	clientSettings               gcp.ClientSettings
+	configPath                   string
+	lockDir                      string
+	logger                       infraLog.Logger
 }
 
 func NewSession(cmd *cobra.Command, configPath string) (*Session, context.Context, error) {
 	return NewSessionWithOptions(cmd, configPath, Options{
-		LoadConfig: config.NewConfig,
-		NewVMRepository: func(ctx context.Context, logger infraLog.Logger) (VMRepositoryCloser, error) {
-			return gcp.NewVMRepository(ctx, logger)
+		LoadConfig: LoadConfig,
+		NewVMRepository: func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (VMRepositoryCloser, error) {
+			return gcp.NewVMRepository(ctx, logger, settings)
+		},
+		NewOperationRepository: func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (OperationRepositoryCloser, error) {
+			return gcp.NewOperationRepository(ctx, logger, settings)
+		},
+		NewSchedulePolicyRepository: func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (SchedulePolicyRepositoryCloser, error) {
+			return gcp.NewSchedulePolicyRepository(ctx, logger, settings)
 		},
-		Logger: infraLog.DefaultLogger,
+		NewMachineTypeRepository: func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (MachineTypeRepositoryCloser, error) {
+			return gcp.NewMachineTypeRepository(ctx, logger, settings)
+		},
+		NewAcceleratorTypeRepository: func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (AcceleratorTypeRepositoryCloser, error) {
+			return gcp.NewAcceleratorTypeRepository(ctx, logger, settings)
+		},
+		NewDiskRepository: func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (DiskRepositoryCloser, error) {
+			return gcp.NewDiskRepository(ctx, logger, settings)
+		},
+		NewSnapshotRepository: func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (SnapshotRepositoryCloser, error) {
+			return gcp.NewSnapshotRepository(ctx, logger, settings)
+		},
+		NewMachineImageRepository: func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (MachineImageRepositoryCloser, error) {
+			return gcp.NewMachineImageRepository(ctx, logger, settings)
+		},
+		NewSerialPortRepository: func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (SerialPortRepositoryCloser, error) {
+			return gcp.NewSerialPortRepository(ctx, logger, settings)
+		},
+		NewLogRepository: func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (LogRepositoryCloser, error) {
+			return gcp.NewLogRepository(ctx, logger, settings)
+		},
+		NewMetricsRepository: func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (MetricsRepositoryCloser, error) {
+			return gcp.NewMetricsRepository(ctx, logger, settings)
+		},
+		NewRegionRepository: func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (RegionRepositoryCloser, error) {
+			return gcp.NewRegionRepository(ctx, logger, settings)
+		},
+		NewProjectRepository: func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (ProjectRepositoryCloser, error) {
+			return gcp.NewProjectRepository(ctx, logger, settings)
+		},
+		NewNotifier: newConfiguredNotifier,
+		Logger:      infraLog.DefaultLogger,
 	})
 }
 
+func newConfiguredNotifier(cfg *config.Config) (repository.Notifier, error) {
+	return notifier.NewRouter(cfg.NotificationBackends, cfg.NotificationRoutes)
+}
+
+// newConfiguredOperationHistory opens the operation-history file of cfg, or the default one.
+func newConfiguredOperationHistory(cfg *config.Config) (repository.OperationHistory, error) {
+	return OpenOperationHistory(cfg)
+}
+
+// OpenOperationHistory opens the operation history the config records to:
+// operation-history when it is set, ~/.local/state/gcectl/operations.log otherwise.
+func OpenOperationHistory(cfg *config.Config) (*oplog.File, error) {
+	if cfg != nil && cfg.OperationHistory != "" {
+		return oplog.NewFile(cfg.OperationHistory), nil
+	}
+	path, err := oplog.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return oplog.NewFile(path), nil
+}
+
 func NewSessionWithOptions(cmd *cobra.Command, configPath string, opts Options) (*Session, context.Context, error) {
 	if cmd == nil {
 		return nil, nil, errors.New("cmd is required")
@@ -60,33 +296,247 @@ func NewSessionWithOptions(cmd *cobra.Command, configPath string, opts Options)
 		opts.LoadConfig = config.NewConfig
 	}
 	if opts.NewVMRepository == nil {
-		opts.NewVMRepository = func(ctx context.Context, logger infraLog.Logger) (VMRepositoryCloser, error) {
-			return gcp.NewVMRepository(ctx, logger)
+		opts.NewVMRepository = func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (VMRepositoryCloser, error) {
+			return gcp.NewVMRepository(ctx, logger, settings)
+		}
+	}
+	if opts.NewOperationRepository == nil {
+		opts.NewOperationRepository = func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (OperationRepositoryCloser, error) {
+			return gcp.NewOperationRepository(ctx, logger, settings)
+		}
+	}
+	if opts.NewSchedulePolicyRepository == nil {
+		opts.NewSchedulePolicyRepository = func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (SchedulePolicyRepositoryCloser, error) {
+			return gcp.NewSchedulePolicyRepository(ctx, logger, settings)
+		}
+	}
+	if opts.NewMachineTypeRepository == nil {
+		opts.NewMachineTypeRepository = func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (MachineTypeRepositoryCloser, error) {
+			return gcp.NewMachineTypeRepository(ctx, logger, settings)
+		}
+	}
+	if opts.NewAcceleratorTypeRepository == nil {
+		opts.NewAcceleratorTypeRepository = func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (AcceleratorTypeRepositoryCloser, error) {
+			return gcp.NewAcceleratorTypeRepository(ctx, logger, settings)
+		}
+	}
+	if opts.NewDiskRepository == nil {
+		opts.NewDiskRepository = func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (DiskRepositoryCloser, error) {
+			return gcp.NewDiskRepository(ctx, logger, settings)
+		}
+	}
+	if opts.NewSnapshotRepository == nil {
+		opts.NewSnapshotRepository = func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (SnapshotRepositoryCloser, error) {
+			return gcp.NewSnapshotRepository(ctx, logger, settings)
+		}
+	}
+	if opts.NewMachineImageRepository == nil {
+		opts.NewMachineImageRepository = func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (MachineImageRepositoryCloser, error) {
+			return gcp.NewMachineImageRepository(ctx, logger, settings)
 		}
 	}
+	if opts.NewSerialPortRepository == nil {
+		opts.NewSerialPortRepository = func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (SerialPortRepositoryCloser, error) {
+			return gcp.NewSerialPortRepository(ctx, logger, settings)
+		}
+	}
+	if opts.NewLogRepository == nil {
+		opts.NewLogRepository = func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (LogRepositoryCloser, error) {
+			return gcp.NewLogRepository(ctx, logger, settings)
+		}
+	}
+	if opts.NewMetricsRepository == nil {
+		opts.NewMetricsRepository = func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (MetricsRepositoryCloser, error) {
+			return gcp.NewMetricsRepository(ctx, logger, settings)
+		}
+	}
+	if opts.NewRegionRepository == nil {
+		opts.NewRegionRepository = func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (RegionRepositoryCloser, error) {
+			return gcp.NewRegionRepository(ctx, logger, settings)
+		}
+	}
+	if opts.NewProjectRepository == nil {
+		opts.NewProjectRepository = func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (ProjectRepositoryCloser, error) {
+			return gcp.NewProjectRepository(ctx, logger, settings)
+		}
+	}
+	if opts.NewNotifier == nil {
+		opts.NewNotifier = newConfiguredNotifier
+	}
+	if opts.NewOperationHistory == nil {
+		opts.NewOperationHistory = newConfiguredOperationHistory
+	}
+	if opts.NewAuditLog == nil {
+		opts.NewAuditLog = newConfiguredAuditLog
+	}
 	if opts.Logger == nil {
 		opts.Logger = infraLog.DefaultLogger
 	}
 
 	cfg, err := opts.LoadConfig(configPath)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, &ConfigError{Err: err}
+	}
+	if flagErr := applyMaxConcurrencyFlag(cmd, cfg); flagErr != nil {
+		return nil, nil, &ConfigError{Err: flagErr}
+	}
+	settings, err := ClientSettings(cmd, cfg)
+	if err != nil {
+		return nil, nil, &ConfigError{Err: err}
 	}
+	tracker := newOperationTracker()
+	events := make(chan model.OperationEvent, operationEventBuffer)
+	settings.OperationEvents = events
 
 	parentCtx := cmd.Context()
 	if parentCtx == nil {
 		parentCtx = context.Background()
 	}
-	ctx, stop := signal.NotifyContext(parentCtx, os.Interrupt, syscall.SIGTERM)
+	ctx, stop := watchInterrupts(parentCtx, tracker)
+	ctx = presenter.WithOperationProgress(ctx, tracker)
+	recorder := newOperationRecorder(commandLine(), localUser())
+	tracked := make(chan struct{})
+	go func() {
+		defer close(tracked)
+		tracker.track(events, ctx.Done(), recorder.apply)
+	}()
 
 	return &Session{
-		Config:          cfg,
-		stop:            stop,
-		newVMRepository: opts.NewVMRepository,
-		logger:          opts.Logger,
+		Config:                       cfg,
+		stop:                         stop,
+		newVMRepository:              opts.NewVMRepository,
+		vmProviders:                  opts.VMProviders,
+		newOperationRepository:       opts.NewOperationRepository,
+		newSchedulePolicyRepository:  opts.NewSchedulePolicyRepository,
+		newMachineTypeRepository:     opts.NewMachineTypeRepository,
+		newAcceleratorTypeRepository: opts.NewAcceleratorTypeRepository,
+		newDiskRepository:            opts.NewDiskRepository,
+		newSnapshotRepository:        opts.NewSnapshotRepository,
+		newMachineImageRepository:    opts.NewMachineImageRepository,
+		newSerialPortRepository:      opts.NewSerialPortRepository,
+		newLogRepository:             opts.NewLogRepository,
+		newMetricsRepository:         opts.NewMetricsRepository,
+		newRegionRepository:          opts.NewRegionRepository,
+		newProjectRepository:         opts.NewProjectRepository,
+		newNotifier:                  opts.NewNotifier,
+		newOperationHistory:          opts.NewOperationHistory,
+		newAuditLog:                  opts.NewAuditLog,
+		events:                       events,
+		tracked:                      tracked,
+		recorder:                     recorder,
+		clientSettings:               settings,
+		configPath:                   configPath,
+		lockDir:                      opts.LockDir,
+		logger:                       opts.Logger,
 	}, ctx, nil
 }
 
+// applyMaxConcurrencyFlag overrides the configured max-concurrency when the
+// --max-concurrency flag was set explicitly.
+func applyMaxConcurrencyFlag(cmd *cobra.Command, cfg *config.Config) error {
+	flag := cmd.Flags().Lookup("max-concurrency")
+	if flag == nil || !flag.Changed || cfg == nil {
+		return nil
+	}
+	n, err := cmd.Flags().GetInt("max-concurrency")
+	if err != nil {
+		return err
+	}
+	if n <= 0 {
+		return fmt.Errorf("--max-concurrency must be positive: %d", n)
+	}
+	cfg.MaxConcurrency = n
+	return nil
+}
+
+// ClientSettings returns how the GCP clients authenticate and connect according to cfg,
+// with the --credentials-file, --endpoint, --timeout and --operation-timeout flags
+// taking precedence over the config when set. --trace and --trace-bodies make the clients
+// log their requests through the default logger, and --record and --replay record them
+// to or answer them from a cassette. cfg may be nil when the config could not be loaded.
+func ClientSettings(cmd *cobra.Command, cfg *config.Config) (gcp.ClientSettings, error) {
+	var settings gcp.ClientSettings
+	if cfg != nil {
+		settings.CredentialsFile = cfg.CredentialsFile
+		settings.ComputeEndpoint = cfg.ComputeEndpoint
+		settings.ProxyURL = cfg.Proxy
+	}
+	if flag := cmd.Flags().Lookup("credentials-file"); flag != nil && flag.Changed {
+		path, err := cmd.Flags().GetString("credentials-file")
+		if err != nil {
+			return gcp.ClientSettings{}, err
+		}
+		settings.CredentialsFile = path
+	}
+	if flag := cmd.Flags().Lookup("endpoint"); flag != nil && flag.Changed {
+		endpoint, err := cmd.Flags().GetString("endpoint")
+		if err != nil {
+			return gcp.ClientSettings{}, err
+		}
+		if u, err := url.Parse(endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+			return gcp.ClientSettings{}, fmt.Errorf("--endpoint must be an absolute URL such as http://localhost:8080: %q", endpoint)
+		}
+		settings.ComputeEndpoint = endpoint
+	}
+	if err := applyDurationFlag(cmd, "timeout", &settings.Timeouts.Call); err != nil {
+		return gcp.ClientSettings{}, err
+	}
+	if err := applyDurationFlag(cmd, "operation-timeout", &settings.Timeouts.Operation); err != nil {
+		return gcp.ClientSettings{}, err
+	}
+	trace, err := boolFlag(cmd, "trace")
+	if err != nil {
+		return gcp.ClientSettings{}, err
+	}
+	settings.TraceBodies, err = boolFlag(cmd, "trace-bodies")
+	if err != nil {
+		return gcp.ClientSettings{}, err
+	}
+	if trace || settings.TraceBodies {
+		settings.Tracer = infraLog.DefaultLogger
+	}
+	if settings.Cassette, err = cassetteFlags(cmd); err != nil {
+		return gcp.ClientSettings{}, err
+	}
+	return settings, nil
+}
+
+// cassetteFlags returns the cassette --record or --replay name, or nil when neither is set.
+func cassetteFlags(cmd *cobra.Command) (*gcp.Cassette, error) {
+	if flag := cmd.Flags().Lookup("replay"); flag != nil && flag.Changed {
+		return gcp.LoadCassette(flag.Value.String())
+	}
+	if flag := cmd.Flags().Lookup("record"); flag != nil && flag.Changed {
+		return gcp.NewRecordingCassette(flag.Value.String()), nil
+	}
+	return nil, nil
+}
+
+// boolFlag returns the value of the bool flag name, or false when cmd has no such flag.
+func boolFlag(cmd *cobra.Command, name string) (bool, error) {
+	if cmd.Flags().Lookup(name) == nil {
+		return false, nil
+	}
+	return cmd.Flags().GetBool(name)
+}
+
+// applyDurationFlag stores the duration flag name in dst when it was set explicitly.
+func applyDurationFlag(cmd *cobra.Command, name string, dst *time.Duration) error {
+	flag := cmd.Flags().Lookup(name)
+	if flag == nil || !flag.Changed {
+		return nil
+	}
+	d, err := cmd.Flags().GetDuration(name)
+	if err != nil {
+		return err
+	}
+	if d < 0 {
+		return fmt.Errorf("--%s must not be negative: %s", name, d)
+	}
+	*dst = d
+	return nil
+}
+
 func (s *Session) OpenVMRepository(ctx context.Context) error {
 	if s == nil {
 		return errors.New("session is nil")
@@ -94,15 +544,359 @@ func (s *Session) OpenVMRepository(ctx context.Context) error {
 	if s.VMRepository != nil || s.closeRepo != nil {
 		return nil
 	}
-	repo, err := s.newVMRepository(ctx, s.logger)
+	repo, err := s.newVMRepository(ctx, s.logger, s.clientSettings)
 	if err != nil {
 		return fmt.Errorf("failed to create VM repository: %w", err)
 	}
-	s.VMRepository = repo
-	s.closeRepo = repo.Close
+	var vmRepo repository.VMRepository = repo
+	closeRepo := repo.Close
+	if s.Config != nil && slices.ContainsFunc(s.Config.Providers(), func(p string) bool { return p != model.ProviderGCP }) {
+		vmRepo, closeRepo, err = s.routeVMProviders(ctx, repo)
+		if err != nil {
+			return err
+		}
+	}
+	s.VMRepository = vmRepo
+	if s.Config != nil && s.Config.Retry.Enabled() {
+		s.VMRepository = retry.NewVMRepository(vmRepo, s.Config.Retry, s.logger)
+	}
+	if s.Config != nil && s.Config.LockVMs {
+		s.lockVMs()
+	}
+	s.closeRepo = closeRepo
+	s.locateVMs(ctx)
 	return nil
 }
 
+// lockVMs makes the changes of the VM repository hold the lock of the VM, so
+// that other gcectl processes do not change it at the same time. Without a
+// lock directory the VMs are not locked.
+func (s *Session) lockVMs() {
+	dir := s.lockDir
+	if dir == "" {
+		var err error
+		if dir, err = vmlock.DefaultDir(); err != nil {
+			s.logger.Warnf("VMs are not locked against concurrent changes: %v", err)
+			return
+		}
+	}
+	s.locker = vmlock.NewLocker(dir, commandLine(), localUser())
+	s.VMRepository = vmlock.NewVMRepository(s.VMRepository, s.locker)
+}
+
+// LockVM takes the lock the VM repository holds while it changes vm, for a
+// change spanning several calls, such as a shutdown from inside the guest. The
+// lock is reentrant, so the repository calls made meanwhile still go through.
+// Without VM locking it does nothing.
+func (s *Session) LockVM(vm *model.VM) (func(), error) {
+	if s.locker == nil {
+		return func() {}, nil
+	}
+	return s.locker.Lock(vm)
+}
+
+// Detached reports whether the user detached from the pending operations, which
+// keep running on GCP although the command stopped waiting for them.
+func (s *Session) Detached() bool {
+	return detached.Load()
+}
+
+// EmitOperationEvent reports an operation performed without the API, e.g. a
+// shutdown from inside the guest, like the events of the repositories, so it
+// is tracked and recorded in the operation history and the audit log.
+func (s *Session) EmitOperationEvent(ev model.OperationEvent) {
+	select {
+	case s.events <- ev:
+	case <-s.tracked:
+		// The tracking stopped with the command context; record it directly.
+		if recorder := s.recorder; recorder != nil {
+			recorder.apply(ev)
+		}
+	}
+}
+
+// routeVMProviders opens the VM repositories of the providers the configured VMs
+// use and returns a repository routing each VM to its provider's, with gcpRepo
+// serving GCP. On error every repository opened, gcpRepo included, is closed.
+func (s *Session) routeVMProviders(ctx context.Context, gcpRepo VMRepositoryCloser) (repository.VMRepository, func() error, error) {
+	backends := map[string]repository.VMRepository{model.ProviderGCP: gcpRepo}
+	closers := []func() error{gcpRepo.Close}
+	closeAll := func() error {
+		var errs []error
+		for _, closeFn := range closers {
+			errs = append(errs, closeFn())
+		}
+		return errors.Join(errs...)
+	}
+	for _, name := range s.Config.Providers() {
+		if name == model.ProviderGCP {
+			continue
+		}
+		factory, ok := s.vmProviders[name]
+		if !ok {
+			_ = closeAll()
+			supported := append([]string{model.ProviderGCP}, slices.Sorted(maps.Keys(s.vmProviders))...)
+			return nil, nil, &ConfigError{Err: fmt.Errorf("provider %q is not supported, use one of %s", name, strings.Join(supported, ", "))}
+		}
+		repo, err := factory(ctx, s.logger, s.clientSettings)
+		if err != nil {
+			_ = closeAll()
+			return nil, nil, fmt.Errorf("failed to create VM repository for provider %s: %w", name, err)
+		}
+		backends[name] = repo
+		closers = append(closers, repo.Close)
+	}
+	return provider.NewVMRepository(backends), closeAll, nil
+}
+
+// sharedConfig is the remote config the config file is a local copy of, or
+// empty when it is a local file. See UseSharedConfig.
+var sharedConfig string
+
+// UseSharedConfig marks the config file of every session as the local copy of
+// the remote config raw, which the next refresh replaces, so that sessions do
+// not write to it.
+func UseSharedConfig(raw string) {
+	sharedConfig = raw
+}
+
+// locateVMs looks up the zones of the configured VMs without one, and writes
+// them to the config file when persist-zones is set, unless it is a shared
+// config. A failed lookup is only logged, so that commands not acting on the VM
+// still work.
+func (s *Session) locateVMs(ctx context.Context) {
+	if s.Config == nil {
+		return
+	}
+	located, err := usecase.NewLocateVMsUseCase(s.VMRepository).Execute(ctx, s.Config.VMs)
+	if err != nil {
+		s.logger.Warnf("%v", err)
+	}
+	persist := s.Config.PersistZones
+	if persist && sharedConfig != "" && len(located) > 0 {
+		s.logger.Warnf("Not saving the zones of the VMs: %s is a shared config; set them at its source", sharedConfig)
+		persist = false
+	}
+	for _, vm := range located {
+		s.logger.Debugf("Found %s in zone %s", vm.QualifiedName(), vm.Zone)
+		if !persist {
+			continue
+		}
+		if persistErr := config.SetVMZone(s.configPath, vm); persistErr != nil {
+			s.logger.Warnf("Failed to save the zone of %s: %v", vm.QualifiedName(), persistErr)
+		}
+	}
+}
+
+func (s *Session) OpenOperationRepository(ctx context.Context) error {
+	if s == nil {
+		return errors.New("session is nil")
+	}
+	if s.OperationRepository != nil || s.closeOperationRepo != nil {
+		return nil
+	}
+	repo, err := s.newOperationRepository(ctx, s.logger, s.clientSettings)
+	if err != nil {
+		return fmt.Errorf("failed to create operation repository: %w", err)
+	}
+	s.OperationRepository = repo
+	s.closeOperationRepo = repo.Close
+	return nil
+}
+
+func (s *Session) OpenSchedulePolicyRepository(ctx context.Context) error {
+	if s == nil {
+		return errors.New("session is nil")
+	}
+	if s.SchedulePolicyRepository != nil || s.closeSchedulePolicyRepo != nil {
+		return nil
+	}
+	repo, err := s.newSchedulePolicyRepository(ctx, s.logger, s.clientSettings)
+	if err != nil {
+		return fmt.Errorf("failed to create schedule policy repository: %w", err)
+	}
+	s.SchedulePolicyRepository = repo
+	s.closeSchedulePolicyRepo = repo.Close
+	return nil
+}
+
+func (s *Session) OpenMachineTypeRepository(ctx context.Context) error {
+	if s == nil {
+		return errors.New("session is nil")
+	}
+	if s.MachineTypeRepository != nil || s.closeMachineTypeRepo != nil {
+		return nil
+	}
+	repo, err := s.newMachineTypeRepository(ctx, s.logger, s.clientSettings)
+	if err != nil {
+		return fmt.Errorf("failed to create machine type repository: %w", err)
+	}
+	s.MachineTypeRepository = repo
+	s.closeMachineTypeRepo = repo.Close
+	return nil
+}
+
+func (s *Session) OpenAcceleratorTypeRepository(ctx context.Context) error {
+	if s == nil {
+		return errors.New("session is nil")
+	}
+	if s.AcceleratorTypeRepository != nil || s.closeAcceleratorTypeRepo != nil {
+		return nil
+	}
+	repo, err := s.newAcceleratorTypeRepository(ctx, s.logger, s.clientSettings)
+	if err != nil {
+		return fmt.Errorf("failed to create accelerator type repository: %w", err)
+	}
+	s.AcceleratorTypeRepository = repo
+	s.closeAcceleratorTypeRepo = repo.Close
+	return nil
+}
+
+func (s *Session) OpenDiskRepository(ctx context.Context) error {
+	if s == nil {
+		return errors.New("session is nil")
+	}
+	if s.DiskRepository != nil || s.closeDiskRepo != nil {
+		return nil
+	}
+	repo, err := s.newDiskRepository(ctx, s.logger, s.clientSettings)
+	if err != nil {
+		return fmt.Errorf("failed to create disk repository: %w", err)
+	}
+	s.DiskRepository = repo
+	s.closeDiskRepo = repo.Close
+	return nil
+}
+
+func (s *Session) OpenSnapshotRepository(ctx context.Context) error {
+	if s == nil {
+		return errors.New("session is nil")
+	}
+	if s.SnapshotRepository != nil || s.closeSnapshotRepo != nil {
+		return nil
+	}
+	repo, err := s.newSnapshotRepository(ctx, s.logger, s.clientSettings)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot repository: %w", err)
+	}
+	s.SnapshotRepository = repo
+	s.closeSnapshotRepo = repo.Close
+	return nil
+}
+
+func (s *Session) OpenMachineImageRepository(ctx context.Context) error {
+	if s == nil {
+		return errors.New("session is nil")
+	}
+	if s.MachineImageRepository != nil || s.closeMachineImageRepo != nil {
+		return nil
+	}
+	repo, err := s.newMachineImageRepository(ctx, s.logger, s.clientSettings)
+	if err != nil {
+		return fmt.Errorf("failed to create machine image repository: %w", err)
+	}
+	s.MachineImageRepository = repo
+	s.closeMachineImageRepo = repo.Close
+	return nil
+}
+
+func (s *Session) OpenSerialPortRepository(ctx context.Context) error {
+	if s == nil {
+		return errors.New("session is nil")
+	}
+	if s.SerialPortRepository != nil || s.closeSerialPortRepo != nil {
+		return nil
+	}
+	repo, err := s.newSerialPortRepository(ctx, s.logger, s.clientSettings)
+	if err != nil {
+		return fmt.Errorf("failed to create serial port repository: %w", err)
+	}
+	s.SerialPortRepository = repo
+	s.closeSerialPortRepo = repo.Close
+	return nil
+}
+
+func (s *Session) OpenLogRepository(ctx context.Context) error {
+	if s == nil {
+		return errors.New("session is nil")
+	}
+	if s.LogRepository != nil || s.closeLogRepo != nil {
+		return nil
+	}
+	repo, err := s.newLogRepository(ctx, s.logger, s.clientSettings)
+	if err != nil {
+		return fmt.Errorf("failed to create log repository: %w", err)
+	}
+	s.LogRepository = repo
+	s.closeLogRepo = repo.Close
+	return nil
+}
+
+func (s *Session) OpenMetricsRepository(ctx context.Context) error {
+	if s == nil {
+		return errors.New("session is nil")
+	}
+	if s.MetricsRepository != nil || s.closeMetricsRepo != nil {
+		return nil
+	}
+	repo, err := s.newMetricsRepository(ctx, s.logger, s.clientSettings)
+	if err != nil {
+		return fmt.Errorf("failed to create metrics repository: %w", err)
+	}
+	s.MetricsRepository = repo
+	s.closeMetricsRepo = repo.Close
+	return nil
+}
+
+func (s *Session) OpenRegionRepository(ctx context.Context) error {
+	if s == nil {
+		return errors.New("session is nil")
+	}
+	if s.RegionRepository != nil || s.closeRegionRepo != nil {
+		return nil
+	}
+	repo, err := s.newRegionRepository(ctx, s.logger, s.clientSettings)
+	if err != nil {
+		return fmt.Errorf("failed to create region repository: %w", err)
+	}
+	s.RegionRepository = repo
+	s.closeRegionRepo = repo.Close
+	return nil
+}
+
+func (s *Session) OpenProjectRepository(ctx context.Context) error {
+	if s == nil {
+		return errors.New("session is nil")
+	}
+	if s.ProjectRepository != nil || s.closeProjectRepo != nil {
+		return nil
+	}
+	repo, err := s.newProjectRepository(ctx, s.logger, s.clientSettings)
+	if err != nil {
+		return fmt.Errorf("failed to create project repository: %w", err)
+	}
+	s.ProjectRepository = repo
+	s.closeProjectRepo = repo.Close
+	return nil
+}
+
+// Notify sends events to the notifier backends configured for their type.
+// Delivery failures are logged as warnings and never fail the command.
+func (s *Session) Notify(events ...model.Event) {
+	if s == nil || len(events) == 0 {
+		return
+	}
+	n, err := s.newNotifier(s.Config)
+	if err != nil {
+		s.logger.Warnf("Notifications disabled: %v", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+	_ = usecase.NewNotifyEventUseCase(n, s.logger).Execute(ctx, events...)
+}
+
 func (s *Session) Close() {
 	if s == nil {
 		return
@@ -111,8 +905,136 @@ func (s *Session) Close() {
 		_ = s.closeRepo()
 		s.closeRepo = nil
 	}
+	if s.closeOperationRepo != nil {
+		_ = s.closeOperationRepo()
+		s.closeOperationRepo = nil
+	}
+	if s.closeSchedulePolicyRepo != nil {
+		_ = s.closeSchedulePolicyRepo()
+		s.closeSchedulePolicyRepo = nil
+	}
+	if s.closeMachineTypeRepo != nil {
+		_ = s.closeMachineTypeRepo()
+		s.closeMachineTypeRepo = nil
+	}
+	if s.closeAcceleratorTypeRepo != nil {
+		_ = s.closeAcceleratorTypeRepo()
+		s.closeAcceleratorTypeRepo = nil
+	}
+	if s.closeDiskRepo != nil {
+		_ = s.closeDiskRepo()
+		s.closeDiskRepo = nil
+	}
+	if s.closeSnapshotRepo != nil {
+		_ = s.closeSnapshotRepo()
+		s.closeSnapshotRepo = nil
+	}
+	if s.closeMachineImageRepo != nil {
+		_ = s.closeMachineImageRepo()
+		s.closeMachineImageRepo = nil
+	}
+	if s.closeSerialPortRepo != nil {
+		_ = s.closeSerialPortRepo()
+		s.closeSerialPortRepo = nil
+	}
+	if s.closeLogRepo != nil {
+		_ = s.closeLogRepo()
+		s.closeLogRepo = nil
+	}
+	if s.closeMetricsRepo != nil {
+		_ = s.closeMetricsRepo()
+		s.closeMetricsRepo = nil
+	}
+	if s.closeRegionRepo != nil {
+		_ = s.closeRegionRepo()
+		s.closeRegionRepo = nil
+	}
+	if s.closeProjectRepo != nil {
+		_ = s.closeProjectRepo()
+		s.closeProjectRepo = nil
+	}
 	if s.stop != nil {
 		s.stop()
 		s.stop = nil
 	}
+	s.recordOperations()
+}
+
+// newConfiguredAuditLog opens the audit log configured in cfg.
+func newConfiguredAuditLog(cfg *config.Config) (repository.AuditLog, error) {
+	return OpenAuditLog(cfg, "")
+}
+
+// OpenAuditLog opens the audit log at path, or else at audit-log.path from the
+// config, or else at the default location, rotated as the config sets.
+func OpenAuditLog(cfg *config.Config, path string) (*audit.File, error) {
+	var settings config.AuditLog
+	if cfg != nil {
+		settings = cfg.AuditLog
+	}
+	if path == "" {
+		path = settings.Path
+	}
+	if path == "" {
+		var err error
+		if path, err = audit.DefaultPath(); err != nil {
+			return nil, err
+		}
+	}
+	f := audit.NewFile(path)
+	if settings.MaxSize != nil || settings.Backups != nil {
+		maxSize, backups := audit.DefaultMaxSize, -1
+		if settings.MaxSize != nil {
+			maxSize = *settings.MaxSize
+		}
+		if settings.Backups != nil {
+			backups = *settings.Backups
+		}
+		f.WithRotation(maxSize, backups)
+	}
+	return f, nil
+}
+
+// recordOperations appends the operations the command waited for to the
+// operation history and the audit log. Failing to record them is only logged.
+func (s *Session) recordOperations() {
+	if s.recorder == nil {
+		return
+	}
+	recorder := s.recorder
+	s.recorder = nil
+	// Apply the events still buffered when the tracking stopped.
+	<-s.tracked
+	for drained := false; !drained; {
+		select {
+		case ev := <-s.events:
+			recorder.apply(ev)
+		default:
+			drained = true
+		}
+	}
+
+	records := recorder.take()
+	if len(records) == 0 {
+		return
+	}
+	history, err := s.newOperationHistory(s.Config)
+	if err == nil {
+		err = history.Append(records...)
+	}
+	if err != nil {
+		s.logger.Warnf("Failed to record the operation history: %v", err)
+	}
+
+	entries := make([]*model.AuditEntry, 0, len(records))
+	for _, r := range records {
+		entries = append(entries, model.AuditEntryFromRecord(r))
+	}
+	auditLog, err := s.newAuditLog(s.Config)
+	if err == nil {
+		err = auditLog.Append(entries...)
+	}
+	if err != nil {
+		s.logger.Warnf("Failed to write the audit log: %v", err)
+	}
 }
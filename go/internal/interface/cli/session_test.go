@@ -3,11 +3,21 @@ package cli
 import (
 	"context"
 	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
 	"github.com/haru-256/gcectl/internal/infrastructure/config"
+	"github.com/haru-256/gcectl/internal/infrastructure/gcp"
 	infraLog "github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/infrastructure/retry"
+	"github.com/haru-256/gcectl/internal/infrastructure/vmlock"
 	mockCli "github.com/haru-256/gcectl/internal/mock/interface/cli"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -24,7 +34,7 @@ func TestNewSessionWithOptionsCreatesSession(t *testing.T) {
 			require.Equal(t, "config.yaml", path)
 			return &config.Config{}, nil
 		},
-		NewVMRepository: func(ctx context.Context, logger infraLog.Logger) (VMRepositoryCloser, error) {
+		NewVMRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (VMRepositoryCloser, error) {
 			t.Fatal("repository factory should not be called during NewSession")
 			return nil, nil
 		},
@@ -38,6 +48,170 @@ func TestNewSessionWithOptionsCreatesSession(t *testing.T) {
 	require.Nil(t, session.VMRepository)
 }
 
+func TestNewSessionWithOptionsAppliesMaxConcurrencyFlag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    int
+		wantErr bool
+	}{
+		{name: "flag not set keeps config", args: nil, want: 3},
+		{name: "flag overrides config", args: []string{"--max-concurrency=25"}, want: 25},
+		{name: "non-positive flag is rejected", args: []string{"--max-concurrency=0"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := &cobra.Command{}
+			cmd.Flags().Int("max-concurrency", 0, "")
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			session, _, err := NewSessionWithOptions(cmd, "config.yaml", Options{
+				LoadConfig: func(string) (*config.Config, error) {
+					return &config.Config{MaxConcurrency: 3}, nil
+				},
+				Logger: infraLog.DefaultLogger,
+			})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			defer session.Close()
+			require.Equal(t, tt.want, session.Config.MaxConcurrency)
+		})
+	}
+}
+
+func TestClientSettings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cfg  *config.Config
+		args []string
+		want string
+	}{
+		{name: "no credentials uses ADC", cfg: &config.Config{}, want: ""},
+		{name: "config credentials", cfg: &config.Config{CredentialsFile: "/keys/config.json"}, want: "/keys/config.json"},
+		{name: "flag overrides config", cfg: &config.Config{CredentialsFile: "/keys/config.json"}, args: []string{"--credentials-file=/keys/flag.json"}, want: "/keys/flag.json"},
+		{name: "flag without config", cfg: nil, args: []string{"--credentials-file=/keys/flag.json"}, want: "/keys/flag.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := &cobra.Command{}
+			cmd.Flags().String("credentials-file", "", "")
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			settings, err := ClientSettings(cmd, tt.cfg)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, settings.CredentialsFile)
+		})
+	}
+}
+
+func TestClientSettingsCopiesEndpointAndProxy(t *testing.T) {
+	t.Parallel()
+
+	proxy := &url.URL{Scheme: "http", Host: "proxy.example.com:3128"}
+	settings, err := ClientSettings(&cobra.Command{}, &config.Config{
+		ComputeEndpoint: "https://compute-private.p.googleapis.com",
+		Proxy:           proxy,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "https://compute-private.p.googleapis.com", settings.ComputeEndpoint)
+	require.Same(t, proxy, settings.ProxyURL)
+}
+
+func TestClientSettingsEndpointFlag(t *testing.T) {
+	t.Parallel()
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("endpoint", "", "")
+	require.NoError(t, cmd.ParseFlags([]string{"--endpoint=http://localhost:8080"}))
+	settings, err := ClientSettings(cmd, &config.Config{ComputeEndpoint: "https://compute-private.p.googleapis.com"})
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:8080", settings.ComputeEndpoint)
+
+	cmd = &cobra.Command{}
+	cmd.Flags().String("endpoint", "", "")
+	require.NoError(t, cmd.ParseFlags([]string{"--endpoint=localhost:8080"}))
+	_, err = ClientSettings(cmd, &config.Config{})
+	require.Error(t, err)
+}
+
+func TestClientSettingsTimeouts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    gcp.Timeouts
+		wantErr bool
+	}{
+		{name: "no flags means no limits", args: nil, want: gcp.Timeouts{}},
+		{name: "both flags", args: []string{"--timeout=30s", "--operation-timeout=10m"}, want: gcp.Timeouts{Call: 30 * time.Second, Operation: 10 * time.Minute}},
+		{name: "negative timeout is rejected", args: []string{"--timeout=-1s"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := &cobra.Command{}
+			cmd.Flags().Duration("timeout", 0, "")
+			cmd.Flags().Duration("operation-timeout", 0, "")
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			settings, err := ClientSettings(cmd, &config.Config{})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, settings.Timeouts)
+		})
+	}
+}
+
+func TestClientSettingsTrace(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantTracer bool
+		wantBodies bool
+	}{
+		{name: "no flags means no tracing", args: nil},
+		{name: "trace", args: []string{"--trace"}, wantTracer: true},
+		{name: "trace bodies implies trace", args: []string{"--trace-bodies"}, wantTracer: true, wantBodies: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := &cobra.Command{}
+			cmd.Flags().Bool("trace", false, "")
+			cmd.Flags().Bool("trace-bodies", false, "")
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			settings, err := ClientSettings(cmd, &config.Config{})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantTracer, settings.Tracer != nil)
+			require.Equal(t, tt.wantBodies, settings.TraceBodies)
+		})
+	}
+}
+
 func TestNewSessionWithOptionsReturnsConfigError(t *testing.T) {
 	t.Parallel()
 
@@ -47,7 +221,7 @@ func TestNewSessionWithOptionsReturnsConfigError(t *testing.T) {
 		LoadConfig: func(path string) (*config.Config, error) {
 			return nil, expectedErr
 		},
-		NewVMRepository: func(ctx context.Context, logger infraLog.Logger) (VMRepositoryCloser, error) {
+		NewVMRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (VMRepositoryCloser, error) {
 			t.Fatal("repository factory should not be called when config loading fails")
 			return nil, nil
 		},
@@ -55,6 +229,7 @@ func TestNewSessionWithOptionsReturnsConfigError(t *testing.T) {
 	})
 
 	require.ErrorIs(t, err, expectedErr)
+	require.Equal(t, ExitConfig, ExitCode(err))
 	require.Nil(t, session)
 	require.Nil(t, ctx)
 }
@@ -66,7 +241,7 @@ func TestNewSessionWithOptionsReturnsErrorForNilCmd(t *testing.T) {
 		LoadConfig: func(path string) (*config.Config, error) {
 			return &config.Config{}, nil
 		},
-		NewVMRepository: func(ctx context.Context, logger infraLog.Logger) (VMRepositoryCloser, error) {
+		NewVMRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (VMRepositoryCloser, error) {
 			t.Fatal("repository factory should not be called when cmd is nil")
 			return nil, nil
 		},
@@ -92,7 +267,7 @@ func TestNewSessionWithOptionsHandlesNilCommandContext(t *testing.T) {
 		LoadConfig: func(path string) (*config.Config, error) {
 			return &config.Config{}, nil
 		},
-		NewVMRepository: func(ctx context.Context, logger infraLog.Logger) (VMRepositoryCloser, error) {
+		NewVMRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (VMRepositoryCloser, error) {
 			require.NotNil(t, ctx)
 			return repo, nil
 		},
@@ -123,7 +298,7 @@ func TestNewSessionWithOptionsFallsBackToDefaultOptions(t *testing.T) {
 		LoadConfig: func(path string) (*config.Config, error) {
 			return &config.Config{}, nil
 		},
-		NewVMRepository: func(ctx context.Context, logger infraLog.Logger) (VMRepositoryCloser, error) {
+		NewVMRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (VMRepositoryCloser, error) {
 			require.NotNil(t, ctx)
 			require.NotNil(t, logger)
 			return repo, nil
@@ -140,6 +315,65 @@ func TestNewSessionWithOptionsFallsBackToDefaultOptions(t *testing.T) {
 	session.Close()
 }
 
+func TestOpenVMRepositoryRoutesVMProviders(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	gce := mockCli.NewMockVMRepositoryCloser(ctrl)
+	ec2 := mockCli.NewMockVMRepositoryCloser(ctrl)
+	builder := &model.VM{Name: "builder", Project: "ci-account", Zone: "us-east-1a", Provider: "aws"}
+	ec2.EXPECT().Stop(gomock.Any(), builder).Return(nil)
+	gce.EXPECT().Close().Return(nil)
+	ec2.EXPECT().Close().Return(nil)
+
+	factory := func(repo VMRepositoryCloser) VMRepositoryFactory {
+		return func(context.Context, infraLog.Logger, gcp.ClientSettings) (VMRepositoryCloser, error) {
+			return repo, nil
+		}
+	}
+	session, ctx, err := NewSessionWithOptions(&cobra.Command{}, "config.yaml", Options{
+		LoadConfig: func(path string) (*config.Config, error) {
+			return &config.Config{VMs: []*model.VM{{Name: "sandbox", Project: "p", Zone: "z"}, builder}}, nil
+		},
+		NewVMRepository: factory(gce),
+		VMProviders:     map[string]VMRepositoryFactory{"aws": factory(ec2)},
+		Logger:          infraLog.DefaultLogger,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, session.OpenVMRepository(ctx))
+	require.NoError(t, session.VMRepository.Stop(ctx, builder))
+	session.Close()
+}
+
+func TestOpenVMRepositoryRejectsUnsupportedProvider(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	gce := mockCli.NewMockVMRepositoryCloser(ctrl)
+	gce.EXPECT().Close().Return(nil)
+
+	session, ctx, err := NewSessionWithOptions(&cobra.Command{}, "config.yaml", Options{
+		LoadConfig: func(path string) (*config.Config, error) {
+			return &config.Config{VMs: []*model.VM{{Name: "hv1", Project: "lab", Zone: "rack1", Provider: "libvirt"}}}, nil
+		},
+		NewVMRepository: func(context.Context, infraLog.Logger, gcp.ClientSettings) (VMRepositoryCloser, error) {
+			return gce, nil
+		},
+		Logger: infraLog.DefaultLogger,
+	})
+	require.NoError(t, err)
+
+	err = session.OpenVMRepository(ctx)
+	require.Error(t, err)
+	require.Equal(t, ExitConfig, ExitCode(err))
+	require.Contains(t, err.Error(), `provider "libvirt" is not supported, use one of gcp`)
+	require.Nil(t, session.VMRepository)
+	session.Close()
+}
+
 func TestOpenVMRepositoryCreatesAndStoresRepository(t *testing.T) {
 	t.Parallel()
 
@@ -155,7 +389,7 @@ func TestOpenVMRepositoryCreatesAndStoresRepository(t *testing.T) {
 		LoadConfig: func(path string) (*config.Config, error) {
 			return &config.Config{}, nil
 		},
-		NewVMRepository: func(ctx context.Context, logger infraLog.Logger) (VMRepositoryCloser, error) {
+		NewVMRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (VMRepositoryCloser, error) {
 			require.NotNil(t, ctx)
 			require.NotNil(t, logger)
 			return repo, nil
@@ -174,6 +408,73 @@ func TestOpenVMRepositoryCreatesAndStoresRepository(t *testing.T) {
 	session.Close()
 }
 
+func TestOpenVMRepositoryLocatesVMsWithoutZone(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mockCli.NewMockVMRepositoryCloser(ctrl)
+	repo.EXPECT().ListByProject(gomock.Any(), "p", gomock.Nil()).Return([]*model.VM{{Name: "web", Project: "p", Zone: "asia-northeast1-b"}}, nil)
+	repo.EXPECT().Close().Return(nil)
+
+	confPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(confPath, []byte("default-project: p\npersist-zones: true\nvm:\n  - name: web\n    zone: auto\n"), 0o600))
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	session, ctx, err := NewSessionWithOptions(cmd, confPath, Options{
+		NewVMRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (VMRepositoryCloser, error) {
+			return repo, nil
+		},
+		Logger: infraLog.DefaultLogger,
+	})
+	require.NoError(t, err)
+	require.Empty(t, session.Config.VMs[0].Zone)
+
+	require.NoError(t, session.OpenVMRepository(ctx))
+	require.Equal(t, "asia-northeast1-b", session.Config.VMs[0].Zone)
+
+	reloaded, err := config.NewConfig(confPath)
+	require.NoError(t, err)
+	require.Equal(t, "asia-northeast1-b", reloaded.VMs[0].Zone, "persist-zones should save the zone")
+
+	session.Close()
+}
+
+func TestOpenVMRepositoryKeepsSharedConfig(t *testing.T) {
+	UseSharedConfig("gs://team-bucket/gcectl.yaml")
+	t.Cleanup(func() { UseSharedConfig("") })
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mockCli.NewMockVMRepositoryCloser(ctrl)
+	repo.EXPECT().ListByProject(gomock.Any(), "p", gomock.Nil()).Return([]*model.VM{{Name: "web", Project: "p", Zone: "asia-northeast1-b"}}, nil)
+	repo.EXPECT().Close().Return(nil)
+
+	confPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := []byte("default-project: p\npersist-zones: true\nvm:\n  - name: web\n    zone: auto\n")
+	require.NoError(t, os.WriteFile(confPath, content, 0o600))
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	session, ctx, err := NewSessionWithOptions(cmd, confPath, Options{
+		NewVMRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (VMRepositoryCloser, error) {
+			return repo, nil
+		},
+		Logger: infraLog.DefaultLogger,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, session.OpenVMRepository(ctx))
+	require.Equal(t, "asia-northeast1-b", session.Config.VMs[0].Zone)
+
+	saved, err := os.ReadFile(confPath)
+	require.NoError(t, err)
+	require.Equal(t, content, saved, "the local copy of a shared config should not be written")
+
+	session.Close()
+}
+
 func TestOpenVMRepositoryReturnsWrappedError(t *testing.T) {
 	t.Parallel()
 
@@ -185,7 +486,7 @@ func TestOpenVMRepositoryReturnsWrappedError(t *testing.T) {
 		LoadConfig: func(path string) (*config.Config, error) {
 			return &config.Config{}, nil
 		},
-		NewVMRepository: func(ctx context.Context, logger infraLog.Logger) (VMRepositoryCloser, error) {
+		NewVMRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (VMRepositoryCloser, error) {
 			require.NotNil(t, ctx)
 			return nil, expectedErr
 		},
@@ -217,7 +518,7 @@ func TestOpenVMRepositoryIsIdempotent(t *testing.T) {
 		LoadConfig: func(path string) (*config.Config, error) {
 			return &config.Config{}, nil
 		},
-		NewVMRepository: func(ctx context.Context, logger infraLog.Logger) (VMRepositoryCloser, error) {
+		NewVMRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (VMRepositoryCloser, error) {
 			callCount++
 			return repo, nil
 		},
@@ -255,7 +556,7 @@ func TestSessionCloseIsIdempotent(t *testing.T) {
 		LoadConfig: func(path string) (*config.Config, error) {
 			return &config.Config{}, nil
 		},
-		NewVMRepository: func(ctx context.Context, logger infraLog.Logger) (VMRepositoryCloser, error) {
+		NewVMRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (VMRepositoryCloser, error) {
 			return repo, nil
 		},
 		Logger: infraLog.DefaultLogger,
@@ -282,3 +583,672 @@ func TestSessionCloseIsNilSafe(t *testing.T) {
 		session.Close()
 	})
 }
+
+func TestOpenOperationRepositoryCreatesAndClosesRepository(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mockCli.NewMockOperationRepositoryCloser(ctrl)
+	repo.EXPECT().Close().Return(nil).Times(1)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	callCount := 0
+	session, ctx, err := NewSessionWithOptions(cmd, "config.yaml", Options{
+		LoadConfig: func(path string) (*config.Config, error) {
+			return &config.Config{}, nil
+		},
+		NewVMRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (VMRepositoryCloser, error) {
+			t.Fatal("VM repository factory should not be called")
+			return nil, nil
+		},
+		NewOperationRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (OperationRepositoryCloser, error) {
+			callCount++
+			return repo, nil
+		},
+		Logger: infraLog.DefaultLogger,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, session.OpenOperationRepository(ctx))
+	require.NoError(t, session.OpenOperationRepository(ctx))
+	require.Same(t, repo, session.OperationRepository)
+	require.Equal(t, 1, callCount)
+
+	session.Close()
+	session.Close()
+}
+
+func TestOpenOperationRepositoryReturnsWrappedError(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("repository failed")
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	session, ctx, err := NewSessionWithOptions(cmd, "config.yaml", Options{
+		LoadConfig: func(path string) (*config.Config, error) {
+			return &config.Config{}, nil
+		},
+		NewOperationRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (OperationRepositoryCloser, error) {
+			return nil, expectedErr
+		},
+		Logger: infraLog.DefaultLogger,
+	})
+	require.NoError(t, err)
+	defer session.Close()
+
+	err = session.OpenOperationRepository(ctx)
+	require.ErrorIs(t, err, expectedErr)
+	require.ErrorContains(t, err, "failed to create operation repository")
+}
+
+func TestOpenSchedulePolicyRepositoryCreatesAndClosesRepository(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mockCli.NewMockSchedulePolicyRepositoryCloser(ctrl)
+	repo.EXPECT().Close().Return(nil).Times(1)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	callCount := 0
+	session, ctx, err := NewSessionWithOptions(cmd, "config.yaml", Options{
+		LoadConfig: func(path string) (*config.Config, error) {
+			return &config.Config{}, nil
+		},
+		NewSchedulePolicyRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (SchedulePolicyRepositoryCloser, error) {
+			callCount++
+			return repo, nil
+		},
+		Logger: infraLog.DefaultLogger,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, session.OpenSchedulePolicyRepository(ctx))
+	require.NoError(t, session.OpenSchedulePolicyRepository(ctx))
+	require.Same(t, repo, session.SchedulePolicyRepository)
+	require.Equal(t, 1, callCount)
+
+	session.Close()
+	session.Close()
+}
+
+func TestOpenMachineTypeRepositoryCreatesAndClosesRepository(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mockCli.NewMockMachineTypeRepositoryCloser(ctrl)
+	repo.EXPECT().Close().Return(nil).Times(1)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	callCount := 0
+	session, ctx, err := NewSessionWithOptions(cmd, "config.yaml", Options{
+		LoadConfig: func(path string) (*config.Config, error) {
+			return &config.Config{}, nil
+		},
+		NewMachineTypeRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (MachineTypeRepositoryCloser, error) {
+			callCount++
+			return repo, nil
+		},
+		Logger: infraLog.DefaultLogger,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, session.OpenMachineTypeRepository(ctx))
+	require.NoError(t, session.OpenMachineTypeRepository(ctx))
+	require.Same(t, repo, session.MachineTypeRepository)
+	require.Equal(t, 1, callCount)
+
+	session.Close()
+	session.Close()
+}
+
+func TestOpenAcceleratorTypeRepositoryCreatesAndClosesRepository(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mockCli.NewMockAcceleratorTypeRepositoryCloser(ctrl)
+	repo.EXPECT().Close().Return(nil).Times(1)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	callCount := 0
+	session, ctx, err := NewSessionWithOptions(cmd, "config.yaml", Options{
+		LoadConfig: func(path string) (*config.Config, error) {
+			return &config.Config{}, nil
+		},
+		NewAcceleratorTypeRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (AcceleratorTypeRepositoryCloser, error) {
+			callCount++
+			return repo, nil
+		},
+		Logger: infraLog.DefaultLogger,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, session.OpenAcceleratorTypeRepository(ctx))
+	require.NoError(t, session.OpenAcceleratorTypeRepository(ctx))
+	require.Same(t, repo, session.AcceleratorTypeRepository)
+	require.Equal(t, 1, callCount)
+
+	session.Close()
+	session.Close()
+}
+
+func TestOpenDiskRepositoryCreatesAndClosesRepository(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mockCli.NewMockDiskRepositoryCloser(ctrl)
+	repo.EXPECT().Close().Return(nil).Times(1)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	callCount := 0
+	session, ctx, err := NewSessionWithOptions(cmd, "config.yaml", Options{
+		LoadConfig: func(path string) (*config.Config, error) {
+			return &config.Config{}, nil
+		},
+		NewDiskRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (DiskRepositoryCloser, error) {
+			callCount++
+			return repo, nil
+		},
+		Logger: infraLog.DefaultLogger,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, session.OpenDiskRepository(ctx))
+	require.NoError(t, session.OpenDiskRepository(ctx))
+	require.Same(t, repo, session.DiskRepository)
+	require.Equal(t, 1, callCount)
+
+	session.Close()
+	session.Close()
+}
+
+func TestOpenSnapshotRepositoryCreatesAndClosesRepository(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mockCli.NewMockSnapshotRepositoryCloser(ctrl)
+	repo.EXPECT().Close().Return(nil).Times(1)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	callCount := 0
+	session, ctx, err := NewSessionWithOptions(cmd, "config.yaml", Options{
+		LoadConfig: func(path string) (*config.Config, error) {
+			return &config.Config{}, nil
+		},
+		NewSnapshotRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (SnapshotRepositoryCloser, error) {
+			callCount++
+			return repo, nil
+		},
+		Logger: infraLog.DefaultLogger,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, session.OpenSnapshotRepository(ctx))
+	require.NoError(t, session.OpenSnapshotRepository(ctx))
+	require.Same(t, repo, session.SnapshotRepository)
+	require.Equal(t, 1, callCount)
+
+	session.Close()
+	session.Close()
+}
+
+func TestOpenMachineImageRepositoryCreatesAndClosesRepository(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mockCli.NewMockMachineImageRepositoryCloser(ctrl)
+	repo.EXPECT().Close().Return(nil).Times(1)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	callCount := 0
+	session, ctx, err := NewSessionWithOptions(cmd, "config.yaml", Options{
+		LoadConfig: func(path string) (*config.Config, error) {
+			return &config.Config{}, nil
+		},
+		NewMachineImageRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (MachineImageRepositoryCloser, error) {
+			callCount++
+			return repo, nil
+		},
+		Logger: infraLog.DefaultLogger,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, session.OpenMachineImageRepository(ctx))
+	require.NoError(t, session.OpenMachineImageRepository(ctx))
+	require.Same(t, repo, session.MachineImageRepository)
+	require.Equal(t, 1, callCount)
+
+	session.Close()
+	session.Close()
+}
+
+func TestOpenSerialPortRepositoryCreatesAndClosesRepository(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mockCli.NewMockSerialPortRepositoryCloser(ctrl)
+	repo.EXPECT().Close().Return(nil).Times(1)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	callCount := 0
+	session, ctx, err := NewSessionWithOptions(cmd, "config.yaml", Options{
+		LoadConfig: func(path string) (*config.Config, error) {
+			return &config.Config{}, nil
+		},
+		NewSerialPortRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (SerialPortRepositoryCloser, error) {
+			callCount++
+			return repo, nil
+		},
+		Logger: infraLog.DefaultLogger,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, session.OpenSerialPortRepository(ctx))
+	require.NoError(t, session.OpenSerialPortRepository(ctx))
+	require.Same(t, repo, session.SerialPortRepository)
+	require.Equal(t, 1, callCount)
+
+	session.Close()
+	session.Close()
+}
+
+func TestOpenLogRepositoryCreatesAndClosesRepository(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mockCli.NewMockLogRepositoryCloser(ctrl)
+	repo.EXPECT().Close().Return(nil).Times(1)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	callCount := 0
+	session, ctx, err := NewSessionWithOptions(cmd, "config.yaml", Options{
+		LoadConfig: func(path string) (*config.Config, error) {
+			return &config.Config{}, nil
+		},
+		NewLogRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (LogRepositoryCloser, error) {
+			callCount++
+			return repo, nil
+		},
+		Logger: infraLog.DefaultLogger,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, session.OpenLogRepository(ctx))
+	require.NoError(t, session.OpenLogRepository(ctx))
+	require.Same(t, repo, session.LogRepository)
+	require.Equal(t, 1, callCount)
+
+	session.Close()
+	session.Close()
+}
+
+func TestOpenMetricsRepositoryCreatesAndClosesRepository(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mockCli.NewMockMetricsRepositoryCloser(ctrl)
+	repo.EXPECT().Close().Return(nil).Times(1)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	callCount := 0
+	session, ctx, err := NewSessionWithOptions(cmd, "config.yaml", Options{
+		LoadConfig: func(path string) (*config.Config, error) {
+			return &config.Config{}, nil
+		},
+		NewMetricsRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (MetricsRepositoryCloser, error) {
+			callCount++
+			return repo, nil
+		},
+		Logger: infraLog.DefaultLogger,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, session.OpenMetricsRepository(ctx))
+	require.NoError(t, session.OpenMetricsRepository(ctx))
+	require.Same(t, repo, session.MetricsRepository)
+	require.Equal(t, 1, callCount)
+
+	session.Close()
+	session.Close()
+}
+
+func TestOpenRegionRepositoryCreatesAndClosesRepository(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mockCli.NewMockRegionRepositoryCloser(ctrl)
+	repo.EXPECT().Close().Return(nil).Times(1)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	callCount := 0
+	session, ctx, err := NewSessionWithOptions(cmd, "config.yaml", Options{
+		LoadConfig: func(path string) (*config.Config, error) {
+			return &config.Config{}, nil
+		},
+		NewRegionRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (RegionRepositoryCloser, error) {
+			callCount++
+			return repo, nil
+		},
+		Logger: infraLog.DefaultLogger,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, session.OpenRegionRepository(ctx))
+	require.NoError(t, session.OpenRegionRepository(ctx))
+	require.Same(t, repo, session.RegionRepository)
+	require.Equal(t, 1, callCount)
+
+	session.Close()
+	session.Close()
+}
+
+func TestOpenProjectRepositoryCreatesAndClosesRepository(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mockCli.NewMockProjectRepositoryCloser(ctrl)
+	repo.EXPECT().Close().Return(nil).Times(1)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	callCount := 0
+	session, ctx, err := NewSessionWithOptions(cmd, "config.yaml", Options{
+		LoadConfig: func(path string) (*config.Config, error) {
+			return &config.Config{}, nil
+		},
+		NewProjectRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (ProjectRepositoryCloser, error) {
+			callCount++
+			return repo, nil
+		},
+		Logger: infraLog.DefaultLogger,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, session.OpenProjectRepository(ctx))
+	require.NoError(t, session.OpenProjectRepository(ctx))
+	require.Same(t, repo, session.ProjectRepository)
+	require.Equal(t, 1, callCount)
+
+	session.Close()
+	session.Close()
+}
+
+func TestSessionNotifySendsEventsToConfiguredNotifier(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	n := mock_repository.NewMockNotifier(ctrl)
+	n.EXPECT().Notify(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+
+	cfg := &config.Config{}
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	session, _, err := NewSessionWithOptions(cmd, "config.yaml", Options{
+		LoadConfig: func(path string) (*config.Config, error) {
+			return cfg, nil
+		},
+		NewNotifier: func(c *config.Config) (repository.Notifier, error) {
+			require.Same(t, cfg, c)
+			return n, nil
+		},
+		Logger: infraLog.DefaultLogger,
+	})
+	require.NoError(t, err)
+	defer session.Close()
+
+	session.Notify(
+		model.Event{Type: model.EventOperationSuccess, VMName: "vm1"},
+		model.Event{Type: model.EventOperationSuccess, VMName: "vm2"},
+	)
+}
+
+func TestSessionNotifyIgnoresNotifierFactoryError(t *testing.T) {
+	t.Parallel()
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	session, _, err := NewSessionWithOptions(cmd, "config.yaml", Options{
+		LoadConfig: func(path string) (*config.Config, error) {
+			return &config.Config{}, nil
+		},
+		NewNotifier: func(c *config.Config) (repository.Notifier, error) {
+			return nil, errors.New("bad backend")
+		},
+		Logger: infraLog.DefaultLogger,
+	})
+	require.NoError(t, err)
+	defer session.Close()
+
+	require.NotPanics(t, func() {
+		session.Notify(model.Event{Type: model.EventOperationFailure})
+	})
+}
+
+func TestOpenVMRepositoryWrapsRepositoryWithRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mockCli.NewMockVMRepositoryCloser(ctrl)
+	repo.EXPECT().Close().Return(nil)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	session, ctx, err := NewSessionWithOptions(cmd, "config.yaml", Options{
+		LoadConfig: func(path string) (*config.Config, error) {
+			return &config.Config{Retry: retry.DefaultPolicy()}, nil
+		},
+		NewVMRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (VMRepositoryCloser, error) {
+			return repo, nil
+		},
+		Logger: infraLog.DefaultLogger,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, session.OpenVMRepository(ctx))
+	require.IsType(t, &retry.VMRepository{}, session.VMRepository)
+
+	session.Close()
+}
+
+func TestOpenVMRepositoryLocksVMs(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mockCli.NewMockVMRepositoryCloser(ctrl)
+	repo.EXPECT().Close().Return(nil)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	session, ctx, err := NewSessionWithOptions(cmd, "config.yaml", Options{
+		LoadConfig: func(path string) (*config.Config, error) {
+			return &config.Config{Retry: retry.DefaultPolicy(), LockVMs: true}, nil
+		},
+		NewVMRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (VMRepositoryCloser, error) {
+			return repo, nil
+		},
+		Logger:  infraLog.DefaultLogger,
+		LockDir: t.TempDir(),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, session.OpenVMRepository(ctx))
+	require.IsType(t, &vmlock.VMRepository{}, session.VMRepository, "the lock is taken outside the retries")
+
+	session.Close()
+}
+
+func TestSessionCloseRecordsOperations(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mockCli.NewMockVMRepositoryCloser(ctrl)
+	repo.EXPECT().Close().Return(nil)
+	history := mock_repository.NewMockOperationHistory(ctrl)
+	auditLog := mock_repository.NewMockAuditLog(ctrl)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	var events chan<- model.OperationEvent
+	session, ctx, err := NewSessionWithOptions(cmd, "config.yaml", Options{
+		LoadConfig: func(path string) (*config.Config, error) {
+			return &config.Config{}, nil
+		},
+		NewVMRepository: func(ctx context.Context, logger infraLog.Logger, settings gcp.ClientSettings) (VMRepositoryCloser, error) {
+			events = settings.OperationEvents
+			return repo, nil
+		},
+		NewOperationHistory: func(*config.Config) (repository.OperationHistory, error) {
+			return history, nil
+		},
+		NewAuditLog: func(*config.Config) (repository.AuditLog, error) {
+			return auditLog, nil
+		},
+		Logger: infraLog.DefaultLogger,
+	})
+	require.NoError(t, err)
+	require.NoError(t, session.OpenVMRepository(ctx))
+
+	inserted := time.Date(2025, 1, 2, 19, 0, 0, 0, time.UTC)
+	ended := inserted.Add(30 * time.Second)
+	op := &model.Operation{Name: "operation-1", Project: "p", Zone: "z", Type: "stop", Target: "vm1", User: "alice@example.com", InsertTime: &inserted, EndTime: &ended}
+	events <- model.OperationStarted{Op: op}
+	events <- model.OperationDone{Op: op}
+
+	history.EXPECT().Append(gomock.Any()).DoAndReturn(func(records ...*model.OperationRecord) error {
+		require.Len(t, records, 1)
+		require.Equal(t, inserted, records[0].Time)
+		require.Equal(t, 30*time.Second, records[0].Duration)
+		require.Equal(t, "vm1", records[0].Target)
+		require.Equal(t, "stop", records[0].Type)
+		require.Equal(t, "alice@example.com", records[0].User)
+		require.True(t, records[0].Succeeded())
+		return nil
+	})
+	auditLog.EXPECT().Append(gomock.Any()).DoAndReturn(func(entries ...*model.AuditEntry) error {
+		require.Len(t, entries, 1)
+		require.Equal(t, model.AuditTaskCommand, entries[0].Task)
+		require.Equal(t, "projects/p/zones/z/operations/operation-1", entries[0].Operation)
+		return nil
+	})
+	session.Close()
+	session.Close()
+}
+
+func TestSessionGuestOperation(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := mockCli.NewMockVMRepositoryCloser(ctrl)
+	repo.EXPECT().Close().Return(nil)
+	history := mock_repository.NewMockOperationHistory(ctrl)
+	auditLog := mock_repository.NewMockAuditLog(ctrl)
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	lockDir := t.TempDir()
+	session, ctx, err := NewSessionWithOptions(cmd, "config.yaml", Options{
+		LoadConfig: func(path string) (*config.Config, error) {
+			return &config.Config{LockVMs: true}, nil
+		},
+		NewVMRepository: func(ctx context.Context, logger infraLog.Logger, _ gcp.ClientSettings) (VMRepositoryCloser, error) {
+			return repo, nil
+		},
+		NewOperationHistory: func(*config.Config) (repository.OperationHistory, error) {
+			return history, nil
+		},
+		NewAuditLog: func(*config.Config) (repository.AuditLog, error) {
+			return auditLog, nil
+		},
+		Logger:  infraLog.DefaultLogger,
+		LockDir: lockDir,
+	})
+	require.NoError(t, err)
+	require.NoError(t, session.OpenVMRepository(ctx))
+
+	vm := &model.VM{Project: "p", Zone: "z", Name: "vm1"}
+	unlock, err := session.LockVM(vm)
+	require.NoError(t, err)
+	repo.EXPECT().Stop(gomock.Any(), vm).Return(nil)
+	require.NoError(t, session.VMRepository.Stop(ctx, vm), "the repository takes the lock held by the session again")
+	files, err := os.ReadDir(lockDir)
+	require.NoError(t, err)
+	require.Len(t, files, 1, "the lock is held until unlocked")
+	unlock()
+	files, err = os.ReadDir(lockDir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+
+	op := &model.Operation{Name: "guest-shutdown-1", Project: "p", Zone: "z", Type: "stop", Target: "vm1"}
+	session.EmitOperationEvent(model.OperationStarted{Op: op})
+	session.EmitOperationEvent(model.OperationDone{Op: op})
+	history.EXPECT().Append(gomock.Any()).DoAndReturn(func(records ...*model.OperationRecord) error {
+		require.Len(t, records, 1)
+		require.Equal(t, "guest-shutdown-1", records[0].Operation)
+		return nil
+	})
+	auditLog.EXPECT().Append(gomock.Any()).Return(nil)
+	session.Close()
+}
+
+func TestOpenAuditLog(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/state")
+
+	f, err := OpenAuditLog(nil, "")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join("/state", "gcectl", "audit.log"), f.Path())
+
+	cfg := &config.Config{AuditLog: config.AuditLog{Path: "/var/log/gcectl/audit.log"}}
+	f, err = OpenAuditLog(cfg, "")
+	require.NoError(t, err)
+	require.Equal(t, "/var/log/gcectl/audit.log", f.Path())
+
+	f, err = OpenAuditLog(cfg, "/tmp/audit.log")
+	require.NoError(t, err)
+	require.Equal(t, "/tmp/audit.log", f.Path(), "an explicit path wins over the config")
+}
@@ -1,9 +1,16 @@
 package presenter
 
 import (
+	"bufio"
 	"context"
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
@@ -20,165 +27,1734 @@ var (
 	headerStyle  = lipgloss.NewStyle().Foreground(purple).Bold(true).Align(lipgloss.Center).Padding(0, 1)
 	baseRowStyle = lipgloss.NewStyle().Padding(0, 1).Foreground(gray)
 	prefixStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#ff79c6"))
+
+	// uptimeStyles color the Uptime cell of long-running VMs.
+	uptimeStyles = map[model.UptimeLevel]lipgloss.Style{
+		model.UptimeWarning:  baseRowStyle.Foreground(lipgloss.Color("#f1fa8c")),
+		model.UptimeCritical: baseRowStyle.Foreground(lipgloss.Color("#ff5555")).Bold(true),
+	}
+
+	// diffRemovedStyle and diffAddedStyle color the current and desired lines of `gcectl diff`.
+	diffRemovedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#ff5555"))
+	diffAddedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#50fa7b"))
 )
 
+// vmListUptimeColumn is the index of the Uptime column of the VM table.
+const vmListUptimeColumn = 6
+
 // ConsolePresenter handles console output with styled messages.
 type ConsolePresenter struct {
+	input        *bufio.Reader
 	errorStyle   lipgloss.Style
 	successStyle lipgloss.Style
 }
 
-// NewConsolePresenter creates a new ConsolePresenter instance.
+// NewConsolePresenter creates a new ConsolePresenter instance.
+//
+// Returns:
+//   - *ConsolePresenter: A new presenter with predefined styles
+func NewConsolePresenter() *ConsolePresenter {
+	return &ConsolePresenter{
+		input:        bufio.NewReader(os.Stdin),
+		errorStyle:   lipgloss.NewStyle().Foreground(lipgloss.Color("#ff5555")).Bold(true),
+		successStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("#50fa7b")).Bold(true),
+	}
+}
+
+// Success prints a success message with green styling, unless quiet mode is set.
+//
+// Parameters:
+//   - msg: The success message to display
+func (p *ConsolePresenter) Success(msg string) {
+	if quietOutput.Load() {
+		return
+	}
+	fmt.Println(p.successStyle.Render("[SUCCESS] | ") + msg)
+}
+
+// Error prints an error message with red styling.
+//
+// Parameters:
+//   - msg: The error message to display
+func (p *ConsolePresenter) Error(msg string) {
+	fmt.Println(p.errorStyle.Render("[ERROR] | ") + msg)
+}
+
+// ErrorWithHint prints msg like Error, followed by how to fix err when it is a
+// failure with a known remedy such as missing permissions or an exhausted quota.
+//
+// Parameters:
+//   - msg: The error message to display
+//   - err: The error msg describes
+func (p *ConsolePresenter) ErrorWithHint(msg string, err error) {
+	p.Error(msg)
+	if hint := errorHint(err); hint != "" {
+		fmt.Println(prefixStyle.Render("[HINT] | ") + hint)
+	}
+}
+
+// errorHint returns the remediation for err, or "" when there is none.
+func errorHint(err error) string {
+	switch {
+	case errors.Is(err, model.ErrVMNotFoundInConfig):
+		return "add the VM under vm: in the config file, or check the configured names with 'gcectl list'"
+	case errors.Is(err, model.ErrQuotaExceeded):
+		return "request a higher quota at https://console.cloud.google.com/iam-admin/quotas, or use a smaller machine type, fewer GPUs or another region"
+	case errors.Is(err, model.ErrPermissionDenied):
+		return "grant the credentials the Compute Instance Admin (v1) role (roles/compute.instanceAdmin.v1); 'gcectl doctor' lists the missing permissions"
+	}
+	return ""
+}
+
+// progressStart prints a progress message without a newline.
+//
+// Parameters:
+//   - msg: The progress message to display
+func (p *ConsolePresenter) progressStart(msg string) {
+	fmt.Print(msg)
+}
+
+// progress prints a dot (.) without a newline for progress indication.
+func (p *ConsolePresenter) progress() {
+	fmt.Print(".")
+}
+
+// progressDone prints a newline to complete a progress line.
+func (p *ConsolePresenter) progressDone() {
+	fmt.Println()
+}
+
+// VMListItem represents a VM instance for display.
+//
+//nolint:govet // Field order optimized for readability
+type VMListItem struct {
+	Name           string
+	Project        string
+	Zone           string
+	MachineType    string
+	Status         model.Status
+	SchedulePolicy string
+	// ScheduleTimeZone is the time zone the schedule policy's cron expressions are evaluated in.
+	ScheduleTimeZone string
+	Uptime           string // Pre-calculated uptime (e.g., "7d12h45m", "2h30m", "5m30s", "N/A")
+	StoppedFor       string // Pre-calculated time since a stopped VM was stopped (e.g., "30d2h0m", "N/A")
+	NextSchedule     string // Pre-calculated next schedule trigger (e.g., "stops in 3h12m", "N/A")
+	// UptimeLevel colors the Uptime cell of RenderVMList when the VM runs past the uptime-warning threshold.
+	UptimeLevel model.UptimeLevel
+	// AutomaticRestart and OnHostMaintenance are the VM's scheduling options ("" when unknown).
+	AutomaticRestart  string
+	OnHostMaintenance string
+	// Maintenance is the formatted start of a scheduled host maintenance window ("" when none).
+	// RenderVMList marks the status of the VM when it is set.
+	Maintenance string
+	// Created and LastStopped are formatted timestamps, shown by RenderVMDetail only ("" when unknown).
+	Created     string
+	LastStopped string
+	// Labels are the VM's labels, shown by RenderVMDetail only.
+	Labels map[string]string
+	// Disks are the attached disks, shown as a section by RenderVMDetail only.
+	Disks []model.Disk
+	// NetworkInterfaces and ServiceAccount are shown as sections by RenderVMDetail only.
+	NetworkInterfaces []model.NetworkInterface
+	ServiceAccount    *model.ServiceAccount
+	// Loading marks a row whose details have not been fetched yet (progressive rendering).
+	Loading bool
+	// Failed marks a row whose details could not be fetched.
+	Failed bool
+}
+
+// VMDetail is an alias for VMListItem for code clarity.
+type VMDetail = VMListItem
+
+// getStatusEmoji returns an emoji for the given VM status.
+//
+// Parameters:
+//   - status: The VM status
+//
+// Returns:
+//   - string: 🟢 for RUNNING, 🔴 for STOPPED/TERMINATED, ⚪ for others,
+//     or [RUN], [STOP] and [----] in ASCII mode
+func getStatusEmoji(status model.Status) string {
+	switch status.String() {
+	case "RUNNING":
+		return marker("🟢", "[RUN]")
+	case "STOPPED", "TERMINATED":
+		return marker("🔴", "[STOP]")
+	default:
+		return marker("⚪", "[----]")
+	}
+}
+
+// RenderVMList renders VMs in a formatted table.
+//
+// Parameters:
+//   - items: VMs to display with pre-calculated uptime strings
+func (p *ConsolePresenter) RenderVMList(items []VMListItem) {
+	fmt.Println(renderVMListTable(items))
+}
+
+// renderVMListTable builds the VM table as a string. VMs of several projects
+// are shown as one table per project, in the order the projects first appear.
+func renderVMListTable(items []VMListItem) string {
+	var projects []string
+	byProject := make(map[string][]VMListItem)
+	for _, item := range items {
+		if _, ok := byProject[item.Project]; !ok {
+			projects = append(projects, item.Project)
+		}
+		byProject[item.Project] = append(byProject[item.Project], item)
+	}
+	if len(projects) <= 1 {
+		return renderVMListGroup(items)
+	}
+
+	groups := make([]string, len(projects))
+	for i, project := range projects {
+		groups[i] = fmt.Sprintf("%s %s\n%s", prefixStyle.Render("Project:"), project, renderVMListGroup(byProject[project]))
+	}
+	return strings.Join(groups, "\n")
+}
+
+// renderVMListGroup builds a single VM table as a string.
+func renderVMListGroup(items []VMListItem) string {
+	var rows [][]string
+
+	for _, item := range items {
+		rows = append(rows, vmListRow(item))
+	}
+
+	t := table.New().
+		Border(tableBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(purple)).
+		Headers("Name", "Project", "Zone", "Machine-Type", "Status", "Schedule", "Uptime", "Stopped For", "Next Schedule").
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			if style, ok := uptimeStyles[items[row].UptimeLevel]; ok && col == vmListUptimeColumn {
+				return style.Align(lipgloss.Left)
+			}
+			return baseRowStyle.Align(lipgloss.Left)
+		})
+
+	return t.String()
+}
+
+// vmListRow returns the table cells for a single VM.
+func vmListRow(item VMListItem) []string {
+	switch {
+	case item.Loading:
+		loading := marker("…", "...")
+		return []string{item.Name, item.Project, item.Zone, loading, marker("⏳", "[WAIT]") + " LOADING", loading, loading, loading, loading}
+	case item.Failed:
+		return []string{item.Name, item.Project, item.Zone, "-", marker("⚠️", "[FAIL]") + " ERROR", "-", "-", "-", "-"}
+	}
+	return []string{
+		item.Name,
+		item.Project,
+		item.Zone,
+		item.MachineType,
+		formatListStatus(item),
+		formatScheduleWithTimeZone(item.SchedulePolicy, item.ScheduleTimeZone),
+		item.Uptime,
+		item.StoppedFor,
+		item.NextSchedule,
+	}
+}
+
+// formatListStatus returns the status cell of a VM, marked when host maintenance is scheduled.
+func formatListStatus(item VMListItem) string {
+	status := getStatusEmoji(item.Status) + " " + item.Status.String()
+	if item.Maintenance != "" {
+		status += " " + marker("🔧", "[MAINT]")
+	}
+	return status
+}
+
+// vmListCSVHeaders are the columns of WriteVMListCSV.
+var vmListCSVHeaders = []string{"Name", "Project", "Zone", "Machine-Type", "Status", "Schedule", "Time-Zone", "Uptime", "Stopped-For", "Next-Schedule"}
+
+// WriteVMListCSV writes VMs as delimited text with a header row, one row per VM.
+// Unlike the table, the status has no marker and the schedule policy and its
+// time zone are separate columns, empty when the VM has none.
+//
+// Parameters:
+//   - w: Destination of the rows
+//   - items: VMs to write
+//   - comma: The field delimiter, ',' for CSV or '\t' for TSV
+//
+// Returns:
+//   - error: An error if writing fails
+func WriteVMListCSV(w io.Writer, items []VMListItem, comma rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	if err := cw.Write(vmListCSVHeaders); err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := cw.Write([]string{
+			item.Name,
+			item.Project,
+			item.Zone,
+			item.MachineType,
+			item.Status.String(),
+			item.SchedulePolicy,
+			item.ScheduleTimeZone,
+			item.Uptime,
+			item.StoppedFor,
+			item.NextSchedule,
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// LiveVMList is a VM table that is redrawn in place as rows are updated.
+// It must only be used when stdout is a terminal (see IsInteractive).
+type LiveVMList struct {
+	items []VMListItem
+	lines int
+	mu    sync.Mutex
+}
+
+// NewLiveVMList renders items immediately and returns a handle to update them.
+//
+// Parameters:
+//   - items: Initial rows, typically built from config with Loading set
+//
+// Returns:
+//   - *LiveVMList: A table that can be updated concurrently
+func (p *ConsolePresenter) NewLiveVMList(items []VMListItem) *LiveVMList {
+	l := &LiveVMList{items: append([]VMListItem(nil), items...)}
+	l.redraw()
+	return l
+}
+
+// Update replaces row i and redraws the table. It is safe for concurrent use.
+func (l *LiveVMList) Update(i int, item VMListItem) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if i < 0 || i >= len(l.items) {
+		return
+	}
+	l.items[i] = item
+	l.redraw()
+}
+
+// redraw moves the cursor back over the previous render and prints the table again.
+func (l *LiveVMList) redraw() {
+	if l.lines > 0 {
+		// Cursor up to the first line of the previous table, then clear to end of screen
+		fmt.Printf("\033[%dA\033[J", l.lines)
+	}
+	rendered := renderVMListTable(l.items)
+	fmt.Println(rendered)
+	l.lines = strings.Count(rendered, "\n") + 1
+}
+
+// IsInteractive reports whether stdout is a terminal, i.e. whether in-place
+// redrawing such as LiveVMList is possible.
+func (p *ConsolePresenter) IsInteractive() bool {
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// FleetSummary is the presenter representation of a fleet dashboard.
+//
+//nolint:govet // Field order optimized for readability
+type FleetSummary struct {
+	StatusCounts         map[model.Status]int
+	LongestRunning       []VMListItem
+	UnpricedMachineTypes []string
+	HourlyCost           float64
+	Total                int
+	UpdatedAt            time.Time
+}
+
+// fleetStatusOrder is the display order of statuses in the dashboard.
+var fleetStatusOrder = []model.Status{
+	model.StatusRunning,
+	model.StatusProvisioning,
+	model.StatusStopped,
+	model.StatusTerminated,
+	model.StatusUnknown,
+}
+
+// RenderFleetSummary renders a dashboard with a status heatmap, the estimated
+// hourly cost and the longest-running VMs.
+//
+// Parameters:
+//   - summary: The fleet summary to display
+func (p *ConsolePresenter) RenderFleetSummary(summary FleetSummary) {
+	fmt.Println(renderFleetSummary(summary))
+}
+
+// renderFleetSummary builds the dashboard as a string.
+func renderFleetSummary(summary FleetSummary) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s %d VMs (updated %s)\n\n", prefixStyle.Render("Fleet:"), summary.Total, summary.UpdatedAt.Format("15:04:05"))
+
+	// Heatmap: one cell per VM, grouped by status
+	var cells strings.Builder
+	var counts []string
+	for _, status := range fleetStatusOrder {
+		n := summary.StatusCounts[status]
+		if n == 0 {
+			continue
+		}
+		cells.WriteString(strings.Repeat(getStatusEmoji(status), n))
+		counts = append(counts, fmt.Sprintf("%s %d", status, n))
+	}
+	fmt.Fprintf(&b, "%s %s\n", cells.String(), strings.Join(counts, "  "))
+
+	cost := fmt.Sprintf("$%.2f/h", summary.HourlyCost)
+	if len(summary.UnpricedMachineTypes) > 0 {
+		cost += fmt.Sprintf(" (no price for: %s)", strings.Join(summary.UnpricedMachineTypes, ", "))
+	}
+	fmt.Fprintf(&b, "%s %s\n", prefixStyle.Render("Estimated cost:"), cost)
+
+	if len(summary.LongestRunning) > 0 {
+		var rows [][]string
+		for _, item := range summary.LongestRunning {
+			rows = append(rows, []string{item.Name, item.MachineType, item.Uptime})
+		}
+		t := table.New().
+			Border(tableBorder()).
+			BorderStyle(lipgloss.NewStyle().Foreground(purple)).
+			Headers("Longest running", "Machine-Type", "Uptime").
+			Rows(rows...).
+			StyleFunc(func(row, col int) lipgloss.Style {
+				if row == table.HeaderRow {
+					return headerStyle
+				}
+				return baseRowStyle.Align(lipgloss.Left)
+			})
+		b.WriteString("\n")
+		b.WriteString(t.String())
+	}
+
+	return b.String()
+}
+
+// VMMetricsRow is the presenter representation of one row of the top table.
+//
+//nolint:govet // Field order optimized for readability
+type VMMetricsRow struct {
+	Name        string
+	Zone        string
+	MachineType string
+	Metrics     *model.InstanceMetrics
+}
+
+// RenderTop renders the average resource usage of running VMs as a table.
+//
+// Parameters:
+//   - rows: The VMs to display, in display order
+//   - window: The averaging window shown in the title
+//   - updatedAt: When the metrics were fetched
+func (p *ConsolePresenter) RenderTop(rows []VMMetricsRow, window time.Duration, updatedAt time.Time) {
+	fmt.Println(renderTop(rows, window, updatedAt))
+}
+
+// renderTop builds the top table as a string.
+func renderTop(rows []VMMetricsRow, window time.Duration, updatedAt time.Time) string {
+	title := fmt.Sprintf("%s %d running VMs, %s average (updated %s)", prefixStyle.Render("Top:"), len(rows), window, updatedAt.Format("15:04:05"))
+	if len(rows) == 0 {
+		return title
+	}
+
+	cells := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		m := r.Metrics
+		if m == nil {
+			m = &model.InstanceMetrics{}
+		}
+		cells = append(cells, []string{
+			r.Name,
+			r.Zone,
+			r.MachineType,
+			formatPercent(m.CPUUtilization),
+			formatPercent(m.MemoryUtilization),
+			formatRate(m.DiskReadBytesPerSec),
+			formatRate(m.DiskWriteBytesPerSec),
+			formatRate(m.NetworkInBytesPerSec),
+			formatRate(m.NetworkOutBytesPerSec),
+		})
+	}
+
+	t := table.New().
+		Border(tableBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(purple)).
+		Headers("Name", "Zone", "Machine-Type", "CPU", "Memory", "Disk Read", "Disk Write", "Net In", "Net Out").
+		Rows(cells...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			if col >= 3 {
+				return baseRowStyle.Align(lipgloss.Right)
+			}
+			return baseRowStyle.Align(lipgloss.Left)
+		})
+
+	return fmt.Sprintf("%s\n%s", title, t.String())
+}
+
+// IdleSummary is the presenter representation of the idle VM report.
+//
+//nolint:govet // Field order optimized for readability
+type IdleSummary struct {
+	VMs                  []VMMetricsRow
+	UnpricedMachineTypes []string
+	HourlyCost           float64
+	Window               time.Duration
+}
+
+// RenderIdleVMs renders the idle VMs and what they cost per hour.
+//
+// Parameters:
+//   - summary: The idle VMs to display
+func (p *ConsolePresenter) RenderIdleVMs(summary IdleSummary) {
+	fmt.Println(renderIdleVMs(summary))
+}
+
+// renderIdleVMs builds the idle VM report as a string.
+func renderIdleVMs(summary IdleSummary) string {
+	if len(summary.VMs) == 0 {
+		return fmt.Sprintf("%s no idle VMs in the last %s", prefixStyle.Render("Idle:"), summary.Window)
+	}
+
+	cells := make([][]string, 0, len(summary.VMs))
+	for _, r := range summary.VMs {
+		cells = append(cells, []string{
+			r.Name,
+			r.Zone,
+			r.MachineType,
+			formatPercent(r.Metrics.CPUUtilization),
+			formatRate(r.Metrics.NetworkInBytesPerSec),
+			formatRate(r.Metrics.NetworkOutBytesPerSec),
+		})
+	}
+
+	t := table.New().
+		Border(tableBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(purple)).
+		Headers("Name", "Zone", "Machine-Type", "CPU", "Net In", "Net Out").
+		Rows(cells...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			if col >= 3 {
+				return baseRowStyle.Align(lipgloss.Right)
+			}
+			return baseRowStyle.Align(lipgloss.Left)
+		})
+
+	cost := fmt.Sprintf("$%.2f/h", summary.HourlyCost)
+	if len(summary.UnpricedMachineTypes) > 0 {
+		cost += fmt.Sprintf(" (no price for: %s)", strings.Join(summary.UnpricedMachineTypes, ", "))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %d VMs look idle over the last %s\n", prefixStyle.Render("Idle:"), len(summary.VMs), summary.Window)
+	b.WriteString(t.String())
+	fmt.Fprintf(&b, "\n%s %s", prefixStyle.Render("Estimated cost:"), cost)
+	return b.String()
+}
+
+// ReconcileSummary is the presenter representation of the differences between
+// the config file and the instances in GCP.
+type ReconcileSummary struct {
+	Projects  []string
+	Unmanaged []*model.VM
+	Stale     []*model.VM
+}
+
+// RenderReconcile renders the instances missing from the config file and the
+// config entries missing from GCP.
+//
+// Parameters:
+//   - summary: The differences to display
+func (p *ConsolePresenter) RenderReconcile(summary ReconcileSummary) {
+	fmt.Println(renderReconcile(summary))
+}
+
+// renderReconcile builds the reconcile report as a string.
+func renderReconcile(summary ReconcileSummary) string {
+	projects := strings.Join(summary.Projects, ", ")
+	if len(summary.Unmanaged) == 0 && len(summary.Stale) == 0 {
+		return fmt.Sprintf("%s the config file matches the instances in %s", prefixStyle.Render("Reconcile:"), projects)
+	}
+
+	var b strings.Builder
+	if len(summary.Unmanaged) > 0 {
+		fmt.Fprintf(&b, "%s %d instances in %s are not in the config file\n", prefixStyle.Render("Unmanaged:"), len(summary.Unmanaged), projects)
+		b.WriteString(renderReconcileTable(summary.Unmanaged, true))
+	}
+	if len(summary.Stale) > 0 {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s %d config entries have no instance\n", prefixStyle.Render("Stale:"), len(summary.Stale))
+		b.WriteString(renderReconcileTable(summary.Stale, false))
+	}
+	return b.String()
+}
+
+// renderReconcileTable builds a table of vms, with their status when it is known.
+func renderReconcileTable(vms []*model.VM, withStatus bool) string {
+	headers := []string{"Name", "Project", "Zone"}
+	if withStatus {
+		headers = append(headers, "Machine-Type", "Status")
+	}
+	rows := make([][]string, 0, len(vms))
+	for _, vm := range vms {
+		row := []string{vm.Name, vm.Project, vm.Zone}
+		if withStatus {
+			row = append(row, vm.MachineType, fmt.Sprintf("%s %s", getStatusEmoji(vm.Status), vm.Status))
+		}
+		rows = append(rows, row)
+	}
+
+	t := table.New().
+		Border(tableBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(purple)).
+		Headers(headers...).
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			return baseRowStyle.Align(lipgloss.Left)
+		})
+	return t.String()
+}
+
+// RenderApplyPlan renders the actions `gcectl apply` will run, one per line.
+//
+// Parameters:
+//   - actions: The planned actions, in the order they run
+func (p *ConsolePresenter) RenderApplyPlan(actions []model.ApplyAction) {
+	fmt.Println(renderApplyPlan(actions))
+}
+
+// applySymbols marks each action as in a Terraform plan.
+var applySymbols = map[model.ApplyActionType]string{
+	model.ApplyStart:             "+",
+	model.ApplyStop:              "-",
+	model.ApplySetMachineType:    "~",
+	model.ApplySetSchedulePolicy: "~",
+}
+
+// renderApplyPlan builds the apply plan as a string.
+func renderApplyPlan(actions []model.ApplyAction) string {
+	if len(actions) == 0 {
+		return fmt.Sprintf("%s no changes, all managed VMs match the config file", prefixStyle.Render("Plan:"))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %d changes", prefixStyle.Render("Plan:"), len(actions))
+	for _, a := range actions {
+		fmt.Fprintf(&b, "\n  %s %s: %s (%s -> %s)", applySymbols[a.Type], a.VM.Name, a.Type, a.From, a.To)
+	}
+	return b.String()
+}
+
+// RenderDrift renders how each VM differs from the config file as a diff of
+// current (-) and desired (+) values.
+//
+// Parameters:
+//   - drifts: One entry per compared VM, in display order
+func (p *ConsolePresenter) RenderDrift(drifts []model.VMDrift) {
+	fmt.Println(renderDrift(drifts))
+}
+
+// renderDrift builds the drift report as a string.
+func renderDrift(drifts []model.VMDrift) string {
+	drifted := 0
+	var b strings.Builder
+	for _, d := range drifts {
+		if len(d.Fields) == 0 {
+			fmt.Fprintf(&b, "  %s: in sync\n", d.VM.Name)
+			continue
+		}
+		drifted++
+		fmt.Fprintf(&b, "~ %s\n", prefixStyle.Render(d.VM.Name))
+		for _, f := range d.Fields {
+			b.WriteString(diffRemovedStyle.Render(fmt.Sprintf("    - %s: %s", f.Field, formatDriftValue(f.Current))) + "\n")
+			b.WriteString(diffAddedStyle.Render(fmt.Sprintf("    + %s: %s", f.Field, formatDriftValue(f.Desired))) + "\n")
+		}
+	}
+	if drifted == 0 {
+		fmt.Fprintf(&b, "%s all %d managed VMs match the config file", prefixStyle.Render("Diff:"), len(drifts))
+	} else {
+		fmt.Fprintf(&b, "%s %d of %d managed VMs differ from the config file", prefixStyle.Render("Diff:"), drifted, len(drifts))
+	}
+	return b.String()
+}
+
+// formatDriftValue shows an unset value as (none).
+func formatDriftValue(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
+
+// UsageRow is the presenter representation of one VM in the usage report.
+//
+//nolint:govet // Field order optimized for readability
+type UsageRow struct {
+	Name        string
+	Zone        string
+	MachineType string
+	Hours       float64
+	// Cost is nil when the machine type has no price.
+	Cost *float64
+}
+
+// UsageSummary is the presenter representation of the usage report.
+//
+//nolint:govet // Field order optimized for readability
+type UsageSummary struct {
+	From                 time.Time
+	To                   time.Time
+	Rows                 []UsageRow
+	UnpricedMachineTypes []string
+	TotalHours           float64
+	TotalCost            float64
+}
+
+// usageHeaders are the columns of the usage table and CSV.
+var usageHeaders = []string{"Name", "Zone", "Machine-Type", "Hours", "Cost"}
+
+// RenderUsageReport renders runtime hours and estimated cost per VM as a table.
+//
+// Parameters:
+//   - summary: The usage report to display
+func (p *ConsolePresenter) RenderUsageReport(summary UsageSummary) {
+	fmt.Println(renderUsageReport(summary))
+}
+
+// renderUsageReport builds the usage report as a string.
+func renderUsageReport(summary UsageSummary) string {
+	rows := make([][]string, 0, len(summary.Rows)+1)
+	for _, r := range summary.Rows {
+		rows = append(rows, usageRecord(r, "$"))
+	}
+
+	t := table.New().
+		Border(tableBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(purple)).
+		Headers(usageHeaders...).
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			if col >= 3 {
+				return baseRowStyle.Align(lipgloss.Right)
+			}
+			return baseRowStyle.Align(lipgloss.Left)
+		})
+
+	total := fmt.Sprintf("%.1f hours, $%.2f", summary.TotalHours, summary.TotalCost)
+	if len(summary.UnpricedMachineTypes) > 0 {
+		total += fmt.Sprintf(" (no price for: %s)", strings.Join(summary.UnpricedMachineTypes, ", "))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s to %s\n", prefixStyle.Render("Usage:"), summary.From.Format("2006-01-02 15:04"), summary.To.Format("2006-01-02 15:04"))
+	b.WriteString(t.String())
+	fmt.Fprintf(&b, "\n%s %s", prefixStyle.Render("Total:"), total)
+	return b.String()
+}
+
+// WriteUsageCSV writes the usage report as CSV with a header row, one row per VM.
+// Costs are plain numbers and empty when the machine type has no price.
+//
+// Parameters:
+//   - w: Destination of the CSV
+//   - summary: The usage report to write
+//
+// Returns:
+//   - error: An error if writing fails
+func WriteUsageCSV(w io.Writer, summary UsageSummary) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(usageHeaders); err != nil {
+		return err
+	}
+	for _, r := range summary.Rows {
+		if err := cw.Write(usageRecord(r, "")); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// usageRecord formats a usage row; currency prefixes the cost, which is "-" in the
+// table and empty in CSV when unknown.
+func usageRecord(r UsageRow, currency string) []string {
+	cost := ""
+	if r.Cost != nil {
+		cost = fmt.Sprintf("%s%.2f", currency, *r.Cost)
+	} else if currency != "" {
+		cost = "-"
+	}
+	return []string{r.Name, r.Zone, r.MachineType, fmt.Sprintf("%.1f", r.Hours), cost}
+}
+
+// formatPercent formats a 0-1 fraction as a percentage, or "-" without data.
+func formatPercent(v *float64) string {
+	if v == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", *v*100)
+}
+
+// formatRate formats bytes per second with a binary unit, or "-" without data.
+func formatRate(v *float64) string {
+	if v == nil {
+		return "-"
+	}
+	units := []string{"B/s", "KiB/s", "MiB/s", "GiB/s"}
+	rate := *v
+	i := 0
+	for rate >= 1024 && i < len(units)-1 {
+		rate /= 1024
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%.0f %s", rate, units[i])
+	}
+	return fmt.Sprintf("%.1f %s", rate, units[i])
+}
+
+// RenderCapabilities renders which gcectl operations are valid for a VM as a table.
+//
+// Parameters:
+//   - vmName: The VM the capabilities belong to
+//   - caps: Capability evaluation results
+func (p *ConsolePresenter) RenderCapabilities(vmName string, caps []model.CapabilityStatus) {
+	fmt.Println(renderCapabilities(vmName, caps))
+}
+
+// renderCapabilities builds the capabilities table as a string.
+func renderCapabilities(vmName string, caps []model.CapabilityStatus) string {
+	rows := make([][]string, 0, len(caps))
+	for _, c := range caps {
+		mark := marker("✅", "[OK]")
+		if !c.Supported {
+			mark = marker("❌", "[NG]")
+		}
+		rows = append(rows, []string{string(c.Capability), c.Command, mark, c.Reason})
+	}
+
+	t := table.New().
+		Border(tableBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(purple)).
+		Headers("Capability", "Command", "Supported", "Reason").
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			return baseRowStyle.Align(lipgloss.Left)
+		})
+
+	return fmt.Sprintf("%s %s\n%s", prefixStyle.Render("Capabilities of"), vmName, t.String())
+}
+
+// RenderCheckResults renders preflight check results, with the fix under each failure.
+//
+// Parameters:
+//   - results: Check results in the order they ran
+func (p *ConsolePresenter) RenderCheckResults(results []model.CheckResult) {
+	fmt.Println(renderCheckResults(results))
+}
+
+// renderCheckResults builds the check result list as a string.
+func renderCheckResults(results []model.CheckResult) string {
+	var b strings.Builder
+	for i, r := range results {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		switch r.Status {
+		case model.CheckPassed:
+			fmt.Fprintf(&b, "%s %s: %s", marker("✅", "[OK]"), r.Name, r.Detail)
+		case model.CheckFailed:
+			fmt.Fprintf(&b, "%s %s: %s", marker("❌", "[NG]"), r.Name, r.Detail)
+			if r.Fix != "" {
+				fmt.Fprintf(&b, "\n   %s %s", prefixStyle.Render("Fix:"), r.Fix)
+			}
+		default:
+			fmt.Fprintf(&b, "%s %s: skipped (%s)", marker("➖", "[--]"), r.Name, r.Detail)
+		}
+	}
+	return b.String()
+}
+
+// ClearScreen clears the terminal and moves the cursor to the top-left corner.
+func (p *ConsolePresenter) ClearScreen() {
+	fmt.Print("\033[H\033[2J")
+}
+
+// RenderVMDetail renders detailed VM information in a list format.
+//
+// Parameters:
+//   - detail: VM details with pre-calculated uptime string
+func (p *ConsolePresenter) RenderVMDetail(detail VMDetail) {
+	listItemsHeader := []string{
+		"Name",
+		"Project",
+		"Zone",
+		"MachineType",
+		"Status",
+		"SchedulePolicy",
+		"TimeZone",
+		"NextSchedule",
+		"Uptime",
+		"StoppedFor",
+		"Created",
+		"LastStopped",
+		"AutomaticRestart",
+		"OnHostMaintenance",
+		"UpcomingMaintenance",
+	}
+	itemPaddings := getItemPaddings(listItemsHeader)
+
+	l := list.New(
+		fmt.Sprintf("%s%s: %s", prefixStyle.Render(listItemsHeader[0]), itemPaddings[0], detail.Name),
+		fmt.Sprintf("%s%s: %s", prefixStyle.Render(listItemsHeader[1]), itemPaddings[1], detail.Project),
+		fmt.Sprintf("%s%s: %s", prefixStyle.Render(listItemsHeader[2]), itemPaddings[2], detail.Zone),
+		fmt.Sprintf("%s%s: %s", prefixStyle.Render(listItemsHeader[3]), itemPaddings[3], detail.MachineType),
+		fmt.Sprintf("%s%s: %s", prefixStyle.Render(listItemsHeader[4]), itemPaddings[4], detail.Status.String()),
+		fmt.Sprintf("%s%s: %s", prefixStyle.Render(listItemsHeader[5]), itemPaddings[5], formatSchedulePolicy(detail.SchedulePolicy)),
+		fmt.Sprintf("%s%s: %s", prefixStyle.Render(listItemsHeader[6]), itemPaddings[6], formatTimeZone(detail.ScheduleTimeZone)),
+		fmt.Sprintf("%s%s: %s", prefixStyle.Render(listItemsHeader[7]), itemPaddings[7], detail.NextSchedule),
+		fmt.Sprintf("%s%s: %s", prefixStyle.Render(listItemsHeader[8]), itemPaddings[8], detail.Uptime),
+		fmt.Sprintf("%s%s: %s", prefixStyle.Render(listItemsHeader[9]), itemPaddings[9], detail.StoppedFor),
+		fmt.Sprintf("%s%s: %s", prefixStyle.Render(listItemsHeader[10]), itemPaddings[10], formatUnknown(detail.Created)),
+		fmt.Sprintf("%s%s: %s", prefixStyle.Render(listItemsHeader[11]), itemPaddings[11], formatUnknown(detail.LastStopped)),
+		fmt.Sprintf("%s%s: %s", prefixStyle.Render(listItemsHeader[12]), itemPaddings[12], formatUnknown(detail.AutomaticRestart)),
+		fmt.Sprintf("%s%s: %s", prefixStyle.Render(listItemsHeader[13]), itemPaddings[13], formatUnknown(detail.OnHostMaintenance)),
+		fmt.Sprintf("%s%s: %s", prefixStyle.Render(listItemsHeader[14]), itemPaddings[14], formatMaintenance(detail.Maintenance)),
+	).Enumerator(bulletEnumerator).EnumeratorStyle(lipgloss.NewStyle().Padding(0, 1))
+	if len(detail.Disks) > 0 {
+		disks := newDetailSection()
+		for _, d := range detail.Disks {
+			disks.Item(formatDisk(d))
+		}
+		l.Item(prefixStyle.Render("Disks")).Item(disks)
+	}
+	if len(detail.NetworkInterfaces) > 0 {
+		nics := newDetailSection()
+		for _, ni := range detail.NetworkInterfaces {
+			nics.Item(formatNetworkInterface(ni))
+		}
+		l.Item(prefixStyle.Render("Network Interfaces")).Item(nics)
+	}
+	if sa := detail.ServiceAccount; sa != nil {
+		account := newDetailSection().Item(sa.Email)
+		if len(sa.Scopes) > 0 {
+			scopes := newDetailSection()
+			for _, scope := range sa.Scopes {
+				scopes.Item(strings.TrimPrefix(scope, oauthScopePrefix))
+			}
+			account.Item("scopes:").Item(scopes)
+		}
+		l.Item(prefixStyle.Render("Service Account")).Item(account)
+	}
+	if len(detail.Labels) > 0 {
+		keys := make([]string, 0, len(detail.Labels))
+		for key := range detail.Labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		labels := newDetailSection()
+		for _, key := range keys {
+			labels.Item(key + "=" + detail.Labels[key])
+		}
+		l.Item(prefixStyle.Render("Labels")).Item(labels)
+	}
+
+	fmt.Println(l)
+}
+
+// oauthScopePrefix is trimmed from the scopes of a service account for display.
+const oauthScopePrefix = "https://www.googleapis.com/auth/"
+
+// newDetailSection returns an empty dashed sub-list of RenderVMDetail.
+func newDetailSection() *list.List {
+	return list.New().Enumerator(list.Dash).EnumeratorStyle(lipgloss.NewStyle().Padding(0, 1))
+}
+
+// formatNetworkInterface summarizes a network interface, e.g.
+// "nic0 (network: default, subnet: default, internal: 10.128.0.2, external: 34.1.2.3)".
+func formatNetworkInterface(ni model.NetworkInterface) string {
+	details := []string{"network: " + formatUnknown(ni.Network)}
+	if ni.Subnetwork != "" {
+		details = append(details, "subnet: "+ni.Subnetwork)
+	}
+	details = append(details, "internal: "+formatUnknown(ni.InternalIP))
+	if ni.ExternalIP != "" {
+		details = append(details, "external: "+ni.ExternalIP)
+	}
+	return fmt.Sprintf("%s (%s)", ni.Name, strings.Join(details, ", "))
+}
+
+func formatSchedulePolicy(policy string) string {
+	if policy == "" {
+		return "#NONE"
+	}
+	return policy
+}
+
+// formatScheduleWithTimeZone appends the policy's time zone to the schedule, if known.
+func formatScheduleWithTimeZone(policy, timeZone string) string {
+	if policy == "" || timeZone == "" {
+		return formatSchedulePolicy(policy)
+	}
+	return policy + " " + timeZone
+}
+
+// formatTimeZone shows "-" when the VM has no schedule policy time zone.
+func formatTimeZone(timeZone string) string {
+	if timeZone == "" {
+		return "-"
+	}
+	return timeZone
+}
+
+// formatDisk summarizes an attached disk, e.g. "data-1 (device: data, 100 GB, READ_WRITE)".
+func formatDisk(d model.Disk) string {
+	details := []string{"device: " + d.DeviceName}
+	if d.Type != "" {
+		details = append(details, d.Type)
+	}
+	details = append(details, fmt.Sprintf("%d GB", d.SizeGB), d.Mode)
+	if d.Boot {
+		details = append(details, "boot")
+	}
+	return fmt.Sprintf("%s (%s)", d.Name, strings.Join(details, ", "))
+}
+
+// formatUnknown shows "-" for a value that could not be determined.
+func formatUnknown(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// formatMaintenance formats the start of a scheduled maintenance window, or "none" when none is scheduled.
+func formatMaintenance(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
+
+// RenderIP prints a bare IP address followed by a newline.
+// No styling is applied so the output can be piped into other commands.
+//
+// Parameters:
+//   - ip: The IP address to display
+func (p *ConsolePresenter) RenderIP(ip string) {
+	fmt.Println(ip)
+}
+
+// RenderOperations prints one line per operation with the target instance and the
+// operation path, separated by a tab, so the paths can be passed to "gcectl operations wait".
+//
+// Parameters:
+//   - ops: The operations to display
+func (p *ConsolePresenter) RenderOperations(ops []*model.Operation) {
+	for _, op := range ops {
+		fmt.Printf("%s\t%s\n", op.Target, op.Path())
+	}
+}
+
+// RenderOperationList renders operations as a table.
+//
+// Parameters:
+//   - ops: Operations to display, in display order
+func (p *ConsolePresenter) RenderOperationList(ops []*model.Operation) {
+	fmt.Println(renderOperationList(ops))
+}
+
+// renderOperationList builds the operations table as a string.
+func renderOperationList(ops []*model.Operation) string {
+	rows := make([][]string, 0, len(ops))
+	for _, op := range ops {
+		rows = append(rows, []string{
+			op.Name,
+			op.Type,
+			op.Target,
+			formatOperationStatus(op),
+			formatOperationTime(op.InsertTime),
+			op.Error,
+		})
+	}
+
+	t := table.New().
+		Border(tableBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(purple)).
+		Headers("Name", "Type", "Target", "Status", "Started", "Error").
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			return baseRowStyle.Align(lipgloss.Left)
+		})
+
+	return t.String()
+}
+
+// RenderSystemEvents renders the system events of a VM as a table.
+//
+// Parameters:
+//   - events: System events to display, in display order
+func (p *ConsolePresenter) RenderSystemEvents(events []*model.Operation) {
+	fmt.Println(renderSystemEvents(events))
+}
+
+// renderSystemEvents builds the system events table as a string.
+func renderSystemEvents(events []*model.Operation) string {
+	rows := make([][]string, 0, len(events))
+	for _, ev := range events {
+		rows = append(rows, []string{
+			formatOperationTime(ev.InsertTime),
+			strings.TrimPrefix(ev.Type, "compute.instances."),
+			ev.SystemEventDescription(),
+			ev.Error,
+		})
+	}
+
+	t := table.New().
+		Border(tableBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(purple)).
+		Headers("Time", "Event", "Description", "Error").
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			return baseRowStyle.Align(lipgloss.Left)
+		})
+
+	return t.String()
+}
+
+// RenderOperationHistory renders recorded operations as a table.
+//
+// Parameters:
+//   - records: Operations to display, in display order
+func (p *ConsolePresenter) RenderOperationHistory(records []*model.OperationRecord) {
+	fmt.Println(renderOperationHistory(records))
+}
+
+// renderOperationHistory builds the operation history table as a string.
+func renderOperationHistory(records []*model.OperationRecord) string {
+	rows := make([][]string, 0, len(records))
+	for _, r := range records {
+		result := "ok"
+		switch {
+		case r.Running:
+			result = "running (detached)"
+		case !r.Succeeded():
+			result = "failed: " + r.Error
+		}
+		rows = append(rows, []string{
+			formatOperationTime(&r.Time),
+			r.Target,
+			r.Type,
+			formatUnknown(r.User),
+			formatUnknown(r.LocalUser),
+			result,
+			r.Duration.Round(time.Second).String(),
+		})
+	}
+
+	t := table.New().
+		Border(tableBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(purple)).
+		Headers("Time", "Target", "Type", "User", "Local User", "Result", "Duration").
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			return baseRowStyle.Align(lipgloss.Left)
+		})
+
+	return t.String()
+}
+
+// RenderOperationStats renders how long the recorded operations took, one row
+// per VM and operation type.
+//
+// Parameters:
+//   - stats: The statistics to display, in display order
+func (p *ConsolePresenter) RenderOperationStats(stats []*model.OperationStats) {
+	fmt.Println(renderOperationStats(stats))
+}
+
+// renderOperationStats builds the operation statistics table as a string.
+// Durations are dashes when none of the operations succeeded.
+func renderOperationStats(stats []*model.OperationStats) string {
+	rows := make([][]string, 0, len(stats))
+	for _, s := range stats {
+		row := []string{s.Target, s.Type, fmt.Sprint(s.Count), fmt.Sprint(s.Failed)}
+		for _, d := range []time.Duration{s.Average, s.Median, s.Min, s.Max} {
+			if s.Count == 0 {
+				row = append(row, "-")
+				continue
+			}
+			row = append(row, d.Round(time.Second).String())
+		}
+		rows = append(rows, append(row, formatOperationTime(&s.Last)))
+	}
+
+	t := table.New().
+		Border(tableBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(purple)).
+		Headers("Target", "Type", "Count", "Failed", "Average", "Median", "Min", "Max", "Last").
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			return baseRowStyle.Align(lipgloss.Left)
+		})
+
+	return t.String()
+}
+
+// RenderVMResults renders the results of a command acting on several VMs: a
+// summary line and one row per VM, so that failures stand out. Nothing is
+// rendered in quiet mode when every VM succeeded.
 //
-// Returns:
-//   - *ConsolePresenter: A new presenter with predefined styles
-func NewConsolePresenter() *ConsolePresenter {
-	return &ConsolePresenter{
-		errorStyle:   lipgloss.NewStyle().Foreground(lipgloss.Color("#ff5555")).Bold(true),
-		successStyle: lipgloss.NewStyle().Foreground(lipgloss.Color("#50fa7b")).Bold(true),
+// Parameters:
+//   - results: One result per VM, in display order
+func (p *ConsolePresenter) RenderVMResults(results []model.VMResult) {
+	if quietOutput.Load() && failedVMResults(results) == 0 {
+		return
+	}
+	fmt.Println(renderVMResults(results))
+}
+
+// failedVMResults counts the results that are failures.
+func failedVMResults(results []model.VMResult) int {
+	failed := 0
+	for _, r := range results {
+		if !r.Succeeded() {
+			failed++
+		}
+	}
+	return failed
+}
+
+// renderVMResults builds the VM results summary and table as a string.
+func renderVMResults(results []model.VMResult) string {
+	failed := failedVMResults(results)
+	summary := fmt.Sprintf("%s %d VMs, %d succeeded, %d failed", prefixStyle.Render("Summary:"), len(results), len(results)-failed, failed)
+
+	rows := make([][]string, 0, len(results))
+	for _, r := range results {
+		result := "ok"
+		switch {
+		case errors.Is(r.Err, context.Canceled):
+			result = "canceled"
+		case r.Err != nil:
+			// The VM is in its own column.
+			result = "failed: " + strings.TrimPrefix(r.Err.Error(), "VM "+r.VM+": ")
+		}
+		rows = append(rows, []string{r.VM, r.Action, r.Duration.Round(time.Second).String(), result})
 	}
+
+	t := table.New().
+		Border(tableBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(purple)).
+		Headers("VM", "Action", "Duration", "Result").
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			if col == 3 && rows[row][3] != "ok" {
+				return baseRowStyle.Align(lipgloss.Left).Foreground(lipgloss.Color("#ff5555"))
+			}
+			return baseRowStyle.Align(lipgloss.Left)
+		})
+	return summary + "\n" + t.String()
 }
 
-// Success prints a success message with green styling.
+// RenderSchedulePolicies renders instance schedule policies as a table.
 //
 // Parameters:
-//   - msg: The success message to display
-func (p *ConsolePresenter) Success(msg string) {
-	fmt.Println(p.successStyle.Render("[SUCCESS] | ") + msg)
+//   - policies: Policies to display, in display order
+func (p *ConsolePresenter) RenderSchedulePolicies(policies []*model.SchedulePolicy) {
+	fmt.Println(renderSchedulePolicies(policies))
 }
 
-// Error prints an error message with red styling.
+// renderSchedulePolicies builds the schedule policies table as a string.
+func renderSchedulePolicies(policies []*model.SchedulePolicy) string {
+	rows := make([][]string, 0, len(policies))
+	for _, policy := range policies {
+		rows = append(rows, []string{
+			policy.Name,
+			formatCron(policy.StartCron),
+			formatCron(policy.StopCron),
+			policy.TimeZone,
+			fmt.Sprintf("%d", policy.AttachedVMs),
+		})
+	}
+
+	t := table.New().
+		Border(tableBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(purple)).
+		Headers("Name", "Start", "Stop", "Time Zone", "VMs").
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			return baseRowStyle.Align(lipgloss.Left)
+		})
+
+	return t.String()
+}
+
+// RenderDisks renders the disks attached to a VM as a table.
 //
 // Parameters:
-//   - msg: The error message to display
-func (p *ConsolePresenter) Error(msg string) {
-	fmt.Println(p.errorStyle.Render("[ERROR] | ") + msg)
+//   - disks: Disks to display, in display order
+func (p *ConsolePresenter) RenderDisks(disks []*model.Disk) {
+	fmt.Println(renderDisks(disks))
 }
 
-// progressStart prints a progress message without a newline.
+// renderDisks builds the disks table as a string.
+func renderDisks(disks []*model.Disk) string {
+	rows := make([][]string, 0, len(disks))
+	for _, d := range disks {
+		boot := ""
+		if d.Boot {
+			boot = marker("✓", "yes")
+		}
+		rows = append(rows, []string{
+			d.Name,
+			d.DeviceName,
+			formatUnknown(d.Type),
+			fmt.Sprintf("%d GB", d.SizeGB),
+			d.Mode,
+			boot,
+		})
+	}
+
+	t := table.New().
+		Border(tableBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(purple)).
+		Headers("Name", "Device", "Type", "Size", "Mode", "Boot").
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			return baseRowStyle.Align(lipgloss.Left)
+		})
+
+	return t.String()
+}
+
+// RenderSnapshots renders disk snapshots as a table.
 //
 // Parameters:
-//   - msg: The progress message to display
-func (p *ConsolePresenter) progressStart(msg string) {
-	fmt.Print(msg)
+//   - snapshots: Snapshots to display, in display order
+func (p *ConsolePresenter) RenderSnapshots(snapshots []*model.Snapshot) {
+	fmt.Println(renderSnapshots(snapshots))
 }
 
-// progress prints a dot (.) without a newline for progress indication.
-func (p *ConsolePresenter) progress() {
-	fmt.Print(".")
-}
+// renderSnapshots builds the snapshots table as a string.
+func renderSnapshots(snapshots []*model.Snapshot) string {
+	rows := make([][]string, 0, len(snapshots))
+	for _, s := range snapshots {
+		rows = append(rows, []string{
+			s.Name,
+			s.SourceDisk,
+			s.Status,
+			fmt.Sprintf("%d GB", s.DiskSizeGB),
+			formatMemory(int(s.StorageBytes >> 20)),
+			formatOperationTime(s.CreationTime),
+		})
+	}
 
-// progressDone prints a newline to complete a progress line.
-func (p *ConsolePresenter) progressDone() {
-	fmt.Println()
+	t := table.New().
+		Border(tableBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(purple)).
+		Headers("Name", "Disk", "Status", "Disk Size", "Stored", "Created").
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			return baseRowStyle.Align(lipgloss.Left)
+		})
+
+	return t.String()
 }
 
-// VMListItem represents a VM instance for display.
+// RenderProfiles renders the profiles of `gcectl context` with the current one marked.
 //
-//nolint:govet // Field order optimized for readability
-type VMListItem struct {
-	Name           string
-	Project        string
-	Zone           string
-	MachineType    string
-	Status         model.Status
-	SchedulePolicy string
-	Uptime         string // Pre-calculated uptime (e.g., "7d12h45m", "2h30m", "5m30s", "N/A")
+// Parameters:
+//   - profiles: Profiles to display
+//   - current: The name of the current profile
+func (p *ConsolePresenter) RenderProfiles(profiles []*model.Profile, current string) {
+	fmt.Println(renderProfiles(profiles, current))
 }
 
-// VMDetail is an alias for VMListItem for code clarity.
-type VMDetail = VMListItem
+// renderProfiles builds the profile table as a string. Unset fields are shown as "-".
+func renderProfiles(profiles []*model.Profile, current string) string {
+	orDash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	cells := make([][]string, 0, len(profiles))
+	for _, profile := range profiles {
+		mark := ""
+		if profile.Name == current {
+			mark = "*"
+		}
+		cells = append(cells, []string{mark, profile.Name, orDash(profile.ConfigPath), orDash(profile.Project), orDash(profile.Zone)})
+	}
 
-// getStatusEmoji returns an emoji for the given VM status.
+	t := table.New().
+		Border(tableBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(purple)).
+		Headers("Current", "Name", "Config", "Project", "Zone").
+		Rows(cells...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			return baseRowStyle.Align(lipgloss.Left)
+		})
+
+	return t.String()
+}
+
+// RenderScheduledActions renders the pending actions of the local scheduler as a table.
 //
 // Parameters:
-//   - status: The VM status
+//   - actions: Pending actions, earliest first
+func (p *ConsolePresenter) RenderScheduledActions(actions []*model.ScheduledAction) {
+	fmt.Println(renderScheduledActions(actions))
+}
+
+// renderScheduledActions builds the pending action table as a string.
+func renderScheduledActions(actions []*model.ScheduledAction) string {
+	rows := make([][]string, 0, len(actions))
+	for _, a := range actions {
+		rows = append(rows, []string{
+			a.VMName,
+			a.Project,
+			a.Zone,
+			string(a.Action),
+			a.At.Local().Format("2006-01-02 15:04 MST"),
+			formatUnknown(a.Reason),
+		})
+	}
+
+	t := table.New().
+		Border(tableBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(purple)).
+		Headers("Name", "Project", "Zone", "Action", "At", "Reason").
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			return baseRowStyle.Align(lipgloss.Left)
+		})
+
+	return t.String()
+}
+
+// formatCron shows "-" for an unset schedule.
+func formatCron(cron string) string {
+	if cron == "" {
+		return "-"
+	}
+	return cron
+}
+
+// RenderOperationDetail renders a single operation in a list format.
 //
-// Returns:
-//   - string: 🟢 for RUNNING, 🔴 for STOPPED/TERMINATED, ⚪ for others
-func getStatusEmoji(status model.Status) string {
-	switch status.String() {
-	case "RUNNING":
-		return "🟢"
-	case "STOPPED", "TERMINATED":
-		return "🔴"
+// Parameters:
+//   - op: The operation to display
+func (p *ConsolePresenter) RenderOperationDetail(op *model.Operation) {
+	headers := []string{"Name", "Type", "Target", "Status", "User", "Started", "Ended", "Error"}
+	values := []string{
+		op.Path(),
+		op.Type,
+		op.Target,
+		formatOperationStatus(op),
+		op.User,
+		formatOperationTime(op.InsertTime),
+		formatOperationTime(op.EndTime),
+		op.Error,
+	}
+	itemPaddings := getItemPaddings(headers)
+
+	items := make([]any, 0, len(headers))
+	for i, h := range headers {
+		if values[i] == "" {
+			values[i] = "-"
+		}
+		items = append(items, fmt.Sprintf("%s%s: %s", prefixStyle.Render(h), itemPaddings[i], values[i]))
+	}
+	l := list.New(items...).Enumerator(bulletEnumerator).EnumeratorStyle(lipgloss.NewStyle().Padding(0, 1))
+
+	fmt.Println(l)
+}
+
+// formatOperationStatus shows progress for unfinished operations and marks failures.
+func formatOperationStatus(op *model.Operation) string {
+	switch {
+	case op.IsDone() && op.Error != "":
+		return "DONE (failed)"
+	case !op.IsDone() && op.Progress > 0:
+		return fmt.Sprintf("%s %d%%", op.Status, op.Progress)
 	default:
-		return "⚪"
+		return op.Status
 	}
 }
 
-// RenderVMList renders VMs in a formatted table.
+func formatOperationTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+// RenderLogEntries prints one line per log entry with its local time, severity,
+// log name and message. No styling is applied so the output can be piped into grep.
 //
 // Parameters:
-//   - items: VMs to display with pre-calculated uptime strings
-func (p *ConsolePresenter) RenderVMList(items []VMListItem) {
-	var rows [][]string
+//   - entries: Log entries to display, oldest first
+func (p *ConsolePresenter) RenderLogEntries(entries []*model.LogEntry) {
+	for _, e := range entries {
+		fmt.Println(formatLogEntry(e))
+	}
+}
 
-	for _, item := range items {
-		statusEmoji := getStatusEmoji(item.Status)
+// formatLogEntry formats a log entry as a single line.
+func formatLogEntry(e *model.LogEntry) string {
+	return fmt.Sprintf("%s  %-8s %s: %s", e.Timestamp.Local().Format("2006-01-02 15:04:05"), e.Severity, e.Log,
+		strings.TrimRight(e.Message, "\n"))
+}
+
+// RenderMachineTypes renders machine types as a numbered table grouped by family.
+// The numbers can be typed at Prompt to pick a machine type.
+//
+// Parameters:
+//   - machineTypes: Machine types to display, grouped by family
+func (p *ConsolePresenter) RenderMachineTypes(machineTypes []*model.MachineType) {
+	fmt.Println(renderMachineTypes(machineTypes))
+}
 
+// renderMachineTypes builds the machine type table as a string. The family is
+// only shown on the first row of each group.
+func renderMachineTypes(machineTypes []*model.MachineType) string {
+	rows := make([][]string, 0, len(machineTypes))
+	prevFamily := ""
+	for i, mt := range machineTypes {
+		family := mt.Family()
+		familyCell := ""
+		if family != prevFamily {
+			familyCell = family
+			prevFamily = family
+		}
 		rows = append(rows, []string{
-			item.Name,
-			item.Project,
-			item.Zone,
-			item.MachineType,
-			statusEmoji + " " + item.Status.String(),
-			formatSchedulePolicy(item.SchedulePolicy),
-			item.Uptime,
+			fmt.Sprintf("%d", i+1),
+			familyCell,
+			mt.Name,
+			fmt.Sprintf("%d", mt.GuestCPUs),
+			formatMemory(mt.MemoryMB),
 		})
 	}
 
 	t := table.New().
-		Border(lipgloss.NormalBorder()).
+		Border(tableBorder()).
 		BorderStyle(lipgloss.NewStyle().Foreground(purple)).
-		Headers("Name", "Project", "Zone", "Machine-Type", "Status", "Schedule", "Uptime").
+		Headers("#", "Family", "Machine-Type", "vCPUs", "Memory").
 		Rows(rows...).
 		StyleFunc(func(row, col int) lipgloss.Style {
-			switch row {
-			case table.HeaderRow:
+			if row == table.HeaderRow {
 				return headerStyle
-			default:
-				return baseRowStyle.Align(lipgloss.Left)
 			}
+			return baseRowStyle.Align(lipgloss.Left)
 		})
 
-	fmt.Println(t)
+	return t.String()
 }
 
-// RenderVMDetail renders detailed VM information in a list format.
+// formatMemory formats megabytes as gigabytes, e.g. "4 GB" or "0.60 GB".
+func formatMemory(mb int) string {
+	if mb%1024 == 0 {
+		return fmt.Sprintf("%d GB", mb/1024)
+	}
+	return fmt.Sprintf("%.2f GB", float64(mb)/1024)
+}
+
+// GPUAvailabilityRow is the presenter representation of one accelerator type in a zone.
+//
+//nolint:govet // Field order optimized for readability
+type GPUAvailabilityRow struct {
+	Zone     string
+	Type     string
+	MaxCards int
+	// Quota is the regional quota of the type, or nil when unknown.
+	Quota  *model.Quota
+	Likely bool
+}
+
+// RenderGPUAvailability renders where an accelerator type is offered and whether
+// the quota leaves room for it.
 //
 // Parameters:
-//   - detail: VM details with pre-calculated uptime string
-func (p *ConsolePresenter) RenderVMDetail(detail VMDetail) {
-	listItemsHeader := []string{
-		"Name",
-		"Project",
-		"Zone",
-		"MachineType",
-		"Status",
-		"SchedulePolicy",
-		"Uptime",
+//   - rows: Zones and types to display, likely ones first
+func (p *ConsolePresenter) RenderGPUAvailability(rows []GPUAvailabilityRow) {
+	fmt.Println(renderGPUAvailability(rows))
+}
+
+// renderGPUAvailability builds the GPU availability table as a string.
+func renderGPUAvailability(rows []GPUAvailabilityRow) string {
+	cells := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		quota, free := "-", "-"
+		if r.Quota != nil {
+			quota = fmt.Sprintf("%g/%g", r.Quota.Usage, r.Quota.Limit)
+			free = fmt.Sprintf("%g", r.Quota.Available())
+		}
+		likely := "no"
+		if r.Likely {
+			likely = "yes"
+		}
+		cells = append(cells, []string{r.Zone, r.Type, fmt.Sprintf("%d", r.MaxCards), quota, free, likely})
 	}
-	itemPaddings := getItemPaddings(listItemsHeader)
 
-	l := list.New(
-		fmt.Sprintf("%s%s: %s", prefixStyle.Render(listItemsHeader[0]), itemPaddings[0], detail.Name),
-		fmt.Sprintf("%s%s: %s", prefixStyle.Render(listItemsHeader[1]), itemPaddings[1], detail.Project),
-		fmt.Sprintf("%s%s: %s", prefixStyle.Render(listItemsHeader[2]), itemPaddings[2], detail.Zone),
-		fmt.Sprintf("%s%s: %s", prefixStyle.Render(listItemsHeader[3]), itemPaddings[3], detail.MachineType),
-		fmt.Sprintf("%s%s: %s", prefixStyle.Render(listItemsHeader[4]), itemPaddings[4], detail.Status.String()),
-		fmt.Sprintf("%s%s: %s", prefixStyle.Render(listItemsHeader[5]), itemPaddings[5], formatSchedulePolicy(detail.SchedulePolicy)),
-		fmt.Sprintf("%s%s: %s", prefixStyle.Render(listItemsHeader[6]), itemPaddings[6], detail.Uptime),
-	).Enumerator(list.Bullet).EnumeratorStyle(lipgloss.NewStyle().Padding(0, 1))
+	t := table.New().
+		Border(tableBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(purple)).
+		Headers("Zone", "Type", "Max/VM", "Quota Used", "Free", "Likely").
+		Rows(cells...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			return baseRowStyle.Align(lipgloss.Left)
+		})
 
-	fmt.Println(l)
+	return t.String()
 }
 
-func formatSchedulePolicy(policy string) string {
-	if policy == "" {
-		return "#NONE"
+// RenderProjects renders the projects the caller can access.
+//
+// Parameters:
+//   - projects: Projects to display
+func (p *ConsolePresenter) RenderProjects(projects []*model.Project) {
+	fmt.Println(renderProjects(projects))
+}
+
+// renderProjects builds the project table as a string.
+func renderProjects(projects []*model.Project) string {
+	cells := make([][]string, 0, len(projects))
+	for _, project := range projects {
+		cells = append(cells, []string{project.ID, project.Name, fmt.Sprintf("%d", project.Number)})
 	}
-	return policy
+
+	t := table.New().
+		Border(tableBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(purple)).
+		Headers("Project ID", "Name", "Number").
+		Rows(cells...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			return baseRowStyle.Align(lipgloss.Left)
+		})
+
+	return t.String()
+}
+
+// RenderZones renders the zones of a project.
+//
+// Parameters:
+//   - zones: Zones to display
+func (p *ConsolePresenter) RenderZones(zones []*model.Zone) {
+	fmt.Println(renderZones(zones))
+}
+
+// renderZones builds the zone table as a string.
+func renderZones(zones []*model.Zone) string {
+	cells := make([][]string, 0, len(zones))
+	for _, zone := range zones {
+		cells = append(cells, []string{zone.Name, zone.Region, zone.Status})
+	}
+
+	t := table.New().
+		Border(tableBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(purple)).
+		Headers("Zone", "Region", "Status").
+		Rows(cells...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			return baseRowStyle.Align(lipgloss.Left)
+		})
+
+	return t.String()
+}
+
+// Prompt prints label and reads one line from stdin, without the trailing newline.
+//
+// Parameters:
+//   - label: The prompt to display
+//
+// Returns:
+//   - string: The trimmed input line
+//   - error: io.EOF if stdin was closed before any input
+func (p *ConsolePresenter) Prompt(label string) (string, error) {
+	fmt.Print(prefixStyle.Render(label) + " ")
+	line, err := p.input.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// RenderText prints text as-is without adding styling or a trailing newline.
+//
+// Parameters:
+//   - text: The text to display
+func (p *ConsolePresenter) RenderText(text string) {
+	fmt.Print(text)
 }
 
 // RenderVersion renders version information in a list format.
@@ -197,11 +1773,27 @@ func (p *ConsolePresenter) RenderVersion(version, commit, date string) {
 		fmt.Sprintf("%s%s: %s", prefixStyle.Render("Version"), itemPaddings[0], version),
 		fmt.Sprintf("%s%s: %s", prefixStyle.Render("Git Commit"), itemPaddings[1], commit),
 		fmt.Sprintf("%s%s: %s", prefixStyle.Render("Build Date"), itemPaddings[2], date),
-	).Enumerator(list.Bullet).EnumeratorStyle(lipgloss.NewStyle().Padding(0, 1))
+	).Enumerator(bulletEnumerator).EnumeratorStyle(lipgloss.NewStyle().Padding(0, 1))
 
 	fmt.Println(l)
 }
 
+// UpdateNotice prints a one-line notice of a newer release to stderr, so that
+// it never mixes with output that is piped or parsed.
+//
+// Parameters:
+//   - current: The running version
+//   - latest: The version of the newer release
+//   - url: The release page
+func (p *ConsolePresenter) UpdateNotice(current, latest, url string) {
+	fmt.Fprintln(os.Stderr, formatUpdateNotice(current, latest, url))
+}
+
+func formatUpdateNotice(current, latest, url string) string {
+	return fmt.Sprintf("%sA new release of gcectl is available: %s %s %s, see %s (set update-check: false in the config to silence this)",
+		prefixStyle.Render("[UPDATE] | "), current, marker("→", "->"), latest, url)
+}
+
 // getItemPaddings calculates padding strings for list items to ensure alignment.
 //
 // Parameters:
@@ -236,8 +1828,11 @@ func getItemPaddings(listItemsHeader []string) []string {
 
 // ExecuteWithProgress executes a function with progress indication.
 //
-// Displays a progress message, executes the provided function in a goroutine,
-// and shows progress dots every second until completion.
+// Displays a progress message and executes the provided function in a goroutine.
+// On a terminal a spinner shows the elapsed time and, when ctx carries an
+// OperationProgress, the progress GCE reports; otherwise, or with
+// SetPlainProgress, progress dots are printed every second until completion.
+// In quiet mode fn runs without any progress output.
 //
 // Parameters:
 //   - ctx: Context for cancellation control
@@ -247,7 +1842,13 @@ func getItemPaddings(listItemsHeader []string) []string {
 // Returns:
 //   - error: Error from the executed function, or nil on success
 func (p *ConsolePresenter) ExecuteWithProgress(ctx context.Context, message string, fn func(context.Context) error) error {
-	p.progressStart(message)
+	if quietOutput.Load() {
+		return fn(ctx)
+	}
+	plain := plainProgress.Load() || !p.IsInteractive()
+	if plain {
+		p.progressStart(message)
+	}
 	defer p.progressDone()
 
 	eg, ctx := errgroup.WithContext(ctx)
@@ -262,6 +1863,14 @@ func (p *ConsolePresenter) ExecuteWithProgress(ctx context.Context, message stri
 		return nil
 	})
 
+	if !plain {
+		eg.Go(func() error {
+			p.spin(ctx, message, doneCh)
+			return nil
+		})
+		return eg.Wait()
+	}
+
 	// Display progress dots every second
 	eg.Go(func() error {
 		ticker := time.NewTicker(1 * time.Second)
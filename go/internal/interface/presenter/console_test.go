@@ -1,11 +1,16 @@
 package presenter
 
 import (
+	"bufio"
 	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/haru-256/gcectl/internal/domain/model"
 	"github.com/stretchr/testify/assert"
@@ -244,13 +249,30 @@ func TestConsolePresenter_RenderVMDetail(t *testing.T) {
 	presenter := NewConsolePresenter()
 
 	detail := VMDetail{
-		Name:           "test-vm",
-		Project:        "test-project",
-		Zone:           "us-central1-a",
-		MachineType:    "e2-medium",
-		Status:         model.StatusRunning,
-		SchedulePolicy: "test-policy",
-		Uptime:         "2h30m",
+		Name:              "test-vm",
+		Project:           "test-project",
+		Zone:              "us-central1-a",
+		MachineType:       "e2-medium",
+		Status:            model.StatusRunning,
+		SchedulePolicy:    "test-policy",
+		ScheduleTimeZone:  "Asia/Tokyo",
+		Uptime:            "2h30m",
+		NextSchedule:      "stops in 3h12m",
+		AutomaticRestart:  "false",
+		OnHostMaintenance: "TERMINATE",
+		Labels:            map[string]string{"team": "ml", "env": "dev"},
+		Disks: []model.Disk{
+			{Name: "test-vm", DeviceName: "persistent-disk-0", SizeGB: 10, Mode: "READ_WRITE", Boot: true},
+			{Name: "data-1", DeviceName: "data", SizeGB: 200, Mode: "READ_ONLY"},
+		},
+		NetworkInterfaces: []model.NetworkInterface{
+			{Name: "nic0", Network: "default", Subnetwork: "default", InternalIP: "10.128.0.2", ExternalIP: "34.1.2.3"},
+			{Name: "nic1", Network: "backend", InternalIP: "10.10.0.5"},
+		},
+		ServiceAccount: &model.ServiceAccount{
+			Email:  "vm@test-project.iam.gserviceaccount.com",
+			Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+		},
 	}
 
 	// Capture stdout
@@ -277,7 +299,24 @@ func TestConsolePresenter_RenderVMDetail(t *testing.T) {
 		"e2-medium",
 		"RUNNING",
 		"test-policy",
+		"Asia/Tokyo",
+		"stops in 3h12m",
 		"2h30m",
+		"AutomaticRestart",
+		"false",
+		"TERMINATE",
+		"Labels",
+		"env=dev",
+		"team=ml",
+		"Disks",
+		"test-vm (device: persistent-disk-0, 10 GB, READ_WRITE, boot)",
+		"data-1 (device: data, 200 GB, READ_ONLY)",
+		"Network Interfaces",
+		"nic0 (network: default, subnet: default, internal: 10.128.0.2, external: 34.1.2.3)",
+		"nic1 (network: backend, internal: 10.10.0.5)",
+		"Service Account",
+		"vm@test-project.iam.gserviceaccount.com",
+		"cloud-platform",
 	}
 
 	for _, field := range expectedFields {
@@ -391,3 +430,549 @@ func TestGetItemPaddings(t *testing.T) {
 		})
 	}
 }
+
+func TestConsolePresenter_RenderIP(t *testing.T) {
+	presenter := NewConsolePresenter()
+
+	// Capture stdout
+	old := os.Stdout
+	r, w, err := os.Pipe()
+	require.NoError(t, err, "Failed to create pipe")
+	os.Stdout = w
+
+	presenter.RenderIP("34.1.2.3")
+
+	require.NoError(t, w.Close(), "Failed to close write pipe")
+	os.Stdout = old
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, r)
+	require.NoError(t, err, "Failed to copy output")
+
+	assert.Equal(t, "34.1.2.3\n", buf.String(), "RenderIP should print only the IP")
+}
+
+func TestConsolePresenter_RenderOperations(t *testing.T) {
+	presenter := NewConsolePresenter()
+
+	// Capture stdout
+	old := os.Stdout
+	r, w, err := os.Pipe()
+	require.NoError(t, err, "Failed to create pipe")
+	os.Stdout = w
+
+	presenter.RenderOperations([]*model.Operation{
+		{Project: "p", Zone: "z", Name: "op-1", Target: "vm1"},
+		{Project: "p", Zone: "z", Name: "op-2", Target: "vm2"},
+	})
+
+	require.NoError(t, w.Close(), "Failed to close write pipe")
+	os.Stdout = old
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, r)
+	require.NoError(t, err, "Failed to copy output")
+
+	assert.Equal(t, "vm1\tprojects/p/zones/z/operations/op-1\nvm2\tprojects/p/zones/z/operations/op-2\n", buf.String())
+}
+
+func TestVMListRow(t *testing.T) {
+	loading := vmListRow(VMListItem{Name: "vm1", Project: "p", Zone: "z", Loading: true})
+	assert.Equal(t, "vm1", loading[0])
+	assert.Contains(t, loading[4], "LOADING")
+
+	failed := vmListRow(VMListItem{Name: "vm1", Project: "p", Zone: "z", Failed: true})
+	assert.Contains(t, failed[4], "ERROR")
+
+	loaded := vmListRow(VMListItem{Name: "vm1", Status: model.StatusRunning, Uptime: "5m30s"})
+	assert.Equal(t, "🟢 RUNNING", loaded[4])
+	assert.Equal(t, "#NONE", loaded[5])
+	assert.Equal(t, "5m30s", loaded[6])
+
+	scheduled := vmListRow(VMListItem{
+		Name:             "vm1",
+		Status:           model.StatusRunning,
+		SchedulePolicy:   "weekday-stop(0 19 * * 1-5)",
+		ScheduleTimeZone: "Asia/Tokyo",
+		NextSchedule:     "stops in 3h12m",
+	})
+	assert.Equal(t, "weekday-stop(0 19 * * 1-5) Asia/Tokyo", scheduled[5])
+	assert.Equal(t, "stops in 3h12m", scheduled[8])
+
+	maintenance := vmListRow(VMListItem{Name: "vm1", Status: model.StatusRunning, Maintenance: "2026-05-02 12:00 JST"})
+	assert.Equal(t, "🟢 RUNNING 🔧", maintenance[4])
+
+	stopped := vmListRow(VMListItem{Name: "vm1", Status: model.StatusTerminated, Uptime: "N/A", StoppedFor: "30d2h0m"})
+	assert.Equal(t, "30d2h0m", stopped[7])
+	assert.Len(t, loading, len(scheduled), "loading rows must have one cell per column")
+	assert.Len(t, failed, len(scheduled), "failed rows must have one cell per column")
+}
+
+func TestRenderVMListTable_GroupsByProject(t *testing.T) {
+	single := renderVMListTable([]VMListItem{
+		{Name: "vm1", Project: "proj-a", Zone: "z"},
+		{Name: "vm2", Project: "proj-a", Zone: "z"},
+	})
+	assert.NotContains(t, single, "Project:")
+
+	grouped := renderVMListTable([]VMListItem{
+		{Name: "vm1", Project: "proj-b", Zone: "z"},
+		{Name: "vm2", Project: "proj-a", Zone: "z"},
+		{Name: "vm3", Project: "proj-b", Zone: "z"},
+	})
+	projB := strings.Index(grouped, "Project: proj-b")
+	projA := strings.Index(grouped, "Project: proj-a")
+	require.GreaterOrEqual(t, projB, 0, grouped)
+	require.GreaterOrEqual(t, projA, 0, grouped)
+	assert.Less(t, projB, projA, "projects should keep their first-seen order")
+	assert.Less(t, strings.Index(grouped, "vm3"), projA, "vm3 should be grouped with proj-b")
+}
+
+func TestLiveVMList_Update(t *testing.T) {
+	presenter := NewConsolePresenter()
+
+	// Capture stdout
+	old := os.Stdout
+	r, w, err := os.Pipe()
+	require.NoError(t, err, "Failed to create pipe")
+	os.Stdout = w
+
+	live := presenter.NewLiveVMList([]VMListItem{{Name: "vm1", Project: "p", Zone: "z", Loading: true}})
+	live.Update(0, VMListItem{Name: "vm1", Project: "p", Zone: "z", MachineType: "e2-medium", Status: model.StatusRunning, Uptime: "2h30m"})
+	live.Update(5, VMListItem{Name: "out-of-range"})
+
+	require.NoError(t, w.Close(), "Failed to close write pipe")
+	os.Stdout = old
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, r)
+	require.NoError(t, err, "Failed to copy output")
+	output := buf.String()
+
+	assert.Contains(t, output, "LOADING", "Initial render should show loading rows")
+	assert.Contains(t, output, "\033[", "Update should move the cursor to redraw in place")
+	assert.Contains(t, output, "e2-medium", "Update should render the fetched details")
+	assert.NotContains(t, output, "out-of-range", "Out of range updates should be ignored")
+}
+
+func TestRenderFleetSummary(t *testing.T) {
+	output := renderFleetSummary(FleetSummary{
+		StatusCounts: map[model.Status]int{
+			model.StatusRunning:    2,
+			model.StatusTerminated: 1,
+		},
+		LongestRunning: []VMListItem{
+			{Name: "long-vm", MachineType: "n1-standard-4", Uptime: "2d0h0m"},
+		},
+		UnpricedMachineTypes: []string{"a2-highgpu-1g"},
+		HourlyCost:           0.22,
+		Total:                3,
+	})
+
+	assert.Contains(t, output, "3 VMs")
+	assert.Contains(t, output, "🟢🟢🔴", "Heatmap should have one cell per VM")
+	assert.Contains(t, output, "RUNNING 2")
+	assert.Contains(t, output, "TERMINATED 1")
+	assert.Contains(t, output, "$0.22/h")
+	assert.Contains(t, output, "a2-highgpu-1g")
+	assert.Contains(t, output, "long-vm")
+	assert.NotContains(t, output, "STOPPED", "Statuses without VMs should be omitted")
+}
+
+func TestRenderTop(t *testing.T) {
+	cpu, rx := 0.425, 3.5*1024*1024
+	output := renderTop([]VMMetricsRow{
+		{Name: "busy-vm", Zone: "us-central1-a", MachineType: "e2-medium", Metrics: &model.InstanceMetrics{CPUUtilization: &cpu, NetworkInBytesPerSec: &rx}},
+	}, 10*time.Minute, time.Date(2025, 1, 2, 6, 0, 0, 0, time.UTC))
+
+	assert.Contains(t, output, "1 running VMs, 10m0s average")
+	assert.Contains(t, output, "busy-vm")
+	assert.Contains(t, output, "42.5%")
+	assert.Contains(t, output, "3.5 MiB/s")
+	assert.Contains(t, output, "-", "Metrics without data should be shown as -")
+}
+
+func TestRenderIdleVMs(t *testing.T) {
+	cpu, zero := 0.012, 0.0
+	output := renderIdleVMs(IdleSummary{
+		VMs: []VMMetricsRow{
+			{Name: "gpu-box", MachineType: "a2-highgpu-1g", Metrics: &model.InstanceMetrics{CPUUtilization: &cpu, NetworkInBytesPerSec: &zero, NetworkOutBytesPerSec: &zero}},
+		},
+		UnpricedMachineTypes: []string{"custom-2-4096"},
+		HourlyCost:           3.67,
+		Window:               time.Hour,
+	})
+
+	assert.Contains(t, output, "1 VMs look idle over the last 1h0m0s")
+	assert.Contains(t, output, "gpu-box")
+	assert.Contains(t, output, "1.2%")
+	assert.Contains(t, output, "$3.67/h (no price for: custom-2-4096)")
+
+	assert.Contains(t, renderIdleVMs(IdleSummary{Window: time.Hour}), "no idle VMs")
+}
+
+func TestRenderReconcile(t *testing.T) {
+	output := renderReconcile(ReconcileSummary{
+		Projects:  []string{"p1", "p2"},
+		Unmanaged: []*model.VM{{Name: "scratch", Project: "p1", Zone: "us-central1-a", MachineType: "e2-medium", Status: model.StatusRunning}},
+		Stale:     []*model.VM{{Name: "deleted", Project: "p2", Zone: "us-east1-b"}},
+	})
+
+	assert.Contains(t, output, "1 instances in p1, p2 are not in the config file")
+	assert.Contains(t, output, "scratch")
+	assert.Contains(t, output, "e2-medium")
+	assert.Contains(t, output, "1 config entries have no instance")
+	assert.Contains(t, output, "deleted")
+
+	assert.Contains(t, renderReconcile(ReconcileSummary{Projects: []string{"p1"}}), "matches the instances in p1")
+}
+
+func TestRenderApplyPlan(t *testing.T) {
+	web := &model.VM{Name: "web"}
+	output := renderApplyPlan([]model.ApplyAction{
+		{VM: web, Type: model.ApplySetMachineType, From: "e2-medium", To: "e2-standard-4"},
+		{VM: web, Type: model.ApplyStart, From: "TERMINATED", To: "running"},
+	})
+
+	assert.Contains(t, output, "2 changes")
+	assert.Contains(t, output, "~ web: set-machine-type (e2-medium -> e2-standard-4)")
+	assert.Contains(t, output, "+ web: start (TERMINATED -> running)")
+
+	assert.Contains(t, renderApplyPlan(nil), "no changes")
+}
+
+func TestRenderDrift(t *testing.T) {
+	output := renderDrift([]model.VMDrift{
+		{VM: &model.VM{Name: "web"}},
+		{VM: &model.VM{Name: "batch"}, Fields: []model.FieldDrift{
+			{Field: "machine-type", Current: "e2-medium", Desired: "e2-small"},
+			{Field: "schedule-policy", Current: "", Desired: "weekday-stop"},
+		}},
+	})
+
+	assert.Contains(t, output, "web: in sync")
+	assert.Contains(t, output, "- machine-type: e2-medium")
+	assert.Contains(t, output, "+ machine-type: e2-small")
+	assert.Contains(t, output, "- schedule-policy: (none)")
+	assert.Contains(t, output, "1 of 2 managed VMs differ")
+
+	assert.Contains(t, renderDrift([]model.VMDrift{{VM: &model.VM{Name: "web"}}}), "all 1 managed VMs match")
+}
+
+func TestRenderUsageReport(t *testing.T) {
+	cost := 28.0
+	summary := UsageSummary{
+		From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		Rows: []UsageRow{
+			{Name: "gpu", Zone: "us-central1-a", MachineType: "a2-highgpu-1g", Hours: 8, Cost: &cost},
+			{Name: "dev", Zone: "us-central1-a", MachineType: "e2-medium", Hours: 2.25},
+		},
+		UnpricedMachineTypes: []string{"e2-medium"},
+		TotalHours:           10.25,
+		TotalCost:            28,
+	}
+
+	output := renderUsageReport(summary)
+	assert.Contains(t, output, "2025-01-01 00:00 to 2025-02-01 00:00")
+	assert.Contains(t, output, "$28.00")
+	assert.Contains(t, output, "10.2 hours, $28.00 (no price for: e2-medium)")
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteUsageCSV(&buf, summary))
+	assert.Equal(t, "Name,Zone,Machine-Type,Hours,Cost\n"+
+		"gpu,us-central1-a,a2-highgpu-1g,8.0,28.00\n"+
+		"dev,us-central1-a,e2-medium,2.2,\n", buf.String())
+}
+
+func TestWriteVMListCSV(t *testing.T) {
+	items := []VMListItem{
+		{Name: "dev", Project: "p", Zone: "us-central1-a", MachineType: "e2-medium", Status: model.StatusRunning,
+			SchedulePolicy: "stop-19", ScheduleTimeZone: "Asia/Tokyo", Uptime: "2h30m", NextSchedule: "stops in 3h"},
+		{Name: "gpu", Project: "p", Zone: "us-central1-a", MachineType: "a2-highgpu-1g", Status: model.StatusTerminated, Uptime: "N/A", StoppedFor: "30d2h0m", NextSchedule: "N/A"},
+	}
+
+	var csvBuf bytes.Buffer
+	require.NoError(t, WriteVMListCSV(&csvBuf, items, ','))
+	assert.Equal(t, "Name,Project,Zone,Machine-Type,Status,Schedule,Time-Zone,Uptime,Stopped-For,Next-Schedule\n"+
+		"dev,p,us-central1-a,e2-medium,RUNNING,stop-19,Asia/Tokyo,2h30m,,stops in 3h\n"+
+		"gpu,p,us-central1-a,a2-highgpu-1g,TERMINATED,,,N/A,30d2h0m,N/A\n", csvBuf.String())
+
+	var tsvBuf bytes.Buffer
+	require.NoError(t, WriteVMListCSV(&tsvBuf, items[1:], '\t'))
+	assert.Equal(t, "Name\tProject\tZone\tMachine-Type\tStatus\tSchedule\tTime-Zone\tUptime\tStopped-For\tNext-Schedule\n"+
+		"gpu\tp\tus-central1-a\ta2-highgpu-1g\tTERMINATED\t\t\tN/A\t30d2h0m\tN/A\n", tsvBuf.String())
+}
+
+func TestFormatRate(t *testing.T) {
+	v := func(f float64) *float64 { return &f }
+	assert.Equal(t, "-", formatRate(nil))
+	assert.Equal(t, "512 B/s", formatRate(v(512)))
+	assert.Equal(t, "1.5 KiB/s", formatRate(v(1536)))
+	assert.Equal(t, "2.0 GiB/s", formatRate(v(2*1024*1024*1024)))
+}
+
+func TestRenderCapabilities(t *testing.T) {
+	output := renderCapabilities("sandbox", []model.CapabilityStatus{
+		{Capability: model.CapabilityStart, Command: "gcectl on", Supported: true},
+		{Capability: model.CapabilitySSH, Command: "gcectl ssh-config", Reason: "VM has no external IP"},
+	})
+
+	assert.Contains(t, output, "sandbox")
+	assert.Contains(t, output, "gcectl on")
+	assert.Contains(t, output, "✅")
+	assert.Contains(t, output, "❌")
+	assert.Contains(t, output, "VM has no external IP")
+}
+
+func TestRenderOperationList(t *testing.T) {
+	started := time.Date(2025, 1, 2, 3, 4, 5, 0, time.Local)
+	output := renderOperationList([]*model.Operation{
+		{Name: "op-1", Type: "start", Target: "vm1", Status: "RUNNING", Progress: 40, InsertTime: &started},
+		{Name: "op-2", Type: "stop", Target: "vm2", Status: "DONE", Error: "quota exceeded"},
+	})
+
+	assert.Contains(t, output, "op-1")
+	assert.Contains(t, output, "RUNNING 40%")
+	assert.Contains(t, output, "2025-01-02 03:04:05")
+	assert.Contains(t, output, "DONE (failed)")
+	assert.Contains(t, output, "quota exceeded")
+}
+
+func TestRenderSystemEvents(t *testing.T) {
+	at := time.Date(2025, 1, 2, 3, 4, 5, 0, time.Local)
+	output := renderSystemEvents([]*model.Operation{
+		{Type: "compute.instances.migrateOnHostMaintenance", InsertTime: &at},
+	})
+
+	assert.Contains(t, output, "2025-01-02 03:04:05")
+	assert.Contains(t, output, "migrateOnHostMaintenance")
+	assert.Contains(t, output, "live migrated for host maintenance")
+}
+
+func TestRenderOperationHistory(t *testing.T) {
+	at := time.Date(2025, 1, 2, 3, 4, 5, 0, time.Local)
+	output := renderOperationHistory([]*model.OperationRecord{
+		{Time: at, Duration: 42*time.Second + 300*time.Millisecond, Target: "vm1", Type: "stop", User: "alice@example.com", LocalUser: "alice@laptop"},
+		{Time: at, Target: "vm2", Type: "start", Error: "quota exceeded"},
+		{Time: at, Target: "vm3", Type: "stop", Running: true},
+	})
+
+	assert.Contains(t, output, "2025-01-02 03:04:05")
+	assert.Contains(t, output, "alice@example.com")
+	assert.Contains(t, output, "42s")
+	assert.Contains(t, output, "failed: quota exceeded")
+	assert.Contains(t, output, "running (detached)")
+}
+
+func TestRenderOperationStats(t *testing.T) {
+	at := time.Date(2025, 1, 2, 3, 4, 5, 0, time.Local)
+	output := renderOperationStats([]*model.OperationStats{
+		{Last: at, Target: "vm1", Type: "start", Count: 3, Failed: 1, Average: 70 * time.Second, Median: 60 * time.Second, Min: 40 * time.Second, Max: 110 * time.Second},
+		{Last: at, Target: "vm2", Type: "start", Failed: 2},
+	})
+
+	assert.Contains(t, output, "1m10s")
+	assert.Contains(t, output, "1m50s")
+	assert.Contains(t, output, "2025-01-02 03:04:05")
+	assert.Contains(t, output, "-", "durations without a successful operation are dashes")
+}
+
+func TestRenderVMResults(t *testing.T) {
+	output := renderVMResults([]model.VMResult{
+		{VM: "vm1", Action: "stop", Duration: 41*time.Second + 700*time.Millisecond},
+		{VM: "vm2", Action: "stop", Duration: time.Second, Err: errors.New("VM vm2: cannot be stopped (current status: TERMINATED)")},
+		{VM: "vm3", Action: "stop", Err: fmt.Errorf("VM vm3: failed to stop: %w", context.Canceled)},
+	})
+
+	assert.Contains(t, output, "3 VMs, 1 succeeded, 2 failed")
+	assert.Contains(t, output, "42s")
+	assert.Contains(t, output, "failed: cannot be stopped (current status: TERMINATED)")
+	assert.Contains(t, output, "canceled")
+}
+
+func TestRenderSchedulePolicies(t *testing.T) {
+	output := renderSchedulePolicies([]*model.SchedulePolicy{
+		{Name: "weekday-stop", StopCron: "0 19 * * 1-5", TimeZone: "Asia/Tokyo", AttachedVMs: 3},
+	})
+
+	assert.Contains(t, output, "weekday-stop")
+	assert.Contains(t, output, "0 19 * * 1-5")
+	assert.Contains(t, output, "Asia/Tokyo")
+	assert.Contains(t, output, "3")
+	assert.Contains(t, output, "-", "unset start schedule is shown as a dash")
+}
+
+func TestRenderDisks(t *testing.T) {
+	output := renderDisks([]*model.Disk{
+		{Name: "vm-1", DeviceName: "persistent-disk-0", Type: "pd-balanced", SizeGB: 10, Mode: "READ_WRITE", Boot: true},
+		{Name: "data-1", DeviceName: "data", SizeGB: 200, Mode: "READ_ONLY"},
+	})
+
+	assert.Contains(t, output, "persistent-disk-0")
+	assert.Contains(t, output, "pd-balanced")
+	assert.Contains(t, output, "200 GB")
+	assert.Contains(t, output, "READ_ONLY")
+	assert.Equal(t, 1, strings.Count(output, "✓"), "only the boot disk is marked")
+}
+
+func TestRenderSnapshots(t *testing.T) {
+	output := renderSnapshots([]*model.Snapshot{
+		{Name: "data-1-20250102-060405", SourceDisk: "data-1", Status: "READY", DiskSizeGB: 200, StorageBytes: 3 << 30},
+	})
+
+	assert.Contains(t, output, "data-1-20250102-060405")
+	assert.Contains(t, output, "READY")
+	assert.Contains(t, output, "200 GB")
+	assert.Contains(t, output, "3 GB")
+	assert.Contains(t, output, "-", "unknown creation time is shown as a dash")
+}
+
+func TestRenderScheduledActions(t *testing.T) {
+	at := time.Date(2025, 1, 2, 19, 0, 0, 0, time.Local)
+	output := renderScheduledActions([]*model.ScheduledAction{
+		{At: at, VMName: "gpu-box", Project: "proj", Zone: "us-central1-a", Action: model.ScheduledStop, Reason: "ttl 3h0m0s"},
+	})
+
+	assert.Contains(t, output, "gpu-box")
+	assert.Contains(t, output, "stop")
+	assert.Contains(t, output, "2025-01-02 19:00")
+	assert.Contains(t, output, "ttl 3h0m0s")
+}
+
+func TestRenderGPUAvailability(t *testing.T) {
+	output := renderGPUAvailability([]GPUAvailabilityRow{
+		{Zone: "us-central1-a", Type: "nvidia-tesla-a100", MaxCards: 16, Quota: &model.Quota{Limit: 16, Usage: 4}, Likely: true},
+		{Zone: "us-central1-b", Type: "nvidia-a100-80gb", MaxCards: 8},
+	})
+
+	assert.Contains(t, output, "nvidia-tesla-a100")
+	assert.Contains(t, output, "4/16")
+	assert.Contains(t, output, " 12 ")
+	assert.Contains(t, output, "yes")
+	assert.Contains(t, output, "no")
+}
+
+func TestRenderProjects(t *testing.T) {
+	output := renderProjects([]*model.Project{{ID: "my-project", Name: "My Project", Number: 123456789012}})
+
+	assert.Contains(t, output, "Project ID")
+	assert.Contains(t, output, "my-project")
+	assert.Contains(t, output, "My Project")
+	assert.Contains(t, output, "123456789012")
+}
+
+func TestRenderZones(t *testing.T) {
+	output := renderZones([]*model.Zone{{Name: "us-central1-a", Region: "us-central1", Status: "UP"}})
+
+	assert.Contains(t, output, "us-central1-a")
+	assert.Contains(t, output, "us-central1 ")
+	assert.Contains(t, output, "UP")
+}
+
+func TestRenderProfiles(t *testing.T) {
+	output := renderProfiles([]*model.Profile{
+		{Name: "default"},
+		{Name: "work", ConfigPath: "/etc/gcectl/work.yaml", Project: "work-proj"},
+	}, "work")
+
+	lines := strings.Split(output, "\n")
+	var workLine, defaultLine string
+	for _, line := range lines {
+		switch {
+		case strings.Contains(line, "work-proj"):
+			workLine = line
+		case strings.Contains(line, "default"):
+			defaultLine = line
+		}
+	}
+	assert.Contains(t, workLine, "*")
+	assert.Contains(t, workLine, "/etc/gcectl/work.yaml")
+	assert.NotContains(t, defaultLine, "*")
+	assert.Contains(t, defaultLine, "-")
+}
+
+func TestRenderCheckResults(t *testing.T) {
+	output := renderCheckResults([]model.CheckResult{
+		{Name: "Credentials", Status: model.CheckPassed, Detail: "user credentials"},
+		{Name: "Compute API in p", Status: model.CheckFailed, Detail: "disabled", Fix: "Run `gcloud services enable compute.googleapis.com --project p`"},
+		{Name: "IAM permissions in p", Status: model.CheckSkipped, Detail: "needs the Compute Engine API"},
+	})
+
+	lines := strings.Split(output, "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, "✅ Credentials: user credentials", lines[0])
+	assert.Equal(t, "❌ Compute API in p: disabled", lines[1])
+	assert.Contains(t, lines[2], "gcloud services enable compute.googleapis.com --project p")
+	assert.Equal(t, "➖ IAM permissions in p: skipped (needs the Compute Engine API)", lines[3])
+}
+
+func TestFormatLogEntry(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 6, 0, 3, 0, time.UTC)
+	got := formatLogEntry(&model.LogEntry{Timestamp: ts, Severity: "ERROR", Log: "syslog", Message: "disk full\n"})
+
+	assert.Equal(t, ts.Local().Format("2006-01-02 15:04:05")+"  ERROR    syslog: disk full", got)
+}
+
+func TestRenderMachineTypes(t *testing.T) {
+	output := renderMachineTypes([]*model.MachineType{
+		{Name: "e2-micro", GuestCPUs: 2, MemoryMB: 1024},
+		{Name: "e2-medium", GuestCPUs: 2, MemoryMB: 4096},
+		{Name: "f1-micro", GuestCPUs: 1, MemoryMB: 614},
+	})
+
+	assert.Equal(t, 1, strings.Count(output, " e2 "), "family is only shown on the first row of a group")
+	assert.Contains(t, output, " f1 ")
+	assert.Contains(t, output, "e2-medium")
+	assert.Contains(t, output, "4 GB")
+	assert.Contains(t, output, "0.60 GB")
+	assert.Contains(t, output, " 3 ", "rows are numbered for the picker")
+}
+
+func TestConsolePresenter_Prompt(t *testing.T) {
+	presenter := NewConsolePresenter()
+	presenter.input = bufio.NewReader(strings.NewReader("e2-medium\n2"))
+
+	got, err := presenter.Prompt("Machine type:")
+	require.NoError(t, err)
+	assert.Equal(t, "e2-medium", got)
+
+	got, err = presenter.Prompt("Machine type:")
+	require.NoError(t, err)
+	assert.Equal(t, "2", got, "the last line does not need a newline")
+
+	_, err = presenter.Prompt("Machine type:")
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestFormatUpdateNotice(t *testing.T) {
+	got := formatUpdateNotice("1.2.0", "v1.3.0", "https://github.com/haru-256/gcectl/releases/tag/v1.3.0")
+	assert.Contains(t, got, "1.2.0 → v1.3.0")
+	assert.Contains(t, got, "https://github.com/haru-256/gcectl/releases/tag/v1.3.0")
+	assert.Contains(t, got, "update-check: false")
+	assert.NotContains(t, got, "\n")
+}
+
+func TestErrorHint(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+		name string
+	}{
+		{name: "VM not in config", err: fmt.Errorf("%w: sandbox", model.ErrVMNotFoundInConfig), want: "gcectl list"},
+		{name: "permission denied", err: fmt.Errorf("failed to start instance: %w", model.ErrPermissionDenied), want: "roles/compute.instanceAdmin.v1"},
+		{name: "quota exceeded", err: fmt.Errorf("operation failed: %w", model.ErrQuotaExceeded), want: "quota"},
+		{name: "other error", err: errors.New("connection reset")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := errorHint(tt.err)
+			if tt.want == "" {
+				assert.Empty(t, got)
+				return
+			}
+			assert.Contains(t, got, tt.want)
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package presenter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// ErrInvalidQuery is returned when a --query expression cannot be parsed or applied.
+var ErrInvalidQuery = errors.New("invalid query")
+
+// RenderTemplate prints each item with the Go template format followed by a
+// newline, e.g. "{{.Name}} {{.Status}}".
+//
+// Parameters:
+//   - format: A text/template over VMListItem
+//   - items: The items to print
+//
+// Returns:
+//   - error: Error if format is not a valid template or fails to execute
+func (p *ConsolePresenter) RenderTemplate(format string, items []VMListItem) error {
+	return renderTemplate(os.Stdout, format, items)
+}
+
+func renderTemplate(w io.Writer, format string, items []VMListItem) error {
+	tmpl, err := template.New("format").Option("missingkey=zero").Parse(format)
+	if err != nil {
+		return fmt.Errorf("invalid format: %w", err)
+	}
+	for _, item := range items {
+		if err := tmpl.Execute(w, item); err != nil {
+			return fmt.Errorf("failed to format %s: %w", item.Name, err)
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+// RenderQuery prints the values query selects from data, one per line. Strings
+// and numbers are printed as is, objects and arrays as JSON.
+//
+// The query is a jq-like path: ".Name", ".Labels.env", ".Disks[0].Name", or
+// ".[].Name" to select a field of every element. Field names match case
+// insensitively.
+//
+// Parameters:
+//   - query: The path to select
+//   - data: A VMListItem, or a slice of them
+//
+// Returns:
+//   - error: ErrInvalidQuery if query is malformed or selects into a value of the wrong kind
+func (p *ConsolePresenter) RenderQuery(query string, data any) error {
+	return renderQuery(os.Stdout, query, data)
+}
+
+func renderQuery(w io.Writer, query string, data any) error {
+	root, err := queryValue(data)
+	if err != nil {
+		return err
+	}
+	results, err := applyQuery(query, root)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		line, err := formatQueryResult(result)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, line)
+	}
+	return nil
+}
+
+// WriteVMDetailsJSON writes data as indented JSON with the same fields --query
+// selects from, e.g. the status as its name.
+//
+// Parameters:
+//   - w: Destination of the JSON
+//   - data: A VMDetail, or a slice of them
+//
+// Returns:
+//   - error: An error if encoding or writing fails
+func WriteVMDetailsJSON(w io.Writer, data any) error {
+	v, err := queryValue(data)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// queryValue converts data to the generic maps and slices of decoded JSON,
+// with each VM status as its name instead of its number.
+func queryValue(data any) (any, error) {
+	switch d := data.(type) {
+	case VMListItem:
+		return itemQueryValue(d)
+	case []VMListItem:
+		values := make([]any, len(d))
+		for i, item := range d {
+			v, err := itemQueryValue(item)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = v
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("cannot query %T", data)
+}
+
+func itemQueryValue(item VMListItem) (any, error) {
+	b, err := json.Marshal(item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", item.Name, err)
+	}
+	var v map[string]any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", item.Name, err)
+	}
+	v["Status"] = item.Status.String()
+	delete(v, "Loading")
+	delete(v, "Failed")
+	return v, nil
+}
+
+// querySegment is one step of a query: a field, an index, or all elements.
+type querySegment struct {
+	field string
+	index int
+	all   bool
+}
+
+// parseQuery splits query into its segments, e.g. ".Disks[0].Name" into
+// field Disks, index 0 and field Name.
+func parseQuery(query string) ([]querySegment, error) {
+	rest := strings.TrimSpace(query)
+	if !strings.HasPrefix(rest, ".") {
+		return nil, fmt.Errorf("%w: %q must start with '.'", ErrInvalidQuery, query)
+	}
+	var segments []querySegment
+	for rest != "" {
+		switch {
+		case rest == ".":
+			rest = ""
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("%w: %q has an unclosed '['", ErrInvalidQuery, query)
+			}
+			inner := rest[1:end]
+			rest = rest[end+1:]
+			if inner == "" {
+				segments = append(segments, querySegment{all: true})
+				continue
+			}
+			index, err := strconv.Atoi(inner)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q has a non-numeric index %q", ErrInvalidQuery, query, inner)
+			}
+			segments = append(segments, querySegment{index: index})
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				if strings.HasPrefix(rest, "[") {
+					continue
+				}
+				return nil, fmt.Errorf("%w: %q has an empty field name", ErrInvalidQuery, query)
+			}
+			segments = append(segments, querySegment{field: rest[:end]})
+			rest = rest[end:]
+		default:
+			return nil, fmt.Errorf("%w: unexpected %q in %q", ErrInvalidQuery, rest, query)
+		}
+	}
+	return segments, nil
+}
+
+// applyQuery returns the values query selects from root. A missing field
+// selects null, as in jq.
+func applyQuery(query string, root any) ([]any, error) {
+	segments, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	values := []any{root}
+	for _, seg := range segments {
+		var next []any
+		for _, v := range values {
+			selected, err := applySegment(seg, v)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q: %w", ErrInvalidQuery, query, err)
+			}
+			next = append(next, selected...)
+		}
+		values = next
+	}
+	return values, nil
+}
+
+func applySegment(seg querySegment, v any) ([]any, error) {
+	if v == nil {
+		return []any{nil}, nil
+	}
+	switch {
+	case seg.all:
+		switch c := v.(type) {
+		case []any:
+			return c, nil
+		case map[string]any:
+			values := make([]any, 0, len(c))
+			for _, key := range sortedKeys(c) {
+				values = append(values, c[key])
+			}
+			return values, nil
+		}
+		return nil, fmt.Errorf("cannot iterate over %s", kindOf(v))
+	case seg.field != "":
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("cannot select field %s of %s", seg.field, kindOf(v))
+		}
+		return []any{lookupField(m, seg.field)}, nil
+	}
+	s, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("cannot index %s", kindOf(v))
+	}
+	index := seg.index
+	if index < 0 {
+		index += len(s)
+	}
+	if index < 0 || index >= len(s) {
+		return []any{nil}, nil
+	}
+	return []any{s[index]}, nil
+}
+
+// lookupField returns the value of field in m, matching the name exactly first
+// and then case insensitively.
+func lookupField(m map[string]any, field string) any {
+	if v, ok := m[field]; ok {
+		return v
+	}
+	for _, key := range sortedKeys(m) {
+		if strings.EqualFold(key, field) {
+			return m[key]
+		}
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func kindOf(v any) string {
+	switch v.(type) {
+	case map[string]any:
+		return "an object"
+	case []any:
+		return "an array"
+	case string:
+		return "a string"
+	case float64:
+		return "a number"
+	case bool:
+		return "a boolean"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// formatQueryResult prints strings without quotes, like jq -r, and everything else as JSON.
+func formatQueryResult(v any) (string, error) {
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode query result: %w", err)
+	}
+	return string(b), nil
+}
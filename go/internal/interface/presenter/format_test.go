@@ -0,0 +1,92 @@
+package presenter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func formatTestItems() []VMListItem {
+	return []VMListItem{
+		{
+			Name:   "vm-1",
+			Status: model.StatusRunning,
+			Labels: map[string]string{"env": "dev"},
+			Disks:  []model.Disk{{Name: "vm-1", Boot: true, SizeGB: 10}, {Name: "data-1", SizeGB: 200}},
+		},
+		{Name: "vm-2", Status: model.StatusTerminated},
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, renderTemplate(&buf, "{{.Name}} {{.Status}}", formatTestItems()))
+	assert.Equal(t, "vm-1 RUNNING\nvm-2 TERMINATED\n", buf.String())
+
+	err := renderTemplate(&buf, "{{.Name", formatTestItems())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid format")
+
+	err = renderTemplate(&buf, "{{.Missing}}", formatTestItems())
+	require.Error(t, err)
+}
+
+func TestRenderQuery(t *testing.T) {
+	items := formatTestItems()
+	tests := []struct {
+		name  string
+		query string
+		data  any
+		want  string
+	}{
+		{name: "field of every item", query: ".[].Name", data: items, want: "vm-1\nvm-2\n"},
+		{name: "status as name", query: ".[].status", data: items, want: "RUNNING\nTERMINATED\n"},
+		{name: "map key", query: ".Labels.env", data: items[0], want: "dev\n"},
+		{name: "index", query: ".Disks[1].SizeGB", data: items[0], want: "200\n"},
+		{name: "negative index", query: ".Disks[-1].Name", data: items[0], want: "data-1\n"},
+		{name: "missing field", query: ".Labels.team", data: items[0], want: "null\n"},
+		{name: "object as JSON", query: ".Labels", data: items[0], want: "{\"env\":\"dev\"}\n"},
+		{name: "item by index", query: ".[1].Name", data: items, want: "vm-2\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, renderQuery(&buf, tt.query, tt.data))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestRenderQueryInvalid(t *testing.T) {
+	items := formatTestItems()
+	for _, query := range []string{"Name", ".Disks[x]", ".Disks[0", ".Name.first", ".Name[0]", ".Name[]"} {
+		t.Run(query, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := renderQuery(&buf, query, items[0])
+			require.ErrorIs(t, err, ErrInvalidQuery)
+		})
+	}
+}
+
+func TestWriteVMDetailsJSON(t *testing.T) {
+	items := formatTestItems()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteVMDetailsJSON(&buf, items))
+	var decoded []map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Len(t, decoded, 2)
+	assert.Equal(t, "vm-1", decoded[0]["Name"])
+	assert.Equal(t, "TERMINATED", decoded[1]["Status"])
+	assert.NotContains(t, decoded[0], "Loading")
+
+	buf.Reset()
+	require.NoError(t, WriteVMDetailsJSON(&buf, items[0]))
+	var single map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &single))
+	assert.Equal(t, "RUNNING", single["Status"])
+}
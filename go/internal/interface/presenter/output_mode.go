@@ -0,0 +1,61 @@
+package presenter
+
+import (
+	"sync/atomic"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/list"
+	"github.com/muesli/termenv"
+)
+
+// quietOutput suppresses success messages and progress, leaving errors and the primary output.
+var quietOutput atomic.Bool
+
+// asciiOutput replaces emoji, box-drawing and other non-ASCII characters with plain text.
+var asciiOutput atomic.Bool
+
+// SetQuiet makes Success and the progress of ExecuteWithProgress and
+// ExecuteWithVMProgress print nothing, so only errors and the primary output such
+// as tables remain. It backs the --quiet flag.
+func SetQuiet(quiet bool) {
+	quietOutput.Store(quiet)
+}
+
+// SetNoColor disables the colors and text attributes of all output. It backs the
+// --no-color flag and the NO_COLOR environment variable.
+func SetNoColor(noColor bool) {
+	if noColor {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
+
+// SetASCII makes the output use only ASCII characters, e.g. [RUN] instead of 🟢
+// and +--+ table borders, for CI logs and terminals without emoji. It backs the
+// --ascii flag.
+func SetASCII(ascii bool) {
+	asciiOutput.Store(ascii)
+}
+
+// marker returns symbol, or text in ASCII mode.
+func marker(symbol, text string) string {
+	if asciiOutput.Load() {
+		return text
+	}
+	return symbol
+}
+
+// tableBorder returns the border of all tables.
+func tableBorder() lipgloss.Border {
+	if asciiOutput.Load() {
+		return lipgloss.ASCIIBorder()
+	}
+	return lipgloss.NormalBorder()
+}
+
+// bulletEnumerator is list.Bullet, with "*" as the bullet in ASCII mode.
+func bulletEnumerator(items list.Items, i int) string {
+	if asciiOutput.Load() {
+		return "*"
+	}
+	return list.Bullet(items, i)
+}
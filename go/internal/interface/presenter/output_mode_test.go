@@ -0,0 +1,99 @@
+package presenter
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useASCII turns on ASCII mode for the duration of the test.
+func useASCII(t *testing.T) {
+	t.Helper()
+	SetASCII(true)
+	t.Cleanup(func() { SetASCII(false) })
+}
+
+// isASCII reports whether s only contains ASCII characters.
+func isASCII(s string) bool {
+	for _, r := range s {
+		if r > 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+func TestASCIIMode(t *testing.T) {
+	useASCII(t)
+
+	assert.Equal(t, "[RUN]", getStatusEmoji(model.StatusRunning))
+	assert.Equal(t, "[STOP]", getStatusEmoji(model.StatusTerminated))
+	assert.Equal(t, "[----]", getStatusEmoji(model.StatusProvisioning))
+	assert.Equal(t, "| Starting VMs 12s", spinnerLine(0, "Starting VMs", 12*time.Second, nil))
+
+	outputs := map[string]string{
+		"capabilities": renderCapabilities("sandbox", []model.CapabilityStatus{
+			{Capability: model.CapabilityStart, Command: "gcectl on", Supported: true},
+			{Capability: model.CapabilitySSH, Command: "gcectl ssh-config", Reason: "VM has no external IP"},
+		}),
+		"check results": renderCheckResults([]model.CheckResult{
+			{Name: "Credentials", Status: model.CheckPassed, Detail: "user credentials"},
+			{Name: "Compute API in p", Status: model.CheckFailed, Detail: "disabled"},
+			{Name: "IAM permissions in p", Status: model.CheckSkipped, Detail: "needs the Compute Engine API"},
+		}),
+		"disks": renderDisks([]*model.Disk{{Name: "boot", DeviceName: "persistent-disk-0", Boot: true}}),
+	}
+	for name, output := range outputs {
+		assert.True(t, isASCII(output), "%s output should be ASCII:\n%s", name, output)
+	}
+	assert.Contains(t, outputs["capabilities"], "[OK]")
+	assert.Contains(t, outputs["check results"], "[NG] Compute API in p: disabled")
+
+	for _, row := range [][]string{
+		vmListRow(VMListItem{Name: "vm", Loading: true}),
+		vmListRow(VMListItem{Name: "vm", Failed: true}),
+	} {
+		for _, cell := range row {
+			assert.True(t, isASCII(cell), "cell %q should be ASCII", cell)
+		}
+	}
+}
+
+func TestQuietMode(t *testing.T) {
+	SetQuiet(true)
+	t.Cleanup(func() { SetQuiet(false) })
+	presenter := NewConsolePresenter()
+
+	old := os.Stdout
+	r, w, err := os.Pipe()
+	require.NoError(t, err, "Failed to create pipe")
+	os.Stdout = w
+
+	presenter.Success("VM started")
+	ran := false
+	err = presenter.ExecuteWithProgress(context.Background(), "Starting VM", func(context.Context) error {
+		ran = true
+		return nil
+	})
+	presenter.Error("failed")
+
+	require.NoError(t, w.Close(), "Failed to close write pipe")
+	os.Stdout = old
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, r)
+	require.NoError(t, err, "Failed to copy output")
+
+	assert.True(t, ran)
+	assert.NotContains(t, buf.String(), "VM started")
+	assert.NotContains(t, buf.String(), "Starting VM")
+	assert.Contains(t, buf.String(), "[ERROR]")
+}
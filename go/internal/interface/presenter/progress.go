@@ -0,0 +1,204 @@
+package presenter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// spinnerFrames are drawn in turn, one per spinnerInterval.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// asciiSpinnerFrames replace spinnerFrames in ASCII mode.
+var asciiSpinnerFrames = []string{"|", "/", "-", "\\"}
+
+const spinnerInterval = 100 * time.Millisecond
+
+// plainProgress forces the dot progress of ExecuteWithProgress even on a terminal.
+var plainProgress atomic.Bool
+
+// SetPlainProgress makes ExecuteWithProgress print dots instead of a spinner, as it
+// always does when stdout is not a terminal. It backs the --plain flag.
+func SetPlainProgress(plain bool) {
+	plainProgress.Store(plain)
+}
+
+// OperationProgress reports how far the GCP operations a command waits for are.
+type OperationProgress interface {
+	// Progress returns the completion percentage; ok is false when no operation is running.
+	Progress() (percent int, ok bool)
+}
+
+type operationProgressKey struct{}
+
+// WithOperationProgress returns ctx carrying src, which the spinner of
+// ExecuteWithProgress shows the percentage of.
+func WithOperationProgress(ctx context.Context, src OperationProgress) context.Context {
+	return context.WithValue(ctx, operationProgressKey{}, src)
+}
+
+func operationProgressFrom(ctx context.Context) OperationProgress {
+	src, _ := ctx.Value(operationProgressKey{}).(OperationProgress)
+	return src
+}
+
+// spinnerLine renders one frame of the spinner, e.g. "⠋ Starting VMs 12s 40%".
+func spinnerLine(frame int, message string, elapsed time.Duration, src OperationProgress) string {
+	frames := spinnerFrames
+	if asciiOutput.Load() {
+		frames = asciiSpinnerFrames
+	}
+	line := fmt.Sprintf("%s %s %s", frames[frame%len(frames)], message, elapsed.Truncate(time.Second))
+	if src == nil {
+		return line
+	}
+	if percent, ok := src.Progress(); ok {
+		line += fmt.Sprintf(" %d%%", percent)
+	}
+	return line
+}
+
+// spin redraws the spinner line in place until done is closed, then replaces it
+// with the message and the total elapsed time.
+func (p *ConsolePresenter) spin(ctx context.Context, message string, done <-chan struct{}) {
+	src := operationProgressFrom(ctx)
+	start := time.Now()
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+
+	for frame := 0; ; frame++ {
+		fmt.Print("\r\033[K" + spinnerLine(frame, message, time.Since(start), src))
+		select {
+		case <-ctx.Done():
+		case <-done:
+		case <-ticker.C:
+			continue
+		}
+		fmt.Printf("\r\033[K%s (%s)", message, time.Since(start).Truncate(time.Second))
+		return
+	}
+}
+
+// LiveVMProgress shows one line per VM of a bulk operation with its phase and
+// elapsed time, redrawn in place. It must only be used when stdout is a terminal.
+type LiveVMProgress struct {
+	start time.Time
+	now   func() time.Time
+	rows  []vmProgressRow
+	lines int
+	mu    sync.Mutex
+}
+
+type vmProgressRow struct {
+	name     string
+	phase    string
+	elapsed  time.Duration
+	finished bool
+}
+
+func newLiveVMProgress(names []string, now func() time.Time) *LiveVMProgress {
+	l := &LiveVMProgress{start: now(), now: now, rows: make([]vmProgressRow, len(names))}
+	for i, name := range names {
+		l.rows[i] = vmProgressRow{name: name, phase: "pending"}
+	}
+	return l
+}
+
+// Update sets the phase of the named VM and redraws the lines. Once finished is
+// true the elapsed time of the VM stops. It is safe for concurrent use and
+// satisfies usecase.VMProgress.
+func (l *LiveVMProgress) Update(name, phase string, finished bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i := range l.rows {
+		if l.rows[i].name != name || l.rows[i].finished {
+			continue
+		}
+		l.rows[i].phase = phase
+		l.rows[i].finished = finished
+		l.rows[i].elapsed = l.now().Sub(l.start)
+	}
+	l.redraw()
+}
+
+// render returns the lines of all VMs, with the names aligned.
+func (l *LiveVMProgress) render() string {
+	width := 0
+	for _, row := range l.rows {
+		width = max(width, len(row.name))
+	}
+	lines := make([]string, len(l.rows))
+	for i, row := range l.rows {
+		elapsed := row.elapsed
+		if !row.finished {
+			elapsed = l.now().Sub(l.start)
+		}
+		lines[i] = fmt.Sprintf("  %-*s  %-10s %s", width, row.name, row.phase, elapsed.Truncate(time.Second))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// redraw moves the cursor back over the previous render and prints the lines again.
+func (l *LiveVMProgress) redraw() {
+	if l.lines > 0 {
+		fmt.Printf("\033[%dA\033[J", l.lines)
+	}
+	rendered := l.render()
+	fmt.Println(rendered)
+	l.lines = strings.Count(rendered, "\n") + 1
+}
+
+// ExecuteWithVMProgress runs fn like ExecuteWithProgress, but on a terminal shows
+// one line per VM updated through the report function fn receives. With a single
+// VM, --plain, --quiet or without a terminal it falls back to ExecuteWithProgress and the
+// reports are dropped.
+//
+// Parameters:
+//   - ctx: Context for cancellation control
+//   - message: Progress message (e.g., "Starting VMs")
+//   - names: The VMs fn reports on, in display order
+//   - fn: The function to execute
+//
+// Returns:
+//   - error: Error from the executed function, or nil on success
+func (p *ConsolePresenter) ExecuteWithVMProgress(ctx context.Context, message string, names []string,
+	fn func(ctx context.Context, report func(name, phase string, finished bool)) error,
+) error {
+	if len(names) < 2 || plainProgress.Load() || quietOutput.Load() || !p.IsInteractive() {
+		return p.ExecuteWithProgress(ctx, message, func(ctx context.Context) error {
+			return fn(ctx, func(string, string, bool) {})
+		})
+	}
+
+	fmt.Println(message)
+	live := newLiveVMProgress(names, time.Now)
+	live.mu.Lock()
+	live.redraw()
+	live.mu.Unlock()
+
+	done := make(chan struct{})
+	redrawn := make(chan struct{})
+	go func() {
+		defer close(redrawn)
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				live.mu.Lock()
+				live.redraw()
+				live.mu.Unlock()
+			}
+		}
+	}()
+
+	err := fn(ctx, live.Update)
+	close(done)
+	<-redrawn
+	return err
+}
@@ -0,0 +1,45 @@
+package presenter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fixedProgress struct {
+	percent int
+	ok      bool
+}
+
+func (f fixedProgress) Progress() (int, bool) {
+	return f.percent, f.ok
+}
+
+func TestSpinnerLine(t *testing.T) {
+	elapsed := 12*time.Second + 300*time.Millisecond
+
+	assert.Equal(t, "⠋ Starting VMs 12s", spinnerLine(0, "Starting VMs", elapsed, nil))
+	assert.Equal(t, "⠙ Starting VMs 12s", spinnerLine(1, "Starting VMs", elapsed, fixedProgress{}))
+	assert.Equal(t, "⠋ Starting VMs 12s 40%", spinnerLine(len(spinnerFrames), "Starting VMs", elapsed, fixedProgress{percent: 40, ok: true}))
+}
+
+func TestWithOperationProgress(t *testing.T) {
+	assert.Nil(t, operationProgressFrom(context.Background()))
+
+	src := fixedProgress{percent: 40, ok: true}
+	assert.Equal(t, src, operationProgressFrom(WithOperationProgress(context.Background(), src)))
+}
+
+func TestLiveVMProgressRender(t *testing.T) {
+	start := time.Date(2025, 1, 2, 6, 0, 0, 0, time.UTC)
+	now := start
+	l := newLiveVMProgress([]string{"vm1", "gpu-box"}, func() time.Time { return now })
+
+	// vm1 finished after 5s; the elapsed time of gpu-box keeps running.
+	l.rows[0] = vmProgressRow{name: "vm1", phase: "done", elapsed: 5 * time.Second, finished: true}
+	now = start.Add(12 * time.Second)
+
+	assert.Equal(t, "  vm1      done       5s\n  gpu-box  pending    12s", l.render())
+}
@@ -12,6 +12,7 @@ package mock_cli
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/haru-256/gcectl/internal/domain/model"
 	gomock "go.uber.org/mock/gomock"
@@ -55,6 +56,21 @@ func (mr *MockVMRepositoryCloserMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockVMRepositoryCloser)(nil).Close))
 }
 
+// FindAll mocks base method.
+func (m *MockVMRepositoryCloser) FindAll(ctx context.Context, vms []*model.VM) ([]*model.VM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, vms)
+	ret0, _ := ret[0].([]*model.VM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockVMRepositoryCloserMockRecorder) FindAll(ctx, vms any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockVMRepositoryCloser)(nil).FindAll), ctx, vms)
+}
+
 // FindByName mocks base method.
 func (m *MockVMRepositoryCloser) FindByName(ctx context.Context, vm *model.VM) (*model.VM, error) {
 	m.ctrl.T.Helper()
@@ -70,6 +86,107 @@ func (mr *MockVMRepositoryCloserMockRecorder) FindByName(ctx, vm any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockVMRepositoryCloser)(nil).FindByName), ctx, vm)
 }
 
+// GetMetadata mocks base method.
+func (m *MockVMRepositoryCloser) GetMetadata(ctx context.Context, vm *model.VM) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetadata", ctx, vm)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetadata indicates an expected call of GetMetadata.
+func (mr *MockVMRepositoryCloserMockRecorder) GetMetadata(ctx, vm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetadata", reflect.TypeOf((*MockVMRepositoryCloser)(nil).GetMetadata), ctx, vm)
+}
+
+// GetRaw mocks base method.
+func (m *MockVMRepositoryCloser) GetRaw(ctx context.Context, vm *model.VM) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRaw", ctx, vm)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRaw indicates an expected call of GetRaw.
+func (mr *MockVMRepositoryCloserMockRecorder) GetRaw(ctx, vm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRaw", reflect.TypeOf((*MockVMRepositoryCloser)(nil).GetRaw), ctx, vm)
+}
+
+// ListByProject mocks base method.
+func (m *MockVMRepositoryCloser) ListByProject(ctx context.Context, project string, selector map[string]string) ([]*model.VM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByProject", ctx, project, selector)
+	ret0, _ := ret[0].([]*model.VM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByProject indicates an expected call of ListByProject.
+func (mr *MockVMRepositoryCloserMockRecorder) ListByProject(ctx, project, selector any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByProject", reflect.TypeOf((*MockVMRepositoryCloser)(nil).ListByProject), ctx, project, selector)
+}
+
+// Reset mocks base method.
+func (m *MockVMRepositoryCloser) Reset(ctx context.Context, vm *model.VM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx, vm)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockVMRepositoryCloserMockRecorder) Reset(ctx, vm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockVMRepositoryCloser)(nil).Reset), ctx, vm)
+}
+
+// SetAccelerators mocks base method.
+func (m *MockVMRepositoryCloser) SetAccelerators(ctx context.Context, vm *model.VM, accelerators []model.Accelerator) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAccelerators", ctx, vm, accelerators)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAccelerators indicates an expected call of SetAccelerators.
+func (mr *MockVMRepositoryCloserMockRecorder) SetAccelerators(ctx, vm, accelerators any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAccelerators", reflect.TypeOf((*MockVMRepositoryCloser)(nil).SetAccelerators), ctx, vm, accelerators)
+}
+
+// SetLabels mocks base method.
+func (m *MockVMRepositoryCloser) SetLabels(ctx context.Context, vm *model.VM, labels map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLabels", ctx, vm, labels)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLabels indicates an expected call of SetLabels.
+func (mr *MockVMRepositoryCloserMockRecorder) SetLabels(ctx, vm, labels any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLabels", reflect.TypeOf((*MockVMRepositoryCloser)(nil).SetLabels), ctx, vm, labels)
+}
+
+// SetMetadata mocks base method.
+func (m *MockVMRepositoryCloser) SetMetadata(ctx context.Context, vm *model.VM, items map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMetadata", ctx, vm, items)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMetadata indicates an expected call of SetMetadata.
+func (mr *MockVMRepositoryCloserMockRecorder) SetMetadata(ctx, vm, items any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMetadata", reflect.TypeOf((*MockVMRepositoryCloser)(nil).SetMetadata), ctx, vm, items)
+}
+
 // SetSchedulePolicy mocks base method.
 func (m *MockVMRepositoryCloser) SetSchedulePolicy(ctx context.Context, vm *model.VM, policyName string) error {
 	m.ctrl.T.Helper()
@@ -84,6 +201,34 @@ func (mr *MockVMRepositoryCloserMockRecorder) SetSchedulePolicy(ctx, vm, policyN
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSchedulePolicy", reflect.TypeOf((*MockVMRepositoryCloser)(nil).SetSchedulePolicy), ctx, vm, policyName)
 }
 
+// SetScheduling mocks base method.
+func (m *MockVMRepositoryCloser) SetScheduling(ctx context.Context, vm *model.VM, scheduling model.Scheduling) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetScheduling", ctx, vm, scheduling)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetScheduling indicates an expected call of SetScheduling.
+func (mr *MockVMRepositoryCloserMockRecorder) SetScheduling(ctx, vm, scheduling any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetScheduling", reflect.TypeOf((*MockVMRepositoryCloser)(nil).SetScheduling), ctx, vm, scheduling)
+}
+
+// SetSchedulingOptions mocks base method.
+func (m *MockVMRepositoryCloser) SetSchedulingOptions(ctx context.Context, vm *model.VM, opts model.SchedulingOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSchedulingOptions", ctx, vm, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSchedulingOptions indicates an expected call of SetSchedulingOptions.
+func (mr *MockVMRepositoryCloserMockRecorder) SetSchedulingOptions(ctx, vm, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSchedulingOptions", reflect.TypeOf((*MockVMRepositoryCloser)(nil).SetSchedulingOptions), ctx, vm, opts)
+}
+
 // Start mocks base method.
 func (m *MockVMRepositoryCloser) Start(ctx context.Context, vm *model.VM) error {
 	m.ctrl.T.Helper()
@@ -98,6 +243,21 @@ func (mr *MockVMRepositoryCloserMockRecorder) Start(ctx, vm any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockVMRepositoryCloser)(nil).Start), ctx, vm)
 }
 
+// StartAsync mocks base method.
+func (m *MockVMRepositoryCloser) StartAsync(ctx context.Context, vm *model.VM) (*model.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartAsync", ctx, vm)
+	ret0, _ := ret[0].(*model.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartAsync indicates an expected call of StartAsync.
+func (mr *MockVMRepositoryCloserMockRecorder) StartAsync(ctx, vm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartAsync", reflect.TypeOf((*MockVMRepositoryCloser)(nil).StartAsync), ctx, vm)
+}
+
 // Stop mocks base method.
 func (m *MockVMRepositoryCloser) Stop(ctx context.Context, vm *model.VM) error {
 	m.ctrl.T.Helper()
@@ -112,6 +272,21 @@ func (mr *MockVMRepositoryCloserMockRecorder) Stop(ctx, vm any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockVMRepositoryCloser)(nil).Stop), ctx, vm)
 }
 
+// StopAsync mocks base method.
+func (m *MockVMRepositoryCloser) StopAsync(ctx context.Context, vm *model.VM) (*model.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopAsync", ctx, vm)
+	ret0, _ := ret[0].(*model.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopAsync indicates an expected call of StopAsync.
+func (mr *MockVMRepositoryCloserMockRecorder) StopAsync(ctx, vm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopAsync", reflect.TypeOf((*MockVMRepositoryCloser)(nil).StopAsync), ctx, vm)
+}
+
 // UnsetSchedulePolicy mocks base method.
 func (m *MockVMRepositoryCloser) UnsetSchedulePolicy(ctx context.Context, vm *model.VM, policyName string) error {
 	m.ctrl.T.Helper()
@@ -126,6 +301,20 @@ func (mr *MockVMRepositoryCloserMockRecorder) UnsetSchedulePolicy(ctx, vm, polic
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsetSchedulePolicy", reflect.TypeOf((*MockVMRepositoryCloser)(nil).UnsetSchedulePolicy), ctx, vm, policyName)
 }
 
+// UpdateLabels mocks base method.
+func (m *MockVMRepositoryCloser) UpdateLabels(ctx context.Context, vm *model.VM, set map[string]string, remove []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLabels", ctx, vm, set, remove)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLabels indicates an expected call of UpdateLabels.
+func (mr *MockVMRepositoryCloserMockRecorder) UpdateLabels(ctx, vm, set, remove any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLabels", reflect.TypeOf((*MockVMRepositoryCloser)(nil).UpdateLabels), ctx, vm, set, remove)
+}
+
 // UpdateMachineType mocks base method.
 func (m *MockVMRepositoryCloser) UpdateMachineType(ctx context.Context, vm *model.VM, machineType string) error {
 	m.ctrl.T.Helper()
@@ -139,3 +328,797 @@ func (mr *MockVMRepositoryCloserMockRecorder) UpdateMachineType(ctx, vm, machine
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMachineType", reflect.TypeOf((*MockVMRepositoryCloser)(nil).UpdateMachineType), ctx, vm, machineType)
 }
+
+// UpdateMetadata mocks base method.
+func (m *MockVMRepositoryCloser) UpdateMetadata(ctx context.Context, vm *model.VM, set map[string]string, remove []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMetadata", ctx, vm, set, remove)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMetadata indicates an expected call of UpdateMetadata.
+func (mr *MockVMRepositoryCloserMockRecorder) UpdateMetadata(ctx, vm, set, remove any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetadata", reflect.TypeOf((*MockVMRepositoryCloser)(nil).UpdateMetadata), ctx, vm, set, remove)
+}
+
+// MockOperationRepositoryCloser is a mock of OperationRepositoryCloser interface.
+type MockOperationRepositoryCloser struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperationRepositoryCloserMockRecorder
+	isgomock struct{}
+}
+
+// MockOperationRepositoryCloserMockRecorder is the mock recorder for MockOperationRepositoryCloser.
+type MockOperationRepositoryCloserMockRecorder struct {
+	mock *MockOperationRepositoryCloser
+}
+
+// NewMockOperationRepositoryCloser creates a new mock instance.
+func NewMockOperationRepositoryCloser(ctrl *gomock.Controller) *MockOperationRepositoryCloser {
+	mock := &MockOperationRepositoryCloser{ctrl: ctrl}
+	mock.recorder = &MockOperationRepositoryCloserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperationRepositoryCloser) EXPECT() *MockOperationRepositoryCloserMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockOperationRepositoryCloser) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockOperationRepositoryCloserMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockOperationRepositoryCloser)(nil).Close))
+}
+
+// Get mocks base method.
+func (m *MockOperationRepositoryCloser) Get(ctx context.Context, op *model.Operation) (*model.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, op)
+	ret0, _ := ret[0].(*model.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockOperationRepositoryCloserMockRecorder) Get(ctx, op any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockOperationRepositoryCloser)(nil).Get), ctx, op)
+}
+
+// ListByTarget mocks base method.
+func (m *MockOperationRepositoryCloser) ListByTarget(ctx context.Context, vm *model.VM, limit int) ([]*model.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTarget", ctx, vm, limit)
+	ret0, _ := ret[0].([]*model.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTarget indicates an expected call of ListByTarget.
+func (mr *MockOperationRepositoryCloserMockRecorder) ListByTarget(ctx, vm, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTarget", reflect.TypeOf((*MockOperationRepositoryCloser)(nil).ListByTarget), ctx, vm, limit)
+}
+
+// ListSystemEvents mocks base method.
+func (m *MockOperationRepositoryCloser) ListSystemEvents(ctx context.Context, vm *model.VM, limit int) ([]*model.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSystemEvents", ctx, vm, limit)
+	ret0, _ := ret[0].([]*model.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSystemEvents indicates an expected call of ListSystemEvents.
+func (mr *MockOperationRepositoryCloserMockRecorder) ListSystemEvents(ctx, vm, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSystemEvents", reflect.TypeOf((*MockOperationRepositoryCloser)(nil).ListSystemEvents), ctx, vm, limit)
+}
+
+// Wait mocks base method.
+func (m *MockOperationRepositoryCloser) Wait(ctx context.Context, op *model.Operation) (*model.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Wait", ctx, op)
+	ret0, _ := ret[0].(*model.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Wait indicates an expected call of Wait.
+func (mr *MockOperationRepositoryCloserMockRecorder) Wait(ctx, op any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wait", reflect.TypeOf((*MockOperationRepositoryCloser)(nil).Wait), ctx, op)
+}
+
+// MockSchedulePolicyRepositoryCloser is a mock of SchedulePolicyRepositoryCloser interface.
+type MockSchedulePolicyRepositoryCloser struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchedulePolicyRepositoryCloserMockRecorder
+	isgomock struct{}
+}
+
+// MockSchedulePolicyRepositoryCloserMockRecorder is the mock recorder for MockSchedulePolicyRepositoryCloser.
+type MockSchedulePolicyRepositoryCloserMockRecorder struct {
+	mock *MockSchedulePolicyRepositoryCloser
+}
+
+// NewMockSchedulePolicyRepositoryCloser creates a new mock instance.
+func NewMockSchedulePolicyRepositoryCloser(ctrl *gomock.Controller) *MockSchedulePolicyRepositoryCloser {
+	mock := &MockSchedulePolicyRepositoryCloser{ctrl: ctrl}
+	mock.recorder = &MockSchedulePolicyRepositoryCloserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchedulePolicyRepositoryCloser) EXPECT() *MockSchedulePolicyRepositoryCloserMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockSchedulePolicyRepositoryCloser) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockSchedulePolicyRepositoryCloserMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockSchedulePolicyRepositoryCloser)(nil).Close))
+}
+
+// Create mocks base method.
+func (m *MockSchedulePolicyRepositoryCloser) Create(ctx context.Context, policy *model.SchedulePolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSchedulePolicyRepositoryCloserMockRecorder) Create(ctx, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSchedulePolicyRepositoryCloser)(nil).Create), ctx, policy)
+}
+
+// List mocks base method.
+func (m *MockSchedulePolicyRepositoryCloser) List(ctx context.Context, project, region string) ([]*model.SchedulePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, project, region)
+	ret0, _ := ret[0].([]*model.SchedulePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockSchedulePolicyRepositoryCloserMockRecorder) List(ctx, project, region any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSchedulePolicyRepositoryCloser)(nil).List), ctx, project, region)
+}
+
+// MockMachineTypeRepositoryCloser is a mock of MachineTypeRepositoryCloser interface.
+type MockMachineTypeRepositoryCloser struct {
+	ctrl     *gomock.Controller
+	recorder *MockMachineTypeRepositoryCloserMockRecorder
+	isgomock struct{}
+}
+
+// MockMachineTypeRepositoryCloserMockRecorder is the mock recorder for MockMachineTypeRepositoryCloser.
+type MockMachineTypeRepositoryCloserMockRecorder struct {
+	mock *MockMachineTypeRepositoryCloser
+}
+
+// NewMockMachineTypeRepositoryCloser creates a new mock instance.
+func NewMockMachineTypeRepositoryCloser(ctrl *gomock.Controller) *MockMachineTypeRepositoryCloser {
+	mock := &MockMachineTypeRepositoryCloser{ctrl: ctrl}
+	mock.recorder = &MockMachineTypeRepositoryCloserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMachineTypeRepositoryCloser) EXPECT() *MockMachineTypeRepositoryCloserMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockMachineTypeRepositoryCloser) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockMachineTypeRepositoryCloserMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockMachineTypeRepositoryCloser)(nil).Close))
+}
+
+// List mocks base method.
+func (m *MockMachineTypeRepositoryCloser) List(ctx context.Context, project, zone string) ([]*model.MachineType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, project, zone)
+	ret0, _ := ret[0].([]*model.MachineType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockMachineTypeRepositoryCloserMockRecorder) List(ctx, project, zone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMachineTypeRepositoryCloser)(nil).List), ctx, project, zone)
+}
+
+// MockAcceleratorTypeRepositoryCloser is a mock of AcceleratorTypeRepositoryCloser interface.
+type MockAcceleratorTypeRepositoryCloser struct {
+	ctrl     *gomock.Controller
+	recorder *MockAcceleratorTypeRepositoryCloserMockRecorder
+	isgomock struct{}
+}
+
+// MockAcceleratorTypeRepositoryCloserMockRecorder is the mock recorder for MockAcceleratorTypeRepositoryCloser.
+type MockAcceleratorTypeRepositoryCloserMockRecorder struct {
+	mock *MockAcceleratorTypeRepositoryCloser
+}
+
+// NewMockAcceleratorTypeRepositoryCloser creates a new mock instance.
+func NewMockAcceleratorTypeRepositoryCloser(ctrl *gomock.Controller) *MockAcceleratorTypeRepositoryCloser {
+	mock := &MockAcceleratorTypeRepositoryCloser{ctrl: ctrl}
+	mock.recorder = &MockAcceleratorTypeRepositoryCloserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAcceleratorTypeRepositoryCloser) EXPECT() *MockAcceleratorTypeRepositoryCloserMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockAcceleratorTypeRepositoryCloser) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockAcceleratorTypeRepositoryCloserMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockAcceleratorTypeRepositoryCloser)(nil).Close))
+}
+
+// List mocks base method.
+func (m *MockAcceleratorTypeRepositoryCloser) List(ctx context.Context, project, zone string) ([]*model.AcceleratorType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, project, zone)
+	ret0, _ := ret[0].([]*model.AcceleratorType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAcceleratorTypeRepositoryCloserMockRecorder) List(ctx, project, zone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAcceleratorTypeRepositoryCloser)(nil).List), ctx, project, zone)
+}
+
+// MockDiskRepositoryCloser is a mock of DiskRepositoryCloser interface.
+type MockDiskRepositoryCloser struct {
+	ctrl     *gomock.Controller
+	recorder *MockDiskRepositoryCloserMockRecorder
+	isgomock struct{}
+}
+
+// MockDiskRepositoryCloserMockRecorder is the mock recorder for MockDiskRepositoryCloser.
+type MockDiskRepositoryCloserMockRecorder struct {
+	mock *MockDiskRepositoryCloser
+}
+
+// NewMockDiskRepositoryCloser creates a new mock instance.
+func NewMockDiskRepositoryCloser(ctrl *gomock.Controller) *MockDiskRepositoryCloser {
+	mock := &MockDiskRepositoryCloser{ctrl: ctrl}
+	mock.recorder = &MockDiskRepositoryCloserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDiskRepositoryCloser) EXPECT() *MockDiskRepositoryCloserMockRecorder {
+	return m.recorder
+}
+
+// Attach mocks base method.
+func (m *MockDiskRepositoryCloser) Attach(ctx context.Context, vm *model.VM, diskName string, readOnly bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attach", ctx, vm, diskName, readOnly)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockDiskRepositoryCloserMockRecorder) Attach(ctx, vm, diskName, readOnly any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockDiskRepositoryCloser)(nil).Attach), ctx, vm, diskName, readOnly)
+}
+
+// Close mocks base method.
+func (m *MockDiskRepositoryCloser) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockDiskRepositoryCloserMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockDiskRepositoryCloser)(nil).Close))
+}
+
+// Detach mocks base method.
+func (m *MockDiskRepositoryCloser) Detach(ctx context.Context, vm *model.VM, deviceName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Detach", ctx, vm, deviceName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Detach indicates an expected call of Detach.
+func (mr *MockDiskRepositoryCloserMockRecorder) Detach(ctx, vm, deviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detach", reflect.TypeOf((*MockDiskRepositoryCloser)(nil).Detach), ctx, vm, deviceName)
+}
+
+// List mocks base method.
+func (m *MockDiskRepositoryCloser) List(ctx context.Context, vm *model.VM) ([]*model.Disk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, vm)
+	ret0, _ := ret[0].([]*model.Disk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockDiskRepositoryCloserMockRecorder) List(ctx, vm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDiskRepositoryCloser)(nil).List), ctx, vm)
+}
+
+// Resize mocks base method.
+func (m *MockDiskRepositoryCloser) Resize(ctx context.Context, vm *model.VM, diskName string, sizeGB int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resize", ctx, vm, diskName, sizeGB)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resize indicates an expected call of Resize.
+func (mr *MockDiskRepositoryCloserMockRecorder) Resize(ctx, vm, diskName, sizeGB any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resize", reflect.TypeOf((*MockDiskRepositoryCloser)(nil).Resize), ctx, vm, diskName, sizeGB)
+}
+
+// MockSnapshotRepositoryCloser is a mock of SnapshotRepositoryCloser interface.
+type MockSnapshotRepositoryCloser struct {
+	ctrl     *gomock.Controller
+	recorder *MockSnapshotRepositoryCloserMockRecorder
+	isgomock struct{}
+}
+
+// MockSnapshotRepositoryCloserMockRecorder is the mock recorder for MockSnapshotRepositoryCloser.
+type MockSnapshotRepositoryCloserMockRecorder struct {
+	mock *MockSnapshotRepositoryCloser
+}
+
+// NewMockSnapshotRepositoryCloser creates a new mock instance.
+func NewMockSnapshotRepositoryCloser(ctrl *gomock.Controller) *MockSnapshotRepositoryCloser {
+	mock := &MockSnapshotRepositoryCloser{ctrl: ctrl}
+	mock.recorder = &MockSnapshotRepositoryCloserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSnapshotRepositoryCloser) EXPECT() *MockSnapshotRepositoryCloserMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockSnapshotRepositoryCloser) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockSnapshotRepositoryCloserMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockSnapshotRepositoryCloser)(nil).Close))
+}
+
+// CreateAsync mocks base method.
+func (m *MockSnapshotRepositoryCloser) CreateAsync(ctx context.Context, vm *model.VM, diskName, snapshotName string) (*model.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAsync", ctx, vm, diskName, snapshotName)
+	ret0, _ := ret[0].(*model.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAsync indicates an expected call of CreateAsync.
+func (mr *MockSnapshotRepositoryCloserMockRecorder) CreateAsync(ctx, vm, diskName, snapshotName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAsync", reflect.TypeOf((*MockSnapshotRepositoryCloser)(nil).CreateAsync), ctx, vm, diskName, snapshotName)
+}
+
+// List mocks base method.
+func (m *MockSnapshotRepositoryCloser) List(ctx context.Context, vm *model.VM, diskNames []string) ([]*model.Snapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, vm, diskNames)
+	ret0, _ := ret[0].([]*model.Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockSnapshotRepositoryCloserMockRecorder) List(ctx, vm, diskNames any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSnapshotRepositoryCloser)(nil).List), ctx, vm, diskNames)
+}
+
+// MockMachineImageRepositoryCloser is a mock of MachineImageRepositoryCloser interface.
+type MockMachineImageRepositoryCloser struct {
+	ctrl     *gomock.Controller
+	recorder *MockMachineImageRepositoryCloserMockRecorder
+	isgomock struct{}
+}
+
+// MockMachineImageRepositoryCloserMockRecorder is the mock recorder for MockMachineImageRepositoryCloser.
+type MockMachineImageRepositoryCloserMockRecorder struct {
+	mock *MockMachineImageRepositoryCloser
+}
+
+// NewMockMachineImageRepositoryCloser creates a new mock instance.
+func NewMockMachineImageRepositoryCloser(ctrl *gomock.Controller) *MockMachineImageRepositoryCloser {
+	mock := &MockMachineImageRepositoryCloser{ctrl: ctrl}
+	mock.recorder = &MockMachineImageRepositoryCloserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMachineImageRepositoryCloser) EXPECT() *MockMachineImageRepositoryCloserMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockMachineImageRepositoryCloser) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockMachineImageRepositoryCloserMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockMachineImageRepositoryCloser)(nil).Close))
+}
+
+// Create mocks base method.
+func (m *MockMachineImageRepositoryCloser) Create(ctx context.Context, source *model.VM, imageName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, source, imageName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMachineImageRepositoryCloserMockRecorder) Create(ctx, source, imageName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMachineImageRepositoryCloser)(nil).Create), ctx, source, imageName)
+}
+
+// CreateInstance mocks base method.
+func (m *MockMachineImageRepositoryCloser) CreateInstance(ctx context.Context, imageName string, target *model.VM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInstance", ctx, imageName, target)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateInstance indicates an expected call of CreateInstance.
+func (mr *MockMachineImageRepositoryCloserMockRecorder) CreateInstance(ctx, imageName, target any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInstance", reflect.TypeOf((*MockMachineImageRepositoryCloser)(nil).CreateInstance), ctx, imageName, target)
+}
+
+// MockSerialPortRepositoryCloser is a mock of SerialPortRepositoryCloser interface.
+type MockSerialPortRepositoryCloser struct {
+	ctrl     *gomock.Controller
+	recorder *MockSerialPortRepositoryCloserMockRecorder
+	isgomock struct{}
+}
+
+// MockSerialPortRepositoryCloserMockRecorder is the mock recorder for MockSerialPortRepositoryCloser.
+type MockSerialPortRepositoryCloserMockRecorder struct {
+	mock *MockSerialPortRepositoryCloser
+}
+
+// NewMockSerialPortRepositoryCloser creates a new mock instance.
+func NewMockSerialPortRepositoryCloser(ctrl *gomock.Controller) *MockSerialPortRepositoryCloser {
+	mock := &MockSerialPortRepositoryCloser{ctrl: ctrl}
+	mock.recorder = &MockSerialPortRepositoryCloserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSerialPortRepositoryCloser) EXPECT() *MockSerialPortRepositoryCloserMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockSerialPortRepositoryCloser) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockSerialPortRepositoryCloserMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockSerialPortRepositoryCloser)(nil).Close))
+}
+
+// Read mocks base method.
+func (m *MockSerialPortRepositoryCloser) Read(ctx context.Context, vm *model.VM, port int32, start int64) (*model.SerialOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", ctx, vm, port, start)
+	ret0, _ := ret[0].(*model.SerialOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockSerialPortRepositoryCloserMockRecorder) Read(ctx, vm, port, start any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockSerialPortRepositoryCloser)(nil).Read), ctx, vm, port, start)
+}
+
+// MockLogRepositoryCloser is a mock of LogRepositoryCloser interface.
+type MockLogRepositoryCloser struct {
+	ctrl     *gomock.Controller
+	recorder *MockLogRepositoryCloserMockRecorder
+	isgomock struct{}
+}
+
+// MockLogRepositoryCloserMockRecorder is the mock recorder for MockLogRepositoryCloser.
+type MockLogRepositoryCloserMockRecorder struct {
+	mock *MockLogRepositoryCloser
+}
+
+// NewMockLogRepositoryCloser creates a new mock instance.
+func NewMockLogRepositoryCloser(ctrl *gomock.Controller) *MockLogRepositoryCloser {
+	mock := &MockLogRepositoryCloser{ctrl: ctrl}
+	mock.recorder = &MockLogRepositoryCloserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLogRepositoryCloser) EXPECT() *MockLogRepositoryCloserMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockLogRepositoryCloser) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockLogRepositoryCloserMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockLogRepositoryCloser)(nil).Close))
+}
+
+// List mocks base method.
+func (m *MockLogRepositoryCloser) List(ctx context.Context, vm *model.VM, since time.Time, filter string, limit int) ([]*model.LogEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, vm, since, filter, limit)
+	ret0, _ := ret[0].([]*model.LogEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockLogRepositoryCloserMockRecorder) List(ctx, vm, since, filter, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockLogRepositoryCloser)(nil).List), ctx, vm, since, filter, limit)
+}
+
+// MockMetricsRepositoryCloser is a mock of MetricsRepositoryCloser interface.
+type MockMetricsRepositoryCloser struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricsRepositoryCloserMockRecorder
+	isgomock struct{}
+}
+
+// MockMetricsRepositoryCloserMockRecorder is the mock recorder for MockMetricsRepositoryCloser.
+type MockMetricsRepositoryCloserMockRecorder struct {
+	mock *MockMetricsRepositoryCloser
+}
+
+// NewMockMetricsRepositoryCloser creates a new mock instance.
+func NewMockMetricsRepositoryCloser(ctrl *gomock.Controller) *MockMetricsRepositoryCloser {
+	mock := &MockMetricsRepositoryCloser{ctrl: ctrl}
+	mock.recorder = &MockMetricsRepositoryCloserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricsRepositoryCloser) EXPECT() *MockMetricsRepositoryCloserMockRecorder {
+	return m.recorder
+}
+
+// Average mocks base method.
+func (m *MockMetricsRepositoryCloser) Average(ctx context.Context, project string, instanceIDs []string, start, end time.Time) (map[string]*model.InstanceMetrics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Average", ctx, project, instanceIDs, start, end)
+	ret0, _ := ret[0].(map[string]*model.InstanceMetrics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Average indicates an expected call of Average.
+func (mr *MockMetricsRepositoryCloserMockRecorder) Average(ctx, project, instanceIDs, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Average", reflect.TypeOf((*MockMetricsRepositoryCloser)(nil).Average), ctx, project, instanceIDs, start, end)
+}
+
+// Close mocks base method.
+func (m *MockMetricsRepositoryCloser) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockMetricsRepositoryCloserMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockMetricsRepositoryCloser)(nil).Close))
+}
+
+// MockRegionRepositoryCloser is a mock of RegionRepositoryCloser interface.
+type MockRegionRepositoryCloser struct {
+	ctrl     *gomock.Controller
+	recorder *MockRegionRepositoryCloserMockRecorder
+	isgomock struct{}
+}
+
+// MockRegionRepositoryCloserMockRecorder is the mock recorder for MockRegionRepositoryCloser.
+type MockRegionRepositoryCloserMockRecorder struct {
+	mock *MockRegionRepositoryCloser
+}
+
+// NewMockRegionRepositoryCloser creates a new mock instance.
+func NewMockRegionRepositoryCloser(ctrl *gomock.Controller) *MockRegionRepositoryCloser {
+	mock := &MockRegionRepositoryCloser{ctrl: ctrl}
+	mock.recorder = &MockRegionRepositoryCloserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRegionRepositoryCloser) EXPECT() *MockRegionRepositoryCloserMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockRegionRepositoryCloser) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockRegionRepositoryCloserMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRegionRepositoryCloser)(nil).Close))
+}
+
+// Get mocks base method.
+func (m *MockRegionRepositoryCloser) Get(ctx context.Context, project, region string) (*model.Region, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, project, region)
+	ret0, _ := ret[0].(*model.Region)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRegionRepositoryCloserMockRecorder) Get(ctx, project, region any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRegionRepositoryCloser)(nil).Get), ctx, project, region)
+}
+
+// ListZones mocks base method.
+func (m *MockRegionRepositoryCloser) ListZones(ctx context.Context, project, region string) ([]*model.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListZones", ctx, project, region)
+	ret0, _ := ret[0].([]*model.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListZones indicates an expected call of ListZones.
+func (mr *MockRegionRepositoryCloserMockRecorder) ListZones(ctx, project, region any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListZones", reflect.TypeOf((*MockRegionRepositoryCloser)(nil).ListZones), ctx, project, region)
+}
+
+// MockProjectRepositoryCloser is a mock of ProjectRepositoryCloser interface.
+type MockProjectRepositoryCloser struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectRepositoryCloserMockRecorder
+	isgomock struct{}
+}
+
+// MockProjectRepositoryCloserMockRecorder is the mock recorder for MockProjectRepositoryCloser.
+type MockProjectRepositoryCloserMockRecorder struct {
+	mock *MockProjectRepositoryCloser
+}
+
+// NewMockProjectRepositoryCloser creates a new mock instance.
+func NewMockProjectRepositoryCloser(ctrl *gomock.Controller) *MockProjectRepositoryCloser {
+	mock := &MockProjectRepositoryCloser{ctrl: ctrl}
+	mock.recorder = &MockProjectRepositoryCloserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProjectRepositoryCloser) EXPECT() *MockProjectRepositoryCloserMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockProjectRepositoryCloser) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockProjectRepositoryCloserMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockProjectRepositoryCloser)(nil).Close))
+}
+
+// List mocks base method.
+func (m *MockProjectRepositoryCloser) List(ctx context.Context) ([]*model.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*model.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockProjectRepositoryCloserMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockProjectRepositoryCloser)(nil).List), ctx)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: accelerator_type_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=accelerator_type_repository.go -destination=../../mock/repository/accelerator_type_repository_mock.go -package=mock_repository
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/haru-256/gcectl/internal/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAcceleratorTypeRepository is a mock of AcceleratorTypeRepository interface.
+type MockAcceleratorTypeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAcceleratorTypeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAcceleratorTypeRepositoryMockRecorder is the mock recorder for MockAcceleratorTypeRepository.
+type MockAcceleratorTypeRepositoryMockRecorder struct {
+	mock *MockAcceleratorTypeRepository
+}
+
+// NewMockAcceleratorTypeRepository creates a new mock instance.
+func NewMockAcceleratorTypeRepository(ctrl *gomock.Controller) *MockAcceleratorTypeRepository {
+	mock := &MockAcceleratorTypeRepository{ctrl: ctrl}
+	mock.recorder = &MockAcceleratorTypeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAcceleratorTypeRepository) EXPECT() *MockAcceleratorTypeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockAcceleratorTypeRepository) List(ctx context.Context, project, zone string) ([]*model.AcceleratorType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, project, zone)
+	ret0, _ := ret[0].([]*model.AcceleratorType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAcceleratorTypeRepositoryMockRecorder) List(ctx, project, zone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAcceleratorTypeRepository)(nil).List), ctx, project, zone)
+}
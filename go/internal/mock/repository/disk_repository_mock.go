@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: disk_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=disk_repository.go -destination=../../mock/repository/disk_repository_mock.go -package=mock_repository
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/haru-256/gcectl/internal/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDiskRepository is a mock of DiskRepository interface.
+type MockDiskRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDiskRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDiskRepositoryMockRecorder is the mock recorder for MockDiskRepository.
+type MockDiskRepositoryMockRecorder struct {
+	mock *MockDiskRepository
+}
+
+// NewMockDiskRepository creates a new mock instance.
+func NewMockDiskRepository(ctrl *gomock.Controller) *MockDiskRepository {
+	mock := &MockDiskRepository{ctrl: ctrl}
+	mock.recorder = &MockDiskRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDiskRepository) EXPECT() *MockDiskRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Attach mocks base method.
+func (m *MockDiskRepository) Attach(ctx context.Context, vm *model.VM, diskName string, readOnly bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attach", ctx, vm, diskName, readOnly)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockDiskRepositoryMockRecorder) Attach(ctx, vm, diskName, readOnly any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockDiskRepository)(nil).Attach), ctx, vm, diskName, readOnly)
+}
+
+// Detach mocks base method.
+func (m *MockDiskRepository) Detach(ctx context.Context, vm *model.VM, deviceName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Detach", ctx, vm, deviceName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Detach indicates an expected call of Detach.
+func (mr *MockDiskRepositoryMockRecorder) Detach(ctx, vm, deviceName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detach", reflect.TypeOf((*MockDiskRepository)(nil).Detach), ctx, vm, deviceName)
+}
+
+// List mocks base method.
+func (m *MockDiskRepository) List(ctx context.Context, vm *model.VM) ([]*model.Disk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, vm)
+	ret0, _ := ret[0].([]*model.Disk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockDiskRepositoryMockRecorder) List(ctx, vm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDiskRepository)(nil).List), ctx, vm)
+}
+
+// Resize mocks base method.
+func (m *MockDiskRepository) Resize(ctx context.Context, vm *model.VM, diskName string, sizeGB int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resize", ctx, vm, diskName, sizeGB)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resize indicates an expected call of Resize.
+func (mr *MockDiskRepositoryMockRecorder) Resize(ctx, vm, diskName, sizeGB any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resize", reflect.TypeOf((*MockDiskRepository)(nil).Resize), ctx, vm, diskName, sizeGB)
+}
@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: log_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=log_repository.go -destination=../../mock/repository/log_repository_mock.go -package=mock_repository
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/haru-256/gcectl/internal/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLogRepository is a mock of LogRepository interface.
+type MockLogRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLogRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockLogRepositoryMockRecorder is the mock recorder for MockLogRepository.
+type MockLogRepositoryMockRecorder struct {
+	mock *MockLogRepository
+}
+
+// NewMockLogRepository creates a new mock instance.
+func NewMockLogRepository(ctrl *gomock.Controller) *MockLogRepository {
+	mock := &MockLogRepository{ctrl: ctrl}
+	mock.recorder = &MockLogRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLogRepository) EXPECT() *MockLogRepositoryMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockLogRepository) List(ctx context.Context, vm *model.VM, since time.Time, filter string, limit int) ([]*model.LogEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, vm, since, filter, limit)
+	ret0, _ := ret[0].([]*model.LogEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockLogRepositoryMockRecorder) List(ctx, vm, since, filter, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockLogRepository)(nil).List), ctx, vm, since, filter, limit)
+}
@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: machine_image_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=machine_image_repository.go -destination=../../mock/repository/machine_image_repository_mock.go -package=mock_repository
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/haru-256/gcectl/internal/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMachineImageRepository is a mock of MachineImageRepository interface.
+type MockMachineImageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMachineImageRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMachineImageRepositoryMockRecorder is the mock recorder for MockMachineImageRepository.
+type MockMachineImageRepositoryMockRecorder struct {
+	mock *MockMachineImageRepository
+}
+
+// NewMockMachineImageRepository creates a new mock instance.
+func NewMockMachineImageRepository(ctrl *gomock.Controller) *MockMachineImageRepository {
+	mock := &MockMachineImageRepository{ctrl: ctrl}
+	mock.recorder = &MockMachineImageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMachineImageRepository) EXPECT() *MockMachineImageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMachineImageRepository) Create(ctx context.Context, source *model.VM, imageName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, source, imageName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMachineImageRepositoryMockRecorder) Create(ctx, source, imageName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMachineImageRepository)(nil).Create), ctx, source, imageName)
+}
+
+// CreateInstance mocks base method.
+func (m *MockMachineImageRepository) CreateInstance(ctx context.Context, imageName string, target *model.VM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInstance", ctx, imageName, target)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateInstance indicates an expected call of CreateInstance.
+func (mr *MockMachineImageRepositoryMockRecorder) CreateInstance(ctx, imageName, target any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInstance", reflect.TypeOf((*MockMachineImageRepository)(nil).CreateInstance), ctx, imageName, target)
+}
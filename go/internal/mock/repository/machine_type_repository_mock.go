@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: machine_type_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=machine_type_repository.go -destination=../../mock/repository/machine_type_repository_mock.go -package=mock_repository
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/haru-256/gcectl/internal/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMachineTypeRepository is a mock of MachineTypeRepository interface.
+type MockMachineTypeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMachineTypeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMachineTypeRepositoryMockRecorder is the mock recorder for MockMachineTypeRepository.
+type MockMachineTypeRepositoryMockRecorder struct {
+	mock *MockMachineTypeRepository
+}
+
+// NewMockMachineTypeRepository creates a new mock instance.
+func NewMockMachineTypeRepository(ctrl *gomock.Controller) *MockMachineTypeRepository {
+	mock := &MockMachineTypeRepository{ctrl: ctrl}
+	mock.recorder = &MockMachineTypeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMachineTypeRepository) EXPECT() *MockMachineTypeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockMachineTypeRepository) List(ctx context.Context, project, zone string) ([]*model.MachineType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, project, zone)
+	ret0, _ := ret[0].([]*model.MachineType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockMachineTypeRepositoryMockRecorder) List(ctx, project, zone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMachineTypeRepository)(nil).List), ctx, project, zone)
+}
@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: metrics_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=metrics_repository.go -destination=../../mock/repository/metrics_repository_mock.go -package=mock_repository
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/haru-256/gcectl/internal/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMetricsRepository is a mock of MetricsRepository interface.
+type MockMetricsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMetricsRepositoryMockRecorder is the mock recorder for MockMetricsRepository.
+type MockMetricsRepositoryMockRecorder struct {
+	mock *MockMetricsRepository
+}
+
+// NewMockMetricsRepository creates a new mock instance.
+func NewMockMetricsRepository(ctrl *gomock.Controller) *MockMetricsRepository {
+	mock := &MockMetricsRepository{ctrl: ctrl}
+	mock.recorder = &MockMetricsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricsRepository) EXPECT() *MockMetricsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Average mocks base method.
+func (m *MockMetricsRepository) Average(ctx context.Context, project string, instanceIDs []string, start, end time.Time) (map[string]*model.InstanceMetrics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Average", ctx, project, instanceIDs, start, end)
+	ret0, _ := ret[0].(map[string]*model.InstanceMetrics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Average indicates an expected call of Average.
+func (mr *MockMetricsRepositoryMockRecorder) Average(ctx, project, instanceIDs, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Average", reflect.TypeOf((*MockMetricsRepository)(nil).Average), ctx, project, instanceIDs, start, end)
+}
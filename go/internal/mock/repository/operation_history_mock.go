@@ -0,0 +1,74 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: operation_history.go
+//
+// Generated by this command:
+//
+//	mockgen -source=operation_history.go -destination=../../mock/repository/operation_history_mock.go -package=mock_repository
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/haru-256/gcectl/internal/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOperationHistory is a mock of OperationHistory interface.
+type MockOperationHistory struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperationHistoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOperationHistoryMockRecorder is the mock recorder for MockOperationHistory.
+type MockOperationHistoryMockRecorder struct {
+	mock *MockOperationHistory
+}
+
+// NewMockOperationHistory creates a new mock instance.
+func NewMockOperationHistory(ctrl *gomock.Controller) *MockOperationHistory {
+	mock := &MockOperationHistory{ctrl: ctrl}
+	mock.recorder = &MockOperationHistoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperationHistory) EXPECT() *MockOperationHistoryMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockOperationHistory) Append(records ...*model.OperationRecord) error {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range records {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Append", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockOperationHistoryMockRecorder) Append(records ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockOperationHistory)(nil).Append), records...)
+}
+
+// List mocks base method.
+func (m *MockOperationHistory) List() ([]*model.OperationRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]*model.OperationRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockOperationHistoryMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockOperationHistory)(nil).List))
+}
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: operation_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=operation_repository.go -destination=../../mock/repository/operation_repository_mock.go -package=mock_repository
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/haru-256/gcectl/internal/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOperationRepository is a mock of OperationRepository interface.
+type MockOperationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOperationRepositoryMockRecorder is the mock recorder for MockOperationRepository.
+type MockOperationRepositoryMockRecorder struct {
+	mock *MockOperationRepository
+}
+
+// NewMockOperationRepository creates a new mock instance.
+func NewMockOperationRepository(ctrl *gomock.Controller) *MockOperationRepository {
+	mock := &MockOperationRepository{ctrl: ctrl}
+	mock.recorder = &MockOperationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperationRepository) EXPECT() *MockOperationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockOperationRepository) Get(ctx context.Context, op *model.Operation) (*model.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, op)
+	ret0, _ := ret[0].(*model.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockOperationRepositoryMockRecorder) Get(ctx, op any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockOperationRepository)(nil).Get), ctx, op)
+}
+
+// ListByTarget mocks base method.
+func (m *MockOperationRepository) ListByTarget(ctx context.Context, vm *model.VM, limit int) ([]*model.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTarget", ctx, vm, limit)
+	ret0, _ := ret[0].([]*model.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTarget indicates an expected call of ListByTarget.
+func (mr *MockOperationRepositoryMockRecorder) ListByTarget(ctx, vm, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTarget", reflect.TypeOf((*MockOperationRepository)(nil).ListByTarget), ctx, vm, limit)
+}
+
+// ListSystemEvents mocks base method.
+func (m *MockOperationRepository) ListSystemEvents(ctx context.Context, vm *model.VM, limit int) ([]*model.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSystemEvents", ctx, vm, limit)
+	ret0, _ := ret[0].([]*model.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSystemEvents indicates an expected call of ListSystemEvents.
+func (mr *MockOperationRepositoryMockRecorder) ListSystemEvents(ctx, vm, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSystemEvents", reflect.TypeOf((*MockOperationRepository)(nil).ListSystemEvents), ctx, vm, limit)
+}
+
+// Wait mocks base method.
+func (m *MockOperationRepository) Wait(ctx context.Context, op *model.Operation) (*model.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Wait", ctx, op)
+	ret0, _ := ret[0].(*model.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Wait indicates an expected call of Wait.
+func (mr *MockOperationRepositoryMockRecorder) Wait(ctx, op any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wait", reflect.TypeOf((*MockOperationRepository)(nil).Wait), ctx, op)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: preflight_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=preflight_repository.go -destination=../../mock/repository/preflight_repository_mock.go -package=mock_repository
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPreflightRepository is a mock of PreflightRepository interface.
+type MockPreflightRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreflightRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPreflightRepositoryMockRecorder is the mock recorder for MockPreflightRepository.
+type MockPreflightRepositoryMockRecorder struct {
+	mock *MockPreflightRepository
+}
+
+// NewMockPreflightRepository creates a new mock instance.
+func NewMockPreflightRepository(ctrl *gomock.Controller) *MockPreflightRepository {
+	mock := &MockPreflightRepository{ctrl: ctrl}
+	mock.recorder = &MockPreflightRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreflightRepository) EXPECT() *MockPreflightRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ComputeAPIEnabled mocks base method.
+func (m *MockPreflightRepository) ComputeAPIEnabled(ctx context.Context, project string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ComputeAPIEnabled", ctx, project)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ComputeAPIEnabled indicates an expected call of ComputeAPIEnabled.
+func (mr *MockPreflightRepositoryMockRecorder) ComputeAPIEnabled(ctx, project any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ComputeAPIEnabled", reflect.TypeOf((*MockPreflightRepository)(nil).ComputeAPIEnabled), ctx, project)
+}
+
+// Credentials mocks base method.
+func (m *MockPreflightRepository) Credentials(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Credentials", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Credentials indicates an expected call of Credentials.
+func (mr *MockPreflightRepositoryMockRecorder) Credentials(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Credentials", reflect.TypeOf((*MockPreflightRepository)(nil).Credentials), ctx)
+}
+
+// TestPermissions mocks base method.
+func (m *MockPreflightRepository) TestPermissions(ctx context.Context, project string, permissions []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TestPermissions", ctx, project, permissions)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TestPermissions indicates an expected call of TestPermissions.
+func (mr *MockPreflightRepositoryMockRecorder) TestPermissions(ctx, project, permissions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TestPermissions", reflect.TypeOf((*MockPreflightRepository)(nil).TestPermissions), ctx, project, permissions)
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: region_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=region_repository.go -destination=../../mock/repository/region_repository_mock.go -package=mock_repository
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/haru-256/gcectl/internal/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRegionRepository is a mock of RegionRepository interface.
+type MockRegionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRegionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRegionRepositoryMockRecorder is the mock recorder for MockRegionRepository.
+type MockRegionRepositoryMockRecorder struct {
+	mock *MockRegionRepository
+}
+
+// NewMockRegionRepository creates a new mock instance.
+func NewMockRegionRepository(ctrl *gomock.Controller) *MockRegionRepository {
+	mock := &MockRegionRepository{ctrl: ctrl}
+	mock.recorder = &MockRegionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRegionRepository) EXPECT() *MockRegionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockRegionRepository) Get(ctx context.Context, project, region string) (*model.Region, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, project, region)
+	ret0, _ := ret[0].(*model.Region)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRegionRepositoryMockRecorder) Get(ctx, project, region any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRegionRepository)(nil).Get), ctx, project, region)
+}
+
+// ListZones mocks base method.
+func (m *MockRegionRepository) ListZones(ctx context.Context, project, region string) ([]*model.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListZones", ctx, project, region)
+	ret0, _ := ret[0].([]*model.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListZones indicates an expected call of ListZones.
+func (mr *MockRegionRepositoryMockRecorder) ListZones(ctx, project, region any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListZones", reflect.TypeOf((*MockRegionRepository)(nil).ListZones), ctx, project, region)
+}
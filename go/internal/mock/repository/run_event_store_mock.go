@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: run_event_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=run_event_store.go -destination=../../mock/repository/run_event_store_mock.go -package=mock_repository
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/haru-256/gcectl/internal/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRunEventStore is a mock of RunEventStore interface.
+type MockRunEventStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRunEventStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockRunEventStoreMockRecorder is the mock recorder for MockRunEventStore.
+type MockRunEventStoreMockRecorder struct {
+	mock *MockRunEventStore
+}
+
+// NewMockRunEventStore creates a new mock instance.
+func NewMockRunEventStore(ctrl *gomock.Controller) *MockRunEventStore {
+	mock := &MockRunEventStore{ctrl: ctrl}
+	mock.recorder = &MockRunEventStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRunEventStore) EXPECT() *MockRunEventStoreMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockRunEventStore) Add(events ...*model.RunEvent) error {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Add", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockRunEventStoreMockRecorder) Add(events ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockRunEventStore)(nil).Add), events...)
+}
+
+// List mocks base method.
+func (m *MockRunEventStore) List(vm *model.VM) []*model.RunEvent {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", vm)
+	ret0, _ := ret[0].([]*model.RunEvent)
+	return ret0
+}
+
+// List indicates an expected call of List.
+func (mr *MockRunEventStoreMockRecorder) List(vm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRunEventStore)(nil).List), vm)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: schedule_policy_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=schedule_policy_repository.go -destination=../../mock/repository/schedule_policy_repository_mock.go -package=mock_repository
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/haru-256/gcectl/internal/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSchedulePolicyRepository is a mock of SchedulePolicyRepository interface.
+type MockSchedulePolicyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchedulePolicyRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSchedulePolicyRepositoryMockRecorder is the mock recorder for MockSchedulePolicyRepository.
+type MockSchedulePolicyRepositoryMockRecorder struct {
+	mock *MockSchedulePolicyRepository
+}
+
+// NewMockSchedulePolicyRepository creates a new mock instance.
+func NewMockSchedulePolicyRepository(ctrl *gomock.Controller) *MockSchedulePolicyRepository {
+	mock := &MockSchedulePolicyRepository{ctrl: ctrl}
+	mock.recorder = &MockSchedulePolicyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchedulePolicyRepository) EXPECT() *MockSchedulePolicyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSchedulePolicyRepository) Create(ctx context.Context, policy *model.SchedulePolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSchedulePolicyRepositoryMockRecorder) Create(ctx, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSchedulePolicyRepository)(nil).Create), ctx, policy)
+}
+
+// List mocks base method.
+func (m *MockSchedulePolicyRepository) List(ctx context.Context, project, region string) ([]*model.SchedulePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, project, region)
+	ret0, _ := ret[0].([]*model.SchedulePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockSchedulePolicyRepositoryMockRecorder) List(ctx, project, region any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSchedulePolicyRepository)(nil).List), ctx, project, region)
+}
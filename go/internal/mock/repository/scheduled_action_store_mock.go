@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: scheduled_action_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=scheduled_action_store.go -destination=../../mock/repository/scheduled_action_store_mock.go -package=mock_repository
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/haru-256/gcectl/internal/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockScheduledActionStore is a mock of ScheduledActionStore interface.
+type MockScheduledActionStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduledActionStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockScheduledActionStoreMockRecorder is the mock recorder for MockScheduledActionStore.
+type MockScheduledActionStoreMockRecorder struct {
+	mock *MockScheduledActionStore
+}
+
+// NewMockScheduledActionStore creates a new mock instance.
+func NewMockScheduledActionStore(ctrl *gomock.Controller) *MockScheduledActionStore {
+	mock := &MockScheduledActionStore{ctrl: ctrl}
+	mock.recorder = &MockScheduledActionStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduledActionStore) EXPECT() *MockScheduledActionStoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockScheduledActionStore) Delete(vm *model.VM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", vm)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockScheduledActionStoreMockRecorder) Delete(vm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockScheduledActionStore)(nil).Delete), vm)
+}
+
+// Get mocks base method.
+func (m *MockScheduledActionStore) Get(vm *model.VM) (*model.ScheduledAction, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", vm)
+	ret0, _ := ret[0].(*model.ScheduledAction)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockScheduledActionStoreMockRecorder) Get(vm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockScheduledActionStore)(nil).Get), vm)
+}
+
+// List mocks base method.
+func (m *MockScheduledActionStore) List() []*model.ScheduledAction {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]*model.ScheduledAction)
+	return ret0
+}
+
+// List indicates an expected call of List.
+func (mr *MockScheduledActionStoreMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockScheduledActionStore)(nil).List))
+}
+
+// Put mocks base method.
+func (m *MockScheduledActionStore) Put(action *model.ScheduledAction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", action)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockScheduledActionStoreMockRecorder) Put(action any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockScheduledActionStore)(nil).Put), action)
+}
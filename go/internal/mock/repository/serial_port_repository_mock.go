@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: serial_port_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=serial_port_repository.go -destination=../../mock/repository/serial_port_repository_mock.go -package=mock_repository
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/haru-256/gcectl/internal/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSerialPortRepository is a mock of SerialPortRepository interface.
+type MockSerialPortRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSerialPortRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSerialPortRepositoryMockRecorder is the mock recorder for MockSerialPortRepository.
+type MockSerialPortRepositoryMockRecorder struct {
+	mock *MockSerialPortRepository
+}
+
+// NewMockSerialPortRepository creates a new mock instance.
+func NewMockSerialPortRepository(ctrl *gomock.Controller) *MockSerialPortRepository {
+	mock := &MockSerialPortRepository{ctrl: ctrl}
+	mock.recorder = &MockSerialPortRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSerialPortRepository) EXPECT() *MockSerialPortRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Read mocks base method.
+func (m *MockSerialPortRepository) Read(ctx context.Context, vm *model.VM, port int32, start int64) (*model.SerialOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", ctx, vm, port, start)
+	ret0, _ := ret[0].(*model.SerialOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockSerialPortRepositoryMockRecorder) Read(ctx, vm, port, start any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockSerialPortRepository)(nil).Read), ctx, vm, port, start)
+}
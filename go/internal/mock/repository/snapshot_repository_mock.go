@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: snapshot_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=snapshot_repository.go -destination=../../mock/repository/snapshot_repository_mock.go -package=mock_repository
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/haru-256/gcectl/internal/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSnapshotRepository is a mock of SnapshotRepository interface.
+type MockSnapshotRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSnapshotRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSnapshotRepositoryMockRecorder is the mock recorder for MockSnapshotRepository.
+type MockSnapshotRepositoryMockRecorder struct {
+	mock *MockSnapshotRepository
+}
+
+// NewMockSnapshotRepository creates a new mock instance.
+func NewMockSnapshotRepository(ctrl *gomock.Controller) *MockSnapshotRepository {
+	mock := &MockSnapshotRepository{ctrl: ctrl}
+	mock.recorder = &MockSnapshotRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSnapshotRepository) EXPECT() *MockSnapshotRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAsync mocks base method.
+func (m *MockSnapshotRepository) CreateAsync(ctx context.Context, vm *model.VM, diskName, snapshotName string) (*model.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAsync", ctx, vm, diskName, snapshotName)
+	ret0, _ := ret[0].(*model.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAsync indicates an expected call of CreateAsync.
+func (mr *MockSnapshotRepositoryMockRecorder) CreateAsync(ctx, vm, diskName, snapshotName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAsync", reflect.TypeOf((*MockSnapshotRepository)(nil).CreateAsync), ctx, vm, diskName, snapshotName)
+}
+
+// List mocks base method.
+func (m *MockSnapshotRepository) List(ctx context.Context, vm *model.VM, diskNames []string) ([]*model.Snapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, vm, diskNames)
+	ret0, _ := ret[0].([]*model.Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockSnapshotRepositoryMockRecorder) List(ctx, vm, diskNames any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSnapshotRepository)(nil).List), ctx, vm, diskNames)
+}
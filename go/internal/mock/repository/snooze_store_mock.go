@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: snooze_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=snooze_store.go -destination=../../mock/repository/snooze_store_mock.go -package=mock_repository
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/haru-256/gcectl/internal/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSnoozeStore is a mock of SnoozeStore interface.
+type MockSnoozeStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockSnoozeStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockSnoozeStoreMockRecorder is the mock recorder for MockSnoozeStore.
+type MockSnoozeStoreMockRecorder struct {
+	mock *MockSnoozeStore
+}
+
+// NewMockSnoozeStore creates a new mock instance.
+func NewMockSnoozeStore(ctrl *gomock.Controller) *MockSnoozeStore {
+	mock := &MockSnoozeStore{ctrl: ctrl}
+	mock.recorder = &MockSnoozeStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSnoozeStore) EXPECT() *MockSnoozeStoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockSnoozeStore) Delete(vm *model.VM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", vm)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSnoozeStoreMockRecorder) Delete(vm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSnoozeStore)(nil).Delete), vm)
+}
+
+// Get mocks base method.
+func (m *MockSnoozeStore) Get(vm *model.VM) (*model.Snooze, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", vm)
+	ret0, _ := ret[0].(*model.Snooze)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSnoozeStoreMockRecorder) Get(vm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSnoozeStore)(nil).Get), vm)
+}
+
+// List mocks base method.
+func (m *MockSnoozeStore) List() []*model.Snooze {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]*model.Snooze)
+	return ret0
+}
+
+// List indicates an expected call of List.
+func (mr *MockSnoozeStoreMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSnoozeStore)(nil).List))
+}
+
+// Put mocks base method.
+func (m *MockSnoozeStore) Put(snooze *model.Snooze) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", snooze)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockSnoozeStoreMockRecorder) Put(snooze any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockSnoozeStore)(nil).Put), snooze)
+}
@@ -41,6 +41,21 @@ func (m *MockVMRepository) EXPECT() *MockVMRepositoryMockRecorder {
 	return m.recorder
 }
 
+// FindAll mocks base method.
+func (m *MockVMRepository) FindAll(ctx context.Context, vms []*model.VM) ([]*model.VM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, vms)
+	ret0, _ := ret[0].([]*model.VM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockVMRepositoryMockRecorder) FindAll(ctx, vms any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockVMRepository)(nil).FindAll), ctx, vms)
+}
+
 // FindByName mocks base method.
 func (m *MockVMRepository) FindByName(ctx context.Context, vm *model.VM) (*model.VM, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +71,107 @@ func (mr *MockVMRepositoryMockRecorder) FindByName(ctx, vm any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockVMRepository)(nil).FindByName), ctx, vm)
 }
 
+// GetMetadata mocks base method.
+func (m *MockVMRepository) GetMetadata(ctx context.Context, vm *model.VM) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetadata", ctx, vm)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetadata indicates an expected call of GetMetadata.
+func (mr *MockVMRepositoryMockRecorder) GetMetadata(ctx, vm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetadata", reflect.TypeOf((*MockVMRepository)(nil).GetMetadata), ctx, vm)
+}
+
+// GetRaw mocks base method.
+func (m *MockVMRepository) GetRaw(ctx context.Context, vm *model.VM) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRaw", ctx, vm)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRaw indicates an expected call of GetRaw.
+func (mr *MockVMRepositoryMockRecorder) GetRaw(ctx, vm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRaw", reflect.TypeOf((*MockVMRepository)(nil).GetRaw), ctx, vm)
+}
+
+// ListByProject mocks base method.
+func (m *MockVMRepository) ListByProject(ctx context.Context, project string, selector map[string]string) ([]*model.VM, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByProject", ctx, project, selector)
+	ret0, _ := ret[0].([]*model.VM)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByProject indicates an expected call of ListByProject.
+func (mr *MockVMRepositoryMockRecorder) ListByProject(ctx, project, selector any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByProject", reflect.TypeOf((*MockVMRepository)(nil).ListByProject), ctx, project, selector)
+}
+
+// Reset mocks base method.
+func (m *MockVMRepository) Reset(ctx context.Context, vm *model.VM) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx, vm)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockVMRepositoryMockRecorder) Reset(ctx, vm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockVMRepository)(nil).Reset), ctx, vm)
+}
+
+// SetAccelerators mocks base method.
+func (m *MockVMRepository) SetAccelerators(ctx context.Context, vm *model.VM, accelerators []model.Accelerator) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAccelerators", ctx, vm, accelerators)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAccelerators indicates an expected call of SetAccelerators.
+func (mr *MockVMRepositoryMockRecorder) SetAccelerators(ctx, vm, accelerators any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAccelerators", reflect.TypeOf((*MockVMRepository)(nil).SetAccelerators), ctx, vm, accelerators)
+}
+
+// SetLabels mocks base method.
+func (m *MockVMRepository) SetLabels(ctx context.Context, vm *model.VM, labels map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLabels", ctx, vm, labels)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLabels indicates an expected call of SetLabels.
+func (mr *MockVMRepositoryMockRecorder) SetLabels(ctx, vm, labels any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLabels", reflect.TypeOf((*MockVMRepository)(nil).SetLabels), ctx, vm, labels)
+}
+
+// SetMetadata mocks base method.
+func (m *MockVMRepository) SetMetadata(ctx context.Context, vm *model.VM, items map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMetadata", ctx, vm, items)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMetadata indicates an expected call of SetMetadata.
+func (mr *MockVMRepositoryMockRecorder) SetMetadata(ctx, vm, items any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMetadata", reflect.TypeOf((*MockVMRepository)(nil).SetMetadata), ctx, vm, items)
+}
+
 // SetSchedulePolicy mocks base method.
 func (m *MockVMRepository) SetSchedulePolicy(ctx context.Context, vm *model.VM, policyName string) error {
 	m.ctrl.T.Helper()
@@ -70,6 +186,34 @@ func (mr *MockVMRepositoryMockRecorder) SetSchedulePolicy(ctx, vm, policyName an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSchedulePolicy", reflect.TypeOf((*MockVMRepository)(nil).SetSchedulePolicy), ctx, vm, policyName)
 }
 
+// SetScheduling mocks base method.
+func (m *MockVMRepository) SetScheduling(ctx context.Context, vm *model.VM, scheduling model.Scheduling) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetScheduling", ctx, vm, scheduling)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetScheduling indicates an expected call of SetScheduling.
+func (mr *MockVMRepositoryMockRecorder) SetScheduling(ctx, vm, scheduling any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetScheduling", reflect.TypeOf((*MockVMRepository)(nil).SetScheduling), ctx, vm, scheduling)
+}
+
+// SetSchedulingOptions mocks base method.
+func (m *MockVMRepository) SetSchedulingOptions(ctx context.Context, vm *model.VM, opts model.SchedulingOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSchedulingOptions", ctx, vm, opts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSchedulingOptions indicates an expected call of SetSchedulingOptions.
+func (mr *MockVMRepositoryMockRecorder) SetSchedulingOptions(ctx, vm, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSchedulingOptions", reflect.TypeOf((*MockVMRepository)(nil).SetSchedulingOptions), ctx, vm, opts)
+}
+
 // Start mocks base method.
 func (m *MockVMRepository) Start(ctx context.Context, vm *model.VM) error {
 	m.ctrl.T.Helper()
@@ -84,6 +228,21 @@ func (mr *MockVMRepositoryMockRecorder) Start(ctx, vm any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockVMRepository)(nil).Start), ctx, vm)
 }
 
+// StartAsync mocks base method.
+func (m *MockVMRepository) StartAsync(ctx context.Context, vm *model.VM) (*model.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartAsync", ctx, vm)
+	ret0, _ := ret[0].(*model.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartAsync indicates an expected call of StartAsync.
+func (mr *MockVMRepositoryMockRecorder) StartAsync(ctx, vm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartAsync", reflect.TypeOf((*MockVMRepository)(nil).StartAsync), ctx, vm)
+}
+
 // Stop mocks base method.
 func (m *MockVMRepository) Stop(ctx context.Context, vm *model.VM) error {
 	m.ctrl.T.Helper()
@@ -98,6 +257,21 @@ func (mr *MockVMRepositoryMockRecorder) Stop(ctx, vm any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockVMRepository)(nil).Stop), ctx, vm)
 }
 
+// StopAsync mocks base method.
+func (m *MockVMRepository) StopAsync(ctx context.Context, vm *model.VM) (*model.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopAsync", ctx, vm)
+	ret0, _ := ret[0].(*model.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopAsync indicates an expected call of StopAsync.
+func (mr *MockVMRepositoryMockRecorder) StopAsync(ctx, vm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopAsync", reflect.TypeOf((*MockVMRepository)(nil).StopAsync), ctx, vm)
+}
+
 // UnsetSchedulePolicy mocks base method.
 func (m *MockVMRepository) UnsetSchedulePolicy(ctx context.Context, vm *model.VM, policyName string) error {
 	m.ctrl.T.Helper()
@@ -112,6 +286,20 @@ func (mr *MockVMRepositoryMockRecorder) UnsetSchedulePolicy(ctx, vm, policyName
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsetSchedulePolicy", reflect.TypeOf((*MockVMRepository)(nil).UnsetSchedulePolicy), ctx, vm, policyName)
 }
 
+// UpdateLabels mocks base method.
+func (m *MockVMRepository) UpdateLabels(ctx context.Context, vm *model.VM, set map[string]string, remove []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLabels", ctx, vm, set, remove)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLabels indicates an expected call of UpdateLabels.
+func (mr *MockVMRepositoryMockRecorder) UpdateLabels(ctx, vm, set, remove any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLabels", reflect.TypeOf((*MockVMRepository)(nil).UpdateLabels), ctx, vm, set, remove)
+}
+
 // UpdateMachineType mocks base method.
 func (m *MockVMRepository) UpdateMachineType(ctx context.Context, vm *model.VM, machineType string) error {
 	m.ctrl.T.Helper()
@@ -125,3 +313,17 @@ func (mr *MockVMRepositoryMockRecorder) UpdateMachineType(ctx, vm, machineType a
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMachineType", reflect.TypeOf((*MockVMRepository)(nil).UpdateMachineType), ctx, vm, machineType)
 }
+
+// UpdateMetadata mocks base method.
+func (m *MockVMRepository) UpdateMetadata(ctx context.Context, vm *model.VM, set map[string]string, remove []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMetadata", ctx, vm, set, remove)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMetadata indicates an expected call of UpdateMetadata.
+func (mr *MockVMRepositoryMockRecorder) UpdateMetadata(ctx, vm, set, remove any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetadata", reflect.TypeOf((*MockVMRepository)(nil).UpdateMetadata), ctx, vm, set, remove)
+}
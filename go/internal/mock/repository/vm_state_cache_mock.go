@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: vm_state_cache.go
+//
+// Generated by this command:
+//
+//	mockgen -source=vm_state_cache.go -destination=../../mock/repository/vm_state_cache_mock.go -package=mock_repository
+//
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+	time "time"
+
+	model "github.com/haru-256/gcectl/internal/domain/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVMStateCache is a mock of VMStateCache interface.
+type MockVMStateCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockVMStateCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockVMStateCacheMockRecorder is the mock recorder for MockVMStateCache.
+type MockVMStateCacheMockRecorder struct {
+	mock *MockVMStateCache
+}
+
+// NewMockVMStateCache creates a new mock instance.
+func NewMockVMStateCache(ctrl *gomock.Controller) *MockVMStateCache {
+	mock := &MockVMStateCache{ctrl: ctrl}
+	mock.recorder = &MockVMStateCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVMStateCache) EXPECT() *MockVMStateCacheMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockVMStateCache) Get(vm *model.VM) (*model.VM, time.Time, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", vm)
+	ret0, _ := ret[0].(*model.VM)
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(bool)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockVMStateCacheMockRecorder) Get(vm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockVMStateCache)(nil).Get), vm)
+}
+
+// Put mocks base method.
+func (m *MockVMStateCache) Put(vms []*model.VM, fetchedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", vms, fetchedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockVMStateCacheMockRecorder) Put(vms, fetchedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockVMStateCache)(nil).Put), vms, fetchedAt)
+}
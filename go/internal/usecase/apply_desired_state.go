@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	"golang.org/x/sync/errgroup"
+)
+
+// ApplyDesiredStateUseCase brings VMs to the status and machine type declared in config.
+type ApplyDesiredStateUseCase struct {
+	vmRepo         repository.VMRepository
+	logger         log.Logger
+	maxConcurrency int
+}
+
+// NewApplyDesiredStateUseCase creates a new ApplyDesiredStateUseCase instance.
+func NewApplyDesiredStateUseCase(vmRepo repository.VMRepository, logger log.Logger) *ApplyDesiredStateUseCase {
+	return &ApplyDesiredStateUseCase{vmRepo: vmRepo, logger: logger, maxConcurrency: maxConcurrentVMLookups}
+}
+
+// WithMaxConcurrency sets how many VMs are changed at once. Values <= 0 keep the default.
+func (uc *ApplyDesiredStateUseCase) WithMaxConcurrency(n int) *ApplyDesiredStateUseCase {
+	if n > 0 {
+		uc.maxConcurrency = n
+	}
+	return uc
+}
+
+// Plan fetches the current state of vms and returns the actions that bring
+// them to their desired states, grouped by VM in the order of vms.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - vms: The VMs to plan for
+//   - desired: The desired state of each VM keyed by name; VMs without one are skipped
+//
+// Returns:
+//   - []model.ApplyAction: The actions to run, empty when every VM is up to date
+//   - error: Error if the VMs cannot be fetched, or joined errors for VMs that do not exist
+func (uc *ApplyDesiredStateUseCase) Plan(ctx context.Context, vms []*model.VM, desired map[string]model.DesiredState) ([]model.ApplyAction, error) {
+	var actions []model.ApplyAction
+	err := uc.eachManaged(ctx, vms, desired, func(vm *model.VM, state model.DesiredState) {
+		actions = append(actions, state.Plan(vm)...)
+	})
+	return actions, err
+}
+
+// Diff fetches the current state of vms and compares it with their desired
+// states without changing anything.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - vms: The VMs to compare
+//   - desired: The desired state of each VM keyed by name; VMs without one are skipped
+//
+// Returns:
+//   - []model.VMDrift: One entry per existing VM with a desired state, in the order of vms
+//   - error: Error if the VMs cannot be fetched, or joined errors for VMs that do not exist
+func (uc *ApplyDesiredStateUseCase) Diff(ctx context.Context, vms []*model.VM, desired map[string]model.DesiredState) ([]model.VMDrift, error) {
+	var drifts []model.VMDrift
+	err := uc.eachManaged(ctx, vms, desired, func(vm *model.VM, state model.DesiredState) {
+		drifts = append(drifts, state.Drift(vm))
+	})
+	return drifts, err
+}
+
+// eachManaged fetches vms and calls fn with the current state of each VM that
+// has a desired state, in the order of vms.
+func (uc *ApplyDesiredStateUseCase) eachManaged(ctx context.Context, vms []*model.VM, desired map[string]model.DesiredState, fn func(*model.VM, model.DesiredState)) error {
+	found, err := uc.vmRepo.FindAll(ctx, vms)
+	if err != nil {
+		return fmt.Errorf("failed to get VMs: %w", err)
+	}
+
+	var errs []error
+	for i, vm := range vms {
+		state, ok := desired[vm.Name]
+		if !ok {
+			continue
+		}
+		if found[i] == nil {
+			errs = append(errs, fmt.Errorf("VM %s (project=%s, zone=%s): %w", vm.Name, vm.Project, vm.Zone, model.ErrVMNotFound))
+			continue
+		}
+		fn(found[i], state)
+	}
+	return errors.Join(errs...)
+}
+
+// Apply runs actions as returned by Plan. The actions of one VM run in order and
+// VMs are changed in parallel; a failed action skips the remaining actions of
+// its VM only.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - actions: The actions to run
+//
+// Returns:
+//   - error: Joined errors of the VMs whose actions failed
+func (uc *ApplyDesiredStateUseCase) Apply(ctx context.Context, actions []model.ApplyAction) error {
+	var order []string
+	byVM := make(map[string][]model.ApplyAction)
+	for _, action := range actions {
+		name := action.VM.Name
+		if _, ok := byVM[name]; !ok {
+			order = append(order, name)
+		}
+		byVM[name] = append(byVM[name], action)
+	}
+
+	errs := make([]error, len(order))
+	var eg errgroup.Group
+	eg.SetLimit(uc.maxConcurrency)
+	for i, name := range order {
+		eg.Go(func() error {
+			for _, action := range byVM[name] {
+				if err := uc.applyOne(ctx, action); err != nil {
+					errs[i] = fmt.Errorf("VM %s: failed to %s: %w", name, action.Type, err)
+					return nil
+				}
+			}
+			return nil
+		})
+	}
+	_ = eg.Wait()
+	return errors.Join(errs...)
+}
+
+// applyOne runs a single action.
+func (uc *ApplyDesiredStateUseCase) applyOne(ctx context.Context, action model.ApplyAction) error {
+	vm := action.VM
+	switch action.Type {
+	case model.ApplyStart:
+		if err := uc.vmRepo.Start(ctx, vm); err != nil {
+			return err
+		}
+	case model.ApplyStop:
+		if err := uc.vmRepo.Stop(ctx, vm); err != nil {
+			return err
+		}
+	case model.ApplySetMachineType:
+		changeUseCase := NewChangeMachineTypeWithRestartUseCase(uc.vmRepo, uc.logger)
+		if err := changeUseCase.Execute(ctx, vm.Project, vm.Zone, vm.Name, action.To); err != nil {
+			return err
+		}
+	case model.ApplySetSchedulePolicy:
+		if action.From != "" {
+			if err := uc.vmRepo.UnsetSchedulePolicy(ctx, vm, action.From); err != nil {
+				return err
+			}
+		}
+		if err := uc.vmRepo.SetSchedulePolicy(ctx, vm, action.To); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown action %q", action.Type)
+	}
+	uc.logger.Infof("✓ Applied %s to VM %s", action.Type, vm.Name)
+	return nil
+}
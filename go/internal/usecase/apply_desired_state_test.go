@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestApplyDesiredStateUseCase_Plan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	vms := []*model.VM{{Name: "web"}, {Name: "batch"}, {Name: "gone"}, {Name: "unmanaged"}}
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+	mockRepo.EXPECT().FindAll(gomock.Any(), vms).Return([]*model.VM{
+		{Name: "web", Status: model.StatusTerminated, MachineType: "e2-medium"},
+		{Name: "batch", Status: model.StatusTerminated, MachineType: "e2-medium"},
+		nil,
+		{Name: "unmanaged", Status: model.StatusRunning},
+	}, nil)
+
+	desired := map[string]model.DesiredState{
+		"web":   {Status: model.DesiredRunning, MachineType: "e2-standard-4"},
+		"batch": {Status: model.DesiredStopped},
+		"gone":  {Status: model.DesiredRunning},
+	}
+	actions, err := NewApplyDesiredStateUseCase(mockRepo, log.NewLogger()).Plan(context.Background(), vms, desired)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "VM gone")
+	require.Len(t, actions, 2)
+	assert.Equal(t, model.ApplySetMachineType, actions[0].Type)
+	assert.Equal(t, "e2-standard-4", actions[0].To)
+	assert.Equal(t, model.ApplyStart, actions[1].Type)
+}
+
+func TestApplyDesiredStateUseCase_Apply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	web := &model.VM{Name: "web", Status: model.StatusRunning}
+	db := &model.VM{Name: "db", Status: model.StatusRunning, MachineType: "e2-medium"}
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+	mockRepo.EXPECT().Stop(gomock.Any(), web).Return(nil)
+	mockRepo.EXPECT().Stop(gomock.Any(), db).Return(errors.New("quota exceeded"))
+
+	actions := []model.ApplyAction{
+		{VM: web, Type: model.ApplyStop},
+		{VM: db, Type: model.ApplyStop},
+		{VM: db, Type: model.ApplySetMachineType, From: "e2-medium", To: "e2-small"},
+	}
+	err := NewApplyDesiredStateUseCase(mockRepo, log.NewLogger()).Apply(context.Background(), actions)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "VM db: failed to stop: quota exceeded")
+	assert.NotContains(t, err.Error(), "VM web")
+}
+
+func TestApplyDesiredStateUseCase_Diff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	vms := []*model.VM{{Name: "web"}, {Name: "batch"}}
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+	mockRepo.EXPECT().FindAll(gomock.Any(), vms).Return([]*model.VM{
+		{Name: "web", Status: model.StatusRunning, MachineType: "e2-medium"},
+		{Name: "batch", Status: model.StatusRunning, Schedule: &model.SchedulePolicy{Name: "nightly-stop"}},
+	}, nil)
+
+	desired := map[string]model.DesiredState{
+		"web":   {Status: model.DesiredRunning, MachineType: "e2-medium"},
+		"batch": {SchedulePolicy: "weekday-stop"},
+	}
+	drifts, err := NewApplyDesiredStateUseCase(mockRepo, log.NewLogger()).Diff(context.Background(), vms, desired)
+	require.NoError(t, err)
+	require.Len(t, drifts, 2)
+	assert.Empty(t, drifts[0].Fields)
+	assert.Equal(t, []model.FieldDrift{{Field: "schedule-policy", Current: "nightly-stop", Desired: "weekday-stop"}}, drifts[1].Fields)
+}
+
+func TestApplyDesiredStateUseCase_ApplySchedulePolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	vm := &model.VM{Name: "batch"}
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+	gomock.InOrder(
+		mockRepo.EXPECT().UnsetSchedulePolicy(gomock.Any(), vm, "nightly-stop").Return(nil),
+		mockRepo.EXPECT().SetSchedulePolicy(gomock.Any(), vm, "weekday-stop").Return(nil),
+	)
+
+	actions := []model.ApplyAction{{VM: vm, Type: model.ApplySetSchedulePolicy, From: "nightly-stop", To: "weekday-stop"}}
+	require.NoError(t, NewApplyDesiredStateUseCase(mockRepo, log.NewLogger()).Apply(context.Background(), actions))
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// AttachDiskUseCase handles attaching an existing persistent disk to a VM
+type AttachDiskUseCase struct {
+	repo   repository.DiskRepository
+	logger log.Logger
+}
+
+// NewAttachDiskUseCase creates a new instance of AttachDiskUseCase
+func NewAttachDiskUseCase(repo repository.DiskRepository, logger log.Logger) *AttachDiskUseCase {
+	return &AttachDiskUseCase{repo: repo, logger: logger}
+}
+
+// Execute attaches the persistent disk diskName, which must be in the VM's zone,
+// to a VM as a secondary disk.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - project: The GCP project ID
+//   - zone: The GCP zone
+//   - name: The VM instance name
+//   - diskName: The disk to attach
+//   - readOnly: Attach the disk in READ_ONLY mode instead of READ_WRITE
+//
+// Returns:
+//   - error: nil on success, otherwise an error describing what went wrong.
+//     Attaching a disk that is already attached to the VM is an error.
+//
+// Example:
+//
+//	usecase := NewAttachDiskUseCase(diskRepo, logger)
+//	err := usecase.Execute(ctx, "my-project", "us-central1-a", "my-vm", "data-1", false)
+func (uc *AttachDiskUseCase) Execute(ctx context.Context, project, zone, name, diskName string, readOnly bool) error {
+	vm := &model.VM{Project: project, Zone: zone, Name: name}
+	disks, err := uc.repo.List(ctx, vm)
+	if err != nil {
+		return fmt.Errorf("failed to list disks: %w", err)
+	}
+	if model.FindDisk(disks, diskName) != nil {
+		return fmt.Errorf("disk %s is already attached to VM %s", diskName, name)
+	}
+
+	if attachErr := uc.repo.Attach(ctx, vm, diskName, readOnly); attachErr != nil {
+		return fmt.Errorf("failed to attach disk: %w", attachErr)
+	}
+
+	uc.logger.Infof("✓ Successfully attached disk %s to VM %s", diskName, name)
+	return nil
+}
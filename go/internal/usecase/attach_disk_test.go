@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAttachDiskUseCase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repository.NewMockDiskRepository(ctrl)
+	repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(attachedDisks(), nil).Times(3)
+	repo.EXPECT().Attach(gomock.Any(), gomock.Any(), "data-2", true).Return(nil)
+	repo.EXPECT().Attach(gomock.Any(), gomock.Any(), "data-3", false).Return(errors.New("in use"))
+
+	uc := NewAttachDiskUseCase(repo, loggerForDisk)
+	require.NoError(t, uc.Execute(context.Background(), "proj", "z", "vm-1", "data-2", true))
+
+	err := uc.Execute(context.Background(), "proj", "z", "vm-1", "data-1", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already attached")
+
+	err = uc.Execute(context.Background(), "proj", "z", "vm-1", "data-3", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to attach disk")
+}
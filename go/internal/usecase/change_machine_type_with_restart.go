@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+const defaultStopPollInterval = 2 * time.Second
+
+// PhaseRunner runs one phase of a multi-step use case, e.g. to show progress
+// while fn runs. ConsolePresenter.ExecuteWithProgress satisfies it.
+type PhaseRunner func(ctx context.Context, message string, fn func(context.Context) error) error
+
+// runPhaseDirectly is the default PhaseRunner; it runs fn without reporting.
+func runPhaseDirectly(ctx context.Context, _ string, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+// ChangeMachineTypeWithRestartUseCase changes the machine type of a VM that may be
+// running by stopping it, applying the new machine type and starting it again.
+type ChangeMachineTypeWithRestartUseCase struct {
+	vmRepo       repository.VMRepository
+	logger       log.Logger
+	runPhase     PhaseRunner
+	pollInterval time.Duration
+}
+
+// NewChangeMachineTypeWithRestartUseCase creates a new instance of ChangeMachineTypeWithRestartUseCase
+func NewChangeMachineTypeWithRestartUseCase(vmRepo repository.VMRepository, logger log.Logger) *ChangeMachineTypeWithRestartUseCase {
+	return &ChangeMachineTypeWithRestartUseCase{
+		vmRepo:       vmRepo,
+		logger:       logger,
+		runPhase:     runPhaseDirectly,
+		pollInterval: defaultStopPollInterval,
+	}
+}
+
+// WithPhaseRunner sets the runner each phase (stop, wait, update, start) is executed with.
+func (uc *ChangeMachineTypeWithRestartUseCase) WithPhaseRunner(r PhaseRunner) *ChangeMachineTypeWithRestartUseCase {
+	if r != nil {
+		uc.runPhase = r
+	}
+	return uc
+}
+
+// Execute changes the machine type of a VM, restarting it if it is running.
+//
+// This method performs the following phases:
+// 1. Stops the VM if it is running
+// 2. Waits until the VM is TERMINATED
+// 3. Applies the new machine type
+// 4. Starts the VM again if it was running
+//
+// A VM that is already stopped is updated and left stopped, and a VM in a
+// transitional state such as STOPPING is waited for. If the update fails
+// after the VM was stopped, the VM is started again with its old machine type so
+// the failure does not leave it down.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - project: The GCP project ID
+//   - zone: The GCP zone
+//   - name: The VM instance name
+//   - machineType: The new machine type (e.g., "e2-medium", "custom-8-32768")
+//
+// Returns:
+//   - error: nil on success, otherwise an error naming the phase that failed
+//
+// Example:
+//
+//	usecase := NewChangeMachineTypeWithRestartUseCase(vmRepo, logger).WithPhaseRunner(console.ExecuteWithProgress)
+//	err := usecase.Execute(ctx, "my-project", "us-central1-a", "my-vm", "e2-standard-4")
+func (uc *ChangeMachineTypeWithRestartUseCase) Execute(ctx context.Context, project, zone, name, machineType string) error {
+	vm := &model.VM{Project: project, Zone: zone, Name: name}
+	foundVM, err := uc.vmRepo.FindByName(ctx, vm)
+	if err != nil {
+		return fmt.Errorf("failed to find VM: %w", err)
+	}
+	if foundVM == nil {
+		return fmt.Errorf("VM %s: %w", name, model.ErrVMNotFound)
+	}
+
+	if foundVM.Status == model.StatusProvisioning {
+		return fmt.Errorf("VM %s is being started; retry once it is RUNNING", name)
+	}
+	wasRunning := foundVM.Status == model.StatusRunning
+	if wasRunning {
+		if stopErr := uc.runPhase(ctx, fmt.Sprintf("Stopping VM %s", name), func(ctx context.Context) error {
+			return uc.vmRepo.Stop(ctx, foundVM)
+		}); stopErr != nil {
+			return fmt.Errorf("failed to stop VM: %w", stopErr)
+		}
+	}
+
+	if !foundVM.CanChangeMachineType() {
+		if waitErr := uc.runPhase(ctx, fmt.Sprintf("Waiting for VM %s to be TERMINATED", name), func(ctx context.Context) error {
+			stopped, err := uc.waitStopped(ctx, vm)
+			if err == nil {
+				foundVM = stopped
+			}
+			return err
+		}); waitErr != nil {
+			return fmt.Errorf("VM %s did not stop: %w", name, waitErr)
+		}
+	}
+
+	updateErr := uc.runPhase(ctx, fmt.Sprintf("Updating machine type of VM %s to %s", name, machineType), func(ctx context.Context) error {
+		return uc.vmRepo.UpdateMachineType(ctx, foundVM, machineType)
+	})
+	if updateErr != nil {
+		updateErr = fmt.Errorf("failed to update machine type: %w", updateErr)
+		if wasRunning {
+			if startErr := uc.vmRepo.Start(ctx, foundVM); startErr != nil {
+				return fmt.Errorf("%w; restarting VM with its old machine type also failed: %v", updateErr, startErr)
+			}
+			uc.logger.Warnf("Restarted VM %s with its old machine type", name)
+		}
+		return updateErr
+	}
+
+	if wasRunning {
+		if startErr := uc.runPhase(ctx, fmt.Sprintf("Starting VM %s", name), func(ctx context.Context) error {
+			return uc.vmRepo.Start(ctx, foundVM)
+		}); startErr != nil {
+			return fmt.Errorf("machine type was updated but starting VM failed: %w", startErr)
+		}
+	}
+
+	uc.logger.Infof("✓ Successfully updated machine type to %s for VM %s", machineType, name)
+	return nil
+}
+
+// ExecuteCustom is Execute for a custom machine type, which is validated against
+// GCE's constraints before the VM is stopped.
+//
+// Returns:
+//   - string: The applied machine type name (e.g., "custom-8-32768")
+//   - error: nil on success, otherwise an error describing what went wrong
+func (uc *ChangeMachineTypeWithRestartUseCase) ExecuteCustom(ctx context.Context, project, zone, name string, custom model.CustomMachineType) (string, error) {
+	if err := validateCustomMachineType(custom); err != nil {
+		return "", err
+	}
+	machineType := custom.Name()
+	if err := uc.Execute(ctx, project, zone, name, machineType); err != nil {
+		return "", err
+	}
+	return machineType, nil
+}
+
+// waitStopped polls the VM every pollInterval until it can have its machine type changed.
+func (uc *ChangeMachineTypeWithRestartUseCase) waitStopped(ctx context.Context, vm *model.VM) (*model.VM, error) {
+	ticker := time.NewTicker(uc.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		current, err := uc.vmRepo.FindByName(ctx, vm)
+		if err != nil {
+			return nil, err
+		}
+		if current != nil && current.CanChangeMachineType() {
+			return current, nil
+		}
+		if current != nil {
+			uc.logger.Debugf("VM %s is %s, waiting", vm.Name, current.Status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
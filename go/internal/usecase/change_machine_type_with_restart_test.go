@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestChangeMachineTypeWithRestartUseCase_Execute(t *testing.T) {
+	running := &model.VM{Name: "vm-1", Project: "proj", Zone: "z", Status: model.StatusRunning}
+	stopping := &model.VM{Name: "vm-1", Project: "proj", Zone: "z", Status: model.StatusUnknown}
+	terminated := &model.VM{Name: "vm-1", Project: "proj", Zone: "z", Status: model.StatusTerminated}
+
+	//nolint:govet // field alignment is less important than readability in tests
+	tests := []struct {
+		name       string
+		setup      func(m *mock_repository.MockVMRepository)
+		wantPhases []string
+		wantErr    string
+	}{
+		{
+			name: "success: running VM is stopped, updated and started",
+			setup: func(m *mock_repository.MockVMRepository) {
+				gomock.InOrder(
+					m.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(running, nil),
+					m.EXPECT().Stop(gomock.Any(), running).Return(nil),
+					m.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(stopping, nil),
+					m.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(terminated, nil),
+					m.EXPECT().UpdateMachineType(gomock.Any(), terminated, "e2-standard-4").Return(nil),
+					m.EXPECT().Start(gomock.Any(), terminated).Return(nil),
+				)
+			},
+			wantPhases: []string{
+				"Stopping VM vm-1",
+				"Waiting for VM vm-1 to be TERMINATED",
+				"Updating machine type of VM vm-1 to e2-standard-4",
+				"Starting VM vm-1",
+			},
+		},
+		{
+			name: "success: stopped VM is only updated",
+			setup: func(m *mock_repository.MockVMRepository) {
+				m.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(terminated, nil)
+				m.EXPECT().UpdateMachineType(gomock.Any(), terminated, "e2-standard-4").Return(nil)
+			},
+			wantPhases: []string{"Updating machine type of VM vm-1 to e2-standard-4"},
+		},
+		{
+			name: "error: failed update restarts the VM with its old machine type",
+			setup: func(m *mock_repository.MockVMRepository) {
+				gomock.InOrder(
+					m.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(running, nil),
+					m.EXPECT().Stop(gomock.Any(), running).Return(nil),
+					m.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(terminated, nil),
+					m.EXPECT().UpdateMachineType(gomock.Any(), terminated, "e2-standard-4").Return(errors.New("quota exceeded")),
+					m.EXPECT().Start(gomock.Any(), terminated).Return(nil),
+				)
+			},
+			wantPhases: []string{
+				"Stopping VM vm-1",
+				"Waiting for VM vm-1 to be TERMINATED",
+				"Updating machine type of VM vm-1 to e2-standard-4",
+			},
+			wantErr: "failed to update machine type: quota exceeded",
+		},
+		{
+			name: "error: stop fails",
+			setup: func(m *mock_repository.MockVMRepository) {
+				m.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(running, nil)
+				m.EXPECT().Stop(gomock.Any(), running).Return(errors.New("denied"))
+			},
+			wantPhases: []string{"Stopping VM vm-1"},
+			wantErr:    "failed to stop VM",
+		},
+		{
+			name: "error: VM is being started",
+			setup: func(m *mock_repository.MockVMRepository) {
+				m.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(&model.VM{Name: "vm-1", Status: model.StatusProvisioning}, nil)
+			},
+			wantErr: "is being started",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mock_repository.NewMockVMRepository(ctrl)
+			tt.setup(repo)
+
+			var phases []string
+			uc := NewChangeMachineTypeWithRestartUseCase(repo, log.NewLogger()).
+				WithPhaseRunner(func(ctx context.Context, message string, fn func(context.Context) error) error {
+					phases = append(phases, message)
+					return fn(ctx)
+				})
+			uc.pollInterval = time.Millisecond
+
+			err := uc.Execute(context.Background(), "proj", "z", "vm-1", "e2-standard-4")
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantPhases, phases)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// BudgetCheck returns a *model.BudgetExceededError (possibly joined) if starting
+// vms is projected to exceed a budget. CheckBudgetUseCase.Execute satisfies it.
+type BudgetCheck func(ctx context.Context, vms []*model.VM) error
+
+// CheckBudgetUseCase projects this month's spend before VMs are started.
+type CheckBudgetUseCase struct {
+	report     *UsageReportUseCase
+	configured []*model.VM
+	hourlyCost map[string]float64
+	budget     model.Budget
+}
+
+// NewCheckBudgetUseCase creates a new instance of CheckBudgetUseCase.
+// Spend so far is computed with the usage report of all configured VMs.
+func NewCheckBudgetUseCase(report *UsageReportUseCase, configured []*model.VM, budget model.Budget, hourlyCost map[string]float64) *CheckBudgetUseCase {
+	return &CheckBudgetUseCase{report: report, configured: configured, budget: budget, hourlyCost: hourlyCost}
+}
+
+// Execute checks whether starting vms keeps this month's projected spend within budget.
+//
+// The projected spend is the estimated spend so far this month plus the hourly cost
+// of each VM to start for the budget's projection (how long a started VM is assumed
+// to run). VMs without a price add nothing to the projection.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - vms: The VMs about to be started
+//
+// Returns:
+//   - error: nil if within budget, the joined *model.BudgetExceededError of every
+//     exceeded cap, or an error if the spend so far could not be computed
+func (uc *CheckBudgetUseCase) Execute(ctx context.Context, vms []*model.VM) error {
+	if !uc.budget.IsSet() {
+		return nil
+	}
+
+	// 1. 今月のここまでの利用額を集計
+	now := uc.report.now()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	report, err := uc.report.Execute(ctx, uc.configured, month, uc.hourlyCost)
+	if err != nil {
+		return err
+	}
+	spent := make(map[string]float64, len(report.VMs))
+	machineTypes := make(map[string]string, len(report.VMs))
+	for _, u := range report.VMs {
+		machineTypes[u.VM.Name] = u.VM.MachineType
+		if u.Cost != nil {
+			spent[u.VM.Name] = *u.Cost
+		}
+	}
+
+	// 2. 起動するVMの見込み額を加えて上限と比較
+	hours := uc.budget.Projection.Hours()
+	var exceeded []error
+	projectedTotal := report.TotalCost
+	for _, vm := range vms {
+		added := uc.hourlyCost[machineTypes[vm.Name]] * hours
+		projectedTotal += added
+		limit, ok := uc.budget.MonthlyPerVM[vm.Name]
+		if !ok {
+			continue
+		}
+		if projected := spent[vm.Name] + added; projected > limit {
+			exceeded = append(exceeded, &model.BudgetExceededError{VMName: vm.Name, Cap: limit, Projected: projected})
+		}
+	}
+	if uc.budget.MonthlyTotal > 0 && projectedTotal > uc.budget.MonthlyTotal {
+		exceeded = append(exceeded, &model.BudgetExceededError{Cap: uc.budget.MonthlyTotal, Projected: projectedTotal})
+	}
+	return errors.Join(exceeded...)
+}
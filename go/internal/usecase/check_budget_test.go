@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var loggerForCheckBudget = log.NewLogger()
+
+func TestCheckBudgetUseCase_Execute(t *testing.T) {
+	now := time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC)
+	gpuCfg := &model.VM{Name: "gpu", Project: "p", Zone: "z"}
+	devCfg := &model.VM{Name: "dev", Project: "p", Zone: "z"}
+	configured := []*model.VM{gpuCfg, devCfg}
+	hourlyCost := map[string]float64{"a2-highgpu-1g": 4, "e2-medium": 0.05}
+
+	// gpu ran 20h (stopped now) and dev has been running all month (240h)
+	newUseCase := func(t *testing.T, ctrl *gomock.Controller, budget model.Budget) *CheckBudgetUseCase {
+		t.Helper()
+		vmRepo := mock_repository.NewMockVMRepository(ctrl)
+		opRepo := mock_repository.NewMockOperationRepository(ctrl)
+		store := mock_repository.NewMockRunEventStore(ctrl)
+		vmRepo.EXPECT().FindAll(gomock.Any(), configured).Return([]*model.VM{
+			{Name: "gpu", Project: "p", Zone: "z", MachineType: "a2-highgpu-1g", Status: model.StatusTerminated},
+			{Name: "dev", Project: "p", Zone: "z", MachineType: "e2-medium", Status: model.StatusRunning},
+		}, nil)
+		opRepo.EXPECT().ListByTarget(gomock.Any(), gomock.Any(), operationHistoryLimit).Return(nil, nil).Times(2)
+		store.EXPECT().Add().Return(nil).Times(2)
+		store.EXPECT().List(gpuCfg).Return([]*model.RunEvent{
+			{Type: model.RunEventStart, Time: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+			{Type: model.RunEventStop, Time: time.Date(2025, 1, 2, 20, 0, 0, 0, time.UTC)},
+		})
+		store.EXPECT().List(devCfg).Return(nil)
+
+		report := NewUsageReportUseCase(vmRepo, opRepo, store, loggerForCheckBudget)
+		report.now = func() time.Time { return now }
+		return NewCheckBudgetUseCase(report, configured, budget, hourlyCost)
+	}
+
+	t.Run("within budget", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uc := newUseCase(t, ctrl, model.Budget{MonthlyPerVM: map[string]float64{"gpu": 120}, MonthlyTotal: 200, Projection: 8 * time.Hour})
+		assert.NoError(t, uc.Execute(context.Background(), []*model.VM{gpuCfg}), "80 + 32 <= 120 and 92 + 32 <= 200")
+	})
+
+	t.Run("per-VM and total caps exceeded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uc := newUseCase(t, ctrl, model.Budget{MonthlyPerVM: map[string]float64{"gpu": 100}, MonthlyTotal: 120, Projection: 8 * time.Hour})
+		err := uc.Execute(context.Background(), []*model.VM{gpuCfg})
+		require.ErrorIs(t, err, model.ErrBudgetExceeded)
+
+		var budgetErr *model.BudgetExceededError
+		require.True(t, errors.As(err, &budgetErr))
+		assert.Equal(t, "gpu", budgetErr.VMName)
+		assert.InDelta(t, 112.0, budgetErr.Projected, 1e-9)
+		assert.Contains(t, err.Error(), "projected spend of all VMs this month is $124.00, over the $120.00 budget")
+	})
+
+	t.Run("no budget skips the report", func(t *testing.T) {
+		uc := NewCheckBudgetUseCase(nil, configured, model.Budget{}, hourlyCost)
+		assert.NoError(t, uc.Execute(context.Background(), []*model.VM{gpuCfg}))
+	})
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// CloneResult describes a finished clone.
+type CloneResult struct {
+	// VM is the new VM (Name, Project and Zone).
+	VM *model.VM
+	// MachineImage is the machine image the clone was created from. It is kept.
+	MachineImage string
+}
+
+// CloneVMUseCase handles copying a VM through a machine image
+type CloneVMUseCase struct {
+	vmRepo    repository.VMRepository
+	imageRepo repository.MachineImageRepository
+	logger    log.Logger
+	runPhase  PhaseRunner
+	now       func() time.Time
+}
+
+// NewCloneVMUseCase creates a new instance of CloneVMUseCase
+func NewCloneVMUseCase(vmRepo repository.VMRepository, imageRepo repository.MachineImageRepository, logger log.Logger) *CloneVMUseCase {
+	return &CloneVMUseCase{
+		vmRepo:    vmRepo,
+		imageRepo: imageRepo,
+		logger:    logger,
+		runPhase:  runPhaseDirectly,
+		now:       time.Now,
+	}
+}
+
+// WithPhaseRunner sets the runner each phase (machine image, instance) is executed with.
+func (uc *CloneVMUseCase) WithPhaseRunner(r PhaseRunner) *CloneVMUseCase {
+	if r != nil {
+		uc.runPhase = r
+	}
+	return uc
+}
+
+// Execute copies a VM by creating a machine image of it and a new VM from that image.
+//
+// This method performs the following steps:
+// 1. Validates the new name and retrieves the source VM
+// 2. Creates a machine image of the source VM, named after it and the current UTC time
+// 3. Creates the new VM from the machine image in targetZone
+//
+// The machine image is kept so further copies can be made from it.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - project: The GCP project ID of the source VM (the clone is created in the same project)
+//   - zone: The GCP zone of the source VM
+//   - name: The source VM instance name
+//   - newName: The name of the new VM
+//   - targetZone: The zone of the new VM, or "" for the source VM's zone
+//
+// Returns:
+//   - *CloneResult: The new VM and the machine image it was created from
+//   - error: nil on success, otherwise an error describing what went wrong
+//
+// Example:
+//
+//	usecase := NewCloneVMUseCase(vmRepo, imageRepo, logger).WithPhaseRunner(console.ExecuteWithProgress)
+//	result, err := usecase.Execute(ctx, "my-project", "us-central1-a", "my-vm", "my-vm-copy", "")
+func (uc *CloneVMUseCase) Execute(ctx context.Context, project, zone, name, newName, targetZone string) (*CloneResult, error) {
+	// 1. 入力チェックとコピー元VMの取得
+	if err := model.ValidateResourceName(newName); err != nil {
+		return nil, err
+	}
+	if targetZone == "" {
+		targetZone = zone
+	}
+	if newName == name {
+		return nil, fmt.Errorf("the new VM must have a different name than %s", name)
+	}
+	source, err := uc.vmRepo.FindByName(ctx, &model.VM{Project: project, Zone: zone, Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find VM: %w", err)
+	}
+	if source == nil {
+		return nil, fmt.Errorf("VM %s: %w", name, model.ErrVMNotFound)
+	}
+
+	// 2. マシンイメージを作成
+	imageName := model.MachineImageName(source.Name, uc.now())
+	err = uc.runPhase(ctx, fmt.Sprintf("Creating machine image %s of VM %s", imageName, source.Name), func(ctx context.Context) error {
+		return uc.imageRepo.Create(ctx, source, imageName)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create machine image: %w", err)
+	}
+
+	// 3. マシンイメージから新しいVMを作成
+	target := &model.VM{Project: project, Zone: targetZone, Name: newName}
+	err = uc.runPhase(ctx, fmt.Sprintf("Creating VM %s in %s", newName, targetZone), func(ctx context.Context) error {
+		return uc.imageRepo.CreateInstance(ctx, imageName, target)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create VM %s from machine image %s: %w", newName, imageName, err)
+	}
+
+	uc.logger.Infof("✓ Successfully cloned VM %s to %s", source.Name, newName)
+	return &CloneResult{VM: target, MachineImage: imageName}, nil
+}
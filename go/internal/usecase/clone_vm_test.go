@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var loggerForCloneVM = log.NewLogger()
+
+func TestCloneVMUseCase_Execute(t *testing.T) {
+	now := time.Date(2025, 1, 2, 6, 4, 5, 0, time.UTC)
+	source := &model.VM{Name: "sandbox", Project: "proj", Zone: "us-central1-a", Status: model.StatusRunning}
+
+	//nolint:govet // Test struct field order optimized for readability
+	tests := []struct {
+		name        string
+		newName     string
+		targetZone  string
+		wantFind    bool
+		createErr   error
+		wantCreate  bool
+		instanceErr error
+		wantTarget  *model.VM
+		errContains string
+	}{
+		{
+			name: "success: same zone", newName: "sandbox-copy",
+			wantFind: true, wantCreate: true,
+			wantTarget: &model.VM{Name: "sandbox-copy", Project: "proj", Zone: "us-central1-a"},
+		},
+		{
+			name: "success: other zone", newName: "sandbox-tokyo", targetZone: "asia-northeast1-a",
+			wantFind: true, wantCreate: true,
+			wantTarget: &model.VM{Name: "sandbox-tokyo", Project: "proj", Zone: "asia-northeast1-a"},
+		},
+		{name: "error: invalid name", newName: "Sandbox_Copy", errContains: "invalid name"},
+		{name: "error: same name", newName: "sandbox", targetZone: "asia-northeast1-a", errContains: "different name"},
+		{
+			name: "error: machine image fails", newName: "sandbox-copy",
+			wantFind: true, createErr: errors.New("quota"), errContains: "failed to create machine image",
+		},
+		{
+			name: "error: instance creation fails", newName: "sandbox-copy",
+			wantFind: true, wantCreate: true, instanceErr: errors.New("already exists"),
+			wantTarget:  &model.VM{Name: "sandbox-copy", Project: "proj", Zone: "us-central1-a"},
+			errContains: "from machine image sandbox-20250102-060405",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			vmRepo := mock_repository.NewMockVMRepository(ctrl)
+			imageRepo := mock_repository.NewMockMachineImageRepository(ctrl)
+			if tt.wantFind {
+				vmRepo.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(source, nil)
+				imageRepo.EXPECT().Create(gomock.Any(), source, "sandbox-20250102-060405").Return(tt.createErr)
+			}
+			if tt.wantCreate {
+				imageRepo.EXPECT().CreateInstance(gomock.Any(), "sandbox-20250102-060405", tt.wantTarget).Return(tt.instanceErr)
+			}
+
+			uc := NewCloneVMUseCase(vmRepo, imageRepo, loggerForCloneVM)
+			uc.now = func() time.Time { return now }
+
+			got, err := uc.Execute(context.Background(), "proj", "us-central1-a", "sandbox", tt.newName, tt.targetZone)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &CloneResult{VM: tt.wantTarget, MachineImage: "sandbox-20250102-060405"}, got)
+		})
+	}
+}
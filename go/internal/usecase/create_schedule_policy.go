@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+)
+
+// CreateSchedulePolicyUseCase creates instance schedule policies.
+type CreateSchedulePolicyUseCase struct {
+	repo repository.SchedulePolicyRepository
+}
+
+// NewCreateSchedulePolicyUseCase creates a new CreateSchedulePolicyUseCase instance.
+func NewCreateSchedulePolicyUseCase(repo repository.SchedulePolicyRepository) *CreateSchedulePolicyUseCase {
+	return &CreateSchedulePolicyUseCase{repo: repo}
+}
+
+// Execute validates policy and creates it, waiting for the operation to finish.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - policy: The policy to create (must contain Project, Region, Name, a time zone and at least one schedule)
+//
+// Returns:
+//   - error: Error if the policy is invalid or creation fails
+//
+// Example:
+//
+//	useCase := NewCreateSchedulePolicyUseCase(repo)
+//	err := useCase.Execute(ctx, &model.SchedulePolicy{
+//	    Name: "weekday-stop", Project: "my-project", Region: "us-central1",
+//	    StopCron: "0 19 * * 1-5", TimeZone: "Asia/Tokyo",
+//	})
+func (u *CreateSchedulePolicyUseCase) Execute(ctx context.Context, policy *model.SchedulePolicy) error {
+	if policy.Project == "" || policy.Region == "" {
+		return fmt.Errorf("project and region are required (set default-project/default-zone in config or pass --project/--region)")
+	}
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	if err := u.repo.Create(ctx, policy); err != nil {
+		return fmt.Errorf("policy %s: failed to create: %w", policy.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateSchedulePolicyUseCase_Execute(t *testing.T) {
+	valid := model.SchedulePolicy{
+		Name:     "weekday-stop",
+		Project:  "proj",
+		Region:   "us-central1",
+		StopCron: "0 19 * * 1-5",
+		TimeZone: "UTC",
+	}
+
+	//nolint:govet // field alignment is less important than readability in tests
+	tests := []struct {
+		name      string
+		modify    func(p *model.SchedulePolicy)
+		setupMock func(m *mock_repository.MockSchedulePolicyRepository)
+		wantErr   string
+	}{
+		{
+			name:   "creates valid policy",
+			modify: func(p *model.SchedulePolicy) {},
+			setupMock: func(m *mock_repository.MockSchedulePolicyRepository) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "missing region",
+			modify:    func(p *model.SchedulePolicy) { p.Region = "" },
+			setupMock: func(m *mock_repository.MockSchedulePolicyRepository) {},
+			wantErr:   "project and region are required",
+		},
+		{
+			name:      "invalid policy is not sent",
+			modify:    func(p *model.SchedulePolicy) { p.StopCron = "" },
+			setupMock: func(m *mock_repository.MockSchedulePolicyRepository) {},
+			wantErr:   "at least one of",
+		},
+		{
+			name:   "repository error",
+			modify: func(p *model.SchedulePolicy) {},
+			setupMock: func(m *mock_repository.MockSchedulePolicyRepository) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("already exists"))
+			},
+			wantErr: "policy weekday-stop: failed to create",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repository.NewMockSchedulePolicyRepository(ctrl)
+			tt.setupMock(repo)
+			policy := valid
+			tt.modify(&policy)
+
+			err := NewCreateSchedulePolicyUseCase(repo).Execute(context.Background(), &policy)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// CreateSnapshotUseCase handles taking snapshots of the disks of a VM
+type CreateSnapshotUseCase struct {
+	diskRepo     repository.DiskRepository
+	snapshotRepo repository.SnapshotRepository
+	opRepo       repository.OperationRepository
+	logger       log.Logger
+	runPhase     PhaseRunner
+	now          func() time.Time
+}
+
+// NewCreateSnapshotUseCase creates a new instance of CreateSnapshotUseCase
+func NewCreateSnapshotUseCase(
+	diskRepo repository.DiskRepository,
+	snapshotRepo repository.SnapshotRepository,
+	opRepo repository.OperationRepository,
+	logger log.Logger,
+) *CreateSnapshotUseCase {
+	return &CreateSnapshotUseCase{
+		diskRepo:     diskRepo,
+		snapshotRepo: snapshotRepo,
+		opRepo:       opRepo,
+		logger:       logger,
+		runPhase:     runPhaseDirectly,
+		now:          time.Now,
+	}
+}
+
+// WithPhaseRunner sets the runner the wait for each snapshot is executed with.
+func (uc *CreateSnapshotUseCase) WithPhaseRunner(r PhaseRunner) *CreateSnapshotUseCase {
+	if r != nil {
+		uc.runPhase = r
+	}
+	return uc
+}
+
+// Execute snapshots the disks of a VM and waits for each snapshot to finish.
+//
+// All attached disks are snapshotted unless disk names one of them (by name or
+// device name). Snapshots are named after the disk and the current UTC time,
+// e.g. "data-1-20250102-150405". Snapshots are taken one disk at a time so a
+// failure stops before the remaining disks.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - project: The GCP project ID
+//   - zone: The GCP zone
+//   - name: The VM instance name
+//   - disk: The disk to snapshot, or "" for all attached disks
+//
+// Returns:
+//   - []string: Names of the snapshots that were created
+//   - error: nil on success, otherwise an error describing what went wrong
+//
+// Example:
+//
+//	usecase := NewCreateSnapshotUseCase(diskRepo, snapshotRepo, opRepo, logger).
+//	    WithPhaseRunner(console.ExecuteWithProgress)
+//	names, err := usecase.Execute(ctx, "my-project", "us-central1-a", "my-vm", "")
+func (uc *CreateSnapshotUseCase) Execute(ctx context.Context, project, zone, name, disk string) ([]string, error) {
+	// 1. 対象ディスクを決定
+	vm := &model.VM{Project: project, Zone: zone, Name: name}
+	var targets []*model.Disk
+	if disk != "" {
+		found, err := findAttachedDisk(ctx, uc.diskRepo, vm, disk)
+		if err != nil {
+			return nil, err
+		}
+		targets = []*model.Disk{found}
+	} else {
+		disks, err := uc.diskRepo.List(ctx, vm)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list disks: %w", err)
+		}
+		targets = disks
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("VM %s has no disks to snapshot", name)
+	}
+
+	// 2. ディスクごとにスナップショットを作成し完了を待つ
+	now := uc.now()
+	created := make([]string, 0, len(targets))
+	for _, d := range targets {
+		snapshotName := model.SnapshotName(d.Name, now)
+		message := fmt.Sprintf("Creating snapshot %s of disk %s", snapshotName, d.Name)
+		err := uc.runPhase(ctx, message, func(ctx context.Context) error {
+			op, createErr := uc.snapshotRepo.CreateAsync(ctx, vm, d.Name, snapshotName)
+			if createErr != nil {
+				return createErr
+			}
+			_, waitErr := NewWaitOperationUseCase(uc.opRepo, uc.logger).Execute(ctx, op)
+			return waitErr
+		})
+		if err != nil {
+			return created, fmt.Errorf("failed to snapshot disk %s: %w", d.Name, err)
+		}
+		created = append(created, snapshotName)
+	}
+
+	uc.logger.Infof("✓ Successfully created %d snapshot(s) for VM %s", len(created), name)
+	return created, nil
+}
@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateSnapshotUseCase_Execute(t *testing.T) {
+	now := time.Date(2025, 1, 2, 6, 4, 5, 0, time.UTC)
+
+	//nolint:govet // Test struct field order optimized for readability
+	tests := []struct {
+		name        string
+		disk        string
+		failDisk    string
+		want        []string
+		wantPhases  int
+		errContains string
+	}{
+		{name: "success: all disks", want: []string{"vm-1-20250102-060405", "data-1-20250102-060405"}, wantPhases: 2},
+		{name: "success: one disk by device name", disk: "data", want: []string{"data-1-20250102-060405"}, wantPhases: 1},
+		{name: "error: disk not attached", disk: "missing", errContains: "not attached"},
+		{name: "error: operation fails", failDisk: "data-1", want: []string{"vm-1-20250102-060405"}, wantPhases: 2, errContains: "failed to snapshot disk data-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			diskRepo := mock_repository.NewMockDiskRepository(ctrl)
+			snapshotRepo := mock_repository.NewMockSnapshotRepository(ctrl)
+			opRepo := mock_repository.NewMockOperationRepository(ctrl)
+
+			diskRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(attachedDisks(), nil)
+			snapshotRepo.EXPECT().
+				CreateAsync(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, vm *model.VM, disk, snapshot string) (*model.Operation, error) {
+					return &model.Operation{Name: "op-" + disk, Project: vm.Project, Zone: vm.Zone, Target: disk}, nil
+				}).
+				Times(tt.wantPhases)
+			opRepo.EXPECT().
+				Wait(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, op *model.Operation) (*model.Operation, error) {
+					if op.Target == tt.failDisk {
+						return nil, errors.New("quota exceeded")
+					}
+					return &model.Operation{Name: op.Name, Status: "DONE"}, nil
+				}).
+				Times(tt.wantPhases)
+
+			var phases []string
+			uc := NewCreateSnapshotUseCase(diskRepo, snapshotRepo, opRepo, loggerForDisk).
+				WithPhaseRunner(func(ctx context.Context, message string, fn func(context.Context) error) error {
+					phases = append(phases, message)
+					return fn(ctx)
+				})
+			uc.now = func() time.Time { return now }
+
+			got, err := uc.Execute(context.Background(), "proj", "z", "vm-1", tt.disk)
+			assert.Len(t, phases, tt.wantPhases)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				assert.Equal(t, tt.want, got, "snapshots created before the failure are reported")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
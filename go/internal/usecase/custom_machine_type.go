@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// customMemoryStepMB is the granularity of custom machine type memory.
+const customMemoryStepMB = 256
+
+// customFamilyLimits describes the custom machine type constraints of a machine family.
+// Extended memory is not supported.
+type customFamilyLimits struct {
+	// validVCPUs reports whether a vCPU count is allowed; vcpuRule describes the rule.
+	validVCPUs func(n int) bool
+	vcpuRule   string
+	// minMemPerVCPUMB and maxMemPerVCPUMB bound the memory per vCPU.
+	minMemPerVCPUMB int
+	maxMemPerVCPUMB int
+}
+
+// customFamilies holds the constraints of the families that support custom machine types.
+// See https://cloud.google.com/compute/docs/instances/creating-instance-with-custom-machine-type
+var customFamilies = map[string]customFamilyLimits{
+	"n1": {
+		validVCPUs:      func(n int) bool { return n == 1 || (n%2 == 0 && n >= 2 && n <= 96) },
+		vcpuRule:        "1 or an even number up to 96",
+		minMemPerVCPUMB: 922, // 0.9 GB
+		maxMemPerVCPUMB: 6656,
+	},
+	"n2": {
+		validVCPUs: func(n int) bool {
+			return (n%2 == 0 && n >= 2 && n <= 32) || (n%4 == 0 && n > 32 && n <= 80)
+		},
+		vcpuRule:        "a multiple of 2 up to 32, or of 4 from 36 to 80",
+		minMemPerVCPUMB: 512,
+		maxMemPerVCPUMB: 8192,
+	},
+	"n2d": {
+		validVCPUs: func(n int) bool {
+			return n == 2 || n == 4 || n == 8 || (n%16 == 0 && n >= 16 && n <= 96)
+		},
+		vcpuRule:        "2, 4, 8 or a multiple of 16 up to 96",
+		minMemPerVCPUMB: 512,
+		maxMemPerVCPUMB: 8192,
+	},
+	"e2": {
+		validVCPUs:      func(n int) bool { return n%2 == 0 && n >= 2 && n <= 32 },
+		vcpuRule:        "an even number from 2 to 32",
+		minMemPerVCPUMB: 512,
+		maxMemPerVCPUMB: 8192,
+	},
+}
+
+// validateCustomMachineType checks c against GCE's custom machine type constraints
+// for its family: the allowed vCPU counts, memory in multiples of 256 MB, and the
+// memory-per-vCPU range.
+func validateCustomMachineType(c model.CustomMachineType) error {
+	family := c.Family
+	if family == "" {
+		family = "n1"
+	}
+	limits, ok := customFamilies[family]
+	if !ok {
+		return fmt.Errorf("machine family %q does not support custom machine types (supported: %s)", family, supportedCustomFamilies())
+	}
+	if !limits.validVCPUs(c.VCPUs) {
+		return fmt.Errorf("invalid vCPU count %d for %s custom machine type: must be %s", c.VCPUs, family, limits.vcpuRule)
+	}
+	if c.MemoryMB <= 0 || c.MemoryMB%customMemoryStepMB != 0 {
+		return fmt.Errorf("invalid memory %d MB: must be a positive multiple of %d MB", c.MemoryMB, customMemoryStepMB)
+	}
+	minMB := c.VCPUs * limits.minMemPerVCPUMB
+	maxMB := c.VCPUs * limits.maxMemPerVCPUMB
+	if c.MemoryMB < minMB || c.MemoryMB > maxMB {
+		return fmt.Errorf("invalid memory %d MB for %d vCPUs: %s custom machine types need %d-%d MB",
+			c.MemoryMB, c.VCPUs, family, minMB, maxMB)
+	}
+	return nil
+}
+
+func supportedCustomFamilies() string {
+	families := make([]string, 0, len(customFamilies))
+	for f := range customFamilies {
+		families = append(families, f)
+	}
+	sort.Strings(families)
+	return strings.Join(families, ", ")
+}
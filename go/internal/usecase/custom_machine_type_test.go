@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestValidateCustomMachineType(t *testing.T) {
+	//nolint:govet // field alignment is less important than readability in tests
+	tests := []struct {
+		name    string
+		custom  model.CustomMachineType
+		wantErr string
+	}{
+		{name: "n1 by default", custom: model.CustomMachineType{VCPUs: 8, MemoryMB: 32768}},
+		{name: "n1 single vCPU", custom: model.CustomMachineType{VCPUs: 1, MemoryMB: 3840}},
+		{name: "e2", custom: model.CustomMachineType{Family: "e2", VCPUs: 4, MemoryMB: 16384}},
+		{name: "n2 above 32 vCPUs", custom: model.CustomMachineType{Family: "n2", VCPUs: 36, MemoryMB: 36864}},
+		{name: "odd vCPUs", custom: model.CustomMachineType{VCPUs: 3, MemoryMB: 6144}, wantErr: "invalid vCPU count 3"},
+		{name: "e2 single vCPU", custom: model.CustomMachineType{Family: "e2", VCPUs: 1, MemoryMB: 1024}, wantErr: "invalid vCPU count"},
+		{name: "n2 34 vCPUs", custom: model.CustomMachineType{Family: "n2", VCPUs: 34, MemoryMB: 34816}, wantErr: "invalid vCPU count"},
+		{name: "memory not a multiple of 256 MB", custom: model.CustomMachineType{VCPUs: 2, MemoryMB: 5000}, wantErr: "multiple of 256 MB"},
+		{name: "too much memory per vCPU", custom: model.CustomMachineType{VCPUs: 2, MemoryMB: 16384}, wantErr: "need 1844-13312 MB"},
+		{name: "too little memory per vCPU", custom: model.CustomMachineType{VCPUs: 8, MemoryMB: 4096}, wantErr: "invalid memory 4096 MB for 8 vCPUs"},
+		{name: "unsupported family", custom: model.CustomMachineType{Family: "c2", VCPUs: 4, MemoryMB: 16384}, wantErr: "does not support custom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCustomMachineType(tt.custom)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCustomMachineType_Name(t *testing.T) {
+	assert.Equal(t, "custom-8-32768", model.CustomMachineType{VCPUs: 8, MemoryMB: 32768}.Name())
+	assert.Equal(t, "custom-8-32768", model.CustomMachineType{Family: "n1", VCPUs: 8, MemoryMB: 32768}.Name())
+	assert.Equal(t, "e2-custom-4-16384", model.CustomMachineType{Family: "e2", VCPUs: 4, MemoryMB: 16384}.Name())
+}
+
+func TestUpdateMachineTypeUseCase_ExecuteCustom(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stopped := &model.VM{Name: "vm-1", Project: "proj", Zone: "z", Status: model.StatusTerminated}
+	repo := mock_repository.NewMockVMRepository(ctrl)
+	repo.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(stopped, nil)
+	repo.EXPECT().UpdateMachineType(gomock.Any(), stopped, "custom-8-32768").Return(nil)
+
+	uc := NewUpdateMachineTypeUseCase(repo, loggerForUpdateMachineType)
+	machineType, err := uc.ExecuteCustom(context.Background(), "proj", "z", "vm-1", model.CustomMachineType{VCPUs: 8, MemoryMB: 32768})
+	require.NoError(t, err)
+	assert.Equal(t, "custom-8-32768", machineType)
+
+	_, err = uc.ExecuteCustom(context.Background(), "proj", "z", "vm-1", model.CustomMachineType{VCPUs: 3, MemoryMB: 6144})
+	assert.ErrorContains(t, err, "invalid vCPU count", "invalid shapes fail before any API call")
+}
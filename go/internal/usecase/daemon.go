@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// DaemonTask is one periodic job of the daemon.
+type DaemonTask struct {
+	// Name identifies the task in the audit log (e.g., "scheduled-actions").
+	Name string
+	// Interval is how often the task runs.
+	Interval time.Duration
+	// Run performs the task once and describes what it did. Entries only need
+	// VMName and Message; the daemon fills in the time and task name.
+	Run func(ctx context.Context) ([]*model.AuditEntry, error)
+}
+
+// DaemonUseCase runs periodic tasks until cancelled, auditing everything they do.
+type DaemonUseCase struct {
+	tasks  []DaemonTask
+	audit  repository.AuditLog
+	logger log.Logger
+	now    func() time.Time
+	// next is when each task runs next, by index in tasks.
+	next []time.Time
+}
+
+// NewDaemonUseCase creates a new instance of DaemonUseCase
+func NewDaemonUseCase(tasks []DaemonTask, audit repository.AuditLog, logger log.Logger) *DaemonUseCase {
+	return &DaemonUseCase{tasks: tasks, audit: audit, logger: logger, now: time.Now}
+}
+
+// Run performs every task immediately and then each time its interval elapses.
+//
+// A failing task is logged and audited, and retried at its next interval; it does
+// not stop the daemon or the other tasks.
+//
+// Parameters:
+//   - ctx: The context for the daemon; cancelling it stops the loop
+//
+// Returns:
+//   - error: nil when ctx is cancelled, or an error if a task has no positive interval
+//
+// Example:
+//
+//	daemon := NewDaemonUseCase([]DaemonTask{{Name: "refresh-cache", Interval: time.Minute, Run: refresh}}, auditLog, logger)
+//	err := daemon.Run(ctx)
+func (uc *DaemonUseCase) Run(ctx context.Context) error {
+	// 1. 入力チェック
+	for _, task := range uc.tasks {
+		if task.Interval <= 0 {
+			return fmt.Errorf("task %s: interval must be positive, got %s", task.Name, task.Interval)
+		}
+	}
+	uc.next = make([]time.Time, len(uc.tasks))
+
+	// 2. 期限の来たタスクを実行し、次の期限まで待機
+	for {
+		wake := uc.tick(ctx)
+		timer := time.NewTimer(wake.Sub(uc.now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// tick runs the tasks that are due and returns when the next one is.
+func (uc *DaemonUseCase) tick(ctx context.Context) time.Time {
+	var wake time.Time
+	for i, task := range uc.tasks {
+		if ctx.Err() != nil {
+			break
+		}
+		if !uc.now().Before(uc.next[i]) {
+			uc.runTask(ctx, task)
+			uc.next[i] = uc.now().Add(task.Interval)
+		}
+		if wake.IsZero() || uc.next[i].Before(wake) {
+			wake = uc.next[i]
+		}
+	}
+	return wake
+}
+
+// runTask runs a single task and writes what it did to the audit log.
+func (uc *DaemonUseCase) runTask(ctx context.Context, task DaemonTask) {
+	entries, err := task.Run(ctx)
+	now := uc.now()
+	if err != nil {
+		uc.logger.Errorf("Task %s failed: %v", task.Name, err)
+		entries = append(entries, &model.AuditEntry{Error: err.Error()})
+	}
+	for _, e := range entries {
+		e.Time = now
+		e.Task = task.Name
+	}
+	if auditErr := uc.audit.Append(entries...); auditErr != nil {
+		uc.logger.Errorf("Failed to write audit log: %v", auditErr)
+	}
+}
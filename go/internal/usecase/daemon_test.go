@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var loggerForDaemon = log.NewLogger()
+
+func TestDaemonUseCase_Tick(t *testing.T) {
+	start := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+	now := start
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var stops, refreshes int
+	tasks := []DaemonTask{
+		{
+			Name:     "scheduled-actions",
+			Interval: 30 * time.Second,
+			Run: func(context.Context) ([]*model.AuditEntry, error) {
+				stops++
+				return []*model.AuditEntry{{VMName: "vm-1", Message: "ran scheduled stop"}}, nil
+			},
+		},
+		{
+			Name:     "refresh-cache",
+			Interval: time.Minute,
+			Run: func(context.Context) ([]*model.AuditEntry, error) {
+				refreshes++
+				return nil, errors.New("denied")
+			},
+		},
+	}
+
+	audit := mock_repository.NewMockAuditLog(ctrl)
+	audit.EXPECT().Append(&model.AuditEntry{Time: start, Task: "scheduled-actions", VMName: "vm-1", Message: "ran scheduled stop"}).Return(nil)
+	audit.EXPECT().Append(&model.AuditEntry{Time: start, Task: "refresh-cache", Error: "denied"}).Return(errors.New("disk full"))
+	audit.EXPECT().Append(gomock.Any()).Return(nil)
+
+	uc := NewDaemonUseCase(tasks, audit, loggerForDaemon)
+	uc.now = func() time.Time { return now }
+	uc.next = make([]time.Time, len(tasks))
+
+	// 初回は全タスクを実行
+	wake := uc.tick(context.Background())
+	assert.Equal(t, start.Add(30*time.Second), wake)
+
+	// 30秒後は短い間隔のタスクのみ実行
+	now = wake
+	wake = uc.tick(context.Background())
+	assert.Equal(t, start.Add(time.Minute), wake)
+	assert.Equal(t, 2, stops)
+	assert.Equal(t, 1, refreshes)
+}
+
+func TestDaemonUseCase_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("returns nil when cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		audit := mock_repository.NewMockAuditLog(ctrl)
+		audit.EXPECT().Append().Return(nil)
+		task := DaemonTask{Name: "once", Interval: time.Hour, Run: func(context.Context) ([]*model.AuditEntry, error) {
+			cancel()
+			return nil, nil
+		}}
+
+		require.NoError(t, NewDaemonUseCase([]DaemonTask{task}, audit, loggerForDaemon).Run(ctx))
+	})
+
+	t.Run("rejects non-positive interval", func(t *testing.T) {
+		uc := NewDaemonUseCase([]DaemonTask{{Name: "bad"}}, mock_repository.NewMockAuditLog(ctrl), loggerForDaemon)
+		err := uc.Run(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "task bad: interval must be positive")
+	})
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+)
+
+// DescribeOperationUseCase retrieves the current state of a single GCE operation.
+type DescribeOperationUseCase struct {
+	opRepo repository.OperationRepository
+}
+
+// NewDescribeOperationUseCase creates a new DescribeOperationUseCase instance.
+func NewDescribeOperationUseCase(opRepo repository.OperationRepository) *DescribeOperationUseCase {
+	return &DescribeOperationUseCase{opRepo: opRepo}
+}
+
+// Execute returns the operation's status, progress and error without waiting for it.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - op: The operation to describe (must contain Project, Zone, and Name)
+//
+// Returns:
+//   - *model.Operation: The current state of the operation
+//   - error: Error if the operation cannot be retrieved
+func (u *DescribeOperationUseCase) Execute(ctx context.Context, op *model.Operation) (*model.Operation, error) {
+	current, err := u.opRepo.Get(ctx, op)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe operation: %w", err)
+	}
+	return current, nil
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDescribeOperationUseCase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	op := &model.Operation{Project: "p", Zone: "z", Name: "op-1"}
+	mockRepo := mock_repository.NewMockOperationRepository(ctrl)
+	mockRepo.EXPECT().Get(gomock.Any(), op).Return(&model.Operation{Name: "op-1", Status: "RUNNING", Progress: 40}, nil)
+	mockRepo.EXPECT().Get(gomock.Any(), op).Return(nil, errors.New("not found"))
+
+	uc := NewDescribeOperationUseCase(mockRepo)
+
+	got, err := uc.Execute(context.Background(), op)
+	require.NoError(t, err)
+	assert.Equal(t, 40, got.Progress)
+
+	_, err = uc.Execute(context.Background(), op)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to describe operation")
+}
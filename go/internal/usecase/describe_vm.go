@@ -2,21 +2,32 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/haru-256/gcectl/internal/domain/model"
 	"github.com/haru-256/gcectl/internal/domain/repository"
+	"golang.org/x/sync/errgroup"
 )
 
 // DescribeVMUseCase retrieves detailed information about a specific VM.
 type DescribeVMUseCase struct {
-	repo repository.VMRepository
+	repo           repository.VMRepository
+	maxConcurrency int
 }
 
 // NewDescribeVMUseCase creates a new DescribeVMUseCase instance.
 func NewDescribeVMUseCase(repo repository.VMRepository) *DescribeVMUseCase {
-	return &DescribeVMUseCase{repo: repo}
+	return &DescribeVMUseCase{repo: repo, maxConcurrency: maxConcurrentVMLookups}
+}
+
+// WithMaxConcurrency sets how many VMs ExecuteMany looks up at once. Values <= 0 keep the default.
+func (u *DescribeVMUseCase) WithMaxConcurrency(n int) *DescribeVMUseCase {
+	if n > 0 {
+		u.maxConcurrency = n
+	}
+	return u
 }
 
 // Execute retrieves detailed information about a specific VM and returns it with a calculated uptime string.
@@ -56,7 +67,7 @@ func (u *DescribeVMUseCase) Execute(ctx context.Context, project, zone, name str
 		return nil, "", err
 	}
 	if foundVM == nil {
-		return nil, "", fmt.Errorf("VM %s: not found", name)
+		return nil, "", fmt.Errorf("VM %s: %w", name, model.ErrVMNotFound)
 	}
 
 	// Calculate uptime using shared logic
@@ -64,3 +75,43 @@ func (u *DescribeVMUseCase) Execute(ctx context.Context, project, zone, name str
 
 	return foundVM, uptimeStr, nil
 }
+
+// ExecuteMany describes each of vms like Execute, at most maxConcurrency at a time.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - vms: The VMs to describe, as resolved from config
+//
+// Returns:
+//   - []VMListItem: The described VMs with their uptime or stopped duration, in the order of vms,
+//     without the VMs that failed
+//   - error: Joined error for the failed VMs, or nil if all succeed
+func (u *DescribeVMUseCase) ExecuteMany(ctx context.Context, vms []*model.VM) ([]VMListItem, error) {
+	items := make([]VMListItem, len(vms))
+	errs := make([]error, len(vms))
+
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(u.maxConcurrency)
+	for i, vm := range vms {
+		eg.Go(func() error {
+			found, uptime, err := u.Execute(ctx, vm.Project, vm.Zone, vm.Name)
+			if err != nil {
+				errs[i] = fmt.Errorf("VM %s (project=%s, zone=%s): %w", vm.Name, vm.Project, vm.Zone, err)
+				return nil
+			}
+			items[i] = VMListItem{VM: found, Uptime: uptime, StoppedFor: calculateStoppedForString(found, time.Now())}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	described := make([]VMListItem, 0, len(items))
+	for _, item := range items {
+		if item.VM != nil {
+			described = append(described, item)
+		}
+	}
+	return described, errors.Join(errs...)
+}
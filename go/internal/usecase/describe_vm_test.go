@@ -10,6 +10,7 @@ import (
 	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
 	"github.com/haru-256/gcectl/internal/usecase/testhelpers"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -170,3 +171,33 @@ func TestDescribeVM(t *testing.T) {
 		})
 	}
 }
+
+func TestDescribeVMExecuteMany(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+	mockRepo.EXPECT().
+		FindByName(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, vm *model.VM) (*model.VM, error) {
+			if vm.Name == "broken" {
+				return nil, errTestDescribe
+			}
+			return &model.VM{Name: vm.Name, Project: vm.Project, Zone: vm.Zone, Status: model.StatusTerminated}, nil
+		}).
+		Times(3)
+
+	vms := []*model.VM{
+		{Name: "vm-1", Project: "p", Zone: "z"},
+		{Name: "broken", Project: "p", Zone: "z"},
+		{Name: "vm-2", Project: "p", Zone: "z"},
+	}
+	items, err := NewDescribeVMUseCase(mockRepo).WithMaxConcurrency(2).ExecuteMany(context.Background(), vms)
+
+	require.ErrorIs(t, err, errTestDescribe)
+	assert.Contains(t, err.Error(), "VM broken")
+	require.Len(t, items, 2)
+	assert.Equal(t, "vm-1", items[0].VM.Name)
+	assert.Equal(t, "vm-2", items[1].VM.Name)
+	assert.Equal(t, "N/A", items[1].Uptime)
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// DetachDiskUseCase handles detaching a secondary disk from a VM
+type DetachDiskUseCase struct {
+	repo   repository.DiskRepository
+	logger log.Logger
+}
+
+// NewDetachDiskUseCase creates a new instance of DetachDiskUseCase
+func NewDetachDiskUseCase(repo repository.DiskRepository, logger log.Logger) *DetachDiskUseCase {
+	return &DetachDiskUseCase{repo: repo, logger: logger}
+}
+
+// Execute detaches a secondary disk from a VM. The disk itself is kept.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - project: The GCP project ID
+//   - zone: The GCP zone
+//   - name: The VM instance name
+//   - disk: The disk name or device name
+//
+// Returns:
+//   - error: nil on success, otherwise an error describing what went wrong.
+//     The boot disk cannot be detached.
+//
+// Example:
+//
+//	usecase := NewDetachDiskUseCase(diskRepo, logger)
+//	err := usecase.Execute(ctx, "my-project", "us-central1-a", "my-vm", "data-1")
+func (uc *DetachDiskUseCase) Execute(ctx context.Context, project, zone, name, disk string) error {
+	vm := &model.VM{Project: project, Zone: zone, Name: name}
+	found, err := findAttachedDisk(ctx, uc.repo, vm, disk)
+	if err != nil {
+		return err
+	}
+	if found.Boot {
+		return fmt.Errorf("disk %s is the boot disk of VM %s and cannot be detached", found.Name, name)
+	}
+
+	if detachErr := uc.repo.Detach(ctx, vm, found.DeviceName); detachErr != nil {
+		return fmt.Errorf("failed to detach disk: %w", detachErr)
+	}
+
+	uc.logger.Infof("✓ Successfully detached disk %s from VM %s", found.Name, name)
+	return nil
+}
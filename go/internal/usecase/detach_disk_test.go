@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDetachDiskUseCase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repository.NewMockDiskRepository(ctrl)
+	repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(attachedDisks(), nil).Times(3)
+	repo.EXPECT().Detach(gomock.Any(), gomock.Any(), "data").Return(nil)
+
+	uc := NewDetachDiskUseCase(repo, loggerForDisk)
+	require.NoError(t, uc.Execute(context.Background(), "proj", "z", "vm-1", "data-1"), "detaches by device name")
+
+	err := uc.Execute(context.Background(), "proj", "z", "vm-1", "persistent-disk-0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boot disk")
+
+	err = uc.Execute(context.Background(), "proj", "z", "vm-1", "missing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not attached")
+}
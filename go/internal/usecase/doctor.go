@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// VMConfigLoader loads the VMs of the configuration file, reporting why it cannot be used.
+type VMConfigLoader func() ([]*model.VM, error)
+
+// DoctorUseCase checks that gcectl is set up to manage the configured VMs.
+type DoctorUseCase struct {
+	preflight  repository.PreflightRepository
+	loadConfig VMConfigLoader
+	logger     log.Logger
+}
+
+// NewDoctorUseCase creates a new instance of DoctorUseCase
+func NewDoctorUseCase(preflight repository.PreflightRepository, loadConfig VMConfigLoader, logger log.Logger) *DoctorUseCase {
+	return &DoctorUseCase{preflight: preflight, loadConfig: loadConfig, logger: logger}
+}
+
+// Execute runs every preflight check and returns their results with fixes for failures.
+//
+// This method performs the following checks in order:
+// 1. The configuration file loads
+// 2. Application Default Credentials exist and yield a token
+// 3. For each project of the configured VMs: the Compute Engine API is enabled
+// 4. For each project: the caller holds model.RequiredPermissions
+//
+// Checks that depend on a failed one are reported as skipped.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//
+// Returns:
+//   - []model.CheckResult: One result per check, in the order above
+//
+// Example:
+//
+//	usecase := NewDoctorUseCase(preflight, loadConfig, logger)
+//	results := usecase.Execute(ctx)
+//	if model.Failed(results) { os.Exit(1) }
+func (uc *DoctorUseCase) Execute(ctx context.Context) []model.CheckResult {
+	var results []model.CheckResult
+
+	// 1. 設定ファイル
+	vms, err := uc.loadConfig()
+	if err != nil {
+		results = append(results, model.CheckResult{
+			Name:   "Config",
+			Status: model.CheckFailed,
+			Detail: err.Error(),
+			Fix:    "Fix config.yaml (see the Configuration section of the README) or pass its path with --config",
+		})
+	} else {
+		results = append(results, model.CheckResult{
+			Name:   "Config",
+			Status: model.CheckPassed,
+			Detail: fmt.Sprintf("%d VMs configured", len(vms)),
+		})
+	}
+
+	// 2. 認証情報
+	principal, err := uc.preflight.Credentials(ctx)
+	if err != nil {
+		results = append(results, model.CheckResult{
+			Name:   "Credentials",
+			Status: model.CheckFailed,
+			Detail: err.Error(),
+			Fix:    "Run `gcloud auth application-default login`, or set GOOGLE_APPLICATION_CREDENTIALS to a service account key file",
+		})
+	} else {
+		results = append(results, model.CheckResult{Name: "Credentials", Status: model.CheckPassed, Detail: principal})
+	}
+	credentialsOK := err == nil
+
+	// 3, 4. プロジェクトごとのAPIと権限
+	for _, project := range vmProjects(vms) {
+		if !credentialsOK {
+			results = append(results,
+				model.CheckResult{Name: "Compute API in " + project, Status: model.CheckSkipped, Detail: "needs working credentials"},
+				model.CheckResult{Name: "IAM permissions in " + project, Status: model.CheckSkipped, Detail: "needs working credentials"},
+			)
+			continue
+		}
+		apiResult := uc.checkComputeAPI(ctx, project)
+		results = append(results, apiResult)
+		if apiResult.Status != model.CheckPassed {
+			results = append(results, model.CheckResult{
+				Name:   "IAM permissions in " + project,
+				Status: model.CheckSkipped,
+				Detail: "needs the Compute Engine API",
+			})
+			continue
+		}
+		results = append(results, uc.checkPermissions(ctx, project))
+	}
+	return results
+}
+
+// checkComputeAPI checks that the Compute Engine API is enabled in project.
+func (uc *DoctorUseCase) checkComputeAPI(ctx context.Context, project string) model.CheckResult {
+	result := model.CheckResult{Name: "Compute API in " + project}
+	enabled, err := uc.preflight.ComputeAPIEnabled(ctx, project)
+	switch {
+	case err != nil:
+		result.Status = model.CheckFailed
+		result.Detail = err.Error()
+		result.Fix = fmt.Sprintf("Check that project %s exists and that you can view it (e.g. roles/viewer)", project)
+	case !enabled:
+		result.Status = model.CheckFailed
+		result.Detail = "the Compute Engine API is disabled"
+		result.Fix = fmt.Sprintf("Run `gcloud services enable compute.googleapis.com --project %s`", project)
+	default:
+		result.Status = model.CheckPassed
+		result.Detail = "enabled"
+	}
+	return result
+}
+
+// checkPermissions checks that the caller holds every required permission on project.
+func (uc *DoctorUseCase) checkPermissions(ctx context.Context, project string) model.CheckResult {
+	result := model.CheckResult{Name: "IAM permissions in " + project}
+	granted, err := uc.preflight.TestPermissions(ctx, project, model.RequiredPermissions)
+	if err != nil {
+		result.Status = model.CheckFailed
+		result.Detail = err.Error()
+		result.Fix = fmt.Sprintf("Check that project %s exists and that you can view it (e.g. roles/viewer)", project)
+		return result
+	}
+
+	var missing []string
+	for _, p := range model.RequiredPermissions {
+		if !slices.Contains(granted, p) {
+			missing = append(missing, p)
+		}
+	}
+	if len(missing) > 0 {
+		result.Status = model.CheckFailed
+		result.Detail = "missing " + strings.Join(missing, ", ")
+		result.Fix = fmt.Sprintf("Grant roles/compute.instanceAdmin.v1 (or a custom role with the missing permissions) on project %s", project)
+		return result
+	}
+	result.Status = model.CheckPassed
+	result.Detail = fmt.Sprintf("all %d required permissions granted", len(model.RequiredPermissions))
+	return result
+}
+
+// vmProjects returns the distinct projects of vms in order of first appearance.
+func vmProjects(vms []*model.VM) []string {
+	var projects []string
+	for _, vm := range vms {
+		if vm.Project != "" && !slices.Contains(projects, vm.Project) {
+			projects = append(projects, vm.Project)
+		}
+	}
+	return projects
+}
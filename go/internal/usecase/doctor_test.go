@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+var loggerForDoctor = log.NewLogger()
+
+func TestDoctorUseCase_Execute(t *testing.T) {
+	vms := []*model.VM{
+		{Name: "vm1", Project: "ok"},
+		{Name: "vm2", Project: "disabled"},
+		{Name: "vm3", Project: "restricted"},
+		{Name: "vm4", Project: "ok"},
+	}
+	loadVMs := func() ([]*model.VM, error) { return vms, nil }
+	statuses := func(results []model.CheckResult) map[string]model.CheckStatus {
+		got := make(map[string]model.CheckStatus, len(results))
+		for _, r := range results {
+			got[r.Name] = r.Status
+		}
+		return got
+	}
+
+	t.Run("checks each project", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		preflight := mock_repository.NewMockPreflightRepository(ctrl)
+		preflight.EXPECT().Credentials(gomock.Any()).Return("gcectl@p.iam.gserviceaccount.com (service_account)", nil)
+		preflight.EXPECT().ComputeAPIEnabled(gomock.Any(), "ok").Return(true, nil)
+		preflight.EXPECT().ComputeAPIEnabled(gomock.Any(), "disabled").Return(false, nil)
+		preflight.EXPECT().ComputeAPIEnabled(gomock.Any(), "restricted").Return(true, nil)
+		preflight.EXPECT().TestPermissions(gomock.Any(), "ok", model.RequiredPermissions).Return(model.RequiredPermissions, nil)
+		preflight.EXPECT().TestPermissions(gomock.Any(), "restricted", model.RequiredPermissions).Return([]string{"compute.instances.get", "compute.instances.list"}, nil)
+
+		results := NewDoctorUseCase(preflight, loadVMs, loggerForDoctor).Execute(context.Background())
+
+		assert.Len(t, results, 8)
+		assert.Equal(t, map[string]model.CheckStatus{
+			"Config":                        model.CheckPassed,
+			"Credentials":                   model.CheckPassed,
+			"Compute API in ok":             model.CheckPassed,
+			"IAM permissions in ok":         model.CheckPassed,
+			"Compute API in disabled":       model.CheckFailed,
+			"IAM permissions in disabled":   model.CheckSkipped,
+			"Compute API in restricted":     model.CheckPassed,
+			"IAM permissions in restricted": model.CheckFailed,
+		}, statuses(results))
+		assert.Contains(t, results[4].Fix, "gcloud services enable compute.googleapis.com --project disabled")
+		assert.Contains(t, results[7].Detail, "missing compute.instances.start, compute.instances.stop")
+		assert.True(t, model.Failed(results))
+	})
+
+	t.Run("broken config and credentials", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		preflight := mock_repository.NewMockPreflightRepository(ctrl)
+		preflight.EXPECT().Credentials(gomock.Any()).Return("", errors.New("could not find default credentials"))
+
+		results := NewDoctorUseCase(preflight, func() ([]*model.VM, error) {
+			return nil, errors.New("failed to parse config YAML")
+		}, loggerForDoctor).Execute(context.Background())
+
+		assert.Equal(t, map[string]model.CheckStatus{
+			"Config":      model.CheckFailed,
+			"Credentials": model.CheckFailed,
+		}, statuses(results))
+		assert.Contains(t, results[1].Fix, "gcloud auth application-default login")
+	})
+}
@@ -0,0 +1,241 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// Editor lets the user modify a document, typically by opening it in $EDITOR.
+type Editor interface {
+	// Edit returns the edited content. name is a hint used for the temporary file name.
+	Edit(ctx context.Context, name string, content []byte) ([]byte, error)
+}
+
+// Top-level instance fields that EditVMUseCase can apply.
+const (
+	editFieldLabels      = "labels"
+	editFieldMetadata    = "metadata"
+	editFieldScheduling  = "scheduling"
+	editFieldMachineType = "machineType"
+)
+
+// editableSchedulingFields are the scheduling sub-fields covered by model.Scheduling.
+var editableSchedulingFields = map[string]bool{
+	"automaticRestart":          true,
+	"onHostMaintenance":         true,
+	"provisioningModel":         true,
+	"instanceTerminationAction": true,
+	"preemptible":               true,
+}
+
+// UnsupportedChangesError reports edited fields that gcectl cannot apply.
+type UnsupportedChangesError struct {
+	Fields []string
+}
+
+func (e *UnsupportedChangesError) Error() string {
+	return fmt.Sprintf("unsupported field changes: %s (only labels, metadata.items, scheduling and machineType can be edited)", strings.Join(e.Fields, ", "))
+}
+
+// EditResult describes the outcome of an edit session.
+type EditResult struct {
+	// Changed lists the edited fields that were applied, in apply order.
+	Changed []string
+}
+
+// EditVMUseCase handles kubectl-style editing of a VM's raw resource.
+type EditVMUseCase struct {
+	vmRepo repository.VMRepository
+	editor Editor
+	logger log.Logger
+}
+
+// NewEditVMUseCase creates a new instance of EditVMUseCase
+func NewEditVMUseCase(vmRepo repository.VMRepository, editor Editor, logger log.Logger) *EditVMUseCase {
+	return &EditVMUseCase{vmRepo: vmRepo, editor: editor, logger: logger}
+}
+
+// Execute fetches the VM resource, lets the user edit it and applies supported changes.
+//
+// This method performs the following steps:
+// 1. Retrieves the instance resource as JSON and opens it in the editor
+// 2. Diffs the edited document against the original
+// 3. Rejects the edit if any unsupported field changed (nothing is applied)
+// 4. Applies machine type (VM must be stopped), scheduling, metadata and label changes
+//
+// Parameters:
+//   - ctx: The context for the operation
+//   - vm: The VM to edit (must contain Project, Zone, and Name)
+//
+// Returns:
+//   - *EditResult: The applied fields; empty when the document was not modified
+//   - error: *UnsupportedChangesError if unsupported fields changed, or an API error
+func (uc *EditVMUseCase) Execute(ctx context.Context, vm *model.VM) (*EditResult, error) {
+	original, err := uc.vmRepo.GetRaw(ctx, vm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get instance: %w", err)
+	}
+
+	edited, err := uc.editor.Edit(ctx, vm.Name+".json", original)
+	if err != nil {
+		return nil, fmt.Errorf("failed to edit instance: %w", err)
+	}
+	if bytes.Equal(bytes.TrimSpace(original), bytes.TrimSpace(edited)) {
+		return &EditResult{}, nil
+	}
+
+	var before, after map[string]any
+	if err = json.Unmarshal(original, &before); err != nil {
+		return nil, fmt.Errorf("failed to parse instance: %w", err)
+	}
+	if err = json.Unmarshal(edited, &after); err != nil {
+		return nil, fmt.Errorf("edited resource is not valid JSON: %w", err)
+	}
+
+	changed, unsupported := diffInstance(before, after)
+	if len(unsupported) > 0 {
+		return nil, &UnsupportedChangesError{Fields: unsupported}
+	}
+
+	result := &EditResult{}
+	for _, field := range changed {
+		if applyErr := uc.apply(ctx, vm, field, after[field]); applyErr != nil {
+			return result, applyErr
+		}
+		result.Changed = append(result.Changed, field)
+	}
+	return result, nil
+}
+
+// apply performs the API call for a single supported top-level field.
+func (uc *EditVMUseCase) apply(ctx context.Context, vm *model.VM, field string, value any) error {
+	switch field {
+	case editFieldMachineType:
+		machineType, ok := value.(string)
+		if !ok || machineType == "" {
+			return fmt.Errorf("machineType must be a non-empty string")
+		}
+		// Accept either a short name or a full machine type URL
+		machineType = machineType[strings.LastIndex(machineType, "/")+1:]
+		return NewUpdateMachineTypeUseCase(uc.vmRepo, uc.logger).Execute(ctx, vm.Project, vm.Zone, vm.Name, machineType)
+	case editFieldScheduling:
+		var scheduling model.Scheduling
+		if err := remarshal(value, &scheduling); err != nil {
+			return fmt.Errorf("invalid scheduling: %w", err)
+		}
+		if err := uc.vmRepo.SetScheduling(ctx, vm, scheduling); err != nil {
+			return fmt.Errorf("failed to set scheduling: %w", err)
+		}
+	case editFieldMetadata:
+		var metadata struct {
+			Items []struct {
+				Key   string `json:"key"`
+				Value string `json:"value"`
+			} `json:"items"`
+		}
+		if err := remarshal(value, &metadata); err != nil {
+			return fmt.Errorf("invalid metadata: %w", err)
+		}
+		items := make(map[string]string, len(metadata.Items))
+		for _, item := range metadata.Items {
+			items[item.Key] = item.Value
+		}
+		if err := uc.vmRepo.SetMetadata(ctx, vm, items); err != nil {
+			return fmt.Errorf("failed to set metadata: %w", err)
+		}
+	case editFieldLabels:
+		labels := map[string]string{}
+		if err := remarshal(value, &labels); err != nil {
+			return fmt.Errorf("invalid labels: %w", err)
+		}
+		if err := uc.vmRepo.SetLabels(ctx, vm, labels); err != nil {
+			return fmt.Errorf("failed to set labels: %w", err)
+		}
+	}
+	uc.logger.Infof("✓ Successfully applied %s for VM %s", field, vm.Name)
+	return nil
+}
+
+// diffInstance compares two instance documents and splits changed fields into
+// supported top-level fields (in apply order) and unsupported field paths (sorted).
+func diffInstance(before, after map[string]any) (changed, unsupported []string) {
+	for _, key := range changedKeys(before, after) {
+		switch key {
+		case editFieldLabels, editFieldMachineType:
+			// whole-field replacement
+		case editFieldScheduling:
+			unsupported = append(unsupported, unsupportedSubfields(key, before[key], after[key], editableSchedulingFields)...)
+		case editFieldMetadata:
+			unsupported = append(unsupported, unsupportedSubfields(key, before[key], after[key], map[string]bool{"items": true})...)
+		default:
+			unsupported = append(unsupported, key)
+		}
+	}
+	if len(unsupported) > 0 {
+		return nil, unsupported
+	}
+
+	for _, key := range []string{editFieldMachineType, editFieldScheduling, editFieldMetadata, editFieldLabels} {
+		if !reflect.DeepEqual(before[key], after[key]) {
+			changed = append(changed, key)
+		}
+	}
+	return changed, nil
+}
+
+// unsupportedSubfields returns "parent.child" paths for changed sub-fields not in allowed.
+func unsupportedSubfields(parent string, before, after any, allowed map[string]bool) []string {
+	beforeMap, _ := before.(map[string]any)
+	afterMap, _ := after.(map[string]any)
+	if after != nil && afterMap == nil {
+		return []string{parent}
+	}
+
+	var fields []string
+	for _, key := range changedKeys(beforeMap, afterMap) {
+		if !allowed[key] {
+			fields = append(fields, parent+"."+key)
+		}
+	}
+	return fields
+}
+
+// changedKeys returns the sorted keys whose values differ between a and b.
+func changedKeys(a, b map[string]any) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	var keys []string
+	for _, m := range []map[string]any{a, b} {
+		for k := range m {
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			if !reflect.DeepEqual(a[k], b[k]) {
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// remarshal converts a decoded JSON value into a typed struct.
+func remarshal(value any, out any) error {
+	if value == nil {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
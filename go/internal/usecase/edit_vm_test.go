@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeEditor replaces the original document with a fixed result.
+type fakeEditor struct {
+	err    error
+	result string
+}
+
+func (e *fakeEditor) Edit(_ context.Context, _ string, content []byte) ([]byte, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	if e.result == "" {
+		return content, nil
+	}
+	return []byte(e.result), nil
+}
+
+const editOriginal = `{
+  "name": "test-vm",
+  "machineType": "https://www.googleapis.com/compute/v1/projects/p/zones/z/machineTypes/e2-small",
+  "labels": {"env": "dev"},
+  "metadata": {"fingerprint": "abc", "items": [{"key": "foo", "value": "bar"}]},
+  "scheduling": {"automaticRestart": true, "onHostMaintenance": "MIGRATE"},
+  "deletionProtection": false
+}`
+
+//nolint:gocognit // Test function is complex but readable with table-driven design
+func TestEditVMUseCase_Execute(t *testing.T) {
+	vm := &model.VM{Project: "p", Zone: "z", Name: "test-vm"}
+
+	tests := []struct {
+		name        string
+		editor      *fakeEditor
+		setupMock   func(*mock_repository.MockVMRepository)
+		wantChanged []string
+		errContains string
+		wantErr     bool
+	}{
+		{
+			name:        "no changes",
+			editor:      &fakeEditor{},
+			setupMock:   func(m *mock_repository.MockVMRepository) {},
+			wantChanged: nil,
+		},
+		{
+			name: "labels and metadata are applied",
+			editor: &fakeEditor{result: strings.NewReplacer(
+				`{"env": "dev"}`, `{"env": "prod"}`,
+				`"value": "bar"`, `"value": "baz"`,
+			).Replace(editOriginal)},
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				m.EXPECT().SetMetadata(gomock.Any(), vm, map[string]string{"foo": "baz"}).Return(nil)
+				m.EXPECT().SetLabels(gomock.Any(), vm, map[string]string{"env": "prod"}).Return(nil)
+			},
+			wantChanged: []string{"metadata", "labels"},
+		},
+		{
+			name:   "scheduling is applied",
+			editor: &fakeEditor{result: strings.Replace(editOriginal, `"MIGRATE"`, `"TERMINATE"`, 1)},
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				restart := true
+				m.EXPECT().SetScheduling(gomock.Any(), vm, model.Scheduling{AutomaticRestart: &restart, OnHostMaintenance: "TERMINATE"}).Return(nil)
+			},
+			wantChanged: []string{"scheduling"},
+		},
+		{
+			name:   "machine type requires stopped VM",
+			editor: &fakeEditor{result: strings.Replace(editOriginal, "e2-small", "e2-medium", 1)},
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				m.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(&model.VM{Name: "test-vm", Status: model.StatusRunning}, nil)
+			},
+			wantErr:     true,
+			errContains: "must be stopped",
+		},
+		{
+			name:   "machine type is applied when stopped",
+			editor: &fakeEditor{result: strings.Replace(editOriginal, "e2-small", "e2-medium", 1)},
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				stopped := &model.VM{Name: "test-vm", Status: model.StatusStopped}
+				m.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(stopped, nil)
+				m.EXPECT().UpdateMachineType(gomock.Any(), stopped, "e2-medium").Return(nil)
+			},
+			wantChanged: []string{"machineType"},
+		},
+		{
+			name: "unsupported changes are rejected without applying anything",
+			editor: &fakeEditor{result: strings.NewReplacer(
+				`"deletionProtection": false`, `"deletionProtection": true`,
+				`"fingerprint": "abc"`, `"fingerprint": "xyz"`,
+				`{"env": "dev"}`, `{"env": "prod"}`,
+			).Replace(editOriginal)},
+			setupMock:   func(m *mock_repository.MockVMRepository) {},
+			wantErr:     true,
+			errContains: "deletionProtection, metadata.fingerprint",
+		},
+		{
+			name:        "invalid JSON",
+			editor:      &fakeEditor{result: "{not json"},
+			setupMock:   func(m *mock_repository.MockVMRepository) {},
+			wantErr:     true,
+			errContains: "not valid JSON",
+		},
+		{
+			name:        "editor fails",
+			editor:      &fakeEditor{err: errors.New("editor exited with status 1")},
+			setupMock:   func(m *mock_repository.MockVMRepository) {},
+			wantErr:     true,
+			errContains: "failed to edit instance",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_repository.NewMockVMRepository(ctrl)
+			mockRepo.EXPECT().GetRaw(gomock.Any(), vm).Return([]byte(editOriginal), nil)
+			tt.setupMock(mockRepo)
+
+			result, err := NewEditVMUseCase(mockRepo, tt.editor, logger).Execute(context.Background(), vm)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantChanged, result.Changed)
+		})
+	}
+}
+
+func TestUnsupportedChangesError(t *testing.T) {
+	err := &UnsupportedChangesError{Fields: []string{"disks", "name"}}
+	assert.Contains(t, err.Error(), "disks, name")
+}
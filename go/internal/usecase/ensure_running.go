@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+)
+
+// EnsureRunningUseCase starts a VM unless it is already running, for commands
+// that connect to the VM such as forward and code.
+type EnsureRunningUseCase struct {
+	vmRepo repository.VMRepository
+	start  *StartVMUseCase
+}
+
+// NewEnsureRunningUseCase creates a new EnsureRunningUseCase starting stopped VMs with start.
+func NewEnsureRunningUseCase(vmRepo repository.VMRepository, start *StartVMUseCase) *EnsureRunningUseCase {
+	return &EnsureRunningUseCase{vmRepo: vmRepo, start: start}
+}
+
+// Execute starts vm and waits for it when it is stopped.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout
+//   - vm: The VM to run (must contain Project, Zone, and Name)
+//
+// Returns:
+//   - bool: true when the VM was started, false when it was already running
+//   - error: nil on success, or error when the VM cannot be found or started
+func (uc *EnsureRunningUseCase) Execute(ctx context.Context, vm *model.VM) (bool, error) {
+	foundVM, err := uc.vmRepo.FindByName(ctx, vm)
+	if err != nil {
+		return false, fmt.Errorf("VM %s: failed to find: %w", vm.Name, err)
+	}
+	if foundVM == nil {
+		return false, fmt.Errorf("VM %s: %w", vm.Name, model.ErrVMNotFound)
+	}
+	if foundVM.Status == model.StatusRunning {
+		return false, nil
+	}
+	if err = uc.start.Execute(ctx, []*model.VM{vm}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
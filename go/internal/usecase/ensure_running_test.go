@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEnsureRunningUseCase_Execute(t *testing.T) {
+	vm := &model.VM{Project: "test-project", Zone: "us-central1-a", Name: "test-vm"}
+
+	tests := []struct {
+		name        string
+		setupMock   func(*mock_repository.MockVMRepository)
+		wantStarted bool
+		errContains string
+	}{
+		{
+			name: "success: running VM is left alone",
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				m.EXPECT().FindByName(gomock.Any(), vm).
+					Return(&model.VM{Name: "test-vm", Status: model.StatusRunning}, nil)
+			},
+			wantStarted: false,
+		},
+		{
+			name: "success: stopped VM is started",
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				stopped := &model.VM{Project: "test-project", Zone: "us-central1-a", Name: "test-vm", Status: model.StatusStopped}
+				m.EXPECT().FindByName(gomock.Any(), vm).Return(stopped, nil).Times(2)
+				m.EXPECT().Start(gomock.Any(), stopped).Return(nil)
+			},
+			wantStarted: true,
+		},
+		{
+			name: "error: VM not found",
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				m.EXPECT().FindByName(gomock.Any(), vm).Return(nil, nil)
+			},
+			errContains: "not found",
+		},
+		{
+			name: "error: VM cannot be started",
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				m.EXPECT().FindByName(gomock.Any(), vm).
+					Return(&model.VM{Name: "test-vm", Status: model.StatusProvisioning}, nil).Times(2)
+			},
+			errContains: "cannot be started",
+		},
+		{
+			name: "error: lookup fails",
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				m.EXPECT().FindByName(gomock.Any(), vm).Return(nil, errors.New("api error"))
+			},
+			errContains: "api error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockVMRepository(ctrl)
+			tt.setupMock(mockRepo)
+
+			uc := NewEnsureRunningUseCase(mockRepo, NewStartVMUseCase(mockRepo, logger))
+			started, err := uc.Execute(context.Background(), vm)
+
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStarted, started)
+		})
+	}
+}
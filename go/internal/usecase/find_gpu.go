@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	"golang.org/x/sync/errgroup"
+)
+
+// GPUAvailability is an accelerator type offered in a zone, with the regional
+// quota that limits it.
+type GPUAvailability struct {
+	Type *model.AcceleratorType
+	// Quota is the regional quota of the type, or nil if the region does not report it.
+	Quota *model.Quota
+	// Likely reports whether a VM with the requested number of cards fits both
+	// the type's per-VM maximum and the free quota.
+	Likely bool
+}
+
+// FindGPUUseCase looks for the zones of a region where a GPU is likely to be obtainable.
+type FindGPUUseCase struct {
+	regionRepo      repository.RegionRepository
+	acceleratorRepo repository.AcceleratorTypeRepository
+	logger          log.Logger
+}
+
+// NewFindGPUUseCase creates a new instance of FindGPUUseCase
+func NewFindGPUUseCase(regionRepo repository.RegionRepository, acceleratorRepo repository.AcceleratorTypeRepository, logger log.Logger) *FindGPUUseCase {
+	return &FindGPUUseCase{regionRepo: regionRepo, acceleratorRepo: acceleratorRepo, logger: logger}
+}
+
+// Execute lists the zones of a region offering matching accelerator types and
+// whether the project's quota leaves room for them.
+//
+// GCE does not expose spare capacity, so a likely zone can still be out of
+// resources; zones that offer the type with free quota are where a start has a
+// chance at all.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - project: The GCP project ID whose quota is checked
+//   - region: The region to search (e.g., "us-central1")
+//   - gpuType: Case-insensitive part of the accelerator type name (e.g., "a100")
+//   - cards: How many cards one VM needs
+//   - spot: Check the quota of Spot (preemptible) GPUs instead of on-demand ones
+//
+// Returns:
+//   - []GPUAvailability: Matching types per zone, likely ones first, then by free quota
+//   - error: Error if the region or a zone's accelerator types cannot be read
+//
+// Example:
+//
+//	usecase := NewFindGPUUseCase(regionRepo, acceleratorRepo, logger)
+//	found, err := usecase.Execute(ctx, "my-project", "us-central1", "a100", 1, true)
+func (uc *FindGPUUseCase) Execute(ctx context.Context, project, region, gpuType string, cards int, spot bool) ([]GPUAvailability, error) {
+	// 1. 入力チェック
+	if gpuType == "" {
+		return nil, fmt.Errorf("GPU type must not be empty")
+	}
+	if cards < 1 {
+		return nil, fmt.Errorf("card count must be at least 1, got %d", cards)
+	}
+
+	// 2. リージョンのゾーンとクォータを取得
+	r, err := uc.regionRepo.Get(ctx, project, region)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. 各ゾーンで提供されているアクセラレータを並列に取得
+	types := make([][]*model.AcceleratorType, len(r.Zones))
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(maxConcurrentVMLookups)
+	for i, zone := range r.Zones {
+		eg.Go(func() error {
+			zoneTypes, listErr := uc.acceleratorRepo.List(egCtx, project, zone)
+			types[i] = zoneTypes
+			return listErr
+		})
+	}
+	if waitErr := eg.Wait(); waitErr != nil {
+		return nil, waitErr
+	}
+
+	// 4. 条件に合うものを抽出し、クォータと照合
+	needle := strings.ToLower(gpuType)
+	var found []GPUAvailability
+	for _, zoneTypes := range types {
+		for _, t := range zoneTypes {
+			if !strings.Contains(strings.ToLower(t.Name), needle) {
+				continue
+			}
+			quota := r.Quota(t.QuotaMetric(spot))
+			found = append(found, GPUAvailability{
+				Type:   t,
+				Quota:  quota,
+				Likely: quota != nil && quota.Available() >= float64(cards) && t.MaxCardsPerInstance >= cards,
+			})
+		}
+	}
+	uc.logger.Debugf("Found %d accelerator types matching %q in %s", len(found), gpuType, region)
+
+	// 5. 見込みのあるゾーン、空きクォータの多い順に並べる
+	sort.SliceStable(found, func(i, j int) bool {
+		a, b := found[i], found[j]
+		if a.Likely != b.Likely {
+			return a.Likely
+		}
+		if qa, qb := availableQuota(a.Quota), availableQuota(b.Quota); qa != qb {
+			return qa > qb
+		}
+		if a.Type.Zone != b.Type.Zone {
+			return a.Type.Zone < b.Type.Zone
+		}
+		return a.Type.Name < b.Type.Name
+	})
+	return found, nil
+}
+
+// availableQuota orders unknown quotas after every known one.
+func availableQuota(q *model.Quota) float64 {
+	if q == nil {
+		return -1
+	}
+	return q.Available()
+}
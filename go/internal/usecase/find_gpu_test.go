@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var loggerForFindGPU = log.NewLogger()
+
+func TestFindGPUUseCase_Execute(t *testing.T) {
+	region := &model.Region{
+		Name:  "us-central1",
+		Zones: []string{"us-central1-a", "us-central1-b", "us-central1-c"},
+		Quotas: []model.Quota{
+			{Metric: "NVIDIA_A100_GPUS", Limit: 8, Usage: 8},
+			{Metric: "PREEMPTIBLE_NVIDIA_A100_GPUS", Limit: 16, Usage: 0},
+			{Metric: "PREEMPTIBLE_NVIDIA_A100_80GB_GPUS", Limit: 4, Usage: 2},
+		},
+	}
+	a100 := func(zone string) *model.AcceleratorType {
+		return &model.AcceleratorType{Name: "nvidia-tesla-a100", Zone: zone, MaxCardsPerInstance: 16}
+	}
+	a100b := &model.AcceleratorType{Name: "nvidia-a100-80gb", Zone: "us-central1-a", MaxCardsPerInstance: 8}
+	t4 := &model.AcceleratorType{Name: "nvidia-tesla-t4", Zone: "us-central1-b", MaxCardsPerInstance: 4}
+
+	setup := func(ctrl *gomock.Controller) (*mock_repository.MockRegionRepository, *mock_repository.MockAcceleratorTypeRepository) {
+		regionRepo := mock_repository.NewMockRegionRepository(ctrl)
+		acceleratorRepo := mock_repository.NewMockAcceleratorTypeRepository(ctrl)
+		regionRepo.EXPECT().Get(gomock.Any(), "p", "us-central1").Return(region, nil)
+		acceleratorRepo.EXPECT().List(gomock.Any(), "p", "us-central1-a").Return([]*model.AcceleratorType{a100("us-central1-a"), a100b}, nil)
+		acceleratorRepo.EXPECT().List(gomock.Any(), "p", "us-central1-b").Return([]*model.AcceleratorType{a100("us-central1-b"), t4}, nil)
+		acceleratorRepo.EXPECT().List(gomock.Any(), "p", "us-central1-c").Return(nil, nil)
+		return regionRepo, acceleratorRepo
+	}
+
+	t.Run("spot quota", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		regionRepo, acceleratorRepo := setup(ctrl)
+		uc := NewFindGPUUseCase(regionRepo, acceleratorRepo, loggerForFindGPU)
+		found, err := uc.Execute(context.Background(), "p", "us-central1", "A100", 4, true)
+		require.NoError(t, err)
+
+		require.Len(t, found, 3)
+		assert.Equal(t, a100("us-central1-a"), found[0].Type)
+		assert.True(t, found[0].Likely)
+		assert.Equal(t, a100("us-central1-b"), found[1].Type)
+		assert.True(t, found[1].Likely)
+		assert.Equal(t, a100b, found[2].Type)
+		assert.False(t, found[2].Likely, "2 free cards are too few for 4")
+	})
+
+	t.Run("on-demand quota used up", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		regionRepo, acceleratorRepo := setup(ctrl)
+		uc := NewFindGPUUseCase(regionRepo, acceleratorRepo, loggerForFindGPU)
+		found, err := uc.Execute(context.Background(), "p", "us-central1", "a100", 1, false)
+		require.NoError(t, err)
+
+		require.Len(t, found, 3)
+		for _, f := range found {
+			assert.False(t, f.Likely, f.Type.Name)
+		}
+		assert.Nil(t, found[2].Quota, "the region reports no on-demand quota for nvidia-a100-80gb")
+	})
+}
+
+func TestFindGPUUseCase_Execute_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	regionRepo := mock_repository.NewMockRegionRepository(ctrl)
+	acceleratorRepo := mock_repository.NewMockAcceleratorTypeRepository(ctrl)
+	uc := NewFindGPUUseCase(regionRepo, acceleratorRepo, loggerForFindGPU)
+
+	_, err := uc.Execute(context.Background(), "p", "us-central1", "", 1, false)
+	require.Error(t, err)
+	_, err = uc.Execute(context.Background(), "p", "us-central1", "t4", 0, false)
+	require.Error(t, err)
+
+	regionRepo.EXPECT().Get(gomock.Any(), "p", "us-central1").Return(&model.Region{Zones: []string{"us-central1-a"}}, nil)
+	acceleratorRepo.EXPECT().List(gomock.Any(), "p", "us-central1-a").Return(nil, errors.New("denied"))
+	_, err = uc.Execute(context.Background(), "p", "us-central1", "t4", 1, false)
+	require.ErrorContains(t, err, "denied")
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// IdleThresholds defines the usage below which a running VM is considered idle.
+type IdleThresholds struct {
+	// CPU is the average CPU utilization as a fraction (0-1).
+	CPU float64
+	// NetworkBytesPerSec is the average of received plus sent bytes per second.
+	NetworkBytesPerSec float64
+}
+
+// IdleReport lists the running VMs that look idle.
+type IdleReport struct {
+	// VMs are the idle VMs, most expensive first.
+	VMs []VMMetrics
+	// UnpricedMachineTypes lists machine types of idle VMs missing from the cost table.
+	UnpricedMachineTypes []string
+	// HourlyCost is the estimated cost per hour of the idle VMs with a known price.
+	HourlyCost float64
+}
+
+// FindIdleVMsUseCase flags running VMs whose CPU and network usage stayed low.
+type FindIdleVMsUseCase struct {
+	fleet *FleetMetricsUseCase
+}
+
+// NewFindIdleVMsUseCase creates a new instance of FindIdleVMsUseCase
+func NewFindIdleVMsUseCase(vmRepo repository.VMRepository, metricsRepo repository.MetricsRepository, logger log.Logger) *FindIdleVMsUseCase {
+	return &FindIdleVMsUseCase{fleet: NewFleetMetricsUseCase(vmRepo, metricsRepo, logger)}
+}
+
+// Execute returns the configured VMs that were running with CPU and network usage
+// below thresholds, averaged over the last window.
+//
+// A VM is only flagged when both its CPU and network metrics have data, so VMs that
+// just started (or whose metrics are not collected) are never reported as idle.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - vms: The configured VMs (must contain Project, Zone, and Name)
+//   - window: How far back to average (e.g., time.Hour)
+//   - thresholds: The usage below which a VM is idle
+//   - hourlyCost: Estimated price per hour keyed by machine type
+//
+// Returns:
+//   - *IdleReport: The idle VMs and their estimated hourly cost
+//   - error: nil on success, otherwise an error describing what went wrong
+//
+// Example:
+//
+//	usecase := NewFindIdleVMsUseCase(vmRepo, metricsRepo, logger)
+//	report, err := usecase.Execute(ctx, cnf.VMs, time.Hour, IdleThresholds{CPU: 0.05, NetworkBytesPerSec: 10 * 1024}, cnf.HourlyCost)
+func (uc *FindIdleVMsUseCase) Execute(ctx context.Context, vms []*model.VM, window time.Duration, thresholds IdleThresholds, hourlyCost map[string]float64) (*IdleReport, error) {
+	// 1. 稼働中VMのメトリクスを取得
+	rows, err := uc.fleet.Execute(ctx, vms, window)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. しきい値未満のVMを抽出
+	report := &IdleReport{}
+	unpriced := make(map[string]bool)
+	for _, row := range rows {
+		if !isIdle(row.Metrics, thresholds) {
+			continue
+		}
+		report.VMs = append(report.VMs, row)
+		if price, ok := hourlyCost[row.VM.MachineType]; ok {
+			report.HourlyCost += price
+		} else if !unpriced[row.VM.MachineType] {
+			unpriced[row.VM.MachineType] = true
+			report.UnpricedMachineTypes = append(report.UnpricedMachineTypes, row.VM.MachineType)
+		}
+	}
+
+	// 3. 高価なVMから並べる
+	sort.SliceStable(report.VMs, func(i, j int) bool {
+		return hourlyCost[report.VMs[i].VM.MachineType] > hourlyCost[report.VMs[j].VM.MachineType]
+	})
+	sort.Strings(report.UnpricedMachineTypes)
+	return report, nil
+}
+
+// isIdle reports whether m has CPU and network data below thresholds.
+func isIdle(m *model.InstanceMetrics, thresholds IdleThresholds) bool {
+	if m.CPUUtilization == nil || m.NetworkInBytesPerSec == nil || m.NetworkOutBytesPerSec == nil {
+		return false
+	}
+	network := *m.NetworkInBytesPerSec + *m.NetworkOutBytesPerSec
+	return *m.CPUUtilization < thresholds.CPU && network < thresholds.NetworkBytesPerSec
+}
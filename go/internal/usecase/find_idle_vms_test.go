@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var loggerForFindIdleVMs = log.NewLogger()
+
+func TestFindIdleVMsUseCase_Execute(t *testing.T) {
+	now := time.Date(2025, 1, 2, 6, 0, 0, 0, time.UTC)
+	configured := []*model.VM{{Name: "gpu"}, {Name: "busy"}, {Name: "cheap"}, {Name: "new"}, {Name: "custom"}}
+	found := []*model.VM{
+		{ID: "1", Name: "gpu", Project: "p", MachineType: "a2-highgpu-1g", Status: model.StatusRunning},
+		{ID: "2", Name: "busy", Project: "p", MachineType: "e2-medium", Status: model.StatusRunning},
+		{ID: "3", Name: "cheap", Project: "p", MachineType: "e2-medium", Status: model.StatusRunning},
+		{ID: "4", Name: "new", Project: "p", MachineType: "e2-medium", Status: model.StatusRunning},
+		{ID: "5", Name: "custom", Project: "p", MachineType: "custom-2-4096", Status: model.StatusRunning},
+	}
+	thresholds := IdleThresholds{CPU: 0.05, NetworkBytesPerSec: 10 * 1024}
+	hourlyCost := map[string]float64{"a2-highgpu-1g": 3.67, "e2-medium": 0.03}
+
+	t.Run("success: flags VMs below both thresholds", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		vmRepo := mock_repository.NewMockVMRepository(ctrl)
+		metricsRepo := mock_repository.NewMockMetricsRepository(ctrl)
+		vmRepo.EXPECT().FindAll(gomock.Any(), configured).Return(found, nil)
+		metricsRepo.EXPECT().Average(gomock.Any(), "p", []string{"1", "2", "3", "4", "5"}, now.Add(-time.Hour), now).
+			Return(map[string]*model.InstanceMetrics{
+				"1": {CPUUtilization: floatPtr(0.01), NetworkInBytesPerSec: floatPtr(100), NetworkOutBytesPerSec: floatPtr(100)},
+				"2": {CPUUtilization: floatPtr(0.01), NetworkInBytesPerSec: floatPtr(1024 * 1024), NetworkOutBytesPerSec: floatPtr(0)},
+				"3": {CPUUtilization: floatPtr(0.02), NetworkInBytesPerSec: floatPtr(10), NetworkOutBytesPerSec: floatPtr(10)},
+				"4": {CPUUtilization: floatPtr(0.01)},
+				"5": {CPUUtilization: floatPtr(0.03), NetworkInBytesPerSec: floatPtr(0), NetworkOutBytesPerSec: floatPtr(0)},
+			}, nil)
+
+		uc := NewFindIdleVMsUseCase(vmRepo, metricsRepo, loggerForFindIdleVMs)
+		uc.fleet.now = func() time.Time { return now }
+
+		report, err := uc.Execute(context.Background(), configured, time.Hour, thresholds, hourlyCost)
+		require.NoError(t, err)
+		names := make([]string, len(report.VMs))
+		for i, row := range report.VMs {
+			names[i] = row.VM.Name
+		}
+		assert.Equal(t, []string{"gpu", "cheap", "custom"}, names, "busy network and missing metrics are not idle")
+		assert.InDelta(t, 3.70, report.HourlyCost, 1e-9)
+		assert.Equal(t, []string{"custom-2-4096"}, report.UnpricedMachineTypes)
+	})
+
+	t.Run("error: metrics query fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		vmRepo := mock_repository.NewMockVMRepository(ctrl)
+		metricsRepo := mock_repository.NewMockMetricsRepository(ctrl)
+		vmRepo.EXPECT().FindAll(gomock.Any(), configured).Return(found, nil)
+		metricsRepo.EXPECT().Average(gomock.Any(), "p", gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("denied"))
+
+		_, err := NewFindIdleVMsUseCase(vmRepo, metricsRepo, loggerForFindIdleVMs).Execute(context.Background(), configured, time.Hour, thresholds, hourlyCost)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "denied")
+	})
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// VMMetrics pairs a running VM with its average resource usage.
+type VMMetrics struct {
+	VM *model.VM
+	// Metrics is never nil; its fields are nil for metrics without data.
+	Metrics *model.InstanceMetrics
+}
+
+// FleetMetricsUseCase handles fetching the resource usage of running configured VMs
+type FleetMetricsUseCase struct {
+	vmRepo      repository.VMRepository
+	metricsRepo repository.MetricsRepository
+	logger      log.Logger
+	now         func() time.Time
+}
+
+// NewFleetMetricsUseCase creates a new instance of FleetMetricsUseCase
+func NewFleetMetricsUseCase(vmRepo repository.VMRepository, metricsRepo repository.MetricsRepository, logger log.Logger) *FleetMetricsUseCase {
+	return &FleetMetricsUseCase{vmRepo: vmRepo, metricsRepo: metricsRepo, logger: logger, now: time.Now}
+}
+
+// Execute returns the average resource usage over the last window of the configured
+// VMs that are running, busiest (by CPU) first.
+//
+// VMs are looked up in as few API calls as possible and their metrics are queried
+// once per project. VMs that do not exist or are not running are left out.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - vms: The configured VMs (must contain Project, Zone, and Name)
+//   - window: How far back to average (e.g., 5*time.Minute)
+//
+// Returns:
+//   - []VMMetrics: The running VMs with their metrics, highest CPU utilization first
+//   - error: nil on success, otherwise an error describing what went wrong
+//
+// Example:
+//
+//	usecase := NewFleetMetricsUseCase(vmRepo, metricsRepo, logger)
+//	rows, err := usecase.Execute(ctx, cnf.VMs, 10*time.Minute)
+func (uc *FleetMetricsUseCase) Execute(ctx context.Context, vms []*model.VM, window time.Duration) ([]VMMetrics, error) {
+	// 1. 入力チェック
+	if window < time.Minute {
+		return nil, fmt.Errorf("window must be at least 1m: %s", window)
+	}
+
+	// 2. 稼働中のVMを取得
+	found, err := uc.vmRepo.FindAll(ctx, vms)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find VMs: %w", err)
+	}
+	var running []*model.VM
+	idsByProject := make(map[string][]string)
+	var projects []string
+	for _, vm := range found {
+		if vm == nil || vm.Status != model.StatusRunning || vm.ID == "" {
+			continue
+		}
+		running = append(running, vm)
+		if _, ok := idsByProject[vm.Project]; !ok {
+			projects = append(projects, vm.Project)
+		}
+		idsByProject[vm.Project] = append(idsByProject[vm.Project], vm.ID)
+	}
+
+	// 3. プロジェクトごとにメトリクスを取得
+	end := uc.now()
+	start := end.Add(-window)
+	metricsByID := make(map[string]*model.InstanceMetrics, len(running))
+	for _, project := range projects {
+		metrics, avgErr := uc.metricsRepo.Average(ctx, project, idsByProject[project], start, end)
+		if avgErr != nil {
+			return nil, fmt.Errorf("failed to get metrics of project %s: %w", project, avgErr)
+		}
+		for id, m := range metrics {
+			metricsByID[id] = m
+		}
+	}
+
+	// 4. CPU使用率の高い順に並べる
+	rows := make([]VMMetrics, 0, len(running))
+	for _, vm := range running {
+		m := metricsByID[vm.ID]
+		if m == nil {
+			m = &model.InstanceMetrics{}
+		}
+		rows = append(rows, VMMetrics{VM: vm, Metrics: m})
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return cpuOrder(rows[i].Metrics) > cpuOrder(rows[j].Metrics)
+	})
+	return rows, nil
+}
+
+// cpuOrder returns the CPU utilization used for sorting; unknown values sort last.
+func cpuOrder(m *model.InstanceMetrics) float64 {
+	if m.CPUUtilization == nil {
+		return -1
+	}
+	return *m.CPUUtilization
+}
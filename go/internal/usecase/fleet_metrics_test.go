@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var loggerForFleetMetrics = log.NewLogger()
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func TestFleetMetricsUseCase_Execute(t *testing.T) {
+	now := time.Date(2025, 1, 2, 6, 0, 0, 0, time.UTC)
+	configured := []*model.VM{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}, {Name: "gone"}}
+	a := &model.VM{ID: "1", Name: "a", Project: "p1", Status: model.StatusRunning}
+	b := &model.VM{ID: "2", Name: "b", Project: "p1", Status: model.StatusRunning}
+	c := &model.VM{ID: "3", Name: "c", Project: "p2", Status: model.StatusRunning}
+	d := &model.VM{ID: "4", Name: "d", Project: "p1", Status: model.StatusTerminated}
+
+	t.Run("success: running VMs, busiest first", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		vmRepo := mock_repository.NewMockVMRepository(ctrl)
+		metricsRepo := mock_repository.NewMockMetricsRepository(ctrl)
+		vmRepo.EXPECT().FindAll(gomock.Any(), configured).Return([]*model.VM{a, b, c, d, nil}, nil)
+		metricsRepo.EXPECT().Average(gomock.Any(), "p1", []string{"1", "2"}, now.Add(-10*time.Minute), now).
+			Return(map[string]*model.InstanceMetrics{
+				"1": {CPUUtilization: floatPtr(0.1)},
+				"2": {CPUUtilization: floatPtr(0.9)},
+			}, nil)
+		metricsRepo.EXPECT().Average(gomock.Any(), "p2", []string{"3"}, now.Add(-10*time.Minute), now).
+			Return(map[string]*model.InstanceMetrics{}, nil)
+
+		uc := NewFleetMetricsUseCase(vmRepo, metricsRepo, loggerForFleetMetrics)
+		uc.now = func() time.Time { return now }
+
+		rows, err := uc.Execute(context.Background(), configured, 10*time.Minute)
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+		assert.Equal(t, "b", rows[0].VM.Name)
+		assert.Equal(t, "a", rows[1].VM.Name)
+		assert.Equal(t, "c", rows[2].VM.Name)
+		assert.Equal(t, &model.InstanceMetrics{}, rows[2].Metrics, "VMs without data get empty metrics")
+	})
+
+	t.Run("error: window too short", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uc := NewFleetMetricsUseCase(mock_repository.NewMockVMRepository(ctrl), mock_repository.NewMockMetricsRepository(ctrl), loggerForFleetMetrics)
+		_, err := uc.Execute(context.Background(), configured, 30*time.Second)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "at least 1m")
+	})
+
+	t.Run("error: metrics query fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		vmRepo := mock_repository.NewMockVMRepository(ctrl)
+		metricsRepo := mock_repository.NewMockMetricsRepository(ctrl)
+		vmRepo.EXPECT().FindAll(gomock.Any(), configured).Return([]*model.VM{a, nil, nil, nil, nil}, nil)
+		metricsRepo.EXPECT().Average(gomock.Any(), "p1", []string{"1"}, gomock.Any(), gomock.Any()).Return(nil, errors.New("denied"))
+
+		_, err := NewFleetMetricsUseCase(vmRepo, metricsRepo, loggerForFleetMetrics).Execute(context.Background(), configured, 10*time.Minute)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get metrics of project p1")
+	})
+}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+)
+
+// GenerateSSHConfigUseCase builds SSH Host entries for configured VMs.
+type GenerateSSHConfigUseCase struct {
+	repo           repository.VMRepository
+	maxConcurrency int
+}
+
+// NewGenerateSSHConfigUseCase creates a new GenerateSSHConfigUseCase instance.
+func NewGenerateSSHConfigUseCase(repo repository.VMRepository) *GenerateSSHConfigUseCase {
+	return &GenerateSSHConfigUseCase{repo: repo}
+}
+
+// WithMaxConcurrency sets how many VMs are looked up at once. Values <= 0 keep the default.
+func (u *GenerateSSHConfigUseCase) WithMaxConcurrency(n int) *GenerateSSHConfigUseCase {
+	u.maxConcurrency = n
+	return u
+}
+
+// Execute looks up the current external IP of each configured VM and returns one
+// SSH Host entry per VM, applying the per-VM overrides from sshOptions.
+//
+// Like ListVMsUseCase, this is best-effort: VMs that cannot be found or have no
+// external IP (e.g., stopped VMs with ephemeral addresses) are skipped and reported
+// in the returned error, while the remaining hosts are still returned.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - configuredVMs: VMs loaded from config
+//   - sshOptions: Per-VM SSH overrides keyed by VM name
+//
+// Returns:
+//   - []model.SSHHost: Host entries in config order
+//   - error: Joined error for skipped VMs, or nil if all VMs produced an entry
+func (u *GenerateSSHConfigUseCase) Execute(ctx context.Context, configuredVMs []*model.VM, sshOptions map[string]model.SSHOptions) ([]model.SSHHost, error) {
+	items, listErr := NewListVMsUseCase(u.repo).WithMaxConcurrency(u.maxConcurrency).Execute(ctx, configuredVMs)
+
+	errs := []error{listErr}
+	hosts := make([]model.SSHHost, 0, len(items))
+	for _, item := range items {
+		ip, err := item.VM.IPAddress(false)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("VM %s (status: %s): %w", item.VM.Name, item.VM.Status, err))
+			continue
+		}
+		hosts = append(hosts, model.SSHHost{
+			Alias:      item.VM.Name,
+			HostName:   ip,
+			SSHOptions: sshOptions[item.VM.Name],
+		})
+	}
+
+	return hosts, errors.Join(errs...)
+}
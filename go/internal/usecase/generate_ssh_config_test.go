@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGenerateSSHConfigUseCase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+	mockRepo.EXPECT().
+		FindAll(gomock.Any(), gomock.Any()).
+		DoAndReturn(findAllBy(func(inputVM *model.VM) (*model.VM, error) {
+			switch inputVM.Name {
+			case "running-vm":
+				return &model.VM{Name: "running-vm", Status: model.StatusRunning, ExternalIP: "34.1.2.3"}, nil
+			case "stopped-vm":
+				return &model.VM{Name: "stopped-vm", Status: model.StatusStopped}, nil
+			}
+			t.Errorf("unexpected VM name: %s", inputVM.Name)
+			return nil, nil
+		}))
+
+	configured := []*model.VM{
+		{Name: "running-vm", Project: "p", Zone: "z"},
+		{Name: "stopped-vm", Project: "p", Zone: "z"},
+	}
+	opts := map[string]model.SSHOptions{
+		"running-vm": {User: "alice", Port: 2222},
+	}
+
+	hosts, err := NewGenerateSSHConfigUseCase(mockRepo).Execute(context.Background(), configured, opts)
+
+	require.Error(t, err, "stopped VM without external IP should be reported")
+	assert.ErrorIs(t, err, model.ErrNoExternalIP)
+	require.Len(t, hosts, 1)
+	assert.Equal(t, model.SSHHost{
+		Alias:      "running-vm",
+		HostName:   "34.1.2.3",
+		SSHOptions: model.SSHOptions{User: "alice", Port: 2222},
+	}, hosts[0])
+}
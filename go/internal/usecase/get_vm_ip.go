@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+)
+
+// GetVMIPUseCase retrieves the IP address of a specific VM.
+type GetVMIPUseCase struct {
+	repo repository.VMRepository
+}
+
+// NewGetVMIPUseCase creates a new GetVMIPUseCase instance.
+func NewGetVMIPUseCase(repo repository.VMRepository) *GetVMIPUseCase {
+	return &GetVMIPUseCase{repo: repo}
+}
+
+// Execute returns the external IP of a VM, or its internal IP when internal is true.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - project: GCP project ID
+//   - zone: GCP zone
+//   - name: VM instance name
+//   - internal: Whether to return the internal IP instead of the external IP
+//
+// Returns:
+//   - string: The requested IP address
+//   - error: Error if VM retrieval fails or the VM has no such address
+//
+// Example:
+//
+//	useCase := NewGetVMIPUseCase(repo)
+//	ip, err := useCase.Execute(ctx, "my-project", "us-central1-a", "my-vm", false)
+//	// ip: "34.123.45.67"
+func (u *GetVMIPUseCase) Execute(ctx context.Context, project, zone, name string, internal bool) (string, error) {
+	vm := &model.VM{
+		Project: project,
+		Zone:    zone,
+		Name:    name,
+	}
+	foundVM, err := u.repo.FindByName(ctx, vm)
+	if err != nil {
+		return "", fmt.Errorf("failed to find VM: %w", err)
+	}
+	if foundVM == nil {
+		return "", fmt.Errorf("VM %s: %w", name, model.ErrVMNotFound)
+	}
+
+	ip, err := foundVM.IPAddress(internal)
+	if err != nil {
+		return "", fmt.Errorf("VM %s (status: %s): %w", name, foundVM.Status, err)
+	}
+	return ip, nil
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/haru-256/gcectl/internal/usecase/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetVMIPUseCase_Execute(t *testing.T) {
+	expectedVM := &model.VM{Project: "test-project", Zone: "us-central1-a", Name: "test-vm"}
+
+	tests := []struct {
+		name        string
+		internal    bool
+		setupMock   func(*mock_repository.MockVMRepository)
+		want        string
+		errContains string
+		wantErr     bool
+	}{
+		{
+			name:     "success: external IP",
+			internal: false,
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				returnVM := &model.VM{Name: "test-vm", Status: model.StatusRunning, ExternalIP: "34.1.2.3", InternalIP: "10.0.0.2"}
+				m.EXPECT().
+					FindByName(gomock.Any(), gomock.Any()).
+					DoAndReturn(testhelpers.VMFindByNameMatcher(t, expectedVM, returnVM, nil))
+			},
+			want: "34.1.2.3",
+		},
+		{
+			name:     "success: internal IP",
+			internal: true,
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				returnVM := &model.VM{Name: "test-vm", Status: model.StatusStopped, InternalIP: "10.0.0.2"}
+				m.EXPECT().
+					FindByName(gomock.Any(), gomock.Any()).
+					DoAndReturn(testhelpers.VMFindByNameMatcher(t, expectedVM, returnVM, nil))
+			},
+			want: "10.0.0.2",
+		},
+		{
+			name:     "error: stopped VM has no external IP",
+			internal: false,
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				returnVM := &model.VM{Name: "test-vm", Status: model.StatusStopped, InternalIP: "10.0.0.2"}
+				m.EXPECT().
+					FindByName(gomock.Any(), gomock.Any()).
+					DoAndReturn(testhelpers.VMFindByNameMatcher(t, expectedVM, returnVM, nil))
+			},
+			wantErr:     true,
+			errContains: "no external IP",
+		},
+		{
+			name:     "error: VM lookup fails",
+			internal: false,
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				m.EXPECT().
+					FindByName(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("API error"))
+			},
+			wantErr:     true,
+			errContains: "failed to find VM",
+		},
+		{
+			name:     "error: VM not found",
+			internal: false,
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				m.EXPECT().
+					FindByName(gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+			},
+			wantErr:     true,
+			errContains: "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_repository.NewMockVMRepository(ctrl)
+			tt.setupMock(mockRepo)
+
+			useCase := NewGetVMIPUseCase(mockRepo)
+			ip, err := useCase.Execute(context.Background(), "test-project", "us-central1-a", "test-vm", tt.internal)
+
+			if tt.wantErr {
+				assert.Error(t, err, "Execute() should return an error")
+				assert.Contains(t, err.Error(), tt.errContains, "Error should contain %v", tt.errContains)
+				return
+			}
+			assert.NoError(t, err, "Execute() should not return an error")
+			assert.Equal(t, tt.want, ip, "IP should match")
+		})
+	}
+}
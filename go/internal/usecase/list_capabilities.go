@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+)
+
+// ListCapabilitiesUseCase reports which gcectl operations are currently valid for a VM.
+type ListCapabilitiesUseCase struct {
+	repo repository.VMRepository
+}
+
+// NewListCapabilitiesUseCase creates a new ListCapabilitiesUseCase instance.
+func NewListCapabilitiesUseCase(repo repository.VMRepository) *ListCapabilitiesUseCase {
+	return &ListCapabilitiesUseCase{repo: repo}
+}
+
+// Execute fetches the VM's current state and evaluates every capability against it.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - vm: The VM to inspect (must contain Project, Zone, and Name)
+//
+// Returns:
+//   - []model.CapabilityStatus: One entry per capability with the reason for any that are unavailable
+//   - error: Error if VM retrieval fails
+//
+// Example:
+//
+//	useCase := NewListCapabilitiesUseCase(repo)
+//	caps, err := useCase.Execute(ctx, vm)
+//	// caps[0]: {Capability: "start", Supported: false, Reason: "VM is RUNNING, must be STOPPED or TERMINATED"}
+func (u *ListCapabilitiesUseCase) Execute(ctx context.Context, vm *model.VM) ([]model.CapabilityStatus, error) {
+	foundVM, err := u.repo.FindByName(ctx, vm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find VM: %w", err)
+	}
+	if foundVM == nil {
+		return nil, fmt.Errorf("VM %s: %w", vm.Name, model.ErrVMNotFound)
+	}
+	return foundVM.Capabilities(), nil
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListCapabilitiesUseCase_Execute(t *testing.T) {
+	vm := &model.VM{Project: "test-project", Zone: "us-central1-a", Name: "test-vm"}
+
+	tests := []struct {
+		name        string
+		setupMock   func(*mock_repository.MockVMRepository)
+		errContains string
+		wantErr     bool
+	}{
+		{
+			name: "success: capabilities of running VM",
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				m.EXPECT().FindByName(gomock.Any(), vm).Return(&model.VM{Name: "test-vm", Status: model.StatusRunning}, nil)
+			},
+		},
+		{
+			name: "error: repository failure",
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				m.EXPECT().FindByName(gomock.Any(), vm).Return(nil, errors.New("api error"))
+			},
+			wantErr:     true,
+			errContains: "failed to find VM",
+		},
+		{
+			name: "error: VM not found",
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				m.EXPECT().FindByName(gomock.Any(), vm).Return(nil, nil)
+			},
+			wantErr:     true,
+			errContains: "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_repository.NewMockVMRepository(ctrl)
+			tt.setupMock(mockRepo)
+
+			caps, err := NewListCapabilitiesUseCase(mockRepo).Execute(context.Background(), vm)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			require.NotEmpty(t, caps)
+			assert.Equal(t, model.CapabilityStart, caps[0].Capability)
+			assert.False(t, caps[0].Supported)
+			assert.Equal(t, model.CapabilityStop, caps[1].Capability)
+			assert.True(t, caps[1].Supported)
+		})
+	}
+}
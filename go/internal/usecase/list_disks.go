@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+)
+
+// ListDisksUseCase lists the disks attached to a VM.
+type ListDisksUseCase struct {
+	repo repository.DiskRepository
+}
+
+// NewListDisksUseCase creates a new ListDisksUseCase instance.
+func NewListDisksUseCase(repo repository.DiskRepository) *ListDisksUseCase {
+	return &ListDisksUseCase{repo: repo}
+}
+
+// Execute returns the disks attached to a VM, boot disk first.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - project: GCP project ID
+//   - zone: GCP zone
+//   - name: VM instance name
+//
+// Returns:
+//   - []*model.Disk: Attached disks with their type and size
+//   - error: Error if listing fails
+//
+// Example:
+//
+//	useCase := NewListDisksUseCase(repo)
+//	disks, err := useCase.Execute(ctx, "my-project", "us-central1-a", "my-vm")
+func (u *ListDisksUseCase) Execute(ctx context.Context, project, zone, name string) ([]*model.Disk, error) {
+	disks, err := u.repo.List(ctx, &model.VM{Project: project, Zone: zone, Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list disks: %w", err)
+	}
+	return disks, nil
+}
+
+// findAttachedDisk looks up a disk of vm by name or device name. The error lists
+// the attached disks when none matches.
+func findAttachedDisk(ctx context.Context, repo repository.DiskRepository, vm *model.VM, nameOrDevice string) (*model.Disk, error) {
+	disks, err := repo.List(ctx, vm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list disks: %w", err)
+	}
+	disk := model.FindDisk(disks, nameOrDevice)
+	if disk == nil {
+		names := make([]string, 0, len(disks))
+		for _, d := range disks {
+			names = append(names, d.Name)
+		}
+		return nil, fmt.Errorf("disk %s is not attached to VM %s (attached: %s)", nameOrDevice, vm.Name, strings.Join(names, ", "))
+	}
+	return disk, nil
+}
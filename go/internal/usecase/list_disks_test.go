@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// attachedDisks is the disk layout shared by the disk use case tests.
+func attachedDisks() []*model.Disk {
+	return []*model.Disk{
+		{Name: "vm-1", DeviceName: "persistent-disk-0", Type: "pd-balanced", SizeGB: 10, Boot: true},
+		{Name: "data-1", DeviceName: "data", Type: "pd-ssd", SizeGB: 100},
+	}
+}
+
+func TestListDisksUseCase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repository.NewMockDiskRepository(ctrl)
+	repo.EXPECT().
+		List(gomock.Any(), &model.VM{Project: "proj", Zone: "z", Name: "vm-1"}).
+		Return(attachedDisks(), nil)
+	repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, errors.New("denied"))
+
+	uc := NewListDisksUseCase(repo)
+	got, err := uc.Execute(context.Background(), "proj", "z", "vm-1")
+	require.NoError(t, err)
+	assert.Equal(t, attachedDisks(), got)
+
+	_, err = uc.Execute(context.Background(), "proj", "z", "vm-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to list disks")
+}
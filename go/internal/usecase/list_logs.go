@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// ListLogsUseCase handles retrieving the Cloud Logging entries of a VM
+type ListLogsUseCase struct {
+	vmRepo  repository.VMRepository
+	logRepo repository.LogRepository
+	logger  log.Logger
+	now     func() time.Time
+}
+
+// NewListLogsUseCase creates a new instance of ListLogsUseCase
+func NewListLogsUseCase(vmRepo repository.VMRepository, logRepo repository.LogRepository, logger log.Logger) *ListLogsUseCase {
+	return &ListLogsUseCase{vmRepo: vmRepo, logRepo: logRepo, logger: logger, now: time.Now}
+}
+
+// Execute returns the most recent log entries of a VM, oldest first.
+//
+// Entries are looked up by the VM's instance ID, so the logs of a deleted VM that
+// had the same name are not included.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - project: The GCP project ID
+//   - zone: The GCP zone
+//   - name: The VM instance name
+//   - since: How far back to look (e.g., time.Hour)
+//   - filter: An additional Cloud Logging filter (e.g., "severity>=ERROR"), or ""
+//   - limit: The maximum number of entries to return
+//
+// Returns:
+//   - []*model.LogEntry: The entries, oldest first
+//   - error: nil on success, otherwise an error describing what went wrong
+//
+// Example:
+//
+//	usecase := NewListLogsUseCase(vmRepo, logRepo, logger)
+//	entries, err := usecase.Execute(ctx, "my-project", "us-central1-a", "my-vm", time.Hour, "severity>=ERROR", 100)
+func (uc *ListLogsUseCase) Execute(ctx context.Context, project, zone, name string, since time.Duration, filter string, limit int) ([]*model.LogEntry, error) {
+	// 1. 入力チェック
+	if since <= 0 {
+		return nil, fmt.Errorf("--since must be positive: %s", since)
+	}
+	if limit < 1 {
+		return nil, fmt.Errorf("--limit must be at least 1: %d", limit)
+	}
+
+	// 2. VMを取得してインスタンスIDを得る
+	foundVM, err := uc.vmRepo.FindByName(ctx, &model.VM{Project: project, Zone: zone, Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find VM: %w", err)
+	}
+	if foundVM == nil {
+		return nil, fmt.Errorf("VM %s: %w", name, model.ErrVMNotFound)
+	}
+
+	// 3. ログを取得
+	entries, err := uc.logRepo.List(ctx, foundVM, uc.now().Add(-since), filter, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list logs: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var loggerForListLogs = log.NewLogger()
+
+func TestListLogsUseCase_Execute(t *testing.T) {
+	now := time.Date(2025, 1, 2, 6, 0, 0, 0, time.UTC)
+	vm := &model.VM{ID: "123", Name: "vm-1", Project: "proj", Zone: "z"}
+	entries := []*model.LogEntry{{Timestamp: now.Add(-time.Minute), Severity: "INFO", Log: "syslog", Message: "hello"}}
+
+	//nolint:govet // Test struct field order optimized for readability
+	tests := []struct {
+		name        string
+		since       time.Duration
+		limit       int
+		findErr     error
+		listErr     error
+		wantFind    bool
+		wantList    bool
+		errContains string
+	}{
+		{name: "success", since: time.Hour, limit: 100, wantFind: true, wantList: true},
+		{name: "error: since not positive", since: 0, limit: 100, errContains: "--since"},
+		{name: "error: limit below one", since: time.Hour, limit: 0, errContains: "--limit"},
+		{name: "error: VM not found", since: time.Hour, limit: 100, findErr: errors.New("not found"), wantFind: true, errContains: "failed to find VM"},
+		{name: "error: listing fails", since: time.Hour, limit: 100, listErr: errors.New("denied"), wantFind: true, wantList: true, errContains: "failed to list logs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			vmRepo := mock_repository.NewMockVMRepository(ctrl)
+			logRepo := mock_repository.NewMockLogRepository(ctrl)
+			if tt.wantFind {
+				vmRepo.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(vm, tt.findErr)
+			}
+			if tt.wantList {
+				logRepo.EXPECT().List(gomock.Any(), vm, now.Add(-tt.since), "severity>=ERROR", tt.limit).Return(entries, tt.listErr)
+			}
+
+			uc := NewListLogsUseCase(vmRepo, logRepo, loggerForListLogs)
+			uc.now = func() time.Time { return now }
+
+			got, err := uc.Execute(context.Background(), "proj", "z", "vm-1", tt.since, "severity>=ERROR", tt.limit)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, entries, got)
+		})
+	}
+}
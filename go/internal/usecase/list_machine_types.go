@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+)
+
+// ListMachineTypesUseCase lists the machine types available in a zone.
+type ListMachineTypesUseCase struct {
+	repo repository.MachineTypeRepository
+}
+
+// NewListMachineTypesUseCase creates a new ListMachineTypesUseCase instance.
+func NewListMachineTypesUseCase(repo repository.MachineTypeRepository) *ListMachineTypesUseCase {
+	return &ListMachineTypesUseCase{repo: repo}
+}
+
+// Execute returns the machine types available in project/zone, grouped by family.
+// Within a family machine types are ordered by vCPUs, then memory, then name.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - project: The project to list machine types of
+//   - zone: The zone to list machine types of (e.g., "us-central1-a")
+//
+// Returns:
+//   - []*model.MachineType: Available machine types, families in alphabetical order
+//   - error: Error if listing fails
+//
+// Example:
+//
+//	useCase := NewListMachineTypesUseCase(repo)
+//	machineTypes, err := useCase.Execute(ctx, "my-project", "us-central1-a")
+func (u *ListMachineTypesUseCase) Execute(ctx context.Context, project, zone string) ([]*model.MachineType, error) {
+	machineTypes, err := u.repo.List(ctx, project, zone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list machine types: %w", err)
+	}
+	sort.Slice(machineTypes, func(i, j int) bool {
+		a, b := machineTypes[i], machineTypes[j]
+		if a.Family() != b.Family() {
+			return a.Family() < b.Family()
+		}
+		if a.GuestCPUs != b.GuestCPUs {
+			return a.GuestCPUs < b.GuestCPUs
+		}
+		if a.MemoryMB != b.MemoryMB {
+			return a.MemoryMB < b.MemoryMB
+		}
+		return a.Name < b.Name
+	})
+	return machineTypes, nil
+}
+
+// SelectMachineType resolves a choice typed at the machine type picker.
+//
+// The input may be the 1-based number shown next to a machine type or its name.
+//
+// Parameters:
+//   - machineTypes: The machine types offered, in display order
+//   - input: The user's input
+//
+// Returns:
+//   - *model.MachineType: The chosen machine type
+//   - error: Error if the input matches no offered machine type
+func SelectMachineType(machineTypes []*model.MachineType, input string) (*model.MachineType, error) {
+	input = strings.TrimSpace(input)
+	if n, err := strconv.Atoi(input); err == nil {
+		if n < 1 || n > len(machineTypes) {
+			return nil, fmt.Errorf("choice %d is out of range 1-%d", n, len(machineTypes))
+		}
+		return machineTypes[n-1], nil
+	}
+	for _, mt := range machineTypes {
+		if mt.Name == input {
+			return mt, nil
+		}
+	}
+	return nil, fmt.Errorf("machine type %q is not available in this zone", input)
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListMachineTypesUseCase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repository.NewMockMachineTypeRepository(ctrl)
+	repo.EXPECT().List(gomock.Any(), "proj", "us-central1-a").Return([]*model.MachineType{
+		{Name: "n2-standard-4", GuestCPUs: 4, MemoryMB: 16384},
+		{Name: "e2-standard-2", GuestCPUs: 2, MemoryMB: 8192},
+		{Name: "e2-medium", GuestCPUs: 2, MemoryMB: 4096},
+		{Name: "e2-micro", GuestCPUs: 2, MemoryMB: 1024},
+	}, nil)
+
+	machineTypes, err := NewListMachineTypesUseCase(repo).Execute(context.Background(), "proj", "us-central1-a")
+	require.NoError(t, err)
+	names := make([]string, 0, len(machineTypes))
+	for _, mt := range machineTypes {
+		names = append(names, mt.Name)
+	}
+	assert.Equal(t, []string{"e2-micro", "e2-medium", "e2-standard-2", "n2-standard-4"}, names)
+
+	repo.EXPECT().List(gomock.Any(), "proj", "us-central1-a").Return(nil, errors.New("denied"))
+	_, err = NewListMachineTypesUseCase(repo).Execute(context.Background(), "proj", "us-central1-a")
+	require.Error(t, err)
+}
+
+func TestSelectMachineType(t *testing.T) {
+	machineTypes := []*model.MachineType{{Name: "e2-micro"}, {Name: "e2-medium"}}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{name: "by number", input: "2", want: "e2-medium"},
+		{name: "by name", input: " e2-micro\n", want: "e2-micro"},
+		{name: "number out of range", input: "3", wantErr: "out of range"},
+		{name: "unavailable name", input: "n2-standard-4", wantErr: "not available"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SelectMachineType(machineTypes, tt.input)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Name)
+		})
+	}
+}
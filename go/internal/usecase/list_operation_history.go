@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+)
+
+// ListOperationHistoryUseCase lists the operations gcectl recorded in the local
+// operation history.
+type ListOperationHistoryUseCase struct {
+	history repository.OperationHistory
+}
+
+// NewListOperationHistoryUseCase creates a new ListOperationHistoryUseCase instance.
+func NewListOperationHistoryUseCase(history repository.OperationHistory) *ListOperationHistoryUseCase {
+	return &ListOperationHistoryUseCase{history: history}
+}
+
+// Execute returns the most recent recorded operations, optionally only those on the given VMs.
+//
+// Parameters:
+//   - names: VM names to keep the operations of, or none for all
+//   - limit: Maximum number of operations (0 means no limit)
+//
+// Returns:
+//   - []*model.OperationRecord: The operations, newest first
+//   - error: Error if the history cannot be read
+func (u *ListOperationHistoryUseCase) Execute(names []string, limit int) ([]*model.OperationRecord, error) {
+	records, err := u.history.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the operation history: %w", err)
+	}
+
+	var matched []*model.OperationRecord
+	for _, r := range records {
+		if len(names) == 0 || slices.Contains(names, r.Target) {
+			matched = append(matched, r)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].Time.After(matched[j].Time)
+	})
+	if limit > 0 && len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched, nil
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListOperationHistoryUseCase_Execute(t *testing.T) {
+	at := time.Date(2025, 1, 2, 19, 0, 0, 0, time.UTC)
+	records := []*model.OperationRecord{
+		{Time: at, Target: "vm-1", Operation: "op-1"},
+		{Time: at.Add(time.Hour), Target: "vm-2", Operation: "op-2"},
+		{Time: at.Add(2 * time.Hour), Target: "vm-1", Operation: "op-3"},
+	}
+	operations := func(rs []*model.OperationRecord) []string {
+		names := make([]string, 0, len(rs))
+		for _, r := range rs {
+			names = append(names, r.Operation)
+		}
+		return names
+	}
+
+	t.Run("success: all VMs newest first", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		history := mock_repository.NewMockOperationHistory(ctrl)
+		history.EXPECT().List().Return(records, nil)
+
+		got, err := NewListOperationHistoryUseCase(history).Execute(nil, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"op-3", "op-2", "op-1"}, operations(got))
+	})
+
+	t.Run("success: filters by VM and limits", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		history := mock_repository.NewMockOperationHistory(ctrl)
+		history.EXPECT().List().Return(records, nil)
+
+		got, err := NewListOperationHistoryUseCase(history).Execute([]string{"vm-1"}, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"op-3"}, operations(got))
+	})
+
+	t.Run("error: history cannot be read", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		history := mock_repository.NewMockOperationHistory(ctrl)
+		history.EXPECT().List().Return(nil, errors.New("permission denied"))
+
+		_, err := NewListOperationHistoryUseCase(history).Execute(nil, 0)
+
+		require.Error(t, err)
+	})
+}
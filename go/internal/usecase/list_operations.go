@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"golang.org/x/sync/errgroup"
+)
+
+// ListOperationsUseCase lists recent GCE operations for configured VMs.
+type ListOperationsUseCase struct {
+	opRepo         repository.OperationRepository
+	maxConcurrency int
+}
+
+// NewListOperationsUseCase creates a new ListOperationsUseCase instance.
+func NewListOperationsUseCase(opRepo repository.OperationRepository) *ListOperationsUseCase {
+	return &ListOperationsUseCase{opRepo: opRepo, maxConcurrency: maxConcurrentVMLookups}
+}
+
+// WithMaxConcurrency sets how many VMs are queried at once. Values <= 0 keep the default.
+func (u *ListOperationsUseCase) WithMaxConcurrency(n int) *ListOperationsUseCase {
+	if n > 0 {
+		u.maxConcurrency = n
+	}
+	return u
+}
+
+// Execute fetches the most recent operations of each VM concurrently and merges them.
+//
+// Like ListVMsUseCase, this is best-effort: VMs whose operations cannot be listed are
+// reported in the returned error, while operations of the remaining VMs are still returned.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - vms: VMs to list operations for (must contain Project, Zone, and Name)
+//   - limit: Maximum number of operations per VM (0 means no limit)
+//
+// Returns:
+//   - []*model.Operation: Operations of all VMs, newest first
+//   - error: Joined error for VMs that failed, or nil
+func (u *ListOperationsUseCase) Execute(ctx context.Context, vms []*model.VM, limit int) ([]*model.Operation, error) {
+	var (
+		mu   sync.Mutex
+		ops  []*model.Operation
+		errs []error
+	)
+
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(u.maxConcurrency)
+	for _, vm := range vms {
+		eg.Go(func() error {
+			vmOps, err := u.opRepo.ListByTarget(ctx, vm, limit)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("VM %s: %w", vm.Name, err))
+				return nil
+			}
+			ops = append(ops, vmOps...)
+			return nil
+		})
+	}
+	_ = eg.Wait()
+
+	sortOperationsNewestFirst(ops)
+	return ops, errors.Join(errs...)
+}
+
+// sortOperationsNewestFirst orders operations by insert time, newest first.
+// Operations without an insert time are placed last.
+func sortOperationsNewestFirst(ops []*model.Operation) {
+	sort.SliceStable(ops, func(i, j int) bool {
+		a, b := ops[i].InsertTime, ops[j].InsertTime
+		switch {
+		case a == nil:
+			return false
+		case b == nil:
+			return true
+		default:
+			return a.After(*b)
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListOperationsUseCase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	vms := []*model.VM{
+		{Project: "p", Zone: "z", Name: "vm-1"},
+		{Project: "p", Zone: "z", Name: "vm-2"},
+		{Project: "p", Zone: "z", Name: "vm-3"},
+	}
+	mockRepo := mock_repository.NewMockOperationRepository(ctrl)
+	mockRepo.EXPECT().
+		ListByTarget(gomock.Any(), gomock.Any(), 5).
+		Times(3).
+		DoAndReturn(func(ctx context.Context, vm *model.VM, limit int) ([]*model.Operation, error) {
+			switch vm.Name {
+			case "vm-1":
+				return []*model.Operation{
+					{Name: "op-old", Target: vm.Name, InsertTime: timePtr(now.Add(-time.Hour))},
+					{Name: "op-no-time", Target: vm.Name},
+				}, nil
+			case "vm-2":
+				return []*model.Operation{{Name: "op-new", Target: vm.Name, InsertTime: timePtr(now)}}, nil
+			default:
+				return nil, errors.New("permission denied")
+			}
+		})
+
+	ops, err := NewListOperationsUseCase(mockRepo).Execute(context.Background(), vms, 5)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "VM vm-3: permission denied")
+	require.Len(t, ops, 3)
+	assert.Equal(t, "op-new", ops[0].Name)
+	assert.Equal(t, "op-old", ops[1].Name)
+	assert.Equal(t, "op-no-time", ops[2].Name)
+}
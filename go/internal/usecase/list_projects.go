@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+)
+
+// ListProjectsUseCase lists the projects the caller can access.
+type ListProjectsUseCase struct {
+	repo repository.ProjectRepository
+}
+
+// NewListProjectsUseCase creates a new ListProjectsUseCase instance.
+func NewListProjectsUseCase(repo repository.ProjectRepository) *ListProjectsUseCase {
+	return &ListProjectsUseCase{repo: repo}
+}
+
+// Execute returns the active projects the caller can access, ordered by ID.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//
+// Returns:
+//   - []*model.Project: Accessible projects in ID order
+//   - error: Error if listing fails
+//
+// Example:
+//
+//	useCase := NewListProjectsUseCase(repo)
+//	projects, err := useCase.Execute(ctx)
+func (u *ListProjectsUseCase) Execute(ctx context.Context) ([]*model.Project, error) {
+	projects, err := u.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].ID < projects[j].ID
+	})
+	return projects, nil
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListProjectsUseCase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repository.NewMockProjectRepository(ctrl)
+	repo.EXPECT().List(gomock.Any()).Return([]*model.Project{{ID: "proj-b"}, {ID: "proj-a"}}, nil)
+
+	projects, err := NewListProjectsUseCase(repo).Execute(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*model.Project{{ID: "proj-a"}, {ID: "proj-b"}}, projects)
+
+	repo.EXPECT().List(gomock.Any()).Return(nil, errors.New("denied"))
+	_, err = NewListProjectsUseCase(repo).Execute(context.Background())
+	require.Error(t, err)
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+)
+
+// ListSchedulePoliciesUseCase lists the instance schedule policies of a project region.
+type ListSchedulePoliciesUseCase struct {
+	repo repository.SchedulePolicyRepository
+}
+
+// NewListSchedulePoliciesUseCase creates a new ListSchedulePoliciesUseCase instance.
+func NewListSchedulePoliciesUseCase(repo repository.SchedulePolicyRepository) *ListSchedulePoliciesUseCase {
+	return &ListSchedulePoliciesUseCase{repo: repo}
+}
+
+// Execute returns the instance schedule policies in project/region, sorted by name.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - project: The project to list policies of
+//   - region: The region to list policies of (e.g., "us-central1")
+//
+// Returns:
+//   - []*model.SchedulePolicy: Policies with their cron schedules and attached VM counts
+//   - error: Error if project or region is empty or listing fails
+//
+// Example:
+//
+//	useCase := NewListSchedulePoliciesUseCase(repo)
+//	policies, err := useCase.Execute(ctx, "my-project", "us-central1")
+func (u *ListSchedulePoliciesUseCase) Execute(ctx context.Context, project, region string) ([]*model.SchedulePolicy, error) {
+	if project == "" || region == "" {
+		return nil, fmt.Errorf("project and region are required (set default-project/default-zone in config or pass --project/--region)")
+	}
+
+	policies, err := u.repo.List(ctx, project, region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedule policies: %w", err)
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+	return policies, nil
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListSchedulePoliciesUseCase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repository.NewMockSchedulePolicyRepository(ctrl)
+	repo.EXPECT().List(gomock.Any(), "proj", "us-central1").Return([]*model.SchedulePolicy{
+		{Name: "weekday-stop", AttachedVMs: 2},
+		{Name: "nightly-stop"},
+	}, nil)
+
+	policies, err := NewListSchedulePoliciesUseCase(repo).Execute(context.Background(), "proj", "us-central1")
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	assert.Equal(t, "nightly-stop", policies[0].Name)
+	assert.Equal(t, "weekday-stop", policies[1].Name)
+}
+
+func TestListSchedulePoliciesUseCase_ExecuteErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repository.NewMockSchedulePolicyRepository(ctrl)
+	_, err := NewListSchedulePoliciesUseCase(repo).Execute(context.Background(), "proj", "")
+	require.Error(t, err)
+
+	repo.EXPECT().List(gomock.Any(), "proj", "us-central1").Return(nil, errors.New("denied"))
+	_, err = NewListSchedulePoliciesUseCase(repo).Execute(context.Background(), "proj", "us-central1")
+	require.ErrorContains(t, err, "failed to list schedule policies")
+}
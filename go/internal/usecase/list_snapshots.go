@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+)
+
+// ListSnapshotsUseCase lists the snapshots of the disks of a VM.
+type ListSnapshotsUseCase struct {
+	diskRepo     repository.DiskRepository
+	snapshotRepo repository.SnapshotRepository
+}
+
+// NewListSnapshotsUseCase creates a new ListSnapshotsUseCase instance.
+func NewListSnapshotsUseCase(diskRepo repository.DiskRepository, snapshotRepo repository.SnapshotRepository) *ListSnapshotsUseCase {
+	return &ListSnapshotsUseCase{diskRepo: diskRepo, snapshotRepo: snapshotRepo}
+}
+
+// Execute returns the snapshots taken from the disks currently attached to a VM, newest first.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - project: GCP project ID
+//   - zone: GCP zone
+//   - name: VM instance name
+//
+// Returns:
+//   - []*model.Snapshot: Snapshots of the VM's disks, newest first
+//   - error: Error if listing disks or snapshots fails
+//
+// Example:
+//
+//	useCase := NewListSnapshotsUseCase(diskRepo, snapshotRepo)
+//	snapshots, err := useCase.Execute(ctx, "my-project", "us-central1-a", "my-vm")
+func (u *ListSnapshotsUseCase) Execute(ctx context.Context, project, zone, name string) ([]*model.Snapshot, error) {
+	vm := &model.VM{Project: project, Zone: zone, Name: name}
+	disks, err := u.diskRepo.List(ctx, vm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list disks: %w", err)
+	}
+	diskNames := make([]string, 0, len(disks))
+	for _, d := range disks {
+		diskNames = append(diskNames, d.Name)
+	}
+
+	snapshots, err := u.snapshotRepo.List(ctx, vm, diskNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		a, b := snapshots[i].CreationTime, snapshots[j].CreationTime
+		switch {
+		case a == nil:
+			return false
+		case b == nil:
+			return true
+		default:
+			return a.After(*b)
+		}
+	})
+	return snapshots, nil
+}
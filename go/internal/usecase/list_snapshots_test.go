@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListSnapshotsUseCase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	older := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(24 * time.Hour)
+	diskRepo := mock_repository.NewMockDiskRepository(ctrl)
+	snapshotRepo := mock_repository.NewMockSnapshotRepository(ctrl)
+	diskRepo.EXPECT().List(gomock.Any(), gomock.Any()).Return(attachedDisks(), nil).Times(2)
+	snapshotRepo.EXPECT().
+		List(gomock.Any(), gomock.Any(), []string{"vm-1", "data-1"}).
+		Return([]*model.Snapshot{
+			{Name: "unknown-time"},
+			{Name: "older", CreationTime: &older},
+			{Name: "newer", CreationTime: &newer},
+		}, nil)
+	snapshotRepo.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("denied"))
+
+	uc := NewListSnapshotsUseCase(diskRepo, snapshotRepo)
+	got, err := uc.Execute(context.Background(), "proj", "z", "vm-1")
+	require.NoError(t, err)
+	names := make([]string, 0, len(got))
+	for _, s := range got {
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{"newer", "older", "unknown-time"}, names, "newest first, unknown creation time last")
+
+	_, err = uc.Execute(context.Background(), "proj", "z", "vm-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to list snapshots")
+}
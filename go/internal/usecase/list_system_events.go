@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+)
+
+// ListSystemEventsUseCase lists the recent system events of a VM, such as host
+// maintenance, preemption and automatic restarts.
+type ListSystemEventsUseCase struct {
+	opRepo repository.OperationRepository
+}
+
+// NewListSystemEventsUseCase creates a new ListSystemEventsUseCase instance.
+func NewListSystemEventsUseCase(opRepo repository.OperationRepository) *ListSystemEventsUseCase {
+	return &ListSystemEventsUseCase{opRepo: opRepo}
+}
+
+// Execute fetches the most recent system events of vm.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - vm: The VM (must contain Project, Zone, and Name)
+//   - limit: Maximum number of events (0 means no limit)
+//
+// Returns:
+//   - []*model.Operation: The system events, newest first
+//   - error: Error if the events cannot be listed
+func (u *ListSystemEventsUseCase) Execute(ctx context.Context, vm *model.VM, limit int) ([]*model.Operation, error) {
+	events, err := u.opRepo.ListSystemEvents(ctx, vm, limit)
+	if err != nil {
+		return nil, fmt.Errorf("VM %s: %w", vm.Name, err)
+	}
+	sortOperationsNewestFirst(events)
+	return events, nil
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListSystemEventsUseCase_Execute(t *testing.T) {
+	vm := &model.VM{Project: "p", Zone: "z", Name: "vm-1"}
+	now := time.Now()
+
+	t.Run("success: newest first", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mock_repository.NewMockOperationRepository(ctrl)
+		mockRepo.EXPECT().ListSystemEvents(gomock.Any(), vm, 10).Return([]*model.Operation{
+			{Name: "op-old", Type: model.OperationTypePreempted, InsertTime: timePtr(now.Add(-time.Hour))},
+			{Name: "op-new", Type: "compute.instances.migrateOnHostMaintenance", InsertTime: timePtr(now)},
+		}, nil)
+
+		events, err := NewListSystemEventsUseCase(mockRepo).Execute(context.Background(), vm, 10)
+
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, "op-new", events[0].Name)
+	})
+
+	t.Run("error: repository fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mock_repository.NewMockOperationRepository(ctrl)
+		mockRepo.EXPECT().ListSystemEvents(gomock.Any(), vm, 10).Return(nil, errors.New("permission denied"))
+
+		_, err := NewListSystemEventsUseCase(mockRepo).Execute(context.Background(), vm, 10)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "VM vm-1: permission denied")
+	})
+}
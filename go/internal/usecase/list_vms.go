@@ -12,19 +12,31 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
+// maxConcurrentVMLookups is the default cap on concurrent API calls per use case.
+// It can be overridden with WithMaxConcurrency.
 const maxConcurrentVMLookups = 10
 
-// VMListItem represents a VM with its display information including uptime.
+// VMListItem represents a VM with its display information including uptime
+// and the next schedule policy trigger.
 // This struct is used to pass presentation-ready data from the use case layer
 // to the presenter layer, keeping business logic out of the presentation layer.
 type VMListItem struct {
 	VM     *model.VM
 	Uptime string
+	// StoppedFor is how long a stopped VM has been stopped, "N/A" otherwise.
+	StoppedFor   string
+	NextSchedule string
+	// UptimeLevel is how far the uptime is past the threshold set with WithUptimeWarning.
+	UptimeLevel model.UptimeLevel
 }
 
 // ListVMsUseCase handles the business logic for listing VMs with their uptime.
 type ListVMsUseCase struct {
-	repo repository.VMRepository
+	repo           repository.VMRepository
+	cache          repository.VMStateCache
+	scheduled      repository.ScheduledActionStore
+	maxConcurrency int
+	uptimeWarning  time.Duration
 }
 
 // NewListVMsUseCase creates a new ListVMsUseCase instance.
@@ -36,10 +48,49 @@ type ListVMsUseCase struct {
 //   - *ListVMsUseCase: A new use case instance
 func NewListVMsUseCase(repo repository.VMRepository) *ListVMsUseCase {
 	return &ListVMsUseCase{
-		repo: repo,
+		repo:           repo,
+		maxConcurrency: maxConcurrentVMLookups,
 	}
 }
 
+// WithMaxConcurrency sets how many projects are looked up at once. Values <= 0 keep the default.
+func (u *ListVMsUseCase) WithMaxConcurrency(n int) *ListVMsUseCase {
+	if n > 0 {
+		u.maxConcurrency = n
+	}
+	return u
+}
+
+// WithUptimeWarning sets the uptime past which items report a UptimeLevel above
+// UptimeNormal. Zero, the default, disables it.
+func (u *ListVMsUseCase) WithUptimeWarning(threshold time.Duration) *ListVMsUseCase {
+	u.uptimeWarning = threshold
+	return u
+}
+
+// WithCache makes the use case record every successful lookup in cache,
+// so later calls to ExecuteCached can be served without API calls.
+func (u *ListVMsUseCase) WithCache(cache repository.VMStateCache) *ListVMsUseCase {
+	u.cache = cache
+	return u
+}
+
+// WithScheduledActions makes NextSchedule report pending stops of the local
+// scheduler when they come before the schedule policy's next trigger.
+func (u *ListVMsUseCase) WithScheduledActions(store repository.ScheduledActionStore) *ListVMsUseCase {
+	u.scheduled = store
+	return u
+}
+
+// nextSchedule describes the next schedule trigger of vm, including pending stops.
+func (u *ListVMsUseCase) nextSchedule(vm *model.VM, now time.Time) string {
+	if u.scheduled == nil {
+		return NextScheduleString(vm, now)
+	}
+	pending, _ := u.scheduled.Get(vm)
+	return NextScheduleWithPending(vm, pending, now)
+}
+
 // Execute retrieves the configured VMs and calculates their uptime strings.
 //
 // This method encapsulates the business logic of calculating uptime,
@@ -66,34 +117,71 @@ func NewListVMsUseCase(repo repository.VMRepository) *ListVMsUseCase {
 //	    fmt.Printf("%s: %s\n", item.VM.Name, item.Uptime)
 //	}
 func (u *ListVMsUseCase) Execute(ctx context.Context, configuredVMs []*model.VM) ([]VMListItem, error) {
+	return u.Stream(ctx, configuredVMs, nil)
+}
+
+// Stream behaves like Execute but also reports each lookup as soon as it completes,
+// so callers can render results progressively instead of waiting for the slowest project.
+// VMs are fetched with one FindAll call per project, and projects are queried in parallel.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - configuredVMs: VMs loaded from config to query from the repository
+//   - onResult: Called once per configured VM with its index in configuredVMs and either
+//     the item or the lookup error. It is called concurrently from multiple goroutines
+//     and may be nil.
+//
+// Returns:
+//   - []VMListItem: Successfully retrieved VMs with calculated uptime strings
+//   - error: Joined error for failed VM lookups, or nil if all lookups succeed
+func (u *ListVMsUseCase) Stream(ctx context.Context, configuredVMs []*model.VM, onResult func(index int, item VMListItem, err error)) ([]VMListItem, error) {
 	now := time.Now()
 	items := make([]VMListItem, len(configuredVMs))
 	errs := make([]error, 0)
 	var mu sync.Mutex
 
+	report := func(i int, item VMListItem, err error) {
+		if err != nil {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		}
+		if onResult != nil {
+			onResult(i, item, err)
+		}
+	}
+
 	eg, ctx := errgroup.WithContext(ctx)
-	eg.SetLimit(maxConcurrentVMLookups)
+	eg.SetLimit(u.maxConcurrency)
 
-	for i, configuredVM := range configuredVMs {
-		i, configuredVM := i, configuredVM
+	for _, indices := range indicesByProject(configuredVMs) {
+		indices := indices
 		eg.Go(func() error {
-			vm, err := u.repo.FindByName(ctx, configuredVM)
-			if err != nil {
-				mu.Lock()
-				errs = append(errs, fmt.Errorf("VM %s (project=%s, zone=%s): failed to find: %w", configuredVM.Name, configuredVM.Project, configuredVM.Zone, err))
-				mu.Unlock()
-				return nil
-			}
-			if vm == nil {
-				mu.Lock()
-				errs = append(errs, fmt.Errorf("VM %s (project=%s, zone=%s): not found", configuredVM.Name, configuredVM.Project, configuredVM.Zone))
-				mu.Unlock()
-				return nil
+			batch := make([]*model.VM, len(indices))
+			for j, i := range indices {
+				batch[j] = configuredVMs[i]
 			}
 
-			items[i] = VMListItem{
-				VM:     vm,
-				Uptime: calculateUptimeString(vm, now),
+			found, err := u.repo.FindAll(ctx, batch)
+			for j, i := range indices {
+				configuredVM := configuredVMs[i]
+				if err != nil {
+					report(i, VMListItem{}, fmt.Errorf("VM %s (project=%s, zone=%s): failed to find: %w", configuredVM.Name, configuredVM.Project, configuredVM.Zone, err))
+					continue
+				}
+				if j >= len(found) || found[j] == nil {
+					report(i, VMListItem{}, fmt.Errorf("VM %s (project=%s, zone=%s): %w", configuredVM.Name, configuredVM.Project, configuredVM.Zone, model.ErrVMNotFound))
+					continue
+				}
+
+				items[i] = VMListItem{
+					VM:           found[j],
+					Uptime:       calculateUptimeString(found[j], now),
+					StoppedFor:   calculateStoppedForString(found[j], now),
+					NextSchedule: u.nextSchedule(found[j], now),
+					UptimeLevel:  found[j].UptimeLevel(now, u.uptimeWarning),
+				}
+				report(i, items[i], nil)
 			}
 			return nil
 		})
@@ -104,11 +192,120 @@ func (u *ListVMsUseCase) Execute(ctx context.Context, configuredVMs []*model.VM)
 	}
 
 	successfulItems := make([]VMListItem, 0, len(items))
+	fetched := make([]*model.VM, 0, len(items))
 	for _, item := range items {
 		if item.VM != nil {
 			successfulItems = append(successfulItems, item)
+			fetched = append(fetched, item.VM)
 		}
 	}
 
+	if u.cache != nil && len(fetched) > 0 {
+		// The cache is an optimization; failing to update it must not fail the listing.
+		_ = u.cache.Put(fetched, now)
+	}
+
 	return successfulItems, errors.Join(errs...)
 }
+
+// LongRunning returns the items whose uptime is past the uptime-warning threshold.
+func LongRunning(items []VMListItem) []VMListItem {
+	long := make([]VMListItem, 0, len(items))
+	for _, item := range items {
+		if item.UptimeLevel != model.UptimeNormal {
+			long = append(long, item)
+		}
+	}
+	return long
+}
+
+// indicesByProject groups the indices of vms by project, in first-seen order.
+func indicesByProject(vms []*model.VM) [][]int {
+	groups := make([][]int, 0)
+	position := make(map[string]int)
+	for i, vm := range vms {
+		g, ok := position[vm.Project]
+		if !ok {
+			g = len(groups)
+			position[vm.Project] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+	return groups
+}
+
+// ExecuteCached serves the configured VMs from the cache set with WithCache.
+// VMs missing from the cache are looked up live (and cached), so every configured
+// VM is listed on the first run as well.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - configuredVMs: VMs loaded from config
+//   - ttl: Maximum age of a cache entry before it is considered stale
+//
+// Returns:
+//   - []VMListItem: VMs in config order, with uptime calculated against the current time
+//   - bool: true if any served entry is older than ttl and the cache should be refreshed
+//   - error: Joined error for failed live lookups, or nil if all lookups succeed
+//
+// Example:
+//
+//	items, stale, err := NewListVMsUseCase(repo).WithCache(cache).ExecuteCached(ctx, configuredVMs, 5*time.Minute)
+//	if stale {
+//	    // refresh the cache in the background
+//	}
+func (u *ListVMsUseCase) ExecuteCached(ctx context.Context, configuredVMs []*model.VM, ttl time.Duration) ([]VMListItem, bool, error) {
+	if u.cache == nil {
+		items, err := u.Execute(ctx, configuredVMs)
+		return items, false, err
+	}
+
+	now := time.Now()
+	cached := make([]*model.VM, len(configuredVMs))
+	misses := make([]*model.VM, 0)
+	stale := false
+	for i, configuredVM := range configuredVMs {
+		vm, fetchedAt, ok := u.cache.Get(configuredVM)
+		if !ok {
+			misses = append(misses, configuredVM)
+			continue
+		}
+		cached[i] = vm
+		if now.Sub(fetchedAt) > ttl {
+			stale = true
+		}
+	}
+
+	fetched := make(map[*model.VM]VMListItem, len(misses))
+	var err error
+	if len(misses) > 0 {
+		var live []VMListItem
+		live, err = u.Execute(ctx, misses)
+		for _, item := range live {
+			for _, miss := range misses {
+				if miss.Name == item.VM.Name && miss.Project == item.VM.Project && miss.Zone == item.VM.Zone {
+					fetched[miss] = item
+				}
+			}
+		}
+	}
+
+	items := make([]VMListItem, 0, len(configuredVMs))
+	for i, configuredVM := range configuredVMs {
+		if cached[i] != nil {
+			items = append(items, VMListItem{
+				VM:           cached[i],
+				Uptime:       calculateUptimeString(cached[i], now),
+				StoppedFor:   calculateStoppedForString(cached[i], now),
+				NextSchedule: u.nextSchedule(cached[i], now),
+				UptimeLevel:  cached[i].UptimeLevel(now, u.uptimeWarning),
+			})
+			continue
+		}
+		if item, ok := fetched[configuredVM]; ok {
+			items = append(items, item)
+		}
+	}
+	return items, stale, err
+}
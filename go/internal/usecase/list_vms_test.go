@@ -3,6 +3,8 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -40,7 +42,7 @@ func TestListVMsUseCase_Execute(t *testing.T) {
 					Status:        model.StatusRunning,
 					LastStartTime: timePtr(time.Now().Add(-2 * time.Hour)),
 				}
-				m.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(vm, nil)
+				m.EXPECT().FindAll(gomock.Any(), gomock.Any()).Return([]*model.VM{vm}, nil)
 			},
 			wantLen:             1,
 			wantUptimeAvailable: []bool{true},
@@ -60,7 +62,7 @@ func TestListVMsUseCase_Execute(t *testing.T) {
 					Status:        model.StatusStopped,
 					LastStartTime: nil,
 				}
-				m.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(vm, nil)
+				m.EXPECT().FindAll(gomock.Any(), gomock.Any()).Return([]*model.VM{vm}, nil)
 			},
 			wantLen:             1,
 			wantUptimeAvailable: []bool{false},
@@ -92,9 +94,8 @@ func TestListVMsUseCase_Execute(t *testing.T) {
 					},
 				}
 				m.EXPECT().
-					FindByName(gomock.Any(), gomock.Any()).
-					Times(2).
-					DoAndReturn(func(ctx context.Context, vm *model.VM) (*model.VM, error) {
+					FindAll(gomock.Any(), gomock.Any()).
+					DoAndReturn(findAllBy(func(vm *model.VM) (*model.VM, error) {
 						switch vm.Name {
 						case "running-vm":
 							return vms[0], nil
@@ -103,7 +104,7 @@ func TestListVMsUseCase_Execute(t *testing.T) {
 						default:
 							return nil, errors.New("unexpected VM")
 						}
-					})
+					}))
 			},
 			wantLen:             2,
 			wantUptimeAvailable: []bool{true, false},
@@ -113,7 +114,7 @@ func TestListVMsUseCase_Execute(t *testing.T) {
 			name: "partial results with repository error",
 			configured: []*model.VM{
 				{Name: "running-vm", Project: "test-project", Zone: "us-central1-a"},
-				{Name: "missing-vm", Project: "test-project", Zone: "us-west1-a"},
+				{Name: "missing-vm", Project: "other-project", Zone: "us-west1-a"},
 			},
 			setupMock: func(m *mock_repository.MockVMRepository) {
 				runningVM := &model.VM{
@@ -125,9 +126,9 @@ func TestListVMsUseCase_Execute(t *testing.T) {
 					LastStartTime: timePtr(time.Now().Add(-30 * time.Minute)),
 				}
 				m.EXPECT().
-					FindByName(gomock.Any(), gomock.Any()).
+					FindAll(gomock.Any(), gomock.Any()).
 					Times(2).
-					DoAndReturn(func(ctx context.Context, vm *model.VM) (*model.VM, error) {
+					DoAndReturn(findAllBy(func(vm *model.VM) (*model.VM, error) {
 						switch vm.Name {
 						case "running-vm":
 							return runningVM, nil
@@ -136,19 +137,31 @@ func TestListVMsUseCase_Execute(t *testing.T) {
 						default:
 							return nil, errors.New("unexpected VM")
 						}
-					})
+					}))
 			},
 			wantLen:             1,
 			wantUptimeAvailable: []bool{true},
 			wantError:           true,
 		},
+		{
+			name: "VM missing from batch result is not found",
+			configured: []*model.VM{
+				{Name: "deleted-vm", Project: "test-project", Zone: "us-central1-a"},
+			},
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				m.EXPECT().FindAll(gomock.Any(), gomock.Any()).Return([]*model.VM{nil}, nil)
+			},
+			wantLen:             0,
+			wantUptimeAvailable: nil,
+			wantError:           true,
+		},
 		{
 			name: "repository error",
 			configured: []*model.VM{
 				{Name: "error-vm", Project: "test-project", Zone: "us-central1-a"},
 			},
 			setupMock: func(m *mock_repository.MockVMRepository) {
-				m.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(nil, errTestList)
+				m.EXPECT().FindAll(gomock.Any(), gomock.Any()).Return(nil, errTestList)
 			},
 			wantLen:             0,
 			wantUptimeAvailable: nil,
@@ -194,7 +207,7 @@ func TestListVMsUseCase_ExecuteLimitsConcurrentLookups(t *testing.T) {
 
 	configured := make([]*model.VM, maxConcurrentVMLookups+1)
 	for i := range configured {
-		configured[i] = &model.VM{Name: "test-vm", Project: "test-project", Zone: "us-central1-a"}
+		configured[i] = &model.VM{Name: "test-vm", Project: fmt.Sprintf("project-%d", i), Zone: "us-central1-a"}
 	}
 
 	var inFlight int32
@@ -202,9 +215,10 @@ func TestListVMsUseCase_ExecuteLimitsConcurrentLookups(t *testing.T) {
 	release := make(chan struct{})
 	mockRepo := mock_repository.NewMockVMRepository(ctrl)
 	mockRepo.EXPECT().
-		FindByName(gomock.Any(), gomock.Any()).
+		FindAll(gomock.Any(), gomock.Any()).
 		Times(len(configured)).
-		DoAndReturn(func(ctx context.Context, vm *model.VM) (*model.VM, error) {
+		DoAndReturn(func(ctx context.Context, vms []*model.VM) ([]*model.VM, error) {
+			vm := vms[0]
 			current := atomic.AddInt32(&inFlight, 1)
 			for {
 				previous := atomic.LoadInt32(&maxInFlight)
@@ -214,14 +228,14 @@ func TestListVMsUseCase_ExecuteLimitsConcurrentLookups(t *testing.T) {
 			}
 			<-release
 			atomic.AddInt32(&inFlight, -1)
-			return &model.VM{
+			return []*model.VM{{
 				Name:          vm.Name,
 				Project:       vm.Project,
 				Zone:          vm.Zone,
 				MachineType:   "e2-medium",
 				Status:        model.StatusRunning,
 				LastStartTime: timePtr(time.Now().Add(-30 * time.Minute)),
-			}, nil
+			}}, nil
 		})
 
 	done := make(chan error, 1)
@@ -242,7 +256,158 @@ func TestListVMsUseCase_ExecuteLimitsConcurrentLookups(t *testing.T) {
 	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(maxConcurrentVMLookups))
 }
 
+func TestListVMsUseCase_WithMaxConcurrency(t *testing.T) {
+	repo := mock_repository.NewMockVMRepository(gomock.NewController(t))
+
+	assert.Equal(t, 3, NewListVMsUseCase(repo).WithMaxConcurrency(3).maxConcurrency)
+	assert.Equal(t, maxConcurrentVMLookups, NewListVMsUseCase(repo).WithMaxConcurrency(0).maxConcurrency,
+		"non-positive values keep the default")
+}
+
+func TestListVMsUseCase_StreamReportsEachResult(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	configured := []*model.VM{
+		{Name: "ok-vm", Project: "test-project", Zone: "us-central1-a"},
+		{Name: "bad-vm", Project: "other-project", Zone: "us-central1-a"},
+	}
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+	mockRepo.EXPECT().
+		FindAll(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(findAllBy(func(vm *model.VM) (*model.VM, error) {
+			if vm.Name == "bad-vm" {
+				return nil, errTestList
+			}
+			return &model.VM{Name: vm.Name, Status: model.StatusStopped}, nil
+		}))
+
+	var mu sync.Mutex
+	reported := map[int]error{}
+	items, err := NewListVMsUseCase(mockRepo).Stream(context.Background(), configured, func(i int, item VMListItem, itemErr error) {
+		mu.Lock()
+		defer mu.Unlock()
+		reported[i] = itemErr
+		if itemErr == nil {
+			assert.Equal(t, configured[i].Name, item.VM.Name)
+		}
+	})
+
+	require.ErrorIs(t, err, errTestList)
+	require.Len(t, items, 1)
+	require.Len(t, reported, 2)
+	assert.NoError(t, reported[0])
+	assert.ErrorIs(t, reported[1], errTestList)
+}
+
+func TestListVMsUseCase_WithUptimeWarning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	configured := []*model.VM{
+		{Name: "fresh", Project: "p", Zone: "z"},
+		{Name: "forgotten", Project: "p", Zone: "z"},
+		{Name: "stopped", Project: "p", Zone: "z"},
+	}
+	started := map[string]time.Duration{"fresh": time.Hour, "forgotten": 100 * time.Hour}
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+	mockRepo.EXPECT().
+		FindAll(gomock.Any(), gomock.Any()).
+		DoAndReturn(findAllBy(func(vm *model.VM) (*model.VM, error) {
+			uptime, ok := started[vm.Name]
+			if !ok {
+				return &model.VM{Name: vm.Name, Status: model.StatusTerminated}, nil
+			}
+			return &model.VM{Name: vm.Name, Status: model.StatusRunning, LastStartTime: timePtr(time.Now().Add(-uptime))}, nil
+		}))
+
+	items, err := NewListVMsUseCase(mockRepo).WithUptimeWarning(72*time.Hour).Execute(context.Background(), configured)
+
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	assert.Equal(t, model.UptimeNormal, items[0].UptimeLevel)
+	assert.Equal(t, model.UptimeWarning, items[1].UptimeLevel)
+	assert.Equal(t, model.UptimeNormal, items[2].UptimeLevel)
+
+	long := LongRunning(items)
+	require.Len(t, long, 1)
+	assert.Equal(t, "forgotten", long[0].VM.Name)
+}
+
+// findAllBy adapts a per-VM lookup to the FindAll signature for mock expectations.
+// The whole batch fails if any lookup fails, as a single API call would.
+func findAllBy(lookup func(vm *model.VM) (*model.VM, error)) func(context.Context, []*model.VM) ([]*model.VM, error) {
+	return func(_ context.Context, vms []*model.VM) ([]*model.VM, error) {
+		found := make([]*model.VM, len(vms))
+		for i, vm := range vms {
+			v, err := lookup(vm)
+			if err != nil {
+				return nil, err
+			}
+			found[i] = v
+		}
+		return found, nil
+	}
+}
+
 // Helper function to create time pointers
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
+
+func TestListVMsUseCase_ExecuteCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hit := &model.VM{Name: "cached-vm", Project: "test-project", Zone: "us-central1-a"}
+	miss := &model.VM{Name: "new-vm", Project: "test-project", Zone: "us-central1-a"}
+	cachedState := &model.VM{Name: "cached-vm", Project: "test-project", Zone: "us-central1-a", Status: model.StatusStopped}
+	liveState := &model.VM{Name: "new-vm", Project: "test-project", Zone: "us-central1-a", Status: model.StatusStopped}
+
+	tests := []struct {
+		name      string
+		fetchedAt time.Time
+		wantStale bool
+	}{
+		{name: "fresh entry", fetchedAt: time.Now().Add(-time.Minute), wantStale: false},
+		{name: "stale entry", fetchedAt: time.Now().Add(-time.Hour), wantStale: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mock_repository.NewMockVMRepository(ctrl)
+			mockCache := mock_repository.NewMockVMStateCache(ctrl)
+			mockCache.EXPECT().Get(hit).Return(cachedState, tt.fetchedAt, true)
+			mockCache.EXPECT().Get(miss).Return(nil, time.Time{}, false)
+			mockRepo.EXPECT().FindAll(gomock.Any(), []*model.VM{miss}).Return([]*model.VM{liveState}, nil)
+			mockCache.EXPECT().Put([]*model.VM{liveState}, gomock.Any()).Return(nil)
+
+			items, stale, err := NewListVMsUseCase(mockRepo).WithCache(mockCache).
+				ExecuteCached(context.Background(), []*model.VM{hit, miss}, 5*time.Minute)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStale, stale)
+			require.Len(t, items, 2)
+			assert.Same(t, cachedState, items[0].VM)
+			assert.Same(t, liveState, items[1].VM)
+		})
+	}
+}
+
+func TestListVMsUseCase_ExecuteStoresResultsInCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	found := &model.VM{Name: "vm-1", Project: "test-project", Zone: "us-central1-a", Status: model.StatusStopped}
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+	mockRepo.EXPECT().FindAll(gomock.Any(), gomock.Any()).Return([]*model.VM{found}, nil)
+	mockCache := mock_repository.NewMockVMStateCache(ctrl)
+	mockCache.EXPECT().Put([]*model.VM{found}, gomock.Any()).Return(errors.New("disk full"))
+
+	items, err := NewListVMsUseCase(mockRepo).WithCache(mockCache).
+		Execute(context.Background(), []*model.VM{{Name: "vm-1", Project: "test-project", Zone: "us-central1-a"}})
+
+	require.NoError(t, err, "cache write failures do not fail the listing")
+	assert.Len(t, items, 1)
+}
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+)
+
+// ListZonesUseCase lists the zones of a project.
+type ListZonesUseCase struct {
+	repo repository.RegionRepository
+}
+
+// NewListZonesUseCase creates a new ListZonesUseCase instance.
+func NewListZonesUseCase(repo repository.RegionRepository) *ListZonesUseCase {
+	return &ListZonesUseCase{repo: repo}
+}
+
+// Execute returns the zones of project, ordered by name.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - project: The project to list zones of
+//   - region: Only list the zones of this region (e.g., "us-central1"); empty lists all zones
+//
+// Returns:
+//   - []*model.Zone: The zones in name order
+//   - error: Error if listing fails
+//
+// Example:
+//
+//	useCase := NewListZonesUseCase(repo)
+//	zones, err := useCase.Execute(ctx, "my-project", "us-central1")
+func (u *ListZonesUseCase) Execute(ctx context.Context, project, region string) ([]*model.Zone, error) {
+	zones, err := u.repo.ListZones(ctx, project, region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zones: %w", err)
+	}
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].Name < zones[j].Name
+	})
+	return zones, nil
+}
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListZonesUseCase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repository.NewMockRegionRepository(ctrl)
+	repo.EXPECT().ListZones(gomock.Any(), "proj", "us-central1").Return([]*model.Zone{
+		{Name: "us-central1-c", Region: "us-central1"},
+		{Name: "us-central1-a", Region: "us-central1"},
+	}, nil)
+
+	zones, err := NewListZonesUseCase(repo).Execute(context.Background(), "proj", "us-central1")
+	require.NoError(t, err)
+	assert.Equal(t, []*model.Zone{
+		{Name: "us-central1-a", Region: "us-central1"},
+		{Name: "us-central1-c", Region: "us-central1"},
+	}, zones)
+
+	repo.EXPECT().ListZones(gomock.Any(), "proj", "").Return(nil, errors.New("denied"))
+	_, err = NewListZonesUseCase(repo).Execute(context.Background(), "proj", "")
+	require.Error(t, err)
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+)
+
+// LocateVMsUseCase finds the zones of configured VMs whose zone is unknown.
+type LocateVMsUseCase struct {
+	repo repository.VMRepository
+}
+
+// NewLocateVMsUseCase creates a new LocateVMsUseCase instance.
+func NewLocateVMsUseCase(repo repository.VMRepository) *LocateVMsUseCase {
+	return &LocateVMsUseCase{repo: repo}
+}
+
+// Execute sets the Zone of every VM in vms without one to the zone its instance
+// is in, searching all zones of the VM's project with one aggregated list per
+// project. VMs that already have a zone are left untouched.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - vms: VMs loaded from config, updated in place
+//
+// Returns:
+//   - []*model.VM: The VMs whose zone was filled in
+//   - error: Joined error for the projects that could not be listed and the
+//     VMs not found in their project
+func (u *LocateVMsUseCase) Execute(ctx context.Context, vms []*model.VM) ([]*model.VM, error) {
+	var projects []string
+	unknown := make(map[string][]*model.VM)
+	for _, vm := range vms {
+		if vm.Zone != "" {
+			continue
+		}
+		if _, ok := unknown[vm.Project]; !ok {
+			projects = append(projects, vm.Project)
+		}
+		unknown[vm.Project] = append(unknown[vm.Project], vm)
+	}
+
+	var located []*model.VM
+	var errs []error
+	for _, project := range projects {
+		instances, err := u.repo.ListByProject(ctx, project, nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", project, err))
+			continue
+		}
+		zones := make(map[string]string, len(instances))
+		for _, instance := range instances {
+			zones[instance.Name] = instance.Zone
+		}
+		for _, vm := range unknown[project] {
+			zone, ok := zones[vm.Name]
+			if !ok {
+				errs = append(errs, fmt.Errorf("VM %s not found in any zone of %s", vm.Name, project))
+				continue
+			}
+			vm.Zone = zone
+			located = append(located, vm)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return located, fmt.Errorf("failed to look up zones: %w", err)
+	}
+	return located, nil
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLocateVMsUseCase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	known := &model.VM{Name: "known", Project: "p1", Zone: "us-central1-a"}
+	web := &model.VM{Name: "web", Project: "p1"}
+	db := &model.VM{Name: "db", Project: "p2"}
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+	mockRepo.EXPECT().ListByProject(gomock.Any(), "p1", gomock.Nil()).Return([]*model.VM{
+		{Name: "known", Project: "p1", Zone: "us-central1-a"},
+		{Name: "web", Project: "p1", Zone: "asia-northeast1-b"},
+	}, nil)
+	mockRepo.EXPECT().ListByProject(gomock.Any(), "p2", gomock.Nil()).Return([]*model.VM{
+		{Name: "db", Project: "p2", Zone: "europe-west1-c"},
+	}, nil)
+
+	located, err := NewLocateVMsUseCase(mockRepo).Execute(context.Background(), []*model.VM{known, web, db})
+	require.NoError(t, err)
+	assert.Equal(t, []*model.VM{web, db}, located)
+	assert.Equal(t, "asia-northeast1-b", web.Zone)
+	assert.Equal(t, "europe-west1-c", db.Zone)
+	assert.Equal(t, "us-central1-a", known.Zone)
+}
+
+func TestLocateVMsUseCase_ExecuteErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	missing := &model.VM{Name: "missing", Project: "p1"}
+	denied := &model.VM{Name: "db", Project: "p2"}
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+	mockRepo.EXPECT().ListByProject(gomock.Any(), "p1", gomock.Nil()).Return([]*model.VM{{Name: "web", Project: "p1", Zone: "z"}}, nil)
+	mockRepo.EXPECT().ListByProject(gomock.Any(), "p2", gomock.Nil()).Return(nil, errors.New("permission denied"))
+
+	located, err := NewLocateVMsUseCase(mockRepo).Execute(context.Background(), []*model.VM{missing, denied})
+	require.Error(t, err)
+	assert.Empty(t, located)
+	assert.Contains(t, err.Error(), "VM missing not found in any zone of p1")
+	assert.Contains(t, err.Error(), "p2: permission denied")
+	assert.Empty(t, missing.Zone)
+}
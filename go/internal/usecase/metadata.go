@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// MetadataUseCase handles reading and changing metadata items of a VM
+type MetadataUseCase struct {
+	vmRepo repository.VMRepository
+	logger log.Logger
+}
+
+// NewMetadataUseCase creates a new instance of MetadataUseCase
+func NewMetadataUseCase(vmRepo repository.VMRepository, logger log.Logger) *MetadataUseCase {
+	return &MetadataUseCase{vmRepo: vmRepo, logger: logger}
+}
+
+// Get returns the value of the metadata item key of a VM.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - project: The GCP project ID
+//   - zone: The GCP zone
+//   - name: The VM instance name
+//   - key: The metadata key
+//
+// Returns:
+//   - string: The value of the item
+//   - error: nil on success, otherwise an error (including when the VM has no such item)
+//
+// Example:
+//
+//	usecase := NewMetadataUseCase(vmRepo, logger)
+//	script, err := usecase.Get(ctx, "my-project", "us-central1-a", "my-vm", model.StartupScriptKey)
+func (uc *MetadataUseCase) Get(ctx context.Context, project, zone, name, key string) (string, error) {
+	items, err := uc.vmRepo.GetMetadata(ctx, &model.VM{Project: project, Zone: zone, Name: name})
+	if err != nil {
+		return "", fmt.Errorf("failed to get metadata: %w", err)
+	}
+	value, ok := items[key]
+	if !ok {
+		return "", fmt.Errorf("VM %s has no metadata %s", name, key)
+	}
+	return value, nil
+}
+
+// Set adds or overwrites metadata items of a VM, keeping all other items.
+//
+// Keys and value sizes are validated before anything is changed, and the total size
+// of the resulting metadata is checked against the GCE limit.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - project: The GCP project ID
+//   - zone: The GCP zone
+//   - name: The VM instance name
+//   - items: The metadata items to set
+//
+// Returns:
+//   - error: nil on success, otherwise an error describing what went wrong
+//
+// Example:
+//
+//	usecase := NewMetadataUseCase(vmRepo, logger)
+//	err := usecase.Set(ctx, "my-project", "us-central1-a", "my-vm", map[string]string{"enable-oslogin": "TRUE"})
+func (uc *MetadataUseCase) Set(ctx context.Context, project, zone, name string, items map[string]string) error {
+	// 1. 入力チェック
+	if len(items) == 0 {
+		return errors.New("no metadata to set")
+	}
+	for key, value := range items {
+		if err := model.ValidateMetadataItem(key, value); err != nil {
+			return err
+		}
+	}
+
+	// 2. 現在のメタデータを取得してサイズ上限を確認
+	vm := &model.VM{Project: project, Zone: zone, Name: name}
+	current, err := uc.vmRepo.GetMetadata(ctx, vm)
+	if err != nil {
+		return fmt.Errorf("failed to get metadata: %w", err)
+	}
+	merged := make(map[string]string, len(current)+len(items))
+	for k, v := range current {
+		merged[k] = v
+	}
+	for k, v := range items {
+		merged[k] = v
+	}
+	if size := model.MetadataSize(merged); size > model.MaxMetadataBytes {
+		return fmt.Errorf("metadata of VM %s would be %d bytes, at most %d are allowed", name, size, model.MaxMetadataBytes)
+	}
+
+	// 3. メタデータを更新
+	if err = uc.vmRepo.UpdateMetadata(ctx, vm, items, nil); err != nil {
+		return fmt.Errorf("failed to set metadata: %w", err)
+	}
+
+	uc.logger.Infof("✓ Successfully set metadata for VM %s", name)
+	return nil
+}
+
+// Remove deletes metadata items of a VM, keeping all other items.
+// Removing an item the VM does not have is an error, so typos in keys are noticed.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - project: The GCP project ID
+//   - zone: The GCP zone
+//   - name: The VM instance name
+//   - keys: The keys of the items to remove
+//
+// Returns:
+//   - error: nil on success, otherwise an error describing what went wrong
+//
+// Example:
+//
+//	usecase := NewMetadataUseCase(vmRepo, logger)
+//	err := usecase.Remove(ctx, "my-project", "us-central1-a", "my-vm", []string{model.StartupScriptKey})
+func (uc *MetadataUseCase) Remove(ctx context.Context, project, zone, name string, keys []string) error {
+	// 1. 入力チェック
+	if len(keys) == 0 {
+		return errors.New("no metadata to remove")
+	}
+
+	// 2. 削除対象が存在するか確認
+	vm := &model.VM{Project: project, Zone: zone, Name: name}
+	current, err := uc.vmRepo.GetMetadata(ctx, vm)
+	if err != nil {
+		return fmt.Errorf("failed to get metadata: %w", err)
+	}
+	var missing []string
+	for _, key := range keys {
+		if _, ok := current[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("VM %s has no metadata %s", name, strings.Join(missing, ", "))
+	}
+
+	// 3. メタデータを更新
+	if err = uc.vmRepo.UpdateMetadata(ctx, vm, nil, keys); err != nil {
+		return fmt.Errorf("failed to remove metadata: %w", err)
+	}
+
+	uc.logger.Infof("✓ Successfully removed metadata for VM %s", name)
+	return nil
+}
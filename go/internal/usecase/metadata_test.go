@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var loggerForMetadata = log.NewLogger()
+
+func currentMetadata() map[string]string {
+	return map[string]string{"enable-oslogin": "TRUE", model.StartupScriptKey: "#!/bin/bash\necho hi\n"}
+}
+
+func TestMetadataUseCase_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repository.NewMockVMRepository(ctrl)
+	repo.EXPECT().GetMetadata(gomock.Any(), gomock.Any()).Return(currentMetadata(), nil).Times(2)
+	repo.EXPECT().GetMetadata(gomock.Any(), gomock.Any()).Return(nil, errors.New("not found"))
+
+	uc := NewMetadataUseCase(repo, loggerForMetadata)
+	got, err := uc.Get(context.Background(), "proj", "z", "vm-1", model.StartupScriptKey)
+	require.NoError(t, err)
+	assert.Equal(t, "#!/bin/bash\necho hi\n", got)
+
+	_, err = uc.Get(context.Background(), "proj", "z", "vm-1", "team")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has no metadata team")
+
+	_, err = uc.Get(context.Background(), "proj", "z", "vm-1", "team")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get metadata")
+}
+
+func TestMetadataUseCase_Set(t *testing.T) {
+	//nolint:govet // Test struct field order optimized for readability
+	tests := []struct {
+		name        string
+		items       map[string]string
+		current     map[string]string
+		updateErr   error
+		wantGet     bool
+		wantUpdate  bool
+		errContains string
+	}{
+		{name: "success: add item", items: map[string]string{"team": "ml"}, current: currentMetadata(), wantGet: true, wantUpdate: true},
+		{name: "success: replace startup script", items: map[string]string{model.StartupScriptKey: "#!/bin/sh"}, current: currentMetadata(), wantGet: true, wantUpdate: true},
+		{name: "error: nothing to set", errContains: "no metadata"},
+		{name: "error: invalid key", items: map[string]string{"team name": "ml"}, errContains: "invalid metadata key"},
+		{
+			name:    "error: total size exceeded",
+			items:   map[string]string{"b": strings.Repeat("x", model.MaxMetadataValueBytes)},
+			current: map[string]string{"a": strings.Repeat("x", model.MaxMetadataValueBytes)},
+			wantGet: true, errContains: "at most 524288",
+		},
+		{name: "error: repository fails", items: map[string]string{"team": "ml"}, current: currentMetadata(), updateErr: errors.New("denied"), wantGet: true, wantUpdate: true, errContains: "failed to set metadata"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repository.NewMockVMRepository(ctrl)
+			if tt.wantGet {
+				repo.EXPECT().GetMetadata(gomock.Any(), gomock.Any()).Return(tt.current, nil)
+			}
+			if tt.wantUpdate {
+				repo.EXPECT().UpdateMetadata(gomock.Any(), gomock.Any(), tt.items, gomock.Nil()).Return(tt.updateErr)
+			}
+
+			err := NewMetadataUseCase(repo, loggerForMetadata).Set(context.Background(), "proj", "z", "vm-1", tt.items)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMetadataUseCase_Remove(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repository.NewMockVMRepository(ctrl)
+	repo.EXPECT().GetMetadata(gomock.Any(), gomock.Any()).Return(currentMetadata(), nil).Times(2)
+	repo.EXPECT().UpdateMetadata(gomock.Any(), gomock.Any(), gomock.Nil(), []string{model.StartupScriptKey}).Return(nil)
+
+	uc := NewMetadataUseCase(repo, loggerForMetadata)
+	require.NoError(t, uc.Remove(context.Background(), "proj", "z", "vm-1", []string{model.StartupScriptKey}))
+
+	err := uc.Remove(context.Background(), "proj", "z", "vm-1", []string{"team", "enable-oslogin"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has no metadata team")
+
+	err = uc.Remove(context.Background(), "proj", "z", "vm-1", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no metadata to remove")
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// NotifyEventUseCase delivers events to the configured notifier.
+// Delivery is best-effort: a failing backend never fails the VM operation that triggered it.
+type NotifyEventUseCase struct {
+	notifier repository.Notifier
+	logger   log.Logger
+	now      func() time.Time
+}
+
+// NewNotifyEventUseCase creates a new instance of NotifyEventUseCase
+func NewNotifyEventUseCase(notifier repository.Notifier, logger log.Logger) *NotifyEventUseCase {
+	return &NotifyEventUseCase{notifier: notifier, logger: logger, now: time.Now}
+}
+
+// Execute sends each event in order, stamping the current time on events that have none.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout
+//   - events: The events to send
+//
+// Returns:
+//   - error: nil if every event was delivered, otherwise the joined delivery errors.
+//     Failures are also logged as warnings so callers may ignore the error.
+func (uc *NotifyEventUseCase) Execute(ctx context.Context, events ...model.Event) error {
+	var errs []error
+	for _, event := range events {
+		if event.Time.IsZero() {
+			event.Time = uc.now()
+		}
+		if err := uc.notifier.Notify(ctx, event); err != nil {
+			uc.logger.Warnf("Failed to send %s notification: %v", event.Type, err)
+			errs = append(errs, fmt.Errorf("%s notification: %w", event.Type, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNotifyEventUseCase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fixed := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	explicit := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	mockNotifier := mock_repository.NewMockNotifier(ctrl)
+	gomock.InOrder(
+		mockNotifier.EXPECT().
+			Notify(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, event model.Event) error {
+				assert.Equal(t, fixed, event.Time, "zero time should be stamped")
+				return nil
+			}),
+		mockNotifier.EXPECT().
+			Notify(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, event model.Event) error {
+				assert.Equal(t, explicit, event.Time, "explicit time should be kept")
+				return errors.New("webhook down")
+			}),
+	)
+
+	uc := NewNotifyEventUseCase(mockNotifier, logger)
+	uc.now = func() time.Time { return fixed }
+
+	err := uc.Execute(context.Background(),
+		model.Event{Type: model.EventOperationSuccess, VMName: "vm-1"},
+		model.Event{Type: model.EventOperationFailure, VMName: "vm-2", Time: explicit},
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "operation-failure notification: webhook down")
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+)
+
+// OperationStatsUseCase summarizes how long the operations recorded in the
+// local operation history took, per VM and operation type.
+type OperationStatsUseCase struct {
+	history repository.OperationHistory
+}
+
+// NewOperationStatsUseCase creates a new OperationStatsUseCase instance.
+func NewOperationStatsUseCase(history repository.OperationHistory) *OperationStatsUseCase {
+	return &OperationStatsUseCase{history: history}
+}
+
+// Execute returns the duration statistics of the recorded operations. Only
+// operations that succeeded are timed, as a failed start says little about how
+// long starting takes; failures are counted separately, and operations the user
+// detached from are left out.
+//
+// Parameters:
+//   - names: VM names to summarize, or none for all
+//   - types: Operation types to summarize (e.g., "start"), or none for all
+//   - since: Only operations started at or after since count; the zero time keeps all
+//
+// Returns:
+//   - []*model.OperationStats: One entry per VM and operation type, sorted by VM then type
+//   - error: Error if the history cannot be read
+func (u *OperationStatsUseCase) Execute(names, types []string, since time.Time) ([]*model.OperationStats, error) {
+	records, err := u.history.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the operation history: %w", err)
+	}
+
+	type key struct{ target, typ string }
+	durations := make(map[key][]time.Duration)
+	stats := make(map[key]*model.OperationStats)
+	for _, r := range records {
+		if (len(names) > 0 && !slices.Contains(names, r.Target)) ||
+			(len(types) > 0 && !slices.Contains(types, r.Type)) ||
+			r.Time.Before(since) || r.Running {
+			continue
+		}
+		k := key{r.Target, r.Type}
+		s, ok := stats[k]
+		if !ok {
+			s = &model.OperationStats{Target: r.Target, Type: r.Type}
+			stats[k] = s
+		}
+		if r.Time.After(s.Last) {
+			s.Last = r.Time
+		}
+		if !r.Succeeded() {
+			s.Failed++
+			continue
+		}
+		durations[k] = append(durations[k], r.Duration)
+	}
+
+	result := make([]*model.OperationStats, 0, len(stats))
+	for k, s := range stats {
+		summarizeDurations(s, durations[k])
+		result = append(result, s)
+	}
+	slices.SortFunc(result, func(a, b *model.OperationStats) int {
+		return cmp.Or(cmp.Compare(a.Target, b.Target), cmp.Compare(a.Type, b.Type))
+	})
+	return result, nil
+}
+
+// summarizeDurations sets the count, average, median, minimum and maximum of
+// s from durations, leaving them zero when there are none.
+func summarizeDurations(s *model.OperationStats, durations []time.Duration) {
+	s.Count = len(durations)
+	if s.Count == 0 {
+		return
+	}
+	slices.Sort(durations)
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+	s.Average = total / time.Duration(s.Count)
+	s.Min = durations[0]
+	s.Max = durations[s.Count-1]
+	s.Median = durations[s.Count/2]
+	if s.Count%2 == 0 {
+		s.Median = (durations[s.Count/2-1] + durations[s.Count/2]) / 2
+	}
+}
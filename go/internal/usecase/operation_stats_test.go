@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOperationStatsUseCase_Execute(t *testing.T) {
+	at := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+	records := []*model.OperationRecord{
+		{Time: at, Target: "vm-2", Type: "start", Duration: 90 * time.Second},
+		{Time: at, Target: "vm-1", Type: "start", Duration: 40 * time.Second},
+		{Time: at.Add(24 * time.Hour), Target: "vm-1", Type: "start", Duration: 60 * time.Second},
+		{Time: at.Add(48 * time.Hour), Target: "vm-1", Type: "start", Duration: 5 * time.Second, Error: "ZONE_RESOURCE_POOL_EXHAUSTED"},
+		{Time: at.Add(72 * time.Hour), Target: "vm-1", Type: "start", Duration: 110 * time.Second},
+		{Time: at.Add(72 * time.Hour), Target: "vm-1", Type: "stop", Duration: 30 * time.Second},
+		{Time: at.Add(96 * time.Hour), Target: "vm-1", Type: "start", Duration: time.Second, Running: true},
+	}
+
+	t.Run("success: summarizes per VM and type", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		history := mock_repository.NewMockOperationHistory(ctrl)
+		history.EXPECT().List().Return(records, nil)
+
+		got, err := NewOperationStatsUseCase(history).Execute(nil, nil, time.Time{})
+
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+		assert.Equal(t, &model.OperationStats{
+			Last:    at.Add(72 * time.Hour),
+			Target:  "vm-1",
+			Type:    "start",
+			Count:   3,
+			Failed:  1,
+			Average: 70 * time.Second,
+			Median:  60 * time.Second,
+			Min:     40 * time.Second,
+			Max:     110 * time.Second,
+		}, got[0])
+		assert.Equal(t, "stop", got[1].Type)
+		assert.Equal(t, "vm-2", got[2].Target)
+	})
+
+	t.Run("success: filters by VM, type and time", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		history := mock_repository.NewMockOperationHistory(ctrl)
+		history.EXPECT().List().Return(records, nil)
+
+		got, err := NewOperationStatsUseCase(history).Execute([]string{"vm-1"}, []string{"start"}, at.Add(time.Hour))
+
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, 2, got[0].Count)
+		assert.Equal(t, 85*time.Second, got[0].Median, "the median of an even count is the mean of the middle two")
+	})
+
+	t.Run("error: history cannot be read", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		history := mock_repository.NewMockOperationHistory(ctrl)
+		history.EXPECT().List().Return(nil, errors.New("permission denied"))
+
+		_, err := NewOperationStatsUseCase(history).Execute(nil, nil, time.Time{})
+
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+const defaultSerialPollInterval = 2 * time.Second
+
+// ReadSerialOutputUseCase handles printing and following the serial port output of a VM
+type ReadSerialOutputUseCase struct {
+	serialRepo   repository.SerialPortRepository
+	logger       log.Logger
+	pollInterval time.Duration
+}
+
+// NewReadSerialOutputUseCase creates a new instance of ReadSerialOutputUseCase
+func NewReadSerialOutputUseCase(serialRepo repository.SerialPortRepository, logger log.Logger) *ReadSerialOutputUseCase {
+	return &ReadSerialOutputUseCase{serialRepo: serialRepo, logger: logger, pollInterval: defaultSerialPollInterval}
+}
+
+// Execute passes the buffered serial port output of a VM to emit and, when follow is
+// set, keeps polling for new output until ctx is done.
+//
+// The VM keeps only the last 1 MB of output. If output is discarded between two polls,
+// a warning is logged and emitting continues with the oldest output still available.
+//
+// Parameters:
+//   - ctx: The context for the operation; cancelling it ends following without an error
+//   - project: The GCP project ID
+//   - zone: The GCP zone
+//   - name: The VM instance name
+//   - port: The serial port number (1-4)
+//   - follow: Whether to keep polling for new output
+//   - emit: Called with each chunk of output, in order
+//
+// Returns:
+//   - error: nil on success (or when following is interrupted), otherwise the first read error
+//
+// Example:
+//
+//	usecase := NewReadSerialOutputUseCase(serialRepo, logger)
+//	err := usecase.Execute(ctx, "my-project", "us-central1-a", "my-vm", 1, true, console.RenderText)
+func (uc *ReadSerialOutputUseCase) Execute(ctx context.Context, project, zone, name string, port int32, follow bool, emit func(string)) error {
+	// 1. 入力チェック
+	if port < 1 || port > 4 {
+		return fmt.Errorf("serial port must be between 1 and 4: %d", port)
+	}
+
+	// 2. バッファ済みの出力を表示し、followの場合は新しい出力をポーリング
+	vm := &model.VM{Project: project, Zone: zone, Name: name}
+	ticker := time.NewTicker(uc.pollInterval)
+	defer ticker.Stop()
+
+	var start int64
+	for {
+		output, err := uc.serialRepo.Read(ctx, vm, port, start)
+		if err != nil {
+			if follow && ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to read serial port output: %w", err)
+		}
+		if start > 0 && output.Start > start {
+			uc.logger.Warnf("%d bytes of serial port output were discarded before they could be read", output.Start-start)
+		}
+		if output.Contents != "" {
+			emit(output.Contents)
+		}
+		start = output.Next
+
+		if !follow {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
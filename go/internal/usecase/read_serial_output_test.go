@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var loggerForReadSerialOutput = log.NewLogger()
+
+func TestReadSerialOutputUseCase_Execute(t *testing.T) {
+	t.Run("success: print buffered output once", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockSerialPortRepository(ctrl)
+		repo.EXPECT().Read(gomock.Any(), &model.VM{Project: "proj", Zone: "z", Name: "vm-1"}, int32(1), int64(0)).
+			Return(&model.SerialOutput{Contents: "Booting...\n", Next: 11}, nil)
+
+		var out strings.Builder
+		err := NewReadSerialOutputUseCase(repo, loggerForReadSerialOutput).
+			Execute(context.Background(), "proj", "z", "vm-1", 1, false, func(s string) { out.WriteString(s) })
+		require.NoError(t, err)
+		assert.Equal(t, "Booting...\n", out.String())
+	})
+
+	t.Run("success: follow continues from next until cancelled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		repo := mock_repository.NewMockSerialPortRepository(ctrl)
+		gomock.InOrder(
+			repo.EXPECT().Read(gomock.Any(), gomock.Any(), int32(2), int64(0)).
+				Return(&model.SerialOutput{Contents: "a\n", Next: 2}, nil),
+			repo.EXPECT().Read(gomock.Any(), gomock.Any(), int32(2), int64(2)).
+				Return(&model.SerialOutput{Start: 2, Next: 2}, nil),
+			repo.EXPECT().Read(gomock.Any(), gomock.Any(), int32(2), int64(2)).
+				DoAndReturn(func(context.Context, *model.VM, int32, int64) (*model.SerialOutput, error) {
+					cancel()
+					return &model.SerialOutput{Contents: "b\n", Start: 10, Next: 12}, nil
+				}),
+		)
+
+		var out strings.Builder
+		uc := NewReadSerialOutputUseCase(repo, loggerForReadSerialOutput)
+		uc.pollInterval = time.Millisecond
+		err := uc.Execute(ctx, "proj", "z", "vm-1", 2, true, func(s string) { out.WriteString(s) })
+		require.NoError(t, err)
+		assert.Equal(t, "a\nb\n", out.String())
+	})
+
+	t.Run("error: invalid port", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		err := NewReadSerialOutputUseCase(mock_repository.NewMockSerialPortRepository(ctrl), loggerForReadSerialOutput).
+			Execute(context.Background(), "proj", "z", "vm-1", 5, false, func(string) {})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "between 1 and 4")
+	})
+
+	t.Run("error: read fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockSerialPortRepository(ctrl)
+		repo.EXPECT().Read(gomock.Any(), gomock.Any(), int32(1), int64(0)).Return(nil, errors.New("not found"))
+
+		err := NewReadSerialOutputUseCase(repo, loggerForReadSerialOutput).
+			Execute(context.Background(), "proj", "z", "vm-1", 1, true, func(string) {})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read serial port output")
+	})
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+)
+
+// ReconcileReport lists the differences between the config file and the instances in GCP.
+type ReconcileReport struct {
+	// Projects are the projects whose instances were listed.
+	Projects []string
+	// Unmanaged are instances in GCP that are not in the config file.
+	Unmanaged []*model.VM
+	// Stale are config entries without a matching instance in GCP.
+	Stale []*model.VM
+}
+
+// ReconcileVMsUseCase compares the configured VMs with the instances that exist in GCP.
+type ReconcileVMsUseCase struct {
+	repo repository.VMRepository
+}
+
+// NewReconcileVMsUseCase creates a new ReconcileVMsUseCase instance.
+func NewReconcileVMsUseCase(repo repository.VMRepository) *ReconcileVMsUseCase {
+	return &ReconcileVMsUseCase{repo: repo}
+}
+
+// Execute lists every instance in the default project and the projects of
+// configuredVMs and diffs them against configuredVMs by project, zone and name.
+//
+// A project that cannot be listed is left out of the report, so its config
+// entries are never reported as stale.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - defaultProject: The default project of the config file, may be empty
+//   - configuredVMs: VMs loaded from config
+//
+// Returns:
+//   - *ReconcileReport: The differences in the projects that could be listed
+//   - error: Joined error for the projects that could not be listed
+func (u *ReconcileVMsUseCase) Execute(ctx context.Context, defaultProject string, configuredVMs []*model.VM) (*ReconcileReport, error) {
+	report := &ReconcileReport{}
+	configured := make(map[string]bool, len(configuredVMs))
+	for _, vm := range configuredVMs {
+		configured[vmKey(vm)] = true
+	}
+
+	var errs []error
+	existing := make(map[string]bool)
+	listed := make(map[string]bool)
+	for _, project := range knownProjects(defaultProject, configuredVMs) {
+		instances, err := u.repo.ListByProject(ctx, project, nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", project, err))
+			continue
+		}
+		listed[project] = true
+		report.Projects = append(report.Projects, project)
+		for _, instance := range instances {
+			existing[vmKey(instance)] = true
+			if !configured[vmKey(instance)] {
+				report.Unmanaged = append(report.Unmanaged, instance)
+			}
+		}
+	}
+
+	for _, vm := range configuredVMs {
+		if listed[vm.Project] && !existing[vmKey(vm)] {
+			report.Stale = append(report.Stale, vm)
+		}
+	}
+	return report, errors.Join(errs...)
+}
+
+// knownProjects returns defaultProject followed by the other projects of vms, without duplicates.
+func knownProjects(defaultProject string, vms []*model.VM) []string {
+	seen := make(map[string]bool)
+	var projects []string
+	add := func(project string) {
+		if project != "" && !seen[project] {
+			seen[project] = true
+			projects = append(projects, project)
+		}
+	}
+	add(defaultProject)
+	for _, vm := range vms {
+		add(vm.Project)
+	}
+	return projects
+}
+
+func vmKey(vm *model.VM) string {
+	return vm.Project + "/" + vm.Zone + "/" + vm.Name
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReconcileVMsUseCase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	configured := []*model.VM{
+		{Name: "web", Project: "p1", Zone: "us-central1-a"},
+		{Name: "deleted", Project: "p1", Zone: "us-central1-a"},
+		{Name: "moved", Project: "p1", Zone: "us-central1-a"},
+		{Name: "db", Project: "p2", Zone: "us-east1-b"},
+	}
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+	mockRepo.EXPECT().ListByProject(gomock.Any(), "p1", nil).Return([]*model.VM{
+		{Name: "web", Project: "p1", Zone: "us-central1-a"},
+		{Name: "moved", Project: "p1", Zone: "us-west1-b"},
+		{Name: "scratch", Project: "p1", Zone: "us-central1-a"},
+	}, nil)
+	mockRepo.EXPECT().ListByProject(gomock.Any(), "p2", nil).Return(nil, errors.New("permission denied"))
+
+	report, err := NewReconcileVMsUseCase(mockRepo).Execute(context.Background(), "p1", configured)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "p2: permission denied")
+
+	assert.Equal(t, []string{"p1"}, report.Projects)
+	require.Len(t, report.Unmanaged, 2)
+	assert.Equal(t, "moved", report.Unmanaged[0].Name)
+	assert.Equal(t, "scratch", report.Unmanaged[1].Name)
+	require.Len(t, report.Stale, 2, "entries of unlisted projects are not stale")
+	assert.Equal(t, "deleted", report.Stale[0].Name)
+	assert.Equal(t, "moved", report.Stale[1].Name)
+}
+
+func TestKnownProjects(t *testing.T) {
+	vms := []*model.VM{{Project: "p2"}, {Project: "p1"}, {Project: "p2"}}
+	assert.Equal(t, []string{"p1", "p2"}, knownProjects("p1", vms))
+	assert.Equal(t, []string{"p2", "p1"}, knownProjects("", vms))
+}
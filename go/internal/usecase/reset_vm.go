@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// ResetVMUseCase hard resets a wedged VM, which is faster than stopping and
+// starting it again.
+type ResetVMUseCase struct {
+	vmRepo repository.VMRepository
+	logger log.Logger
+}
+
+// NewResetVMUseCase creates a new instance of ResetVMUseCase
+func NewResetVMUseCase(vmRepo repository.VMRepository, logger log.Logger) *ResetVMUseCase {
+	return &ResetVMUseCase{vmRepo: vmRepo, logger: logger}
+}
+
+// Check returns the current state of the VM if it can be reset, so a caller
+// can reject a VM that is not running before asking to confirm the reset.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout
+//   - vm: The VM to check (must contain Project, Zone, and Name)
+//
+// Returns:
+//   - *model.VM: The VM as found on GCP
+//   - error: a *model.CapabilityError if the VM is not RUNNING, or an error
+//     naming the VM if it cannot be found
+func (uc *ResetVMUseCase) Check(ctx context.Context, vm *model.VM) (*model.VM, error) {
+	// 1. VMが存在するか確認
+	foundVM, err := uc.vmRepo.FindByName(ctx, vm)
+	if err != nil {
+		return nil, fmt.Errorf("VM %s: failed to find: %w", vm.Name, err)
+	}
+	if foundVM == nil {
+		return nil, fmt.Errorf("VM %s: %w", vm.Name, model.ErrVMNotFound)
+	}
+
+	// 2. ビジネスルールチェック
+	if checkErr := foundVM.Check(model.CapabilityReset); checkErr != nil {
+		return nil, checkErr
+	}
+	return foundVM, nil
+}
+
+// Execute checks that the VM is running and resets it. The guest is not shut
+// down, so unsaved data in memory is lost.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout
+//   - vm: The VM to reset (must contain Project, Zone, and Name)
+//
+// Returns:
+//   - error: nil on success, a *model.CapabilityError if the VM is not
+//     RUNNING, or an error naming the VM if it cannot be found or reset
+//
+// Example:
+//
+//	uc := NewResetVMUseCase(vmRepo, logger)
+//	err := uc.Execute(ctx, &model.VM{Project: "my-project", Zone: "us-central1-a", Name: "my-vm"})
+func (uc *ResetVMUseCase) Execute(ctx context.Context, vm *model.VM) error {
+	// 1. VMが存在し、リセットできるか確認
+	foundVM, err := uc.Check(ctx, vm)
+	if err != nil {
+		return err
+	}
+
+	// 2. リセット実行
+	if resetErr := uc.vmRepo.Reset(ctx, foundVM); resetErr != nil {
+		return fmt.Errorf("VM %s: failed to reset: %w", foundVM.Name, resetErr)
+	}
+
+	uc.logger.Infof("✓ Successfully reset VM %s", foundVM.Name)
+	return nil
+}
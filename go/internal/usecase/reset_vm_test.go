@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResetVMUseCase_Execute(t *testing.T) {
+	vm := &model.VM{Project: "test-project", Zone: "us-central1-a", Name: "test-vm"}
+
+	tests := []struct {
+		name        string
+		setupMock   func(*mock_repository.MockVMRepository)
+		errContains string
+		wantErr     bool
+	}{
+		{
+			name: "success: resets a running VM",
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				found := &model.VM{Project: "test-project", Zone: "us-central1-a", Name: "test-vm", Status: model.StatusRunning}
+				m.EXPECT().FindByName(gomock.Any(), vm).Return(found, nil)
+				m.EXPECT().Reset(gomock.Any(), found).Return(nil)
+			},
+		},
+		{
+			name: "error: VM is not running",
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				m.EXPECT().FindByName(gomock.Any(), vm).Return(&model.VM{Name: "test-vm", Status: model.StatusTerminated}, nil)
+			},
+			wantErr:     true,
+			errContains: "reset is not supported: VM is TERMINATED, must be RUNNING",
+		},
+		{
+			name: "error: VM not found",
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				m.EXPECT().FindByName(gomock.Any(), vm).Return(nil, nil)
+			},
+			wantErr:     true,
+			errContains: "VM test-vm: not found",
+		},
+		{
+			name: "error: reset fails",
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				found := &model.VM{Name: "test-vm", Status: model.StatusRunning}
+				m.EXPECT().FindByName(gomock.Any(), vm).Return(found, nil)
+				m.EXPECT().Reset(gomock.Any(), found).Return(errors.New("permission denied"))
+			},
+			wantErr:     true,
+			errContains: "VM test-vm: failed to reset: permission denied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := mock_repository.NewMockVMRepository(ctrl)
+			tt.setupMock(mockRepo)
+
+			err := NewResetVMUseCase(mockRepo, logger).Execute(context.Background(), vm)
+
+			if !tt.wantErr {
+				require.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.errContains)
+		})
+	}
+}
+
+func TestResetVMUseCase_Check(t *testing.T) {
+	vm := &model.VM{Project: "test-project", Zone: "us-central1-a", Name: "test-vm"}
+	ctrl := gomock.NewController(t)
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+	running := &model.VM{Name: "test-vm", Status: model.StatusRunning}
+	gomock.InOrder(
+		mockRepo.EXPECT().FindByName(gomock.Any(), vm).Return(running, nil),
+		mockRepo.EXPECT().FindByName(gomock.Any(), vm).Return(&model.VM{Name: "test-vm", Status: model.StatusStopped}, nil),
+	)
+	uc := NewResetVMUseCase(mockRepo, logger)
+
+	found, err := uc.Check(context.Background(), vm)
+	require.NoError(t, err, "Check does not reset the VM")
+	assert.Equal(t, running, found)
+
+	_, err = uc.Check(context.Background(), vm)
+	var capErr *model.CapabilityError
+	assert.ErrorAs(t, err, &capErr)
+}
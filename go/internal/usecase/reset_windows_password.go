@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+const (
+	// windowsKeysKey is the metadata item the Windows guest agent reads password reset requests from.
+	windowsKeysKey = "windows-keys"
+	// windowsPasswordPort is the serial port the guest agent writes the encrypted password to.
+	windowsPasswordPort = 4
+	// windowsKeyLifetime is how long the guest agent accepts a reset request.
+	windowsKeyLifetime = 5 * time.Minute
+	// defaultWindowsPasswordTimeout bounds waiting for the guest agent to answer.
+	defaultWindowsPasswordTimeout = 3 * time.Minute
+)
+
+// windowsKey is a password reset request in the windows-keys metadata item.
+type windowsKey struct {
+	UserName string `json:"userName"`
+	Modulus  string `json:"modulus"`
+	Exponent string `json:"exponent"`
+	Email    string `json:"email"`
+	ExpireOn string `json:"expireOn"`
+}
+
+// windowsPasswordResponse is the answer of the guest agent on serial port 4.
+type windowsPasswordResponse struct {
+	Modulus           string `json:"modulus"`
+	EncryptedPassword string `json:"encryptedPassword"`
+	ErrorMessage      string `json:"errorMessage"`
+}
+
+// ResetWindowsPasswordUseCase resets the password of a Windows user through the
+// guest agent, creating the user when it does not exist, like
+// "gcloud compute reset-windows-password".
+type ResetWindowsPasswordUseCase struct {
+	vmRepo       repository.VMRepository
+	serialRepo   repository.SerialPortRepository
+	logger       log.Logger
+	generateKey  func() (*rsa.PrivateKey, error)
+	now          func() time.Time
+	pollInterval time.Duration
+	timeout      time.Duration
+}
+
+// NewResetWindowsPasswordUseCase creates a new instance of ResetWindowsPasswordUseCase
+func NewResetWindowsPasswordUseCase(vmRepo repository.VMRepository, serialRepo repository.SerialPortRepository, logger log.Logger) *ResetWindowsPasswordUseCase {
+	return &ResetWindowsPasswordUseCase{
+		vmRepo:     vmRepo,
+		serialRepo: serialRepo,
+		logger:     logger,
+		generateKey: func() (*rsa.PrivateKey, error) {
+			return rsa.GenerateKey(rand.Reader, 2048)
+		},
+		now:          time.Now,
+		pollInterval: defaultSerialPollInterval,
+		timeout:      defaultWindowsPasswordTimeout,
+	}
+}
+
+// Execute asks the guest agent of a running Windows VM for a new password of user
+// and returns it.
+//
+// A fresh RSA key is added to the windows-keys metadata item, keeping the requests
+// that have not expired; the agent answers on serial port 4 with the password
+// encrypted with that key.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - vm: The VM (must contain Project, Zone, and Name)
+//   - user: The Windows user name
+//
+// Returns:
+//   - string: The new password
+//   - error: nil on success, otherwise an error (including when the VM is not a running Windows VM)
+func (uc *ResetWindowsPasswordUseCase) Execute(ctx context.Context, vm *model.VM, user string) (string, error) {
+	// 1. 入力チェック
+	if user == "" {
+		return "", errors.New("user name must not be empty")
+	}
+	foundVM, err := uc.vmRepo.FindByName(ctx, vm)
+	if err != nil {
+		return "", fmt.Errorf("VM %s: failed to find: %w", vm.Name, err)
+	}
+	if foundVM == nil {
+		return "", fmt.Errorf("VM %s: %w", vm.Name, model.ErrVMNotFound)
+	}
+	if !foundVM.Windows {
+		return "", fmt.Errorf("VM %s is not a Windows instance", vm.Name)
+	}
+	if foundVM.Status != model.StatusRunning {
+		return "", model.InvalidStateErrorf("VM %s must be running to reset a password (current status: %s)", vm.Name, foundVM.Status)
+	}
+
+	// 2. 既存のシリアル出力の末尾を記録してから鍵を登録
+	output, err := uc.serialRepo.Read(ctx, vm, windowsPasswordPort, 0)
+	if err != nil {
+		return "", fmt.Errorf("failed to read serial port output: %w", err)
+	}
+	start := output.Next
+
+	key, err := uc.generateKey()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate a key: %w", err)
+	}
+	request := windowsKey{
+		UserName: user,
+		Modulus:  base64.StdEncoding.EncodeToString(key.N.Bytes()),
+		Exponent: base64.StdEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		ExpireOn: uc.now().Add(windowsKeyLifetime).UTC().Format(time.RFC3339),
+	}
+	items, err := uc.vmRepo.GetMetadata(ctx, vm)
+	if err != nil {
+		return "", fmt.Errorf("failed to get metadata: %w", err)
+	}
+	keys, err := uc.appendWindowsKey(items[windowsKeysKey], request)
+	if err != nil {
+		return "", err
+	}
+	if err = uc.vmRepo.UpdateMetadata(ctx, vm, map[string]string{windowsKeysKey: keys}, nil); err != nil {
+		return "", fmt.Errorf("failed to update metadata: %w", err)
+	}
+	uc.logger.Debugf("Waiting for the guest agent of %s to answer on serial port %d", vm.Name, windowsPasswordPort)
+
+	// 3. ゲストエージェントの応答をポーリング
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+	response, err := uc.waitResponse(ctx, vm, start, request.Modulus)
+	if err != nil {
+		return "", err
+	}
+	if response.ErrorMessage != "" {
+		return "", fmt.Errorf("the guest agent of %s failed to reset the password: %s", vm.Name, response.ErrorMessage)
+	}
+	return decryptWindowsPassword(key, response.EncryptedPassword)
+}
+
+// appendWindowsKey adds request to the windows-keys value existing, dropping the
+// requests that have expired.
+func (uc *ResetWindowsPasswordUseCase) appendWindowsKey(existing string, request windowsKey) (string, error) {
+	var lines []string
+	for _, line := range strings.Split(existing, "\n") {
+		var key windowsKey
+		if json.Unmarshal([]byte(line), &key) != nil {
+			continue
+		}
+		if expireOn, err := time.Parse(time.RFC3339, key.ExpireOn); err == nil && expireOn.After(uc.now()) {
+			lines = append(lines, line)
+		}
+	}
+	data, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode the key: %w", err)
+	}
+	return strings.Join(append(lines, string(data)), "\n"), nil
+}
+
+// waitResponse polls serial port 4 of vm from byte start until the guest agent
+// answers the request for modulus.
+func (uc *ResetWindowsPasswordUseCase) waitResponse(ctx context.Context, vm *model.VM, start int64, modulus string) (*windowsPasswordResponse, error) {
+	ticker := time.NewTicker(uc.pollInterval)
+	defer ticker.Stop()
+
+	var partial string
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for the guest agent of %s to answer: %w", vm.Name, ctx.Err())
+		case <-ticker.C:
+		}
+
+		output, err := uc.serialRepo.Read(ctx, vm, windowsPasswordPort, start)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read serial port output: %w", err)
+		}
+		start = output.Next
+		lines := strings.Split(partial+output.Contents, "\n")
+		// The last element is an unfinished line, or "" after a newline.
+		partial = lines[len(lines)-1]
+		for _, line := range lines[:len(lines)-1] {
+			var response windowsPasswordResponse
+			if json.Unmarshal([]byte(strings.TrimSpace(line)), &response) == nil && response.Modulus == modulus {
+				return &response, nil
+			}
+		}
+	}
+}
+
+// decryptWindowsPassword decrypts the password the guest agent encrypted with key.
+func decryptWindowsPassword(key *rsa.PrivateKey, encrypted string) (string, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode the encrypted password: %w", err)
+	}
+	password, err := rsa.DecryptOAEP(sha1.New(), nil, key, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt the password: %w", err)
+	}
+	return string(password), nil
+}
@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResetWindowsPasswordUseCase_Execute(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	modulus := base64.StdEncoding.EncodeToString(key.N.Bytes())
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	vm := &model.VM{Project: "proj", Zone: "z", Name: "win-1"}
+
+	newUseCase := func(vmRepo *mock_repository.MockVMRepository, serialRepo *mock_repository.MockSerialPortRepository) *ResetWindowsPasswordUseCase {
+		uc := NewResetWindowsPasswordUseCase(vmRepo, serialRepo, logger)
+		uc.generateKey = func() (*rsa.PrivateKey, error) { return key, nil }
+		uc.now = func() time.Time { return now }
+		uc.pollInterval = time.Millisecond
+		return uc
+	}
+
+	t.Run("success: decrypts the password answered on serial port 4", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		vmRepo := mock_repository.NewMockVMRepository(ctrl)
+		serialRepo := mock_repository.NewMockSerialPortRepository(ctrl)
+
+		encrypted, encErr := rsa.EncryptOAEP(sha1.New(), rand.Reader, &key.PublicKey, []byte("s3cret!"), nil)
+		require.NoError(t, encErr)
+		response, _ := json.Marshal(windowsPasswordResponse{Modulus: modulus, EncryptedPassword: base64.StdEncoding.EncodeToString(encrypted)})
+		other, _ := json.Marshal(windowsPasswordResponse{Modulus: "other", EncryptedPassword: "x"})
+
+		valid := `{"userName":"bob","modulus":"m","exponent":"AQAB","email":"","expireOn":"2026-05-01T12:03:00Z"}`
+		expired := `{"userName":"bob","modulus":"m","exponent":"AQAB","email":"","expireOn":"2026-05-01T11:00:00Z"}`
+		vmRepo.EXPECT().FindByName(gomock.Any(), vm).
+			Return(&model.VM{Name: "win-1", Status: model.StatusRunning, Windows: true}, nil)
+		vmRepo.EXPECT().GetMetadata(gomock.Any(), vm).
+			Return(map[string]string{windowsKeysKey: expired + "\n" + valid}, nil)
+		vmRepo.EXPECT().UpdateMetadata(gomock.Any(), vm, gomock.Any(), nil).
+			DoAndReturn(func(_ context.Context, _ *model.VM, set map[string]string, _ []string) error {
+				lines := strings.Split(set[windowsKeysKey], "\n")
+				require.Len(t, lines, 2)
+				assert.Equal(t, valid, lines[0])
+				var request windowsKey
+				require.NoError(t, json.Unmarshal([]byte(lines[1]), &request))
+				assert.Equal(t, windowsKey{UserName: "alice", Modulus: modulus, Exponent: "AQAB", ExpireOn: "2026-05-01T12:05:00Z"}, request)
+				return nil
+			})
+		gomock.InOrder(
+			serialRepo.EXPECT().Read(gomock.Any(), vm, int32(4), int64(0)).
+				Return(&model.SerialOutput{Contents: "old output\n", Next: 100}, nil),
+			serialRepo.EXPECT().Read(gomock.Any(), vm, int32(4), int64(100)).
+				Return(&model.SerialOutput{Contents: string(other) + "\n" + string(response[:10]), Start: 100, Next: 150}, nil),
+			serialRepo.EXPECT().Read(gomock.Any(), vm, int32(4), int64(150)).
+				Return(&model.SerialOutput{Contents: string(response[10:]) + "\n", Start: 150, Next: 400}, nil),
+		)
+
+		password, err := newUseCase(vmRepo, serialRepo).Execute(context.Background(), vm, "alice")
+
+		require.NoError(t, err)
+		assert.Equal(t, "s3cret!", password)
+	})
+
+	t.Run("error: guest agent reports an error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		vmRepo := mock_repository.NewMockVMRepository(ctrl)
+		serialRepo := mock_repository.NewMockSerialPortRepository(ctrl)
+
+		response, _ := json.Marshal(windowsPasswordResponse{Modulus: modulus, ErrorMessage: "user name is invalid"})
+		vmRepo.EXPECT().FindByName(gomock.Any(), vm).
+			Return(&model.VM{Name: "win-1", Status: model.StatusRunning, Windows: true}, nil)
+		vmRepo.EXPECT().GetMetadata(gomock.Any(), vm).Return(map[string]string{}, nil)
+		vmRepo.EXPECT().UpdateMetadata(gomock.Any(), vm, gomock.Any(), nil).Return(nil)
+		serialRepo.EXPECT().Read(gomock.Any(), vm, int32(4), gomock.Any()).
+			Return(&model.SerialOutput{Contents: string(response) + "\n"}, nil).Times(2)
+
+		_, err := newUseCase(vmRepo, serialRepo).Execute(context.Background(), vm, "alice")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "user name is invalid")
+	})
+
+	t.Run("error: times out without an answer", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		vmRepo := mock_repository.NewMockVMRepository(ctrl)
+		serialRepo := mock_repository.NewMockSerialPortRepository(ctrl)
+
+		vmRepo.EXPECT().FindByName(gomock.Any(), vm).
+			Return(&model.VM{Name: "win-1", Status: model.StatusRunning, Windows: true}, nil)
+		vmRepo.EXPECT().GetMetadata(gomock.Any(), vm).Return(map[string]string{}, nil)
+		vmRepo.EXPECT().UpdateMetadata(gomock.Any(), vm, gomock.Any(), nil).Return(nil)
+		serialRepo.EXPECT().Read(gomock.Any(), vm, int32(4), gomock.Any()).
+			Return(&model.SerialOutput{}, nil).AnyTimes()
+
+		uc := newUseCase(vmRepo, serialRepo)
+		uc.timeout = 20 * time.Millisecond
+		_, err := uc.Execute(context.Background(), vm, "alice")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "timed out")
+	})
+
+	t.Run("error: not a Windows VM", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		vmRepo := mock_repository.NewMockVMRepository(ctrl)
+		vmRepo.EXPECT().FindByName(gomock.Any(), vm).
+			Return(&model.VM{Name: "win-1", Status: model.StatusRunning}, nil)
+
+		_, err := newUseCase(vmRepo, mock_repository.NewMockSerialPortRepository(ctrl)).Execute(context.Background(), vm, "alice")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not a Windows instance")
+	})
+
+	t.Run("error: VM is stopped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		vmRepo := mock_repository.NewMockVMRepository(ctrl)
+		vmRepo.EXPECT().FindByName(gomock.Any(), vm).
+			Return(&model.VM{Name: "win-1", Status: model.StatusTerminated, Windows: true}, nil)
+
+		_, err := newUseCase(vmRepo, mock_repository.NewMockSerialPortRepository(ctrl)).Execute(context.Background(), vm, "alice")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be running")
+	})
+
+	t.Run("error: empty user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		_, err := newUseCase(mock_repository.NewMockVMRepository(ctrl), mock_repository.NewMockSerialPortRepository(ctrl)).Execute(context.Background(), vm, "")
+
+		require.Error(t, err)
+	})
+}
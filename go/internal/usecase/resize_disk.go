@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// ResizeDiskUseCase handles growing a persistent disk attached to a VM
+type ResizeDiskUseCase struct {
+	repo   repository.DiskRepository
+	logger log.Logger
+}
+
+// NewResizeDiskUseCase creates a new instance of ResizeDiskUseCase
+func NewResizeDiskUseCase(repo repository.DiskRepository, logger log.Logger) *ResizeDiskUseCase {
+	return &ResizeDiskUseCase{repo: repo, logger: logger}
+}
+
+// Execute grows a disk attached to a VM to sizeGB.
+//
+// The disk must be attached to the VM, and since persistent disks cannot shrink,
+// sizeGB must be larger than the current size. The guest file system is not
+// resized; that has to be done inside the VM.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - project: The GCP project ID
+//   - zone: The GCP zone
+//   - name: The VM instance name
+//   - disk: The disk name or device name
+//   - sizeGB: The new size in GB
+//
+// Returns:
+//   - *model.Disk: The disk as it was before the resize
+//   - error: nil on success, otherwise an error describing what went wrong
+//
+// Example:
+//
+//	usecase := NewResizeDiskUseCase(diskRepo, logger)
+//	before, err := usecase.Execute(ctx, "my-project", "us-central1-a", "my-vm", "data-1", 200)
+func (uc *ResizeDiskUseCase) Execute(ctx context.Context, project, zone, name, disk string, sizeGB int64) (*model.Disk, error) {
+	// 1. VMにアタッチされたディスクを取得
+	vm := &model.VM{Project: project, Zone: zone, Name: name}
+	found, err := findAttachedDisk(ctx, uc.repo, vm, disk)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. ディスクは拡張のみ可能
+	if sizeGB <= found.SizeGB {
+		return nil, fmt.Errorf("disk %s can only grow: current size is %d GB, requested %d GB", found.Name, found.SizeGB, sizeGB)
+	}
+
+	// 3. リサイズ実行
+	if resizeErr := uc.repo.Resize(ctx, vm, found.Name, sizeGB); resizeErr != nil {
+		return nil, fmt.Errorf("failed to resize disk: %w", resizeErr)
+	}
+
+	uc.logger.Infof("✓ Successfully resized disk %s from %d GB to %d GB", found.Name, found.SizeGB, sizeGB)
+	return found, nil
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var loggerForDisk = log.NewLogger()
+
+func TestResizeDiskUseCase_Execute(t *testing.T) {
+	//nolint:govet // Test struct field order optimized for readability
+	tests := []struct {
+		name        string
+		disk        string
+		sizeGB      int64
+		resizeErr   error
+		wantResize  string
+		errContains string
+	}{
+		{name: "success: grow by disk name", disk: "data-1", sizeGB: 200, wantResize: "data-1"},
+		{name: "success: grow by device name", disk: "data", sizeGB: 200, wantResize: "data-1"},
+		{name: "error: disk not attached", disk: "other", sizeGB: 200, errContains: "attached: vm-1, data-1"},
+		{name: "error: shrink", disk: "data-1", sizeGB: 50, errContains: "can only grow"},
+		{name: "error: same size", disk: "data-1", sizeGB: 100, errContains: "can only grow"},
+		{name: "error: resize fails", disk: "data-1", sizeGB: 200, resizeErr: errors.New("quota"), wantResize: "data-1", errContains: "failed to resize disk"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repository.NewMockDiskRepository(ctrl)
+			repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(attachedDisks(), nil)
+			if tt.wantResize != "" {
+				repo.EXPECT().Resize(gomock.Any(), gomock.Any(), tt.wantResize, tt.sizeGB).Return(tt.resizeErr)
+			}
+
+			before, err := NewResizeDiskUseCase(repo, loggerForDisk).
+				Execute(context.Background(), "proj", "z", "vm-1", tt.disk, tt.sizeGB)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(100), before.SizeGB)
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// preemptionLookback is how many recent operations are searched for a preemption.
+const preemptionLookback = 5
+
+// Defaults for how often a preempted VM is restarted.
+const (
+	DefaultRestartBackoff    = time.Minute
+	DefaultMaxRestartBackoff = 30 * time.Minute
+	DefaultMaxRestarts       = 5
+)
+
+// restartState tracks the restart attempts of one VM.
+type restartState struct {
+	notBefore time.Time
+	attempts  int
+	gaveUp    bool
+}
+
+// RestartPreemptedUseCase starts Spot VMs that GCE has preempted.
+//
+// It remembers the restarts it made, so the same instance should be reused for
+// every round of a long-running watch.
+type RestartPreemptedUseCase struct {
+	vmRepo      repository.VMRepository
+	opRepo      repository.OperationRepository
+	logger      log.Logger
+	now         func() time.Time
+	state       map[string]*restartState
+	backoff     time.Duration
+	maxBackoff  time.Duration
+	maxRestarts int
+}
+
+// NewRestartPreemptedUseCase creates a new instance of RestartPreemptedUseCase
+func NewRestartPreemptedUseCase(vmRepo repository.VMRepository, opRepo repository.OperationRepository, logger log.Logger) *RestartPreemptedUseCase {
+	return &RestartPreemptedUseCase{
+		vmRepo:      vmRepo,
+		opRepo:      opRepo,
+		logger:      logger,
+		now:         time.Now,
+		state:       make(map[string]*restartState),
+		backoff:     DefaultRestartBackoff,
+		maxBackoff:  DefaultMaxRestartBackoff,
+		maxRestarts: DefaultMaxRestarts,
+	}
+}
+
+// WithRestartLimits sets how long to wait before restarting a VM again and how
+// many restart attempts to make per VM before giving up.
+// The wait starts at backoff and doubles after each attempt, up to maxBackoff.
+// A maxRestarts of zero or less means no limit.
+func (uc *RestartPreemptedUseCase) WithRestartLimits(backoff, maxBackoff time.Duration, maxRestarts int) *RestartPreemptedUseCase {
+	uc.backoff = backoff
+	uc.maxBackoff = maxBackoff
+	uc.maxRestarts = maxRestarts
+	return uc
+}
+
+// Execute starts every terminated Spot VM whose last operation was a preemption.
+//
+// VMs stopped on purpose are left alone: only a VM whose most recent operation
+// is GCE's preemption event is restarted. A VM restarted before waits out its
+// backoff, and once it has used up its restart attempts it is reported once and
+// then left terminated.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - vms: The configured VMs to watch
+//
+// Returns:
+//   - []*model.VM: The VMs that were restarted
+//   - error: Joined errors of the VMs that could not be checked or started
+//
+// Example:
+//
+//	usecase := NewRestartPreemptedUseCase(vmRepo, opRepo, logger)
+//	restarted, err := usecase.Execute(ctx, cfg.VMs)
+func (uc *RestartPreemptedUseCase) Execute(ctx context.Context, vms []*model.VM) ([]*model.VM, error) {
+	// 1. VMの状態を取得
+	found, err := uc.vmRepo.FindAll(ctx, vms)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VMs: %w", err)
+	}
+
+	// 2. プリエンプトされたSpot VMを再起動
+	var restarted []*model.VM
+	var errs []error
+	for _, vm := range found {
+		// A VM deleted from GCP is nil and has nothing to restart.
+		if vm == nil || vm.Status != model.StatusTerminated || vm.Scheduling == nil || !vm.Scheduling.IsSpot() {
+			continue
+		}
+		preempted, checkErr := uc.preempted(ctx, vm)
+		if checkErr != nil {
+			errs = append(errs, checkErr)
+			continue
+		}
+		if !preempted {
+			continue
+		}
+		ok, startErr := uc.restart(ctx, vm)
+		if startErr != nil {
+			errs = append(errs, startErr)
+			continue
+		}
+		if ok {
+			restarted = append(restarted, vm)
+		}
+	}
+	return restarted, errors.Join(errs...)
+}
+
+// restart starts vm unless it is backing off or out of attempts, reporting
+// whether it was started.
+func (uc *RestartPreemptedUseCase) restart(ctx context.Context, vm *model.VM) (bool, error) {
+	key := vm.Project + "/" + vm.Zone + "/" + vm.Name
+	st, ok := uc.state[key]
+	if !ok {
+		st = &restartState{}
+		uc.state[key] = st
+	}
+	now := uc.now()
+	switch {
+	case st.gaveUp:
+		return false, nil
+	case now.Before(st.notBefore):
+		uc.logger.Debugf("VM %s: backing off until %s before restarting", vm.Name, st.notBefore.Format(time.RFC3339))
+		return false, nil
+	case uc.maxRestarts > 0 && st.attempts >= uc.maxRestarts:
+		st.gaveUp = true
+		return false, fmt.Errorf("VM %s: preempted again after %d restart attempts, giving up", vm.Name, st.attempts)
+	}
+
+	st.attempts++
+	st.notBefore = now.Add(uc.backoffAfter(st.attempts))
+	if err := uc.vmRepo.Start(ctx, vm); err != nil {
+		return false, fmt.Errorf("VM %s: failed to restart: %w", vm.Name, err)
+	}
+	uc.logger.Infof("✓ Restarted preempted VM %s (attempt %d)", vm.Name, st.attempts)
+	return true, nil
+}
+
+// backoffAfter returns how long to wait after the given number of attempts.
+func (uc *RestartPreemptedUseCase) backoffAfter(attempts int) time.Duration {
+	d := uc.backoff
+	for i := 1; i < attempts && d < uc.maxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, uc.maxBackoff)
+}
+
+// preempted reports whether the most recent operation on vm is a preemption.
+func (uc *RestartPreemptedUseCase) preempted(ctx context.Context, vm *model.VM) (bool, error) {
+	ops, err := uc.opRepo.ListByTarget(ctx, vm, preemptionLookback)
+	if err != nil {
+		return false, fmt.Errorf("VM %s: failed to list operations: %w", vm.Name, err)
+	}
+	return len(ops) > 0 && ops[0].IsPreemption(), nil
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var loggerForRestartPreempted = log.NewLogger()
+
+func TestRestartPreemptedUseCase_Execute(t *testing.T) {
+	spot := &model.Scheduling{ProvisioningModel: "SPOT"}
+	preempted := &model.VM{Name: "preempted", Status: model.StatusTerminated, Scheduling: spot}
+	stopped := &model.VM{Name: "stopped", Status: model.StatusTerminated, Scheduling: spot}
+	failing := &model.VM{Name: "failing", Status: model.StatusTerminated, Scheduling: spot}
+	running := &model.VM{Name: "running", Status: model.StatusRunning, Scheduling: spot}
+	standard := &model.VM{Name: "standard", Status: model.StatusTerminated, Scheduling: &model.Scheduling{ProvisioningModel: "STANDARD"}}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	vmRepo := mock_repository.NewMockVMRepository(ctrl)
+	opRepo := mock_repository.NewMockOperationRepository(ctrl)
+	vmRepo.EXPECT().FindAll(gomock.Any(), gomock.Any()).Return([]*model.VM{preempted, stopped, failing, running, standard}, nil)
+	opRepo.EXPECT().ListByTarget(gomock.Any(), preempted, preemptionLookback).Return([]*model.Operation{
+		{Type: model.OperationTypePreempted}, {Type: "start"},
+	}, nil)
+	opRepo.EXPECT().ListByTarget(gomock.Any(), stopped, preemptionLookback).Return([]*model.Operation{
+		{Type: "stop"}, {Type: model.OperationTypePreempted},
+	}, nil)
+	opRepo.EXPECT().ListByTarget(gomock.Any(), failing, preemptionLookback).Return(nil, errors.New("denied"))
+	vmRepo.EXPECT().Start(gomock.Any(), preempted).Return(nil)
+
+	uc := NewRestartPreemptedUseCase(vmRepo, opRepo, loggerForRestartPreempted)
+	restarted, err := uc.Execute(context.Background(), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "VM failing: failed to list operations: denied")
+	assert.Equal(t, []*model.VM{preempted}, restarted)
+}
+
+func TestRestartPreemptedUseCase_Execute_FindAllError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	vmRepo := mock_repository.NewMockVMRepository(ctrl)
+	vmRepo.EXPECT().FindAll(gomock.Any(), gomock.Any()).Return(nil, errors.New("denied"))
+
+	uc := NewRestartPreemptedUseCase(vmRepo, mock_repository.NewMockOperationRepository(ctrl), loggerForRestartPreempted)
+	_, err := uc.Execute(context.Background(), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get VMs")
+}
+
+func TestRestartPreemptedUseCase_Execute_DeletedVM(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	vmRepo := mock_repository.NewMockVMRepository(ctrl)
+	vmRepo.EXPECT().FindAll(gomock.Any(), gomock.Any()).Return([]*model.VM{nil}, nil)
+
+	uc := NewRestartPreemptedUseCase(vmRepo, mock_repository.NewMockOperationRepository(ctrl), loggerForRestartPreempted)
+	restarted, err := uc.Execute(context.Background(), []*model.VM{{Name: "deleted"}})
+	require.NoError(t, err)
+	assert.Empty(t, restarted)
+}
+
+func TestRestartPreemptedUseCase_Execute_Limits(t *testing.T) {
+	start := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+	now := start
+	vm := &model.VM{Name: "spot", Status: model.StatusTerminated, Scheduling: &model.Scheduling{Preemptible: true}}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	vmRepo := mock_repository.NewMockVMRepository(ctrl)
+	opRepo := mock_repository.NewMockOperationRepository(ctrl)
+	vmRepo.EXPECT().FindAll(gomock.Any(), gomock.Any()).Return([]*model.VM{vm}, nil).AnyTimes()
+	opRepo.EXPECT().ListByTarget(gomock.Any(), vm, preemptionLookback).Return([]*model.Operation{{Type: model.OperationTypePreempted}}, nil).AnyTimes()
+	vmRepo.EXPECT().Start(gomock.Any(), vm).Return(errors.New("ZONE_RESOURCE_POOL_EXHAUSTED"))
+	vmRepo.EXPECT().Start(gomock.Any(), vm).Return(nil)
+
+	uc := NewRestartPreemptedUseCase(vmRepo, opRepo, loggerForRestartPreempted).WithRestartLimits(time.Minute, 90*time.Second, 2)
+	uc.now = func() time.Time { return now }
+
+	//nolint:govet // field alignment is less important than readability in tests
+	rounds := []struct {
+		after       time.Duration
+		restarted   int
+		errContains string
+	}{
+		{after: 0, errContains: "failed to restart: ZONE_RESOURCE_POOL_EXHAUSTED"},
+		{after: 59 * time.Second},
+		{after: time.Minute, restarted: 1},
+		{after: 2 * time.Minute},
+		{after: 2*time.Minute + 30*time.Second, errContains: "preempted again after 2 restart attempts, giving up"},
+		{after: time.Hour},
+	}
+	for _, r := range rounds {
+		now = start.Add(r.after)
+		restarted, err := uc.Execute(context.Background(), nil)
+		if r.errContains != "" {
+			require.Error(t, err, "after %s", r.after)
+			assert.Contains(t, err.Error(), r.errContains)
+		} else {
+			require.NoError(t, err, "after %s", r.after)
+		}
+		assert.Len(t, restarted, r.restarted, "after %s", r.after)
+	}
+}
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// ScheduleStopUseCase defers stopping a running VM to a later time.
+type ScheduleStopUseCase struct {
+	vmRepo repository.VMRepository
+	store  repository.ScheduledActionStore
+	logger log.Logger
+	now    func() time.Time
+}
+
+// NewScheduleStopUseCase creates a new instance of ScheduleStopUseCase
+func NewScheduleStopUseCase(vmRepo repository.VMRepository, store repository.ScheduledActionStore, logger log.Logger) *ScheduleStopUseCase {
+	return &ScheduleStopUseCase{vmRepo: vmRepo, store: store, logger: logger, now: time.Now}
+}
+
+// Execute records a stop of a running VM at the given time.
+//
+// The stop is only recorded; RunScheduledActionsUseCase performs it once it is due.
+// A VM has at most one pending action, so scheduling again replaces the previous one.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - project: The GCP project ID
+//   - zone: The GCP zone
+//   - name: The VM instance name
+//   - at: When to stop the VM (must be in the future)
+//   - reason: Where the stop comes from, shown with pending actions (e.g., "ttl 3h0m0s")
+//
+// Returns:
+//   - *model.ScheduledAction: The recorded action
+//   - error: nil on success, otherwise an error describing what went wrong
+//
+// Example:
+//
+//	usecase := NewScheduleStopUseCase(vmRepo, store, logger)
+//	action, err := usecase.Execute(ctx, "my-project", "us-central1-a", "my-vm", time.Now().Add(3*time.Hour), "ttl 3h0m0s")
+func (uc *ScheduleStopUseCase) Execute(ctx context.Context, project, zone, name string, at time.Time, reason string) (*model.ScheduledAction, error) {
+	// 1. 入力チェック
+	now := uc.now()
+	if !at.After(now) {
+		return nil, fmt.Errorf("stop time %s is not in the future", at.Format(time.RFC3339))
+	}
+
+	// 2. VMが稼働中か確認
+	vm, err := uc.vmRepo.FindByName(ctx, &model.VM{Project: project, Zone: zone, Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find VM: %w", err)
+	}
+	if vm == nil {
+		return nil, fmt.Errorf("VM %s: %w", name, model.ErrVMNotFound)
+	}
+	if !vm.CanStop() {
+		return nil, model.InvalidStateErrorf("VM %s: cannot schedule a stop (current status: %s)", name, vm.Status)
+	}
+
+	// 3. 停止予定を記録
+	action := &model.ScheduledAction{
+		At:        at,
+		CreatedAt: now,
+		VMName:    name,
+		Project:   project,
+		Zone:      zone,
+		Action:    model.ScheduledStop,
+		Reason:    reason,
+	}
+	if putErr := uc.store.Put(action); putErr != nil {
+		return nil, fmt.Errorf("failed to record scheduled stop: %w", putErr)
+	}
+	uc.logger.Infof("✓ Scheduled stop of VM %s at %s", name, at.Format(time.RFC3339))
+	return action, nil
+}
+
+// RunScheduledActionsUseCase performs the pending actions that are due.
+type RunScheduledActionsUseCase struct {
+	vmRepo repository.VMRepository
+	store  repository.ScheduledActionStore
+	logger log.Logger
+	now    func() time.Time
+}
+
+// NewRunScheduledActionsUseCase creates a new instance of RunScheduledActionsUseCase
+func NewRunScheduledActionsUseCase(vmRepo repository.VMRepository, store repository.ScheduledActionStore, logger log.Logger) *RunScheduledActionsUseCase {
+	return &RunScheduledActionsUseCase{vmRepo: vmRepo, store: store, logger: logger, now: time.Now}
+}
+
+// Execute performs every due action and removes it from the store.
+//
+// Due actions are removed before they run so that a failing stop is not retried
+// forever. An action is skipped when its VM no longer exists, is not running, or
+// was started again after the action was scheduled.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//
+// Returns:
+//   - []*model.ScheduledAction: The actions that were performed
+//   - error: Joined errors of the actions that failed
+func (uc *RunScheduledActionsUseCase) Execute(ctx context.Context) ([]*model.ScheduledAction, error) {
+	now := uc.now()
+	var done []*model.ScheduledAction
+	var errs []error
+	for _, action := range uc.store.List() {
+		if !action.Due(now) {
+			continue
+		}
+		if err := uc.store.Delete(action.VM()); err != nil {
+			errs = append(errs, fmt.Errorf("VM %s: failed to remove scheduled %s: %w", action.VMName, action.Action, err))
+			continue
+		}
+
+		ran, err := uc.run(ctx, action)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if ran {
+			done = append(done, action)
+		}
+	}
+	return done, errors.Join(errs...)
+}
+
+// run performs a single action, reporting false if it was skipped.
+func (uc *RunScheduledActionsUseCase) run(ctx context.Context, action *model.ScheduledAction) (bool, error) {
+	vm, err := uc.vmRepo.FindByName(ctx, action.VM())
+	if err != nil {
+		return false, fmt.Errorf("VM %s: failed to find: %w", action.VMName, err)
+	}
+	switch {
+	case vm == nil:
+		uc.logger.Warnf("Skipping scheduled %s of VM %s: not found", action.Action, action.VMName)
+		return false, nil
+	case action.Stale(vm):
+		uc.logger.Infof("Skipping scheduled %s of VM %s: restarted since it was scheduled", action.Action, action.VMName)
+		return false, nil
+	}
+
+	switch action.Action {
+	case model.ScheduledStop:
+		if !vm.CanStop() {
+			uc.logger.Infof("Skipping scheduled stop of VM %s: already %s", action.VMName, vm.Status)
+			return false, nil
+		}
+		if stopErr := uc.vmRepo.Stop(ctx, vm); stopErr != nil {
+			return false, fmt.Errorf("VM %s: failed to stop: %w", action.VMName, stopErr)
+		}
+	default:
+		return false, fmt.Errorf("VM %s: unknown scheduled action %q", action.VMName, action.Action)
+	}
+	uc.logger.Infof("✓ Ran scheduled %s of VM %s", action.Action, action.VMName)
+	return true, nil
+}
+
+// ParseStopTime parses the time of a deferred stop in now's location.
+//
+// A bare clock time ("19:00") means its next occurrence, today or tomorrow; a
+// date and time ("2025-01-02 19:00") is taken as is.
+//
+// Parameters:
+//   - s: The time to parse
+//   - now: The current time, whose location and date are used
+//
+// Returns:
+//   - time.Time: The parsed time
+//   - error: Error if s matches neither form
+func ParseStopTime(s string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02 15:04", s, now.Location()); err == nil {
+		return t, nil
+	}
+	clock, err := time.ParseInLocation("15:04", s, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: expected HH:MM or YYYY-MM-DD HH:MM", s)
+	}
+	t := time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location())
+	if !t.After(now) {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
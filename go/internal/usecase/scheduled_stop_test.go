@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var loggerForScheduledStop = log.NewLogger()
+
+func TestScheduleStopUseCase_Execute(t *testing.T) {
+	now := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+	at := now.Add(3 * time.Hour)
+
+	//nolint:govet // field alignment is less important than readability in tests
+	tests := []struct {
+		name        string
+		at          time.Time
+		setupMock   func(*mock_repository.MockVMRepository, *mock_repository.MockScheduledActionStore)
+		errContains string
+	}{
+		{
+			name: "success: records stop of running VM",
+			at:   at,
+			setupMock: func(vmRepo *mock_repository.MockVMRepository, store *mock_repository.MockScheduledActionStore) {
+				vmRepo.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(&model.VM{Name: "vm", Status: model.StatusRunning}, nil)
+				store.EXPECT().Put(&model.ScheduledAction{
+					At: at, CreatedAt: now, VMName: "vm", Project: "p", Zone: "z", Action: model.ScheduledStop, Reason: "ttl 3h0m0s",
+				}).Return(nil)
+			},
+		},
+		{
+			name:        "error: time in the past",
+			at:          now.Add(-time.Minute),
+			setupMock:   func(*mock_repository.MockVMRepository, *mock_repository.MockScheduledActionStore) {},
+			errContains: "is not in the future",
+		},
+		{
+			name: "error: VM not running",
+			at:   at,
+			setupMock: func(vmRepo *mock_repository.MockVMRepository, _ *mock_repository.MockScheduledActionStore) {
+				vmRepo.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(&model.VM{Name: "vm", Status: model.StatusTerminated}, nil)
+			},
+			errContains: "cannot schedule a stop",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			vmRepo := mock_repository.NewMockVMRepository(ctrl)
+			store := mock_repository.NewMockScheduledActionStore(ctrl)
+			tt.setupMock(vmRepo, store)
+
+			uc := NewScheduleStopUseCase(vmRepo, store, loggerForScheduledStop)
+			uc.now = func() time.Time { return now }
+
+			action, err := uc.Execute(context.Background(), "p", "z", "vm", tt.at, "ttl 3h0m0s")
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.at, action.At)
+		})
+	}
+}
+
+func TestRunScheduledActionsUseCase_Execute(t *testing.T) {
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	created := now.Add(-3 * time.Hour)
+	action := func(name string, at time.Time) *model.ScheduledAction {
+		return &model.ScheduledAction{At: at, CreatedAt: created, VMName: name, Project: "p", Zone: "z", Action: model.ScheduledStop}
+	}
+	startedBefore := created.Add(-time.Minute)
+	startedAfter := created.Add(time.Hour)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	vmRepo := mock_repository.NewMockVMRepository(ctrl)
+	store := mock_repository.NewMockScheduledActionStore(ctrl)
+	store.EXPECT().List().Return([]*model.ScheduledAction{
+		action("due", now),
+		action("restarted", now),
+		action("stopped", now),
+		action("failing", now),
+		action("later", now.Add(time.Minute)),
+	})
+	for _, name := range []string{"due", "restarted", "stopped", "failing"} {
+		store.EXPECT().Delete(&model.VM{Name: name, Project: "p", Zone: "z"}).Return(nil)
+	}
+
+	due := &model.VM{Name: "due", Status: model.StatusRunning, LastStartTime: &startedBefore}
+	failing := &model.VM{Name: "failing", Status: model.StatusRunning, LastStartTime: &startedBefore}
+	vmRepo.EXPECT().FindByName(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, vm *model.VM) (*model.VM, error) {
+		switch vm.Name {
+		case "due":
+			return due, nil
+		case "restarted":
+			return &model.VM{Name: "restarted", Status: model.StatusRunning, LastStartTime: &startedAfter}, nil
+		case "stopped":
+			return &model.VM{Name: "stopped", Status: model.StatusTerminated}, nil
+		default:
+			return failing, nil
+		}
+	}).Times(4)
+	vmRepo.EXPECT().Stop(gomock.Any(), due).Return(nil)
+	vmRepo.EXPECT().Stop(gomock.Any(), failing).Return(errors.New("quota"))
+
+	uc := NewRunScheduledActionsUseCase(vmRepo, store, loggerForScheduledStop)
+	uc.now = func() time.Time { return now }
+
+	done, err := uc.Execute(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "VM failing: failed to stop: quota")
+	require.Len(t, done, 1)
+	assert.Equal(t, "due", done[0].VMName)
+}
+
+func TestParseStopTime(t *testing.T) {
+	now := time.Date(2025, 1, 2, 15, 48, 0, 0, time.UTC)
+
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{in: "19:00", want: time.Date(2025, 1, 2, 19, 0, 0, 0, time.UTC)},
+		{in: "09:30", want: time.Date(2025, 1, 3, 9, 30, 0, 0, time.UTC)},
+		{in: "15:48", want: time.Date(2025, 1, 3, 15, 48, 0, 0, time.UTC)},
+		{in: "2025-01-05 07:00", want: time.Date(2025, 1, 5, 7, 0, 0, 0, time.UTC)},
+		{in: "7pm", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseStopTime(tt.in, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
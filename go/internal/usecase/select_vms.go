@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+)
+
+// SelectVMsUseCase finds the VMs to act on by their GCP labels instead of their config names.
+type SelectVMsUseCase struct {
+	repo repository.VMRepository
+}
+
+// NewSelectVMsUseCase creates a new SelectVMsUseCase instance.
+func NewSelectVMsUseCase(repo repository.VMRepository) *SelectVMsUseCase {
+	return &SelectVMsUseCase{repo: repo}
+}
+
+// Execute lists the instances with every label in selector in the default
+// project and the projects of configuredVMs. Matching instances need not be in
+// the config file.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - defaultProject: The default project of the config file, may be empty
+//   - configuredVMs: VMs loaded from config, whose projects are searched too
+//   - selector: The labels a VM must have, as returned by model.ParseLabelSelector
+//
+// Returns:
+//   - []*model.VM: The matching VMs, by project and then by zone and name
+//   - error: Joined error for the projects that could not be listed, or an
+//     error when no VM matches
+func (u *SelectVMsUseCase) Execute(ctx context.Context, defaultProject string, configuredVMs []*model.VM, selector map[string]string) ([]*model.VM, error) {
+	var vms []*model.VM
+	var errs []error
+	projects := knownProjects(defaultProject, configuredVMs)
+	for _, project := range projects {
+		found, err := u.repo.ListByProject(ctx, project, selector)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", project, err))
+			continue
+		}
+		vms = append(vms, found...)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("failed to select VMs: %w", err)
+	}
+	if len(vms) == 0 {
+		return nil, fmt.Errorf("no VM in %v has the labels %s", projects, model.FormatLabels(selector))
+	}
+	return vms, nil
+}
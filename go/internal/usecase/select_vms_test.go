@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSelectVMsUseCase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	selector := map[string]string{"env": "dev"}
+	configured := []*model.VM{{Name: "web", Project: "p2"}}
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+	mockRepo.EXPECT().ListByProject(gomock.Any(), "p1", selector).Return([]*model.VM{{Name: "a", Project: "p1"}}, nil)
+	mockRepo.EXPECT().ListByProject(gomock.Any(), "p2", selector).Return([]*model.VM{{Name: "b", Project: "p2"}}, nil)
+
+	vms, err := NewSelectVMsUseCase(mockRepo).Execute(context.Background(), "p1", configured, selector)
+	require.NoError(t, err)
+	require.Len(t, vms, 2)
+	assert.Equal(t, "a", vms[0].Name)
+	assert.Equal(t, "b", vms[1].Name)
+}
+
+func TestSelectVMsUseCase_ExecuteErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	selector := map[string]string{"env": "dev"}
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+	mockRepo.EXPECT().ListByProject(gomock.Any(), "p1", selector).Return(nil, nil)
+	_, err := NewSelectVMsUseCase(mockRepo).Execute(context.Background(), "p1", nil, selector)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no VM in [p1] has the labels env=dev")
+
+	mockRepo.EXPECT().ListByProject(gomock.Any(), "p1", selector).Return(nil, errors.New("permission denied"))
+	_, err = NewSelectVMsUseCase(mockRepo).Execute(context.Background(), "p1", nil, selector)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "p1: permission denied")
+}
@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// SetGPUUseCase handles attaching GPUs to and detaching GPUs from a VM
+type SetGPUUseCase struct {
+	vmRepo              repository.VMRepository
+	acceleratorTypeRepo repository.AcceleratorTypeRepository
+	logger              log.Logger
+}
+
+// NewSetGPUUseCase creates a new instance of SetGPUUseCase
+func NewSetGPUUseCase(vmRepo repository.VMRepository, acceleratorTypeRepo repository.AcceleratorTypeRepository, logger log.Logger) *SetGPUUseCase {
+	return &SetGPUUseCase{vmRepo: vmRepo, acceleratorTypeRepo: acceleratorTypeRepo, logger: logger}
+}
+
+// Attach replaces the GPUs of a stopped VM with count cards of gpuType.
+//
+// This method performs the following steps:
+// 1. Retrieves the VM instance and checks it is stopped
+// 2. Validates that gpuType is offered in the VM's zone and count is within its per-VM limit
+// 3. Sets the VM's guest accelerators
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - project: The GCP project ID
+//   - zone: The GCP zone
+//   - name: The VM instance name
+//   - gpuType: The accelerator type (e.g., "nvidia-tesla-t4")
+//   - count: The number of cards to attach
+//
+// Returns:
+//   - error: nil on success, otherwise an error describing what went wrong
+//
+// Error conditions:
+//   - VM not found: when the VM does not exist in the specified project/zone
+//   - VM is running: a *model.CapabilityError for model.CapabilityGPU
+//   - GPU unavailable: when gpuType is not offered in the zone (the error lists the ones that are)
+//   - Invalid count: when count is below 1 or above the type's maximum cards per instance
+//
+// Example:
+//
+//	usecase := NewSetGPUUseCase(vmRepo, acceleratorTypeRepo, logger)
+//	err := usecase.Attach(ctx, "my-project", "us-central1-a", "my-vm", "nvidia-tesla-t4", 1)
+func (uc *SetGPUUseCase) Attach(ctx context.Context, project, zone, name, gpuType string, count int) error {
+	// 1. VMを取得し停止状態を確認
+	foundVM, err := uc.findStoppedVM(ctx, project, zone, name)
+	if err != nil {
+		return err
+	}
+
+	// 2. ゾーンでのGPU提供状況を検証
+	if count < 1 {
+		return fmt.Errorf("GPU count must be at least 1: %d", count)
+	}
+	types, err := uc.acceleratorTypeRepo.List(ctx, project, zone)
+	if err != nil {
+		return fmt.Errorf("failed to list GPU types: %w", err)
+	}
+	acceleratorType := findAcceleratorType(types, gpuType)
+	if acceleratorType == nil {
+		return fmt.Errorf("GPU type %s is not available in zone %s (available: %s)", gpuType, zone, acceleratorTypeNames(types))
+	}
+	if acceleratorType.MaxCardsPerInstance > 0 && count > acceleratorType.MaxCardsPerInstance {
+		return fmt.Errorf("GPU type %s allows at most %d per VM: %d", gpuType, acceleratorType.MaxCardsPerInstance, count)
+	}
+
+	// 3. GPUを設定
+	accelerators := []model.Accelerator{{Type: gpuType, Count: count}}
+	if setErr := uc.vmRepo.SetAccelerators(ctx, foundVM, accelerators); setErr != nil {
+		return fmt.Errorf("failed to attach GPU: %w", setErr)
+	}
+
+	uc.logger.Infof("✓ Successfully attached %d x %s to VM %s", count, gpuType, foundVM.Name)
+	return nil
+}
+
+// Detach removes all GPUs from a stopped VM.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - project: The GCP project ID
+//   - zone: The GCP zone
+//   - name: The VM instance name
+//
+// Returns:
+//   - error: nil on success, otherwise an error describing what went wrong.
+//     Detaching from a VM without GPUs is an error.
+func (uc *SetGPUUseCase) Detach(ctx context.Context, project, zone, name string) error {
+	foundVM, err := uc.findStoppedVM(ctx, project, zone, name)
+	if err != nil {
+		return err
+	}
+	if len(foundVM.Accelerators) == 0 {
+		return fmt.Errorf("VM %s has no GPUs attached", foundVM.Name)
+	}
+
+	if setErr := uc.vmRepo.SetAccelerators(ctx, foundVM, nil); setErr != nil {
+		return fmt.Errorf("failed to detach GPU: %w", setErr)
+	}
+
+	uc.logger.Infof("✓ Successfully detached GPUs from VM %s", foundVM.Name)
+	return nil
+}
+
+func (uc *SetGPUUseCase) findStoppedVM(ctx context.Context, project, zone, name string) (*model.VM, error) {
+	vm := &model.VM{
+		Project: project,
+		Zone:    zone,
+		Name:    name,
+	}
+	foundVM, err := uc.vmRepo.FindByName(ctx, vm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find VM: %w", err)
+	}
+	if foundVM == nil {
+		return nil, fmt.Errorf("VM %s: %w", name, model.ErrVMNotFound)
+	}
+	if err := foundVM.Check(model.CapabilityGPU); err != nil {
+		return nil, err
+	}
+	return foundVM, nil
+}
+
+func findAcceleratorType(types []*model.AcceleratorType, name string) *model.AcceleratorType {
+	for _, t := range types {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+func acceleratorTypeNames(types []*model.AcceleratorType) string {
+	if len(types) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		names = append(names, t.Name)
+	}
+	return strings.Join(names, ", ")
+}
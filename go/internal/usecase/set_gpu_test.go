@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var loggerForSetGPU = log.NewLogger()
+
+func TestSetGPUUseCase_Attach(t *testing.T) {
+	zoneTypes := []*model.AcceleratorType{
+		{Name: "nvidia-tesla-t4", Zone: "us-central1-a", MaxCardsPerInstance: 4},
+		{Name: "nvidia-l4", Zone: "us-central1-a", MaxCardsPerInstance: 8},
+	}
+
+	//nolint:govet // Test struct field order optimized for readability
+	tests := []struct {
+		name        string
+		status      model.Status
+		gpuType     string
+		count       int
+		listErr     error
+		setErr      error
+		wantSet     bool
+		errContains string
+		wantErr     error
+	}{
+		{name: "success: attach to stopped VM", status: model.StatusTerminated, gpuType: "nvidia-tesla-t4", count: 1, wantSet: true},
+		{name: "success: attach maximum cards", status: model.StatusStopped, gpuType: "nvidia-tesla-t4", count: 4, wantSet: true},
+		{name: "error: VM is running", status: model.StatusRunning, gpuType: "nvidia-tesla-t4", count: 1, wantErr: model.ErrUnsupported},
+		{name: "error: count below one", status: model.StatusTerminated, gpuType: "nvidia-tesla-t4", count: 0, errContains: "at least 1"},
+		{name: "error: type not available in zone", status: model.StatusTerminated, gpuType: "nvidia-tesla-a100", count: 1, errContains: "available: nvidia-tesla-t4, nvidia-l4"},
+		{name: "error: count above maximum", status: model.StatusTerminated, gpuType: "nvidia-tesla-t4", count: 5, errContains: "at most 4"},
+		{name: "error: listing types fails", status: model.StatusTerminated, gpuType: "nvidia-tesla-t4", count: 1, listErr: errors.New("denied"), errContains: "failed to list GPU types"},
+		{name: "error: set accelerators fails", status: model.StatusTerminated, gpuType: "nvidia-tesla-t4", count: 1, setErr: errors.New("quota"), wantSet: true, errContains: "failed to attach GPU"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			vm := &model.VM{Name: "vm-1", Project: "proj", Zone: "us-central1-a", Status: tt.status}
+			vmRepo := mock_repository.NewMockVMRepository(ctrl)
+			typeRepo := mock_repository.NewMockAcceleratorTypeRepository(ctrl)
+			vmRepo.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(vm, nil)
+			if vm.CanChangeMachineType() && tt.count >= 1 {
+				typeRepo.EXPECT().List(gomock.Any(), "proj", "us-central1-a").Return(zoneTypes, tt.listErr)
+			}
+			if tt.wantSet {
+				vmRepo.EXPECT().
+					SetAccelerators(gomock.Any(), vm, []model.Accelerator{{Type: tt.gpuType, Count: tt.count}}).
+					Return(tt.setErr)
+			}
+
+			err := NewSetGPUUseCase(vmRepo, typeRepo, loggerForSetGPU).
+				Attach(context.Background(), "proj", "us-central1-a", "vm-1", tt.gpuType, tt.count)
+
+			switch {
+			case tt.wantErr != nil:
+				require.ErrorIs(t, err, tt.wantErr)
+			case tt.errContains != "":
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSetGPUUseCase_Detach(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	withGPU := &model.VM{
+		Name: "vm-1", Project: "proj", Zone: "z", Status: model.StatusTerminated,
+		Accelerators: []model.Accelerator{{Type: "nvidia-tesla-t4", Count: 1}},
+	}
+	withoutGPU := &model.VM{Name: "vm-2", Project: "proj", Zone: "z", Status: model.StatusTerminated}
+	running := &model.VM{
+		Name: "vm-3", Project: "proj", Zone: "z", Status: model.StatusRunning,
+		Accelerators: []model.Accelerator{{Type: "nvidia-tesla-t4", Count: 1}},
+	}
+
+	vmRepo := mock_repository.NewMockVMRepository(ctrl)
+	typeRepo := mock_repository.NewMockAcceleratorTypeRepository(ctrl)
+	vmRepo.EXPECT().FindByName(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, vm *model.VM) (*model.VM, error) {
+		switch vm.Name {
+		case "vm-1":
+			return withGPU, nil
+		case "vm-2":
+			return withoutGPU, nil
+		default:
+			return running, nil
+		}
+	}).Times(3)
+	vmRepo.EXPECT().SetAccelerators(gomock.Any(), withGPU, gomock.Nil()).Return(nil)
+
+	uc := NewSetGPUUseCase(vmRepo, typeRepo, loggerForSetGPU)
+	require.NoError(t, uc.Detach(context.Background(), "proj", "z", "vm-1"))
+
+	err := uc.Detach(context.Background(), "proj", "z", "vm-2")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no GPUs attached")
+
+	err = uc.Detach(context.Background(), "proj", "z", "vm-3")
+	require.ErrorIs(t, err, model.ErrUnsupported)
+}
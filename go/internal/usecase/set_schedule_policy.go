@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/haru-256/gcectl/internal/domain/model"
 	"github.com/haru-256/gcectl/internal/domain/repository"
@@ -11,20 +12,22 @@ import (
 
 // SetSchedulePolicyUseCase handles the business logic for setting a schedule policy
 type SetSchedulePolicyUseCase struct {
-	vmRepo repository.VMRepository
-	logger log.Logger
+	vmRepo     repository.VMRepository
+	policyRepo repository.SchedulePolicyRepository
+	logger     log.Logger
 }
 
 // NewSetSchedulePolicyUseCase creates a new instance of SetSchedulePolicyUseCase
-func NewSetSchedulePolicyUseCase(vmRepo repository.VMRepository, logger log.Logger) *SetSchedulePolicyUseCase {
-	return &SetSchedulePolicyUseCase{vmRepo: vmRepo, logger: logger}
+func NewSetSchedulePolicyUseCase(vmRepo repository.VMRepository, policyRepo repository.SchedulePolicyRepository, logger log.Logger) *SetSchedulePolicyUseCase {
+	return &SetSchedulePolicyUseCase{vmRepo: vmRepo, policyRepo: policyRepo, logger: logger}
 }
 
 // Execute attaches a schedule policy to a VM.
 //
 // This method performs the following steps:
 // 1. Retrieves the VM instance from the repository
-// 2. Executes the schedule policy attachment operation
+// 2. Checks that the policy exists in the VM's region
+// 3. Executes the schedule policy attachment operation
 //
 // A schedule policy controls when the VM should be automatically started or stopped.
 //
@@ -40,11 +43,12 @@ func NewSetSchedulePolicyUseCase(vmRepo repository.VMRepository, logger log.Logg
 //
 // Error conditions:
 //   - VM not found: when the VM does not exist in the specified project/zone
+//   - Policy not found: model.ErrSchedulePolicyNotFound, listing the available policies
 //   - Set operation failed: when the GCP API call to attach the schedule policy fails
 //
 // Example:
 //
-//	usecase := NewSetSchedulePolicyUseCase(vmRepo)
+//	usecase := NewSetSchedulePolicyUseCase(vmRepo, policyRepo, logger)
 //	err := usecase.Execute(ctx, "my-project", "us-central1-a", "my-vm", "my-schedule-policy")
 //	if err != nil {
 //	    log.Fatalf("Failed to set schedule policy: %v", err)
@@ -61,7 +65,12 @@ func (uc *SetSchedulePolicyUseCase) Execute(ctx context.Context, project, zone,
 		return fmt.Errorf("failed to find VM: %w", err)
 	}
 
-	// 2. スケジュールポリシー設定実行
+	// 2. ポリシーの存在確認
+	if checkErr := uc.checkPolicyExists(ctx, project, model.RegionOf(zone), policyName); checkErr != nil {
+		return checkErr
+	}
+
+	// 3. スケジュールポリシー設定実行
 	if setErr := uc.vmRepo.SetSchedulePolicy(ctx, foundVM, policyName); setErr != nil {
 		return fmt.Errorf("failed to set schedule policy: %w", setErr)
 	}
@@ -69,3 +78,30 @@ func (uc *SetSchedulePolicyUseCase) Execute(ctx context.Context, project, zone,
 	uc.logger.Infof("✓ Successfully set schedule policy %s for VM %s", policyName, foundVM.Name)
 	return nil
 }
+
+// checkPolicyExists returns model.ErrSchedulePolicyNotFound, with the names of the
+// available policies, if policyName is not an instance schedule policy of project/region.
+// A failure to list policies is only logged so that attaching is not blocked by
+// missing list permissions; the API then reports any problem itself.
+func (uc *SetSchedulePolicyUseCase) checkPolicyExists(ctx context.Context, project, region, policyName string) error {
+	policies, err := uc.policyRepo.List(ctx, project, region)
+	if err != nil {
+		uc.logger.Warnf("Could not verify schedule policy %s: %v", policyName, err)
+		return nil
+	}
+
+	names := make([]string, 0, len(policies))
+	for _, p := range policies {
+		if p.Name == policyName {
+			return nil
+		}
+		names = append(names, p.Name)
+	}
+
+	if len(names) == 0 {
+		return fmt.Errorf("%w: %s in %s/%s (no instance schedule policies exist there; create one with `gcectl policy create`)",
+			model.ErrSchedulePolicyNotFound, policyName, project, region)
+	}
+	return fmt.Errorf("%w: %s in %s/%s (available: %s)",
+		model.ErrSchedulePolicyNotFound, policyName, project, region, strings.Join(names, ", "))
+}
@@ -24,6 +24,8 @@ func TestSetSchedulePolicyUseCase_Execute(t *testing.T) {
 		policyName  string
 		errContains string
 		setupMock   func(*mock_repository.MockVMRepository)
+		policies    []*model.SchedulePolicy
+		listErr     error
 		wantErr     bool
 	}{
 		{
@@ -51,7 +53,8 @@ func TestSetSchedulePolicyUseCase_Execute(t *testing.T) {
 						return nil
 					})
 			},
-			wantErr: false,
+			policies: []*model.SchedulePolicy{{Name: "my-schedule-policy"}},
+			wantErr:  false,
 		},
 		{
 			name:       "error: VM not found",
@@ -97,9 +100,43 @@ func TestSetSchedulePolicyUseCase_Execute(t *testing.T) {
 						return errors.New("GCP API error")
 					})
 			},
+			policies:    []*model.SchedulePolicy{{Name: "my-schedule-policy"}},
 			wantErr:     true,
 			errContains: "failed to set schedule policy",
 		},
+		{
+			name:       "error: policy does not exist",
+			project:    "test-project",
+			zone:       "us-central1-a",
+			vmName:     "test-vm",
+			policyName: "typo-policy",
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				vm := &model.VM{Name: "test-vm", Project: "test-project", Zone: "us-central1-a", Status: model.StatusRunning}
+				m.EXPECT().
+					FindByName(gomock.Any(), gomock.Any()).
+					DoAndReturn(testhelpers.VMFindByNameMatcher(t, vm, vm, nil))
+				m.EXPECT().SetSchedulePolicy(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			policies:    []*model.SchedulePolicy{{Name: "nightly-stop"}, {Name: "weekday-stop"}},
+			wantErr:     true,
+			errContains: "available: nightly-stop, weekday-stop",
+		},
+		{
+			name:       "success: attach proceeds when policies cannot be listed",
+			project:    "test-project",
+			zone:       "us-central1-a",
+			vmName:     "test-vm",
+			policyName: "my-schedule-policy",
+			setupMock: func(m *mock_repository.MockVMRepository) {
+				vm := &model.VM{Name: "test-vm", Project: "test-project", Zone: "us-central1-a", Status: model.StatusRunning}
+				m.EXPECT().
+					FindByName(gomock.Any(), gomock.Any()).
+					DoAndReturn(testhelpers.VMFindByNameMatcher(t, vm, vm, nil))
+				m.EXPECT().SetSchedulePolicy(gomock.Any(), vm, "my-schedule-policy").Return(nil)
+			},
+			listErr: errors.New("permission denied"),
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -109,8 +146,10 @@ func TestSetSchedulePolicyUseCase_Execute(t *testing.T) {
 
 			mockRepo := mock_repository.NewMockVMRepository(ctrl)
 			tt.setupMock(mockRepo)
+			mockPolicyRepo := mock_repository.NewMockSchedulePolicyRepository(ctrl)
+			mockPolicyRepo.EXPECT().List(gomock.Any(), tt.project, "us-central1").Return(tt.policies, tt.listErr).AnyTimes()
 
-			usecase := NewSetSchedulePolicyUseCase(mockRepo, loggerForSetSchedule)
+			usecase := NewSetSchedulePolicyUseCase(mockRepo, mockPolicyRepo, loggerForSetSchedule)
 			err := usecase.Execute(context.Background(), tt.project, tt.zone, tt.vmName, tt.policyName)
 
 			if tt.wantErr {
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// SetSchedulingOptionsUseCase handles updating the automatic restart and
+// on-host-maintenance options of a VM
+type SetSchedulingOptionsUseCase struct {
+	vmRepo repository.VMRepository
+	logger log.Logger
+}
+
+// NewSetSchedulingOptionsUseCase creates a new instance of SetSchedulingOptionsUseCase
+func NewSetSchedulingOptionsUseCase(vmRepo repository.VMRepository, logger log.Logger) *SetSchedulingOptionsUseCase {
+	return &SetSchedulingOptionsUseCase{vmRepo: vmRepo, logger: logger}
+}
+
+// Execute updates the scheduling options of a VM that are set in opts.
+//
+// The options are checked against the VM before anything is changed:
+// Spot VMs and VMs with GPUs cannot live-migrate, so MIGRATE is rejected for them,
+// and Spot VMs cannot be restarted automatically.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - project: The GCP project ID
+//   - zone: The GCP zone
+//   - name: The VM instance name
+//   - opts: The options to change; unset fields keep their current value
+//
+// Returns:
+//   - error: nil on success, otherwise an error describing what went wrong
+//
+// Example:
+//
+//	usecase := NewSetSchedulingOptionsUseCase(vmRepo, logger)
+//	autoRestart := false
+//	err := usecase.Execute(ctx, "my-project", "us-central1-a", "my-vm", model.SchedulingOptions{
+//	    AutomaticRestart:  &autoRestart,
+//	    OnHostMaintenance: model.OnHostMaintenanceTerminate,
+//	})
+func (uc *SetSchedulingOptionsUseCase) Execute(ctx context.Context, project, zone, name string, opts model.SchedulingOptions) error {
+	// 1. 入力チェック
+	if opts.IsEmpty() {
+		return errors.New("no scheduling option to change")
+	}
+	switch opts.OnHostMaintenance {
+	case "", model.OnHostMaintenanceMigrate, model.OnHostMaintenanceTerminate:
+	default:
+		return fmt.Errorf("on-host-maintenance must be %s or %s: %s", model.OnHostMaintenanceMigrate, model.OnHostMaintenanceTerminate, opts.OnHostMaintenance)
+	}
+
+	// 2. VMを取得
+	vm := &model.VM{
+		Project: project,
+		Zone:    zone,
+		Name:    name,
+	}
+	foundVM, err := uc.vmRepo.FindByName(ctx, vm)
+	if err != nil {
+		return fmt.Errorf("failed to find VM: %w", err)
+	}
+	if foundVM == nil {
+		return fmt.Errorf("VM %s: %w", name, model.ErrVMNotFound)
+	}
+
+	// 3. VMの構成との整合性チェック
+	if opts.OnHostMaintenance == model.OnHostMaintenanceMigrate {
+		if len(foundVM.Accelerators) > 0 {
+			return fmt.Errorf("VM %s has GPUs attached and cannot use on-host-maintenance %s", foundVM.Name, model.OnHostMaintenanceMigrate)
+		}
+		if foundVM.Scheduling != nil && foundVM.Scheduling.IsSpot() {
+			return fmt.Errorf("VM %s is a Spot VM and cannot use on-host-maintenance %s", foundVM.Name, model.OnHostMaintenanceMigrate)
+		}
+	}
+	if opts.AutomaticRestart != nil && *opts.AutomaticRestart && foundVM.Scheduling != nil && foundVM.Scheduling.IsSpot() {
+		return fmt.Errorf("VM %s is a Spot VM and cannot be restarted automatically", foundVM.Name)
+	}
+
+	// 4. スケジューリング設定を更新
+	if setErr := uc.vmRepo.SetSchedulingOptions(ctx, foundVM, opts); setErr != nil {
+		return fmt.Errorf("failed to set scheduling options: %w", setErr)
+	}
+
+	uc.logger.Infof("✓ Successfully updated scheduling options for VM %s", foundVM.Name)
+	return nil
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var loggerForSetSchedulingOptions = log.NewLogger()
+
+func TestSetSchedulingOptionsUseCase_Execute(t *testing.T) {
+	enabled, disabled := true, false
+	standard := &model.VM{Name: "vm-1", Project: "proj", Zone: "z", Status: model.StatusRunning,
+		Scheduling: &model.Scheduling{OnHostMaintenance: model.OnHostMaintenanceMigrate}}
+	spot := &model.VM{Name: "vm-1", Project: "proj", Zone: "z", Status: model.StatusRunning,
+		Scheduling: &model.Scheduling{ProvisioningModel: "SPOT", OnHostMaintenance: model.OnHostMaintenanceTerminate}}
+	withGPU := &model.VM{Name: "vm-1", Project: "proj", Zone: "z", Status: model.StatusTerminated,
+		Accelerators: []model.Accelerator{{Type: "nvidia-tesla-t4", Count: 1}}}
+
+	//nolint:govet // Test struct field order optimized for readability
+	tests := []struct {
+		name        string
+		vm          *model.VM
+		opts        model.SchedulingOptions
+		findErr     error
+		setErr      error
+		wantFind    bool
+		wantSet     bool
+		errContains string
+	}{
+		{name: "success: disable automatic restart", vm: standard, opts: model.SchedulingOptions{AutomaticRestart: &disabled}, wantFind: true, wantSet: true},
+		{name: "success: terminate on host maintenance", vm: standard, opts: model.SchedulingOptions{OnHostMaintenance: model.OnHostMaintenanceTerminate}, wantFind: true, wantSet: true},
+		{name: "success: spot VM without automatic restart", vm: spot, opts: model.SchedulingOptions{AutomaticRestart: &disabled}, wantFind: true, wantSet: true},
+		{name: "error: nothing to change", vm: standard, errContains: "no scheduling option"},
+		{name: "error: invalid on-host-maintenance", vm: standard, opts: model.SchedulingOptions{OnHostMaintenance: "RESTART"}, errContains: "must be MIGRATE or TERMINATE"},
+		{name: "error: VM not found", vm: standard, opts: model.SchedulingOptions{AutomaticRestart: &disabled}, findErr: errors.New("not found"), wantFind: true, errContains: "failed to find VM"},
+		{name: "error: migrate with GPUs", vm: withGPU, opts: model.SchedulingOptions{OnHostMaintenance: model.OnHostMaintenanceMigrate}, wantFind: true, errContains: "GPUs attached"},
+		{name: "error: migrate spot VM", vm: spot, opts: model.SchedulingOptions{OnHostMaintenance: model.OnHostMaintenanceMigrate}, wantFind: true, errContains: "Spot VM"},
+		{name: "error: automatic restart of spot VM", vm: spot, opts: model.SchedulingOptions{AutomaticRestart: &enabled}, wantFind: true, errContains: "restarted automatically"},
+		{name: "error: repository fails", vm: standard, opts: model.SchedulingOptions{AutomaticRestart: &disabled}, setErr: errors.New("denied"), wantFind: true, wantSet: true, errContains: "failed to set scheduling options"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repository.NewMockVMRepository(ctrl)
+			if tt.wantFind {
+				repo.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(tt.vm, tt.findErr)
+			}
+			if tt.wantSet {
+				repo.EXPECT().SetSchedulingOptions(gomock.Any(), tt.vm, tt.opts).Return(tt.setErr)
+			}
+
+			err := NewSetSchedulingOptionsUseCase(repo, loggerForSetSchedulingOptions).
+				Execute(context.Background(), "proj", "z", "vm-1", tt.opts)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// SnoozeScheduleUseCase temporarily detaches a VM's stop schedule policy.
+type SnoozeScheduleUseCase struct {
+	vmRepo repository.VMRepository
+	store  repository.SnoozeStore
+	logger log.Logger
+	now    func() time.Time
+}
+
+// NewSnoozeScheduleUseCase creates a new instance of SnoozeScheduleUseCase
+func NewSnoozeScheduleUseCase(vmRepo repository.VMRepository, store repository.SnoozeStore, logger log.Logger) *SnoozeScheduleUseCase {
+	return &SnoozeScheduleUseCase{vmRepo: vmRepo, store: store, logger: logger, now: time.Now}
+}
+
+// Execute snoozes the stop schedule of a VM for the given duration.
+//
+// This method performs the following steps:
+// 1. Retrieves the VM instance from the repository
+// 2. Records the snooze so the policy can be re-attached later
+// 3. Detaches the schedule policy from the VM
+//
+// The snooze is recorded before the policy is detached so that a detached policy
+// is never forgotten. Snoozing an already snoozed VM only moves its end time.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - project: The GCP project ID
+//   - zone: The GCP zone
+//   - name: The VM instance name
+//   - d: How long to keep the policy detached
+//
+// Returns:
+//   - *model.Snooze: The recorded snooze
+//   - error: nil on success, otherwise an error describing what went wrong
+//
+// Error conditions:
+//   - Non-positive duration
+//   - VM not found: when the VM does not exist in the specified project/zone
+//   - No stop schedule: when the VM has no schedule policy with a stop-cron
+//   - Detach failed: when the GCP API call fails; the snooze record is then removed again
+//
+// Example:
+//
+//	usecase := NewSnoozeScheduleUseCase(vmRepo, store, logger)
+//	snooze, err := usecase.Execute(ctx, "my-project", "us-central1-a", "my-vm", 4*time.Hour)
+//	if err != nil {
+//	    log.Fatalf("Failed to snooze schedule: %v", err)
+//	}
+//	fmt.Printf("re-attaching %s at %s\n", snooze.Policy, snooze.Until)
+func (uc *SnoozeScheduleUseCase) Execute(ctx context.Context, project, zone, name string, d time.Duration) (*model.Snooze, error) {
+	if d <= 0 {
+		return nil, fmt.Errorf("snooze duration must be positive: %s", d)
+	}
+
+	vm := &model.VM{Project: project, Zone: zone, Name: name}
+	until := uc.now().Add(d)
+
+	// 既にスヌーズ中ならポリシーは外れているので終了時刻だけ更新する
+	if existing, ok := uc.store.Get(vm); ok {
+		existing.Until = until
+		if putErr := uc.store.Put(existing); putErr != nil {
+			return nil, fmt.Errorf("failed to record snooze: %w", putErr)
+		}
+		uc.logger.Infof("✓ Extended snooze of %s on VM %s until %s", existing.Policy, name, until.Format(time.RFC3339))
+		return existing, nil
+	}
+
+	foundVM, err := uc.vmRepo.FindByName(ctx, vm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find VM: %w", err)
+	}
+	if foundVM.Schedule == nil || foundVM.Schedule.StopCron == "" {
+		return nil, fmt.Errorf("VM %s has no stop schedule policy to snooze", name)
+	}
+
+	snooze := &model.Snooze{
+		Until:   until,
+		VMName:  foundVM.Name,
+		Project: project,
+		Zone:    zone,
+		Policy:  foundVM.Schedule.Name,
+	}
+	if putErr := uc.store.Put(snooze); putErr != nil {
+		return nil, fmt.Errorf("failed to record snooze: %w", putErr)
+	}
+
+	if unsetErr := uc.vmRepo.UnsetSchedulePolicy(ctx, foundVM, snooze.Policy); unsetErr != nil {
+		if deleteErr := uc.store.Delete(vm); deleteErr != nil {
+			uc.logger.Warnf("Failed to remove snooze record of VM %s: %v", name, deleteErr)
+		}
+		return nil, fmt.Errorf("failed to detach schedule policy: %w", unsetErr)
+	}
+
+	uc.logger.Infof("✓ Snoozed schedule policy %s on VM %s until %s", snooze.Policy, name, until.Format(time.RFC3339))
+	return snooze, nil
+}
+
+// ResumeScheduleUseCase re-attaches schedule policies detached by SnoozeScheduleUseCase.
+type ResumeScheduleUseCase struct {
+	vmRepo repository.VMRepository
+	store  repository.SnoozeStore
+	logger log.Logger
+}
+
+// NewResumeScheduleUseCase creates a new instance of ResumeScheduleUseCase
+func NewResumeScheduleUseCase(vmRepo repository.VMRepository, store repository.SnoozeStore, logger log.Logger) *ResumeScheduleUseCase {
+	return &ResumeScheduleUseCase{vmRepo: vmRepo, store: store, logger: logger}
+}
+
+// Execute ends the snooze of a VM now, regardless of its end time.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - vm: The VM to resume, identified by project, zone and name
+//
+// Returns:
+//   - *model.Snooze: The snooze that was ended
+//   - error: An error if the VM is not snoozed or the policy cannot be re-attached
+func (uc *ResumeScheduleUseCase) Execute(ctx context.Context, vm *model.VM) (*model.Snooze, error) {
+	snooze, ok := uc.store.Get(vm)
+	if !ok {
+		return nil, fmt.Errorf("VM %s is not snoozed", vm.Name)
+	}
+	if err := uc.resume(ctx, snooze); err != nil {
+		return nil, err
+	}
+	return snooze, nil
+}
+
+// ExecuteExpired re-attaches the policies of all snoozes that have ended at now.
+// Every expired snooze is attempted; failures are joined into the returned error
+// and their records are kept so the next run retries them.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - now: The time snooze end times are compared against
+//
+// Returns:
+//   - []*model.Snooze: The snoozes that were ended successfully
+//   - error: The joined errors of the snoozes that could not be ended
+func (uc *ResumeScheduleUseCase) ExecuteExpired(ctx context.Context, now time.Time) ([]*model.Snooze, error) {
+	var (
+		resumed []*model.Snooze
+		errs    []error
+	)
+	for _, snooze := range uc.store.List() {
+		if !snooze.Expired(now) {
+			continue
+		}
+		if err := uc.resume(ctx, snooze); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		resumed = append(resumed, snooze)
+	}
+	return resumed, errors.Join(errs...)
+}
+
+// resume re-attaches the snoozed policy and then removes the snooze record.
+func (uc *ResumeScheduleUseCase) resume(ctx context.Context, snooze *model.Snooze) error {
+	vm := snooze.VM()
+	if err := uc.vmRepo.SetSchedulePolicy(ctx, vm, snooze.Policy); err != nil {
+		return fmt.Errorf("VM %s: failed to re-attach schedule policy %s: %w", snooze.VMName, snooze.Policy, err)
+	}
+	if err := uc.store.Delete(vm); err != nil {
+		return fmt.Errorf("VM %s: failed to remove snooze record: %w", snooze.VMName, err)
+	}
+	uc.logger.Infof("✓ Re-attached schedule policy %s to VM %s", snooze.Policy, snooze.VMName)
+	return nil
+}
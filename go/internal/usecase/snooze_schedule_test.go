@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSnoozeScheduleUseCase_Execute(t *testing.T) {
+	now := time.Date(2024, 1, 10, 22, 0, 0, 0, time.UTC)
+	vm := &model.VM{Name: "vm-1", Project: "proj", Zone: "us-central1-a"}
+	scheduled := &model.VM{
+		Name:     "vm-1",
+		Project:  "proj",
+		Zone:     "us-central1-a",
+		Schedule: &model.SchedulePolicy{Name: "nightly-stop", StopCron: "0 23 * * *"},
+	}
+	want := &model.Snooze{Until: now.Add(4 * time.Hour), VMName: "vm-1", Project: "proj", Zone: "us-central1-a", Policy: "nightly-stop"}
+
+	//nolint:govet // field alignment is less important than readability in tests
+	tests := []struct {
+		name    string
+		d       time.Duration
+		setup   func(vmRepo *mock_repository.MockVMRepository, store *mock_repository.MockSnoozeStore)
+		want    *model.Snooze
+		wantErr string
+	}{
+		{
+			name: "success: detaches the policy after recording the snooze",
+			d:    4 * time.Hour,
+			setup: func(vmRepo *mock_repository.MockVMRepository, store *mock_repository.MockSnoozeStore) {
+				store.EXPECT().Get(vm).Return(nil, false)
+				vmRepo.EXPECT().FindByName(gomock.Any(), vm).Return(scheduled, nil)
+				gomock.InOrder(
+					store.EXPECT().Put(want).Return(nil),
+					vmRepo.EXPECT().UnsetSchedulePolicy(gomock.Any(), scheduled, "nightly-stop").Return(nil),
+				)
+			},
+			want: want,
+		},
+		{
+			name: "success: extends an existing snooze without touching the VM",
+			d:    4 * time.Hour,
+			setup: func(vmRepo *mock_repository.MockVMRepository, store *mock_repository.MockSnoozeStore) {
+				store.EXPECT().Get(vm).Return(&model.Snooze{Until: now, VMName: "vm-1", Project: "proj", Zone: "us-central1-a", Policy: "nightly-stop"}, true)
+				store.EXPECT().Put(want).Return(nil)
+			},
+			want: want,
+		},
+		{
+			name:    "error: non-positive duration",
+			d:       0,
+			setup:   func(*mock_repository.MockVMRepository, *mock_repository.MockSnoozeStore) {},
+			wantErr: "must be positive",
+		},
+		{
+			name: "error: no stop schedule",
+			d:    time.Hour,
+			setup: func(vmRepo *mock_repository.MockVMRepository, store *mock_repository.MockSnoozeStore) {
+				store.EXPECT().Get(vm).Return(nil, false)
+				vmRepo.EXPECT().FindByName(gomock.Any(), vm).Return(&model.VM{Name: "vm-1"}, nil)
+			},
+			wantErr: "no stop schedule policy",
+		},
+		{
+			name: "error: detach failure removes the record",
+			d:    4 * time.Hour,
+			setup: func(vmRepo *mock_repository.MockVMRepository, store *mock_repository.MockSnoozeStore) {
+				store.EXPECT().Get(vm).Return(nil, false)
+				vmRepo.EXPECT().FindByName(gomock.Any(), vm).Return(scheduled, nil)
+				store.EXPECT().Put(want).Return(nil)
+				vmRepo.EXPECT().UnsetSchedulePolicy(gomock.Any(), scheduled, "nightly-stop").Return(errors.New("permission denied"))
+				store.EXPECT().Delete(vm).Return(nil)
+			},
+			wantErr: "failed to detach schedule policy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			vmRepo := mock_repository.NewMockVMRepository(ctrl)
+			store := mock_repository.NewMockSnoozeStore(ctrl)
+			tt.setup(vmRepo, store)
+
+			uc := NewSnoozeScheduleUseCase(vmRepo, store, log.NewLogger())
+			uc.now = func() time.Time { return now }
+			got, err := uc.Execute(context.Background(), "proj", "us-central1-a", "vm-1", tt.d)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResumeScheduleUseCase_Execute(t *testing.T) {
+	vm := &model.VM{Name: "vm-1", Project: "proj", Zone: "z"}
+	snooze := &model.Snooze{VMName: "vm-1", Project: "proj", Zone: "z", Policy: "nightly-stop"}
+
+	t.Run("success: re-attaches and removes the record", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		vmRepo := mock_repository.NewMockVMRepository(ctrl)
+		store := mock_repository.NewMockSnoozeStore(ctrl)
+		store.EXPECT().Get(vm).Return(snooze, true)
+		gomock.InOrder(
+			vmRepo.EXPECT().SetSchedulePolicy(gomock.Any(), vm, "nightly-stop").Return(nil),
+			store.EXPECT().Delete(vm).Return(nil),
+		)
+
+		got, err := NewResumeScheduleUseCase(vmRepo, store, log.NewLogger()).Execute(context.Background(), vm)
+		require.NoError(t, err)
+		assert.Equal(t, snooze, got)
+	})
+
+	t.Run("error: not snoozed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mock_repository.NewMockSnoozeStore(ctrl)
+		store.EXPECT().Get(vm).Return(nil, false)
+
+		_, err := NewResumeScheduleUseCase(mock_repository.NewMockVMRepository(ctrl), store, log.NewLogger()).Execute(context.Background(), vm)
+		assert.ErrorContains(t, err, "is not snoozed")
+	})
+}
+
+func TestResumeScheduleUseCase_ExecuteExpired(t *testing.T) {
+	now := time.Date(2024, 1, 11, 2, 0, 0, 0, time.UTC)
+	expired := &model.Snooze{Until: now.Add(-time.Minute), VMName: "expired", Project: "p", Zone: "z", Policy: "nightly-stop"}
+	failing := &model.Snooze{Until: now, VMName: "failing", Project: "p", Zone: "z", Policy: "nightly-stop"}
+	active := &model.Snooze{Until: now.Add(time.Hour), VMName: "active", Project: "p", Zone: "z", Policy: "nightly-stop"}
+
+	ctrl := gomock.NewController(t)
+	vmRepo := mock_repository.NewMockVMRepository(ctrl)
+	store := mock_repository.NewMockSnoozeStore(ctrl)
+	store.EXPECT().List().Return([]*model.Snooze{expired, failing, active})
+	vmRepo.EXPECT().SetSchedulePolicy(gomock.Any(), expired.VM(), "nightly-stop").Return(nil)
+	store.EXPECT().Delete(expired.VM()).Return(nil)
+	vmRepo.EXPECT().SetSchedulePolicy(gomock.Any(), failing.VM(), "nightly-stop").Return(errors.New("quota exceeded"))
+
+	resumed, err := NewResumeScheduleUseCase(vmRepo, store, log.NewLogger()).ExecuteExpired(context.Background(), now)
+	assert.ErrorContains(t, err, "VM failing")
+	assert.Equal(t, []*model.Snooze{expired}, resumed)
+}
@@ -2,7 +2,9 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/haru-256/gcectl/internal/domain/model"
 	"github.com/haru-256/gcectl/internal/domain/repository"
@@ -10,15 +12,113 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
+// VMProgress is told the phase each VM of a bulk start or stop is in. finished is
+// true for the last report of a VM. LiveVMProgress.Update satisfies it.
+type VMProgress func(vmName, phase string, finished bool)
+
+// Phases reported to VMProgress.
+const (
+	PhaseChecking = "checking"
+	PhaseStarting = "starting"
+	PhaseStopping = "stopping"
+	// PhaseShuttingDown is a graceful stop shutting the guest down from inside.
+	PhaseShuttingDown = "shutting down"
+	PhaseDone         = "done"
+	PhaseFailed       = "failed"
+)
+
+// ignoreVMProgress is the default VMProgress; it drops the reports.
+func ignoreVMProgress(string, string, bool) {}
+
+// VMResults receives the result of each VM of Execute once the VM is done. It
+// is called concurrently for different VMs.
+type VMResults func(result model.VMResult)
+
+// ignoreVMResults is the default VMResults; it drops the results.
+func ignoreVMResults(model.VMResult) {}
+
+// vmResult builds the result of an action on the VM vmName. A VM that failed
+// once ctx was canceled, by an interrupt or the failure of another VM, is
+// reported as canceled rather than with the error the cancellation caused.
+func vmResult(ctx context.Context, vmName, action string, duration time.Duration, err error) model.VMResult {
+	if err != nil && ctx.Err() != nil {
+		err = errors.Join(context.Canceled, err)
+	}
+	return model.VMResult{VM: vmName, Action: action, Duration: duration, Err: err}
+}
+
+// reportResult reports the last phase of a VM depending on err and returns err.
+func reportResult(progress VMProgress, vmName string, err error) error {
+	if err != nil {
+		progress(vmName, PhaseFailed, true)
+		return err
+	}
+	progress(vmName, PhaseDone, true)
+	return nil
+}
+
 // StartVMUseCase handles the business logic for starting a VM
 type StartVMUseCase struct {
-	vmRepo repository.VMRepository
-	logger log.Logger
+	vmRepo         repository.VMRepository
+	logger         log.Logger
+	checkBudget    BudgetCheck
+	progress       VMProgress
+	results        VMResults
+	maxConcurrency int
+	forceBudget    bool
 }
 
 // NewStartVMUseCase creates a new instance of StartVMUseCase
 func NewStartVMUseCase(vmRepo repository.VMRepository, logger log.Logger) *StartVMUseCase {
-	return &StartVMUseCase{vmRepo: vmRepo, logger: logger}
+	return &StartVMUseCase{vmRepo: vmRepo, logger: logger, progress: ignoreVMProgress, results: ignoreVMResults, maxConcurrency: maxConcurrentVMLookups}
+}
+
+// WithProgress makes Execute report the phase of each VM to progress.
+func (uc *StartVMUseCase) WithProgress(progress VMProgress) *StartVMUseCase {
+	uc.progress = progress
+	return uc
+}
+
+// WithResults makes Execute report the result of each VM to results.
+func (uc *StartVMUseCase) WithResults(results VMResults) *StartVMUseCase {
+	uc.results = results
+	return uc
+}
+
+// WithMaxConcurrency sets how many VMs are processed at once. Values <= 0 keep the default.
+func (uc *StartVMUseCase) WithMaxConcurrency(n int) *StartVMUseCase {
+	if n > 0 {
+		uc.maxConcurrency = n
+	}
+	return uc
+}
+
+// WithBudgetCheck makes Execute and ExecuteNoWait check the budget before starting
+// any VM. When force is true, exceeded budgets are logged as warnings instead of
+// refusing to start.
+func (uc *StartVMUseCase) WithBudgetCheck(check BudgetCheck, force bool) *StartVMUseCase {
+	uc.checkBudget = check
+	uc.forceBudget = force
+	return uc
+}
+
+// guardBudget runs the budget check, if any, downgrading exceeded budgets to warnings when forced.
+func (uc *StartVMUseCase) guardBudget(ctx context.Context, vms []*model.VM) error {
+	if uc.checkBudget == nil {
+		return nil
+	}
+	err := uc.checkBudget(ctx, vms)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, model.ErrBudgetExceeded) {
+		return fmt.Errorf("failed to check budget: %w", err)
+	}
+	if !uc.forceBudget {
+		return fmt.Errorf("%w (pass --force to start anyway)", err)
+	}
+	uc.logger.Warnf("Starting despite budget: %v", err)
+	return nil
 }
 
 // Execute starts multiple VM instances in parallel.
@@ -31,35 +131,100 @@ func NewStartVMUseCase(vmRepo repository.VMRepository, logger log.Logger) *Start
 // Returns:
 //   - error: nil on success, or error with VM name on failure
 func (uc *StartVMUseCase) Execute(ctx context.Context, vms []*model.VM) error {
+	if err := uc.guardBudget(ctx, vms); err != nil {
+		return err
+	}
+
 	// TOCTOU問題に対応するため、1つのgoroutineのなかでCheckとUseを実行する
 	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(uc.maxConcurrency)
 	for _, vm := range vms {
 		vm := vm // capture range variable
 		eg.Go(func() error {
-			// 1. VMが存在するか確認
+			started := time.Now()
+			err := uc.startOne(ctx, vm)
+			uc.results(vmResult(ctx, vm.Name, "start", time.Since(started), err))
+			return reportResult(uc.progress, vm.Name, err)
+		})
+	}
+
+	return eg.Wait()
+}
+
+// startOne checks that a single VM can be started and starts it.
+func (uc *StartVMUseCase) startOne(ctx context.Context, vm *model.VM) error {
+	// 1. VMが存在するか確認
+	uc.progress(vm.Name, PhaseChecking, false)
+	foundVM, err := uc.vmRepo.FindByName(ctx, vm)
+	if err != nil {
+		return fmt.Errorf("VM %s: failed to find: %w", vm.Name, err)
+	}
+	if foundVM == nil {
+		return fmt.Errorf("VM %s: %w", vm.Name, model.ErrVMNotFound)
+	}
+
+	// 2. ビジネスルールチェック
+	if !foundVM.CanStart() {
+		return model.InvalidStateErrorf("VM %s: cannot be started (current status: %s)",
+			foundVM.Name, foundVM.Status)
+	}
+
+	// 3. 起動実行
+	uc.progress(vm.Name, PhaseStarting, false)
+	if startErr := uc.vmRepo.Start(ctx, foundVM); startErr != nil {
+		return fmt.Errorf("VM %s: failed to start: %w", foundVM.Name, startErr)
+	}
+
+	uc.logger.Infof("✓ Successfully started VM %s", foundVM.Name)
+	return nil
+}
+
+// ExecuteNoWait issues start requests for multiple VMs in parallel without waiting for them to finish.
+// The same validation as Execute is applied before each request is sent.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout
+//   - vms: VMs to start (must contain Project, Zone, and Name)
+//
+// Returns:
+//   - []*model.Operation: The pending operations, in the same order as vms
+//   - error: nil on success, or error with VM name on failure
+func (uc *StartVMUseCase) ExecuteNoWait(ctx context.Context, vms []*model.VM) ([]*model.Operation, error) {
+	if err := uc.guardBudget(ctx, vms); err != nil {
+		return nil, err
+	}
+
+	ops := make([]*model.Operation, len(vms))
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(uc.maxConcurrency)
+	for i, vm := range vms {
+		eg.Go(func() error {
 			foundVM, err := uc.vmRepo.FindByName(ctx, vm)
 			if err != nil {
 				return fmt.Errorf("VM %s: failed to find: %w", vm.Name, err)
 			}
 			if foundVM == nil {
-				return fmt.Errorf("VM %s: not found", vm.Name)
+				return fmt.Errorf("VM %s: %w", vm.Name, model.ErrVMNotFound)
 			}
 
-			// 2. ビジネスルールチェック
 			if !foundVM.CanStart() {
-				return fmt.Errorf("VM %s: cannot be started (current status: %s)",
+				return model.InvalidStateErrorf("VM %s: cannot be started (current status: %s)",
 					foundVM.Name, foundVM.Status)
 			}
 
-			// 3. 起動実行
-			if startErr := uc.vmRepo.Start(ctx, foundVM); startErr != nil {
+			op, startErr := uc.vmRepo.StartAsync(ctx, foundVM)
+			if startErr != nil {
 				return fmt.Errorf("VM %s: failed to start: %w", foundVM.Name, startErr)
 			}
 
-			uc.logger.Infof("✓ Successfully started VM %s", foundVM.Name)
+			uc.logger.Infof("✓ Requested start of VM %s (operation %s)", foundVM.Name, op.Name)
+			ops[i] = op
 			return nil
 		})
 	}
 
-	return eg.Wait()
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return ops, nil
 }
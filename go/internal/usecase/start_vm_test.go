@@ -3,7 +3,10 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/haru-256/gcectl/internal/domain/model"
 	"github.com/haru-256/gcectl/internal/infrastructure/log"
@@ -253,3 +256,148 @@ func TestStartVMUseCase_Execute(t *testing.T) {
 		})
 	}
 }
+
+func TestStartVMUseCase_ExecuteNoWait(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	vms := []*model.VM{
+		{Project: "test-project", Zone: "us-central1-a", Name: "vm-1"},
+		{Project: "test-project", Zone: "us-central1-a", Name: "vm-2"},
+	}
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+	mockRepo.EXPECT().
+		FindByName(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(ctx context.Context, vm *model.VM) (*model.VM, error) {
+			return &model.VM{Project: vm.Project, Zone: vm.Zone, Name: vm.Name, Status: model.StatusStopped}, nil
+		})
+	mockRepo.EXPECT().
+		StartAsync(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(ctx context.Context, vm *model.VM) (*model.Operation, error) {
+			return &model.Operation{Project: vm.Project, Zone: vm.Zone, Name: "op-" + vm.Name, Status: "RUNNING"}, nil
+		})
+	mockRepo.EXPECT().Start(gomock.Any(), gomock.Any()).Times(0)
+
+	ops, err := NewStartVMUseCase(mockRepo, logger).ExecuteNoWait(context.Background(), vms)
+	assert.NoError(t, err)
+	assert.Len(t, ops, 2)
+	assert.Equal(t, "op-vm-1", ops[0].Name)
+	assert.Equal(t, "op-vm-2", ops[1].Name)
+}
+
+func TestStartVMUseCase_ExecuteNoWaitRejectsRunningVM(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+	mockRepo.EXPECT().
+		FindByName(gomock.Any(), gomock.Any()).
+		Return(&model.VM{Name: "vm-1", Status: model.StatusRunning}, nil)
+	mockRepo.EXPECT().StartAsync(gomock.Any(), gomock.Any()).Times(0)
+
+	ops, err := NewStartVMUseCase(mockRepo, logger).ExecuteNoWait(context.Background(), []*model.VM{{Name: "vm-1"}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be started")
+	assert.Nil(t, ops)
+}
+
+func TestStartVMUseCase_ExecuteRespectsMaxConcurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	vms := make([]*model.VM, 6)
+	for i := range vms {
+		vms[i] = &model.VM{Project: "test-project", Zone: "us-central1-a", Name: fmt.Sprintf("vm-%d", i)}
+	}
+
+	var inFlight, maxInFlight int32
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+	mockRepo.EXPECT().
+		FindByName(gomock.Any(), gomock.Any()).
+		Times(len(vms)).
+		DoAndReturn(func(ctx context.Context, vm *model.VM) (*model.VM, error) {
+			return &model.VM{Project: vm.Project, Zone: vm.Zone, Name: vm.Name, Status: model.StatusStopped}, nil
+		})
+	mockRepo.EXPECT().
+		Start(gomock.Any(), gomock.Any()).
+		Times(len(vms)).
+		DoAndReturn(func(ctx context.Context, vm *model.VM) error {
+			current := atomic.AddInt32(&inFlight, 1)
+			for {
+				previous := atomic.LoadInt32(&maxInFlight)
+				if current <= previous || atomic.CompareAndSwapInt32(&maxInFlight, previous, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			return nil
+		})
+
+	err := NewStartVMUseCase(mockRepo, logger).WithMaxConcurrency(2).Execute(context.Background(), vms)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+}
+
+func TestStartVMUseCase_BudgetCheck(t *testing.T) {
+	vm := &model.VM{Project: "test-project", Zone: "us-central1-a", Name: "gpu"}
+	exceeded := func(context.Context, []*model.VM) error {
+		return &model.BudgetExceededError{VMName: "gpu", Cap: 100, Projected: 120}
+	}
+
+	t.Run("refuses when budget is exceeded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uc := NewStartVMUseCase(mock_repository.NewMockVMRepository(ctrl), logger).WithBudgetCheck(exceeded, false)
+		err := uc.Execute(context.Background(), []*model.VM{vm})
+		assert.ErrorIs(t, err, model.ErrBudgetExceeded)
+		assert.ErrorContains(t, err, "--force")
+
+		_, err = uc.ExecuteNoWait(context.Background(), []*model.VM{vm})
+		assert.ErrorIs(t, err, model.ErrBudgetExceeded)
+	})
+
+	t.Run("starts with force", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mock_repository.NewMockVMRepository(ctrl)
+		found := &model.VM{Project: "test-project", Zone: "us-central1-a", Name: "gpu", Status: model.StatusTerminated}
+		repo.EXPECT().FindByName(gomock.Any(), vm).Return(found, nil)
+		repo.EXPECT().Start(gomock.Any(), found).Return(nil)
+
+		uc := NewStartVMUseCase(repo, logger).WithBudgetCheck(exceeded, true)
+		assert.NoError(t, uc.Execute(context.Background(), []*model.VM{vm}))
+	})
+
+	t.Run("check failure is not bypassed by force", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		failing := func(context.Context, []*model.VM) error { return errors.New("denied") }
+		uc := NewStartVMUseCase(mock_repository.NewMockVMRepository(ctrl), logger).WithBudgetCheck(failing, true)
+		assert.ErrorContains(t, uc.Execute(context.Background(), []*model.VM{vm}), "failed to check budget: denied")
+	})
+}
+
+func TestStartVMUseCase_ExecuteReportsFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+
+	running := &model.VM{Name: "vm1", Project: "p", Zone: "z", Status: model.StatusRunning}
+	mockRepo.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(running, nil)
+
+	var phases []string
+	uc := NewStartVMUseCase(mockRepo, logger).WithProgress(func(_, phase string, finished bool) {
+		assert.Equal(t, phase == PhaseFailed, finished)
+		phases = append(phases, phase)
+	})
+
+	err := uc.Execute(context.Background(), []*model.VM{{Name: "vm1", Project: "p", Zone: "z"}})
+
+	assert.Error(t, err)
+	assert.Equal(t, []string{PhaseChecking, PhaseFailed}, phases)
+}
@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	"golang.org/x/sync/errgroup"
+)
+
+// Placement describes where a VM was started.
+type Placement struct {
+	// VM is the started VM (Name, Project and Zone). Its Zone differs from the
+	// configured one when the VM landed in a fallback zone.
+	VM *model.VM
+	// MachineImage is the machine image the VM was recreated from in a fallback
+	// zone, or "" when an existing instance was started.
+	MachineImage string
+}
+
+// StartWithFailoverUseCase starts VMs, moving on to fallback zones when a zone is
+// out of capacity.
+type StartWithFailoverUseCase struct {
+	start     *StartVMUseCase
+	vmRepo    repository.VMRepository
+	imageRepo repository.MachineImageRepository
+	logger    log.Logger
+	now       func() time.Time
+}
+
+// NewStartWithFailoverUseCase creates a new instance of StartWithFailoverUseCase.
+// The start use case is used for the VMs' own zones, including its budget check.
+func NewStartWithFailoverUseCase(start *StartVMUseCase, vmRepo repository.VMRepository, imageRepo repository.MachineImageRepository, logger log.Logger) *StartWithFailoverUseCase {
+	return &StartWithFailoverUseCase{
+		start:     start,
+		vmRepo:    vmRepo,
+		imageRepo: imageRepo,
+		logger:    logger,
+		now:       time.Now,
+	}
+}
+
+// Execute starts multiple VMs in parallel, failing over to other zones on
+// ZONE_RESOURCE_POOL_EXHAUSTED.
+//
+// Each VM is first started in its own zone. If that zone has no capacity, its
+// fallback zones are tried in order: an instance of the same name already in a
+// fallback zone (left by an earlier failover) is started, otherwise the VM is
+// recreated there from a machine image of it. The original instance is kept,
+// stopped, in its zone. Like StartVMUseCase.Execute, the first failure cancels
+// the others.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - vms: VMs to start (must contain Project, Zone, and Name)
+//   - fallbackZones: Zones to try in order, keyed by VM name; VMs without any are started normally
+//
+// Returns:
+//   - []*Placement: Where each VM was started, in the same order as vms
+//   - error: nil on success, or error with VM name on failure
+//
+// Example:
+//
+//	usecase := NewStartWithFailoverUseCase(startVMUseCase, vmRepo, imageRepo, logger)
+//	placements, err := usecase.Execute(ctx, vms, map[string][]string{"gpu-box": {"us-central1-b", "us-central1-c"}})
+func (uc *StartWithFailoverUseCase) Execute(ctx context.Context, vms []*model.VM, fallbackZones map[string][]string) ([]*Placement, error) {
+	if err := uc.start.guardBudget(ctx, vms); err != nil {
+		return nil, err
+	}
+
+	placements := make([]*Placement, len(vms))
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(uc.start.maxConcurrency)
+	for i, vm := range vms {
+		eg.Go(func() error {
+			started := uc.now()
+			placement, err := uc.startWithFailover(ctx, vm, fallbackZones[vm.Name])
+			placements[i] = placement
+			uc.start.results(vmResult(ctx, vm.Name, "start", uc.now().Sub(started), err))
+			return reportResult(uc.start.progress, vm.Name, err)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return placements, nil
+}
+
+// startWithFailover starts vm in its zone, then in each of zones until one has capacity.
+func (uc *StartWithFailoverUseCase) startWithFailover(ctx context.Context, vm *model.VM, zones []string) (*Placement, error) {
+	// 1. 本来のゾーンで起動
+	err := uc.start.startOne(ctx, vm)
+	if err == nil {
+		return &Placement{VM: vm}, nil
+	}
+	if !errors.Is(err, model.ErrZoneResourceExhausted) || len(zones) == 0 {
+		return nil, err
+	}
+
+	// 2. 代替ゾーンを順に試す
+	var imageName string
+	exhausted := vm.Zone
+	for _, zone := range zones {
+		uc.logger.Warnf("VM %s: no capacity in %s, trying %s", vm.Name, exhausted, zone)
+		uc.start.progress(vm.Name, PhaseStarting+" in "+zone, false)
+		target := &model.VM{Project: vm.Project, Zone: zone, Name: vm.Name}
+
+		existing, findErr := uc.vmRepo.FindByName(ctx, target)
+		if findErr != nil {
+			return nil, fmt.Errorf("VM %s: failed to find in %s: %w", vm.Name, zone, findErr)
+		}
+		if existing != nil {
+			// 以前のフェイルオーバーで作成済みのインスタンスを起動
+			if !existing.CanStart() {
+				return nil, fmt.Errorf("VM %s: already exists in %s (current status: %s)", vm.Name, zone, existing.Status)
+			}
+			err = uc.vmRepo.Start(ctx, existing)
+		} else {
+			// マシンイメージから作り直す (イメージは最初の1回だけ作成)
+			if imageName == "" {
+				imageName = model.MachineImageName(vm.Name, uc.now())
+				if imageErr := uc.imageRepo.Create(ctx, vm, imageName); imageErr != nil {
+					return nil, fmt.Errorf("VM %s: failed to create machine image: %w", vm.Name, imageErr)
+				}
+			}
+			err = uc.imageRepo.CreateInstance(ctx, imageName, target)
+		}
+
+		switch {
+		case err == nil:
+			placement := &Placement{VM: target}
+			if existing == nil {
+				placement.MachineImage = imageName
+			}
+			uc.logger.Infof("✓ Started VM %s in fallback zone %s", vm.Name, zone)
+			return placement, nil
+		case !errors.Is(err, model.ErrZoneResourceExhausted):
+			return nil, fmt.Errorf("VM %s: failed to start in %s: %w", vm.Name, zone, err)
+		}
+		exhausted = zone
+	}
+
+	// 3. 全ゾーンで容量不足
+	tried := append([]string{vm.Zone}, zones...)
+	return nil, fmt.Errorf("VM %s: %w in %s", vm.Name, model.ErrZoneResourceExhausted, strings.Join(tried, ", "))
+}
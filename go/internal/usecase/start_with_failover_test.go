@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var loggerForStartWithFailover = log.NewLogger()
+
+func TestStartWithFailoverUseCase_Execute(t *testing.T) {
+	now := time.Date(2025, 1, 2, 6, 4, 5, 0, time.UTC)
+	exhausted := fmt.Errorf("%w: ZONE_RESOURCE_POOL_EXHAUSTED", model.ErrZoneResourceExhausted)
+	configured := &model.VM{Name: "gpu", Project: "p", Zone: "zone-a"}
+	stopped := func(zone string) *model.VM {
+		return &model.VM{Name: "gpu", Project: "p", Zone: zone, Status: model.StatusTerminated}
+	}
+	inZone := func(zone string) *model.VM {
+		return &model.VM{Name: "gpu", Project: "p", Zone: zone}
+	}
+
+	//nolint:govet // field alignment is less important than readability in tests
+	tests := []struct {
+		name          string
+		fallbackZones []string
+		setupMock     func(*mock_repository.MockVMRepository, *mock_repository.MockMachineImageRepository)
+		want          *Placement
+		errContains   string
+		errIs         error
+	}{
+		{
+			name:          "success: own zone has capacity",
+			fallbackZones: []string{"zone-b"},
+			setupMock: func(vmRepo *mock_repository.MockVMRepository, _ *mock_repository.MockMachineImageRepository) {
+				vmRepo.EXPECT().FindByName(gomock.Any(), configured).Return(stopped("zone-a"), nil)
+				vmRepo.EXPECT().Start(gomock.Any(), stopped("zone-a")).Return(nil)
+			},
+			want: &Placement{VM: configured},
+		},
+		{
+			name:          "success: recreated from machine image in second fallback zone",
+			fallbackZones: []string{"zone-b", "zone-c"},
+			setupMock: func(vmRepo *mock_repository.MockVMRepository, imageRepo *mock_repository.MockMachineImageRepository) {
+				vmRepo.EXPECT().FindByName(gomock.Any(), configured).Return(stopped("zone-a"), nil)
+				vmRepo.EXPECT().Start(gomock.Any(), stopped("zone-a")).Return(exhausted)
+				vmRepo.EXPECT().FindByName(gomock.Any(), inZone("zone-b")).Return(nil, nil)
+				imageRepo.EXPECT().Create(gomock.Any(), configured, "gpu-20250102-060405").Return(nil)
+				imageRepo.EXPECT().CreateInstance(gomock.Any(), "gpu-20250102-060405", inZone("zone-b")).Return(exhausted)
+				vmRepo.EXPECT().FindByName(gomock.Any(), inZone("zone-c")).Return(nil, nil)
+				imageRepo.EXPECT().CreateInstance(gomock.Any(), "gpu-20250102-060405", inZone("zone-c")).Return(nil)
+			},
+			want: &Placement{VM: inZone("zone-c"), MachineImage: "gpu-20250102-060405"},
+		},
+		{
+			name:          "success: starts instance left by an earlier failover",
+			fallbackZones: []string{"zone-b"},
+			setupMock: func(vmRepo *mock_repository.MockVMRepository, _ *mock_repository.MockMachineImageRepository) {
+				vmRepo.EXPECT().FindByName(gomock.Any(), configured).Return(stopped("zone-a"), nil)
+				vmRepo.EXPECT().Start(gomock.Any(), stopped("zone-a")).Return(exhausted)
+				vmRepo.EXPECT().FindByName(gomock.Any(), inZone("zone-b")).Return(stopped("zone-b"), nil)
+				vmRepo.EXPECT().Start(gomock.Any(), stopped("zone-b")).Return(nil)
+			},
+			want: &Placement{VM: inZone("zone-b")},
+		},
+		{
+			name: "error: exhausted without fallback zones",
+			setupMock: func(vmRepo *mock_repository.MockVMRepository, _ *mock_repository.MockMachineImageRepository) {
+				vmRepo.EXPECT().FindByName(gomock.Any(), configured).Return(stopped("zone-a"), nil)
+				vmRepo.EXPECT().Start(gomock.Any(), stopped("zone-a")).Return(exhausted)
+			},
+			errIs: model.ErrZoneResourceExhausted,
+		},
+		{
+			name:          "error: other start failures do not fail over",
+			fallbackZones: []string{"zone-b"},
+			setupMock: func(vmRepo *mock_repository.MockVMRepository, _ *mock_repository.MockMachineImageRepository) {
+				vmRepo.EXPECT().FindByName(gomock.Any(), configured).Return(stopped("zone-a"), nil)
+				vmRepo.EXPECT().Start(gomock.Any(), stopped("zone-a")).Return(errors.New("quota exceeded"))
+			},
+			errContains: "VM gpu: failed to start: quota exceeded",
+		},
+		{
+			name:          "error: every zone exhausted",
+			fallbackZones: []string{"zone-b"},
+			setupMock: func(vmRepo *mock_repository.MockVMRepository, _ *mock_repository.MockMachineImageRepository) {
+				vmRepo.EXPECT().FindByName(gomock.Any(), configured).Return(stopped("zone-a"), nil)
+				vmRepo.EXPECT().Start(gomock.Any(), stopped("zone-a")).Return(exhausted)
+				vmRepo.EXPECT().FindByName(gomock.Any(), inZone("zone-b")).Return(stopped("zone-b"), nil)
+				vmRepo.EXPECT().Start(gomock.Any(), stopped("zone-b")).Return(exhausted)
+			},
+			errContains: "VM gpu: zone resources exhausted in zone-a, zone-b",
+		},
+		{
+			name:          "error: instance running in fallback zone",
+			fallbackZones: []string{"zone-b"},
+			setupMock: func(vmRepo *mock_repository.MockVMRepository, _ *mock_repository.MockMachineImageRepository) {
+				vmRepo.EXPECT().FindByName(gomock.Any(), configured).Return(stopped("zone-a"), nil)
+				vmRepo.EXPECT().Start(gomock.Any(), stopped("zone-a")).Return(exhausted)
+				vmRepo.EXPECT().FindByName(gomock.Any(), inZone("zone-b")).Return(&model.VM{Name: "gpu", Status: model.StatusRunning}, nil)
+			},
+			errContains: "VM gpu: already exists in zone-b (current status: RUNNING)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			vmRepo := mock_repository.NewMockVMRepository(ctrl)
+			imageRepo := mock_repository.NewMockMachineImageRepository(ctrl)
+			tt.setupMock(vmRepo, imageRepo)
+
+			start := NewStartVMUseCase(vmRepo, loggerForStartWithFailover)
+			uc := NewStartWithFailoverUseCase(start, vmRepo, imageRepo, loggerForStartWithFailover)
+			uc.now = func() time.Time { return now }
+
+			placements, err := uc.Execute(context.Background(), []*model.VM{configured}, map[string][]string{"gpu": tt.fallbackZones})
+			switch {
+			case tt.errIs != nil:
+				require.ErrorIs(t, err, tt.errIs)
+				return
+			case tt.errContains != "":
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []*Placement{tt.want}, placements)
+		})
+	}
+}
+
+func TestStartWithFailoverUseCase_Execute_BudgetExceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	vmRepo := mock_repository.NewMockVMRepository(ctrl)
+	start := NewStartVMUseCase(vmRepo, loggerForStartWithFailover).WithBudgetCheck(func(context.Context, []*model.VM) error {
+		return &model.BudgetExceededError{VMName: "gpu", Cap: 100, Projected: 120}
+	}, false)
+	uc := NewStartWithFailoverUseCase(start, vmRepo, mock_repository.NewMockMachineImageRepository(ctrl), loggerForStartWithFailover)
+
+	_, err := uc.Execute(context.Background(), []*model.VM{{Name: "gpu"}}, nil)
+	require.ErrorIs(t, err, model.ErrBudgetExceeded)
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/haru-256/gcectl/internal/domain/model"
 	"github.com/haru-256/gcectl/internal/domain/repository"
@@ -10,15 +11,84 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
+// GuestRunner runs a command inside a running VM, e.g. over SSH.
+type GuestRunner interface {
+	// Run runs command with the shell of the guest and returns once it exits.
+	Run(ctx context.Context, vm *model.VM, command string) error
+}
+
+// GracefulShutdown makes StopVMUseCase shut each VM down from inside before
+// falling back to the Stop API, so the guest can stop its services cleanly.
+type GracefulShutdown struct {
+	Runner GuestRunner
+	// Command returns the in-guest command shutting down the named VM, e.g.
+	// "sudo shutdown -h now" or a script draining its work first.
+	Command func(vmName string) string
+	// Lock, when set, holds vm against other gcectl processes for the whole
+	// shutdown, which the lock of the Stop API call alone would not cover.
+	Lock func(vm *model.VM) (unlock func(), err error)
+	// Events, when set, receives an OperationStarted and an OperationDone of
+	// type "stop" for each shutdown, so it is recorded in the operation history
+	// like a stop through the API.
+	Events func(ev model.OperationEvent)
+	// Timeout bounds the command and the wait for the VM to be TERMINATED
+	// before it is stopped through the API.
+	Timeout time.Duration
+}
+
+func (g *GracefulShutdown) lock(vm *model.VM) (func(), error) {
+	if g.Lock == nil {
+		return func() {}, nil
+	}
+	return g.Lock(vm)
+}
+
+func (g *GracefulShutdown) emit(ev model.OperationEvent) {
+	if g.Events != nil {
+		g.Events(ev)
+	}
+}
+
 // StopVMUseCase handles the business logic for stopping a VM
 type StopVMUseCase struct {
-	vmRepo repository.VMRepository
-	logger log.Logger
+	vmRepo         repository.VMRepository
+	logger         log.Logger
+	progress       VMProgress
+	results        VMResults
+	graceful       *GracefulShutdown
+	maxConcurrency int
 }
 
 // NewStopVMUseCase creates a new instance of StopVMUseCase
 func NewStopVMUseCase(vmRepo repository.VMRepository, logger log.Logger) *StopVMUseCase {
-	return &StopVMUseCase{vmRepo: vmRepo, logger: logger}
+	return &StopVMUseCase{vmRepo: vmRepo, logger: logger, progress: ignoreVMProgress, results: ignoreVMResults, maxConcurrency: maxConcurrentVMLookups}
+}
+
+// WithProgress makes Execute report the phase of each VM to progress.
+func (uc *StopVMUseCase) WithProgress(progress VMProgress) *StopVMUseCase {
+	uc.progress = progress
+	return uc
+}
+
+// WithResults makes Execute report the result of each VM to results.
+func (uc *StopVMUseCase) WithResults(results VMResults) *StopVMUseCase {
+	uc.results = results
+	return uc
+}
+
+// WithGracefulShutdown makes Execute shut each VM down from inside first.
+// ExecuteNoWait is not affected.
+func (uc *StopVMUseCase) WithGracefulShutdown(graceful *GracefulShutdown) *StopVMUseCase {
+	uc.graceful = graceful
+	return uc
+}
+
+// WithMaxConcurrency sets how many VMs are processed at once. Values <= 0 keep the default.
+func (uc *StopVMUseCase) WithMaxConcurrency(n int) *StopVMUseCase {
+	if n > 0 {
+		uc.maxConcurrency = n
+	}
+	return uc
 }
 
 // Execute stops multiple VM instances in parallel after validating each can be stopped.
@@ -31,34 +101,151 @@ func NewStopVMUseCase(vmRepo repository.VMRepository, logger log.Logger) *StopVM
 //   - error: nil on success, otherwise an error describing what went wrong
 func (uc *StopVMUseCase) Execute(ctx context.Context, vms []*model.VM) error {
 	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(uc.maxConcurrency)
 
 	for _, vm := range vms {
 		vm := vm
 		eg.Go(func() error {
-			// 1. VMを取得して存在確認
+			started := time.Now()
+			err := uc.stopOne(ctx, vm)
+			uc.results(vmResult(ctx, vm.Name, "stop", time.Since(started), err))
+			return reportResult(uc.progress, vm.Name, err)
+		})
+	}
+
+	return eg.Wait()
+}
+
+// stopOne checks that a single VM can be stopped and stops it.
+func (uc *StopVMUseCase) stopOne(ctx context.Context, vm *model.VM) error {
+	// 1. VMを取得して存在確認
+	uc.progress(vm.Name, PhaseChecking, false)
+	foundVM, err := uc.vmRepo.FindByName(ctx, vm)
+	if err != nil {
+		return fmt.Errorf("VM %s: failed to find: %w", vm.Name, err)
+	}
+
+	if foundVM == nil {
+		return fmt.Errorf("VM %s: %w", vm.Name, model.ErrVMNotFound)
+	}
+
+	// 2. ビジネスルールチェック
+	if !foundVM.CanStop() {
+		return model.InvalidStateErrorf("VM %s: cannot be stopped (current status: %s)", foundVM.Name, foundVM.Status)
+	}
+
+	// 3. ゲスト内からシャットダウン
+	if uc.graceful != nil {
+		unlock, lockErr := uc.graceful.lock(foundVM)
+		if lockErr != nil {
+			return fmt.Errorf("VM %s: failed to stop: %w", foundVM.Name, lockErr)
+		}
+		defer unlock()
+		if uc.shutdownGuest(ctx, foundVM) {
+			uc.logger.Infof("✓ Successfully shut down VM %s from the guest", foundVM.Name)
+			return nil
+		}
+	}
+
+	// 4. 停止実行
+	uc.progress(vm.Name, PhaseStopping, false)
+	if stopErr := uc.vmRepo.Stop(ctx, foundVM); stopErr != nil {
+		return fmt.Errorf("VM %s: failed to stop: %w", foundVM.Name, stopErr)
+	}
+
+	uc.logger.Infof("✓ Successfully stopped VM %s", foundVM.Name)
+	return nil
+}
+
+// shutdownGuest runs the in-guest shutdown command of vm and waits for the VM
+// to be TERMINATED. It reports false, so that the caller stops the VM through
+// the API, when the command fails or the VM is not down within the timeout.
+func (uc *StopVMUseCase) shutdownGuest(ctx context.Context, vm *model.VM) bool {
+	uc.progress(vm.Name, PhaseShuttingDown, false)
+	// The timeout bounds the command too, which can hang, e.g. on a sudo
+	// password prompt or a wedged sshd.
+	ctx, cancel := context.WithTimeout(ctx, uc.graceful.Timeout)
+	defer cancel()
+
+	started := time.Now()
+	op := &model.Operation{
+		InsertTime: &started,
+		Name:       fmt.Sprintf("guest-shutdown-%d", started.UnixNano()),
+		Project:    vm.Project,
+		Zone:       vm.Zone,
+		Type:       "stop",
+		Target:     vm.Name,
+		Status:     "RUNNING",
+	}
+	uc.graceful.emit(model.OperationStarted{Op: op})
+	err := uc.runGuestShutdown(ctx, vm)
+	ended := time.Now()
+	done := *op
+	done.EndTime = &ended
+	done.Status = "DONE"
+	uc.graceful.emit(model.OperationDone{Op: &done, Err: err})
+
+	if err != nil {
+		uc.logger.Warnf("VM %s: %v; stopping it through the API", vm.Name, err)
+		return false
+	}
+	return true
+}
+
+// runGuestShutdown runs the shutdown command of vm and waits for the VM to be
+// TERMINATED until ctx is done.
+func (uc *StopVMUseCase) runGuestShutdown(ctx context.Context, vm *model.VM) error {
+	command := uc.graceful.Command(vm.Name)
+	if err := uc.graceful.Runner.Run(ctx, vm, command); err != nil {
+		return fmt.Errorf("%q failed: %w", command, err)
+	}
+	if err := NewWaitVMStatusUseCase(uc.vmRepo, uc.logger).Execute(ctx, []*model.VM{vm}, WaitStopped); err != nil {
+		return fmt.Errorf("not shut down within %s: %w", uc.graceful.Timeout, err)
+	}
+	return nil
+}
+
+// ExecuteNoWait issues stop requests for multiple VMs in parallel without waiting for them to finish.
+// The same validation as Execute is applied before each request is sent.
+//
+// Parameters:
+//   - ctx: The context for the operation
+//   - vms: The VM instances to stop
+//
+// Returns:
+//   - []*model.Operation: The pending operations, in the same order as vms
+//   - error: nil on success, otherwise an error describing what went wrong
+func (uc *StopVMUseCase) ExecuteNoWait(ctx context.Context, vms []*model.VM) ([]*model.Operation, error) {
+	ops := make([]*model.Operation, len(vms))
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(uc.maxConcurrency)
+	for i, vm := range vms {
+		eg.Go(func() error {
 			foundVM, err := uc.vmRepo.FindByName(ctx, vm)
 			if err != nil {
 				return fmt.Errorf("VM %s: failed to find: %w", vm.Name, err)
 			}
-
 			if foundVM == nil {
-				return fmt.Errorf("VM %s: not found", vm.Name)
+				return fmt.Errorf("VM %s: %w", vm.Name, model.ErrVMNotFound)
 			}
 
-			// 2. ビジネスルールチェック
 			if !foundVM.CanStop() {
-				return fmt.Errorf("VM %s: cannot be stopped (current status: %s)", foundVM.Name, foundVM.Status)
+				return model.InvalidStateErrorf("VM %s: cannot be stopped (current status: %s)", foundVM.Name, foundVM.Status)
 			}
 
-			// 3. 停止実行
-			if stopErr := uc.vmRepo.Stop(ctx, foundVM); stopErr != nil {
+			op, stopErr := uc.vmRepo.StopAsync(ctx, foundVM)
+			if stopErr != nil {
 				return fmt.Errorf("VM %s: failed to stop: %w", foundVM.Name, stopErr)
 			}
 
-			uc.logger.Infof("✓ Successfully stopped VM %s", foundVM.Name)
+			uc.logger.Infof("✓ Requested stop of VM %s (operation %s)", foundVM.Name, op.Name)
+			ops[i] = op
 			return nil
 		})
 	}
 
-	return eg.Wait()
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return ops, nil
 }
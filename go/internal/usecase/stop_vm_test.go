@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/haru-256/gcectl/internal/domain/model"
 	"github.com/haru-256/gcectl/internal/infrastructure/log"
@@ -246,3 +247,201 @@ func TestStopVMUseCase_Execute(t *testing.T) {
 		})
 	}
 }
+
+func TestStopVMUseCase_ExecuteNoWait(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+	mockRepo.EXPECT().
+		FindByName(gomock.Any(), gomock.Any()).
+		Return(&model.VM{Project: "test-project", Zone: "us-central1-a", Name: "vm-1", Status: model.StatusRunning}, nil)
+	mockRepo.EXPECT().
+		StopAsync(gomock.Any(), gomock.Any()).
+		Return(&model.Operation{Project: "test-project", Zone: "us-central1-a", Name: "op-1", Status: "RUNNING"}, nil)
+	mockRepo.EXPECT().Stop(gomock.Any(), gomock.Any()).Times(0)
+
+	ops, err := NewStopVMUseCase(mockRepo, loggerForStopVM).ExecuteNoWait(context.Background(), []*model.VM{{Name: "vm-1"}})
+	assert.NoError(t, err)
+	assert.Len(t, ops, 1)
+	assert.Equal(t, "projects/test-project/zones/us-central1-a/operations/op-1", ops[0].Path())
+}
+
+func TestStopVMUseCase_ExecuteNoWaitReturnsRequestError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+	mockRepo.EXPECT().
+		FindByName(gomock.Any(), gomock.Any()).
+		Return(&model.VM{Name: "vm-1", Status: model.StatusRunning}, nil)
+	mockRepo.EXPECT().
+		StopAsync(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("permission denied"))
+
+	ops, err := NewStopVMUseCase(mockRepo, loggerForStopVM).ExecuteNoWait(context.Background(), []*model.VM{{Name: "vm-1"}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "VM vm-1: failed to stop")
+	assert.Nil(t, ops)
+}
+
+func TestStopVMUseCase_ExecuteReportsProgress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+
+	running := &model.VM{Name: "vm1", Project: "p", Zone: "z", Status: model.StatusRunning}
+	mockRepo.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(running, nil)
+	mockRepo.EXPECT().Stop(gomock.Any(), running).Return(nil)
+
+	var phases []string
+	uc := NewStopVMUseCase(mockRepo, loggerForStopVM).WithProgress(func(vmName, phase string, finished bool) {
+		assert.Equal(t, "vm1", vmName)
+		assert.Equal(t, phase == PhaseDone, finished)
+		phases = append(phases, phase)
+	})
+
+	err := uc.Execute(context.Background(), []*model.VM{{Name: "vm1", Project: "p", Zone: "z"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{PhaseChecking, PhaseStopping, PhaseDone}, phases)
+}
+
+func TestStopVMUseCase_ExecuteReportsResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+	mockRepo.EXPECT().FindByName(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, vm *model.VM) (*model.VM, error) {
+		return &model.VM{Name: vm.Name, Project: vm.Project, Zone: vm.Zone, Status: model.StatusTerminated}, nil
+	})
+
+	var results []model.VMResult
+	uc := NewStopVMUseCase(mockRepo, loggerForStopVM).WithResults(func(result model.VMResult) {
+		results = append(results, result)
+	})
+	err := uc.Execute(context.Background(), []*model.VM{{Name: "vm1", Project: "p", Zone: "z"}})
+
+	assert.Error(t, err)
+	if assert.Len(t, results, 1) {
+		assert.Equal(t, "vm1", results[0].VM)
+		assert.Equal(t, "stop", results[0].Action)
+		assert.EqualError(t, results[0].Err, "VM vm1: cannot be stopped (current status: TERMINATED)")
+		assert.False(t, results[0].Succeeded())
+	}
+}
+
+// fakeGuestRunner records the commands run in the guests.
+type fakeGuestRunner struct {
+	err      error
+	commands []string
+	// block makes Run hang until its context is done, like a command waiting
+	// for a sudo password.
+	block bool
+}
+
+func (r *fakeGuestRunner) Run(ctx context.Context, vm *model.VM, command string) error {
+	r.commands = append(r.commands, vm.Name+": "+command)
+	if r.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return r.err
+}
+
+func TestStopVMUseCase_ExecuteGraceful(t *testing.T) {
+	running := &model.VM{Name: "vm1", Project: "p", Zone: "z", Status: model.StatusRunning}
+	terminated := &model.VM{Name: "vm1", Project: "p", Zone: "z", Status: model.StatusTerminated}
+	graceful := func(runner GuestRunner) *GracefulShutdown {
+		return &GracefulShutdown{
+			Runner:  runner,
+			Command: func(vmName string) string { return "sudo shutdown -h now" },
+			Timeout: 10 * time.Millisecond,
+		}
+	}
+
+	t.Run("guest shuts down", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mock_repository.NewMockVMRepository(ctrl)
+		gomock.InOrder(
+			mockRepo.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(running, nil),
+			mockRepo.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(terminated, nil),
+		)
+		runner := &fakeGuestRunner{}
+		shutdown := graceful(runner)
+		var locked, unlocked []string
+		shutdown.Lock = func(vm *model.VM) (func(), error) {
+			locked = append(locked, vm.Name)
+			return func() { unlocked = append(unlocked, vm.Name) }, nil
+		}
+		var events []model.OperationEvent
+		shutdown.Events = func(ev model.OperationEvent) { events = append(events, ev) }
+
+		var phases []string
+		err := NewStopVMUseCase(mockRepo, loggerForStopVM).
+			WithGracefulShutdown(shutdown).
+			WithProgress(func(_, phase string, _ bool) { phases = append(phases, phase) }).
+			Execute(context.Background(), []*model.VM{{Name: "vm1", Project: "p", Zone: "z"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"vm1: sudo shutdown -h now"}, runner.commands)
+		assert.Equal(t, []string{PhaseChecking, PhaseShuttingDown, PhaseDone}, phases)
+		assert.Equal(t, []string{"vm1"}, locked)
+		assert.Equal(t, []string{"vm1"}, unlocked)
+		if assert.Len(t, events, 2) {
+			assert.IsType(t, model.OperationStarted{}, events[0])
+			done, ok := events[1].(model.OperationDone)
+			if assert.True(t, ok) {
+				assert.NoError(t, done.Err)
+				assert.Equal(t, "stop", done.Op.Type)
+				assert.Equal(t, "vm1", done.Op.Target)
+				assert.Equal(t, events[0].Operation().Path(), done.Op.Path())
+				assert.NotNil(t, done.Op.EndTime)
+			}
+		}
+	})
+
+	t.Run("timeout falls back to the Stop API", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mock_repository.NewMockVMRepository(ctrl)
+		mockRepo.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(running, nil).Times(2)
+		mockRepo.EXPECT().Stop(gomock.Any(), running).Return(nil)
+
+		err := NewStopVMUseCase(mockRepo, loggerForStopVM).
+			WithGracefulShutdown(graceful(&fakeGuestRunner{})).
+			Execute(context.Background(), []*model.VM{{Name: "vm1", Project: "p", Zone: "z"}})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("hanging command falls back to the Stop API", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mock_repository.NewMockVMRepository(ctrl)
+		mockRepo.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(running, nil)
+		mockRepo.EXPECT().Stop(gomock.Any(), running).Return(nil)
+		var events []model.OperationEvent
+		shutdown := graceful(&fakeGuestRunner{block: true})
+		shutdown.Events = func(ev model.OperationEvent) { events = append(events, ev) }
+
+		err := NewStopVMUseCase(mockRepo, loggerForStopVM).
+			WithGracefulShutdown(shutdown).
+			Execute(context.Background(), []*model.VM{{Name: "vm1", Project: "p", Zone: "z"}})
+
+		assert.NoError(t, err)
+		if assert.Len(t, events, 2) {
+			assert.ErrorIs(t, events[1].(model.OperationDone).Err, context.DeadlineExceeded)
+		}
+	})
+
+	t.Run("failed command falls back to the Stop API", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mock_repository.NewMockVMRepository(ctrl)
+		mockRepo.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(running, nil)
+		mockRepo.EXPECT().Stop(gomock.Any(), running).Return(nil)
+
+		err := NewStopVMUseCase(mockRepo, loggerForStopVM).
+			WithGracefulShutdown(graceful(&fakeGuestRunner{err: errors.New("connection refused")})).
+			Execute(context.Background(), []*model.VM{{Name: "vm1", Project: "p", Zone: "z"}})
+
+		assert.NoError(t, err)
+	})
+}
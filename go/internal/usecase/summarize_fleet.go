@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+)
+
+// FleetSummary is an at-a-glance view of all configured VMs.
+type FleetSummary struct {
+	// StatusCounts is the number of VMs per status.
+	StatusCounts map[model.Status]int
+	// LongestRunning lists running VMs ordered by uptime, longest first.
+	LongestRunning []VMListItem
+	// UnpricedMachineTypes lists machine types of running VMs missing from the cost table.
+	UnpricedMachineTypes []string
+	// HourlyCost is the estimated cost per hour of all running VMs with a known price.
+	HourlyCost float64
+	// Total is the number of VMs that could be fetched.
+	Total int
+}
+
+// SummarizeFleetUseCase aggregates the status and cost of configured VMs.
+type SummarizeFleetUseCase struct {
+	repo           repository.VMRepository
+	maxConcurrency int
+}
+
+// NewSummarizeFleetUseCase creates a new SummarizeFleetUseCase instance.
+func NewSummarizeFleetUseCase(repo repository.VMRepository) *SummarizeFleetUseCase {
+	return &SummarizeFleetUseCase{repo: repo}
+}
+
+// WithMaxConcurrency sets how many VMs are looked up at once. Values <= 0 keep the default.
+func (u *SummarizeFleetUseCase) WithMaxConcurrency(n int) *SummarizeFleetUseCase {
+	u.maxConcurrency = n
+	return u
+}
+
+// Execute fetches all configured VMs and summarizes them.
+//
+// Only RUNNING VMs contribute to the hourly cost, since stopped VMs are not billed
+// for vCPU and memory.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout control
+//   - configuredVMs: VMs loaded from config
+//   - hourlyCost: Estimated price per hour keyed by machine type
+//   - top: Maximum number of entries in LongestRunning
+//
+// Returns:
+//   - *FleetSummary: The summary of VMs that could be fetched
+//   - error: Joined error for failed VM lookups, as returned by ListVMsUseCase
+func (u *SummarizeFleetUseCase) Execute(ctx context.Context, configuredVMs []*model.VM, hourlyCost map[string]float64, top int) (*FleetSummary, error) {
+	items, err := NewListVMsUseCase(u.repo).WithMaxConcurrency(u.maxConcurrency).Execute(ctx, configuredVMs)
+	return summarize(items, hourlyCost, top, time.Now()), err
+}
+
+// summarize builds a FleetSummary from fetched VMs.
+func summarize(items []VMListItem, hourlyCost map[string]float64, top int, now time.Time) *FleetSummary {
+	summary := &FleetSummary{
+		StatusCounts: make(map[model.Status]int),
+		Total:        len(items),
+	}
+
+	unpriced := make(map[string]bool)
+	running := make([]VMListItem, 0, len(items))
+	for _, item := range items {
+		summary.StatusCounts[item.VM.Status]++
+		if item.VM.Status != model.StatusRunning {
+			continue
+		}
+		running = append(running, item)
+		if price, ok := hourlyCost[item.VM.MachineType]; ok {
+			summary.HourlyCost += price
+		} else {
+			unpriced[item.VM.MachineType] = true
+		}
+	}
+
+	for machineType := range unpriced {
+		summary.UnpricedMachineTypes = append(summary.UnpricedMachineTypes, machineType)
+	}
+	sort.Strings(summary.UnpricedMachineTypes)
+
+	uptime := func(item VMListItem) time.Duration {
+		d, err := item.VM.Uptime(now)
+		if err != nil {
+			return 0
+		}
+		return d
+	}
+	sort.SliceStable(running, func(i, j int) bool {
+		return uptime(running[i]) > uptime(running[j])
+	})
+	if top >= 0 && len(running) > top {
+		running = running[:top]
+	}
+	summary.LongestRunning = running
+
+	return summary
+}
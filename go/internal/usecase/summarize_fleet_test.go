@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSummarize(t *testing.T) {
+	now := time.Date(2025, 10, 11, 12, 0, 0, 0, time.UTC)
+	vm := func(name, machineType string, status model.Status, startedAgo time.Duration) VMListItem {
+		start := now.Add(-startedAgo)
+		return VMListItem{VM: &model.VM{Name: name, MachineType: machineType, Status: status, LastStartTime: &start}}
+	}
+	items := []VMListItem{
+		vm("short", "e2-medium", model.StatusRunning, time.Hour),
+		vm("long", "n1-standard-4", model.StatusRunning, 48*time.Hour),
+		vm("mid", "a2-highgpu-1g", model.StatusRunning, 5*time.Hour),
+		vm("stopped", "n1-standard-4", model.StatusTerminated, 0),
+	}
+	cost := map[string]float64{"e2-medium": 0.03, "n1-standard-4": 0.19}
+
+	summary := summarize(items, cost, 2, now)
+
+	assert.Equal(t, 4, summary.Total)
+	assert.Equal(t, 3, summary.StatusCounts[model.StatusRunning])
+	assert.Equal(t, 1, summary.StatusCounts[model.StatusTerminated])
+	assert.InDelta(t, 0.22, summary.HourlyCost, 1e-9, "only running VMs with known prices are counted")
+	assert.Equal(t, []string{"a2-highgpu-1g"}, summary.UnpricedMachineTypes)
+	require.Len(t, summary.LongestRunning, 2)
+	assert.Equal(t, "long", summary.LongestRunning[0].VM.Name)
+	assert.Equal(t, "mid", summary.LongestRunning[1].VM.Name)
+}
+
+func TestSummarizeFleetUseCase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock_repository.NewMockVMRepository(ctrl)
+	mockRepo.EXPECT().
+		FindAll(gomock.Any(), gomock.Any()).
+		Return([]*model.VM{{Name: "vm1", MachineType: "e2-medium", Status: model.StatusStopped}}, nil)
+
+	summary, err := NewSummarizeFleetUseCase(mockRepo).Execute(context.Background(), []*model.VM{{Name: "vm1"}}, nil, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.StatusCounts[model.StatusStopped])
+	assert.Empty(t, summary.LongestRunning)
+	assert.Zero(t, summary.HourlyCost)
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// UpdateLabelsUseCase handles setting and removing labels of a VM
+type UpdateLabelsUseCase struct {
+	vmRepo repository.VMRepository
+	logger log.Logger
+}
+
+// NewUpdateLabelsUseCase creates a new instance of UpdateLabelsUseCase
+func NewUpdateLabelsUseCase(vmRepo repository.VMRepository, logger log.Logger) *UpdateLabelsUseCase {
+	return &UpdateLabelsUseCase{vmRepo: vmRepo, logger: logger}
+}
+
+// Execute sets the labels in set and removes the labels in remove, keeping all
+// other labels of the VM.
+//
+// Labels are validated against the GCE label rules before anything is changed.
+// Removing a label the VM does not have is an error, so typos in keys are noticed.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - project: The GCP project ID
+//   - zone: The GCP zone
+//   - name: The VM instance name
+//   - set: The labels to add or overwrite
+//   - remove: The keys of the labels to remove
+//
+// Returns:
+//   - map[string]string: The labels of the VM after the update
+//   - error: nil on success, otherwise an error describing what went wrong
+//
+// Example:
+//
+//	usecase := NewUpdateLabelsUseCase(vmRepo, logger)
+//	labels, err := usecase.Execute(ctx, "my-project", "us-central1-a", "my-vm",
+//	    map[string]string{"team": "ml"}, []string{"owner"})
+func (uc *UpdateLabelsUseCase) Execute(ctx context.Context, project, zone, name string, set map[string]string, remove []string) (map[string]string, error) {
+	// 1. 入力チェック
+	if len(set) == 0 && len(remove) == 0 {
+		return nil, errors.New("no label to set or remove")
+	}
+	for key, value := range set {
+		if err := model.ValidateLabel(key, value); err != nil {
+			return nil, err
+		}
+	}
+	for _, key := range remove {
+		if _, ok := set[key]; ok {
+			return nil, fmt.Errorf("label %s cannot be both set and removed", key)
+		}
+	}
+
+	// 2. VMを取得
+	foundVM, err := uc.vmRepo.FindByName(ctx, &model.VM{Project: project, Zone: zone, Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find VM: %w", err)
+	}
+	if foundVM == nil {
+		return nil, fmt.Errorf("VM %s: %w", name, model.ErrVMNotFound)
+	}
+
+	// 3. 更新後のラベルを計算して検証
+	labels := make(map[string]string, len(foundVM.Labels)+len(set))
+	for k, v := range foundVM.Labels {
+		labels[k] = v
+	}
+	for k, v := range set {
+		labels[k] = v
+	}
+	var missing []string
+	for _, key := range remove {
+		if _, ok := labels[key]; !ok {
+			missing = append(missing, key)
+		}
+		delete(labels, key)
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("VM %s has no label %s", foundVM.Name, strings.Join(missing, ", "))
+	}
+	if len(labels) > model.MaxLabels {
+		return nil, fmt.Errorf("VM %s would have %d labels, at most %d are allowed", foundVM.Name, len(labels), model.MaxLabels)
+	}
+
+	// 4. ラベルを更新
+	if err = uc.vmRepo.UpdateLabels(ctx, foundVM, set, remove); err != nil {
+		return nil, fmt.Errorf("failed to update labels: %w", err)
+	}
+
+	uc.logger.Infof("✓ Successfully updated labels for VM %s", foundVM.Name)
+	return labels, nil
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var loggerForUpdateLabels = log.NewLogger()
+
+func TestUpdateLabelsUseCase_Execute(t *testing.T) {
+	vm := &model.VM{Name: "vm-1", Project: "proj", Zone: "z", Labels: map[string]string{"team": "ml", "owner": "alice"}}
+
+	//nolint:govet // Test struct field order optimized for readability
+	tests := []struct {
+		name        string
+		set         map[string]string
+		remove      []string
+		findErr     error
+		updateErr   error
+		wantFind    bool
+		wantUpdate  bool
+		want        map[string]string
+		errContains string
+	}{
+		{
+			name: "success: add and overwrite", set: map[string]string{"team": "infra", "env": "dev"},
+			wantFind: true, wantUpdate: true,
+			want: map[string]string{"team": "infra", "owner": "alice", "env": "dev"},
+		},
+		{
+			name: "success: remove", remove: []string{"owner"},
+			wantFind: true, wantUpdate: true,
+			want: map[string]string{"team": "ml"},
+		},
+		{name: "error: nothing to change", errContains: "no label"},
+		{name: "error: invalid key", set: map[string]string{"Team": "ml"}, errContains: "invalid label key"},
+		{name: "error: invalid value", set: map[string]string{"team": "ML"}, errContains: "invalid value for label team"},
+		{name: "error: set and remove", set: map[string]string{"env": "dev"}, remove: []string{"env"}, errContains: "both set and removed"},
+		{name: "error: VM not found", set: map[string]string{"env": "dev"}, findErr: errors.New("not found"), wantFind: true, errContains: "failed to find VM"},
+		{name: "error: remove missing label", remove: []string{"cost", "owner"}, wantFind: true, errContains: "has no label cost"},
+		{
+			name: "error: repository fails", set: map[string]string{"env": "dev"}, updateErr: errors.New("denied"),
+			wantFind: true, wantUpdate: true, errContains: "failed to update labels",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repository.NewMockVMRepository(ctrl)
+			if tt.wantFind {
+				repo.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(vm, tt.findErr)
+			}
+			if tt.wantUpdate {
+				repo.EXPECT().UpdateLabels(gomock.Any(), vm, tt.set, tt.remove).Return(tt.updateErr)
+			}
+
+			got, err := NewUpdateLabelsUseCase(repo, loggerForUpdateLabels).
+				Execute(context.Background(), "proj", "z", "vm-1", tt.set, tt.remove)
+			if tt.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, map[string]string{"team": "ml", "owner": "alice"}, vm.Labels, "the VM's labels are not modified")
+		})
+	}
+}
+
+func TestUpdateLabelsUseCase_TooManyLabels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	labels := make(map[string]string, model.MaxLabels)
+	for i := 0; i < model.MaxLabels; i++ {
+		labels[fmt.Sprintf("key-%d", i)] = "v"
+	}
+	repo := mock_repository.NewMockVMRepository(ctrl)
+	repo.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(&model.VM{Name: "vm-1", Labels: labels}, nil)
+
+	_, err := NewUpdateLabelsUseCase(repo, loggerForUpdateLabels).
+		Execute(context.Background(), "proj", "z", "vm-1", map[string]string{"one-more": "v"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at most 64")
+}
@@ -61,12 +61,12 @@ func (uc *UpdateMachineTypeUseCase) Execute(ctx context.Context, project, zone,
 		return fmt.Errorf("failed to find VM: %w", err)
 	}
 	if foundVM == nil {
-		return fmt.Errorf("VM %s: not found", name)
+		return fmt.Errorf("VM %s: %w", name, model.ErrVMNotFound)
 	}
 
 	// 2. ビジネスルールチェック（VMは停止状態である必要がある）
 	if !foundVM.CanChangeMachineType() {
-		return fmt.Errorf("VM %s must be stopped before changing machine type (current status: %s)", foundVM.Name, foundVM.Status)
+		return model.InvalidStateErrorf("VM %s must be stopped before changing machine type (current status: %s)", foundVM.Name, foundVM.Status)
 	}
 
 	// 3. マシンタイプ更新実行
@@ -77,3 +77,36 @@ func (uc *UpdateMachineTypeUseCase) Execute(ctx context.Context, project, zone,
 	uc.logger.Infof("✓ Successfully updated machine type to %s for VM %s", machineType, foundVM.Name)
 	return nil
 }
+
+// ExecuteCustom updates the machine type of a VM to a custom machine type.
+//
+// The custom machine type is validated against GCE's constraints for its family
+// before the VM is looked up, so an impossible shape fails without any API call.
+// The update itself is done by Execute and follows the same rules.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - project: The GCP project ID
+//   - zone: The GCP zone
+//   - name: The VM instance name
+//   - custom: The custom machine type shape
+//
+// Returns:
+//   - string: The applied machine type name (e.g., "custom-8-32768")
+//   - error: nil on success, otherwise an error describing what went wrong
+//
+// Example:
+//
+//	usecase := NewUpdateMachineTypeUseCase(vmRepo, logger)
+//	machineType, err := usecase.ExecuteCustom(ctx, "my-project", "us-central1-a", "my-vm",
+//	    model.CustomMachineType{VCPUs: 8, MemoryMB: 32768})
+func (uc *UpdateMachineTypeUseCase) ExecuteCustom(ctx context.Context, project, zone, name string, custom model.CustomMachineType) (string, error) {
+	if err := validateCustomMachineType(custom); err != nil {
+		return "", err
+	}
+	machineType := custom.Name()
+	if err := uc.Execute(ctx, project, zone, name, machineType); err != nil {
+		return "", err
+	}
+	return machineType, nil
+}
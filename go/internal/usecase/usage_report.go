@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// operationHistoryLimit is how many recent operations per VM are scanned for start/stop events.
+const operationHistoryLimit = 500
+
+// VMUsage is the runtime of one VM during a report period.
+type VMUsage struct {
+	VM *model.VM
+	// Cost is nil when the VM's machine type is missing from the cost table.
+	Cost  *float64
+	Hours float64
+}
+
+// UsageReport summarizes how long configured VMs ran during a month.
+//
+//nolint:govet // Field order optimized for readability
+type UsageReport struct {
+	// From and To bound the period; To is now for the current month.
+	From time.Time
+	To   time.Time
+	// VMs are ordered by hours, longest first.
+	VMs []VMUsage
+	// UnpricedMachineTypes lists machine types of VMs that ran but are missing from the cost table.
+	UnpricedMachineTypes []string
+	TotalHours           float64
+	// TotalCost is the estimated cost of all VMs with a known price.
+	TotalCost float64
+}
+
+// UsageReportUseCase computes runtime hours and estimated cost per VM.
+type UsageReportUseCase struct {
+	vmRepo repository.VMRepository
+	opRepo repository.OperationRepository
+	store  repository.RunEventStore
+	logger log.Logger
+	now    func() time.Time
+}
+
+// NewUsageReportUseCase creates a new instance of UsageReportUseCase
+func NewUsageReportUseCase(vmRepo repository.VMRepository, opRepo repository.OperationRepository, store repository.RunEventStore, logger log.Logger) *UsageReportUseCase {
+	return &UsageReportUseCase{vmRepo: vmRepo, opRepo: opRepo, store: store, logger: logger, now: time.Now}
+}
+
+// Execute reports how many hours each configured VM ran during the month starting at month.
+//
+// Successful start/stop (and resume/suspend) operations are first copied from the
+// operations history of each VM into the store, since GCE only keeps operations for a
+// limited time; running the report regularly keeps the history complete. Costs are
+// estimated with the VM's current machine type.
+//
+// Parameters:
+//   - ctx: The context for the operation (used for cancellation and timeout)
+//   - vms: The configured VMs (must contain Project, Zone, and Name)
+//   - month: The first instant of the month to report (e.g., 2025-01-01 00:00 local time)
+//   - hourlyCost: Estimated price per hour keyed by machine type
+//
+// Returns:
+//   - *UsageReport: Runtime hours and cost per VM
+//   - error: nil on success, otherwise an error describing what went wrong
+//
+// Example:
+//
+//	usecase := NewUsageReportUseCase(vmRepo, opRepo, store, logger)
+//	report, err := usecase.Execute(ctx, cnf.VMs, time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local), cnf.HourlyCost)
+func (uc *UsageReportUseCase) Execute(ctx context.Context, vms []*model.VM, month time.Time, hourlyCost map[string]float64) (*UsageReport, error) {
+	// 1. 期間の決定
+	now := uc.now()
+	from := month
+	to := month.AddDate(0, 1, 0)
+	if !now.After(from) {
+		return nil, fmt.Errorf("month %s has not started yet", month.Format("2006-01"))
+	}
+	if now.Before(to) {
+		to = now
+	}
+
+	// 2. 現在の状態を取得
+	found, err := uc.vmRepo.FindAll(ctx, vms)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find VMs: %w", err)
+	}
+
+	// 3. オペレーション履歴から起動・停止イベントを記録
+	var syncErrs []error
+	for _, vm := range vms {
+		if syncErr := uc.sync(ctx, vm); syncErr != nil {
+			syncErrs = append(syncErrs, syncErr)
+		}
+	}
+	if len(syncErrs) > 0 {
+		return nil, errors.Join(syncErrs...)
+	}
+
+	// 4. VMごとの稼働時間とコストを集計
+	report := &UsageReport{From: from, To: to}
+	unpriced := make(map[string]bool)
+	for i, vm := range vms {
+		current := found[i]
+		runningNow := false
+		if current != nil {
+			runningNow = current.Status == model.StatusRunning
+		} else {
+			current = vm
+		}
+
+		hours := model.RunningDuration(uc.store.List(vm), from, to, runningNow).Hours()
+		usage := VMUsage{VM: current, Hours: hours}
+		if price, ok := hourlyCost[current.MachineType]; ok {
+			cost := price * hours
+			usage.Cost = &cost
+			report.TotalCost += cost
+		} else if hours > 0 && !unpriced[current.MachineType] {
+			unpriced[current.MachineType] = true
+			report.UnpricedMachineTypes = append(report.UnpricedMachineTypes, current.MachineType)
+		}
+		report.TotalHours += hours
+		report.VMs = append(report.VMs, usage)
+	}
+
+	sort.SliceStable(report.VMs, func(i, j int) bool {
+		return report.VMs[i].Hours > report.VMs[j].Hours
+	})
+	sort.Strings(report.UnpricedMachineTypes)
+	return report, nil
+}
+
+// sync records the start/stop operations of vm in the store.
+func (uc *UsageReportUseCase) sync(ctx context.Context, vm *model.VM) error {
+	ops, err := uc.opRepo.ListByTarget(ctx, vm, operationHistoryLimit)
+	if err != nil {
+		return fmt.Errorf("failed to list operations of VM %s: %w", vm.Name, err)
+	}
+
+	var events []*model.RunEvent
+	for _, op := range ops {
+		if event, ok := model.RunEventFromOperation(op); ok {
+			events = append(events, event)
+		}
+	}
+	uc.logger.Debugf("Recording %d start/stop events of %s", len(events), vm.Name)
+	if addErr := uc.store.Add(events...); addErr != nil {
+		return fmt.Errorf("failed to record history of VM %s: %w", vm.Name, addErr)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var loggerForUsageReport = log.NewLogger()
+
+func TestUsageReportUseCase_Execute(t *testing.T) {
+	month := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC)
+	at := func(day, hour int) *time.Time {
+		tm := time.Date(2025, 1, day, hour, 0, 0, 0, time.UTC)
+		return &tm
+	}
+	gpuCfg := &model.VM{Name: "gpu", Project: "p", Zone: "z"}
+	devCfg := &model.VM{Name: "dev", Project: "p", Zone: "z"}
+	goneCfg := &model.VM{Name: "gone", Project: "p", Zone: "z"}
+	configured := []*model.VM{devCfg, gpuCfg, goneCfg}
+	hourlyCost := map[string]float64{"a2-highgpu-1g": 3.5}
+
+	t.Run("success: syncs history and sums hours", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		vmRepo := mock_repository.NewMockVMRepository(ctrl)
+		opRepo := mock_repository.NewMockOperationRepository(ctrl)
+		store := mock_repository.NewMockRunEventStore(ctrl)
+
+		gpu := &model.VM{Name: "gpu", Project: "p", Zone: "z", MachineType: "a2-highgpu-1g", Status: model.StatusTerminated}
+		dev := &model.VM{Name: "dev", Project: "p", Zone: "z", MachineType: "e2-medium", Status: model.StatusRunning}
+		vmRepo.EXPECT().FindAll(gomock.Any(), configured).Return([]*model.VM{dev, gpu, nil}, nil)
+
+		gpuOps := []*model.Operation{
+			{Name: "op-2", Type: "stop", Target: "gpu", Project: "p", Zone: "z", Status: "DONE", EndTime: at(2, 17)},
+			{Name: "op-1", Type: "start", Target: "gpu", Project: "p", Zone: "z", Status: "DONE", EndTime: at(2, 9)},
+			{Name: "op-0", Type: "setLabels", Target: "gpu", Project: "p", Zone: "z", Status: "DONE", EndTime: at(2, 8)},
+		}
+		opRepo.EXPECT().ListByTarget(gomock.Any(), devCfg, operationHistoryLimit).Return(nil, nil)
+		opRepo.EXPECT().ListByTarget(gomock.Any(), gpuCfg, operationHistoryLimit).Return(gpuOps, nil)
+		opRepo.EXPECT().ListByTarget(gomock.Any(), goneCfg, operationHistoryLimit).Return(nil, nil)
+
+		var gpuEvents []*model.RunEvent
+		store.EXPECT().Add().Return(nil).Times(2)
+		store.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(func(events ...*model.RunEvent) error {
+			gpuEvents = events
+			return nil
+		})
+		store.EXPECT().List(devCfg).Return(nil)
+		store.EXPECT().List(gpuCfg).DoAndReturn(func(*model.VM) []*model.RunEvent { return gpuEvents })
+		store.EXPECT().List(goneCfg).Return(nil)
+
+		uc := NewUsageReportUseCase(vmRepo, opRepo, store, loggerForUsageReport)
+		uc.now = func() time.Time { return now }
+
+		report, err := uc.Execute(context.Background(), configured, month, hourlyCost)
+		require.NoError(t, err)
+		assert.Equal(t, now, report.To, "the current month ends now")
+		require.Len(t, report.VMs, 3)
+
+		assert.Equal(t, "dev", report.VMs[0].VM.Name)
+		assert.InDelta(t, 240.0, report.VMs[0].Hours, 1e-9, "running without events counts the whole period")
+		assert.Nil(t, report.VMs[0].Cost)
+
+		assert.Equal(t, "gpu", report.VMs[1].VM.Name)
+		assert.InDelta(t, 8.0, report.VMs[1].Hours, 1e-9)
+		require.NotNil(t, report.VMs[1].Cost)
+		assert.InDelta(t, 28.0, *report.VMs[1].Cost, 1e-9)
+
+		assert.Equal(t, "gone", report.VMs[2].VM.Name)
+		assert.Zero(t, report.VMs[2].Hours)
+
+		assert.InDelta(t, 248.0, report.TotalHours, 1e-9)
+		assert.InDelta(t, 28.0, report.TotalCost, 1e-9)
+		assert.Equal(t, []string{"e2-medium"}, report.UnpricedMachineTypes)
+	})
+
+	t.Run("error: month in the future", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uc := NewUsageReportUseCase(mock_repository.NewMockVMRepository(ctrl), mock_repository.NewMockOperationRepository(ctrl), mock_repository.NewMockRunEventStore(ctrl), loggerForUsageReport)
+		uc.now = func() time.Time { return now }
+
+		_, err := uc.Execute(context.Background(), configured, month.AddDate(0, 1, 0), hourlyCost)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "2025-02 has not started yet")
+	})
+
+	t.Run("error: operations history fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		vmRepo := mock_repository.NewMockVMRepository(ctrl)
+		opRepo := mock_repository.NewMockOperationRepository(ctrl)
+		store := mock_repository.NewMockRunEventStore(ctrl)
+		vmRepo.EXPECT().FindAll(gomock.Any(), []*model.VM{gpuCfg}).Return([]*model.VM{nil}, nil)
+		opRepo.EXPECT().ListByTarget(gomock.Any(), gpuCfg, operationHistoryLimit).Return(nil, errors.New("denied"))
+
+		uc := NewUsageReportUseCase(vmRepo, opRepo, store, loggerForUsageReport)
+		uc.now = func() time.Time { return now }
+
+		_, err := uc.Execute(context.Background(), []*model.VM{gpuCfg}, month, hourlyCost)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to list operations of VM gpu")
+	})
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+)
+
+// NextScheduleString describes the next trigger of the VM's schedule policy.
+//
+// The earlier of the policy's next start and next stop is reported relative to now,
+// using the same duration format as uptime. "N/A" is returned when the VM has no
+// schedule policy or the policy has no parsable schedule.
+//
+// Parameters:
+//   - vm: The VM whose schedule policy is evaluated
+//   - now: The current time to compute the next trigger from
+//
+// Returns:
+//   - string: e.g. "stops in 3h12m", "starts in 1d14h0m" or "N/A"
+//
+// Example:
+//
+//	next := NextScheduleString(vm, time.Now())
+//	// Returns: "stops in 3h12m" for a policy with stop-cron "0 19 * * *" at 15:48
+func NextScheduleString(vm *model.VM, now time.Time) string {
+	verb, at, ok := nextScheduleTrigger(vm, now)
+	if !ok {
+		return "N/A"
+	}
+	return verb + " in " + formatUptime(at.Sub(now))
+}
+
+// NextScheduleWithPending behaves like NextScheduleString, but reports the pending
+// stop of the local scheduler (e.g. from `gcectl on --ttl`) when it comes first.
+//
+// Parameters:
+//   - vm: The VM whose schedule is evaluated
+//   - pending: The VM's pending scheduled action, or nil
+//   - now: The current time to compute the next trigger from
+//
+// Returns:
+//   - string: e.g. "stops in 2h59m (scheduled)", "stops in 3h12m" or "N/A"
+func NextScheduleWithPending(vm *model.VM, pending *model.ScheduledAction, now time.Time) string {
+	if vm == nil || pending == nil || pending.Action != model.ScheduledStop ||
+		pending.Due(now) || !vm.CanStop() || pending.Stale(vm) {
+		return NextScheduleString(vm, now)
+	}
+	if _, at, ok := nextScheduleTrigger(vm, now); ok && at.Before(pending.At) {
+		return NextScheduleString(vm, now)
+	}
+	return "stops in " + formatUptime(pending.At.Sub(now)) + " (scheduled)"
+}
+
+// nextScheduleTrigger returns the earlier of the policy's next start and next stop.
+func nextScheduleTrigger(vm *model.VM, now time.Time) (string, time.Time, bool) {
+	if vm == nil || vm.Schedule == nil {
+		return "", time.Time{}, false
+	}
+	nextStart, hasStart := vm.Schedule.NextStart(now)
+	nextStop, hasStop := vm.Schedule.NextStop(now)
+
+	switch {
+	case hasStop && (!hasStart || !nextStart.Before(nextStop)):
+		return "stops", nextStop, true
+	case hasStart:
+		return "starts", nextStart, true
+	default:
+		return "", time.Time{}, false
+	}
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextScheduleString(t *testing.T) {
+	// Wednesday 15:48 in Tokyo.
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	now := time.Date(2024, 1, 10, 15, 48, 0, 0, tokyo)
+
+	//nolint:govet // field alignment is less important than readability in tests
+	tests := []struct {
+		name     string
+		schedule *model.SchedulePolicy
+		want     string
+	}{
+		{name: "no policy", schedule: nil, want: "N/A"},
+		{
+			name:     "stop is next",
+			schedule: &model.SchedulePolicy{StartCron: "0 9 * * 1-5", StopCron: "0 19 * * 1-5", TimeZone: "Asia/Tokyo"},
+			want:     "stops in 3h12m",
+		},
+		{
+			name:     "start is next",
+			schedule: &model.SchedulePolicy{StartCron: "0 16 * * *", StopCron: "0 19 * * *", TimeZone: "Asia/Tokyo"},
+			want:     "starts in 12m0s",
+		},
+		{
+			name:     "evaluated in the policy time zone",
+			schedule: &model.SchedulePolicy{StopCron: "0 19 * * *", TimeZone: "UTC"},
+			want:     "stops in 12h12m",
+		},
+		{name: "stop only", schedule: &model.SchedulePolicy{StopCron: "0 7 * * *", TimeZone: "Asia/Tokyo"}, want: "stops in 15h12m"},
+		{name: "unparsable cron", schedule: &model.SchedulePolicy{StopCron: "bogus", TimeZone: "UTC"}, want: "N/A"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := &model.VM{Name: "vm-1", Schedule: tt.schedule}
+			assert.Equal(t, tt.want, NextScheduleString(vm, now))
+		})
+	}
+}
+
+func TestNextScheduleWithPending(t *testing.T) {
+	now := time.Date(2024, 1, 10, 15, 48, 0, 0, time.UTC)
+	started := now.Add(-time.Hour)
+	stopAt19 := &model.SchedulePolicy{StopCron: "0 19 * * *", TimeZone: "UTC"}
+	pending := &model.ScheduledAction{At: now.Add(2 * time.Hour), CreatedAt: now.Add(-time.Minute), Action: model.ScheduledStop}
+
+	//nolint:govet // field alignment is less important than readability in tests
+	tests := []struct {
+		name    string
+		vm      *model.VM
+		pending *model.ScheduledAction
+		want    string
+	}{
+		{
+			name:    "pending stop without policy",
+			vm:      &model.VM{Status: model.StatusRunning, LastStartTime: &started},
+			pending: pending,
+			want:    "stops in 2h0m (scheduled)",
+		},
+		{
+			name:    "pending stop before policy stop",
+			vm:      &model.VM{Status: model.StatusRunning, LastStartTime: &started, Schedule: stopAt19},
+			pending: pending,
+			want:    "stops in 2h0m (scheduled)",
+		},
+		{
+			name:    "policy stop first",
+			vm:      &model.VM{Status: model.StatusRunning, LastStartTime: &started, Schedule: stopAt19},
+			pending: &model.ScheduledAction{At: now.Add(5 * time.Hour), CreatedAt: now, Action: model.ScheduledStop},
+			want:    "stops in 3h12m",
+		},
+		{
+			name:    "VM not running",
+			vm:      &model.VM{Status: model.StatusTerminated},
+			pending: pending,
+			want:    "N/A",
+		},
+		{
+			name:    "VM restarted since",
+			vm:      &model.VM{Status: model.StatusRunning, LastStartTime: &now},
+			pending: pending,
+			want:    "N/A",
+		},
+		{name: "no pending action", vm: &model.VM{Status: model.StatusRunning}, want: "N/A"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NextScheduleWithPending(tt.vm, tt.pending, now))
+		})
+	}
+}
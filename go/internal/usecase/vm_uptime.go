@@ -45,6 +45,17 @@ func calculateUptimeString(vm *model.VM, now time.Time) string {
 	return formatUptime(uptime)
 }
 
+// calculateStoppedForString returns how long a stopped VM has been stopped,
+// formatted like calculateUptimeString, or "N/A" when the VM is not stopped or
+// its stop time is unknown.
+func calculateStoppedForString(vm *model.VM, now time.Time) string {
+	stopped, err := vm.StoppedFor(now)
+	if err != nil {
+		return "N/A"
+	}
+	return formatUptime(stopped)
+}
+
 // formatUptime formats a duration into a human-readable uptime string.
 //
 // Format rules:
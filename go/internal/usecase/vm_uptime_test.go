@@ -133,6 +133,15 @@ func TestCalculateUptimeString(t *testing.T) {
 	}
 }
 
+func TestCalculateStoppedForString(t *testing.T) {
+	now := time.Date(2025, 10, 11, 19, 0, 0, 0, time.UTC)
+	stopped := now.Add(-(30*24*time.Hour + 2*time.Hour))
+
+	assert.Equal(t, "30d2h0m", calculateStoppedForString(&model.VM{Status: model.StatusTerminated, LastStopTime: &stopped}, now))
+	assert.Equal(t, "N/A", calculateStoppedForString(&model.VM{Status: model.StatusRunning, LastStopTime: &stopped}, now))
+	assert.Equal(t, "N/A", calculateStoppedForString(&model.VM{Status: model.StatusTerminated}, now))
+}
+
 func TestFormatUptime(t *testing.T) {
 	tests := []struct {
 		name     string
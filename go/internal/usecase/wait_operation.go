@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// WaitOperationUseCase waits for a previously issued GCE operation to finish.
+type WaitOperationUseCase struct {
+	opRepo repository.OperationRepository
+	logger log.Logger
+}
+
+// NewWaitOperationUseCase creates a new instance of WaitOperationUseCase
+func NewWaitOperationUseCase(opRepo repository.OperationRepository, logger log.Logger) *WaitOperationUseCase {
+	return &WaitOperationUseCase{opRepo: opRepo, logger: logger}
+}
+
+// Execute blocks until the operation is done and reports its outcome.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout
+//   - op: The operation to wait for (must contain Project, Zone, and Name)
+//
+// Returns:
+//   - *model.Operation: The finished operation
+//   - error: nil if the operation succeeded, otherwise the wait or operation error
+func (uc *WaitOperationUseCase) Execute(ctx context.Context, op *model.Operation) (*model.Operation, error) {
+	done, err := uc.opRepo.Wait(ctx, op)
+	if err != nil {
+		return nil, fmt.Errorf("operation %s: failed to wait: %w", op.Name, err)
+	}
+	if done.Error != "" {
+		return done, fmt.Errorf("operation %s failed: %s", op.Name, done.Error)
+	}
+
+	uc.logger.Infof("✓ Operation %s finished", op.Name)
+	return done, nil
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWaitOperationUseCase_Execute(t *testing.T) {
+	op := &model.Operation{Project: "test-project", Zone: "us-central1-a", Name: "operation-123"}
+
+	tests := []struct {
+		name        string
+		setupMock   func(*mock_repository.MockOperationRepository)
+		errContains string
+		wantErr     bool
+	}{
+		{
+			name: "success: operation finished without error",
+			setupMock: func(m *mock_repository.MockOperationRepository) {
+				m.EXPECT().Wait(gomock.Any(), op).Return(&model.Operation{Name: op.Name, Status: "DONE"}, nil)
+			},
+		},
+		{
+			name: "error: operation finished with error",
+			setupMock: func(m *mock_repository.MockOperationRepository) {
+				m.EXPECT().Wait(gomock.Any(), op).Return(&model.Operation{Name: op.Name, Status: "DONE", Error: "quota exceeded"}, nil)
+			},
+			wantErr:     true,
+			errContains: "operation operation-123 failed: quota exceeded",
+		},
+		{
+			name: "error: wait failed",
+			setupMock: func(m *mock_repository.MockOperationRepository) {
+				m.EXPECT().Wait(gomock.Any(), op).Return(nil, errors.New("not found"))
+			},
+			wantErr:     true,
+			errContains: "failed to wait",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock_repository.NewMockOperationRepository(ctrl)
+			tt.setupMock(mockRepo)
+
+			_, err := NewWaitOperationUseCase(mockRepo, logger).Execute(context.Background(), op)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
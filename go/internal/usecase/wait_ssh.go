@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// DefaultSSHPort is the port probed when no port is configured for a VM.
+	DefaultSSHPort = 22
+
+	defaultSSHPollInterval = 2 * time.Second
+)
+
+// PortProber checks whether a TCP address accepts connections.
+type PortProber interface {
+	// Probe returns nil if a connection to address can be established.
+	Probe(ctx context.Context, address string) error
+}
+
+// WaitSSHUseCase waits until the SSH port of running VMs is reachable.
+type WaitSSHUseCase struct {
+	vmRepo       repository.VMRepository
+	prober       PortProber
+	logger       log.Logger
+	pollInterval time.Duration
+}
+
+// NewWaitSSHUseCase creates a new instance of WaitSSHUseCase
+func NewWaitSSHUseCase(vmRepo repository.VMRepository, prober PortProber, logger log.Logger) *WaitSSHUseCase {
+	return &WaitSSHUseCase{vmRepo: vmRepo, prober: prober, logger: logger, pollInterval: defaultSSHPollInterval}
+}
+
+// Execute polls the SSH port of each VM in parallel until it accepts TCP connections.
+//
+// The VM's current external IP is looked up first, so this should be called after
+// the VM has reached RUNNING.
+//
+// Parameters:
+//   - ctx: Context for cancellation; callers should set a deadline to bound the wait
+//   - vms: VMs to wait for (must contain Project, Zone, and Name)
+//   - ports: SSH port per VM name; VMs without an entry use DefaultSSHPort
+//
+// Returns:
+//   - error: nil once all VMs are reachable, or an error naming the first VM that
+//     has no external IP or did not become reachable before ctx was done
+func (uc *WaitSSHUseCase) Execute(ctx context.Context, vms []*model.VM, ports map[string]int) error {
+	eg, ctx := errgroup.WithContext(ctx)
+	for _, vm := range vms {
+		vm := vm
+		eg.Go(func() error {
+			foundVM, err := uc.vmRepo.FindByName(ctx, vm)
+			if err != nil {
+				return fmt.Errorf("VM %s: failed to find: %w", vm.Name, err)
+			}
+			if foundVM == nil {
+				return fmt.Errorf("VM %s: %w", vm.Name, model.ErrVMNotFound)
+			}
+			ip, err := foundVM.IPAddress(false)
+			if err != nil {
+				return fmt.Errorf("VM %s: %w", vm.Name, err)
+			}
+
+			port := ports[vm.Name]
+			if port == 0 {
+				port = DefaultSSHPort
+			}
+			address := net.JoinHostPort(ip, strconv.Itoa(port))
+
+			if waitErr := uc.waitReachable(ctx, address); waitErr != nil {
+				return fmt.Errorf("VM %s: SSH port %s not reachable: %w", vm.Name, address, waitErr)
+			}
+			uc.logger.Infof("✓ SSH is reachable on VM %s (%s)", vm.Name, address)
+			return nil
+		})
+	}
+	return eg.Wait()
+}
+
+// waitReachable probes address every pollInterval until it succeeds or ctx is done.
+func (uc *WaitSSHUseCase) waitReachable(ctx context.Context, address string) error {
+	ticker := time.NewTicker(uc.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		err := uc.prober.Probe(ctx, address)
+		if err == nil {
+			return nil
+		}
+		uc.logger.Debugf("Probe %s failed: %v", address, err)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (last error: %v)", ctx.Err(), err)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeProber fails the first failures probes, then succeeds.
+type fakeProber struct {
+	addresses []string
+	failures  int
+	mu        sync.Mutex
+}
+
+func (p *fakeProber) Probe(_ context.Context, address string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.addresses = append(p.addresses, address)
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func TestWaitSSHUseCase_Execute(t *testing.T) {
+	vms := []*model.VM{{Project: "p", Zone: "z", Name: "test-vm"}}
+
+	t.Run("success after retries with configured port", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockRepo := mock_repository.NewMockVMRepository(ctrl)
+		mockRepo.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(&model.VM{Name: "test-vm", ExternalIP: "34.1.2.3"}, nil)
+
+		prober := &fakeProber{failures: 2}
+		uc := NewWaitSSHUseCase(mockRepo, prober, logger)
+		uc.pollInterval = time.Millisecond
+
+		err := uc.Execute(context.Background(), vms, map[string]int{"test-vm": 2222})
+		require.NoError(t, err)
+		assert.Len(t, prober.addresses, 3)
+		assert.Equal(t, "34.1.2.3:2222", prober.addresses[0])
+	})
+
+	t.Run("default port", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockRepo := mock_repository.NewMockVMRepository(ctrl)
+		mockRepo.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(&model.VM{Name: "test-vm", ExternalIP: "34.1.2.3"}, nil)
+
+		prober := &fakeProber{}
+		err := NewWaitSSHUseCase(mockRepo, prober, logger).Execute(context.Background(), vms, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"34.1.2.3:22"}, prober.addresses)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockRepo := mock_repository.NewMockVMRepository(ctrl)
+		mockRepo.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(&model.VM{Name: "test-vm", ExternalIP: "34.1.2.3"}, nil)
+
+		uc := NewWaitSSHUseCase(mockRepo, &fakeProber{failures: 1 << 30}, logger)
+		uc.pollInterval = time.Millisecond
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err := uc.Execute(ctx, vms, nil)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "connection refused")
+	})
+
+	t.Run("no external IP", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockRepo := mock_repository.NewMockVMRepository(ctrl)
+		mockRepo.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(&model.VM{Name: "test-vm"}, nil)
+
+		err := NewWaitSSHUseCase(mockRepo, &fakeProber{}, logger).Execute(context.Background(), vms, nil)
+		require.ErrorIs(t, err, model.ErrNoExternalIP)
+	})
+}
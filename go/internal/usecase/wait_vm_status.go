@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	defaultStatusPollInterval = 2 * time.Second
+	maxStatusPollInterval     = 30 * time.Second
+)
+
+// WaitTarget is the state WaitVMStatusUseCase waits for a VM to reach.
+type WaitTarget string
+
+const (
+	// WaitRunning waits until a VM is RUNNING.
+	WaitRunning WaitTarget = "running"
+	// WaitStopped waits until a VM is STOPPED or TERMINATED.
+	WaitStopped WaitTarget = "stopped"
+)
+
+// ParseWaitTarget parses "running" or "stopped".
+func ParseWaitTarget(s string) (WaitTarget, error) {
+	switch t := WaitTarget(strings.ToLower(s)); t {
+	case WaitRunning, WaitStopped:
+		return t, nil
+	}
+	return "", fmt.Errorf("invalid state %q; must be running or stopped", s)
+}
+
+// reached reports whether vm is in the state t.
+func (t WaitTarget) reached(vm *model.VM) bool {
+	if t == WaitRunning {
+		return vm.Status == model.StatusRunning
+	}
+	return vm.Status == model.StatusStopped || vm.Status == model.StatusTerminated
+}
+
+// WaitVMStatusUseCase waits until VMs reach a state, whoever changes them, e.g.
+// a schedule policy or another user.
+type WaitVMStatusUseCase struct {
+	vmRepo   repository.VMRepository
+	logger   log.Logger
+	progress VMProgress
+	// pollInterval is the wait after the first poll; it doubles after each
+	// poll up to maxPollInterval.
+	pollInterval    time.Duration
+	maxPollInterval time.Duration
+}
+
+// NewWaitVMStatusUseCase creates a new instance of WaitVMStatusUseCase
+func NewWaitVMStatusUseCase(vmRepo repository.VMRepository, logger log.Logger) *WaitVMStatusUseCase {
+	return &WaitVMStatusUseCase{
+		vmRepo:          vmRepo,
+		logger:          logger,
+		progress:        ignoreVMProgress,
+		pollInterval:    defaultStatusPollInterval,
+		maxPollInterval: maxStatusPollInterval,
+	}
+}
+
+// WithProgress makes Execute report the status of each VM, in lower case, to progress.
+func (uc *WaitVMStatusUseCase) WithProgress(progress VMProgress) *WaitVMStatusUseCase {
+	uc.progress = progress
+	return uc
+}
+
+// Execute polls each VM in parallel until it reaches target. The polls of a VM
+// back off from every 2 seconds to every 30 seconds, so a long wait, e.g. for a
+// schedule policy, does not query the API needlessly often.
+//
+// Parameters:
+//   - ctx: Context for cancellation; callers should set a deadline to bound the wait
+//   - vms: VMs to wait for (must contain Project, Zone, and Name)
+//   - target: The state to wait for
+//
+// Returns:
+//   - error: nil once every VM reached target, or an error naming the first VM
+//     that could not be found or did not reach target before ctx was done
+func (uc *WaitVMStatusUseCase) Execute(ctx context.Context, vms []*model.VM, target WaitTarget) error {
+	eg, ctx := errgroup.WithContext(ctx)
+	for _, vm := range vms {
+		vm := vm
+		eg.Go(func() error {
+			err := uc.wait(ctx, vm, target)
+			return reportResult(uc.progress, vm.Name, err)
+		})
+	}
+	return eg.Wait()
+}
+
+// wait polls vm until it reaches target or ctx is done.
+func (uc *WaitVMStatusUseCase) wait(ctx context.Context, vm *model.VM, target WaitTarget) error {
+	interval := uc.pollInterval
+	for {
+		current, err := uc.vmRepo.FindByName(ctx, vm)
+		if err != nil {
+			// A lookup cut short by the deadline reports the cause, as the polling does.
+			if ctx.Err() != nil {
+				err = context.Cause(ctx)
+			}
+			return fmt.Errorf("VM %s: failed to find: %w", vm.Name, err)
+		}
+		if current == nil {
+			return fmt.Errorf("VM %s: %w", vm.Name, model.ErrVMNotFound)
+		}
+		if target.reached(current) {
+			uc.logger.Infof("✓ VM %s is %s", vm.Name, current.Status)
+			return nil
+		}
+		uc.logger.Debugf("VM %s is %s, waiting %s", vm.Name, current.Status, interval)
+		uc.progress(vm.Name, strings.ToLower(current.Status.String()), false)
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("VM %s: still %s, not %s: %w", vm.Name, current.Status, target, context.Cause(ctx))
+		case <-timer.C:
+		}
+		interval = min(2*interval, uc.maxPollInterval)
+	}
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestParseWaitTarget(t *testing.T) {
+	got, err := ParseWaitTarget("Stopped")
+	require.NoError(t, err)
+	assert.Equal(t, WaitStopped, got)
+
+	_, err = ParseWaitTarget("suspended")
+	assert.ErrorContains(t, err, "must be running or stopped")
+}
+
+func TestWaitVMStatusUseCase_Execute(t *testing.T) {
+	vms := []*model.VM{{Project: "p", Zone: "z", Name: "test-vm"}}
+
+	t.Run("success: waits until stopped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mock_repository.NewMockVMRepository(ctrl)
+		gomock.InOrder(
+			mockRepo.EXPECT().FindByName(gomock.Any(), vms[0]).Return(&model.VM{Name: "test-vm", Status: model.StatusRunning}, nil),
+			mockRepo.EXPECT().FindByName(gomock.Any(), vms[0]).Return(&model.VM{Name: "test-vm", Status: model.StatusTerminated}, nil),
+		)
+		var phases []string
+		uc := NewWaitVMStatusUseCase(mockRepo, logger).WithProgress(func(_, phase string, _ bool) {
+			phases = append(phases, phase)
+		})
+		uc.pollInterval = time.Millisecond
+
+		err := uc.Execute(context.Background(), vms, WaitStopped)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"running", PhaseDone}, phases)
+	})
+
+	t.Run("success: already running", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mock_repository.NewMockVMRepository(ctrl)
+		mockRepo.EXPECT().FindByName(gomock.Any(), vms[0]).Return(&model.VM{Name: "test-vm", Status: model.StatusRunning}, nil)
+
+		err := NewWaitVMStatusUseCase(mockRepo, logger).Execute(context.Background(), vms, WaitRunning)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("error: timeout names the last status", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mock_repository.NewMockVMRepository(ctrl)
+		mockRepo.EXPECT().FindByName(gomock.Any(), vms[0]).Return(&model.VM{Name: "test-vm", Status: model.StatusProvisioning}, nil).AnyTimes()
+		uc := NewWaitVMStatusUseCase(mockRepo, logger)
+		uc.pollInterval = time.Millisecond
+		uc.maxPollInterval = 2 * time.Millisecond
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err := uc.Execute(ctx, vms, WaitRunning)
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "still PROVISIONING, not running")
+	})
+
+	t.Run("error: lookup fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mock_repository.NewMockVMRepository(ctrl)
+		mockRepo.EXPECT().FindByName(gomock.Any(), vms[0]).Return(nil, errors.New("permission denied"))
+
+		err := NewWaitVMStatusUseCase(mockRepo, logger).Execute(context.Background(), vms, WaitRunning)
+
+		assert.ErrorContains(t, err, "VM test-vm: failed to find: permission denied")
+	})
+
+	t.Run("error: timeout during a lookup reports the cause", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mock_repository.NewMockVMRepository(ctrl)
+		mockRepo.EXPECT().FindByName(gomock.Any(), vms[0]).DoAndReturn(func(ctx context.Context, _ *model.VM) (*model.VM, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+		timeout := fmt.Errorf("%w after 1ms", model.ErrWaitTimeout)
+		ctx, cancel := context.WithTimeoutCause(context.Background(), time.Millisecond, timeout)
+		defer cancel()
+
+		err := NewWaitVMStatusUseCase(mockRepo, logger).Execute(ctx, vms, WaitRunning)
+
+		require.ErrorIs(t, err, model.ErrWaitTimeout)
+		assert.NotErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
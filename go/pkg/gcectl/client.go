@@ -0,0 +1,94 @@
+package gcectl
+
+import (
+	"context"
+	"errors"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/domain/repository"
+	"github.com/haru-256/gcectl/internal/infrastructure/gcp"
+	"github.com/haru-256/gcectl/internal/infrastructure/provider"
+	"github.com/haru-256/gcectl/internal/usecase"
+)
+
+// Client performs gcectl operations on the VMs of a config.
+// It is safe for concurrent use. Close it when done.
+type Client struct {
+	cfg   *Config
+	repo  repository.VMRepository
+	close func() error
+	opts  options
+}
+
+// New creates a Client for the VMs of cfg, connecting to Compute Engine the way
+// cfg configures unless opts override it.
+func New(ctx context.Context, cfg *Config, opts ...Option) (*Client, error) {
+	if cfg == nil {
+		return nil, errors.New("config is nil")
+	}
+	o := newOptions(cfg, opts)
+	repo, err := gcp.NewVMRepository(ctx, o.logger, o.settings)
+	if err != nil {
+		return nil, err
+	}
+	// VMs of other providers fail with an unsupported provider error rather than
+	// being looked up in Compute Engine.
+	routed := provider.NewVMRepository(map[string]repository.VMRepository{model.ProviderGCP: repo})
+	return &Client{cfg: cfg, repo: routed, close: repo.Close, opts: o}, nil
+}
+
+// newClient creates a Client over repo, which is not closed by Close.
+func newClient(cfg *Config, repo repository.VMRepository, opts ...Option) *Client {
+	return &Client{cfg: cfg, repo: repo, close: func() error { return nil }, opts: newOptions(cfg, opts)}
+}
+
+// Close releases the API connections of the Client.
+func (c *Client) Close() error {
+	return c.close()
+}
+
+// Config returns the config the Client was created with.
+func (c *Client) Config() *Config {
+	return c.cfg
+}
+
+// ListVMs looks up every VM of the config. Lookups are best-effort: the VMs
+// found are returned along with an error joining the failed lookups.
+func (c *Client) ListVMs(ctx context.Context) ([]VMListItem, error) {
+	return usecase.NewListVMsUseCase(c.repo).
+		WithMaxConcurrency(c.opts.maxConcurrency).
+		WithUptimeWarning(c.cfg.UptimeWarning).
+		Execute(ctx, c.cfg.VMs)
+}
+
+// DescribeVM looks up the VM named name, which may be qualified as "project/name".
+func (c *Client) DescribeVM(ctx context.Context, name string) (*VM, error) {
+	vm, err := c.cfg.ResolveVM(name)
+	if err != nil {
+		return nil, err
+	}
+	found, _, err := usecase.NewDescribeVMUseCase(c.repo).Execute(ctx, vm.Project, vm.Zone, vm.Name)
+	return found, err
+}
+
+// StartVMs starts the named VMs in parallel and waits until they are running.
+func (c *Client) StartVMs(ctx context.Context, names ...string) error {
+	vms, err := c.cfg.ResolveVMs(names)
+	if err != nil {
+		return err
+	}
+	return usecase.NewStartVMUseCase(c.repo, c.opts.logger).
+		WithMaxConcurrency(c.opts.maxConcurrency).
+		Execute(ctx, vms)
+}
+
+// StopVMs stops the named VMs in parallel and waits until they are stopped.
+func (c *Client) StopVMs(ctx context.Context, names ...string) error {
+	vms, err := c.cfg.ResolveVMs(names)
+	if err != nil {
+		return err
+	}
+	return usecase.NewStopVMUseCase(c.repo, c.opts.logger).
+		WithMaxConcurrency(c.opts.maxConcurrency).
+		Execute(ctx, vms)
+}
@@ -0,0 +1,89 @@
+package gcectl
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/haru-256/gcectl/internal/domain/model"
+	mock_repository "github.com/haru-256/gcectl/internal/mock/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func loadTestConfig(t *testing.T) *Config {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`default-project: proj
+default-zone: us-central1-a
+vm:
+  - name: sandbox
+  - name: trainer
+    project: ml
+`), 0o600))
+	cfg, err := LoadConfig(path)
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestLoadConfig(t *testing.T) {
+	cfg := loadTestConfig(t)
+	require.Len(t, cfg.VMs, 2)
+	assert.Equal(t, "proj", cfg.VMs[0].Project)
+	assert.Equal(t, "ml", cfg.VMs[1].Project)
+
+	_, err := LoadConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
+
+func TestNewRejectsNilConfig(t *testing.T) {
+	_, err := New(context.Background(), nil)
+	require.Error(t, err)
+}
+
+func TestClient_DescribeVM(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mock_repository.NewMockVMRepository(ctrl)
+	repo.EXPECT().FindByName(gomock.Any(), &model.VM{Name: "trainer", Project: "ml", Zone: "us-central1-a"}).
+		Return(&model.VM{Name: "trainer", Project: "ml", Zone: "us-central1-a", Status: model.StatusRunning}, nil)
+
+	client := newClient(loadTestConfig(t), repo)
+	vm, err := client.DescribeVM(context.Background(), "trainer")
+	require.NoError(t, err)
+	assert.Equal(t, StatusRunning, vm.Status)
+
+	_, err = client.DescribeVM(context.Background(), "unknown")
+	require.Error(t, err)
+}
+
+func TestClient_StopVMs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mock_repository.NewMockVMRepository(ctrl)
+	running := &model.VM{Name: "sandbox", Project: "proj", Zone: "us-central1-a", Status: model.StatusRunning}
+	repo.EXPECT().FindByName(gomock.Any(), gomock.Any()).Return(running, nil)
+	repo.EXPECT().Stop(gomock.Any(), running).Return(nil)
+
+	client := newClient(loadTestConfig(t), repo, WithMaxConcurrency(1))
+	require.NoError(t, client.StopVMs(context.Background(), "sandbox"))
+	require.Error(t, client.StopVMs(context.Background(), "unknown"))
+	require.NoError(t, client.Close())
+}
+
+func TestNewOptions(t *testing.T) {
+	cfg := loadTestConfig(t)
+	cfg.CredentialsFile = "/keys/config.json"
+	cfg.MaxConcurrency = 4
+
+	o := newOptions(cfg, nil)
+	assert.Equal(t, "/keys/config.json", o.settings.CredentialsFile)
+	assert.Equal(t, 4, o.maxConcurrency)
+	assert.NotNil(t, o.logger)
+
+	o = newOptions(cfg, []Option{WithCredentialsFile("/keys/sa.json"), WithTimeouts(Timeouts{Call: 1}), WithMaxConcurrency(2), WithLogger(nil)})
+	assert.Equal(t, "/keys/sa.json", o.settings.CredentialsFile)
+	assert.Equal(t, Timeouts{Call: 1}, o.settings.Timeouts)
+	assert.Equal(t, 2, o.maxConcurrency)
+	assert.NotNil(t, o.logger)
+}
@@ -0,0 +1,60 @@
+// Package gcectl lets Go programs embed gcectl instead of running the binary:
+// load its config, look up the configured VMs and start or stop them.
+//
+// The package is the stable surface over gcectl's internal packages. It never
+// logs through a global logger and never exits the program; pass WithLogger to
+// see what it does.
+//
+// Example:
+//
+//	cfg, err := gcectl.LoadConfig("/etc/gcectl/config.yaml")
+//	if err != nil {
+//	    return err
+//	}
+//	client, err := gcectl.New(ctx, cfg, gcectl.WithTimeouts(gcectl.Timeouts{Call: 30 * time.Second}))
+//	if err != nil {
+//	    return err
+//	}
+//	defer client.Close()
+//	if err := client.StartVMs(ctx, "sandbox"); err != nil {
+//	    return err
+//	}
+package gcectl
+
+import (
+	"github.com/haru-256/gcectl/internal/domain/model"
+	"github.com/haru-256/gcectl/internal/infrastructure/config"
+	"github.com/haru-256/gcectl/internal/infrastructure/gcp"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+	"github.com/haru-256/gcectl/internal/usecase"
+)
+
+type (
+	// Config is a loaded gcectl config file.
+	Config = config.Config
+	// VM is a Compute Engine instance as gcectl sees it.
+	VM = model.VM
+	// Status is the lifecycle state of a VM.
+	Status = model.Status
+	// VMListItem is a VM with its uptime and next scheduled stop, as listed by ListVMs.
+	VMListItem = usecase.VMListItem
+	// Timeouts bound the API calls and operation waits of a Client.
+	Timeouts = gcp.Timeouts
+	// Logger receives the log messages of a Client.
+	Logger = log.Logger
+)
+
+// VM statuses.
+const (
+	StatusUnknown      = model.StatusUnknown
+	StatusRunning      = model.StatusRunning
+	StatusStopped      = model.StatusStopped
+	StatusTerminated   = model.StatusTerminated
+	StatusProvisioning = model.StatusProvisioning
+)
+
+// LoadConfig reads the gcectl config file at path, the same file the binary reads
+// with --config.
+func LoadConfig(path string) (*Config, error) {
+	return config.NewConfig(path)
+}
@@ -0,0 +1,68 @@
+package gcectl
+
+import (
+	"github.com/haru-256/gcectl/internal/infrastructure/gcp"
+	"github.com/haru-256/gcectl/internal/infrastructure/log"
+)
+
+// options holds what the Options passed to New configure.
+type options struct {
+	logger         Logger
+	settings       gcp.ClientSettings
+	maxConcurrency int
+}
+
+// Option configures a Client created by New.
+type Option func(*options)
+
+// WithLogger makes the Client log to logger. By default it logs nothing.
+func WithLogger(logger Logger) Option {
+	return func(o *options) {
+		if logger != nil {
+			o.logger = logger
+		}
+	}
+}
+
+// WithCredentialsFile makes the Client authenticate with a service account key
+// file instead of the config's credentials or Application Default Credentials.
+func WithCredentialsFile(path string) Option {
+	return func(o *options) {
+		o.settings.CredentialsFile = path
+	}
+}
+
+// WithTimeouts bounds the API calls and operation waits of the Client.
+// By default they are not limited.
+func WithTimeouts(timeouts Timeouts) Option {
+	return func(o *options) {
+		o.settings.Timeouts = timeouts
+	}
+}
+
+// WithMaxConcurrency sets how many VMs are looked up, started or stopped at once,
+// overriding the config's max-concurrency. Values <= 0 keep it.
+func WithMaxConcurrency(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.maxConcurrency = n
+		}
+	}
+}
+
+// newOptions applies opts over the settings of cfg.
+func newOptions(cfg *Config, opts []Option) options {
+	o := options{
+		logger: log.NewNopLogger(),
+		settings: gcp.ClientSettings{
+			CredentialsFile: cfg.CredentialsFile,
+			ComputeEndpoint: cfg.ComputeEndpoint,
+			ProxyURL:        cfg.Proxy,
+		},
+		maxConcurrency: cfg.MaxConcurrency,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}